@@ -116,6 +116,11 @@ const (
 	// BackupMethodPlugin means that this backup should be handled by
 	// a plugin
 	BackupMethodPlugin BackupMethod = "plugin"
+
+	// BackupMethodPgDump means taking a logical dump of a single database
+	// with pg_dump, uploaded to the object store of the cluster. It cannot
+	// be used to recover a cluster
+	BackupMethodPgDump BackupMethod = "pgDump"
 )
 
 // BackupSpec defines the desired state of Backup
@@ -135,9 +140,9 @@ type BackupSpec struct {
 	Target BackupTarget `json:"target,omitempty"`
 
 	// The backup method to be used, possible options are `barmanObjectStore`,
-	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
+	// `volumeSnapshot`, `plugin` or `pgDump`. Defaults to: `barmanObjectStore`.
 	// +optional
-	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin;pgDump
 	// +kubebuilder:default:=barmanObjectStore
 	Method BackupMethod `json:"method,omitempty"`
 
@@ -145,6 +150,10 @@ type BackupSpec struct {
 	// +optional
 	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
 
+	// The options of the logical dump, required by the `pgDump` method
+	// +optional
+	PgDump *BackupPgDumpConfiguration `json:"pgDump,omitempty"`
+
 	// Whether the default type of backup with volume snapshots is
 	// online/hot (`true`, default) or offline/cold (`false`)
 	// Overrides the default setting specified in the cluster field '.spec.backup.volumeSnapshot.online'
@@ -169,6 +178,22 @@ type BackupPluginConfiguration struct {
 	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
+// BackupPgDumpConfiguration contains the options of a logical dump of a
+// single database, taken with `pg_dump -Fc`
+type BackupPgDumpConfiguration struct {
+	// The name of the database to dump
+	// +kubebuilder:validation:MinLength=1
+	Database string `json:"database"`
+
+	// Whether to dump only the schema of the database, without the data
+	// +optional
+	SchemaOnly bool `json:"schemaOnly,omitempty"`
+
+	// Whether to dump only the data of the database, without the schema
+	// +optional
+	DataOnly bool `json:"dataOnly,omitempty"`
+}
+
 // BackupSnapshotStatus the fields exclusive to the volumeSnapshot method backup
 type BackupSnapshotStatus struct {
 	// The elements list, populated with the gathered volume snapshots
@@ -225,6 +250,10 @@ type BackupStatus struct {
 	// +optional
 	Encryption string `json:"encryption,omitempty"`
 
+	// The number of parallel jobs used to upload the backup
+	// +optional
+	Jobs *int32 `json:"jobs,omitempty"`
+
 	// The ID of the Barman backup
 	// +optional
 	BackupID string `json:"backupId,omitempty"`
@@ -300,6 +329,11 @@ type BackupStatus struct {
 	// A map containing the plugin metadata
 	// +optional
 	PluginMetadata map[string]string `json:"pluginMetadata,omitempty"`
+
+	// The path of the logical dump taken with the `pgDump` method,
+	// relative to the destination path of the object store
+	// +optional
+	DumpObjectPath string `json:"dumpObjectPath,omitempty"`
 }
 
 // InstanceID contains the information to identify an instance
@@ -52,3 +52,44 @@ var (
 		}
 	}
 )
+
+// A Condition that can be used to communicate the progress of a dump import
+var (
+	// DumpDownloadingCondition is added to a cluster when the logical
+	// dump is being downloaded from the object store
+	DumpDownloadingCondition = metav1.Condition{
+		Type:    string(ConditionDumpImport),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ConditionReasonDumpDownloading),
+		Message: "Downloading the logical dump from the object store",
+	}
+
+	// DumpRestoringCondition is added to a cluster when the logical
+	// dump is being restored into the application database
+	DumpRestoringCondition = metav1.Condition{
+		Type:    string(ConditionDumpImport),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(ConditionReasonDumpRestoring),
+		Message: "Restoring the logical dump into the application database",
+	}
+
+	// DumpImportSucceededCondition is added to a cluster when the logical
+	// dump has been restored successfully
+	DumpImportSucceededCondition = metav1.Condition{
+		Type:    string(ConditionDumpImport),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(ConditionReasonDumpImportSucceeded),
+		Message: "The logical dump has been restored",
+	}
+
+	// BuildDumpImportFailedCondition builds
+	// ConditionReasonDumpImportFailed condition
+	BuildDumpImportFailedCondition = func(err error) metav1.Condition {
+		return metav1.Condition{
+			Type:    string(ConditionDumpImport),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(ConditionReasonDumpImportFailed),
+			Message: err.Error(),
+		}
+	}
+)
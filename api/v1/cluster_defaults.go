@@ -59,8 +59,10 @@ func (r *Cluster) SetDefaults() {
 }
 
 func (r *Cluster) setDefaults(preserveUserSettings bool) {
-	// Defaulting the image name if not specified
-	if r.Spec.ImageName == "" && r.Spec.ImageCatalogRef == nil {
+	// Defaulting the image name if not specified, unless the operator is
+	// configured to resolve it at reconciliation time
+	if r.Spec.ImageName == "" && r.Spec.ImageCatalogRef == nil &&
+		!configuration.Current.IsDefaultDisabled(configuration.ImageNameDefault) {
 		r.Spec.ImageName = configuration.Current.PostgresImageName
 	}
 
@@ -136,6 +136,17 @@ var _ = Describe("cluster default configuration", func() {
 		Expect(cluster.Spec.ImageName).To(Equal(configuration.Current.PostgresImageName))
 	})
 
+	It("shouldn't fill the image name if its default is disabled", func() {
+		configuration.Current.DisabledDefaults = []string{configuration.ImageNameDefault}
+		DeferCleanup(func() {
+			configuration.Current.DisabledDefaults = nil
+		})
+
+		cluster := Cluster{}
+		cluster.Default()
+		Expect(cluster.Spec.ImageName).To(BeEmpty())
+	})
+
 	It("shouldn't set the image name if already present", func() {
 		cluster := Cluster{
 			Spec: ClusterSpec{
@@ -35,12 +35,14 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	contextutils "github.com/cloudnative-pg/cloudnative-pg/pkg/utils/context"
@@ -291,6 +293,37 @@ func (r *ReplicationSlotsConfiguration) GetUpdateInterval() time.Duration {
 	return time.Duration(r.UpdateInterval) * time.Second
 }
 
+// GetEnabled returns true if the orphaned logical replication slots
+// need to be dropped
+func (r *LogicalSlotsCleanupConfiguration) GetEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// GetInactiveTimeout returns the time an orphaned logical replication slot
+// needs to be inactive for before being dropped, defaulting to
+// DefaultLogicalSlotsInactiveTimeout if empty
+func (r *LogicalSlotsCleanupConfiguration) GetInactiveTimeout() time.Duration {
+	if r == nil || r.InactiveTimeout == nil {
+		return DefaultLogicalSlotsInactiveTimeout
+	}
+	return r.InactiveTimeout.Duration
+}
+
+// GetWindow returns the amount of time in which the crashes of PostgreSQL
+// are counted, defaulting to DefaultStartupFailureWindow if empty
+func (r *StartupFailurePolicyConfiguration) GetWindow() time.Duration {
+	if r == nil || r.Window == nil {
+		return DefaultStartupFailureWindow
+	}
+	return r.Window.Duration
+}
+
+// IsDeclaredConsumer returns true if the passed logical replication slot
+// belongs to a declared consumer
+func (r *LogicalSlotsCleanupConfiguration) IsDeclaredConsumer(slotName string) bool {
+	return r != nil && slices.Contains(r.Consumers, slotName)
+}
+
 // GetSlotPrefix returns the HA slot prefix, defaulting to DefaultReplicationSlotsHASlotPrefix if empty
 func (r *ReplicationSlotsHAConfiguration) GetSlotPrefix() string {
 	if r == nil || r.SlotPrefix == "" {
@@ -395,6 +428,72 @@ func (m *MonitoringConfiguration) AreDefaultQueriesDisabled() bool {
 	return m != nil && m.DisableDefaultQueries != nil && *m.DisableDefaultQueries
 }
 
+// GetWraparoundWarningThreshold returns the percentage of the freeze max age
+// settings above which the transaction ID age is reported as concerning
+func (m *MonitoringConfiguration) GetWraparoundWarningThreshold() int32 {
+	if m == nil || m.WraparoundWarningThreshold == nil {
+		return DefaultWraparoundWarningThreshold
+	}
+	return *m.WraparoundWarningThreshold
+}
+
+// GetTableBloatEstimateMaxTables returns the number of tables whose bloat
+// is estimated, or zero when the estimate is disabled
+func (m *MonitoringConfiguration) GetTableBloatEstimateMaxTables() int32 {
+	if m == nil || m.TableBloatEstimate == nil || !m.TableBloatEstimate.Enabled {
+		return 0
+	}
+	if m.TableBloatEstimate.MaxTables == nil {
+		return DefaultTableBloatEstimateMaxTables
+	}
+	return *m.TableBloatEstimate.MaxTables
+}
+
+// GetReplicationLag returns the replication lag above which the default
+// alert is raised
+func (t *DefaultAlertsThresholds) GetReplicationLag() time.Duration {
+	if t == nil || t.ReplicationLag == nil {
+		return DefaultAlertReplicationLag
+	}
+	return t.ReplicationLag.Duration
+}
+
+// GetBackupAge returns the age of the last available backup above which
+// the default alert is raised
+func (t *DefaultAlertsThresholds) GetBackupAge() time.Duration {
+	if t == nil || t.BackupAge == nil {
+		return DefaultAlertBackupAge
+	}
+	return t.BackupAge.Duration
+}
+
+// GetReplicationSlotRetainedWAL returns the amount of WAL retained by a
+// replication slot above which the default alert is raised
+func (t *DefaultAlertsThresholds) GetReplicationSlotRetainedWAL() resource.Quantity {
+	if t == nil || t.ReplicationSlotRetainedWAL == nil {
+		return resource.MustParse(DefaultAlertReplicationSlotRetainedWAL)
+	}
+	return *t.ReplicationSlotRetainedWAL
+}
+
+// GetTransactionIDAge returns the age of the oldest unfrozen transaction
+// ID above which the default alert is raised
+func (t *DefaultAlertsThresholds) GetTransactionIDAge() int64 {
+	if t == nil || t.TransactionIDAge == nil {
+		return DefaultAlertTransactionIDAge
+	}
+	return *t.TransactionIDAge
+}
+
+// GetCertificateExpiration returns how long before the expiration of a
+// certificate the default alert is raised
+func (t *DefaultAlertsThresholds) GetCertificateExpiration() time.Duration {
+	if t == nil || t.CertificateExpiration == nil {
+		return DefaultAlertCertificateExpiration
+	}
+	return t.CertificateExpiration.Duration
+}
+
 // GetServerName returns the server name, defaulting to the name of the external cluster or using the one specified
 // in the BarmanObjectStore
 func (in ExternalCluster) GetServerName() string {
@@ -477,7 +576,18 @@ func (cluster *Cluster) GetPostgresqlMajorVersion() (int, error) {
 		return int(imgVersion.Major()), nil //nolint:gosec
 	}
 
-	// Fallback for unit tests where a cluster is created without status or defaults
+	// The image name is not defaulted: the cluster runs the image that
+	// was resolved when it was created
+	if cluster.Status.Image != "" {
+		imgVersion, err := version.FromTag(reference.New(cluster.Status.Image).Tag)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse image name %q: %w", cluster.Status.Image, err)
+		}
+		return int(imgVersion.Major()), nil //nolint:gosec
+	}
+
+	// Fallback for new clusters whose image name is not defaulted, and for
+	// unit tests where a cluster is created without status or defaults
 	imgVersion, err := version.FromTag(reference.New(configuration.Current.PostgresImageName).Tag)
 	if err != nil {
 		return 0, fmt.Errorf("cannot parse default image name %q: %w", configuration.Current.PostgresImageName, err)
@@ -738,12 +848,119 @@ func (cluster *Cluster) GetSmartShutdownTimeout() int32 {
 	return 180
 }
 
+// GetTerminationGracePeriodSeconds gets the time the instance pods have
+// to terminate, which defaults to the time PostgreSQL has to stop
+func (cluster *Cluster) GetTerminationGracePeriodSeconds() int64 {
+	if cluster.Spec.TerminationGracePeriodSeconds != nil {
+		return *cluster.Spec.TerminationGracePeriodSeconds
+	}
+	return int64(cluster.GetMaxStopDelay())
+}
+
 // GetRestartTimeout is used to have a timeout for operations that involve
 // a restart of a PostgreSQL instance
 func (cluster *Cluster) GetRestartTimeout() time.Duration {
 	return time.Duration(cluster.GetMaxStopDelay()+cluster.GetMaxStartDelay()) * time.Second
 }
 
+// GetFailoverCooldown returns the amount of time, after an automated
+// failover, during which no other automated failover is performed
+func (cluster *Cluster) GetFailoverCooldown() time.Duration {
+	if cluster.Spec.Failover == nil || cluster.Spec.Failover.Cooldown == nil {
+		return 0
+	}
+	return cluster.Spec.Failover.Cooldown.Duration
+}
+
+// GetTimeout returns the time the backup hook is allowed to run for
+func (hook *BackupHookConfiguration) GetTimeout() time.Duration {
+	if hook == nil || hook.Timeout == nil {
+		return DefaultBackupHookTimeout
+	}
+	return hook.Timeout.Duration
+}
+
+// IsWALArchivingPaused checks if the user requested the WAL archiving
+// to be paused
+func (cluster *Cluster) IsWALArchivingPaused() bool {
+	return cluster.Spec.WALArchiving != nil && cluster.Spec.WALArchiving.Paused
+}
+
+// GetWALArchiveCommand returns the user-provided command archiving the
+// WAL files instead of barman-cloud, if any
+func (cluster *Cluster) GetWALArchiveCommand() string {
+	if cluster.Spec.WALArchiving == nil {
+		return ""
+	}
+	return cluster.Spec.WALArchiving.ArchiveCommand
+}
+
+// GetWALRestoreCommand returns the user-provided command restoring the
+// WAL files instead of barman-cloud, if any
+func (cluster *Cluster) GetWALRestoreCommand() string {
+	if cluster.Spec.WALArchiving == nil {
+		return ""
+	}
+	return cluster.Spec.WALArchiving.RestoreCommand
+}
+
+// GetTopologyKeys returns the node labels identifying the failure
+// domains the instances are spread across
+func (topology *TopologyConfiguration) GetTopologyKeys() []string {
+	if topology == nil || len(topology.TopologyKeys) == 0 {
+		return []string{DefaultTopologySpreadKey}
+	}
+	return topology.TopologyKeys
+}
+
+// GetMaxSkew returns the maximum skew of the generated topology
+// spread constraints
+func (topology *TopologyConfiguration) GetMaxSkew() int32 {
+	if topology == nil || topology.MaxSkew == nil {
+		return DefaultTopologyMaxSkew
+	}
+	return *topology.MaxSkew
+}
+
+// GetWhenUnsatisfiable returns how the generated topology spread
+// constraints deal with an instance that cannot satisfy them
+func (topology *TopologyConfiguration) GetWhenUnsatisfiable() corev1.UnsatisfiableConstraintAction {
+	if topology == nil || topology.WhenUnsatisfiable == "" {
+		return corev1.DoNotSchedule
+	}
+	return topology.WhenUnsatisfiable
+}
+
+// IsFailoverCandidate checks if the passed instance can be promoted
+// to primary, i.e. it has not been excluded from the failover and it
+// is not a delayed replica
+func (cluster *Cluster) IsFailoverCandidate(instanceName string) bool {
+	if cluster.IsDelayedReplica(instanceName) {
+		return false
+	}
+	if cluster.Spec.Failover == nil {
+		return true
+	}
+	return !slices.Contains(cluster.Spec.Failover.ExcludedInstances, instanceName)
+}
+
+// IsDelayedReplica checks if the passed instance applies the changes
+// received from the primary with a delay
+func (cluster *Cluster) IsDelayedReplica(instanceName string) bool {
+	return cluster.Spec.DelayedReplicas != nil &&
+		slices.Contains(cluster.Spec.DelayedReplicas.Instances, instanceName)
+}
+
+// GetMinApplyDelay returns the delay the passed instance applies to the
+// changes received from the primary, which is zero unless the instance
+// is a delayed replica
+func (cluster *Cluster) GetMinApplyDelay(instanceName string) time.Duration {
+	if !cluster.IsDelayedReplica(instanceName) {
+		return 0
+	}
+	return cluster.Spec.DelayedReplicas.MinApplyDelay.Duration
+}
+
 // GetMaxSwitchoverDelay get the amount of time PostgreSQL has to stop before switchover
 func (cluster *Cluster) GetMaxSwitchoverDelay() int32 {
 	if cluster.Spec.MaxSwitchoverDelay > 0 {
@@ -752,6 +969,23 @@ func (cluster *Cluster) GetMaxSwitchoverDelay() int32 {
 	return DefaultMaxSwitchoverDelay
 }
 
+// ShouldWaitForShutdownLSN checks whether the target primary needs to
+// replay the shutdown checkpoint of the former primary before being promoted
+func (cluster *Cluster) ShouldWaitForShutdownLSN() bool {
+	return cluster.Spec.Switchover != nil &&
+		cluster.Spec.Switchover.WaitForShutdownLSN != nil &&
+		*cluster.Spec.Switchover.WaitForShutdownLSN
+}
+
+// GetShutdownLSNTimeout gets the amount of time the target primary waits
+// for the shutdown checkpoint of the former primary to be replayed
+func (cluster *Cluster) GetShutdownLSNTimeout() time.Duration {
+	if cluster.Spec.Switchover != nil && cluster.Spec.Switchover.ShutdownLSNTimeout != nil {
+		return time.Duration(*cluster.Spec.Switchover.ShutdownLSNTimeout) * time.Second
+	}
+	return DefaultShutdownLSNTimeout * time.Second
+}
+
 // GetPrimaryUpdateStrategy get the cluster primary update strategy,
 // defaulting to unsupervised
 func (cluster *Cluster) GetPrimaryUpdateStrategy() PrimaryUpdateStrategy {
@@ -774,6 +1008,89 @@ func (cluster *Cluster) GetPrimaryUpdateMethod() PrimaryUpdateMethod {
 	return strategy
 }
 
+// GetPrimaryUpdateMethodForImageChange get the primary update method
+// to be used when the PostgreSQL image is changed, defaulting to
+// the cluster primary update method
+func (cluster *Cluster) GetPrimaryUpdateMethodForImageChange() PrimaryUpdateMethod {
+	if overrides := cluster.Spec.PrimaryUpdateMethodOverrides; overrides != nil && overrides.Image != "" {
+		return overrides.Image
+	}
+
+	return cluster.GetPrimaryUpdateMethod()
+}
+
+// GetPrimaryUpdateMethodForConfigurationChange get the primary update method
+// to be used when PostgreSQL needs to be restarted to apply a configuration
+// change, defaulting to the cluster primary update method
+func (cluster *Cluster) GetPrimaryUpdateMethodForConfigurationChange() PrimaryUpdateMethod {
+	if overrides := cluster.Spec.PrimaryUpdateMethodOverrides; overrides != nil && overrides.Configuration != "" {
+		return overrides.Configuration
+	}
+
+	return cluster.GetPrimaryUpdateMethod()
+}
+
+// GetMaxConcurrentReplicaUpdates get the maximum number of replicas
+// to be updated at the same time, defaulting to one
+func (cluster *Cluster) GetMaxConcurrentReplicaUpdates() int {
+	if cluster.Spec.MaxConcurrentReplicaUpdates < 1 {
+		return 1
+	}
+
+	return cluster.Spec.MaxConcurrentReplicaUpdates
+}
+
+// GetCollationVersionMismatchPolicy get the action to take when an index
+// depends on a collation whose version has changed, defaulting to warn
+func (cluster *Cluster) GetCollationVersionMismatchPolicy() CollationVersionMismatchPolicy {
+	if cluster.Spec.PostgresConfiguration.CollationVersionMismatch == "" {
+		return CollationVersionMismatchWarn
+	}
+
+	return cluster.Spec.PostgresConfiguration.CollationVersionMismatch
+}
+
+// GetPort get the port of the external endpoint of the cluster,
+// defaulting to the PostgreSQL one
+func (cfg *ExternalConnectionConfiguration) GetPort() int32 {
+	if cfg == nil || cfg.Port == nil {
+		return postgres.ServerPort
+	}
+
+	return *cfg.Port
+}
+
+// GetShutdownMode get the mode used to shut down PostgreSQL during the
+// planned stops, defaulting to smart
+func (cluster *Cluster) GetShutdownMode() ShutdownMode {
+	shutdown := cluster.Spec.PostgresConfiguration.Shutdown
+	if shutdown == nil || shutdown.Mode == "" {
+		return ShutdownModeSmart
+	}
+
+	return shutdown.Mode
+}
+
+// GetDrainOrder get the order in which the instances are moved away
+// from the nodes being drained, defaulting to primary-first
+func (cluster *Cluster) GetDrainOrder() DrainOrder {
+	if cluster.Spec.DrainOrder == "" {
+		return DrainOrderPrimaryFirst
+	}
+
+	return cluster.Spec.DrainOrder
+}
+
+// GetLostStoragePolicy returns the action taken when the storage of
+// an instance is lost, defaulting to `wait`
+func (cluster *Cluster) GetLostStoragePolicy() LostStoragePolicy {
+	if cluster.Spec.LostStoragePolicy == "" {
+		return LostStoragePolicyWait
+	}
+
+	return cluster.Spec.LostStoragePolicy
+}
+
 // GetEnablePDB get the cluster EnablePDB value, defaults to true
 func (cluster *Cluster) GetEnablePDB() bool {
 	if cluster.Spec.EnablePDB == nil {
@@ -783,6 +1100,34 @@ func (cluster *Cluster) GetEnablePDB() bool {
 	return *cluster.Spec.EnablePDB
 }
 
+// GetImageCatalogAutoUpdate returns the maintenance windows in which
+// the cluster adopts the new images published in the catalog, or nil
+// when they are adopted immediately
+func (cluster *Cluster) GetImageCatalogAutoUpdate() *ImageCatalogAutoUpdate {
+	if cluster.Spec.ImageCatalogRef == nil {
+		return nil
+	}
+	return cluster.Spec.ImageCatalogRef.AutoUpdate
+}
+
+// GetDuration returns the duration of each maintenance window, one
+// hour by default
+func (in *ImageCatalogAutoUpdate) GetDuration() time.Duration {
+	if in.Duration == nil || in.Duration.Duration <= 0 {
+		return time.Hour
+	}
+	return in.Duration.Duration
+}
+
+// GetDuration returns the duration of each maintenance window, one
+// hour by default
+func (in *CollationReindexWindow) GetDuration() time.Duration {
+	if in.Duration == nil || in.Duration.Duration <= 0 {
+		return time.Hour
+	}
+	return in.Duration.Duration
+}
+
 // IsNodeMaintenanceWindowInProgress check if the upgrade mode is active or not
 func (cluster *Cluster) IsNodeMaintenanceWindowInProgress() bool {
 	return cluster.Spec.NodeMaintenanceWindow != nil && cluster.Spec.NodeMaintenanceWindow.InProgress
@@ -798,6 +1143,40 @@ func (cluster *Cluster) GetPgCtlTimeoutForPromotion() int32 {
 	return timeout
 }
 
+// IsAutomaticAnalyzeEnabled checks if the databases should be analyzed
+// after a major version upgrade or a recovery
+func (cluster *Cluster) IsAutomaticAnalyzeEnabled() bool {
+	automaticAnalyze := cluster.Spec.PostgresConfiguration.AutomaticAnalyze
+	if automaticAnalyze == nil || automaticAnalyze.Enabled == nil {
+		return true
+	}
+	return *automaticAnalyze.Enabled
+}
+
+// IsAutomaticAnalyzeInStages checks if the automatic analyze should
+// be run with `vacuumdb --analyze-in-stages`
+func (cluster *Cluster) IsAutomaticAnalyzeInStages() bool {
+	automaticAnalyze := cluster.Spec.PostgresConfiguration.AutomaticAnalyze
+	return automaticAnalyze != nil && automaticAnalyze.InStages
+}
+
+// IsMajorUpgradeOnHold checks if the major version upgrade should be held
+// because a dry-run was requested and the result of `pg_upgrade --check`
+// for the current generation of the cluster has already been reported
+func (cluster *Cluster) IsMajorUpgradeOnHold() bool {
+	if !utils.IsMajorUpgradeDryRun(&cluster.ObjectMeta) {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(ConditionMajorUpgrade))
+	if condition == nil || condition.ObservedGeneration != cluster.Generation {
+		return false
+	}
+
+	return condition.Reason == string(ConditionReasonMajorUpgradeCheckSucceeded) ||
+		condition.Reason == string(ConditionReasonMajorUpgradeCheckFailed)
+}
+
 // IsReusePVCEnabled check if in a maintenance window we should reuse PVCs
 func (cluster *Cluster) IsReusePVCEnabled() bool {
 	reusePVC := true
@@ -930,6 +1309,28 @@ func (cluster *Cluster) ShouldInitDBCreateApplicationDatabase() bool {
 	return initDBParameters.Owner != "" && initDBParameters.Database != ""
 }
 
+// IsImportDumpBootstrap returns true if the cluster is bootstrapped by
+// restoring a logical dump stored in an object store
+func (cluster *Cluster) IsImportDumpBootstrap() bool {
+	return cluster.Spec.Bootstrap != nil &&
+		cluster.Spec.Bootstrap.InitDB != nil &&
+		cluster.Spec.Bootstrap.InitDB.ImportDump != nil
+}
+
+// GetFormat returns the format of the logical dump, inferring it from
+// the extension of the file when not explicitly set
+func (importDump *ImportDump) GetFormat() ImportDumpFormat {
+	if importDump.Format != "" {
+		return importDump.Format
+	}
+
+	if strings.HasSuffix(importDump.Path, ".sql") {
+		return ImportDumpFormatPlain
+	}
+
+	return ImportDumpFormatCustom
+}
+
 // ShouldPgBaseBackupCreateApplicationDatabase returns true if the application database needs to be created during the
 // pg_basebackup job
 func (cluster *Cluster) ShouldPgBaseBackupCreateApplicationDatabase() bool {
@@ -1154,6 +1555,10 @@ func (cluster *Cluster) UsesSecret(secret string) bool {
 		return true
 	}
 
+	if cluster.GetAuthenticationRulesSecrets().Has(secret) {
+		return true
+	}
+
 	// watch the secrets defined in external clusters
 	return cluster.GetExternalClusterSecrets().Has(secret)
 }
@@ -1163,7 +1568,48 @@ func (cluster *Cluster) UsesConfigMap(config string) (ok bool) {
 	if _, ok := cluster.Status.ConfigMapResourceVersion.Metrics[config]; ok {
 		return true
 	}
-	return false
+	return cluster.GetAuthenticationRulesConfigMaps().Has(config)
+}
+
+// GetAuthenticationRulesSecrets returns the names of the Secrets containing
+// the pg_hba.conf and pg_ident.conf rules
+func (cluster *Cluster) GetAuthenticationRulesSecrets() *stringset.Data {
+	secrets := stringset.New()
+
+	postgresConfig := &cluster.Spec.PostgresConfiguration
+	for _, refs := range []*AuthenticationRulesRefs{postgresConfig.PgHBARefs, postgresConfig.PgIdentRefs} {
+		if refs == nil {
+			continue
+		}
+		for _, ref := range refs.SecretRefs {
+			secrets.Put(ref.Name)
+		}
+	}
+	return secrets
+}
+
+// GetAuthenticationRulesConfigMaps returns the names of the ConfigMaps
+// containing the pg_hba.conf and pg_ident.conf rules
+func (cluster *Cluster) GetAuthenticationRulesConfigMaps() *stringset.Data {
+	configMaps := stringset.New()
+
+	postgresConfig := &cluster.Spec.PostgresConfiguration
+	for _, refs := range []*AuthenticationRulesRefs{postgresConfig.PgHBARefs, postgresConfig.PgIdentRefs} {
+		if refs == nil {
+			continue
+		}
+		for _, ref := range refs.ConfigMapRefs {
+			configMaps.Put(ref.Name)
+		}
+	}
+	return configMaps
+}
+
+// IsNetworkPolicyEnabled checks if the operator should generate the
+// NetworkPolicy restricting the traffic of the instance pods
+func (cluster *Cluster) IsNetworkPolicyEnabled() bool {
+	return cluster.Spec.Managed != nil && cluster.Spec.Managed.NetworkPolicy != nil &&
+		cluster.Spec.Managed.NetworkPolicy.Enabled
 }
 
 // IsPodMonitorEnabled checks if the PodMonitor object needs to be created
@@ -1175,6 +1621,16 @@ func (cluster *Cluster) IsPodMonitorEnabled() bool {
 	return false
 }
 
+// IsDefaultAlertsEnabled checks if the PrometheusRule containing the
+// default alerts needs to be created
+func (cluster *Cluster) IsDefaultAlertsEnabled() bool {
+	if cluster.Spec.Monitoring != nil {
+		return cluster.Spec.Monitoring.EnableDefaultAlerts
+	}
+
+	return false
+}
+
 // IsMetricsTLSEnabled checks if the metrics endpoint should use TLS
 func (cluster *Cluster) IsMetricsTLSEnabled() bool {
 	if cluster.Spec.Monitoring != nil && cluster.Spec.Monitoring.TLSConfig != nil {
@@ -1309,6 +1765,39 @@ func (cluster *Cluster) GetTablespaceConfiguration(name string) *TablespaceConfi
 	return nil
 }
 
+// IsEphemeral returns true if the tablespace is stored on an ephemeral
+// volume rather than on a persistent volume claim
+func (t *TablespaceConfiguration) IsEphemeral() bool {
+	return t.Ephemeral != nil
+}
+
+// GetPageSize returns the size of the huge pages requested by
+// the instance pods, defaulting to 2Mi
+func (config *HugePagesConfiguration) GetPageSize() resource.Quantity {
+	if config.PageSize == "" {
+		return resource.MustParse("2Mi")
+	}
+
+	return resource.MustParse(config.PageSize)
+}
+
+// GetResourceName returns the name of the resource providing the
+// huge pages, i.e. `hugepages-2Mi`
+func (config *HugePagesConfiguration) GetResourceName() corev1.ResourceName {
+	pageSize := config.GetPageSize()
+	return corev1.ResourceName(corev1.ResourceHugePagesPrefix + pageSize.String())
+}
+
+// GetMode returns the usage of the huge pages requested by
+// PostgreSQL, defaulting to `try`
+func (config *HugePagesConfiguration) GetMode() HugePagesMode {
+	if config.Mode == "" {
+		return HugePagesModeTry
+	}
+
+	return config.Mode
+}
+
 // GetServerCASecretObjectKey returns a types.NamespacedName pointing to the secret
 func (cluster *Cluster) GetServerCASecretObjectKey() types.NamespacedName {
 	return types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.GetServerCASecretName()}
@@ -1419,6 +1908,23 @@ func (cluster *Cluster) IsReadOnlyServiceEnabled() bool {
 	return !slices.Contains(cluster.Spec.Managed.Services.DisabledDefaultServices, ServiceSelectorTypeRO)
 }
 
+// GetDefaultServiceTemplate returns the customization of the default
+// service having the passed selector type, or nil if there is none
+func (cluster *Cluster) GetDefaultServiceTemplate(selectorType ServiceSelectorType) *DefaultServiceTemplate {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.Services == nil {
+		return nil
+	}
+
+	templates := cluster.Spec.Managed.Services.DefaultServices
+	for idx := range templates {
+		if templates[idx].SelectorType == selectorType {
+			return &templates[idx]
+		}
+	}
+
+	return nil
+}
+
 // GetRecoverySourcePlugin returns the configuration of the plugin being
 // the recovery source of the cluster. If no such plugin have been configured,
 // nil is returned
@@ -1539,6 +2045,15 @@ func (p *ProbeWithStrategy) ApplyInto(k8sProbe *corev1.Probe) {
 	p.Probe.ApplyInto(k8sProbe)
 }
 
+// GetExpectedResult gets the value the readiness query must return,
+// defaulting to `true`
+func (in *ProbeQuery) GetExpectedResult() string {
+	if in.ExpectedResult == "" {
+		return "true"
+	}
+	return in.ExpectedResult
+}
+
 // GetEnabledWALArchivePluginName returns the name of the enabled backup plugin or an empty string
 // if no backup plugin is enabled
 func (cluster *Cluster) GetEnabledWALArchivePluginName() string {
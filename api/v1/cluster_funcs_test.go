@@ -111,6 +111,303 @@ var _ = Describe("Primary update strategy", func() {
 	})
 })
 
+var _ = Describe("Primary update method", func() {
+	It("defaults to restart for every kind of change", func() {
+		emptyCluster := Cluster{}
+		Expect(emptyCluster.GetPrimaryUpdateMethod()).To(Equal(PrimaryUpdateMethodRestart))
+		Expect(emptyCluster.GetPrimaryUpdateMethodForImageChange()).To(Equal(PrimaryUpdateMethodRestart))
+		Expect(emptyCluster.GetPrimaryUpdateMethodForConfigurationChange()).To(Equal(PrimaryUpdateMethodRestart))
+	})
+
+	It("uses the cluster-wide method when there are no overrides", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PrimaryUpdateMethod:          PrimaryUpdateMethodSwitchover,
+				PrimaryUpdateMethodOverrides: &PrimaryUpdateMethodOverrides{},
+			},
+		}
+		Expect(cluster.GetPrimaryUpdateMethodForImageChange()).To(Equal(PrimaryUpdateMethodSwitchover))
+		Expect(cluster.GetPrimaryUpdateMethodForConfigurationChange()).To(Equal(PrimaryUpdateMethodSwitchover))
+	})
+
+	It("respects the overrides for each kind of change", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PrimaryUpdateMethodOverrides: &PrimaryUpdateMethodOverrides{
+					Image:         PrimaryUpdateMethodSwitchover,
+					Configuration: PrimaryUpdateMethodRestart,
+				},
+			},
+		}
+		Expect(cluster.GetPrimaryUpdateMethodForImageChange()).To(Equal(PrimaryUpdateMethodSwitchover))
+		Expect(cluster.GetPrimaryUpdateMethodForConfigurationChange()).To(Equal(PrimaryUpdateMethodRestart))
+	})
+})
+
+var _ = Describe("Switchover shutdown LSN", func() {
+	It("is disabled by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.ShouldWaitForShutdownLSN()).To(BeFalse())
+		Expect(cluster.GetShutdownLSNTimeout()).To(Equal(DefaultShutdownLSNTimeout * time.Second))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Switchover: &SwitchoverConfiguration{
+					WaitForShutdownLSN: ptr.To(true),
+					ShutdownLSNTimeout: ptr.To(int32(30)),
+				},
+			},
+		}
+		Expect(cluster.ShouldWaitForShutdownLSN()).To(BeTrue())
+		Expect(cluster.GetShutdownLSNTimeout()).To(Equal(30 * time.Second))
+	})
+})
+
+var _ = Describe("Failover cooldown", func() {
+	It("is disabled by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetFailoverCooldown()).To(BeZero())
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Failover: &FailoverConfiguration{
+					Cooldown: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		}
+		Expect(cluster.GetFailoverCooldown()).To(Equal(10 * time.Minute))
+	})
+})
+
+var _ = Describe("Failover candidates", func() {
+	It("considers every instance eligible by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsFailoverCandidate("cluster-example-1")).To(BeTrue())
+	})
+
+	It("skips the excluded instances", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Failover: &FailoverConfiguration{
+					ExcludedInstances: []string{"cluster-example-3"},
+				},
+			},
+		}
+		Expect(cluster.IsFailoverCandidate("cluster-example-2")).To(BeTrue())
+		Expect(cluster.IsFailoverCandidate("cluster-example-3")).To(BeFalse())
+	})
+
+	It("skips the delayed replicas", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				DelayedReplicas: &DelayedReplicasConfiguration{
+					Instances:     []string{"cluster-example-3"},
+					MinApplyDelay: metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+		Expect(cluster.IsFailoverCandidate("cluster-example-2")).To(BeTrue())
+		Expect(cluster.IsFailoverCandidate("cluster-example-3")).To(BeFalse())
+		Expect(cluster.GetMinApplyDelay("cluster-example-2")).To(BeZero())
+		Expect(cluster.GetMinApplyDelay("cluster-example-3")).To(Equal(time.Hour))
+	})
+})
+
+var _ = Describe("Termination grace period", func() {
+	It("defaults to the stop delay", func() {
+		cluster := Cluster{Spec: ClusterSpec{MaxStopDelay: 600}}
+		Expect(cluster.GetTerminationGracePeriodSeconds()).To(BeEquivalentTo(600))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				MaxStopDelay:                  600,
+				TerminationGracePeriodSeconds: ptr.To(int64(660)),
+			},
+		}
+		Expect(cluster.GetTerminationGracePeriodSeconds()).To(BeEquivalentTo(660))
+	})
+})
+
+var _ = Describe("Automatic analyze", func() {
+	It("is enabled and runs a single stage by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsAutomaticAnalyzeEnabled()).To(BeTrue())
+		Expect(cluster.IsAutomaticAnalyzeInStages()).To(BeFalse())
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					AutomaticAnalyze: &AutomaticAnalyzeConfiguration{
+						Enabled:  ptr.To(false),
+						InStages: true,
+					},
+				},
+			},
+		}
+		Expect(cluster.IsAutomaticAnalyzeEnabled()).To(BeFalse())
+		Expect(cluster.IsAutomaticAnalyzeInStages()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Major upgrade dry-run", func() {
+	checkedCluster := func(reason ConditionReason, generation int64) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Generation: 2,
+				Annotations: map[string]string{
+					utils.MajorUpgradeDryRunAnnotationName: "enabled",
+				},
+			},
+			Status: ClusterStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ConditionMajorUpgrade),
+						Status:             metav1.ConditionFalse,
+						Reason:             string(reason),
+						ObservedGeneration: generation,
+					},
+				},
+			},
+		}
+	}
+
+	It("doesn't hold the upgrade without the annotation", func() {
+		cluster := checkedCluster(ConditionReasonMajorUpgradeCheckSucceeded, 2)
+		cluster.Annotations = nil
+		Expect(cluster.IsMajorUpgradeOnHold()).To(BeFalse())
+	})
+
+	It("holds the upgrade once the check has been reported", func() {
+		Expect(checkedCluster(ConditionReasonMajorUpgradeCheckSucceeded, 2).IsMajorUpgradeOnHold()).To(BeTrue())
+		Expect(checkedCluster(ConditionReasonMajorUpgradeCheckFailed, 2).IsMajorUpgradeOnHold()).To(BeTrue())
+	})
+
+	It("doesn't hold the upgrade while the check is pending", func() {
+		Expect(checkedCluster(ConditionReasonMajorUpgradeCheckRunning, 2).IsMajorUpgradeOnHold()).To(BeFalse())
+		Expect(checkedCluster(ConditionReasonMajorUpgradeCheckSucceeded, 1).IsMajorUpgradeOnHold()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Readiness probe query", func() {
+	It("expects true by default", func() {
+		Expect((&ProbeQuery{}).GetExpectedResult()).To(Equal("true"))
+		Expect((&ProbeQuery{ExpectedResult: "42"}).GetExpectedResult()).To(Equal("42"))
+	})
+})
+
+var _ = Describe("Lost storage policy", func() {
+	It("waits by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetLostStoragePolicy()).To(Equal(LostStoragePolicyWait))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				LostStoragePolicy: LostStoragePolicyRecreate,
+			},
+		}
+		Expect(cluster.GetLostStoragePolicy()).To(Equal(LostStoragePolicyRecreate))
+	})
+})
+
+var _ = Describe("Huge pages configuration", func() {
+	It("uses 2Mi huge pages in try mode by default", func() {
+		config := HugePagesConfiguration{}
+		pageSize := config.GetPageSize()
+		Expect(pageSize.String()).To(Equal("2Mi"))
+		Expect(config.GetResourceName()).To(BeEquivalentTo("hugepages-2Mi"))
+		Expect(config.GetMode()).To(Equal(HugePagesModeTry))
+	})
+
+	It("respects the preference of the user", func() {
+		config := HugePagesConfiguration{
+			PageSize: "1Gi",
+			Mode:     HugePagesModeOn,
+		}
+		Expect(config.GetResourceName()).To(BeEquivalentTo("hugepages-1Gi"))
+		Expect(config.GetMode()).To(Equal(HugePagesModeOn))
+	})
+})
+
+var _ = Describe("Drain order", func() {
+	It("switches over the primary first by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetDrainOrder()).To(Equal(DrainOrderPrimaryFirst))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				DrainOrder: DrainOrderReplicasFirst,
+			},
+		}
+		Expect(cluster.GetDrainOrder()).To(Equal(DrainOrderReplicasFirst))
+	})
+})
+
+var _ = Describe("Collation version mismatch policy", func() {
+	It("only warns by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetCollationVersionMismatchPolicy()).To(Equal(CollationVersionMismatchWarn))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					CollationVersionMismatch: CollationVersionMismatchReindex,
+				},
+			},
+		}
+		Expect(cluster.GetCollationVersionMismatchPolicy()).To(Equal(CollationVersionMismatchReindex))
+	})
+})
+
+var _ = Describe("External connection", func() {
+	It("uses the PostgreSQL port by default", func() {
+		var cfg *ExternalConnectionConfiguration
+		Expect(cfg.GetPort()).To(BeEquivalentTo(5432))
+
+		cfg = &ExternalConnectionConfiguration{Host: "db.example.com"}
+		Expect(cfg.GetPort()).To(BeEquivalentTo(5432))
+	})
+
+	It("respects the port set by the user", func() {
+		cfg := &ExternalConnectionConfiguration{Host: "db.example.com", Port: ptr.To(int32(6432))}
+		Expect(cfg.GetPort()).To(BeEquivalentTo(6432))
+	})
+})
+
+var _ = Describe("Shutdown mode", func() {
+	It("uses the smart mode by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetShutdownMode()).To(Equal(ShutdownModeSmart))
+
+		cluster.Spec.PostgresConfiguration.Shutdown = &ShutdownConfiguration{}
+		Expect(cluster.GetShutdownMode()).To(Equal(ShutdownModeSmart))
+	})
+
+	It("respects the preference of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Shutdown: &ShutdownConfiguration{Mode: ShutdownModeFast},
+				},
+			},
+		}
+		Expect(cluster.GetShutdownMode()).To(Equal(ShutdownModeFast))
+	})
+})
+
 var _ = Describe("Node maintenance window", func() {
 	It("default maintenance not in progress", func() {
 		cluster := Cluster{}
@@ -268,8 +565,28 @@ var _ = Describe("Bootstrap via initdb", func() {
 		Expect(cluster.ShouldCreateApplicationDatabase()).To(BeTrue())
 		Expect(cluster.ShouldCreateApplicationSecret()).To(BeTrue())
 	})
+
+	It("detects a bootstrap from a logical dump", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsImportDumpBootstrap()).To(BeFalse())
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{InitDB: &BootstrapInitDB{}}
+		Expect(cluster.IsImportDumpBootstrap()).To(BeFalse())
+
+		cluster.Spec.Bootstrap.InitDB.ImportDump = &ImportDump{Path: "dumps/app.dump"}
+		Expect(cluster.IsImportDumpBootstrap()).To(BeTrue())
+	})
 })
 
+var _ = DescribeTable("Format of a logical dump",
+	func(importDump ImportDump, expected ImportDumpFormat) {
+		Expect(importDump.GetFormat()).To(Equal(expected))
+	},
+	Entry("plain SQL script", ImportDump{Path: "dumps/app.sql"}, ImportDumpFormatPlain),
+	Entry("custom-format archive", ImportDump{Path: "dumps/app.dump"}, ImportDumpFormatCustom),
+	Entry("explicit format", ImportDump{Path: "dumps/app.sql", Format: ImportDumpFormatCustom}, ImportDumpFormatCustom),
+)
+
 var _ = Describe("Bootstrap via pg_basebackup", func() {
 	It("will create an application database if specified", func() {
 		cluster := Cluster{
@@ -738,6 +1055,32 @@ var _ = Describe("A secret resource version", func() {
 		Expect(cluster.UsesSecret("clustername-pgbouncer-tls")).To(BeTrue())
 		Expect(cluster.UsesSecret("clustername-pgbouncer-basic")).To(BeTrue())
 	})
+
+	It("contains the secrets with the authentication rules", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clustername",
+			},
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					PgHBARefs: &AuthenticationRulesRefs{
+						SecretRefs: []SecretKeySelector{
+							{LocalObjectReference: LocalObjectReference{Name: "hba-secret"}, Key: "rules"},
+						},
+					},
+					PgIdentRefs: &AuthenticationRulesRefs{
+						SecretRefs: []SecretKeySelector{
+							{LocalObjectReference: LocalObjectReference{Name: "ident-secret"}, Key: "rules"},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(cluster.UsesSecret("hba-secret")).To(BeTrue())
+		Expect(cluster.UsesSecret("ident-secret")).To(BeTrue())
+		Expect(cluster.UsesSecret("a-secret")).To(BeFalse())
+	})
 })
 
 var _ = Describe("A config map resource version", func() {
@@ -773,6 +1116,26 @@ var _ = Describe("A config map resource version", func() {
 		found := cluster.UsesConfigMap("a-configmap")
 		Expect(found).To(BeTrue())
 	})
+
+	It("contains the configmaps with the authentication rules", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clustername",
+			},
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					PgHBARefs: &AuthenticationRulesRefs{
+						ConfigMapRefs: []ConfigMapKeySelector{
+							{LocalObjectReference: LocalObjectReference{Name: "hba-configmap"}, Key: "rules"},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(cluster.UsesConfigMap("hba-configmap")).To(BeTrue())
+		Expect(cluster.UsesConfigMap("a-configmap")).To(BeFalse())
+	})
 })
 
 var _ = Describe("PostgreSQL version detection", func() {
@@ -867,6 +1230,65 @@ var _ = Describe("Default Metrics", func() {
 		}
 		Expect(cluster.Spec.Monitoring.AreDefaultQueriesDisabled()).To(BeTrue())
 	})
+
+	It("returns the default wraparound warning threshold when not set", func() {
+		var monitoring *MonitoringConfiguration
+		Expect(monitoring.GetWraparoundWarningThreshold()).To(BeEquivalentTo(DefaultWraparoundWarningThreshold))
+		monitoring = &MonitoringConfiguration{}
+		Expect(monitoring.GetWraparoundWarningThreshold()).To(BeEquivalentTo(DefaultWraparoundWarningThreshold))
+	})
+
+	It("returns the configured wraparound warning threshold", func() {
+		monitoring := &MonitoringConfiguration{WraparoundWarningThreshold: ptr.To(int32(75))}
+		Expect(monitoring.GetWraparoundWarningThreshold()).To(BeEquivalentTo(75))
+	})
+
+	It("doesn't estimate the table bloat by default", func() {
+		var monitoring *MonitoringConfiguration
+		Expect(monitoring.GetTableBloatEstimateMaxTables()).To(BeZero())
+		monitoring = &MonitoringConfiguration{TableBloatEstimate: &TableBloatEstimateConfiguration{}}
+		Expect(monitoring.GetTableBloatEstimateMaxTables()).To(BeZero())
+	})
+
+	It("returns the number of tables whose bloat is estimated", func() {
+		monitoring := &MonitoringConfiguration{TableBloatEstimate: &TableBloatEstimateConfiguration{Enabled: true}}
+		Expect(monitoring.GetTableBloatEstimateMaxTables()).To(BeEquivalentTo(DefaultTableBloatEstimateMaxTables))
+		monitoring.TableBloatEstimate.MaxTables = ptr.To(int32(5))
+		Expect(monitoring.GetTableBloatEstimateMaxTables()).To(BeEquivalentTo(5))
+	})
+
+	It("returns the default thresholds of the default alerts when not set", func() {
+		var thresholds *DefaultAlertsThresholds
+		Expect(thresholds.GetReplicationLag()).To(Equal(DefaultAlertReplicationLag))
+		Expect(thresholds.GetBackupAge()).To(Equal(DefaultAlertBackupAge))
+		retainedWAL := thresholds.GetReplicationSlotRetainedWAL()
+		Expect(retainedWAL.String()).To(Equal(DefaultAlertReplicationSlotRetainedWAL))
+		Expect(thresholds.GetTransactionIDAge()).To(BeEquivalentTo(DefaultAlertTransactionIDAge))
+		Expect(thresholds.GetCertificateExpiration()).To(Equal(DefaultAlertCertificateExpiration))
+	})
+
+	It("returns the configured thresholds of the default alerts", func() {
+		thresholds := &DefaultAlertsThresholds{
+			ReplicationLag:             &metav1.Duration{Duration: time.Minute},
+			BackupAge:                  &metav1.Duration{Duration: 8 * 24 * time.Hour},
+			ReplicationSlotRetainedWAL: ptr.To(resource.MustParse("1Gi")),
+			TransactionIDAge:           ptr.To(int64(500000000)),
+			CertificateExpiration:      &metav1.Duration{Duration: time.Hour},
+		}
+		Expect(thresholds.GetReplicationLag()).To(Equal(time.Minute))
+		Expect(thresholds.GetBackupAge()).To(Equal(8 * 24 * time.Hour))
+		retainedWAL := thresholds.GetReplicationSlotRetainedWAL()
+		Expect(retainedWAL.String()).To(Equal("1Gi"))
+		Expect(thresholds.GetTransactionIDAge()).To(BeEquivalentTo(500000000))
+		Expect(thresholds.GetCertificateExpiration()).To(Equal(time.Hour))
+	})
+
+	It("enables the default alerts only when requested", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsDefaultAlertsEnabled()).To(BeFalse())
+		cluster.Spec.Monitoring = &MonitoringConfiguration{EnableDefaultAlerts: true}
+		Expect(cluster.IsDefaultAlertsEnabled()).To(BeTrue())
+	})
 })
 
 var _ = Describe("Barman Endpoint CA for replica cluster", func() {
@@ -1013,6 +1435,44 @@ var _ = Describe("Replication slots names for instances", func() {
 	})
 })
 
+var _ = Describe("Logical replication slots cleanup", func() {
+	It("is disabled by default, with the default timeout", func() {
+		var config *LogicalSlotsCleanupConfiguration
+		Expect(config.GetEnabled()).To(BeFalse())
+		Expect(config.GetInactiveTimeout()).To(Equal(DefaultLogicalSlotsInactiveTimeout))
+		Expect(config.IsDeclaredConsumer("sub")).To(BeFalse())
+	})
+
+	It("uses the configured values", func() {
+		config := &LogicalSlotsCleanupConfiguration{
+			Enabled:         true,
+			Consumers:       []string{"sub"},
+			InactiveTimeout: &metav1.Duration{Duration: time.Hour},
+		}
+		Expect(config.GetEnabled()).To(BeTrue())
+		Expect(config.GetInactiveTimeout()).To(Equal(time.Hour))
+		Expect(config.IsDeclaredConsumer("sub")).To(BeTrue())
+		Expect(config.IsDeclaredConsumer("other")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Startup failure policy", func() {
+	It("uses the default window", func() {
+		var config *StartupFailurePolicyConfiguration
+		Expect(config.GetWindow()).To(Equal(DefaultStartupFailureWindow))
+		Expect((&StartupFailurePolicyConfiguration{MaxRestartAttempts: 3}).GetWindow()).
+			To(Equal(DefaultStartupFailureWindow))
+	})
+
+	It("uses the configured window", func() {
+		config := &StartupFailurePolicyConfiguration{
+			MaxRestartAttempts: 3,
+			Window:             &metav1.Duration{Duration: time.Hour},
+		}
+		Expect(config.GetWindow()).To(Equal(time.Hour))
+	})
+})
+
 var _ = Describe("Managed Roles", func() {
 	It("Verify default values", func() {
 		cluster := Cluster{
@@ -1788,3 +2248,36 @@ var _ = Describe("Failover quorum", func() {
 		Entry("with failover quorum disabled", clusterWithFailoverQuorumDisabled, false),
 	)
 })
+
+var _ = Describe("Backup hook timeout", func() {
+	It("defaults to five minutes", func() {
+		var hook *BackupHookConfiguration
+		Expect(hook.GetTimeout()).To(Equal(DefaultBackupHookTimeout))
+		Expect((&BackupHookConfiguration{}).GetTimeout()).To(Equal(DefaultBackupHookTimeout))
+	})
+
+	It("uses the configured timeout", func() {
+		hook := &BackupHookConfiguration{Timeout: &metav1.Duration{Duration: time.Minute}}
+		Expect(hook.GetTimeout()).To(Equal(time.Minute))
+	})
+})
+
+var _ = Describe("Topology configuration", func() {
+	It("uses the defaults when not configured", func() {
+		var topology *TopologyConfiguration
+		Expect(topology.GetTopologyKeys()).To(Equal([]string{DefaultTopologySpreadKey}))
+		Expect(topology.GetMaxSkew()).To(BeEquivalentTo(DefaultTopologyMaxSkew))
+		Expect(topology.GetWhenUnsatisfiable()).To(Equal(corev1.DoNotSchedule))
+	})
+
+	It("uses the configured values", func() {
+		topology := &TopologyConfiguration{
+			TopologyKeys:      []string{"kubernetes.io/hostname"},
+			MaxSkew:           ptr.To(int32(2)),
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}
+		Expect(topology.GetTopologyKeys()).To(Equal([]string{"kubernetes.io/hostname"}))
+		Expect(topology.GetMaxSkew()).To(BeEquivalentTo(2))
+		Expect(topology.GetWhenUnsatisfiable()).To(Equal(corev1.ScheduleAnyway))
+	})
+})
@@ -20,8 +20,11 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -94,6 +97,35 @@ const (
 	// DefaultPostgresGID is the default GID which is used by PostgreSQL
 	DefaultPostgresGID = 26
 
+	// DefaultWraparoundWarningThreshold is the default percentage of
+	// autovacuum_freeze_max_age the transaction ID age can reach before
+	// a warning is raised
+	DefaultWraparoundWarningThreshold = 90
+
+	// DefaultTableBloatEstimateMaxTables is the default number of tables
+	// whose bloat is estimated, when the estimate is enabled
+	DefaultTableBloatEstimateMaxTables = 20
+
+	// DefaultAlertReplicationLag is the default replication lag above
+	// which the default alert is raised
+	DefaultAlertReplicationLag = 5 * time.Minute
+
+	// DefaultAlertBackupAge is the default age of the last available
+	// backup above which the default alert is raised
+	DefaultAlertBackupAge = 25 * time.Hour
+
+	// DefaultAlertReplicationSlotRetainedWAL is the default amount of WAL
+	// retained by a replication slot above which the default alert is raised
+	DefaultAlertReplicationSlotRetainedWAL = "10Gi"
+
+	// DefaultAlertTransactionIDAge is the default age of the oldest
+	// unfrozen transaction ID above which the default alert is raised
+	DefaultAlertTransactionIDAge = 300000000
+
+	// DefaultAlertCertificateExpiration is the default time before the
+	// expiration of a certificate when the default alert is raised
+	DefaultAlertCertificateExpiration = 7 * 24 * time.Hour
+
 	// PodAntiAffinityTypeRequired is the label for required anti-affinity type
 	PodAntiAffinityTypeRequired = "required"
 
@@ -201,6 +233,28 @@ type ImageCatalogRef struct {
 	corev1.TypedLocalObjectReference `json:",inline"`
 	// The major version of PostgreSQL we want to use from the ImageCatalog
 	Major int `json:"major"`
+
+	// Defines when the cluster adopts a new image published in the catalog
+	// for the selected major version, such as a new minor release of
+	// PostgreSQL. By default, the new image is adopted immediately
+	// +optional
+	AutoUpdate *ImageCatalogAutoUpdate `json:"autoUpdate,omitempty"`
+}
+
+// ImageCatalogAutoUpdate restricts the adoption of the new images published
+// in the catalog to recurring maintenance windows. The rolling update follows
+// the configured primary update strategy and method
+type ImageCatalogAutoUpdate struct {
+	// The start of the maintenance windows. The schedule does not follow the
+	// same format used in Kubernetes CronJobs as it includes an additional
+	// seconds specifier,
+	// see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// The duration of each maintenance window. Default: `1h`.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="!(has(self.imageCatalogRef) && has(self.imageName))",message="imageName and imageCatalogRef are mutually exclusive"
@@ -349,6 +403,14 @@ type ClusterSpec struct {
 	// +optional
 	SmartShutdownTimeout *int32 `json:"smartShutdownTimeout,omitempty"`
 
+	// The time in seconds given by Kubernetes to the instance pods to
+	// terminate, after which they are killed. It must not be shorter than
+	// `stopDelay`, the time the instance manager waits for the smart and
+	// fast shutdown of Postgres to complete. Defaults to `stopDelay`.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
 	// The time in seconds that is allowed for a primary PostgreSQL instance
 	// to gracefully shutdown during a switchover.
 	// Default value is 3600 seconds (1 hour).
@@ -363,6 +425,25 @@ type ClusterSpec struct {
 	// +optional
 	FailoverDelay int32 `json:"failoverDelay,omitempty"`
 
+	// Configuration of the switchover procedure
+	// +optional
+	Switchover *SwitchoverConfiguration `json:"switchover,omitempty"`
+
+	// Configuration of the automated failover procedure
+	// +optional
+	Failover *FailoverConfiguration `json:"failover,omitempty"`
+
+	// Configuration of the replicas intentionally applying the changes
+	// behind the primary, to protect against accidental data destruction
+	// +optional
+	DelayedReplicas *DelayedReplicasConfiguration `json:"delayedReplicas,omitempty"`
+
+	// The policy marking an instance as permanently failed when its
+	// PostgreSQL keeps crashing right after starting, instead of restarting
+	// it indefinitely. Disabled by default.
+	// +optional
+	StartupFailurePolicy *StartupFailurePolicyConfiguration `json:"startupFailurePolicy,omitempty"`
+
 	// LivenessProbeTimeout is the time (in seconds) that is allowed for a PostgreSQL instance
 	// to successfully respond to the liveness probe (default 30).
 	// The Liveness probe failure threshold is derived from this value using the formula:
@@ -380,6 +461,21 @@ type ClusterSpec struct {
 	// +optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
+	// Topology defines how the instances are spread across the failure
+	// domains, by generating topology spread constraints that are added to
+	// the ones specified in `topologySpreadConstraints`
+	// +optional
+	Topology *TopologyConfiguration `json:"topology,omitempty"`
+
+	// InitContainers is a list of user-provided init containers that are
+	// run in every generated Pod before the ones managed by the operator.
+	// They cannot mount the volumes holding the PostgreSQL data, the WAL
+	// files and the tablespaces. Please refer to
+	// https://kubernetes.io/docs/concepts/workloads/pods/init-containers/
+	// for more information.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
 	// Resources requirements of every generated Pod. Please refer to
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// for more information.
@@ -414,14 +510,52 @@ type ClusterSpec struct {
 	// +optional
 	PrimaryUpdateMethod PrimaryUpdateMethod `json:"primaryUpdateMethod,omitempty"`
 
+	// Overrides the `primaryUpdateMethod` for specific categories of
+	// changes, i.e. a change of the PostgreSQL image or a configuration
+	// change requiring a restart of PostgreSQL
+	// +optional
+	PrimaryUpdateMethodOverrides *PrimaryUpdateMethodOverrides `json:"primaryUpdateMethodOverrides,omitempty"`
+
+	// The maximum number of replicas that can be updated at the same time
+	// during a rolling update procedure (default: 1). The operator never
+	// takes down more replicas than the ones exceeding the number of
+	// synchronous replicas required by the cluster
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReplicaUpdates int `json:"maxConcurrentReplicaUpdates,omitempty"`
+
 	// The configuration to be used for backups
 	// +optional
 	Backup *BackupConfiguration `json:"backup,omitempty"`
 
+	// The configuration of the WAL archiving process, regardless of
+	// whether the WAL files are archived in the object store or by a plugin
+	// +optional
+	WALArchiving *WALArchivingConfiguration `json:"walArchiving,omitempty"`
+
 	// Define a maintenance window for the Kubernetes nodes
 	// +optional
 	NodeMaintenanceWindow *NodeMaintenanceWindow `json:"nodeMaintenanceWindow,omitempty"`
 
+	// The order in which the instances are moved away when the node of the
+	// primary is being drained: the primary can be switched over as soon
+	// as a replica is available on a schedulable node (`primary-first` -
+	// default), or only after the replicas running on nodes being drained
+	// have been rescheduled (`replicas-first`)
+	// +kubebuilder:validation:Enum:=primary-first;replicas-first
+	// +optional
+	DrainOrder DrainOrder `json:"drainOrder,omitempty"`
+
+	// The action taken when the storage of an instance is lost, i.e. when
+	// one of its PVCs is in the `Lost` phase, or is bound to a node that
+	// doesn't exist anymore while the Pod can't run. With `wait` (default)
+	// the operator only emits an event, while with `recreate` the PVCs of
+	// the instance are deleted and a new instance is cloned from the
+	// primary. A lost primary is recreated only after a failover.
+	// +kubebuilder:validation:Enum:=wait;recreate
+	// +optional
+	LostStoragePolicy LostStoragePolicy `json:"lostStoragePolicy,omitempty"`
+
 	// The configuration of the monitoring infrastructure of this cluster
 	// +optional
 	Monitoring *MonitoringConfiguration `json:"monitoring,omitempty"`
@@ -487,6 +621,28 @@ type ClusterSpec struct {
 	// in the PostgreSQL Pods.
 	// +optional
 	Probes *ProbesConfiguration `json:"probes,omitempty"`
+
+	// The host and the port written in the application connection secret
+	// in place of the ones of the read-write service, so that the
+	// applications running outside of the Kubernetes cluster, i.e. through
+	// a service mesh or an external DNS, get a working connection string
+	// +optional
+	ExternalConnection *ExternalConnectionConfiguration `json:"externalConnection,omitempty"`
+}
+
+// ExternalConnectionConfiguration contains the externally resolvable
+// endpoint of the cluster, written in the application connection secret
+type ExternalConnectionConfiguration struct {
+	// The externally resolvable host name, or IP address, of the
+	// read-write endpoint of the cluster
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// The port of the external endpoint of the cluster. Default: `5432`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
 }
 
 // ProbesConfiguration represent the configuration for the probes
@@ -515,6 +671,31 @@ type ProbeWithStrategy struct {
 	// Lag limit. Used only for `streaming` strategy
 	// +optional
 	MaximumLag *resource.Quantity `json:"maximumLag,omitempty"`
+
+	// A custom SQL query run on the primary instance, once the probe
+	// strategy succeeds, to gate the readiness on the state of the
+	// application. Used only for the readiness probe
+	// +optional
+	Query *ProbeQuery `json:"query,omitempty"`
+}
+
+// ProbeQuery is a custom SQL query that must return the expected
+// result for the primary instance to be considered ready
+type ProbeQuery struct {
+	// The SQL query to be run by the instance manager, returning a
+	// single value, i.e. `SELECT EXISTS (SELECT FROM app.migrations)`
+	// +kubebuilder:validation:MinLength=1
+	SQL string `json:"sql"`
+
+	// The database where the query is run. Defaults to the
+	// application database
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// The text representation of the value the query must return.
+	// Defaults to `true`
+	// +optional
+	ExpectedResult string `json:"expectedResult,omitempty"`
 }
 
 // ProbeStrategyType is the type of the strategy used to declare a PostgreSQL instance
@@ -607,6 +788,78 @@ type IsolationCheckConfiguration struct {
 	ConnectionTimeout int `json:"connectionTimeout,omitempty"`
 }
 
+// SwitchoverConfiguration contains the configuration of the switchover procedure
+type SwitchoverConfiguration struct {
+	// When enabled, the target primary waits until it has replayed the WAL
+	// up to the shutdown checkpoint of the former primary before being
+	// promoted. If this doesn't happen within `shutdownLSNTimeout` seconds,
+	// the switchover is aborted and the former primary is restarted as
+	// the primary instance. Defaults to `false`.
+	// +optional
+	WaitForShutdownLSN *bool `json:"waitForShutdownLSN,omitempty"`
+
+	// The time in seconds the target primary waits for the shutdown
+	// checkpoint of the former primary to be replayed before aborting
+	// the switchover. Default value is 60 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ShutdownLSNTimeout *int32 `json:"shutdownLSNTimeout,omitempty"`
+}
+
+// FailoverConfiguration contains the configuration of the automated
+// failover procedure
+type FailoverConfiguration struct {
+	// The amount of time, after an automated failover, during which
+	// no other automated failover is performed, to dampen the failover
+	// storms caused by intermittent infrastructure issues. Switchovers
+	// are still allowed. Disabled by default.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+
+	// The names of the instances that are never promoted, neither by an
+	// automated failover nor by a switchover, e.g. replicas dedicated to
+	// analytics workloads that are allowed to lag behind the primary.
+	// They are not used as synchronous standbys, and keep serving the
+	// read-only traffic. At least one replica must remain eligible for
+	// promotion.
+	// +listType=set
+	// +optional
+	ExcludedInstances []string `json:"excludedInstances,omitempty"`
+}
+
+// DelayedReplicasConfiguration contains the configuration of the replicas
+// applying the changes received from the primary with a delay, through the
+// `recovery_min_apply_delay` PostgreSQL parameter
+type DelayedReplicasConfiguration struct {
+	// The names of the delayed replicas. They are never promoted, neither
+	// by an automated failover nor by a switchover, and keep serving the
+	// read-only traffic. At least one replica must remain eligible for
+	// promotion.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Instances []string `json:"instances"`
+
+	// The amount of time the delayed replicas wait before applying the
+	// changes committed on the primary
+	MinApplyDelay metav1.Duration `json:"minApplyDelay"`
+}
+
+// StartupFailurePolicyConfiguration contains the configuration of the
+// policy marking an instance as permanently failed when its PostgreSQL
+// keeps crashing right after starting
+type StartupFailurePolicyConfiguration struct {
+	// The number of times a crashing PostgreSQL is restarted, within the
+	// window, before the instance is marked as permanently failed and
+	// PostgreSQL is not started anymore
+	// +kubebuilder:validation:Minimum=1
+	MaxRestartAttempts int32 `json:"maxRestartAttempts"`
+
+	// The amount of time in which the crashes of PostgreSQL are counted,
+	// by default 10 minutes
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
 const (
 	// PhaseSwitchover when a cluster is changing the primary node
 	PhaseSwitchover = "Switchover in progress"
@@ -963,6 +1216,10 @@ type ClusterStatus struct {
 	// +optional
 	TargetPrimaryTimestamp string `json:"targetPrimaryTimestamp,omitempty"`
 
+	// The timestamp when the last automated failover has been initiated
+	// +optional
+	LastFailoverTimestamp string `json:"lastFailoverTimestamp,omitempty"`
+
 	// The integration needed by poolers referencing the cluster
 	// +optional
 	PoolerIntegrations *PoolerIntegrations `json:"poolerIntegrations,omitempty"`
@@ -1013,6 +1270,18 @@ type ClusterStatus struct {
 	// SystemID is the latest detected PostgreSQL SystemID
 	// +optional
 	SystemID string `json:"systemID,omitempty"`
+
+	// PrimaryShutdownLSN is the location of the shutdown checkpoint of
+	// the former primary, recorded during a switchover when
+	// `.spec.switchover.waitForShutdownLSN` is enabled
+	// +optional
+	PrimaryShutdownLSN string `json:"primaryShutdownLSN,omitempty"`
+
+	// HugePagesFallback is true when the huge pages requested through
+	// `.spec.postgresql.hugePages` are not available on any node, and
+	// the instances are running without them
+	// +optional
+	HugePagesFallback bool `json:"hugePagesFallback,omitempty"`
 }
 
 // ImageInfo contains the information about a PostgreSQL image
@@ -1039,6 +1308,33 @@ type InstanceReportedState struct {
 	TimeLineID int `json:"timeLineID,omitempty"`
 	// IP address of the instance
 	IP string `json:"ip,omitempty"`
+	// The delay applied by the instance to the changes received from
+	// the primary, as reported by the delayed replicas
+	// +optional
+	MinApplyDelay string `json:"minApplyDelay,omitempty"`
+	// The authentication rules loaded by the instance from the Secrets
+	// and ConfigMaps referenced in `pgHBARefs` and `pgIdentRefs`
+	// +optional
+	AuthenticationRules *AuthenticationRulesStatus `json:"authenticationRules,omitempty"`
+}
+
+// AuthenticationRulesStatus describes the authentication rules that an
+// instance loaded from the Secrets and ConfigMaps referenced in the
+// `pgHBARefs` and `pgIdentRefs` sections
+type AuthenticationRulesStatus struct {
+	// The versions of the Secrets whose rules have been applied.
+	// Map keys are the secret names, map values are the versions
+	// +optional
+	SecretVersions map[string]string `json:"secretVersions,omitempty"`
+	// The versions of the ConfigMaps whose rules have been applied.
+	// Map keys are the config map names, map values are the versions
+	// +optional
+	ConfigMapVersions map[string]string `json:"configMapVersions,omitempty"`
+	// The reason why the current content of the referenced Secrets and
+	// ConfigMaps has been refused. The instance keeps using the rules
+	// that were previously applied until the error is fixed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // ClusterConditionType defines types of cluster conditions
@@ -1056,6 +1352,57 @@ const (
 	// ConditionConsistentSystemID is true when the all the instances of the
 	// cluster report the same System ID.
 	ConditionConsistentSystemID ClusterConditionType = "ConsistentSystemID"
+	// ConditionStorageResize is false when the size of one or more PVCs
+	// cannot be changed as requested, i.e. because their storage class
+	// doesn't allow volume expansion
+	ConditionStorageResize ClusterConditionType = "StorageResize"
+	// ConditionTransactionIDAge is false when the age of the oldest
+	// unfrozen transaction ID, or multixact ID, of a database crosses the
+	// configured wraparound warning threshold
+	ConditionTransactionIDAge ClusterConditionType = "TransactionIDAge"
+	// ConditionDumpImport reports the progress of the restore of a logical
+	// dump during an `initdb.importDump` bootstrap
+	ConditionDumpImport ClusterConditionType = "DumpImport"
+	// ConditionPrimaryUpdateApproved is false when a supervised rolling
+	// update has updated every replica and is waiting for the user to
+	// approve the update of the primary instance
+	ConditionPrimaryUpdateApproved ClusterConditionType = "PrimaryUpdateApproved"
+	// ConditionFailoverCooldown is true when an automated failover is
+	// prevented because the failover cooldown has not elapsed yet
+	ConditionFailoverCooldown ClusterConditionType = "FailoverCooldown"
+	// ConditionObjectStoreAccessible is false when the object store used to
+	// back up the cluster can't be reached with the configured credentials
+	ConditionObjectStoreAccessible ClusterConditionType = "ObjectStoreAccessible"
+	// ConditionStatisticsUpToDate is false when the statistics used by the
+	// query planner need to be collected after a major version upgrade or
+	// a recovery, until the automatic `ANALYZE` completes
+	ConditionStatisticsUpToDate ClusterConditionType = "StatisticsUpToDate"
+	// ConditionMajorUpgrade reports the progress of an in-place major
+	// version upgrade, or of its dry-run, and is true once the upgraded
+	// primary instance is up and running
+	ConditionMajorUpgrade ClusterConditionType = "MajorUpgrade"
+	// ConditionInstancesSchedulable is false when some instance pods
+	// can't be scheduled by Kubernetes, and reports why
+	ConditionInstancesSchedulable ClusterConditionType = "InstancesSchedulable"
+	// ConditionInstancesRunnable is false when some instances have been
+	// marked as permanently failed by the startup failure policy
+	ConditionInstancesRunnable ClusterConditionType = "InstancesRunnable"
+	// ConditionScheduledBackupsSucceeding is false when one of the
+	// scheduled backups of the cluster reached its threshold of
+	// consecutive failed backups
+	ConditionScheduledBackupsSucceeding ClusterConditionType = "ScheduledBackupsSucceeding"
+	// ConditionReplicaClusterPromotion reports the progress of the promotion
+	// of a replica cluster, and is true once the promoted primary instance
+	// doesn't reference the former replication source anymore
+	ConditionReplicaClusterPromotion ClusterConditionType = "ReplicaClusterPromotion"
+
+	// ConditionCollationVersionsUpToDate reports the rebuild of the indexes
+	// affected by a collation version mismatch, with the `reindex` policy
+	ConditionCollationVersionsUpToDate ClusterConditionType = "CollationVersionsUpToDate"
+
+	// ConditionPromotionRequestPending is true while the instance annotated
+	// to be promoted is not ready to be promoted yet
+	ConditionPromotionRequestPending ClusterConditionType = "PromotionRequestPending"
 )
 
 // ConditionStatus defines conditions of resources
@@ -1096,6 +1443,10 @@ const (
 	// the WAL archiving is not working correctly
 	ConditionReasonContinuousArchivingFailing ConditionReason = "ContinuousArchivingFailing"
 
+	// ConditionReasonContinuousArchivingPaused means that the condition has changed because
+	// the WAL archiving has been paused by the user
+	ConditionReasonContinuousArchivingPaused ConditionReason = "ContinuousArchivingPaused"
+
 	// ClusterReady means that the condition changed because the cluster is ready and working properly
 	ClusterReady ConditionReason = "ClusterIsReady"
 
@@ -1104,6 +1455,204 @@ const (
 
 	// DetachedVolume is the reason that is set when we do a rolling upgrade to add a PVC volume to a cluster
 	DetachedVolume ConditionReason = "DetachedVolume"
+
+	// ConditionReasonVolumeExpansionNotAllowed means that the condition changed because
+	// the storage class of one or more PVCs doesn't allow volume expansion
+	ConditionReasonVolumeExpansionNotAllowed ConditionReason = "VolumeExpansionNotAllowed"
+
+	// ConditionReasonVolumeExpansionApplied means that the condition changed because
+	// every requested volume expansion has been applied to the PVCs
+	ConditionReasonVolumeExpansionApplied ConditionReason = "VolumeExpansionApplied"
+
+	// ConditionReasonWraparoundThresholdExceeded means that the condition changed
+	// because the age of the oldest unfrozen transaction ID, or multixact ID,
+	// crossed the wraparound warning threshold
+	ConditionReasonWraparoundThresholdExceeded ConditionReason = "WraparoundThresholdExceeded"
+
+	// ConditionReasonWraparoundThresholdNotExceeded means that the condition changed
+	// because the age of the oldest unfrozen transaction ID, and multixact ID,
+	// is below the wraparound warning threshold
+	ConditionReasonWraparoundThresholdNotExceeded ConditionReason = "WraparoundThresholdNotExceeded"
+
+	// ConditionReasonDumpDownloading means that the logical dump is being
+	// downloaded from the object store
+	ConditionReasonDumpDownloading ConditionReason = "DumpDownloading"
+
+	// ConditionReasonDumpRestoring means that the logical dump is being
+	// restored into the application database
+	ConditionReasonDumpRestoring ConditionReason = "DumpRestoring"
+
+	// ConditionReasonDumpImportSucceeded means that the logical dump has
+	// been restored successfully
+	ConditionReasonDumpImportSucceeded ConditionReason = "DumpImportSucceeded"
+
+	// ConditionReasonDumpImportFailed means that the logical dump could not
+	// be downloaded or restored
+	ConditionReasonDumpImportFailed ConditionReason = "DumpImportFailed"
+
+	// ConditionReasonPrimaryUpdateWaitingForApproval means that the condition
+	// changed because the replicas have been updated and the primary instance
+	// can be updated only after the user approval
+	ConditionReasonPrimaryUpdateWaitingForApproval ConditionReason = "WaitingForApproval"
+
+	// ConditionReasonPrimaryUpdateApproved means that the condition changed
+	// because the user approved the update of the primary instance
+	ConditionReasonPrimaryUpdateApproved ConditionReason = "Approved"
+
+	// ConditionReasonPromotionWaitingForInstance means that the condition
+	// changed because the instance annotated to be promoted is not ready or
+	// not streaming from the primary
+	ConditionReasonPromotionWaitingForInstance ConditionReason = "WaitingForInstance"
+
+	// ConditionReasonPromotionSwitchoverStarted means that the condition
+	// changed because the switchover to the annotated instance started
+	ConditionReasonPromotionSwitchoverStarted ConditionReason = "SwitchoverStarted"
+
+	// ConditionReasonPromotionRequestRejected means that the condition changed
+	// because the annotated instance can't be promoted, and the request has
+	// been removed
+	ConditionReasonPromotionRequestRejected ConditionReason = "RequestRejected"
+
+	// ConditionReasonPromotionRequestExpired means that the condition changed
+	// because the annotated instance didn't become ready to be promoted in
+	// time, and the request has been removed
+	ConditionReasonPromotionRequestExpired ConditionReason = "RequestExpired"
+
+	// ConditionReasonPromotionRequestCanceled means that the condition
+	// changed because the user removed the promotion request
+	ConditionReasonPromotionRequestCanceled ConditionReason = "RequestCanceled"
+
+	// ConditionReasonFailoverPrevented means that the condition changed
+	// because an automated failover is needed, but the failover cooldown
+	// has not elapsed yet
+	ConditionReasonFailoverPrevented ConditionReason = "FailoverPrevented"
+
+	// ConditionReasonFailoverAllowed means that the condition changed
+	// because the failover cooldown is not preventing any failover anymore
+	ConditionReasonFailoverAllowed ConditionReason = "FailoverAllowed"
+
+	// ConditionReasonInstancesUnschedulable means that the condition changed
+	// because some instance pods have been unschedulable for too long
+	ConditionReasonInstancesUnschedulable ConditionReason = "InstancesUnschedulable"
+
+	// ConditionReasonInstancesScheduled means that the condition changed
+	// because every instance pod has been scheduled
+	ConditionReasonInstancesScheduled ConditionReason = "InstancesScheduled"
+
+	// ConditionReasonInstancesPermanentlyFailed means that the condition
+	// changed because some instances crashed too many times in a row, and
+	// PostgreSQL is not started on them anymore
+	ConditionReasonInstancesPermanentlyFailed ConditionReason = "InstancesPermanentlyFailed"
+
+	// ConditionReasonNoInstancePermanentlyFailed means that the condition
+	// changed because no instance is marked as permanently failed anymore
+	ConditionReasonNoInstancePermanentlyFailed ConditionReason = "NoInstancePermanentlyFailed"
+
+	// ConditionReasonScheduledBackupsFailing means that the condition
+	// changed because some scheduled backups reached their threshold of
+	// consecutive failed backups
+	ConditionReasonScheduledBackupsFailing ConditionReason = "ScheduledBackupsFailing"
+
+	// ConditionReasonScheduledBackupsSucceeding means that the condition
+	// changed because no scheduled backup is failing anymore
+	ConditionReasonScheduledBackupsSucceeding ConditionReason = "ScheduledBackupsSucceeding"
+
+	// ConditionReasonObjectStoreAccessible means that the condition changed
+	// because the backups stored in the object store could be listed
+	ConditionReasonObjectStoreAccessible ConditionReason = "ObjectStoreAccessible"
+
+	// ConditionReasonObjectStoreNotAccessible means that the condition changed
+	// because the backups stored in the object store could not be listed,
+	// i.e. because the bucket doesn't exist or the credentials are invalid
+	ConditionReasonObjectStoreNotAccessible ConditionReason = "ObjectStoreNotAccessible"
+
+	// ConditionReasonAnalyzePending means that the condition changed because
+	// a major version upgrade or a recovery left the statistics outdated,
+	// and the automatic `ANALYZE` has not started yet
+	ConditionReasonAnalyzePending ConditionReason = "AnalyzePending"
+
+	// ConditionReasonAnalyzeRunning means that the condition changed because
+	// the automatic `ANALYZE` is running on the primary
+	ConditionReasonAnalyzeRunning ConditionReason = "AnalyzeRunning"
+
+	// ConditionReasonAnalyzeCompleted means that the condition changed because
+	// the automatic `ANALYZE` has collected the statistics of every database
+	ConditionReasonAnalyzeCompleted ConditionReason = "AnalyzeCompleted"
+
+	// ConditionReasonAnalyzeFailed means that the condition changed because
+	// the automatic `ANALYZE` failed
+	ConditionReasonAnalyzeFailed ConditionReason = "AnalyzeFailed"
+
+	// ConditionReasonAnalyzeNotNeeded means that the condition changed because
+	// the statistics of a replica cluster are replicated from its source
+	ConditionReasonAnalyzeNotNeeded ConditionReason = "AnalyzeNotNeeded"
+
+	// ConditionReasonCollationReindexPending means that the condition changed
+	// because some indexes depend on a collation whose version changed, and
+	// they are waiting for the next maintenance window to be rebuilt
+	ConditionReasonCollationReindexPending ConditionReason = "ReindexPending"
+
+	// ConditionReasonCollationReindexRunning means that the condition changed
+	// because the indexes affected by a collation version mismatch are being
+	// rebuilt on the primary
+	ConditionReasonCollationReindexRunning ConditionReason = "ReindexRunning"
+
+	// ConditionReasonCollationReindexCompleted means that the condition
+	// changed because the affected indexes have been rebuilt, and the
+	// collation versions refreshed
+	ConditionReasonCollationReindexCompleted ConditionReason = "ReindexCompleted"
+
+	// ConditionReasonCollationReindexFailed means that the condition changed
+	// because the affected indexes could not be rebuilt
+	ConditionReasonCollationReindexFailed ConditionReason = "ReindexFailed"
+
+	// ConditionReasonMajorUpgradeRunning means that the condition changed
+	// because the job running `pg_upgrade` has been created
+	ConditionReasonMajorUpgradeRunning ConditionReason = "MajorUpgradeRunning"
+
+	// ConditionReasonMajorUpgradeStarting means that the condition changed
+	// because `pg_upgrade` completed and the operator is waiting for the
+	// upgraded primary instance to start
+	ConditionReasonMajorUpgradeStarting ConditionReason = "MajorUpgradeStarting"
+
+	// ConditionReasonMajorUpgradeSucceeded means that the condition changed
+	// because the upgraded primary instance is up and running
+	ConditionReasonMajorUpgradeSucceeded ConditionReason = "MajorUpgradeSucceeded"
+
+	// ConditionReasonMajorUpgradeFailed means that the condition changed
+	// because the job running `pg_upgrade` failed. The data directory is
+	// left on the previous major version, which can be restored by
+	// requesting it again
+	ConditionReasonMajorUpgradeFailed ConditionReason = "MajorUpgradeFailed"
+
+	// ConditionReasonMajorUpgradeRolledBack means that the condition changed
+	// because, after a failed upgrade, the cluster has been brought back
+	// to the previous major version
+	ConditionReasonMajorUpgradeRolledBack ConditionReason = "MajorUpgradeRolledBack"
+
+	// ConditionReasonMajorUpgradeCheckRunning means that the condition changed
+	// because the job running `pg_upgrade --check` has been created
+	ConditionReasonMajorUpgradeCheckRunning ConditionReason = "MajorUpgradeCheckRunning"
+
+	// ConditionReasonMajorUpgradeCheckSucceeded means that the condition
+	// changed because `pg_upgrade --check` found the data directory
+	// compatible with the requested major version
+	ConditionReasonMajorUpgradeCheckSucceeded ConditionReason = "MajorUpgradeCheckSucceeded"
+
+	// ConditionReasonMajorUpgradeCheckFailed means that the condition changed
+	// because `pg_upgrade --check` reported the data directory as not
+	// compatible with the requested major version
+	ConditionReasonMajorUpgradeCheckFailed ConditionReason = "MajorUpgradeCheckFailed"
+
+	// ConditionReasonPromotionStarted means that the condition changed
+	// because the designated primary of a replica cluster started being
+	// promoted to primary
+	ConditionReasonPromotionStarted ConditionReason = "PromotionStarted"
+
+	// ConditionReasonPromotionCompleted means that the condition changed
+	// because the replication settings pointing to the former source have
+	// been removed from the promoted primary instance
+	ConditionReasonPromotionCompleted ConditionReason = "PromotionCompleted"
 )
 
 // EmbeddedObjectMetadata contains metadata to be inherited by all resources related to a Cluster
@@ -1163,6 +1712,19 @@ type ReplicaClusterConfiguration struct {
 	// token cannot be used.
 	// +optional
 	MinApplyDelay *metav1.Duration `json:"minApplyDelay,omitempty"`
+
+	// The name of the physical replication slot, in the source cluster,
+	// used by the designated primary to stream the WAL files, so that the
+	// source retains the WAL files the designated primary still needs.
+	// The slot is created by the designated primary if it doesn't exist,
+	// and dropped once the replica cluster has been promoted. It requires
+	// the source external cluster to have connection parameters.
+	// It may only contain lower case letters, numbers, and the underscore
+	// character.
+	// +kubebuilder:validation:Pattern=^[0-9a-z_]*$
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	PrimarySlotName string `json:"primarySlotName,omitempty"`
 }
 
 // DefaultReplicationSlotsUpdateInterval is the default in seconds for the replication slots update interval
@@ -1171,6 +1733,14 @@ const DefaultReplicationSlotsUpdateInterval = 30
 // DefaultReplicationSlotsHASlotPrefix is the default prefix for names of replication slots used for HA.
 const DefaultReplicationSlotsHASlotPrefix = "_cnpg_"
 
+// DefaultStartupFailureWindow is the default amount of time in which the
+// crashes of PostgreSQL are counted by the startup failure policy
+const DefaultStartupFailureWindow = 10 * time.Minute
+
+// DefaultLogicalSlotsInactiveTimeout is the default time an orphaned logical
+// replication slot needs to be inactive for before being dropped
+const DefaultLogicalSlotsInactiveTimeout = 24 * time.Hour
+
 // SynchronizeReplicasConfiguration contains the configuration for the synchronization of user defined
 // physical replication slots
 type SynchronizeReplicasConfiguration struct {
@@ -1201,6 +1771,39 @@ type ReplicationSlotsConfiguration struct {
 	// Configures the synchronization of the user defined physical replication slots
 	// +optional
 	SynchronizeReplicas *SynchronizeReplicasConfiguration `json:"synchronizeReplicas,omitempty"`
+
+	// Configures the automatic cleanup of the logical replication slots
+	// left behind by decommissioned logical replication consumers
+	// +optional
+	LogicalSlotsCleanup *LogicalSlotsCleanupConfiguration `json:"logicalSlotsCleanup,omitempty"`
+}
+
+// LogicalSlotsCleanupConfiguration encapsulates the configuration of the
+// automatic cleanup of the logical replication slots on the primary.
+// An inactive logical replication slot keeps retaining WAL files forever,
+// so a slot left behind by a removed subscriber can fill the WAL storage.
+// When enabled, every logical replication slot that doesn't belong to a
+// declared consumer and stays inactive for longer than `inactiveTimeout`
+// is dropped. An event is emitted on the cluster when an orphaned slot is
+// detected and before dropping it.
+type LogicalSlotsCleanupConfiguration struct {
+	// When set to true, the orphaned logical replication slots are
+	// automatically dropped (by default false)
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The names of the logical replication slots used by the declared
+	// consumers, such as the subscriptions on other clusters. These slots
+	// are never dropped, even when inactive.
+	// +optional
+	Consumers []string `json:"consumers,omitempty"`
+
+	// The time an orphaned logical replication slot needs to be
+	// continuously inactive before being dropped (by default 24h).
+	// The time is measured by the instance manager of the primary, and
+	// restarts from zero after a restart or a switchover.
+	// +optional
+	InactiveTimeout *metav1.Duration `json:"inactiveTimeout,omitempty"`
 }
 
 // ReplicationSlotsHAConfiguration encapsulates the configuration
@@ -1281,10 +1884,60 @@ type NodeMaintenanceWindow struct {
 // the primary server of the cluster as part of rolling updates
 type PrimaryUpdateStrategy string
 
+// DrainOrder contains the order in which the instances are moved
+// away from the nodes being drained
+type DrainOrder string
+
+// LostStoragePolicy contains the action taken when the storage
+// of an instance is lost
+type LostStoragePolicy string
+
 // PrimaryUpdateMethod contains the method to use when upgrading
 // the primary server of the cluster as part of rolling updates
 type PrimaryUpdateMethod string
 
+// PrimaryUpdateMethodOverrides contains the methods to use when upgrading
+// the primary server of the cluster, depending on the kind of change
+// being applied. When a method is not specified, the cluster-wide
+// `primaryUpdateMethod` is used.
+type PrimaryUpdateMethodOverrides struct {
+	// Method to follow when the PostgreSQL image of the primary
+	// instance is changed: it can be with a switchover (`switchover`)
+	// or in-place (`restart`)
+	// +kubebuilder:validation:Enum:=switchover;restart
+	// +optional
+	Image PrimaryUpdateMethod `json:"image,omitempty"`
+
+	// Method to follow when PostgreSQL needs to be restarted to apply
+	// configuration changes: it can be with a switchover (`switchover`)
+	// or in-place (`restart`)
+	// +kubebuilder:validation:Enum:=switchover;restart
+	// +optional
+	Configuration PrimaryUpdateMethod `json:"configuration,omitempty"`
+}
+
+const (
+	// DrainOrderPrimaryFirst means that the primary is switched over as
+	// soon as a replica is available on a schedulable node (`primary-first`, default)
+	DrainOrderPrimaryFirst DrainOrder = "primary-first"
+
+	// DrainOrderReplicasFirst means that the primary is switched over only
+	// after the replicas running on nodes being drained have been
+	// rescheduled (`replicas-first`)
+	DrainOrderReplicasFirst DrainOrder = "replicas-first"
+)
+
+const (
+	// LostStoragePolicyWait means that the operator only reports the
+	// instances whose storage is lost (`wait`, default)
+	LostStoragePolicyWait LostStoragePolicy = "wait"
+
+	// LostStoragePolicyRecreate means that the operator deletes the PVCs
+	// of the instances whose storage is lost, and clones them again from
+	// the primary (`recreate`)
+	LostStoragePolicyRecreate LostStoragePolicy = "recreate"
+)
+
 const (
 	// PrimaryUpdateStrategySupervised means that the operator need to wait for the
 	// user to manually issue a switchover request before updating the primary
@@ -1312,6 +1965,10 @@ const (
 	// is gracefully shutdown during a switchover.
 	DefaultMaxSwitchoverDelay = 3600
 
+	// DefaultShutdownLSNTimeout is the default time in seconds the target primary
+	// waits for the shutdown checkpoint of the former primary to be replayed
+	DefaultShutdownLSNTimeout = 60
+
 	// DefaultStartupDelay is the default value for startupDelay, startupDelay will be used to calculate the
 	// FailureThreshold of startupProbe, the formula is `FailureThreshold = ceiling(startDelay / periodSeconds)`,
 	// the minimum value is 1
@@ -1417,6 +2074,18 @@ type PostgresConfiguration struct {
 	// +optional
 	PgIdent []string `json:"pg_ident,omitempty"`
 
+	// References to keys of Secrets and ConfigMaps containing PostgreSQL
+	// Host Based Authentication rules, one per line, to be appended to the
+	// pg_hba.conf file after the ones in `pg_hba`
+	// +optional
+	PgHBARefs *AuthenticationRulesRefs `json:"pgHBARefs,omitempty"`
+
+	// References to keys of Secrets and ConfigMaps containing PostgreSQL
+	// User Name Maps rules, one per line, to be appended to the
+	// pg_ident.conf file after the ones in `pg_ident`
+	// +optional
+	PgIdentRefs *AuthenticationRulesRefs `json:"pgIdentRefs,omitempty"`
+
 	// Requirements to be met by sync replicas. This will affect how the "synchronous_standby_names" parameter will be
 	// set up.
 	// +optional
@@ -1446,6 +2115,374 @@ type PostgresConfiguration struct {
 	// The configuration of the extensions to be added
 	// +optional
 	Extensions []ExtensionConfiguration `json:"extensions,omitempty"`
+
+	// The action taken by the instance manager when the primary detects, at
+	// startup, that the version of a collation used by one or more indexes
+	// differs from the one provided by the operating system, i.e. after a
+	// glibc or ICU upgrade. With `warn` (default) the affected indexes are
+	// only logged, while with `reindex` they are rebuilt using
+	// `REINDEX INDEX CONCURRENTLY` before the collation version is refreshed.
+	// +kubebuilder:validation:Enum=warn;reindex
+	// +optional
+	CollationVersionMismatch CollationVersionMismatchPolicy `json:"collationVersionMismatch,omitempty"`
+
+	// The maintenance windows in which the indexes affected by a collation
+	// version mismatch are rebuilt, required by the `reindex` policy
+	// +optional
+	CollationReindexWindow *CollationReindexWindow `json:"collationReindexWindow,omitempty"`
+
+	// When set to `true`, the instance manager sets `default_transaction_read_only`
+	// to `on` on every replica, and removes it as soon as the instance is
+	// promoted, so that writes sent to a replica fail immediately with an
+	// explicit read-only transaction error. Default: `false`.
+	// +optional
+	EnforceReplicaReadOnly bool `json:"enforceReplicaReadOnly,omitempty"`
+
+	// The TLS configuration of the PostgreSQL server, used to restrict
+	// the protocol versions and the ciphers accepted for the connections
+	// +optional
+	SSL *SSLConfiguration `json:"ssl,omitempty"`
+
+	// The logging configuration of PostgreSQL, used to log the slow
+	// queries, the executed statements and the connections without
+	// setting the corresponding parameters one by one
+	// +optional
+	Logging *PostgresLoggingConfiguration `json:"logging,omitempty"`
+
+	// The huge pages configuration of PostgreSQL. When set, the operator
+	// requests in the instance pods the huge pages needed by the shared
+	// memory of PostgreSQL, which is computed from `shared_buffers`, and
+	// sets `huge_pages` accordingly
+	// +optional
+	HugePages *HugePagesConfiguration `json:"hugePages,omitempty"`
+
+	// The timeouts after which PostgreSQL terminates the idle sessions,
+	// so that the abandoned connections don't waste backend slots
+	// +optional
+	IdleSessions *IdleSessionsConfiguration `json:"idleSessions,omitempty"`
+
+	// The algorithm and the cost used by PostgreSQL to hash the passwords
+	// of the roles, including the managed ones. The passwords which are
+	// already stored are not hashed again until they are changed
+	// +optional
+	PasswordEncryption *PasswordEncryptionConfiguration `json:"passwordEncryption,omitempty"`
+
+	// When set to `true`, the generated `pg_hba.conf` rejects the connections
+	// not using TLS and authenticates the others with `scram-sha-256`, and the
+	// user-defined rules can only use the `scram-sha-256` and `reject`
+	// methods. This makes the SCRAM channel binding available to every
+	// password authentication, but PostgreSQL can't require it: the clients
+	// need to set `channel_binding=require`. The passwords must be hashed
+	// with SCRAM-SHA-256. Default: `false`.
+	// +optional
+	RequireSCRAMOverSSL bool `json:"requireSCRAMOverSSL,omitempty"`
+
+	// The configuration of the shutdown of PostgreSQL during the planned
+	// stops, such as the deletion of the pod or the restart of the instance
+	// +optional
+	Shutdown *ShutdownConfiguration `json:"shutdown,omitempty"`
+
+	// The configuration of the `ANALYZE` run by the instance manager on
+	// the primary after a major version upgrade or a recovery, when the
+	// statistics used by the query planner are missing or outdated.
+	// Enabled by default.
+	// +optional
+	AutomaticAnalyze *AutomaticAnalyzeConfiguration `json:"automaticAnalyze,omitempty"`
+
+	// Additional parameters appended to the `primary_conninfo` connection
+	// string used by the replicas to stream from the primary, such as
+	// `gssencmode` or `options`, overriding the ones set by the operator,
+	// i.e. `application_name`. The parameters defining the endpoint and the
+	// credentials, like `host`, `user` and the `ssl*` ones, can't be changed
+	// +optional
+	PrimaryConnInfoParameters map[string]string `json:"primaryConnInfoParameters,omitempty"`
+
+	// The most common tuning options of the autovacuum daemon, applied
+	// on top of the corresponding parameters
+	// +optional
+	Autovacuum *AutovacuumConfiguration `json:"autovacuum,omitempty"`
+}
+
+// AutomaticAnalyzeConfiguration contains the configuration of the `ANALYZE`
+// run after the transitions which leave the statistics outdated
+type AutomaticAnalyzeConfiguration struct {
+	// Whether the databases are analyzed after a major version upgrade
+	// or a recovery
+	// +optional
+	// +kubebuilder:default:=true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// When set to `true`, the statistics are collected with
+	// `vacuumdb --analyze-in-stages`, which produces usable statistics
+	// faster by running `ANALYZE` three times with an increasing
+	// statistics target. Default: `false`.
+	// +optional
+	InStages bool `json:"inStages,omitempty"`
+}
+
+// PasswordEncryptionMethod is the algorithm used to hash the passwords
+// +kubebuilder:validation:Enum="scram-sha-256";md5
+type PasswordEncryptionMethod string
+
+const (
+	// PasswordEncryptionMethodScramSHA256 hashes the passwords with SCRAM-SHA-256
+	PasswordEncryptionMethodScramSHA256 PasswordEncryptionMethod = "scram-sha-256"
+
+	// PasswordEncryptionMethodMD5 hashes the passwords with MD5, which
+	// is deprecated since PostgreSQL 18
+	PasswordEncryptionMethodMD5 PasswordEncryptionMethod = "md5"
+)
+
+// PasswordEncryptionConfiguration contains the settings used by
+// PostgreSQL to hash the passwords
+type PasswordEncryptionConfiguration struct {
+	// The algorithm used to hash the passwords, set as `password_encryption`.
+	// When not specified, the PostgreSQL default is used.
+	// +optional
+	Method PasswordEncryptionMethod `json:"method,omitempty"`
+
+	// The number of iterations used to compute the SCRAM-SHA-256 password
+	// hashes, set as `scram_iterations`. Requires PostgreSQL 16 or newer.
+	// When not specified, the PostgreSQL default is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ScramIterations *int32 `json:"scramIterations,omitempty"`
+}
+
+// ShutdownMode is the mode used by `pg_ctl stop` to shut down PostgreSQL
+// +kubebuilder:validation:Enum=smart;fast;immediate
+type ShutdownMode string
+
+const (
+	// ShutdownModeSmart waits for the clients to disconnect before
+	// shutting down PostgreSQL
+	ShutdownModeSmart ShutdownMode = "smart"
+
+	// ShutdownModeFast disconnects the clients and shuts down PostgreSQL
+	// cleanly
+	ShutdownModeFast ShutdownMode = "fast"
+
+	// ShutdownModeImmediate aborts every PostgreSQL process without a
+	// clean shutdown, requiring a crash recovery at the next start
+	ShutdownModeImmediate ShutdownMode = "immediate"
+)
+
+// ShutdownConfiguration contains the settings used to shut down PostgreSQL
+// during the planned stops
+type ShutdownConfiguration struct {
+	// The mode used to shut down PostgreSQL when the pod is deleted or the
+	// instance is restarted. With `smart` (default), PostgreSQL waits for the
+	// clients to disconnect for up to `smartShutdownTimeout` seconds, then a
+	// `fast` shutdown is requested. With `fast`, the clients are disconnected
+	// right away. With `immediate`, PostgreSQL is stopped without a clean
+	// shutdown and runs a crash recovery at the next start. The shutdowns
+	// requested by a switchover or by fencing are not affected, and still
+	// escalate from `fast` to `immediate`.
+	// +optional
+	Mode ShutdownMode `json:"mode,omitempty"`
+}
+
+// IdleSessionsConfiguration contains the timeouts after which PostgreSQL
+// terminates the sessions that are idle
+type IdleSessionsConfiguration struct {
+	// The time after which a session that is idle outside of a transaction
+	// is terminated, set as `idle_session_timeout`. A zero value disables
+	// the timeout. Requires PostgreSQL 14 or newer. When not specified,
+	// the PostgreSQL default is used.
+	// +optional
+	SessionTimeout *metav1.Duration `json:"sessionTimeout,omitempty"`
+
+	// The time after which a session that is idle within an open
+	// transaction is terminated, set as `idle_in_transaction_session_timeout`.
+	// A zero value disables the timeout. When not specified, the PostgreSQL
+	// default is used.
+	// +optional
+	InTransactionTimeout *metav1.Duration `json:"inTransactionTimeout,omitempty"`
+}
+
+// AutovacuumConfiguration contains the tuning options of the autovacuum
+// daemon. When an option is not specified, the corresponding parameter
+// keeps the value set in `parameters`, or the PostgreSQL default
+type AutovacuumConfiguration struct {
+	// The minimum delay between the autovacuum runs on any given
+	// database, set as `autovacuum_naptime`. It must be a whole number
+	// of seconds, ranging from `1s` to `2147483s`.
+	// +optional
+	Naptime *metav1.Duration `json:"naptime,omitempty"`
+
+	// The maximum number of autovacuum worker processes running at the
+	// same time, set as `autovacuum_max_workers`. Before PostgreSQL 18,
+	// changing it requires a restart of the instances.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=262143
+	// +optional
+	MaxWorkers *int32 `json:"maxWorkers,omitempty"`
+
+	// The cost delay of the automatic vacuum operations, set as
+	// `autovacuum_vacuum_cost_delay`, ranging from `0` to `100ms`
+	// +optional
+	VacuumCostDelay *metav1.Duration `json:"vacuumCostDelay,omitempty"`
+
+	// The cost limit of the automatic vacuum operations, set as
+	// `autovacuum_vacuum_cost_limit`, ranging from `1` to `10000`.
+	// The value `-1` uses the one of `vacuum_cost_limit`.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Maximum=10000
+	// +optional
+	VacuumCostLimit *int32 `json:"vacuumCostLimit,omitempty"`
+}
+
+// HugePagesConfiguration contains the settings used to run PostgreSQL
+// with its shared memory allocated in huge pages
+type HugePagesConfiguration struct {
+	// The size of the huge pages requested by the instance pods, which
+	// must be supported by the nodes, and set as `huge_page_size`.
+	// Defaults to `2Mi`. `1Gi` requires PostgreSQL 14 or newer.
+	// +kubebuilder:validation:Enum="2Mi";"1Gi"
+	// +optional
+	PageSize string `json:"pageSize,omitempty"`
+
+	// Whether PostgreSQL can start when the shared memory can't be
+	// allocated in huge pages, set as `huge_pages`: `try` (default)
+	// falls back to regular memory, while `on` prevents the start
+	// +kubebuilder:validation:Enum=try;on
+	// +optional
+	Mode HugePagesMode `json:"mode,omitempty"`
+}
+
+// HugePagesMode is the usage of the huge pages requested by PostgreSQL
+type HugePagesMode string
+
+const (
+	// HugePagesModeTry means that PostgreSQL falls back to regular memory
+	// when the huge pages can't be allocated
+	HugePagesModeTry HugePagesMode = "try"
+
+	// HugePagesModeOn means that PostgreSQL doesn't start when the huge
+	// pages can't be allocated
+	HugePagesModeOn HugePagesMode = "on"
+
+	// HugePagesModeOff means that PostgreSQL doesn't use huge pages. This
+	// is the mode used when the huge pages are not available on any node
+	HugePagesModeOff HugePagesMode = "off"
+)
+
+// PostgresLoggingConfiguration contains the typed logging settings
+// of PostgreSQL. The destination and the format of the logs are
+// managed by the operator and cannot be changed.
+type PostgresLoggingConfiguration struct {
+	// The minimum execution time of a statement for it to be logged,
+	// set as `log_min_duration_statement`, i.e. `500ms` or `2s`. A zero
+	// value logs all the statements. When not specified, the PostgreSQL
+	// default is used.
+	// +optional
+	SlowQueryThreshold *metav1.Duration `json:"slowQueryThreshold,omitempty"`
+
+	// The kind of SQL statements to be logged, set as `log_statement`.
+	// When not specified, the PostgreSQL default is used.
+	// +kubebuilder:validation:Enum=none;ddl;mod;all
+	// +optional
+	LogStatement StatementLoggingLevel `json:"logStatement,omitempty"`
+
+	// When set, each attempted connection to the server is logged,
+	// as well as the successful authentications, set as `log_connections`
+	// +optional
+	LogConnections *bool `json:"logConnections,omitempty"`
+
+	// When set, the end of each session is logged, together with its
+	// duration, set as `log_disconnections`
+	// +optional
+	LogDisconnections *bool `json:"logDisconnections,omitempty"`
+}
+
+// StatementLoggingLevel is the kind of SQL statements logged by PostgreSQL
+type StatementLoggingLevel string
+
+const (
+	// StatementLoggingLevelNone means that no statement is logged
+	StatementLoggingLevelNone StatementLoggingLevel = "none"
+
+	// StatementLoggingLevelDDL means that the data definition statements are logged
+	StatementLoggingLevelDDL StatementLoggingLevel = "ddl"
+
+	// StatementLoggingLevelMod means that the data definition and the data
+	// modifying statements are logged
+	StatementLoggingLevelMod StatementLoggingLevel = "mod"
+
+	// StatementLoggingLevelAll means that all the statements are logged
+	StatementLoggingLevelAll StatementLoggingLevel = "all"
+)
+
+// SSLConfiguration contains the settings used to harden the TLS
+// connections accepted by PostgreSQL
+type SSLConfiguration struct {
+	// The minimum TLS protocol version accepted by the server, set as
+	// `ssl_min_protocol_version`. Defaults to `TLSv1.3`.
+	// +kubebuilder:validation:Enum=TLSv1;TLSv1.1;TLSv1.2;TLSv1.3
+	// +optional
+	MinProtocolVersion TLSProtocolVersion `json:"minProtocolVersion,omitempty"`
+
+	// The maximum TLS protocol version accepted by the server, set as
+	// `ssl_max_protocol_version`. Defaults to `TLSv1.3`.
+	// +kubebuilder:validation:Enum=TLSv1;TLSv1.1;TLSv1.2;TLSv1.3
+	// +optional
+	MaxProtocolVersion TLSProtocolVersion `json:"maxProtocolVersion,omitempty"`
+
+	// The list of ciphers, in the OpenSSL format, allowed for the
+	// connections using TLS 1.2 or an older version, set as `ssl_ciphers`.
+	// When not specified, the PostgreSQL default is used.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Ciphers string `json:"ciphers,omitempty"`
+}
+
+// TLSProtocolVersion is a version of the TLS protocol, in the format
+// accepted by PostgreSQL
+type TLSProtocolVersion string
+
+const (
+	// TLSProtocolVersion10 is the TLS 1.0 protocol version
+	TLSProtocolVersion10 TLSProtocolVersion = "TLSv1"
+
+	// TLSProtocolVersion11 is the TLS 1.1 protocol version
+	TLSProtocolVersion11 TLSProtocolVersion = "TLSv1.1"
+
+	// TLSProtocolVersion12 is the TLS 1.2 protocol version
+	TLSProtocolVersion12 TLSProtocolVersion = "TLSv1.2"
+
+	// TLSProtocolVersion13 is the TLS 1.3 protocol version
+	TLSProtocolVersion13 TLSProtocolVersion = "TLSv1.3"
+)
+
+// CollationVersionMismatchPolicy is the action taken when an index depends
+// on a collation whose version has changed
+type CollationVersionMismatchPolicy string
+
+const (
+	// CollationVersionMismatchWarn means that the indexes depending on a
+	// collation whose version has changed are only logged (`warn`, default)
+	CollationVersionMismatchWarn CollationVersionMismatchPolicy = "warn"
+
+	// CollationVersionMismatchReindex means that the indexes depending on a
+	// collation whose version has changed are rebuilt (`reindex`)
+	CollationVersionMismatchReindex CollationVersionMismatchPolicy = "reindex"
+)
+
+// CollationReindexWindow restricts the rebuild of the indexes affected by a
+// collation version mismatch to recurring maintenance windows
+type CollationReindexWindow struct {
+	// The start of the maintenance windows. The schedule does not follow the
+	// same format used in Kubernetes CronJobs as it includes an additional
+	// seconds specifier,
+	// see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// The duration of each maintenance window. No index is rebuilt after the
+	// end of the window, while the one being rebuilt is completed.
+	// Default: `1h`.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // ExtensionConfiguration is the configuration used to add
@@ -1721,6 +2758,11 @@ type BootstrapInitDB struct {
 	// +optional
 	Import *Import `json:"import,omitempty"`
 
+	// Bootstraps the new cluster by restoring into the application database
+	// a logical dump, produced by `pg_dump`, that is stored in an object store
+	// +optional
+	ImportDump *ImportDump `json:"importDump,omitempty"`
+
 	// List of references to ConfigMaps or Secrets containing SQL files
 	// to be executed as a superuser in the application database right after
 	// the cluster has been created. The references are processed in a specific order:
@@ -1844,6 +2886,49 @@ type Import struct {
 	PgRestorePostdataOptions []string `json:"pgRestorePostdataOptions,omitempty"`
 }
 
+// ImportDumpFormat is the format of a logical dump produced by `pg_dump`
+type ImportDumpFormat string
+
+const (
+	// ImportDumpFormatPlain is a plain-text SQL script, restored with `psql`
+	ImportDumpFormatPlain ImportDumpFormat = "plain"
+
+	// ImportDumpFormatCustom is a custom-format archive (`pg_dump -Fc`),
+	// restored with `pg_restore`
+	ImportDumpFormatCustom ImportDumpFormat = "custom"
+)
+
+// ImportDump contains the configuration to init the application database
+// from a logical dump stored in an object store
+type ImportDump struct {
+	// The source of the dump. The referenced externalCluster must have a
+	// `barmanObjectStore` section pointing to the bucket that contains it
+	Source ImportSource `json:"source"`
+
+	// The path of the dump, relative to the `destinationPath` of the
+	// object store
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// The format of the dump, `plain` or `custom`. When not specified,
+	// a file with the `.sql` extension is restored as a plain-text SQL
+	// script, and any other file as a custom-format archive
+	// +kubebuilder:validation:Enum=plain;custom
+	// +optional
+	Format ImportDumpFormat `json:"format,omitempty"`
+
+	// List of custom options to pass to the `pg_restore` command. Only
+	// used with `custom` format dumps.
+	//
+	// IMPORTANT: Use with caution. The operator does not validate these options,
+	// and certain flags may interfere with its intended functionality or design.
+	// You are responsible for ensuring that the provided options are compatible
+	// with your environment and desired behavior.
+	//
+	// +optional
+	PgRestoreExtraOptions []string `json:"pgRestoreExtraOptions,omitempty"`
+}
+
 // ImportSource describes the source for the logical snapshot
 type ImportSource struct {
 	// The name of the externalCluster used for import
@@ -1865,6 +2950,21 @@ type SQLRefs struct {
 	ConfigMapRefs []ConfigMapKeySelector `json:"configMapRefs,omitempty"`
 }
 
+// AuthenticationRulesRefs holds references to ConfigMaps or Secrets
+// containing authentication rules, one per line. The references are
+// processed in a specific order: first, all Secrets are processed,
+// followed by all ConfigMaps. Within each group, the processing order
+// follows the sequence specified in their respective arrays.
+type AuthenticationRulesRefs struct {
+	// SecretRefs holds a list of references to Secrets
+	// +optional
+	SecretRefs []SecretKeySelector `json:"secretRefs,omitempty"`
+
+	// ConfigMapRefs holds a list of references to ConfigMaps
+	// +optional
+	ConfigMapRefs []ConfigMapKeySelector `json:"configMapRefs,omitempty"`
+}
+
 // BootstrapRecovery contains the configuration required to restore
 // from an existing cluster using 3 methodologies: external cluster,
 // volume snapshots or backup objects. Full recovery and Point-In-Time
@@ -1922,6 +3022,14 @@ type BootstrapRecovery struct {
 	// created from scratch
 	// +optional
 	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// PostgreSQL configuration parameters applied only while the
+	// recovery job replays the WAL files, such as `max_parallel_workers`,
+	// `maintenance_work_mem` or `recovery_prefetch`. They take precedence
+	// over the ones in `.spec.postgresql.parameters`, and are replaced
+	// by them once the cluster is promoted and the instances start.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // DataSource contains the configuration required to bootstrap a
@@ -2046,8 +3154,16 @@ type TablespaceConfiguration struct {
 	// The name of the tablespace
 	Name string `json:"name"`
 
-	// The storage configuration for the tablespace
-	Storage StorageConfiguration `json:"storage"`
+	// The storage configuration for the tablespace, required unless
+	// the tablespace is ephemeral
+	// +optional
+	Storage StorageConfiguration `json:"storage,omitempty"`
+
+	// When set, the tablespace is stored on an ephemeral volume instead
+	// of a persistent volume claim, and its content is lost when the Pod
+	// is recreated. Only allowed for temporary tablespaces.
+	// +optional
+	Ephemeral *EphemeralTablespaceStorage `json:"ephemeral,omitempty"`
 
 	// Owner is the PostgreSQL user owning the tablespace
 	// +optional
@@ -2062,6 +3178,20 @@ type TablespaceConfiguration struct {
 	Temporary bool `json:"temporary,omitempty"`
 }
 
+// EphemeralTablespaceStorage is the ephemeral volume hosting a
+// temporary tablespace. When no volume source is specified, an
+// `emptyDir` volume is used.
+type EphemeralTablespaceStorage struct {
+	// An `emptyDir` volume, stored on the node running the Pod
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// The template of the generic ephemeral volume to be used, allowing
+	// a specific storage class to be selected
+	// +optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+}
+
 // DatabaseRoleRef is a reference an a role available inside PostgreSQL
 type DatabaseRoleRef struct {
 	// +optional
@@ -2132,6 +3262,72 @@ type AffinityConfiguration struct {
 	AdditionalPodAffinity *corev1.PodAffinity `json:"additionalPodAffinity,omitempty"`
 }
 
+const (
+	// DefaultTopologySpreadKey is the failure domain the instances are
+	// spread across when no topology key is specified
+	DefaultTopologySpreadKey = "topology.kubernetes.io/zone"
+
+	// DefaultTopologyMaxSkew is the maximum skew of the generated topology
+	// spread constraints when not specified
+	DefaultTopologyMaxSkew = 1
+)
+
+// TopologyConfiguration contains the info we need to generate the
+// topology spread constraints of the instance pods
+type TopologyConfiguration struct {
+	// The node labels identifying the failure domains the instances are spread
+	// across, such as `topology.kubernetes.io/zone` or `kubernetes.io/hostname`.
+	// A topology spread constraint is generated for every key.
+	// Defaults to `topology.kubernetes.io/zone`.
+	// +listType=set
+	// +optional
+	TopologyKeys []string `json:"topologyKeys,omitempty"`
+
+	// The maximum difference between the number of instances in any two
+	// failure domains. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+
+	// How to deal with an instance that cannot be scheduled without violating
+	// the maximum skew: "DoNotSchedule" (default) keeps it pending, while
+	// "ScheduleAnyway" schedules it preferring the failure domains that
+	// minimize the skew.
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// WALArchivingConfiguration contains the configuration of the WAL
+// archiving process
+type WALArchivingConfiguration struct {
+	// Pauses the WAL archiving: the WAL files are retained in the volume of
+	// the primary while the archiving is paused, and archived once it is
+	// resumed
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// The amount of WAL expected to be retained by the primary while the
+	// archiving is paused. The archiving is only paused, and stays paused,
+	// while the free space in the volume holding the WAL files is larger
+	// than this. Required when the archiving is paused.
+	// +optional
+	ExpectedBacklog *resource.Quantity `json:"expectedBacklog,omitempty"`
+
+	// A shell command archiving a WAL file, run instead of barman-cloud.
+	// `%p` is replaced by the path of the WAL file, `%f` by its name and
+	// `%%` by a percent sign. Requires the restore command.
+	// +optional
+	ArchiveCommand string `json:"archiveCommand,omitempty"`
+
+	// A shell command restoring a WAL file, run instead of barman-cloud.
+	// `%f` is replaced by the name of the WAL file, `%p` by the path where
+	// it must be copied and `%%` by a percent sign. Requires the archive
+	// command.
+	// +optional
+	RestoreCommand string `json:"restoreCommand,omitempty"`
+}
+
 // BackupTarget describes the preferred targets for a backup
 type BackupTarget string
 
@@ -2176,6 +3372,40 @@ type BackupConfiguration struct {
 	// +kubebuilder:default:=prefer-standby
 	// +optional
 	Target BackupTarget `json:"target,omitempty"`
+
+	// The command executed by the instance manager after every completed
+	// backup, whatever its method, receiving the metadata of the backup in
+	// its environment. A failure of the command is reported, but doesn't
+	// mark the backup as failed.
+	// +optional
+	PostBackupHook *BackupHookConfiguration `json:"postBackupHook,omitempty"`
+
+	// Resources requirements of the jobs restoring a backup while creating
+	// an instance, like the full recovery and the snapshot recovery ones.
+	// When not specified, the resources of the instances are used.
+	// The backups and the WAL archiving are executed by the instance manager
+	// inside the PostgreSQL container, and use the resources of the instances.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DefaultBackupHookTimeout is the default time a backup hook is allowed
+// to run for
+const DefaultBackupHookTimeout = 5 * time.Minute
+
+// BackupHookConfiguration contains the configuration of a command executed
+// by the instance manager taking a backup
+type BackupHookConfiguration struct {
+	// The command to be executed, without a shell: the first element is the
+	// executable, which must be available in the container of the instance,
+	// and the other ones are its arguments
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// The time the command is allowed to run for, after which it is killed.
+	// It must be greater than zero. Defaults to 5 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // MonitoringConfiguration is the type containing all the monitoring
@@ -2222,6 +3452,91 @@ type MonitoringConfiguration struct {
 	// you need this functionality, you can create a PodMonitor manually.
 	// +optional
 	PodMonitorRelabelConfigs []monitoringv1.RelabelConfig `json:"podMonitorRelabelings,omitempty"`
+
+	// The interval at which Prometheus scrapes the metrics of the instances
+	// through the `PodMonitor`, i.e. `30s` or `2m`. When empty, the global
+	// scrape interval of Prometheus is used.
+	// +optional
+	PodMonitorInterval *metav1.Duration `json:"podMonitorInterval,omitempty"`
+
+	// The timeout after which Prometheus considers the scrape of the
+	// metrics of an instance to be failed. It cannot be greater than the
+	// scrape interval. When empty, the global scrape timeout of Prometheus
+	// is used.
+	// +optional
+	PodMonitorScrapeTimeout *metav1.Duration `json:"podMonitorScrapeTimeout,omitempty"`
+
+	// The percentage of `autovacuum_freeze_max_age` (and of
+	// `autovacuum_multixact_freeze_max_age` for multixact IDs) that the
+	// age of the oldest unfrozen transaction ID of any database must
+	// reach before the `TransactionIDAge` condition reports a
+	// warning. Default: 90.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WraparoundWarningThreshold *int32 `json:"wraparoundWarningThreshold,omitempty"`
+
+	// The configuration of the estimate of the space used by the dead
+	// tuples of the biggest tables, exposed by the primary. Disabled by
+	// default, as it requires querying every database at each scrape
+	// +optional
+	TableBloatEstimate *TableBloatEstimateConfiguration `json:"tableBloatEstimate,omitempty"`
+
+	// Enable or disable the `PrometheusRule` containing the default alerts
+	// of the cluster, about the replication lag, the age of the last
+	// backup, the WAL retained by the replication slots, the age of the
+	// transaction IDs and the expiration of the certificates.
+	// Requires the Prometheus Operator to be installed.
+	// +kubebuilder:default:=false
+	// +optional
+	EnableDefaultAlerts bool `json:"enableDefaultAlerts,omitempty"`
+
+	// The thresholds of the default alerts, overriding the default ones
+	// +optional
+	DefaultAlertsThresholds *DefaultAlertsThresholds `json:"defaultAlertsThresholds,omitempty"`
+}
+
+// DefaultAlertsThresholds contains the thresholds above which the default
+// alerts of the cluster are raised
+type DefaultAlertsThresholds struct {
+	// The replication lag of a standby, i.e. `5m`. Default: `5m`.
+	// +optional
+	ReplicationLag *metav1.Duration `json:"replicationLag,omitempty"`
+
+	// The age of the last available backup, i.e. `25h`. Default: `25h`.
+	// +optional
+	BackupAge *metav1.Duration `json:"backupAge,omitempty"`
+
+	// The amount of WAL retained by a replication slot, i.e. `10Gi`.
+	// Default: `10Gi`.
+	// +optional
+	ReplicationSlotRetainedWAL *resource.Quantity `json:"replicationSlotRetainedWAL,omitempty"`
+
+	// The age of the oldest unfrozen transaction ID of any database.
+	// Default: 300000000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TransactionIDAge *int64 `json:"transactionIDAge,omitempty"`
+
+	// How long before the expiration of a certificate the alert is
+	// raised, i.e. `168h`. Default: `168h`.
+	// +optional
+	CertificateExpiration *metav1.Duration `json:"certificateExpiration,omitempty"`
+}
+
+// TableBloatEstimateConfiguration contains the configuration of the
+// estimate of the bloat of the tables
+type TableBloatEstimateConfiguration struct {
+	// Whether the bloat of the tables is estimated. Default: `false`.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The number of tables, chosen as the biggest ones across all the
+	// databases, whose bloat is estimated. Default: 20.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	MaxTables *int32 `json:"maxTables,omitempty"`
 }
 
 // ClusterMonitoringTLSConfiguration is the type containing the TLS configuration
@@ -2320,11 +3635,39 @@ type ManagedServices struct {
 	// Valid values are "r", and "ro", representing read, and read-only services.
 	// +optional
 	DisabledDefaultServices []ServiceSelectorType `json:"disabledDefaultServices,omitempty"`
+	// DefaultServices is a list of customizations applied to the default
+	// services, allowing their type, labels and annotations to be changed.
+	// The selector and the ports remain managed by the operator.
+	// +optional
+	DefaultServices []DefaultServiceTemplate `json:"defaultServices,omitempty"`
 	// Additional is a list of additional managed services specified by the user.
 	// +optional
 	Additional []ManagedService `json:"additional,omitempty"`
 }
 
+// DefaultServiceTemplate represents the customization of one of the
+// default services created by the operator for a cluster.
+type DefaultServiceTemplate struct {
+	// SelectorType specifies the default service to be customized.
+	// Valid values are "rw", "r", and "ro", representing read-write, read, and read-only services.
+	SelectorType ServiceSelectorType `json:"selectorType"`
+
+	// UpdateStrategy describes how the service differences should be reconciled
+	// +kubebuilder:default:="patch"
+	// +optional
+	UpdateStrategy ServiceUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Labels and annotations to be added to the service. The name
+	// is reserved for the operator and cannot be set.
+	// +optional
+	ObjectMeta Metadata `json:"metadata,omitempty"`
+
+	// Type is the type of the service. Defaults to `ClusterIP`.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+}
+
 // ManagedService represents a specific service managed by the cluster.
 // It includes the type of service and its associated template specification.
 type ManagedService struct {
@@ -2350,6 +3693,35 @@ type ManagedConfiguration struct {
 	// Services roles managed by the `Cluster`
 	// +optional
 	Services *ManagedServices `json:"services,omitempty"`
+	// NetworkPolicy restricting the traffic of the instance pods
+	// +optional
+	NetworkPolicy *ManagedNetworkPolicy `json:"networkPolicy,omitempty"`
+}
+
+// ManagedNetworkPolicy configures the NetworkPolicy generated by the
+// operator to restrict the traffic of the instance pods. The traffic
+// between the instances of the cluster is always allowed
+type ManagedNetworkPolicy struct {
+	// Enabled controls the generation of the NetworkPolicy
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The namespaces, selected by their labels, whose pods are allowed to
+	// connect to PostgreSQL. When not specified, only the pods in the
+	// namespace of the cluster are allowed to connect
+	// +optional
+	ApplicationNamespaceSelector *metav1.LabelSelector `json:"applicationNamespaceSelector,omitempty"`
+
+	// Rules allowing additional traffic to the instance pods, on top of
+	// the ones generated by the operator
+	// +optional
+	AdditionalIngress []networkingv1.NetworkPolicyIngressRule `json:"additionalIngress,omitempty"`
+
+	// Rules allowing additional traffic from the instance pods, on top of
+	// the ones generated by the operator
+	// +optional
+	AdditionalEgress []networkingv1.NetworkPolicyEgressRule `json:"additionalEgress,omitempty"`
 }
 
 // PluginConfiguration specifies a plugin that need to be loaded for this
@@ -2592,6 +3964,12 @@ type SecretsResourceVersion struct {
 	// Map keys are the secret names, map values are the versions
 	// +optional
 	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// A map with the versions of all the secrets containing the
+	// pg_hba.conf and pg_ident.conf rules.
+	// Map keys are the secret names, map values are the versions
+	// +optional
+	AuthenticationRules map[string]string `json:"authenticationRules,omitempty"`
 }
 
 // ConfigMapResourceVersion is the resource versions of the secrets
@@ -2601,6 +3979,12 @@ type ConfigMapResourceVersion struct {
 	// Map keys are the config map names, map values are the versions
 	// +optional
 	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// A map with the versions of all the config maps containing the
+	// pg_hba.conf and pg_ident.conf rules.
+	// Map keys are the config map names, map values are the versions
+	// +optional
+	AuthenticationRules map[string]string `json:"authenticationRules,omitempty"`
 }
 
 func init() {
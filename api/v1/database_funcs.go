@@ -88,3 +88,21 @@ func (dbObject DatabaseObjectSpec) GetEnsure() EnsureOption {
 func (dbObject DatabaseObjectSpec) GetName() string {
 	return dbObject.Name
 }
+
+// GetUserMappingsSecretNames returns the names of the secrets containing
+// the credentials of the user mappings which should be present
+func (db *Database) GetUserMappingsSecretNames() []string {
+	var result []string
+	for _, server := range db.Spec.Servers {
+		if server.Ensure == EnsureAbsent {
+			continue
+		}
+		for _, userMapping := range server.UserMappings {
+			if userMapping.Ensure == EnsureAbsent || userMapping.CredentialsSecret == nil {
+				continue
+			}
+			result = append(result, userMapping.CredentialsSecret.Name)
+		}
+	}
+	return result
+}
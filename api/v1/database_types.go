@@ -52,6 +52,40 @@ const (
 	RevokeUsageSpecType UsageSpecType = "revoke"
 )
 
+// SchemaPrivilege is a privilege which can be granted on a schema
+// +kubebuilder:validation:Enum=USAGE;CREATE
+type SchemaPrivilege string
+
+const (
+	// SchemaPrivilegeUsage allows the lookup of the objects in the schema
+	SchemaPrivilegeUsage SchemaPrivilege = "USAGE"
+
+	// SchemaPrivilegeCreate allows the creation of new objects in the schema
+	SchemaPrivilegeCreate SchemaPrivilege = "CREATE"
+)
+
+// DatabasePrivilege is a privilege which can be granted on a database
+// +kubebuilder:validation:Enum=CONNECT;TEMPORARY;CREATE
+type DatabasePrivilege string
+
+const (
+	// DatabasePrivilegeConnect allows the connection to the database
+	DatabasePrivilegeConnect DatabasePrivilege = "CONNECT"
+
+	// DatabasePrivilegeTemporary allows the creation of temporary tables
+	// while using the database
+	DatabasePrivilegeTemporary DatabasePrivilege = "TEMPORARY"
+
+	// DatabasePrivilegeCreate allows the creation of new schemas in
+	// the database
+	DatabasePrivilegeCreate DatabasePrivilege = "CREATE"
+)
+
+// TablePrivilege is a privilege which can be granted on the tables
+// contained in a schema
+// +kubebuilder:validation:Enum=SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER
+type TablePrivilege string
+
 // DatabaseSpec is the specification of a Postgresql Database, built around the
 // `CREATE DATABASE`, `ALTER DATABASE`, and `DROP DATABASE` SQL commands of
 // PostgreSQL.
@@ -174,6 +208,13 @@ type DatabaseSpec struct {
 	// +optional
 	Tablespace string `json:"tablespace,omitempty"`
 
+	// The timeouts set on the database with `ALTER DATABASE ... SET`,
+	// overriding the ones of the cluster for the sessions connected to it.
+	// When specified, the timeouts not listed are reset to the value
+	// of the cluster.
+	// +optional
+	Timeouts *DatabaseTimeoutsConfiguration `json:"timeouts,omitempty"`
+
 	// The policy for end-of-life maintenance of this database.
 	// +kubebuilder:validation:Enum=delete;retain
 	// +kubebuilder:default:=retain
@@ -195,6 +236,22 @@ type DatabaseSpec struct {
 	// The list of foreign servers to be managed in the database
 	// +optional
 	Servers []ServerSpec `json:"servers,omitempty"`
+
+	// The list of existing tables whose storage parameters, such as the
+	// per-table autovacuum settings, are to be managed in the database
+	// +optional
+	Tables []TableSpec `json:"tables,omitempty"`
+
+	// The list of roles for which privileges on the database are managed
+	// +optional
+	Privileges []DatabasePrivilegeSpec `json:"privileges,omitempty"`
+
+	// When set to `true`, the privileges on the database directly granted
+	// to the roles listed in `privileges`, and not requested there, are
+	// revoked. By default, the missing privileges are granted, and the
+	// other ones are left untouched.
+	// +optional
+	RevokeUnlistedPrivileges bool `json:"revokeUnlistedPrivileges,omitempty"`
 }
 
 // DatabaseObjectSpec contains the fields which are common to every
@@ -222,6 +279,48 @@ type SchemaSpec struct {
 	// It maps to the `AUTHORIZATION` parameter of `CREATE SCHEMA` and the
 	// `OWNER TO` command of `ALTER SCHEMA`.
 	Owner string `json:"owner,omitempty"`
+
+	// The list of roles for which privileges on the schema, and on the
+	// tables it contains, are managed
+	// +optional
+	Privileges []SchemaPrivilegeSpec `json:"privileges,omitempty"`
+}
+
+// SchemaPrivilegeSpec configures the privileges of a role on a schema
+// and on its tables. The privileges held by the role and not listed
+// here are revoked.
+type SchemaPrivilegeSpec struct {
+	// Name of the role the privileges are granted to
+	// +kubebuilder:validation:XValidation:rule="self != ''",message="role is required"
+	Role string `json:"role"`
+
+	// The privileges of the role on the schema
+	// +optional
+	Schema []SchemaPrivilege `json:"schema,omitempty"`
+
+	// The privileges of the role on the tables, views, materialized views
+	// and foreign tables of the schema. They are applied to the existing
+	// tables and, via `ALTER DEFAULT PRIVILEGES`, to the ones that the
+	// owner of the schema will create.
+	// +optional
+	Tables []TablePrivilege `json:"tables,omitempty"`
+
+	// When set to `true`, the privileges on the tables already existing
+	// in the schema are left untouched, and only the default privileges
+	// are reconciled
+	// +optional
+	SkipExistingTables bool `json:"skipExistingTables,omitempty"`
+}
+
+// DatabasePrivilegeSpec configures the privileges of a role on the database
+type DatabasePrivilegeSpec struct {
+	// Name of the role the privileges are granted to
+	// +kubebuilder:validation:XValidation:rule="self != ''",message="role is required"
+	Role string `json:"role"`
+
+	// The privileges of the role on the database
+	// +optional
+	Privileges []DatabasePrivilege `json:"privileges,omitempty"`
 }
 
 // ExtensionSpec configures an extension in a database
@@ -290,6 +389,79 @@ type ServerSpec struct {
 	// List of roles for which `USAGE` privileges on the server are granted or revoked.
 	// +optional
 	Usages []UsageSpec `json:"usage,omitempty"`
+
+	// The list of user mappings defined for the server, managed
+	// through the `CREATE USER MAPPING`, `ALTER USER MAPPING` and
+	// `DROP USER MAPPING` commands.
+	// +optional
+	UserMappings []UserMappingSpec `json:"userMappings,omitempty"`
+}
+
+// UserMappingSpec configures the mapping of a local role to a
+// foreign server
+type UserMappingSpec struct {
+	// The name of the local role being mapped. Use `PUBLIC` to
+	// define a mapping for every role without a specific one.
+	// +kubebuilder:validation:XValidation:rule="self != ''",message="user is required"
+	User string `json:"user"`
+
+	// Specifies whether the user mapping should be present or absent in
+	// the server. If set to `present`, the user mapping will be
+	// created if it does not exist. If set to `absent`, the user mapping
+	// will be removed if it exists.
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// The reference to a secret of type `kubernetes.io/basic-auth`, in the
+	// same namespace of the Database, containing the credentials used to
+	// authenticate to the foreign server. The `username` and `password`
+	// keys are mapped, respectively, to the `user` and `password` options.
+	// Changes of the secret content are applied to the user mapping.
+	// +optional
+	CredentialsSecret *LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Options specifies the configuration options for the user mapping
+	// (key is the option name, value is the option value).
+	// +optional
+	Options []OptionSpec `json:"options,omitempty"`
+}
+
+// DatabaseTimeoutsConfiguration contains the timeouts set on a database.
+// A zero value disables the corresponding timeout.
+type DatabaseTimeoutsConfiguration struct {
+	// The maximum duration of a statement, set as `statement_timeout`
+	// +optional
+	StatementTimeout *metav1.Duration `json:"statementTimeout,omitempty"`
+
+	// The maximum time waited to acquire a lock, set as `lock_timeout`
+	// +optional
+	LockTimeout *metav1.Duration `json:"lockTimeout,omitempty"`
+
+	// The time after which a session that is idle within an open
+	// transaction is terminated, set as `idle_in_transaction_session_timeout`
+	// +optional
+	IdleInTransactionSessionTimeout *metav1.Duration `json:"idleInTransactionSessionTimeout,omitempty"`
+}
+
+// TableSpec configures the storage parameters of an existing table.
+// The table itself is not created nor dropped by the operator.
+type TableSpec struct {
+	// Name of the table
+	// +kubebuilder:validation:XValidation:rule="self != ''",message="name is required"
+	Name string `json:"name"`
+
+	// Schema of the table
+	// +kubebuilder:default:="public"
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// The storage parameters of the table, set with `ALTER TABLE ... SET`
+	// when present and reset to their default when absent, such as
+	// `autovacuum_vacuum_scale_factor` or `toast.autovacuum_enabled`
+	// +optional
+	StorageParameters []OptionSpec `json:"storageParameters,omitempty"`
 }
 
 // OptionSpec holds the name, value and the ensure field for an option
@@ -353,6 +525,21 @@ type DatabaseStatus struct {
 	// Servers is the status of the managed servers
 	// +optional
 	Servers []DatabaseObjectStatus `json:"servers,omitempty"`
+
+	// UserMappings is the status of the managed user mappings,
+	// named after the server and the user (e.g. `server/user`)
+	// +optional
+	UserMappings []DatabaseObjectStatus `json:"userMappings,omitempty"`
+
+	// Tables is the status of the managed tables, named after
+	// the schema and the table (e.g. `schema.table`)
+	// +optional
+	Tables []DatabaseObjectStatus `json:"tables,omitempty"`
+
+	// The resource version of the secrets used by the user mappings,
+	// as it was when the Database was last reconciled
+	// +optional
+	SecretsResourceVersion map[string]string `json:"secretsResourceVersion,omitempty"`
 }
 
 // DatabaseObjectStatus is the status of the managed database objects
@@ -26,6 +26,15 @@ func (in PgBouncerSpec) IsPaused() bool {
 	return in.Paused != nil && *in.Paused
 }
 
+// GetDBName returns the name of the PostgreSQL database the connections
+// are forwarded to
+func (in PgBouncerDatabase) GetDBName() string {
+	if in.DBName != "" {
+		return in.DBName
+	}
+	return in.Name
+}
+
 // GetAuthQuerySecretName returns the specified AuthQuerySecret name for PgBouncer
 // if provided or the default name otherwise.
 func (in *Pooler) GetAuthQuerySecretName() string {
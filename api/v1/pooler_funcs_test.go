@@ -37,4 +37,9 @@ var _ = Describe("Pooler type tests", func() {
 		}
 		Expect(pgbouncer.IsPaused()).To(BeTrue())
 	})
+
+	It("pgbouncer databases forward to the database with the same name by default", func() {
+		Expect(PgBouncerDatabase{Name: "app_session"}.GetDBName()).To(Equal("app_session"))
+		Expect(PgBouncerDatabase{Name: "app_session", DBName: "app"}.GetDBName()).To(Equal("app"))
+	})
 })
@@ -182,6 +182,14 @@ type PgBouncerSpec struct {
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
 
+	// Additional databases exposed by PgBouncer, each one with its own
+	// pooling settings. They allow the same Pooler to serve the same
+	// PostgreSQL database in different pool modes, under different names
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []PgBouncerDatabase `json:"databases,omitempty"`
+
 	// PostgreSQL Host Based Authentication rules (lines to be appended
 	// to the pg_hba.conf file)
 	// +optional
@@ -196,6 +204,40 @@ type PgBouncerSpec struct {
 	Paused *bool `json:"paused,omitempty"`
 }
 
+// PgBouncerDatabase defines a database exposed by PgBouncer, forwarding
+// the connections to a database of the cluster with its own pooling settings
+type PgBouncerDatabase struct {
+	// The name of the database the clients connect to. It cannot be
+	// `pgbouncer`, which is reserved to the PgBouncer admin console
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_\-]*$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// The name of the PostgreSQL database the connections are forwarded
+	// to. Default: the value of `name`.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_\-]*$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	DBName string `json:"dbname,omitempty"`
+
+	// The pool mode used for this database. Default: the pool mode
+	// of the Pooler.
+	// +optional
+	PoolMode PgBouncerPoolMode `json:"poolMode,omitempty"`
+
+	// The maximum number of server connections for each user of this database.
+	// Default: the `default_pool_size` parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize *int32 `json:"poolSize,omitempty"`
+
+	// The maximum number of server connections to this database, across
+	// every user. Default: the `max_db_connections` parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDBConnections *int32 `json:"maxDBConnections,omitempty"`
+}
+
 // PoolerStatus defines the observed state of Pooler
 type PoolerStatus struct {
 	// The resource version of the config object
@@ -20,12 +20,30 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"maps"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
+const (
+	// DefaultScheduledBackupRetryBackoff is the default time to wait
+	// before retrying a failed scheduled backup
+	DefaultScheduledBackupRetryBackoff = 5 * time.Minute
+
+	// DefaultScheduledBackupMaxRetryBackoff is the default maximum time
+	// to wait before retrying a failed scheduled backup
+	DefaultScheduledBackupMaxRetryBackoff = time.Hour
+
+	// DefaultScheduledBackupFailureThreshold is the default number of
+	// consecutive failed backups after which a scheduled backup is
+	// reported as failing
+	DefaultScheduledBackupFailureThreshold = 3
+)
+
 // IsSuspended check if a scheduled backup has been suspended or not
 func (scheduledBackup ScheduledBackup) IsSuspended() bool {
 	if scheduledBackup.Spec.Suspend == nil {
@@ -90,5 +108,70 @@ func (scheduledBackup *ScheduledBackup) CreateBackup(name string) *Backup {
 		backup.Annotations[utils.BackupVolumeSnapshotDeadlineAnnotationName] = v
 	}
 
+	if template := scheduledBackup.Spec.BackupTemplate; template != nil {
+		if len(template.ObjectMeta.Labels) > 0 {
+			backup.Labels = make(map[string]string, len(template.ObjectMeta.Labels))
+			maps.Copy(backup.Labels, template.ObjectMeta.Labels)
+		}
+		maps.Copy(backup.Annotations, template.ObjectMeta.Annotations)
+	}
+
 	return &backup
 }
+
+// ShouldInheritClusterMetadata checks if the Backup objects created by this
+// scheduled backup should inherit the cluster's `inheritedMetadata`
+func (scheduledBackup *ScheduledBackup) ShouldInheritClusterMetadata() bool {
+	return scheduledBackup.Spec.BackupTemplate != nil &&
+		scheduledBackup.Spec.BackupTemplate.InheritClusterMetadata
+}
+
+// GetMaxRetries gets the maximum number of times a failed backup is retried
+func (scheduledBackup *ScheduledBackup) GetMaxRetries() int {
+	if scheduledBackup.Spec.FailurePolicy == nil {
+		return 0
+	}
+
+	return scheduledBackup.Spec.FailurePolicy.MaxRetries
+}
+
+// GetRetryBackoff gets the time to wait after a failed backup before
+// retrying it, given the number of retries already done. The time
+// doubles at every retry, up to the maximum backoff
+func (scheduledBackup *ScheduledBackup) GetRetryBackoff(retries int) time.Duration {
+	backoff := DefaultScheduledBackupRetryBackoff
+	maxBackoff := DefaultScheduledBackupMaxRetryBackoff
+	if policy := scheduledBackup.Spec.FailurePolicy; policy != nil {
+		if policy.Backoff != nil {
+			backoff = policy.Backoff.Duration
+		}
+		if policy.MaxBackoff != nil {
+			maxBackoff = policy.MaxBackoff.Duration
+		}
+	}
+
+	for range retries {
+		if backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// GetFailureThreshold gets the number of consecutive failed backups after
+// which the scheduled backup is reported as failing
+func (scheduledBackup *ScheduledBackup) GetFailureThreshold() int {
+	if scheduledBackup.Spec.FailurePolicy == nil || scheduledBackup.Spec.FailurePolicy.FailureThreshold <= 0 {
+		return DefaultScheduledBackupFailureThreshold
+	}
+
+	return scheduledBackup.Spec.FailurePolicy.FailureThreshold
+}
+
+// IsFailing checks whether the number of consecutive failed backups
+// reached the failure threshold
+func (scheduledBackup *ScheduledBackup) IsFailing() bool {
+	return scheduledBackup.Status.ConsecutiveFailures >= scheduledBackup.GetFailureThreshold()
+}
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -84,4 +86,78 @@ var _ = Describe("Scheduled backup", func() {
 		Expect(backup.ObjectMeta.Name).To(BeEquivalentTo(backupName))
 		Expect(backup.Spec.Target).To(BeEquivalentTo(BackupTargetPrimary))
 	})
+
+	It("applies the labels and annotations of the backup template", func() {
+		scheduledBackup.Spec.BackupTemplate = &ScheduledBackupTemplate{
+			ObjectMeta: EmbeddedObjectMetadata{
+				Labels:      map[string]string{"team": "dba"},
+				Annotations: map[string]string{"policy": "compliant"},
+			},
+		}
+		backup := scheduledBackup.CreateBackup("test")
+		Expect(backup).ToNot(BeNil())
+		Expect(backup.Labels).To(HaveKeyWithValue("team", "dba"))
+		Expect(backup.Annotations).To(HaveKeyWithValue("policy", "compliant"))
+	})
+
+	It("does not share the template maps with the generated backup", func() {
+		scheduledBackup.Spec.BackupTemplate = &ScheduledBackupTemplate{
+			ObjectMeta: EmbeddedObjectMetadata{
+				Labels: map[string]string{"team": "dba"},
+			},
+		}
+		backup := scheduledBackup.CreateBackup("test")
+		backup.Labels["other"] = "value"
+		Expect(scheduledBackup.Spec.BackupTemplate.ObjectMeta.Labels).ToNot(HaveKey("other"))
+	})
+
+	It("inherits the cluster metadata only when requested", func() {
+		Expect(scheduledBackup.ShouldInheritClusterMetadata()).To(BeFalse())
+
+		scheduledBackup.Spec.BackupTemplate = &ScheduledBackupTemplate{}
+		Expect(scheduledBackup.ShouldInheritClusterMetadata()).To(BeFalse())
+
+		scheduledBackup.Spec.BackupTemplate.InheritClusterMetadata = true
+		Expect(scheduledBackup.ShouldInheritClusterMetadata()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Scheduled backup failure policy", func() {
+	It("uses the defaults without a failure policy", func() {
+		scheduledBackup := &ScheduledBackup{}
+		Expect(scheduledBackup.GetMaxRetries()).To(BeZero())
+		Expect(scheduledBackup.GetRetryBackoff(0)).To(Equal(DefaultScheduledBackupRetryBackoff))
+		Expect(scheduledBackup.GetFailureThreshold()).To(Equal(DefaultScheduledBackupFailureThreshold))
+	})
+
+	It("doubles the backoff at every retry up to the maximum", func() {
+		scheduledBackup := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				FailurePolicy: &ScheduledBackupFailurePolicy{
+					MaxRetries: 5,
+					Backoff:    &metav1.Duration{Duration: time.Minute},
+					MaxBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		}
+		Expect(scheduledBackup.GetMaxRetries()).To(Equal(5))
+		Expect(scheduledBackup.GetRetryBackoff(0)).To(Equal(time.Minute))
+		Expect(scheduledBackup.GetRetryBackoff(1)).To(Equal(2 * time.Minute))
+		Expect(scheduledBackup.GetRetryBackoff(2)).To(Equal(4 * time.Minute))
+		Expect(scheduledBackup.GetRetryBackoff(3)).To(Equal(5 * time.Minute))
+		Expect(scheduledBackup.GetRetryBackoff(100)).To(Equal(5 * time.Minute))
+	})
+
+	It("is failing once the consecutive failures reach the threshold", func() {
+		scheduledBackup := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				FailurePolicy: &ScheduledBackupFailurePolicy{FailureThreshold: 2},
+			},
+			Status: ScheduledBackupStatus{ConsecutiveFailures: 1},
+		}
+		Expect(scheduledBackup.IsFailing()).To(BeFalse())
+
+		scheduledBackup.Status.ConsecutiveFailures = 2
+		Expect(scheduledBackup.IsFailing()).To(BeTrue())
+	})
 })
@@ -81,6 +81,58 @@ type ScheduledBackupSpec struct {
 	// Overrides the default settings specified in the cluster '.backup.volumeSnapshot.onlineConfiguration' stanza
 	// +optional
 	OnlineConfiguration *OnlineConfiguration `json:"onlineConfiguration,omitempty"`
+
+	// Template used to generate the Backup objects created by this
+	// scheduled backup
+	// +optional
+	BackupTemplate *ScheduledBackupTemplate `json:"backupTemplate,omitempty"`
+
+	// How the failed backups created by this scheduled backup are
+	// retried and reported
+	// +optional
+	FailurePolicy *ScheduledBackupFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// ScheduledBackupFailurePolicy controls how the failed backups created by
+// a ScheduledBackup are retried and reported
+type ScheduledBackupFailurePolicy struct {
+	// The maximum number of times a failed backup is retried before
+	// waiting for the next scheduled execution. Defaults to `0`, meaning
+	// that the failed backups are not retried
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// The time to wait after a failed backup before retrying it, which
+	// doubles at every further retry. Defaults to `5m`
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// The maximum time to wait before retrying a failed backup.
+	// Defaults to `1h`
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// The number of consecutive failed backups after which a warning event
+	// is raised and the `ScheduledBackupsSucceeding` condition of the
+	// cluster is set to `False`. Defaults to `3`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// ScheduledBackupTemplate contains the metadata to be applied to the
+// Backup objects created by a ScheduledBackup
+type ScheduledBackupTemplate struct {
+	// Labels and annotations to be set on the generated Backup objects
+	// +optional
+	ObjectMeta EmbeddedObjectMetadata `json:"metadata,omitempty"`
+
+	// When set to `true`, the generated Backup objects inherit the labels
+	// and annotations defined in the `inheritedMetadata` section of the
+	// cluster, which take precedence over the ones defined in this template
+	// +optional
+	InheritClusterMetadata bool `json:"inheritClusterMetadata,omitempty"`
 }
 
 // ScheduledBackupStatus defines the observed state of ScheduledBackup
@@ -96,6 +148,29 @@ type ScheduledBackupStatus struct {
 	// Next time we will run a backup
 	// +optional
 	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// The name of the last backup created by this scheduled backup
+	// which either completed or failed
+	// +optional
+	LastBackupName string `json:"lastBackupName,omitempty"`
+
+	// The time when the last successful backup created by this scheduled
+	// backup completed
+	// +optional
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+
+	// The time when the last backup created by this scheduled backup failed
+	// +optional
+	LastFailedBackupTime *metav1.Time `json:"lastFailedBackupTime,omitempty"`
+
+	// The number of consecutive failed backups, reset when a backup
+	// completes successfully
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// The number of times the last failed backup has been retried
+	// +optional
+	Retries int `json:"retries,omitempty"`
 }
 
 // +genclient
@@ -27,6 +27,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -80,6 +81,115 @@ func (in *AffinityConfiguration) DeepCopy() *AffinityConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationRulesRefs) DeepCopyInto(out *AuthenticationRulesRefs) {
+	*out = *in
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapRefs != nil {
+		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
+		*out = make([]ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationRulesRefs.
+func (in *AuthenticationRulesRefs) DeepCopy() *AuthenticationRulesRefs {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationRulesRefs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationRulesStatus) DeepCopyInto(out *AuthenticationRulesStatus) {
+	*out = *in
+	if in.SecretVersions != nil {
+		in, out := &in.SecretVersions, &out.SecretVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigMapVersions != nil {
+		in, out := &in.ConfigMapVersions, &out.ConfigMapVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationRulesStatus.
+func (in *AuthenticationRulesStatus) DeepCopy() *AuthenticationRulesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationRulesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticAnalyzeConfiguration) DeepCopyInto(out *AutomaticAnalyzeConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticAnalyzeConfiguration.
+func (in *AutomaticAnalyzeConfiguration) DeepCopy() *AutomaticAnalyzeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticAnalyzeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutovacuumConfiguration) DeepCopyInto(out *AutovacuumConfiguration) {
+	*out = *in
+	if in.Naptime != nil {
+		in, out := &in.Naptime, &out.Naptime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxWorkers != nil {
+		in, out := &in.MaxWorkers, &out.MaxWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VacuumCostDelay != nil {
+		in, out := &in.VacuumCostDelay, &out.VacuumCostDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.VacuumCostLimit != nil {
+		in, out := &in.VacuumCostLimit, &out.VacuumCostLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutovacuumConfiguration.
+func (in *AutovacuumConfiguration) DeepCopy() *AutovacuumConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AutovacuumConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AvailableArchitecture) DeepCopyInto(out *AvailableArchitecture) {
 	*out = *in
@@ -135,6 +245,16 @@ func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
 		*out = new(BarmanObjectStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PostBackupHook != nil {
+		in, out := &in.PostBackupHook, &out.PostBackupHook
+		*out = new(BackupHookConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
@@ -147,6 +267,31 @@ func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHookConfiguration) DeepCopyInto(out *BackupHookConfiguration) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHookConfiguration.
+func (in *BackupHookConfiguration) DeepCopy() *BackupHookConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHookConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -179,6 +324,21 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPgDumpConfiguration) DeepCopyInto(out *BackupPgDumpConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPgDumpConfiguration.
+func (in *BackupPgDumpConfiguration) DeepCopy() *BackupPgDumpConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPgDumpConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupPluginConfiguration) DeepCopyInto(out *BackupPluginConfiguration) {
 	*out = *in
@@ -266,6 +426,11 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = new(BackupPluginConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PgDump != nil {
+		in, out := &in.PgDump, &out.PgDump
+		*out = new(BackupPgDumpConfiguration)
+		**out = **in
+	}
 	if in.Online != nil {
 		in, out := &in.Online, &out.Online
 		*out = new(bool)
@@ -297,6 +462,11 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 		*out = new(SecretKeySelector)
 		**out = **in
 	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(int32)
+		**out = **in
+	}
 	if in.StartedAt != nil {
 		in, out := &in.StartedAt, &out.StartedAt
 		*out = (*in).DeepCopy()
@@ -413,6 +583,11 @@ func (in *BootstrapInitDB) DeepCopyInto(out *BootstrapInitDB) {
 		*out = new(Import)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImportDump != nil {
+		in, out := &in.ImportDump, &out.ImportDump
+		*out = new(ImportDump)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PostInitApplicationSQLRefs != nil {
 		in, out := &in.PostInitApplicationSQLRefs, &out.PostInitApplicationSQLRefs
 		*out = new(SQLRefs)
@@ -483,6 +658,13 @@ func (in *BootstrapRecovery) DeepCopyInto(out *BootstrapRecovery) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapRecovery.
@@ -755,6 +937,31 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Switchover != nil {
+		in, out := &in.Switchover, &out.Switchover
+		*out = new(SwitchoverConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DelayedReplicas != nil {
+		in, out := &in.DelayedReplicas, &out.DelayedReplicas
+		*out = new(DelayedReplicasConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupFailurePolicy != nil {
+		in, out := &in.StartupFailurePolicy, &out.StartupFailurePolicy
+		*out = new(StartupFailurePolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LivenessProbeTimeout != nil {
 		in, out := &in.LivenessProbeTimeout, &out.LivenessProbeTimeout
 		*out = new(int32)
@@ -768,17 +975,39 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.EphemeralVolumesSizeLimit != nil {
 		in, out := &in.EphemeralVolumesSizeLimit, &out.EphemeralVolumesSizeLimit
 		*out = new(EphemeralVolumesSizeLimitConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PrimaryUpdateMethodOverrides != nil {
+		in, out := &in.PrimaryUpdateMethodOverrides, &out.PrimaryUpdateMethodOverrides
+		*out = new(PrimaryUpdateMethodOverrides)
+		**out = **in
+	}
 	if in.Backup != nil {
 		in, out := &in.Backup, &out.Backup
 		*out = new(BackupConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WALArchiving != nil {
+		in, out := &in.WALArchiving, &out.WALArchiving
+		*out = new(WALArchivingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeMaintenanceWindow != nil {
 		in, out := &in.NodeMaintenanceWindow, &out.NodeMaintenanceWindow
 		*out = new(NodeMaintenanceWindow)
@@ -849,6 +1078,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ProbesConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalConnection != nil {
+		in, out := &in.ExternalConnection, &out.ExternalConnection
+		*out = new(ExternalConnectionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -884,7 +1118,7 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		in, out := &in.InstancesReportedState, &out.InstancesReportedState
 		*out = make(map[PodName]InstanceReportedState, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	in.ManagedRolesStatus.DeepCopyInto(&out.ManagedRolesStatus)
@@ -983,6 +1217,26 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollationReindexWindow) DeepCopyInto(out *CollationReindexWindow) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollationReindexWindow.
+func (in *CollationReindexWindow) DeepCopy() *CollationReindexWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(CollationReindexWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapResourceVersion) DeepCopyInto(out *ConfigMapResourceVersion) {
 	*out = *in
@@ -993,6 +1247,13 @@ func (in *ConfigMapResourceVersion) DeepCopyInto(out *ConfigMapResourceVersion)
 			(*out)[key] = val
 		}
 	}
+	if in.AuthenticationRules != nil {
+		in, out := &in.AuthenticationRules, &out.AuthenticationRules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapResourceVersion.
@@ -1137,6 +1398,26 @@ func (in *DatabaseRoleRef) DeepCopy() *DatabaseRoleRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabasePrivilegeSpec) DeepCopyInto(out *DatabasePrivilegeSpec) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]DatabasePrivilege, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabasePrivilegeSpec.
+func (in *DatabasePrivilegeSpec) DeepCopy() *DatabasePrivilegeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabasePrivilegeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 	*out = *in
@@ -1156,10 +1437,17 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(DatabaseTimeoutsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Schemas != nil {
 		in, out := &in.Schemas, &out.Schemas
 		*out = make([]SchemaSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
@@ -1180,6 +1468,20 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]TableSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]DatabasePrivilegeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -1220,6 +1522,23 @@ func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
 		*out = make([]DatabaseObjectStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.UserMappings != nil {
+		in, out := &in.UserMappings, &out.UserMappings
+		*out = make([]DatabaseObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]DatabaseObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretsResourceVersion != nil {
+		in, out := &in.SecretsResourceVersion, &out.SecretsResourceVersion
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
@@ -1233,61 +1552,193 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
+func (in *DatabaseTimeoutsConfiguration) DeepCopyInto(out *DatabaseTimeoutsConfiguration) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.StatementTimeout != nil {
+		in, out := &in.StatementTimeout, &out.StatementTimeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.LockTimeout != nil {
+		in, out := &in.LockTimeout, &out.LockTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleInTransactionSessionTimeout != nil {
+		in, out := &in.IdleInTransactionSessionTimeout, &out.IdleInTransactionSessionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMetadata.
-func (in *EmbeddedObjectMetadata) DeepCopy() *EmbeddedObjectMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseTimeoutsConfiguration.
+func (in *DatabaseTimeoutsConfiguration) DeepCopy() *DatabaseTimeoutsConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedObjectMetadata)
+	out := new(DatabaseTimeoutsConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopyInto(out *EphemeralVolumesSizeLimitConfiguration) {
+func (in *DefaultAlertsThresholds) DeepCopyInto(out *DefaultAlertsThresholds) {
 	*out = *in
-	if in.Shm != nil {
-		in, out := &in.Shm, &out.Shm
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.TemporaryData != nil {
-		in, out := &in.TemporaryData, &out.TemporaryData
+	if in.BackupAge != nil {
+		in, out := &in.BackupAge, &out.BackupAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReplicationSlotRetainedWAL != nil {
+		in, out := &in.ReplicationSlotRetainedWAL, &out.ReplicationSlotRetainedWAL
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.TransactionIDAge != nil {
+		in, out := &in.TransactionIDAge, &out.TransactionIDAge
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CertificateExpiration != nil {
+		in, out := &in.CertificateExpiration, &out.CertificateExpiration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumesSizeLimitConfiguration.
-func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopy() *EphemeralVolumesSizeLimitConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultAlertsThresholds.
+func (in *DefaultAlertsThresholds) DeepCopy() *DefaultAlertsThresholds {
 	if in == nil {
 		return nil
 	}
-	out := new(EphemeralVolumesSizeLimitConfiguration)
+	out := new(DefaultAlertsThresholds)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExtensionConfiguration) DeepCopyInto(out *ExtensionConfiguration) {
+func (in *DefaultServiceTemplate) DeepCopyInto(out *DefaultServiceTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultServiceTemplate.
+func (in *DefaultServiceTemplate) DeepCopy() *DefaultServiceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultServiceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelayedReplicasConfiguration) DeepCopyInto(out *DelayedReplicasConfiguration) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.MinApplyDelay = in.MinApplyDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelayedReplicasConfiguration.
+func (in *DelayedReplicasConfiguration) DeepCopy() *DelayedReplicasConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DelayedReplicasConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMetadata.
+func (in *EmbeddedObjectMetadata) DeepCopy() *EmbeddedObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralTablespaceStorage) DeepCopyInto(out *EphemeralTablespaceStorage) {
+	*out = *in
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(corev1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaimTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralTablespaceStorage.
+func (in *EphemeralTablespaceStorage) DeepCopy() *EphemeralTablespaceStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralTablespaceStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopyInto(out *EphemeralVolumesSizeLimitConfiguration) {
+	*out = *in
+	if in.Shm != nil {
+		in, out := &in.Shm, &out.Shm
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TemporaryData != nil {
+		in, out := &in.TemporaryData, &out.TemporaryData
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumesSizeLimitConfiguration.
+func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopy() *EphemeralVolumesSizeLimitConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralVolumesSizeLimitConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionConfiguration) DeepCopyInto(out *ExtensionConfiguration) {
 	*out = *in
 	out.ImageVolumeSource = in.ImageVolumeSource
 	if in.ExtensionControlPath != nil {
@@ -1385,6 +1836,26 @@ func (in *ExternalCluster) DeepCopy() *ExternalCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConnectionConfiguration) DeepCopyInto(out *ExternalConnectionConfiguration) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConnectionConfiguration.
+func (in *ExternalConnectionConfiguration) DeepCopy() *ExternalConnectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConnectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FDWSpec) DeepCopyInto(out *FDWSpec) {
 	*out = *in
@@ -1411,6 +1882,31 @@ func (in *FDWSpec) DeepCopy() *FDWSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverConfiguration) DeepCopyInto(out *FailoverConfiguration) {
+	*out = *in
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExcludedInstances != nil {
+		in, out := &in.ExcludedInstances, &out.ExcludedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverConfiguration.
+func (in *FailoverConfiguration) DeepCopy() *FailoverConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailoverQuorum) DeepCopyInto(out *FailoverQuorum) {
 	*out = *in
@@ -1489,6 +1985,46 @@ func (in *FailoverQuorumStatus) DeepCopy() *FailoverQuorumStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePagesConfiguration) DeepCopyInto(out *HugePagesConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePagesConfiguration.
+func (in *HugePagesConfiguration) DeepCopy() *HugePagesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePagesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleSessionsConfiguration) DeepCopyInto(out *IdleSessionsConfiguration) {
+	*out = *in
+	if in.SessionTimeout != nil {
+		in, out := &in.SessionTimeout, &out.SessionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InTransactionTimeout != nil {
+		in, out := &in.InTransactionTimeout, &out.InTransactionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleSessionsConfiguration.
+func (in *IdleSessionsConfiguration) DeepCopy() *IdleSessionsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleSessionsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalog) DeepCopyInto(out *ImageCatalog) {
 	*out = *in
@@ -1515,6 +2051,26 @@ func (in *ImageCatalog) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCatalogAutoUpdate) DeepCopyInto(out *ImageCatalogAutoUpdate) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalogAutoUpdate.
+func (in *ImageCatalogAutoUpdate) DeepCopy() *ImageCatalogAutoUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCatalogAutoUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalogList) DeepCopyInto(out *ImageCatalogList) {
 	*out = *in
@@ -1551,6 +2107,11 @@ func (in *ImageCatalogList) DeepCopyObject() runtime.Object {
 func (in *ImageCatalogRef) DeepCopyInto(out *ImageCatalogRef) {
 	*out = *in
 	in.TypedLocalObjectReference.DeepCopyInto(&out.TypedLocalObjectReference)
+	if in.AutoUpdate != nil {
+		in, out := &in.AutoUpdate, &out.AutoUpdate
+		*out = new(ImageCatalogAutoUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalogRef.
@@ -1654,6 +2215,27 @@ func (in *Import) DeepCopy() *Import {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportDump) DeepCopyInto(out *ImportDump) {
+	*out = *in
+	out.Source = in.Source
+	if in.PgRestoreExtraOptions != nil {
+		in, out := &in.PgRestoreExtraOptions, &out.PgRestoreExtraOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportDump.
+func (in *ImportDump) DeepCopy() *ImportDump {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportDump)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportSource) DeepCopyInto(out *ImportSource) {
 	*out = *in
@@ -1687,6 +2269,11 @@ func (in *InstanceID) DeepCopy() *InstanceID {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceReportedState) DeepCopyInto(out *InstanceReportedState) {
 	*out = *in
+	if in.AuthenticationRules != nil {
+		in, out := &in.AuthenticationRules, &out.AuthenticationRules
+		*out = new(AuthenticationRulesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceReportedState.
@@ -1800,6 +2387,31 @@ func (in *LivenessProbe) DeepCopy() *LivenessProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalSlotsCleanupConfiguration) DeepCopyInto(out *LogicalSlotsCleanupConfiguration) {
+	*out = *in
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InactiveTimeout != nil {
+		in, out := &in.InactiveTimeout, &out.InactiveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalSlotsCleanupConfiguration.
+func (in *LogicalSlotsCleanupConfiguration) DeepCopy() *LogicalSlotsCleanupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalSlotsCleanupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
 	*out = *in
@@ -1815,6 +2427,11 @@ func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
 		*out = new(ManagedServices)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(ManagedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedConfiguration.
@@ -1827,6 +2444,40 @@ func (in *ManagedConfiguration) DeepCopy() *ManagedConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedNetworkPolicy) DeepCopyInto(out *ManagedNetworkPolicy) {
+	*out = *in
+	if in.ApplicationNamespaceSelector != nil {
+		in, out := &in.ApplicationNamespaceSelector, &out.ApplicationNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalIngress != nil {
+		in, out := &in.AdditionalIngress, &out.AdditionalIngress
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalEgress != nil {
+		in, out := &in.AdditionalEgress, &out.AdditionalEgress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedNetworkPolicy.
+func (in *ManagedNetworkPolicy) DeepCopy() *ManagedNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedRoles) DeepCopyInto(out *ManagedRoles) {
 	*out = *in
@@ -1905,6 +2556,13 @@ func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
 		*out = make([]ServiceSelectorType, len(*in))
 		copy(*out, *in)
 	}
+	if in.DefaultServices != nil {
+		in, out := &in.DefaultServices, &out.DefaultServices
+		*out = make([]DefaultServiceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Additional != nil {
 		in, out := &in.Additional, &out.Additional
 		*out = make([]ManagedService, len(*in))
@@ -1990,6 +2648,31 @@ func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodMonitorInterval != nil {
+		in, out := &in.PodMonitorInterval, &out.PodMonitorInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PodMonitorScrapeTimeout != nil {
+		in, out := &in.PodMonitorScrapeTimeout, &out.PodMonitorScrapeTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WraparoundWarningThreshold != nil {
+		in, out := &in.WraparoundWarningThreshold, &out.WraparoundWarningThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TableBloatEstimate != nil {
+		in, out := &in.TableBloatEstimate, &out.TableBloatEstimate
+		*out = new(TableBloatEstimateConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultAlertsThresholds != nil {
+		in, out := &in.DefaultAlertsThresholds, &out.DefaultAlertsThresholds
+		*out = new(DefaultAlertsThresholds)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
@@ -2062,6 +2745,26 @@ func (in *OptionSpec) DeepCopy() *OptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordEncryptionConfiguration) DeepCopyInto(out *PasswordEncryptionConfiguration) {
+	*out = *in
+	if in.ScramIterations != nil {
+		in, out := &in.ScramIterations, &out.ScramIterations
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordEncryptionConfiguration.
+func (in *PasswordEncryptionConfiguration) DeepCopy() *PasswordEncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordEncryptionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordState) DeepCopyInto(out *PasswordState) {
 	*out = *in
@@ -2077,6 +2780,31 @@ func (in *PasswordState) DeepCopy() *PasswordState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerDatabase) DeepCopyInto(out *PgBouncerDatabase) {
+	*out = *in
+	if in.PoolSize != nil {
+		in, out := &in.PoolSize, &out.PoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDBConnections != nil {
+		in, out := &in.MaxDBConnections, &out.MaxDBConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerDatabase.
+func (in *PgBouncerDatabase) DeepCopy() *PgBouncerDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PgBouncerIntegrationStatus) DeepCopyInto(out *PgBouncerIntegrationStatus) {
 	*out = *in
@@ -2128,6 +2856,13 @@ func (in *PgBouncerSpec) DeepCopyInto(out *PgBouncerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PgBouncerDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PgHBA != nil {
 		in, out := &in.PgHBA, &out.PgHBA
 		*out = make([]string, len(*in))
@@ -2478,6 +3213,16 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PgHBARefs != nil {
+		in, out := &in.PgHBARefs, &out.PgHBARefs
+		*out = new(AuthenticationRulesRefs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PgIdentRefs != nil {
+		in, out := &in.PgIdentRefs, &out.PgIdentRefs
+		*out = new(AuthenticationRulesRefs)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SyncReplicaElectionConstraint.DeepCopyInto(&out.SyncReplicaElectionConstraint)
 	if in.AdditionalLibraries != nil {
 		in, out := &in.AdditionalLibraries, &out.AdditionalLibraries
@@ -2489,13 +3234,65 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = new(LDAPConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Extensions != nil {
-		in, out := &in.Extensions, &out.Extensions
-		*out = make([]ExtensionConfiguration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CollationReindexWindow != nil {
+		in, out := &in.CollationReindexWindow, &out.CollationReindexWindow
+		*out = new(CollationReindexWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSL != nil {
+		in, out := &in.SSL, &out.SSL
+		*out = new(SSLConfiguration)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(PostgresLoggingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = new(HugePagesConfiguration)
+		**out = **in
+	}
+	if in.IdleSessions != nil {
+		in, out := &in.IdleSessions, &out.IdleSessions
+		*out = new(IdleSessionsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordEncryption != nil {
+		in, out := &in.PasswordEncryption, &out.PasswordEncryption
+		*out = new(PasswordEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shutdown != nil {
+		in, out := &in.Shutdown, &out.Shutdown
+		*out = new(ShutdownConfiguration)
+		**out = **in
+	}
+	if in.AutomaticAnalyze != nil {
+		in, out := &in.AutomaticAnalyze, &out.AutomaticAnalyze
+		*out = new(AutomaticAnalyzeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryConnInfoParameters != nil {
+		in, out := &in.PrimaryConnInfoParameters, &out.PrimaryConnInfoParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.Autovacuum != nil {
+		in, out := &in.Autovacuum, &out.Autovacuum
+		*out = new(AutovacuumConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfiguration.
@@ -2508,6 +3305,51 @@ func (in *PostgresConfiguration) DeepCopy() *PostgresConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresLoggingConfiguration) DeepCopyInto(out *PostgresLoggingConfiguration) {
+	*out = *in
+	if in.SlowQueryThreshold != nil {
+		in, out := &in.SlowQueryThreshold, &out.SlowQueryThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LogConnections != nil {
+		in, out := &in.LogConnections, &out.LogConnections
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogDisconnections != nil {
+		in, out := &in.LogDisconnections, &out.LogDisconnections
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresLoggingConfiguration.
+func (in *PostgresLoggingConfiguration) DeepCopy() *PostgresLoggingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresLoggingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryUpdateMethodOverrides) DeepCopyInto(out *PrimaryUpdateMethodOverrides) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryUpdateMethodOverrides.
+func (in *PrimaryUpdateMethodOverrides) DeepCopy() *PrimaryUpdateMethodOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryUpdateMethodOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2528,6 +3370,21 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeQuery) DeepCopyInto(out *ProbeQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeQuery.
+func (in *ProbeQuery) DeepCopy() *ProbeQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeWithStrategy) DeepCopyInto(out *ProbeWithStrategy) {
 	*out = *in
@@ -2537,6 +3394,11 @@ func (in *ProbeWithStrategy) DeepCopyInto(out *ProbeWithStrategy) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(ProbeQuery)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeWithStrategy.
@@ -2807,6 +3669,11 @@ func (in *ReplicationSlotsConfiguration) DeepCopyInto(out *ReplicationSlotsConfi
 		*out = new(SynchronizeReplicasConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LogicalSlotsCleanup != nil {
+		in, out := &in.LogicalSlotsCleanup, &out.LogicalSlotsCleanup
+		*out = new(LogicalSlotsCleanupConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsConfiguration.
@@ -2898,6 +3765,21 @@ func (in *SQLRefs) DeepCopy() *SQLRefs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLConfiguration) DeepCopyInto(out *SSLConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLConfiguration.
+func (in *SSLConfiguration) DeepCopy() *SSLConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledBackup) DeepCopyInto(out *ScheduledBackup) {
 	*out = *in
@@ -2925,6 +3807,31 @@ func (in *ScheduledBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackupFailurePolicy) DeepCopyInto(out *ScheduledBackupFailurePolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupFailurePolicy.
+func (in *ScheduledBackupFailurePolicy) DeepCopy() *ScheduledBackupFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledBackupFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledBackupList) DeepCopyInto(out *ScheduledBackupList) {
 	*out = *in
@@ -2986,6 +3893,16 @@ func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
 		*out = new(OnlineConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackupTemplate != nil {
+		in, out := &in.BackupTemplate, &out.BackupTemplate
+		*out = new(ScheduledBackupTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(ScheduledBackupFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupSpec.
@@ -3013,6 +3930,14 @@ func (in *ScheduledBackupStatus) DeepCopyInto(out *ScheduledBackupStatus) {
 		in, out := &in.NextScheduleTime, &out.NextScheduleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailedBackupTime != nil {
+		in, out := &in.LastFailedBackupTime, &out.LastFailedBackupTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupStatus.
@@ -3025,10 +3950,58 @@ func (in *ScheduledBackupStatus) DeepCopy() *ScheduledBackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackupTemplate) DeepCopyInto(out *ScheduledBackupTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupTemplate.
+func (in *ScheduledBackupTemplate) DeepCopy() *ScheduledBackupTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledBackupTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaPrivilegeSpec) DeepCopyInto(out *SchemaPrivilegeSpec) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = make([]SchemaPrivilege, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]TablePrivilege, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaPrivilegeSpec.
+func (in *SchemaPrivilegeSpec) DeepCopy() *SchemaPrivilegeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaPrivilegeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchemaSpec) DeepCopyInto(out *SchemaSpec) {
 	*out = *in
 	out.DatabaseObjectSpec = in.DatabaseObjectSpec
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]SchemaPrivilegeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaSpec.
@@ -3080,6 +4053,13 @@ func (in *SecretsResourceVersion) DeepCopyInto(out *SecretsResourceVersion) {
 			(*out)[key] = val
 		}
 	}
+	if in.AuthenticationRules != nil {
+		in, out := &in.AuthenticationRules, &out.AuthenticationRules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsResourceVersion.
@@ -3106,6 +4086,13 @@ func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 		*out = make([]UsageSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.UserMappings != nil {
+		in, out := &in.UserMappings, &out.UserMappings
+		*out = make([]UserMappingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSpec.
@@ -3151,6 +4138,41 @@ func (in *ServiceTemplateSpec) DeepCopy() *ServiceTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShutdownConfiguration) DeepCopyInto(out *ShutdownConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShutdownConfiguration.
+func (in *ShutdownConfiguration) DeepCopy() *ShutdownConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ShutdownConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupFailurePolicyConfiguration) DeepCopyInto(out *StartupFailurePolicyConfiguration) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupFailurePolicyConfiguration.
+func (in *StartupFailurePolicyConfiguration) DeepCopy() *StartupFailurePolicyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupFailurePolicyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfiguration) DeepCopyInto(out *StorageConfiguration) {
 	*out = *in
@@ -3298,6 +4320,31 @@ func (in *SwitchReplicaClusterStatus) DeepCopy() *SwitchReplicaClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchoverConfiguration) DeepCopyInto(out *SwitchoverConfiguration) {
+	*out = *in
+	if in.WaitForShutdownLSN != nil {
+		in, out := &in.WaitForShutdownLSN, &out.WaitForShutdownLSN
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShutdownLSNTimeout != nil {
+		in, out := &in.ShutdownLSNTimeout, &out.ShutdownLSNTimeout
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchoverConfiguration.
+func (in *SwitchoverConfiguration) DeepCopy() *SwitchoverConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchoverConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncReplicaElectionConstraints) DeepCopyInto(out *SyncReplicaElectionConstraints) {
 	*out = *in
@@ -3373,10 +4420,55 @@ func (in *SynchronousReplicaConfiguration) DeepCopy() *SynchronousReplicaConfigu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableBloatEstimateConfiguration) DeepCopyInto(out *TableBloatEstimateConfiguration) {
+	*out = *in
+	if in.MaxTables != nil {
+		in, out := &in.MaxTables, &out.MaxTables
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableBloatEstimateConfiguration.
+func (in *TableBloatEstimateConfiguration) DeepCopy() *TableBloatEstimateConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TableBloatEstimateConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableSpec) DeepCopyInto(out *TableSpec) {
+	*out = *in
+	if in.StorageParameters != nil {
+		in, out := &in.StorageParameters, &out.StorageParameters
+		*out = make([]OptionSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableSpec.
+func (in *TableSpec) DeepCopy() *TableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TablespaceConfiguration) DeepCopyInto(out *TablespaceConfiguration) {
 	*out = *in
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralTablespaceStorage)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Owner = in.Owner
 }
 
@@ -3438,6 +4530,31 @@ func (in *Topology) DeepCopy() *Topology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyConfiguration) DeepCopyInto(out *TopologyConfiguration) {
+	*out = *in
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyConfiguration.
+func (in *TopologyConfiguration) DeepCopy() *TopologyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UsageSpec) DeepCopyInto(out *UsageSpec) {
 	*out = *in
@@ -3453,6 +4570,31 @@ func (in *UsageSpec) DeepCopy() *UsageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserMappingSpec) DeepCopyInto(out *UserMappingSpec) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]OptionSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserMappingSpec.
+func (in *UserMappingSpec) DeepCopy() *UserMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotConfiguration) DeepCopyInto(out *VolumeSnapshotConfiguration) {
 	*out = *in
@@ -3494,3 +4636,23 @@ func (in *VolumeSnapshotConfiguration) DeepCopy() *VolumeSnapshotConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WALArchivingConfiguration) DeepCopyInto(out *WALArchivingConfiguration) {
+	*out = *in
+	if in.ExpectedBacklog != nil {
+		in, out := &in.ExpectedBacklog, &out.ExpectedBacklog
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WALArchivingConfiguration.
+func (in *WALArchivingConfiguration) DeepCopy() *WALArchivingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WALArchivingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
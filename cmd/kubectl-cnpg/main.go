@@ -32,7 +32,9 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/backup"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/certificate"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/connect"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/destroy"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/explain"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/fence"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/fio"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/hibernate"
@@ -48,8 +50,10 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/reload"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/report"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restart"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/runsql"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/snapshot"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/status"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/validate"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/versions"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -67,6 +71,11 @@ func main() {
 		},
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// The validation of the manifests reports its own outcome, and the
+			// logs of the webhooks it runs would only get in the way
+			if cmd.Name() == "validate" && !cmd.Flags().Changed("log-level") {
+				log.SetLogLevel(log.ErrorLevelString)
+			}
 			logFlags.ConfigureLogging()
 
 			// If we're invoking the completion command we shouldn't try to create
@@ -78,6 +87,12 @@ func main() {
 
 			plugin.ConfigureColor(cmd)
 
+			// The validation of the manifests happens locally, without
+			// connecting to a Kubernetes cluster
+			if cmd.Name() == "validate" {
+				return nil
+			}
+
 			return plugin.SetupKubernetesClient(configFlags)
 		},
 	}
@@ -115,7 +130,9 @@ func main() {
 	subcommands := []*cobra.Command{
 		backup.NewCmd(),
 		certificate.NewCmd(),
+		connect.NewCmd(),
 		destroy.NewCmd(),
+		explain.NewCmd(),
 		fence.NewCmd(),
 		fio.NewCmd(),
 		hibernate.NewCmd(),
@@ -130,9 +147,11 @@ func main() {
 		reload.NewCmd(),
 		report.NewCmd(),
 		restart.NewCmd(),
+		runsql.NewCmd(),
 		snapshot.NewCmd(),
 		status.NewCmd(),
 		subscription.NewCmd(),
+		validate.NewCmd(),
 		versions.NewCmd(),
 	}
 
@@ -37,7 +37,12 @@ func NewCmd() *cobra.Command {
 	var pprofHTTPServer bool
 	var leaderLeaseDuration int
 	var leaderRenewDeadline int
+	var leaderRetryPeriod int
 	var maxConcurrentReconciles int
+	var clusterResyncInterval time.Duration
+	var backupResyncInterval time.Duration
+	var poolerResyncInterval time.Duration
+	var cacheSyncPeriod time.Duration
 
 	cmd := cobra.Command{
 		Use:           "controller [flags]",
@@ -51,10 +56,17 @@ func NewCmd() *cobra.Command {
 					enable:        leaderElectionEnable,
 					leaseDuration: time.Duration(leaderLeaseDuration) * time.Second,
 					renewDeadline: time.Duration(leaderRenewDeadline) * time.Second,
+					retryPeriod:   time.Duration(leaderRetryPeriod) * time.Second,
 				},
 				pprofHTTPServer,
 				port,
 				maxConcurrentReconciles,
+				resyncConfiguration{
+					cluster:         clusterResyncInterval,
+					backup:          backupResyncInterval,
+					pooler:          poolerResyncInterval,
+					cacheSyncPeriod: cacheSyncPeriod,
+				},
 				configuration.Current,
 			)
 		},
@@ -69,6 +81,8 @@ func NewCmd() *cobra.Command {
 		"the leader lease duration expressed in seconds")
 	cmd.Flags().IntVar(&leaderRenewDeadline, "leader-renew-deadline", 10,
 		"the leader renew deadline expressed in seconds")
+	cmd.Flags().IntVar(&leaderRetryPeriod, "leader-retry-period", 2,
+		"the interval between two attempts to acquire or renew the leader lease, expressed in seconds")
 
 	cmd.Flags().StringVar(&configMapName, "config-map-name", "", "The name of the ConfigMap containing "+
 		"the operator configuration")
@@ -88,6 +102,19 @@ func NewCmd() *cobra.Command {
 		10,
 		"The maximum number of concurrent reconciles. Defaults to 10.",
 	)
+	cmd.Flags().DurationVar(&clusterResyncInterval, "cluster-resync-interval", 0,
+		"The interval after which every cluster is reconciled again, even when nothing changed. "+
+			"Defaults to 0, which disables the periodic resync of the clusters")
+	cmd.Flags().DurationVar(&backupResyncInterval, "backup-resync-interval", 0,
+		"The interval after which every backup that is neither completed nor failed is reconciled again, "+
+			"even when nothing changed. Defaults to 0, which disables the periodic resync of the backups")
+	cmd.Flags().DurationVar(&poolerResyncInterval, "pooler-resync-interval", 0,
+		"The interval after which every pooler is reconciled again, even when nothing changed. "+
+			"Defaults to 0, which disables the periodic resync of the poolers")
+	cmd.Flags().DurationVar(&cacheSyncPeriod, "cache-sync-period", 0,
+		"The interval after which the informers resync the cached resources, triggering the "+
+			"reconciliation of every watched object. Defaults to 0, which keeps the default "+
+			"of controller-runtime, 10 hours")
 
 	return &cmd
 }
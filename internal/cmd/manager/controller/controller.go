@@ -28,6 +28,7 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -82,6 +83,18 @@ type leaderElectionConfiguration struct {
 	enable        bool
 	leaseDuration time.Duration
 	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// resyncConfiguration contains the intervals after which the resources are
+// reconciled again when no change has been detected. Zero values disable
+// the periodic resync of the corresponding kind, or keep the default of
+// controller-runtime for the sync period of the cache.
+type resyncConfiguration struct {
+	cluster         time.Duration
+	backup          time.Duration
+	pooler          time.Duration
+	cacheSyncPeriod time.Duration
 }
 
 // RunController is the main procedure of the operator, and is used as the
@@ -98,6 +111,7 @@ func RunController(
 	pprofDebug bool,
 	port int,
 	maxConcurrentReconciles int,
+	resyncConfig resyncConfiguration,
 	conf *configuration.Data,
 ) error {
 	ctx := context.Background()
@@ -113,6 +127,7 @@ func RunController(
 		LeaderElection:   leaderConfig.enable,
 		LeaseDuration:    &leaderConfig.leaseDuration,
 		RenewDeadline:    &leaderConfig.renewDeadline,
+		RetryPeriod:      &leaderConfig.retryPeriod,
 		LeaderElectionID: LeaderElectionID,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    port,
@@ -132,11 +147,22 @@ func RunController(
 		LeaderElectionReleaseOnCancel: true,
 	}
 
+	if resyncConfig.cacheSyncPeriod > 0 {
+		managerOptions.Cache.SyncPeriod = &resyncConfig.cacheSyncPeriod
+	}
+
 	if conf.WatchNamespace != "" {
 		namespaces := conf.WatchedNamespaces()
 		managerOptions.NewCache = multicache.DelegatingMultiNamespacedCacheBuilder(
 			namespaces,
 			conf.OperatorNamespace)
+		// The operator may not be allowed to watch the cluster-wide storage
+		// classes, which are only read when a PVC needs to be resized
+		managerOptions.Client = client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&storagev1.StorageClass{}},
+			},
+		}
 		setupLog.Info("Listening for changes", "watchNamespaces", namespaces)
 	} else {
 		setupLog.Info("Listening for changes on all namespaces")
@@ -204,6 +230,20 @@ func RunController(
 		return err
 	}
 
+	// Detect the optional features which can't work because the operator
+	// lacks the cluster-scoped permissions they need
+	missingPermissions, err := utils.DetectClusterScopedPermissions(ctx, kubeClient)
+	if err != nil {
+		setupLog.Error(err, "unable to detect the cluster-scoped permissions of the operator")
+		return err
+	}
+	for _, permission := range missingPermissions {
+		setupLog.Warning("Missing cluster-scoped permission, disabling the features depending on it",
+			"group", permission.Group,
+			"resource", permission.Resource,
+			"disabledFeatures", permission.Features)
+	}
+
 	setupLog.Info("Kubernetes system metadata",
 		"haveSCC", utils.HaveSecurityContextConstraints(),
 		"haveVolumeSnapshot", utils.HaveVolumeSnapshot(),
@@ -222,21 +262,25 @@ func RunController(
 	}
 	defer pluginRepository.Close()
 
-	if err = controller.NewClusterReconciler(
+	clusterReconciler := controller.NewClusterReconciler(
 		mgr,
 		discoveryClient,
 		pluginRepository,
 		conf.DrainTaints,
-	).SetupWithManager(ctx, mgr, maxConcurrentReconciles); err != nil {
+	)
+	clusterReconciler.ResyncInterval = resyncConfig.cluster
+	if err = clusterReconciler.SetupWithManager(ctx, mgr, maxConcurrentReconciles); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cluster")
 		return err
 	}
 
-	if err = controller.NewBackupReconciler(
+	backupReconciler := controller.NewBackupReconciler(
 		mgr,
 		discoveryClient,
 		pluginRepository,
-	).SetupWithManager(ctx, mgr); err != nil {
+	)
+	backupReconciler.ResyncInterval = resyncConfig.backup
+	if err = backupReconciler.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Backup")
 		return err
 	}
@@ -261,6 +305,7 @@ func RunController(
 		DiscoveryClient: discoveryClient,
 		Scheme:          mgr.GetScheme(),
 		Recorder:        mgr.GetEventRecorderFor("cloudnative-pg-pooler"),
+		ResyncInterval:  resyncConfig.pooler,
 	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Pooler")
 		return err
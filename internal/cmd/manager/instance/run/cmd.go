@@ -244,7 +244,13 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance, pprofServer
 	defer pluginRepository.Close()
 
 	metricsExporter := metricserver.NewExporter(instance, metrics.NewPluginCollector(pluginRepository))
-	reconciler := controller.NewInstanceReconciler(instance, mgr.GetClient(), metricsExporter, pluginRepository)
+	reconciler := controller.NewInstanceReconciler(
+		instance,
+		mgr.GetClient(),
+		mgr.GetEventRecorderFor("instance-manager"),
+		metricsExporter,
+		pluginRepository,
+	)
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Cluster{}).
 		Named("instance-cluster").
@@ -305,7 +311,12 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance, pprofServer
 		return err
 	}
 
-	postgresLifecycleManager := lifecycle.NewPostgres(ctx, instance, postgresStartConditions)
+	postgresLifecycleManager := lifecycle.NewPostgres(
+		ctx,
+		instance,
+		postgresStartConditions,
+		mgr.GetEventRecorderFor("instance-manager"),
+	)
 	if err = mgr.Add(postgresLifecycleManager); err != nil {
 		contextLogger.Error(err, "unable to create instance runnable")
 		return err
@@ -328,6 +339,12 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance, pprofServer
 		return err
 	}
 
+	objectStoreChecker := controller.NewObjectStoreChecker(instance, reconciler.GetClient())
+	if err = mgr.Add(objectStoreChecker); err != nil {
+		contextLogger.Error(err, "unable to create object store checker")
+		return err
+	}
+
 	// onlineUpgradeCtx is a child context of the postgres context.
 	// onlineUpgradeCtx will be the context passed to all the manager handled Runnables via Start(ctx),
 	// its deletion will imply all Runnables to stop, but will be handled
@@ -336,7 +353,12 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance, pprofServer
 	// which will imply the deletion of the child onlineUpgradeCtx too, again, terminating all the Runnables.
 	onlineUpgradeCtx, onlineUpgradeCancelFunc := context.WithCancel(postgresLifecycleManager.GetGlobalContext())
 	defer onlineUpgradeCancelFunc()
-	remoteSrv, err := webserver.NewRemoteWebServer(instance, onlineUpgradeCancelFunc, exitedConditions)
+	remoteSrv, err := webserver.NewRemoteWebServer(
+		instance,
+		onlineUpgradeCancelFunc,
+		exitedConditions,
+		mgr.GetEventRecorderFor("remote-webserver"),
+	)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,312 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+	pg "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// crashLoopStateFileName is the file where the crash history of
+	// the postmaster is stored. It lives in the scratch data directory,
+	// which is preserved across the restarts of the container
+	crashLoopStateFileName = "crash-loop.json"
+
+	// crashLoopStableUptime is the time after which a running postmaster
+	// is considered stable, resetting the count of the consecutive crashes
+	crashLoopStableUptime = 1 * time.Minute
+
+	// crashLoopThreshold is the number of consecutive crashes after
+	// which the restarts of the postmaster are delayed
+	crashLoopThreshold = 3
+
+	// crashLoopBaseBackoff is the delay applied when the crash loop
+	// is detected, doubled at every following crash
+	crashLoopBaseBackoff = 10 * time.Second
+
+	// crashLoopMaxBackoff is the maximum delay between two restarts
+	crashLoopMaxBackoff = 5 * time.Minute
+
+	// crashLoopMaxEventMessageLength is the maximum length of the
+	// message of the event reporting a crash loop
+	crashLoopMaxEventMessageLength = 1024
+)
+
+// crashLoopState is the crash history of the postmaster
+type crashLoopState struct {
+	// ConsecutiveCrashes is the number of times the postmaster exited
+	// without being stable in a row
+	ConsecutiveCrashes int `json:"consecutiveCrashes"`
+
+	// LastCrash is the time of the last crash
+	LastCrash time.Time `json:"lastCrash"`
+
+	// RecentCrashes are the times of the consecutive crashes counted by
+	// the startup failure policy
+	RecentCrashes []time.Time `json:"recentCrashes,omitempty"`
+
+	// PermanentlyFailed is true when the startup failure policy marked
+	// the instance as permanently failed
+	PermanentlyFailed bool `json:"permanentlyFailed,omitempty"`
+}
+
+// registerCrash records a crash of a postmaster which run for the given uptime
+func (s *crashLoopState) registerCrash(uptime time.Duration, now time.Time) {
+	if uptime >= crashLoopStableUptime {
+		s.ConsecutiveCrashes = 1
+		s.RecentCrashes = nil
+	} else {
+		s.ConsecutiveCrashes++
+	}
+	s.LastCrash = now
+	s.RecentCrashes = append(s.RecentCrashes, now)
+}
+
+// exceedsStartupFailurePolicy forgets the crashes that happened before the
+// window of the passed policy, and checks whether the following ones
+// exceed the number of restart attempts allowed
+func (s *crashLoopState) exceedsStartupFailurePolicy(
+	policy *apiv1.StartupFailurePolicyConfiguration,
+	now time.Time,
+) bool {
+	if policy == nil {
+		s.RecentCrashes = nil
+		return false
+	}
+
+	window := policy.GetWindow()
+	s.RecentCrashes = slices.DeleteFunc(s.RecentCrashes, func(crash time.Time) bool {
+		return now.Sub(crash) > window
+	})
+
+	// The first crash is followed by the first restart attempt
+	return len(s.RecentCrashes) > int(policy.MaxRestartAttempts)
+}
+
+// backoff is the time to wait before restarting the postmaster,
+// growing exponentially once the crash loop is detected
+func (s *crashLoopState) backoff() time.Duration {
+	if s.ConsecutiveCrashes < crashLoopThreshold {
+		return 0
+	}
+
+	result := crashLoopBaseBackoff
+	for i := crashLoopThreshold; i < s.ConsecutiveCrashes; i++ {
+		result *= 2
+		if result >= crashLoopMaxBackoff {
+			return crashLoopMaxBackoff
+		}
+	}
+
+	return result
+}
+
+// loadCrashLoopState reads the crash history from the given file,
+// returning an empty one if the file doesn't exist
+func loadCrashLoopState(fileName string) (crashLoopState, error) {
+	var result crashLoopState
+
+	content, err := os.ReadFile(fileName) // #nosec
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(content, &result)
+	return result, err
+}
+
+// save writes the crash history to the given file
+func (s *crashLoopState) save(fileName string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = fileutils.WriteFileAtomic(fileName, content, 0o600)
+	return err
+}
+
+// buildCrashLoopMessage creates the message of the event reporting a
+// crash loop, including the latest lines logged by PostgreSQL
+func buildCrashLoopMessage(
+	podName string,
+	state crashLoopState,
+	backoff time.Duration,
+	logLines []string,
+) string {
+	message := fmt.Sprintf(
+		"PostgreSQL on instance %s crashed %d times in a row, delaying the next start by %v",
+		podName, state.ConsecutiveCrashes, backoff)
+	return appendLogLines(message, logLines)
+}
+
+// buildPermanentFailureMessage creates the message of the event reporting
+// that an instance has been marked as permanently failed, including the
+// latest lines logged by PostgreSQL
+func buildPermanentFailureMessage(
+	podName string,
+	state crashLoopState,
+	policy *apiv1.StartupFailurePolicyConfiguration,
+	logLines []string,
+) string {
+	message := fmt.Sprintf(
+		"PostgreSQL on instance %s crashed %d times within %v, marking the instance as permanently failed. "+
+			"Delete the Pod once the issue is solved",
+		podName, len(state.RecentCrashes), policy.GetWindow())
+	return appendLogLines(message, logLines)
+}
+
+// appendLogLines adds the passed log lines to the message of an event,
+// truncating it when too long
+func appendLogLines(message string, logLines []string) string {
+	if len(logLines) == 0 {
+		return message
+	}
+
+	message = fmt.Sprintf("%s. Latest log lines:\n%s", message, strings.Join(logLines, "\n"))
+	if len(message) > crashLoopMaxEventMessageLength {
+		message = message[:crashLoopMaxEventMessageLength-3] + "..."
+	}
+
+	return message
+}
+
+// throttleCrashLoop records an unexpected exit of the postmaster and, when
+// it crashed too many times in a row without being stable, reports the crash
+// loop and waits before letting the instance manager restart it.
+// It returns true when the startup failure policy marked the instance as
+// permanently failed, and PostgreSQL must not be restarted anymore.
+func (i *PostgresLifecycle) throttleCrashLoop(
+	ctx context.Context,
+	uptime time.Duration,
+	signals <-chan os.Signal,
+) bool {
+	contextLogger := log.FromContext(ctx)
+	fileName := filepath.Join(pg.ScratchDataDirectory, crashLoopStateFileName)
+
+	state, err := loadCrashLoopState(fileName)
+	if err != nil {
+		contextLogger.Warning("Unable to read the crash history of PostgreSQL, resetting it",
+			"fileName", fileName, "err", err.Error())
+		state = crashLoopState{}
+	}
+
+	now := time.Now()
+	state.registerCrash(uptime, now)
+	var policy *apiv1.StartupFailurePolicyConfiguration
+	if cluster := i.instance.Cluster; cluster != nil {
+		policy = cluster.Spec.StartupFailurePolicy
+	}
+	state.PermanentlyFailed = state.exceedsStartupFailurePolicy(policy, now)
+	if err := state.save(fileName); err != nil {
+		contextLogger.Warning("Unable to store the crash history of PostgreSQL",
+			"fileName", fileName, "err", err.Error())
+	}
+
+	if state.PermanentlyFailed {
+		logLines := logpipe.PostgresTail.Lines()
+		contextLogger.Info("PostgreSQL crashed too many times, marking the instance as permanently failed",
+			"consecutiveCrashes", state.ConsecutiveCrashes,
+			"maxRestartAttempts", policy.MaxRestartAttempts,
+			"window", policy.GetWindow(),
+			"latestLogLines", logLines,
+		)
+		if i.recorder != nil {
+			i.recorder.Event(i.instance.Cluster, "Warning", "InstancePermanentlyFailed",
+				buildPermanentFailureMessage(i.instance.GetPodName(), state, policy, logLines))
+		}
+		return true
+	}
+
+	backoff := state.backoff()
+	if backoff == 0 {
+		return false
+	}
+
+	logLines := logpipe.PostgresTail.Lines()
+	contextLogger.Info("PostgreSQL is crash looping, delaying the next start",
+		"consecutiveCrashes", state.ConsecutiveCrashes,
+		"backoff", backoff,
+		"latestLogLines", logLines,
+	)
+	if cluster := i.instance.Cluster; cluster != nil && i.recorder != nil {
+		i.recorder.Event(cluster, "Warning", "CrashLoop",
+			buildCrashLoopMessage(i.instance.GetPodName(), state, backoff, logLines))
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	case sig := <-signals:
+		contextLogger.Info("Received termination signal while delaying the next start", "signal", sig)
+	}
+
+	return false
+}
+
+// isPermanentlyFailed checks whether the startup failure policy marked the
+// instance as permanently failed before the container was restarted
+func (i *PostgresLifecycle) isPermanentlyFailed(ctx context.Context) bool {
+	fileName := filepath.Join(pg.ScratchDataDirectory, crashLoopStateFileName)
+	state, err := loadCrashLoopState(fileName)
+	if err != nil {
+		log.FromContext(ctx).Warning("Unable to read the crash history of PostgreSQL",
+			"fileName", fileName, "err", err.Error())
+		return false
+	}
+
+	return state.PermanentlyFailed
+}
+
+// waitForManualIntervention keeps PostgreSQL down on a permanently failed
+// instance, reporting it in the instance status, until the instance
+// manager is asked to terminate, i.e. because the Pod is being deleted
+func (i *PostgresLifecycle) waitForManualIntervention(ctx context.Context, signals <-chan os.Signal) {
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Warning("The instance is permanently failed, PostgreSQL won't be started " +
+		"until the Pod is deleted")
+	i.instance.SetPermanentlyFailed(true)
+
+	select {
+	case <-ctx.Done():
+	case sig := <-signals:
+		contextLogger.Info("Received termination signal while permanently failed", "signal", sig)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("crash loop detection", func() {
+	now := time.Now()
+
+	It("doesn't delay the first crashes", func() {
+		var state crashLoopState
+		for range crashLoopThreshold - 1 {
+			state.registerCrash(time.Second, now)
+			Expect(state.backoff()).To(BeZero())
+		}
+	})
+
+	It("exponentially delays the restarts of a crash looping postmaster", func() {
+		state := crashLoopState{}
+		for range crashLoopThreshold {
+			state.registerCrash(time.Second, now)
+		}
+		Expect(state.backoff()).To(Equal(crashLoopBaseBackoff))
+
+		state.registerCrash(time.Second, now)
+		Expect(state.backoff()).To(Equal(2 * crashLoopBaseBackoff))
+
+		state.registerCrash(time.Second, now)
+		Expect(state.backoff()).To(Equal(4 * crashLoopBaseBackoff))
+
+		for range 10 {
+			state.registerCrash(time.Second, now)
+		}
+		Expect(state.backoff()).To(Equal(crashLoopMaxBackoff))
+	})
+
+	It("resets the count when the postmaster was stable", func() {
+		state := crashLoopState{ConsecutiveCrashes: 10}
+		state.registerCrash(crashLoopStableUptime, now)
+		Expect(state.ConsecutiveCrashes).To(Equal(1))
+		Expect(state.backoff()).To(BeZero())
+	})
+
+	It("stores the crash history in a file", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), crashLoopStateFileName)
+
+		state, err := loadCrashLoopState(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ConsecutiveCrashes).To(BeZero())
+
+		state.registerCrash(time.Second, now)
+		state.registerCrash(time.Second, now)
+		Expect(state.save(fileName)).To(Succeed())
+
+		state, err = loadCrashLoopState(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ConsecutiveCrashes).To(Equal(2))
+		Expect(state.LastCrash.Equal(now)).To(BeTrue())
+	})
+})
+
+var _ = Describe("startup failure policy", func() {
+	now := time.Now()
+	policy := &apiv1.StartupFailurePolicyConfiguration{
+		MaxRestartAttempts: 2,
+		Window:             &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	It("is disabled by default", func() {
+		state := crashLoopState{}
+		for range 10 {
+			state.registerCrash(time.Second, now)
+		}
+		Expect(state.exceedsStartupFailurePolicy(nil, now)).To(BeFalse())
+		Expect(state.RecentCrashes).To(BeEmpty())
+	})
+
+	It("marks the instance as failed when the restart attempts are exhausted", func() {
+		state := crashLoopState{}
+		for range policy.MaxRestartAttempts {
+			state.registerCrash(time.Second, now)
+			Expect(state.exceedsStartupFailurePolicy(policy, now)).To(BeFalse())
+		}
+
+		state.registerCrash(time.Second, now)
+		Expect(state.exceedsStartupFailurePolicy(policy, now)).To(BeTrue())
+	})
+
+	It("only counts the crashes within the window", func() {
+		state := crashLoopState{}
+		state.registerCrash(time.Second, now.Add(-time.Hour))
+		state.registerCrash(time.Second, now.Add(-time.Minute))
+		state.registerCrash(time.Second, now)
+		Expect(state.exceedsStartupFailurePolicy(policy, now)).To(BeFalse())
+		Expect(state.RecentCrashes).To(HaveLen(2))
+	})
+
+	It("forgets the crashes when the postmaster was stable", func() {
+		state := crashLoopState{}
+		state.registerCrash(time.Second, now)
+		state.registerCrash(time.Second, now)
+		state.registerCrash(crashLoopStableUptime, now)
+		Expect(state.exceedsStartupFailurePolicy(policy, now)).To(BeFalse())
+		Expect(state.RecentCrashes).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("crash loop event message", func() {
+	state := crashLoopState{ConsecutiveCrashes: 4}
+
+	It("includes the latest log lines", func() {
+		message := buildCrashLoopMessage("cluster-example-1", state, 20*time.Second,
+			[]string{"LOG: starting PostgreSQL", "FATAL: configuration file contains errors"})
+		Expect(message).To(Equal("PostgreSQL on instance cluster-example-1 crashed 4 times in a row, " +
+			"delaying the next start by 20s. Latest log lines:\n" +
+			"LOG: starting PostgreSQL\nFATAL: configuration file contains errors"))
+	})
+
+	It("reports the permanent failure of the instance", func() {
+		message := buildPermanentFailureMessage("cluster-example-1",
+			crashLoopState{RecentCrashes: make([]time.Time, 4)},
+			&apiv1.StartupFailurePolicyConfiguration{MaxRestartAttempts: 3}, nil)
+		Expect(message).To(Equal("PostgreSQL on instance cluster-example-1 crashed 4 times within 10m0s, " +
+			"marking the instance as permanently failed. Delete the Pod once the issue is solved"))
+	})
+
+	It("is truncated when too long", func() {
+		message := buildCrashLoopMessage("cluster-example-1", state, 20*time.Second,
+			[]string{strings.Repeat("x", 2*crashLoopMaxEventMessageLength)})
+		Expect(message).To(HaveLen(crashLoopMaxEventMessageLength))
+		Expect(message).To(HaveSuffix("..."))
+	})
+})
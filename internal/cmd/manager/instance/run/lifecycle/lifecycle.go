@@ -26,8 +26,10 @@ import (
 	"os/exec"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/concurrency"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
@@ -36,10 +38,15 @@ import (
 // PostgresLifecycle implements the manager.Runnable interface for a postgres.Instance
 type PostgresLifecycle struct {
 	instance *postgres.Instance
+	recorder record.EventRecorder
 
 	globalCtx            context.Context
 	globalCancel         context.CancelFunc
 	systemInitialization concurrency.MultipleExecuted
+
+	// postmasterStartTime is the time when the current postmaster
+	// has been started, zero if it didn't start
+	postmasterStartTime time.Time
 }
 
 // NewPostgres creates a new PostgresLifecycle
@@ -47,10 +54,12 @@ func NewPostgres(
 	ctx context.Context,
 	instance *postgres.Instance,
 	initialization concurrency.MultipleExecuted,
+	recorder record.EventRecorder,
 ) *PostgresLifecycle {
 	ctx, cancel := context.WithCancel(ctx)
 	return &PostgresLifecycle{
 		instance:             instance,
+		recorder:             recorder,
 		globalCtx:            ctx,
 		globalCancel:         cancel,
 		systemInitialization: initialization,
@@ -73,6 +82,13 @@ func (i *PostgresLifecycle) Start(ctx context.Context) error {
 	// manager will shut down
 	defer i.globalCancel()
 
+	// A permanently failed instance stays down across the restarts
+	// of the container
+	if i.isPermanentlyFailed(ctx) {
+		i.waitForManualIntervention(ctx, signals)
+		return nil
+	}
+
 	// Every cycle correspond to the lifespan of a postmaster process
 	for {
 		contextLogger.Debug("starting the postgres loop")
@@ -115,20 +131,27 @@ func (i *PostgresLifecycle) Start(ctx context.Context) error {
 			case err := <-postMasterErrChan:
 				pgStopHandler(err)
 				if !i.instance.MightBeUnavailable() {
+					// Avoid restarting a postmaster which keeps crashing
+					// in a tight loop, or at all when the startup failure
+					// policy gave up on it
+					if i.throttleCrashLoop(ctx, i.postmasterUptime(), signals) {
+						i.waitForManualIntervention(ctx, signals)
+						return nil
+					}
 					return err
 				}
 
 			case <-ctx.Done():
 				// The controller manager asked us to terminate our operations.
-				// We shut down PostgreSQL and terminate using the smart
-				// stop delay.
+				// We shut down PostgreSQL and terminate using the configured
+				// shutdown mode.
 				if i.instance.InstanceManagerIsUpgrading.Load() {
 					contextLogger.Info("Context has been cancelled, but an instance manager online upgrade is in progress, " +
 						"will just exit")
 					return nil
 				}
 				contextLogger.Info("Context has been cancelled, shutting down and exiting")
-				if err := i.instance.TryShuttingDownSmartFast(ctx); err != nil {
+				if err := i.instance.TryShuttingDown(ctx); err != nil {
 					contextLogger.Error(err, "error shutting down instance, proceeding")
 				}
 				return nil
@@ -141,8 +164,9 @@ func (i *PostgresLifecycle) Start(ctx context.Context) error {
 				contextLogger.Info("Received termination signal",
 					"signal", sig,
 					"smartShutdownTimeout", i.instance.SmartStopDelay,
+					"shutdownMode", i.instance.ShutdownMode,
 				)
-				if err := i.instance.TryShuttingDownSmartFast(ctx); err != nil {
+				if err := i.instance.TryShuttingDown(ctx); err != nil {
 					contextLogger.Error(err, "error while shutting down instance, proceeding")
 				}
 				return nil
@@ -174,3 +198,12 @@ func (i *PostgresLifecycle) Start(ctx context.Context) error {
 		// process
 	}
 }
+
+// postmasterUptime returns for how long the latest postmaster has been running
+func (i *PostgresLifecycle) postmasterUptime() time.Duration {
+	if i.postmasterStartTime.IsZero() {
+		return 0
+	}
+
+	return time.Since(i.postmasterStartTime)
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/jackc/pgx/v5"
@@ -108,6 +109,7 @@ func (i *PostgresLifecycle) runPostgresAndWait(ctx context.Context) <-chan error
 		}
 
 		contextLogger.Info("postmaster started", "postMasterPID", postMasterPID)
+		i.postmasterStartTime = time.Now()
 
 		// Now we'll wait for PostgreSQL to accept connections, and setup everything required
 		// for replication and pg_rewind to work correctly.
@@ -133,6 +135,7 @@ func (i *PostgresLifecycle) runPostgresAndWait(ctx context.Context) <-chan error
 		return postmasterExitStatus
 	}
 
+	i.postmasterStartTime = time.Time{}
 	errChan := make(chan error, 1)
 
 	// The following goroutine runs the postmaster process, and stops
@@ -39,6 +39,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -67,6 +68,7 @@ func NewCmd() *cobra.Command {
 	var pgUpgradeArgs []string
 	var initdb string
 	var initdbArgs []string
+	var checkOnly bool
 
 	cmd := &cobra.Command{
 		Use:  "execute [options]",
@@ -97,6 +99,7 @@ func NewCmd() *cobra.Command {
 				pgUpgradeArgs: pgUpgradeArgs,
 				initdb:        initdb,
 				initdbArgs:    initdbArgs,
+				checkOnly:     checkOnly,
 			}
 			return info.upgradeSubCommand(ctx, instance)
 		},
@@ -126,6 +129,9 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().StringArrayVar(&initdbArgs, "initdb-args", nil,
 		`Additional arguments for "initdb" invocation.`+
 			`Use the --initdb-args flag multiple times to pass multiple arguments.`)
+	cmd.Flags().BoolVar(&checkOnly, "check", false,
+		`Only check the clusters with "pg_upgrade --check", without upgrading the data directory. `+
+			`The output of "pg_upgrade" is reported in the termination message of the container.`)
 
 	return cmd
 }
@@ -137,6 +143,7 @@ type upgradeInfo struct {
 	pgUpgradeArgs []string
 	initdb        string
 	initdbArgs    []string
+	checkOnly     bool
 }
 
 // nolint:gocognit
@@ -255,10 +262,18 @@ func (ui upgradeInfo) upgradeSubCommand(ctx context.Context, instance *postgres.
 	_ = fileutils.EnsurePgDataPerms(ui.pgData)
 	_ = fileutils.EnsurePgDataPerms(newDataDir)
 
+	if ui.checkOnly {
+		contextLogger.Info("Running pg_upgrade --check")
+		return ui.runPgUpgradeCheck(ctx, newDataDir, newWalDir)
+	}
+
 	contextLogger.Info("Running pg_upgrade")
 
 	if err := ui.runPgUpgrade(newDataDir); err != nil {
 		// TODO: in case of failures we should dump the content of the pg_upgrade logs
+		if errInner := restoreOldControlFile(ctx, ui.pgData); errInner != nil {
+			contextLogger.Error(errInner, "Error while restoring the control file of the old data directory")
+		}
 		return fmt.Errorf("error while running pg_upgrade: %w", err)
 	}
 
@@ -465,6 +480,80 @@ func (ui upgradeInfo) runPgUpgrade(
 	return nil
 }
 
+// runPgUpgradeCheck runs "pg_upgrade --check", which doesn't change the
+// old data directory, reporting its output in the termination message
+// of the container. The new data directory is removed afterwards
+func (ui upgradeInfo) runPgUpgradeCheck(
+	ctx context.Context,
+	newDataDir string,
+	newWalDir *string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	args := []string{
+		"--check",
+		"--link",
+		"--username", "postgres",
+		"--old-bindir", ui.oldBinDir,
+		"--old-datadir", ui.pgData,
+		"--new-datadir", newDataDir,
+	}
+	args = append(args, ui.pgUpgradeArgs...)
+
+	cmd := exec.Command(ui.pgUpgrade, args...) // #nosec
+	cmd.Dir = newDataDir
+	output, checkErr := cmd.CombinedOutput()
+	contextLogger.Info("pg_upgrade --check output", "output", string(output))
+
+	if err := writeTerminationMessage(output); err != nil {
+		contextLogger.Error(err, "Error while writing the termination message")
+	}
+
+	contextLogger.Info("Removing the new data directory", "directory", newDataDir)
+	if err := os.RemoveAll(newDataDir); err != nil {
+		return fmt.Errorf("failed to remove the directory: %w", err)
+	}
+	if newWalDir != nil {
+		if err := os.RemoveAll(*newWalDir); err != nil {
+			return fmt.Errorf("failed to remove the directory: %w", err)
+		}
+	}
+
+	if checkErr != nil {
+		return fmt.Errorf("error while running %q: %w", cmd, checkErr)
+	}
+
+	return nil
+}
+
+// writeTerminationMessage writes the tail of the passed output in the
+// termination message of the container, which is limited to 4096 bytes
+func writeTerminationMessage(output []byte) error {
+	const maxTerminationMessageLength = 4096
+
+	message := strings.TrimSpace(string(output))
+	if len(message) > maxTerminationMessageLength {
+		message = message[len(message)-maxTerminationMessageLength:]
+	}
+
+	return os.WriteFile(corev1.TerminationMessagePathDefault, []byte(message), 0o600)
+}
+
+// restoreOldControlFile makes the old data directory usable again after
+// a failure of pg_upgrade in link mode, which renames its control file
+// once the new data directory starts sharing its files
+func restoreOldControlFile(ctx context.Context, pgData string) error {
+	contextLogger := log.FromContext(ctx)
+
+	controlFile := path.Join(pgData, "global", "pg_control")
+	if _, err := os.Stat(controlFile + ".old"); os.IsNotExist(err) {
+		return nil
+	}
+
+	contextLogger.Info("Restoring the control file of the old data directory", "file", controlFile)
+	return os.Rename(controlFile+".old", controlFile)
+}
+
 func moveDataInPlace(
 	ctx context.Context,
 	pgData string,
@@ -63,6 +63,15 @@ func NewCmd() *cobra.Command {
 			}
 
 			if err := archiver.Run(ctx, podName, pgData, cluster, args[0]); err != nil {
+				if errors.Is(err, archiver.ErrWALArchivingPaused) {
+					contextLog.Info("WAL archiving is paused, retaining the WAL file", "walName", args[0])
+					if reqErr := localClient.Cluster().SetWALArchivePausedCondition(
+						ctx, "WAL archiving has been paused"); reqErr != nil {
+						contextLog.Error(reqErr, "while invoking the set wal archive condition endpoint")
+					}
+					return err
+				}
+
 				if errors.Is(err, errSwitchoverInProgress) {
 					contextLog.Warning("Refusing to archive WALs until the switchover is not completed",
 						"err", err)
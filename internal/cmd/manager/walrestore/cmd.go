@@ -25,12 +25,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	barmanCommand "github.com/cloudnative-pg/barman-cloud/pkg/command"
 	barmanRestorer "github.com/cloudnative-pg/barman-cloud/pkg/restorer"
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	"github.com/spf13/cobra"
@@ -41,6 +44,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/local"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 )
 
 var (
@@ -60,6 +64,10 @@ const (
 	// SpoolDirectory is the directory where we spool the WAL files that
 	// were pre-archived in parallel
 	SpoolDirectory = postgres.ScratchDataDirectory + "/wal-restore-spool"
+
+	// restoreCommandName is the name used in the logs for the output
+	// of the user-provided restore command
+	restoreCommandName = "restore-command"
 )
 
 // NewCmd creates a new cobra command
@@ -141,6 +149,17 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 		return nil
 	}
 
+	// The user can replace barman-cloud with a custom command
+	if restoreCommand := cluster.GetWALRestoreCommand(); restoreCommand != "" {
+		if err := runRestoreCommand(ctx, restoreCommand, pgData, walName, destinationPath); err != nil {
+			return fmt.Errorf("while running the restore command: %w", err)
+		}
+		contextLog.Info("Restored WAL file with the restore command",
+			"walName", walName,
+			"totalTime", time.Since(startTime))
+		return nil
+	}
+
 	recoverClusterName, recoverEnv, barmanConfiguration, err := GetRecoverConfiguration(cluster, podName)
 	if errors.Is(err, ErrNoBackupConfigured) {
 		// Backup not configured, skipping WAL
@@ -255,6 +274,23 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 	return nil
 }
 
+// runRestoreCommand restores the passed WAL file with the restore command
+// provided by the user, which is run by the shell after replacing its
+// placeholders
+func runRestoreCommand(ctx context.Context, restoreCommand, pgData, walName, destinationPath string) error {
+	if !filepath.IsAbs(destinationPath) {
+		destinationPath = filepath.Join(pgData, destinationPath)
+	}
+
+	command := postgres.ExpandWALCommand(restoreCommand, destinationPath, walName)
+	log.FromContext(ctx).Debug("Restoring WAL file with the restore command",
+		"walName", walName, "command", command)
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command) // #nosec G204
+	cmd.Dir = pgData
+	return execlog.RunBuffering(cmd, restoreCommandName)
+}
+
 // restoreWALViaPlugins requests every capable plugin to restore the passed
 // WAL file, and returns an error if every plugin failed. It will not return
 // an error if there's no plugin capable of WAL archiving too
@@ -353,11 +389,7 @@ func GetRecoverConfiguration(
 			return "", nil, nil, ErrNoBackupConfigured
 		}
 		configuration := externalCluster.BarmanObjectStore
-		if configuration.EndpointCA != nil && configuration.AWS != nil {
-			env = append(env, fmt.Sprintf("AWS_CA_BUNDLE=%s", postgres.BarmanRestoreEndpointCACertificateLocation))
-		} else if configuration.EndpointCA != nil && configuration.Azure != nil {
-			env = append(env, fmt.Sprintf("REQUESTS_CA_BUNDLE=%s", postgres.BarmanRestoreEndpointCACertificateLocation))
-		}
+		env = specs.AppendBarmanEndpointCAEnv(env, configuration, postgres.BarmanRestoreEndpointCACertificateLocation)
 		return externalCluster.Name, env, externalCluster.BarmanObjectStore, nil
 	}
 
@@ -365,11 +397,7 @@ func GetRecoverConfiguration(
 	// back up this cluster
 	if cluster.Spec.Backup != nil && cluster.Spec.Backup.BarmanObjectStore != nil {
 		configuration := cluster.Spec.Backup.BarmanObjectStore
-		if configuration.EndpointCA != nil && configuration.AWS != nil {
-			env = append(env, fmt.Sprintf("AWS_CA_BUNDLE=%s", postgres.BarmanBackupEndpointCACertificateLocation))
-		} else if configuration.EndpointCA != nil && configuration.Azure != nil {
-			env = append(env, fmt.Sprintf("REQUESTS_CA_BUNDLE=%s", postgres.BarmanBackupEndpointCACertificateLocation))
-		}
+		env = specs.AppendBarmanEndpointCAEnv(env, configuration, postgres.BarmanBackupEndpointCACertificateLocation)
 		return cluster.Name, env, cluster.Spec.Backup.BarmanObjectStore, nil
 	}
 
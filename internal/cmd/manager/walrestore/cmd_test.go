@@ -20,6 +20,9 @@ SPDX-License-Identifier: Apache-2.0
 package walrestore
 
 import (
+	"os"
+	"path/filepath"
+
 	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -113,3 +116,20 @@ var _ = Describe("Function isStreamingAvailable", func() {
 		Expect(isStreamingAvailable(&cluster, "primaryPod")).To(BeTrue())
 	})
 })
+
+var _ = Describe("WAL restore command", func() {
+	It("restores the WAL file in the path requested by PostgreSQL", func(ctx SpecContext) {
+		pgData := GinkgoT().TempDir()
+		archive := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(archive, "000000010000000000000001"),
+			[]byte("wal"), 0o600)).To(Succeed())
+
+		Expect(runRestoreCommand(ctx, "cp "+archive+"/%f %p", pgData,
+			"000000010000000000000001", "pg_wal/RECOVERYXLOG")).ToNot(Succeed())
+
+		Expect(os.Mkdir(filepath.Join(pgData, "pg_wal"), 0o700)).To(Succeed())
+		Expect(runRestoreCommand(ctx, "cp "+archive+"/%f %p", pgData,
+			"000000010000000000000001", "pg_wal/RECOVERYXLOG")).To(Succeed())
+		Expect(filepath.Join(pgData, "pg_wal", "RECOVERYXLOG")).To(BeARegularFile())
+	})
+})
@@ -50,6 +50,7 @@ type backupCommandOptions struct {
 	waitForArchive      *bool
 	pluginName          string
 	pluginParameters    pluginParameters
+	pgDump              *apiv1.BackupPgDumpConfiguration
 }
 
 func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfiguration {
@@ -67,11 +68,14 @@ func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfig
 func NewCmd() *cobra.Command {
 	var backupName, backupTarget, backupMethod, online, immediateCheckpoint, waitForArchive, pluginName string
 	var pluginParameters pluginParameters
+	var database string
+	var schemaOnly, dataOnly bool
 
 	backupMethods := []string{
 		string(apiv1.BackupMethodBarmanObjectStore),
 		string(apiv1.BackupMethodVolumeSnapshot),
 		string(apiv1.BackupMethodPlugin),
+		string(apiv1.BackupMethodPgDump),
 	}
 
 	backupSubcommand := &cobra.Command{
@@ -127,6 +131,25 @@ func NewCmd() *cobra.Command {
 				}
 			}
 
+			var pgDump *apiv1.BackupPgDumpConfiguration
+			if backupMethod == string(apiv1.BackupMethodPgDump) {
+				if len(database) == 0 {
+					return fmt.Errorf("database is required when backup method is %s",
+						apiv1.BackupMethodPgDump)
+				}
+				if schemaOnly && dataOnly {
+					return fmt.Errorf("schema-only and data-only cannot be used together")
+				}
+				pgDump = &apiv1.BackupPgDumpConfiguration{
+					Database:   database,
+					SchemaOnly: schemaOnly,
+					DataOnly:   dataOnly,
+				}
+			} else if len(database) > 0 || schemaOnly || dataOnly {
+				return fmt.Errorf("database, schema-only and data-only are allowed only when backup method is %s",
+					apiv1.BackupMethodPgDump)
+			}
+
 			var cluster apiv1.Cluster
 			// check if the cluster exists
 			err := plugin.Client.Get(
@@ -166,6 +189,7 @@ func NewCmd() *cobra.Command {
 					waitForArchive:      parsedWaitForArchive,
 					pluginName:          pluginName,
 					pluginParameters:    pluginParameters,
+					pgDump:              pgDump,
 				})
 		},
 	}
@@ -228,6 +252,21 @@ func NewCmd() *cobra.Command {
 			"is allowed only when the backup method is set to 'plugin'",
 	)
 
+	backupSubcommand.Flags().StringVar(&database, "database", "",
+		"The name of the database to be dumped. This option "+
+			"is required when the backup method is set to 'pgDump'",
+	)
+
+	backupSubcommand.Flags().BoolVar(&schemaOnly, "schema-only", false,
+		"Dump only the schema of the database, without the data. This option "+
+			"is allowed only when the backup method is set to 'pgDump'",
+	)
+
+	backupSubcommand.Flags().BoolVar(&dataOnly, "data-only", false,
+		"Dump only the data of the database, without the schema. This option "+
+			"is allowed only when the backup method is set to 'pgDump'",
+	)
+
 	return backupSubcommand
 }
 
@@ -246,6 +285,7 @@ func createBackup(ctx context.Context, options backupCommandOptions) error {
 			Method:              options.method,
 			Online:              options.online,
 			OnlineConfiguration: options.getOnlineConfiguration(),
+			PgDump:              options.pgDump,
 		},
 	}
 	utils.LabelClusterName(&backup.ObjectMeta, options.clusterName)
@@ -68,5 +68,46 @@ connect to the PostgreSQL cluster.`,
 	certificateCmd.Flags().Bool(
 		"dry-run", false, "If specified, the secret is not created")
 
+	certificateCmd.AddCommand(newRotateCmd())
+
 	return certificateCmd
 }
+
+// newRotateCmd creates the new "certificate rotate" subcommand
+func newRotateCmd() *cobra.Command {
+	rotateCmd := &cobra.Command{
+		Use:   "rotate CLUSTER",
+		Short: `Rotate the operator-managed certificates of a cluster`,
+		Long: `This command deletes the selected operator-managed certificate secrets of a
+cluster. The operator generates them again, and every instance reloads the
+new certificates without restarting PostgreSQL.`,
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			clusterName := args[0]
+
+			server, _ := cmd.Flags().GetBool("server")
+			clientCA, _ := cmd.Flags().GetBool("client-ca")
+			replication, _ := cmd.Flags().GetBool("replication")
+
+			return Rotate(ctx, plugin.Client, plugin.Namespace, clusterName, RotateTargets{
+				Server:      server,
+				ClientCA:    clientCA,
+				Replication: replication,
+			})
+		},
+	}
+
+	rotateCmd.Flags().Bool(
+		"server", false, "Rotate the server certificate")
+	rotateCmd.Flags().Bool(
+		"client-ca", false,
+		"Rotate the client CA, together with the certificates it signed")
+	rotateCmd.Flags().Bool(
+		"replication", false, "Rotate the certificate of the streaming_replica user")
+
+	return rotateCmd
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// RotateTargets are the certificates to be rotated
+type RotateTargets struct {
+	// Server is the server certificate of PostgreSQL
+	Server bool
+
+	// ClientCA is the CA used to sign and verify the client certificates
+	ClientCA bool
+
+	// Replication is the client certificate of the streaming_replica user
+	Replication bool
+}
+
+// errNothingToRotate is raised when no certificate has been selected
+var errNothingToRotate = errors.New("at least one between --server, --client-ca and --replication is required")
+
+// Rotate deletes the selected operator-managed certificate secrets of a cluster,
+// and triggers a reconciliation loop. The operator will generate them again
+// and every instance will reload PostgreSQL to use the new certificates.
+func Rotate(
+	ctx context.Context,
+	cli client.Client,
+	namespace, clusterName string,
+	targets RotateTargets,
+) error {
+	if !targets.Server && !targets.ClientCA && !targets.Replication {
+		return errNothingToRotate
+	}
+
+	var cluster apiv1.Cluster
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster); err != nil {
+		return err
+	}
+
+	secretNames, err := getSecretsToRotate(&cluster, targets)
+	if err != nil {
+		return err
+	}
+
+	for _, secretName := range secretNames {
+		secret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      secretName,
+			},
+		}
+		if err := cli.Delete(ctx, &secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("while deleting secret %s: %w", secretName, err)
+		}
+		fmt.Printf("secret %s deleted\n", secretName)
+	}
+
+	// Changing the annotation triggers a reconciliation loop in the
+	// operator, which regenerates the missing secrets
+	origCluster := cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string)
+	}
+	cluster.Annotations[utils.ClusterReloadAnnotationName] = pgTime.GetCurrentTimestamp()
+	cluster.ManagedFields = nil
+	if err := cli.Patch(ctx, &cluster, client.MergeFrom(origCluster)); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s certificates will be regenerated and reloaded\n", cluster.Name)
+	if targets.ClientCA {
+		fmt.Println("Client certificates issued with the previous CA, i.e. the ones created " +
+			"with the certificate command, are not valid anymore and need to be created again")
+	}
+	if targets.ClientCA && cluster.GetServerCASecretName() == cluster.GetClientCASecretName() {
+		fmt.Printf("The server CA has been rotated too: clients using sslmode=verify-ca or "+
+			"verify-full need the new CA from the %s secret to connect\n", cluster.GetServerCASecretName())
+	}
+
+	return nil
+}
+
+// getSecretsToRotate gets the names of the secrets to be deleted for the
+// requested targets. A certificate not managed by the operator can't be
+// rotated and raises an error.
+func getSecretsToRotate(cluster *apiv1.Cluster, targets RotateTargets) ([]string, error) {
+	certificates := cluster.Spec.Certificates
+	if certificates == nil {
+		certificates = &apiv1.CertificatesConfiguration{}
+	}
+
+	// The replication certificate is signed by the client CA, and
+	// needs to be generated again when the client CA is rotated.
+	// The same applies to the server certificate when the server
+	// and the client CA are the same.
+	rotateReplication := targets.Replication || targets.ClientCA
+	rotateServer := targets.Server ||
+		(targets.ClientCA && cluster.GetServerCASecretName() == cluster.GetClientCASecretName())
+
+	var result []string
+	if targets.ClientCA {
+		if certificates.ClientCASecret != "" {
+			return nil, fmt.Errorf("cannot rotate the user-provided client CA secret %s",
+				certificates.ClientCASecret)
+		}
+		result = append(result, cluster.GetClientCASecretName())
+	}
+
+	if rotateServer {
+		if certificates.ServerTLSSecret != "" {
+			return nil, fmt.Errorf("cannot rotate the user-provided server TLS secret %s",
+				certificates.ServerTLSSecret)
+		}
+		result = append(result, cluster.GetServerTLSSecretName())
+	}
+
+	if rotateReplication {
+		if certificates.ReplicationTLSSecret != "" {
+			return nil, fmt.Errorf("cannot rotate the user-provided replication TLS secret %s",
+				certificates.ReplicationTLSSecret)
+		}
+		result = append(result, cluster.GetReplicationSecretName())
+	}
+
+	return result, nil
+}
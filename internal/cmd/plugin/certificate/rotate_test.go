@@ -0,0 +1,134 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certificate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getSecretsToRotate", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+		}
+	})
+
+	It("rotates the server certificate alone", func() {
+		secrets, err := getSecretsToRotate(cluster, RotateTargets{Server: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(ConsistOf("cluster-example-server"))
+	})
+
+	It("rotates the replication certificate alone", func() {
+		secrets, err := getSecretsToRotate(cluster, RotateTargets{Replication: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(ConsistOf("cluster-example-replication"))
+	})
+
+	It("rotates every certificate signed by the client CA", func() {
+		secrets, err := getSecretsToRotate(cluster, RotateTargets{ClientCA: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(ConsistOf(
+			"cluster-example-ca",
+			"cluster-example-server",
+			"cluster-example-replication",
+		))
+	})
+
+	It("keeps the server certificate when it is signed by a different CA", func() {
+		cluster.Spec.Certificates = &apiv1.CertificatesConfiguration{
+			ServerCASecret:  "server-ca",
+			ServerTLSSecret: "server-tls",
+		}
+		secrets, err := getSecretsToRotate(cluster, RotateTargets{ClientCA: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(ConsistOf("cluster-example-ca", "cluster-example-replication"))
+	})
+
+	It("refuses to rotate user-provided certificates", func() {
+		cluster.Spec.Certificates = &apiv1.CertificatesConfiguration{
+			ServerCASecret:  "server-ca",
+			ServerTLSSecret: "server-tls",
+		}
+		_, err := getSecretsToRotate(cluster, RotateTargets{Server: true})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Rotate", func() {
+	const namespace = "default"
+
+	var (
+		cluster *apiv1.Cluster
+		cli     client.Client
+	)
+
+	newSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cluster-example"},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(
+				cluster,
+				newSecret("cluster-example-ca"),
+				newSecret("cluster-example-server"),
+				newSecret("cluster-example-replication"),
+			).
+			Build()
+	})
+
+	It("requires at least one target", func(ctx SpecContext) {
+		err := Rotate(ctx, cli, namespace, cluster.Name, RotateTargets{})
+		Expect(err).To(MatchError(errNothingToRotate))
+	})
+
+	It("deletes the selected secrets and triggers a reconciliation", func(ctx SpecContext) {
+		err := Rotate(ctx, cli, namespace, cluster.Name, RotateTargets{Server: true})
+		Expect(err).ToNot(HaveOccurred())
+
+		var secret corev1.Secret
+		err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "cluster-example-server"}, &secret)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "cluster-example-ca"}, &secret)).
+			To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).To(HaveKey(utils.ClusterReloadAnnotationName))
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connect
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the "connect" command
+func NewCmd() *cobra.Command {
+	var options Options
+
+	cmd := &cobra.Command{
+		Use:   "connect CLUSTER [-- PSQL_ARGS...]",
+		Short: "Connect to a CloudNativePG cluster with the application credentials",
+		Long: "This command resolves the service of the cluster, the read-write one unless --replica is passed, " +
+			"and the credentials of the user from the secrets generated by CloudNativePG. " +
+			"It then starts a local psql session through a port-forward to an instance behind that service, " +
+			"or prints the connection string of the service with --dsn.",
+		Args: validateConnectArgs,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		GroupID: plugin.GroupIDDatabase,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := getConnectionInfo(cmd.Context(), plugin.Client, plugin.Namespace, args[0], options)
+			if err != nil {
+				return err
+			}
+
+			if options.PrintDSN {
+				fmt.Println(info.dsn(plugin.Namespace))
+				return nil
+			}
+
+			return startSession(cmd.Context(), info, plugin.Namespace, args[1:])
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&options.Replica,
+		"replica",
+		false,
+		"Connect to the read-only service, reaching a replica (by default connects to the read-write service)",
+	)
+
+	cmd.Flags().StringVarP(
+		&options.DBName,
+		"dbname",
+		"d",
+		"",
+		"The database to connect to (defaults to the application database)",
+	)
+
+	cmd.Flags().StringVarP(
+		&options.User,
+		"user",
+		"U",
+		"",
+		"The user to connect as (defaults to the owner of the application database)",
+	)
+
+	cmd.Flags().BoolVar(
+		&options.PrintDSN,
+		"dsn",
+		false,
+		"Print the connection string of the service, including the password, instead of connecting",
+	)
+
+	return cmd
+}
+
+func validateConnectArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+		return err
+	}
+
+	if cmd.ArgsLenAtDash() > 1 {
+		return fmt.Errorf("psqlArgs should be passed after the -- delimiter")
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connect
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// superuserName is the name of the PostgreSQL superuser, whose password
+// is stored in the superuser secret when the superuser access is enabled
+const superuserName = "postgres"
+
+// Options are the options of the connect command
+type Options struct {
+	// Connect to the read-only service instead of the read-write one
+	Replica bool
+
+	// The database to connect to, defaulting to the application one
+	DBName string
+
+	// The user to connect as, defaulting to the application one
+	User string
+
+	// Print the connection string instead of connecting
+	PrintDSN bool
+}
+
+// connectionInfo contains what is needed to connect to a cluster
+type connectionInfo struct {
+	// The name of the cluster
+	clusterName string
+
+	// The name of the service to connect to
+	serviceName string
+
+	// The role of the instances behind the service
+	role string
+
+	// The database to connect to
+	dbname string
+
+	// The user to connect as
+	user string
+
+	// The password of the user, if known
+	password string
+}
+
+// getConnectionInfo resolves the service to connect to and the credentials
+// to be used, reading the password of the user from the secrets of the cluster
+func getConnectionInfo(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	clusterName string,
+	options Options,
+) (*connectionInfo, error) {
+	var cluster apiv1.Cluster
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster); err != nil {
+		return nil, fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	info := &connectionInfo{
+		clusterName: cluster.Name,
+		serviceName: cluster.GetServiceReadWriteName(),
+		role:        specs.ClusterRoleLabelPrimary,
+		dbname:      options.DBName,
+		user:        options.User,
+	}
+	if options.Replica {
+		if !cluster.IsReadOnlyServiceEnabled() {
+			return nil, fmt.Errorf("the read-only service of cluster %s is disabled", cluster.Name)
+		}
+		info.serviceName = cluster.GetServiceReadOnlyName()
+		info.role = specs.ClusterRoleLabelReplica
+	} else if !cluster.IsReadWriteServiceEnabled() {
+		return nil, fmt.Errorf("the read-write service of cluster %s is disabled", cluster.Name)
+	}
+
+	appSecret, err := getSecret(ctx, cli, namespace, cluster.GetApplicationSecretName())
+	if err != nil {
+		return nil, err
+	}
+	appUser := string(appSecret.Data["username"])
+
+	if info.user == "" {
+		info.user = appUser
+	}
+	if info.user == "" {
+		return nil, fmt.Errorf("cannot find the application user of cluster %s, please pass it with --user",
+			cluster.Name)
+	}
+
+	if info.dbname == "" {
+		info.dbname = getApplicationDatabase(&cluster, appSecret)
+	}
+
+	var passwordSecret *corev1.Secret
+	switch secretName := getPasswordSecretName(&cluster, info.user, appUser); secretName {
+	case "":
+	case cluster.GetApplicationSecretName():
+		passwordSecret = appSecret
+	default:
+		if passwordSecret, err = getSecret(ctx, cli, namespace, secretName); err != nil {
+			return nil, err
+		}
+	}
+	if passwordSecret != nil {
+		info.password = string(passwordSecret.Data[corev1.BasicAuthPasswordKey])
+	}
+
+	return info, nil
+}
+
+// getSecret gets the passed secret, returning an empty one if it doesn't exist
+func getSecret(ctx context.Context, cli client.Client, namespace, name string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret)
+	if apierrs.IsNotFound(err) {
+		return &corev1.Secret{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while getting secret %s: %w", name, err)
+	}
+
+	return &secret, nil
+}
+
+// getApplicationDatabase returns the name of the application database,
+// as reported in the application secret or in the bootstrap configuration
+func getApplicationDatabase(cluster *apiv1.Cluster, appSecret *corev1.Secret) string {
+	if dbname := string(appSecret.Data["dbname"]); dbname != "" && dbname != "*" {
+		return dbname
+	}
+
+	if dbname := cluster.GetApplicationDatabaseName(); dbname != "" {
+		return dbname
+	}
+
+	return apiv1.DefaultApplicationDatabaseName
+}
+
+// getPasswordSecretName returns the name of the secret containing the
+// password of the passed user, or an empty string if it is unknown:
+// the application user, the superuser and the managed roles are supported
+func getPasswordSecretName(cluster *apiv1.Cluster, user, appUser string) string {
+	switch user {
+	case appUser:
+		return cluster.GetApplicationSecretName()
+	case superuserName:
+		return cluster.GetSuperuserSecretName()
+	}
+
+	if cluster.Spec.Managed == nil {
+		return ""
+	}
+
+	for _, role := range cluster.Spec.Managed.Roles {
+		if role.Name == user && role.PasswordSecret != nil {
+			return role.PasswordSecret.Name
+		}
+	}
+
+	return ""
+}
+
+// dsn returns the connection string of the service, as reachable from
+// inside the Kubernetes cluster
+func (info *connectionInfo) dsn(namespace string) string {
+	user := url.User(info.user)
+	if info.password != "" {
+		user = url.UserPassword(info.user, info.password)
+	}
+
+	dsn := url.URL{
+		Scheme: "postgresql",
+		User:   user,
+		Host:   fmt.Sprintf("%s.%s:%s", info.serviceName, namespace, strconv.Itoa(postgres.ServerPort)),
+		Path:   info.dbname,
+	}
+	return dsn.String()
+}
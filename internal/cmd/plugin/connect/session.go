@@ -0,0 +1,162 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// psqlCommand is the client used to connect to the cluster
+const psqlCommand = "psql"
+
+// startSession starts a local psql session connected, through a port-forward,
+// to a ready instance with the role of the instances behind the requested
+// service. The password is passed to psql in its environment.
+func startSession(ctx context.Context, info *connectionInfo, namespace string, psqlArgs []string) error {
+	psqlPath, err := exec.LookPath(psqlCommand)
+	if err != nil {
+		return fmt.Errorf("%s is required to connect, use --dsn to get the connection string "+
+			"or `kubectl cnpg psql` to run it inside an instance: %w", psqlCommand, err)
+	}
+
+	podName, err := getTargetPod(ctx, plugin.Client, namespace, info)
+	if err != nil {
+		return err
+	}
+
+	stopChannel := make(chan struct{})
+	readyChannel := make(chan struct{})
+	forwarder, err := newPortForwarder(namespace, podName, stopChannel, readyChannel)
+	if err != nil {
+		return err
+	}
+	defer close(stopChannel)
+
+	forwardErr := make(chan error, 1)
+	go func() {
+		forwardErr <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyChannel:
+	case err := <-forwardErr:
+		return fmt.Errorf("while forwarding a local port to pod %s: %w", podName, err)
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, psqlPath, append([]string{ // #nosec G204
+		"-h", "127.0.0.1",
+		"-p", strconv.Itoa(int(ports[0].Local)),
+		"-d", info.dbname,
+		"-U", info.user,
+	}, psqlArgs...)...)
+	cmd.Env = os.Environ()
+	if info.password != "" {
+		cmd.Env = append(cmd.Env, "PGPASSWORD="+info.password)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// The interrupts are meant for psql, which uses them to cancel the
+	// running query, and must not terminate the port-forward
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	return cmd.Run()
+}
+
+// getTargetPod returns the name of a ready instance having the role of
+// the instances behind the service to connect to
+func getTargetPod(ctx context.Context, cli client.Client, namespace string, info *connectionInfo) (string, error) {
+	var pods corev1.PodList
+	if err := cli.List(
+		ctx,
+		&pods,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			utils.ClusterLabelName:             info.clusterName,
+			utils.ClusterInstanceRoleLabelName: info.role,
+		},
+	); err != nil {
+		return "", err
+	}
+
+	for idx := range pods.Items {
+		if utils.IsPodReady(pods.Items[idx]) {
+			return pods.Items[idx].Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot find a ready instance behind service %s", info.serviceName)
+}
+
+// newPortForwarder creates a port-forward from a random local port to the
+// PostgreSQL port of the passed pod
+func newPortForwarder(
+	namespace string,
+	podName string,
+	stopChannel <-chan struct{},
+	readyChannel chan struct{},
+) (*portforward.PortForwarder, error) {
+	req := plugin.ClientInterface.CoreV1().
+		RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(plugin.Config)
+	if err != nil {
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	return portforward.NewOnAddresses(
+		dialer,
+		[]string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", postgres.ServerPort)},
+		stopChannel,
+		readyChannel,
+		io.Discard,
+		os.Stderr,
+	)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explain
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the "explain" command
+func NewCmd() *cobra.Command {
+	var query string
+	var queryFile string
+	var dbName string
+
+	cmd := &cobra.Command{
+		Use:   "explain CLUSTER (--query SQL | --file FILENAME)",
+		Short: "Compare the plan of a query across the instances of a CloudNativePG cluster",
+		Long: "This command runs EXPLAIN for the passed query on every instance of the cluster, " +
+			"in a read-only session, and compares the plan of each replica with the one of the primary. " +
+			"The differences in the plan, in the estimated rows and in the planner settings are reported.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		GroupID: plugin.GroupIDTroubleshooting,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+
+			sql, err := getQuery(query, queryFile)
+			if err != nil {
+				return err
+			}
+
+			return Run(cmd.Context(), clusterName, dbName, sql)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&query,
+		"query",
+		"q",
+		"",
+		"The query to explain",
+	)
+
+	cmd.Flags().StringVarP(
+		&queryFile,
+		"file",
+		"f",
+		"",
+		"The file containing the query to explain",
+	)
+
+	cmd.Flags().StringVarP(
+		&dbName,
+		"db-name",
+		"d",
+		"app",
+		"The name of the database where the query is explained. Defaults to: app",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("query", "file")
+	cmd.MarkFlagsOneRequired("query", "file")
+
+	return cmd
+}
+
+// getQuery gets the query to explain, either passed directly or read from
+// a file, without the trailing semicolon
+func getQuery(query, queryFile string) (string, error) {
+	if queryFile != "" {
+		content, err := os.ReadFile(queryFile) // #nosec
+		if err != nil {
+			return "", fmt.Errorf("while reading the query file: %w", err)
+		}
+		query = string(content)
+	}
+
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if query == "" {
+		return "", fmt.Errorf("the query to explain is empty")
+	}
+
+	return query, nil
+}
@@ -0,0 +1,243 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// explainTimeout is the maximum time spent explaining the query on an
+// instance
+const explainTimeout = 30 * time.Second
+
+// errExplainFailed is raised when the query could not be explained on at
+// least one instance
+var errExplainFailed = errors.New("the query could not be explained on one or more instances")
+
+// instanceResult is the outcome of the EXPLAIN of the query on an instance
+type instanceResult struct {
+	podName string
+	primary bool
+	plan    *queryPlan
+	err     error
+}
+
+// Run explains the passed query on every instance of the cluster, and
+// compares the plan of each replica with the one of the primary
+func Run(ctx context.Context, clusterName, dbName, query string) error {
+	pods, primaryPod, err := resources.GetInstancePods(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("cluster does not exist or is not accessible")
+	}
+
+	sortInstancePods(pods, primaryPod.Name)
+
+	results := make([]instanceResult, 0, len(pods))
+	for _, pod := range pods {
+		results = append(results, explainOnInstance(ctx, pod, pod.Name == primaryPod.Name, dbName, query))
+	}
+
+	printResults(results)
+
+	for _, result := range results {
+		if result.err != nil {
+			return errExplainFailed
+		}
+	}
+
+	return nil
+}
+
+// sortInstancePods sorts the pods by name, with the primary first
+func sortInstancePods(pods []corev1.Pod, primaryPodName string) {
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		switch {
+		case a.Name == primaryPodName:
+			return -1
+		case b.Name == primaryPodName:
+			return 1
+		default:
+			return strings.Compare(a.Name, b.Name)
+		}
+	})
+}
+
+// explainOnInstance explains the query on the instance running in the
+// passed pod
+func explainOnInstance(
+	ctx context.Context,
+	pod corev1.Pod,
+	primary bool,
+	dbName string,
+	query string,
+) instanceResult {
+	result := instanceResult{podName: pod.Name, primary: primary}
+
+	timeout := explainTimeout
+	stdout, _, err := utils.ExecCommand(
+		ctx,
+		plugin.ClientInterface,
+		plugin.Config,
+		pod,
+		specs.PostgresContainerName,
+		&timeout,
+		getPsqlCommand(dbName, query)...,
+	)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	result.plan, result.err = parseQueryPlan(stdout)
+	return result
+}
+
+// getPsqlCommand gets the psql invocation explaining the query in a
+// read-only session, reporting the plan in JSON format together with
+// the settings affecting the planner
+func getPsqlCommand(dbName, query string) []string {
+	return []string{
+		"psql",
+		"-U",
+		"postgres",
+		"-d",
+		dbName,
+		"-X",
+		"-q",
+		"-A",
+		"-t",
+		"-v",
+		"ON_ERROR_STOP=1",
+		"-c",
+		"SET SESSION CHARACTERISTICS AS TRANSACTION READ ONLY",
+		"-c",
+		"EXPLAIN (FORMAT JSON, SETTINGS) " + query,
+	}
+}
+
+// getReferenceResult gets the result the other ones are compared to,
+// which is the one of the primary unless it has not been explained there
+func getReferenceResult(results []instanceResult) *instanceResult {
+	var reference *instanceResult
+	for idx := range results {
+		result := &results[idx]
+		if result.err != nil {
+			continue
+		}
+		if result.primary {
+			return result
+		}
+		if reference == nil {
+			reference = result
+		}
+	}
+
+	return reference
+}
+
+// printResults reports the plan of the query on each instance, and its
+// differences from the reference plan
+func printResults(results []instanceResult) {
+	reference := getReferenceResult(results)
+
+	summary := tabby.New()
+	summary.AddHeader("Instance", "Role", "Plan", "Total cost", "Estimated rows")
+	differences := make(map[string]planDifferences, len(results))
+	for idx := range results {
+		result := &results[idx]
+		role := specs.ClusterRoleLabelReplica
+		if result.primary {
+			role = specs.ClusterRoleLabelPrimary
+		}
+
+		if result.err != nil {
+			summary.AddLine(result.podName, role, aurora.Red(fmt.Sprintf("FAILED (%v)", result.err)), "", "")
+			continue
+		}
+
+		outcome := aurora.Green("reference")
+		if result != reference {
+			diff := comparePlans(reference.plan, result.plan)
+			outcome = aurora.Green("same")
+			if !diff.isEmpty() {
+				differences[result.podName] = diff
+				outcome = aurora.Red("different")
+			}
+		}
+		summary.AddLine(result.podName, role, outcome,
+			fmt.Sprintf("%.2f", result.plan.Plan.TotalCost), fmt.Sprintf("%.0f", result.plan.Plan.PlanRows))
+	}
+	summary.Print()
+
+	for idx := range results {
+		result := &results[idx]
+		diff, ok := differences[result.podName]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(os.Stdout, "\n--- %s compared to %s\n", result.podName, reference.podName)
+		if diff.differentShape {
+			fmt.Fprintf(os.Stdout, "%s\n", aurora.Red("Different plan"))
+			printPlan(reference)
+			printPlan(result)
+		}
+		printList("Different estimated rows", diff.estimates)
+		printList("Different planner settings", diff.settings)
+	}
+}
+
+// printPlan prints the plan of the query on an instance
+func printPlan(result *instanceResult) {
+	fmt.Fprintf(os.Stdout, "%s:\n", result.podName)
+	for _, line := range result.plan.lines() {
+		fmt.Fprintf(os.Stdout, "  %s\n", line)
+	}
+}
+
+// printList prints a list of differences under the passed title
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", aurora.Red(title))
+	for _, item := range items {
+		fmt.Fprintf(os.Stdout, "  %s\n", item)
+	}
+}
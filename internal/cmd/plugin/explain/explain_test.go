@@ -0,0 +1,100 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("explain", func() {
+	It("uses the query passed via the command line, without the trailing semicolon", func() {
+		Expect(getQuery("SELECT * FROM orders;\n", "")).To(Equal("SELECT * FROM orders"))
+	})
+
+	It("reads the query from a file", func() {
+		queryFile := filepath.Join(GinkgoT().TempDir(), "query.sql")
+		Expect(os.WriteFile(queryFile, []byte("SELECT 1;"), 0o600)).To(Succeed())
+
+		Expect(getQuery("", queryFile)).To(Equal("SELECT 1"))
+	})
+
+	It("refuses an empty query", func() {
+		_, err := getQuery(" ; ", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("explains the query in a read-only session", func() {
+		Expect(getPsqlCommand("app", "SELECT 1")).To(Equal([]string{
+			"psql",
+			"-U",
+			"postgres",
+			"-d",
+			"app",
+			"-X",
+			"-q",
+			"-A",
+			"-t",
+			"-v",
+			"ON_ERROR_STOP=1",
+			"-c",
+			"SET SESSION CHARACTERISTICS AS TRANSACTION READ ONLY",
+			"-c",
+			"EXPLAIN (FORMAT JSON, SETTINGS) SELECT 1",
+		}))
+	})
+
+	It("sorts the instances by name, with the primary first", func() {
+		pods := []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-3"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-2"}},
+		}
+		sortInstancePods(pods, "cluster-example-2")
+
+		names := make([]string, 0, len(pods))
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		Expect(names).To(Equal([]string{"cluster-example-2", "cluster-example-1", "cluster-example-3"}))
+	})
+
+	It("compares the plans with the one of the primary, when available", func() {
+		results := []instanceResult{
+			{podName: "cluster-example-1", primary: true, err: errors.New("connection refused")},
+			{podName: "cluster-example-2", plan: &queryPlan{}},
+			{podName: "cluster-example-3", plan: &queryPlan{}},
+		}
+		Expect(getReferenceResult(results).podName).To(Equal("cluster-example-2"))
+
+		results[0].err = nil
+		results[0].plan = &queryPlan{}
+		Expect(getReferenceResult(results).podName).To(Equal("cluster-example-1"))
+
+		Expect(getReferenceResult(nil)).To(BeNil())
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// planNode is a node of the plan of a query, as reported by
+// `EXPLAIN (FORMAT JSON)`
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	Strategy     string     `json:"Strategy,omitempty"`
+	JoinType     string     `json:"Join Type,omitempty"`
+	RelationName string     `json:"Relation Name,omitempty"`
+	Alias        string     `json:"Alias,omitempty"`
+	IndexName    string     `json:"Index Name,omitempty"`
+	TotalCost    float64    `json:"Total Cost"`
+	PlanRows     float64    `json:"Plan Rows"`
+	Plans        []planNode `json:"Plans,omitempty"`
+}
+
+// queryPlan is the plan of a query, together with the settings affecting
+// the planner which differ from their default value
+type queryPlan struct {
+	Plan     planNode          `json:"Plan"`
+	Settings map[string]string `json:"Settings,omitempty"`
+}
+
+// planDifferences are the differences between the plan of the query on an
+// instance and the reference one
+type planDifferences struct {
+	// differentShape is true when the nodes of the plans differ
+	differentShape bool
+
+	// estimates are the nodes whose estimated rows differ
+	estimates []string
+
+	// settings are the planner settings whose value differs
+	settings []string
+}
+
+// isEmpty checks if no difference has been found
+func (d *planDifferences) isEmpty() bool {
+	return !d.differentShape && len(d.estimates) == 0 && len(d.settings) == 0
+}
+
+// parseQueryPlan parses the output of `EXPLAIN (FORMAT JSON, SETTINGS)`
+func parseQueryPlan(output string) (*queryPlan, error) {
+	var plans []queryPlan
+	if err := json.Unmarshal([]byte(output), &plans); err != nil {
+		return nil, fmt.Errorf("while parsing the plan of the query: %w", err)
+	}
+	if len(plans) != 1 {
+		return nil, fmt.Errorf("expected the plan of a single query, got %d", len(plans))
+	}
+
+	return &plans[0], nil
+}
+
+// String returns a description of the node, similar to the one used by
+// the text format of EXPLAIN
+func (node *planNode) String() string {
+	var sb strings.Builder
+	sb.WriteString(node.NodeType)
+
+	var qualifiers []string
+	for _, qualifier := range []string{node.Strategy, node.JoinType} {
+		if qualifier != "" && qualifier != "Plain" {
+			qualifiers = append(qualifiers, qualifier)
+		}
+	}
+	if len(qualifiers) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(qualifiers, ", "))
+	}
+
+	if node.IndexName != "" {
+		fmt.Fprintf(&sb, " using %s", node.IndexName)
+	}
+	if node.RelationName != "" {
+		fmt.Fprintf(&sb, " on %s", node.RelationName)
+		if node.Alias != "" && node.Alias != node.RelationName {
+			fmt.Fprintf(&sb, " %s", node.Alias)
+		}
+	}
+
+	return sb.String()
+}
+
+// nodes returns the nodes of the plan in depth-first order, together with
+// their depth
+func (plan *queryPlan) nodes() ([]*planNode, []int) {
+	var nodes []*planNode
+	var depths []int
+
+	var visit func(node *planNode, depth int)
+	visit = func(node *planNode, depth int) {
+		nodes = append(nodes, node)
+		depths = append(depths, depth)
+		for idx := range node.Plans {
+			visit(&node.Plans[idx], depth+1)
+		}
+	}
+	visit(&plan.Plan, 0)
+
+	return nodes, depths
+}
+
+// shape returns the description of the nodes of the plan, indented by
+// their depth, ignoring the estimates
+func (plan *queryPlan) shape() []string {
+	nodes, depths := plan.nodes()
+	result := make([]string, len(nodes))
+	for idx, node := range nodes {
+		result[idx] = strings.Repeat("  ", depths[idx]) + node.String()
+	}
+
+	return result
+}
+
+// lines returns the description of the nodes of the plan, indented by
+// their depth, together with their estimates
+func (plan *queryPlan) lines() []string {
+	nodes, _ := plan.nodes()
+	result := plan.shape()
+	for idx, node := range nodes {
+		result[idx] += fmt.Sprintf("  (cost=%.2f rows=%.0f)", node.TotalCost, node.PlanRows)
+	}
+
+	return result
+}
+
+// comparePlans finds the differences between the plan of the query on an
+// instance and the reference one. The estimated rows are compared only
+// when the plans have the same shape
+func comparePlans(reference, other *queryPlan) planDifferences {
+	var result planDifferences
+
+	result.differentShape = !slices.Equal(reference.shape(), other.shape())
+	if !result.differentShape {
+		referenceNodes, _ := reference.nodes()
+		otherNodes, _ := other.nodes()
+		for idx, node := range referenceNodes {
+			if node.PlanRows != otherNodes[idx].PlanRows {
+				result.estimates = append(result.estimates, fmt.Sprintf("%s: %.0f -> %.0f rows",
+					node, node.PlanRows, otherNodes[idx].PlanRows))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(reference.Settings)+len(other.Settings))
+	for name := range reference.Settings {
+		names = append(names, name)
+	}
+	for name := range other.Settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		referenceValue, otherValue := getSetting(reference, name), getSetting(other, name)
+		if referenceValue != otherValue {
+			result.settings = append(result.settings, fmt.Sprintf("%s: %s -> %s",
+				name, referenceValue, otherValue))
+		}
+	}
+
+	return result
+}
+
+// getSetting gets the value of a planner setting, which is
+// reported only when it differs from its default
+func getSetting(plan *queryPlan, name string) string {
+	if value, ok := plan.Settings[name]; ok {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return "default"
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explain
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query plans", func() {
+	const indexScanPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Hash Join",
+      "Join Type": "Inner",
+      "Total Cost": 35.5,
+      "Plan Rows": 120,
+      "Plans": [
+        {
+          "Node Type": "Index Scan",
+          "Relation Name": "orders",
+          "Alias": "o",
+          "Index Name": "orders_customer_idx",
+          "Total Cost": 12.25,
+          "Plan Rows": 120
+        },
+        {
+          "Node Type": "Hash",
+          "Total Cost": 18,
+          "Plan Rows": 40,
+          "Plans": [
+            {
+              "Node Type": "Seq Scan",
+              "Relation Name": "customers",
+              "Alias": "customers",
+              "Total Cost": 18,
+              "Plan Rows": 40
+            }
+          ]
+        }
+      ]
+    },
+    "Settings": {
+      "random_page_cost": "1.1"
+    }
+  }
+]`
+
+	var reference *queryPlan
+
+	BeforeEach(func() {
+		var err error
+		reference, err = parseQueryPlan(indexScanPlan)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("parses the plan reported by EXPLAIN", func() {
+		Expect(reference.Plan.NodeType).To(Equal("Hash Join"))
+		Expect(reference.Plan.Plans).To(HaveLen(2))
+		Expect(reference.Settings).To(HaveKeyWithValue("random_page_cost", "1.1"))
+		Expect(reference.lines()).To(Equal([]string{
+			"Hash Join (Inner)  (cost=35.50 rows=120)",
+			"  Index Scan using orders_customer_idx on orders o  (cost=12.25 rows=120)",
+			"  Hash  (cost=18.00 rows=40)",
+			"    Seq Scan on customers  (cost=18.00 rows=40)",
+		}))
+	})
+
+	It("refuses an output which is not the plan of a single query", func() {
+		_, err := parseQueryPlan("ERROR: syntax error")
+		Expect(err).To(HaveOccurred())
+
+		_, err = parseQueryPlan("[]")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("finds no difference between the same plans", func() {
+		other, err := parseQueryPlan(indexScanPlan)
+		Expect(err).ToNot(HaveOccurred())
+
+		diff := comparePlans(reference, other)
+		Expect(diff.isEmpty()).To(BeTrue())
+	})
+
+	It("reports the different estimated rows and settings", func() {
+		other, err := parseQueryPlan(indexScanPlan)
+		Expect(err).ToNot(HaveOccurred())
+		other.Plan.Plans[1].Plans[0].PlanRows = 4000
+		other.Settings = map[string]string{"work_mem": "64MB"}
+
+		diff := comparePlans(reference, other)
+		Expect(diff.differentShape).To(BeFalse())
+		Expect(diff.estimates).To(Equal([]string{"Seq Scan on customers: 40 -> 4000 rows"}))
+		Expect(diff.settings).To(Equal([]string{
+			`random_page_cost: "1.1" -> default`,
+			`work_mem: default -> "64MB"`,
+		}))
+	})
+
+	It("reports a plan with a different shape", func() {
+		other, err := parseQueryPlan(indexScanPlan)
+		Expect(err).ToNot(HaveOccurred())
+		other.Plan.Plans[0] = planNode{
+			NodeType:     "Seq Scan",
+			RelationName: "orders",
+			Alias:        "o",
+			TotalCost:    250,
+			PlanRows:     120,
+		}
+
+		diff := comparePlans(reference, other)
+		Expect(diff.differentShape).To(BeTrue())
+		Expect(diff.estimates).To(BeEmpty())
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pgbench
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/podlogs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// benchmarkPollInterval is the interval used to check the status
+	// of the benchmark job
+	benchmarkPollInterval = 2 * time.Second
+
+	// benchmarkStartTimeout is the time the benchmark pod has to be
+	// scheduled and started, including the time needed to pull the image
+	benchmarkStartTimeout = 5 * time.Minute
+
+	// benchmarkCompletionTimeout is the time the benchmark job has to be
+	// marked as completed after its pod terminated
+	benchmarkCompletionTimeout = time.Minute
+
+	// benchmarkProgressInterval is the interval, in seconds, between the
+	// progress reports of pgbench
+	benchmarkProgressInterval = 5
+)
+
+// benchmarkResult is the outcome of a benchmark, as reported by pgbench
+type benchmarkResult struct {
+	tps     string
+	latency string
+}
+
+// buildBenchmarkScript generates the shell script executed by the benchmark
+// job: it initializes the pgbench schema with the requested scale factor,
+// runs the workload against the requested service and drops the pgbench
+// tables at the end unless they need to be kept. The initialization and the
+// cleanup always happen on the primary, as they need write access.
+func (cmd *pgBenchRun) buildBenchmarkScript() string {
+	primaryHost := cmd.clusterName + apiv1.ServiceReadWriteSuffix
+
+	lines := []string{"set -e"}
+	if !cmd.keep {
+		lines = append(lines, fmt.Sprintf("trap 'pgbench -i -I d -h %s' EXIT", primaryHost))
+	}
+	lines = append(lines, fmt.Sprintf("pgbench -i -s %d -h %s", cmd.scale, primaryHost))
+
+	workload := fmt.Sprintf("pgbench -c %d -j %d -T %d -P %d",
+		cmd.clients, cmd.jobs, int(cmd.duration.Seconds()), benchmarkProgressInterval)
+	if cmd.service == serviceReadOnly {
+		// Replicas can only run the select-only builtin and cannot be
+		// vacuumed before the run
+		workload += " -S -n"
+	}
+	lines = append(lines, workload)
+
+	return strings.Join(lines, "\n")
+}
+
+// followBenchmark streams the logs of the benchmark job, waiting for it
+// to complete, and reports the results
+func (cmd *pgBenchRun) followBenchmark(ctx context.Context, job *batchv1.Job) error {
+	fmt.Printf("waiting for job/%v to start\n", job.Name)
+	pod, err := waitForJobPod(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	streamPodLogs := podlogs.NewPodLogsWriter(*pod, plugin.ClientInterface)
+	if err := streamPodLogs.Single(
+		ctx,
+		io.MultiWriter(os.Stdout, &output),
+		&corev1.PodLogOptions{Follow: true},
+	); err != nil {
+		return fmt.Errorf("while streaming the logs of job/%v: %w", job.Name, err)
+	}
+
+	if err := waitForJobCompletion(ctx, job); err != nil {
+		return err
+	}
+
+	result := parseBenchmarkResult(output.String())
+	fmt.Printf("\nBenchmark of %v via the %v service completed\n", cmd.clusterName, cmd.service)
+	fmt.Printf("  TPS:             %v\n", result.tps)
+	fmt.Printf("  Average latency: %v\n", result.latency)
+	return nil
+}
+
+// waitForJobPod waits for the pod of the passed job to be started, and
+// returns it
+func waitForJobPod(ctx context.Context, job *batchv1.Job) (*corev1.Pod, error) {
+	var result *corev1.Pod
+	err := wait.PollUntilContextTimeout(ctx, benchmarkPollInterval, benchmarkStartTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			var pods corev1.PodList
+			if err := plugin.Client.List(
+				ctx,
+				&pods,
+				client.InNamespace(job.Namespace),
+				client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+			); err != nil {
+				return false, err
+			}
+
+			for idx := range pods.Items {
+				if pods.Items[idx].Status.Phase != corev1.PodPending {
+					result = &pods.Items[idx]
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("while waiting for job/%v to start: %w", job.Name, err)
+	}
+
+	return result, nil
+}
+
+// waitForJobCompletion waits for the passed job to be either
+// completed or failed, returning an error in the latter case
+func waitForJobCompletion(ctx context.Context, job *batchv1.Job) error {
+	var failed bool
+	err := wait.PollUntilContextTimeout(ctx, benchmarkPollInterval, benchmarkCompletionTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			var current batchv1.Job
+			if err := plugin.Client.Get(ctx, client.ObjectKeyFromObject(job), &current); err != nil {
+				return false, err
+			}
+
+			failed = utils.JobHasFailed(current)
+			return failed || utils.JobHasOneCompletion(current), nil
+		})
+	if err != nil {
+		return fmt.Errorf("while waiting for job/%v to complete: %w", job.Name, err)
+	}
+
+	if failed {
+		return fmt.Errorf("job/%v failed, see its logs for further details", job.Name)
+	}
+
+	return nil
+}
+
+// parseBenchmarkResult extracts the throughput and the average latency
+// from the summary printed by pgbench at the end of a run
+func parseBenchmarkResult(output string) benchmarkResult {
+	result := benchmarkResult{
+		tps:     "unknown",
+		latency: "unknown",
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if value, found := strings.CutPrefix(line, "tps = "); found {
+			result.tps = value
+		}
+		if value, found := strings.CutPrefix(line, "latency average = "); found {
+			result.latency = value
+		}
+	}
+
+	return result
+}
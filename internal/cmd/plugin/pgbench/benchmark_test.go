@@ -0,0 +1,104 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pgbench
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pgbench benchmark", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+		Status:     apiv1.ClusterStatus{Image: "postgres:17"},
+	}
+
+	newRun := func() *pgBenchRun {
+		return &pgBenchRun{
+			jobName:     "bench",
+			clusterName: cluster.Name,
+			dbName:      "app",
+			scale:       10,
+			clients:     4,
+			jobs:        2,
+			duration:    30 * time.Second,
+			service:     serviceReadWrite,
+		}
+	}
+
+	It("initializes, runs the workload and drops the schema", func() {
+		Expect(newRun().buildBenchmarkScript()).To(Equal(
+			"set -e\n" +
+				"trap 'pgbench -i -I d -h cluster-example-rw' EXIT\n" +
+				"pgbench -i -s 10 -h cluster-example-rw\n" +
+				"pgbench -c 4 -j 2 -T 30 -P 5"))
+	})
+
+	It("keeps the schema and runs a select-only workload on the replicas", func() {
+		run := newRun()
+		run.keep = true
+		run.service = serviceReadOnly
+		Expect(run.buildBenchmarkScript()).To(Equal(
+			"set -e\n" +
+				"pgbench -i -s 10 -h cluster-example-rw\n" +
+				"pgbench -c 4 -j 2 -T 30 -P 5 -S -n"))
+
+		job := run.buildJob(cluster)
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "PGHOST", Value: "cluster-example-ro"}))
+	})
+
+	It("runs the benchmark script without retries", func() {
+		job := newRun().buildJob(cluster)
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(HaveLen(3))
+		Expect(job.Spec.Template.Spec.Containers[0].Command[:2]).To(Equal([]string{"sh", "-c"}))
+	})
+
+	It("runs the passed pgbench command as-is", func() {
+		run := newRun()
+		run.pgBenchCommandArgs = []string{"--time", "30"}
+		job := run.buildJob(cluster)
+		Expect(job.Spec.BackoffLimit).To(BeNil())
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"pgbench"}))
+		Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"--time", "30"}))
+	})
+
+	It("extracts the TPS and the latency from the pgbench summary", func() {
+		output := `progress: 5.0 s, 201.2 tps, lat 4.963 ms stddev 1.021, 0 failed
+number of transactions actually processed: 12345
+latency average = 4.860 ms
+initial connection time = 12.345 ms
+tps = 205.763810 (without initial connection time)
+`
+		Expect(parseBenchmarkResult(output)).To(Equal(benchmarkResult{
+			tps:     "205.763810 (without initial connection time)",
+			latency: "4.860 ms",
+		}))
+		Expect(parseBenchmarkResult("")).To(Equal(benchmarkResult{tps: "unknown", latency: "unknown"}))
+	})
+})
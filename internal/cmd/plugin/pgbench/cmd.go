@@ -21,6 +21,7 @@ package pgbench
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,16 +33,22 @@ func NewCmd() *cobra.Command {
 	run := &pgBenchRun{}
 
 	pgBenchCmd := &cobra.Command{
-		Use:     "pgbench CLUSTER [-- PGBENCH_COMMAND_ARGS...]",
-		Short:   "Creates a pgbench job",
-		Args:    validateCommandArgs,
-		Long:    "Creates a pgbench job to run against the specified Postgres Cluster.",
+		Use:   "pgbench CLUSTER [-- PGBENCH_COMMAND_ARGS...]",
+		Short: "Creates a pgbench job",
+		Args:  validateCommandArgs,
+		Long: "Creates a pgbench job to run against the specified Postgres Cluster. " +
+			"When no PGBENCH_COMMAND_ARGS are passed, the job initializes the pgbench schema, " +
+			"runs the builtin workload and drops the schema, while its progress is streamed.",
 		GroupID: plugin.GroupIDMiscellaneous,
 		Example: jobExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			run.clusterName = args[0]
 			run.pgBenchCommandArgs = args[1:]
 
+			if err := run.validate(cmd); err != nil {
+				return err
+			}
+
 			return run.execute(cmd.Context())
 		},
 	}
@@ -87,6 +94,48 @@ func NewCmd() *cobra.Command {
 		[]string{},
 		"Node label selector in the <labelName>=<labelValue> format.",
 	)
+	pgBenchCmd.Flags().IntVar(
+		&run.scale,
+		"scale",
+		1,
+		"The scale factor used to initialize the pgbench schema",
+	)
+
+	pgBenchCmd.Flags().IntVar(
+		&run.clients,
+		"clients",
+		1,
+		"The number of concurrent database clients of the workload",
+	)
+
+	pgBenchCmd.Flags().IntVar(
+		&run.jobs,
+		"jobs",
+		1,
+		"The number of pgbench threads of the workload",
+	)
+
+	pgBenchCmd.Flags().DurationVar(
+		&run.duration,
+		"time",
+		time.Minute,
+		"The duration of the workload, in whole seconds",
+	)
+
+	pgBenchCmd.Flags().StringVar(
+		&run.service,
+		"service",
+		serviceReadWrite,
+		"The service the workload is run against, \"rw\" or \"ro\"",
+	)
+
+	pgBenchCmd.Flags().BoolVar(
+		&run.keep,
+		"keep",
+		false,
+		"When true the pgbench schema is not dropped at the end of the benchmark",
+	)
+
 	_ = pgBenchCmd.Flags().MarkDeprecated("pgbench-job-name", "use job-name instead")
 
 	return pgBenchCmd
@@ -103,3 +152,35 @@ func validateCommandArgs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// benchmarkFlags are the flags that only apply to the builtin benchmark
+var benchmarkFlags = []string{"scale", "clients", "jobs", "time", "keep"}
+
+func (cmd *pgBenchRun) validate(command *cobra.Command) error {
+	if cmd.service != serviceReadWrite && cmd.service != serviceReadOnly {
+		return fmt.Errorf("invalid service %q, must be either %q or %q",
+			cmd.service, serviceReadWrite, serviceReadOnly)
+	}
+
+	if !cmd.isBenchmark() {
+		for _, name := range benchmarkFlags {
+			if command.Flags().Changed(name) {
+				return fmt.Errorf("--%s cannot be used together with PGBENCH_COMMAND_ARGS", name)
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case cmd.scale < 1:
+		return fmt.Errorf("--scale must be at least 1")
+	case cmd.clients < 1:
+		return fmt.Errorf("--clients must be at least 1")
+	case cmd.jobs < 1 || cmd.jobs > cmd.clients:
+		return fmt.Errorf("--jobs must be between 1 and the number of clients")
+	case cmd.duration < time.Second || cmd.duration.Truncate(time.Second) != cmd.duration:
+		return fmt.Errorf("--time must be a whole number of seconds, and at least 1s")
+	}
+
+	return nil
+}
@@ -32,7 +32,7 @@ var _ = Describe("NewCmd", func() {
 
 		Expect(cmd.Use).To(Equal("pgbench CLUSTER [-- PGBENCH_COMMAND_ARGS...]"))
 		Expect(cmd.Short).To(Equal("Creates a pgbench job"))
-		Expect(cmd.Long).To(Equal("Creates a pgbench job to run against the specified Postgres Cluster."))
+		Expect(cmd.Long).To(HavePrefix("Creates a pgbench job to run against the specified Postgres Cluster."))
 		Expect(cmd.Example).To(Equal(jobExample))
 
 		// Test the flags.
@@ -51,6 +51,13 @@ var _ = Describe("NewCmd", func() {
 		nodeSelectorFlag := cmd.Flag("node-selector")
 		Expect(nodeSelectorFlag).ToNot(BeNil())
 		Expect(nodeSelectorFlag.DefValue).To(Equal("[]"))
+
+		Expect(cmd.Flag("scale").DefValue).To(Equal("1"))
+		Expect(cmd.Flag("clients").DefValue).To(Equal("1"))
+		Expect(cmd.Flag("jobs").DefValue).To(Equal("1"))
+		Expect(cmd.Flag("time").DefValue).To(Equal("1m0s"))
+		Expect(cmd.Flag("service").DefValue).To(Equal("rw"))
+		Expect(cmd.Flag("keep").DefValue).To(Equal("false"))
 	})
 
 	It("should correctly parse flags and arguments", func() {
@@ -98,4 +105,18 @@ var _ = Describe("NewCmd", func() {
 		Expect(testRun.ttlSecondsAfterFinished).To(Equal(int32(86400)))
 		Expect(testRun.pgBenchCommandArgs).To(Equal([]string{"arg1", "arg2"}))
 	})
+
+	It("should reject the benchmark flags together with PGBENCH_COMMAND_ARGS", func() {
+		cmd := NewCmd()
+		cmd.SetArgs([]string{"mycluster", "--scale=10", "--", "--time", "30"})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("--scale cannot be used together")))
+	})
+
+	It("should reject invalid benchmark options", func() {
+		for _, flag := range []string{"--service=other", "--clients=0", "--jobs=2", "--time=1500ms"} {
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"mycluster", flag})
+			Expect(cmd.Execute()).To(HaveOccurred(), flag)
+		}
+	})
 })
@@ -25,11 +25,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -44,19 +46,40 @@ type pgBenchRun struct {
 	pgBenchCommandArgs      []string
 	dryRun                  bool
 	ttlSecondsAfterFinished int32
+
+	// The following options are used to run the builtin benchmark,
+	// when no PGBENCH_COMMAND_ARGS are passed
+	scale    int
+	clients  int
+	jobs     int
+	duration time.Duration
+	service  string
+	keep     bool
 }
 
 const (
 	pgBenchKeyWord = "pgbench"
+
+	// serviceReadWrite is the service pointing to the primary instance
+	serviceReadWrite = "rw"
+
+	// serviceReadOnly is the service pointing to the replicas
+	serviceReadOnly = "ro"
 )
 
 var jobExample = `
   # Dry-run command with default values and [cluster] "cluster-example"
   kubectl-cnpg pgbench cluster-example --dry-run
 
-  # Create a pgbench job with default values and [cluster] "cluster-example"
+  # Benchmark [cluster] "cluster-example" with default values, initializing the
+  # pgbench schema, running the workload and dropping the schema afterwards
   kubectl-cnpg pgbench cluster-example
 
+  # Benchmark the replicas of [cluster] "cluster-example" with 10 clients for
+  # 5 minutes, keeping the pgbench schema at the end
+  kubectl-cnpg pgbench cluster-example --scale 50 --clients 10 --jobs 2 --time 5m \
+    --service ro --keep
+
   # Dry-run command with given values and [cluster] "cluster-example"
   kubectl-cnpg pgbench cluster-example --db-name pgbenchDBName --job-name job-name --dry-run -- \
     --time 30 --client 1 --jobs 1
@@ -86,7 +109,18 @@ func (cmd *pgBenchRun) execute(ctx context.Context) error {
 	}
 
 	fmt.Printf("job/%v created\n", job.Name)
-	return nil
+
+	if !cmd.isBenchmark() {
+		return nil
+	}
+
+	return cmd.followBenchmark(ctx, job)
+}
+
+// isBenchmark is true when the job runs the builtin benchmark rather than
+// the passed pgbench command
+func (cmd *pgBenchRun) isBenchmark() bool {
+	return len(cmd.pgBenchCommandArgs) == 0
 }
 
 func (cmd *pgBenchRun) getCluster(ctx context.Context) (*apiv1.Cluster, error) {
@@ -167,6 +201,13 @@ func (cmd *pgBenchRun) buildJob(cluster *apiv1.Cluster) *batchv1.Job {
 		},
 	}
 
+	if cmd.isBenchmark() {
+		// Retrying would run the benchmark again, mixing its
+		// output with the one of the failed attempt
+		result.Spec.BackoffLimit = ptr.To(int32(0))
+		result.Spec.Template.Spec.Containers[0].Command = []string{"sh", "-c", cmd.buildBenchmarkScript()}
+	}
+
 	if cmd.ttlSecondsAfterFinished != 0 {
 		result.Spec.TTLSecondsAfterFinished = &cmd.ttlSecondsAfterFinished
 	}
@@ -176,7 +217,11 @@ func (cmd *pgBenchRun) buildJob(cluster *apiv1.Cluster) *batchv1.Job {
 
 func (cmd *pgBenchRun) buildEnvVariables() []corev1.EnvVar {
 	clusterName := cmd.clusterName
-	pgHost := fmt.Sprintf("%v%v", clusterName, apiv1.ServiceReadWriteSuffix)
+	serviceSuffix := apiv1.ServiceReadWriteSuffix
+	if cmd.service == serviceReadOnly {
+		serviceSuffix = apiv1.ServiceReadOnlySuffix
+	}
+	pgHost := fmt.Sprintf("%v%v", clusterName, serviceSuffix)
 	appSecreteName := fmt.Sprintf("%v-%v", clusterName, "app")
 
 	envVar := []corev1.EnvVar{
@@ -51,6 +51,15 @@ func Promote(ctx context.Context, cli client.Client,
 		return nil
 	}
 
+	// The delayed replicas and the instances excluded from the failover
+	// can never be promoted
+	if cluster.IsDelayedReplica(serverName) {
+		return fmt.Errorf("%s is a delayed replica and cannot be promoted", serverName)
+	}
+	if !cluster.IsFailoverCandidate(serverName) {
+		return fmt.Errorf("%s has been excluded from the failover and cannot be promoted", serverName)
+	}
+
 	// Check if the Pod exist
 	var pod corev1.Pod
 	err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: serverName}, &pod)
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package promote
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,4 +94,35 @@ var _ = Describe("promote subcommand tests", func() {
 		Expect(meta.IsStatusConditionTrue(cl.Status.Conditions, string(apiv1.ConditionClusterReady))).
 			To(BeTrue())
 	})
+
+	It("refuses to promote an instance excluded from the failover", func(ctx SpecContext) {
+		var cl apiv1.Cluster
+		Expect(client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster1"}, &cl)).
+			To(Succeed())
+		cl.Spec.Failover = &apiv1.FailoverConfiguration{ExcludedInstances: []string{"cluster1-2"}}
+		Expect(client.Update(ctx, &cl)).To(Succeed())
+
+		err := Promote(ctx, client, namespace, "cluster1", "cluster1-2")
+		Expect(err).To(MatchError(ContainSubstring("excluded from the failover")))
+		Expect(client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster1"}, &cl)).
+			To(Succeed())
+		Expect(cl.Status.TargetPrimary).To(Equal("cluster1-1"))
+	})
+
+	It("refuses to promote a delayed replica", func(ctx SpecContext) {
+		var cl apiv1.Cluster
+		Expect(client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster1"}, &cl)).
+			To(Succeed())
+		cl.Spec.DelayedReplicas = &apiv1.DelayedReplicasConfiguration{
+			Instances:     []string{"cluster1-2"},
+			MinApplyDelay: metav1.Duration{Duration: time.Hour},
+		}
+		Expect(client.Update(ctx, &cl)).To(Succeed())
+
+		err := Promote(ctx, client, namespace, "cluster1", "cluster1-2")
+		Expect(err).To(MatchError(ContainSubstring("delayed replica")))
+		Expect(client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster1"}, &cl)).
+			To(Succeed())
+		Expect(cl.Status.TargetPrimary).To(Equal("cluster1-1"))
+	})
 })
@@ -202,6 +202,18 @@ func (psql *Command) Output() ([]byte, error) {
 	return cmd.Output()
 }
 
+// CombinedOutput starts a psql process inside the target pod
+// and returns its combined stdout and stderr
+func (psql *Command) CombinedOutput() ([]byte, error) {
+	kubectlExec, err := psql.getKubectlInvocation()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(psql.kubectlPath, kubectlExec[1:]...) // nolint:gosec
+	return cmd.CombinedOutput()
+}
+
 // ErrMissingPod is raised when we can't find a Pod having the desired role
 type ErrMissingPod struct {
 	role string
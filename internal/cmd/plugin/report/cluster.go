@@ -29,14 +29,14 @@ import (
 
 func clusterCmd() *cobra.Command {
 	var (
-		file, output              string
-		includeLogs, logTimeStamp bool
+		file, output                              string
+		includeLogs, logTimeStamp, includeMetrics bool
 	)
 
 	const filePlaceholder = "report_cluster_<name>_<timestamp>.zip"
 	cmd := &cobra.Command{
 		Use:   "cluster CLUSTER",
-		Short: "Report cluster resources, pods, events, logs (opt-in)",
+		Short: "Report cluster resources, pods, events, logs and metrics (opt-in)",
 		Long:  "Collects combined information on the cluster in a Zip file",
 		Args:  plugin.RequiresArguments(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -49,7 +49,7 @@ func clusterCmd() *cobra.Command {
 				file = reportName("cluster", now, clusterName) + ".zip"
 			}
 			return cluster(cmd.Context(), clusterName, plugin.Namespace,
-				plugin.OutputFormat(output), file, includeLogs, logTimeStamp, now)
+				plugin.OutputFormat(output), file, includeLogs, logTimeStamp, includeMetrics, now)
 		},
 	}
 
@@ -60,6 +60,8 @@ func clusterCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&includeLogs, "logs", "l", false, "include logs")
 	cmd.Flags().BoolVarP(&logTimeStamp, "timestamps", "t", false,
 		"Prepend human-readable timestamp to each log line")
+	cmd.Flags().BoolVarP(&includeMetrics, "metrics", "m", false,
+		"include a snapshot of the metrics exposed by each instance")
 
 	return cmd
 }
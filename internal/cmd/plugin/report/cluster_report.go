@@ -82,8 +82,9 @@ func (cr clusterReport) writeToZip(zipper *zip.Writer, format plugin.OutputForma
 //   - events in the cluster namespace
 //   - logs from the cluster pods (optional - activated with `includeLogs`)
 //   - logs from the cluster jobs (optional - activated with `includeLogs`)
+//   - metrics from the cluster instances (optional - activated with `includeMetrics`)
 func cluster(ctx context.Context, clusterName, namespace string, format plugin.OutputFormat,
-	file string, includeLogs, logTimeStamp bool, timestamp time.Time,
+	file string, includeLogs, logTimeStamp, includeMetrics bool, timestamp time.Time,
 ) error {
 	var events corev1.EventList
 	err := plugin.Client.List(ctx, &events, client.InNamespace(namespace))
@@ -147,6 +148,14 @@ func cluster(ctx context.Context, clusterName, namespace string, format plugin.O
 		sections = append(sections, logsZipper, jobLogsZipper)
 	}
 
+	if includeMetrics {
+		metricsZipper := func(zipper *zip.Writer, dirname string) error {
+			return scrapeClusterMetricsToZip(ctx, cluster, pods.Items, dirname, zipper)
+		}
+
+		sections = append(sections, metricsZipper)
+	}
+
 	err = writeZippedReport(sections, file, reportName("cluster", timestamp, clusterName))
 	if err != nil {
 		return fmt.Errorf("could not write report: %w", err)
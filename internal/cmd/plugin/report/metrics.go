@@ -0,0 +1,95 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package report
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// scrapeClusterMetricsToZip scrapes the metrics endpoint of each instance of
+// the cluster, and writes the raw Prometheus output in a new file within a
+// folder. An instance whose metrics can't be scraped doesn't make the whole
+// report fail: the error is written in place of its metrics
+func scrapeClusterMetricsToZip(
+	ctx context.Context,
+	cluster apiv1.Cluster,
+	pods []corev1.Pod,
+	dirname string,
+	zipper *zip.Writer,
+) error {
+	metricsDir := filepath.Join(dirname, "metrics")
+	if _, err := zipper.Create(metricsDir + "/"); err != nil {
+		return fmt.Errorf("could not add '%s' to zip: %w", metricsDir, err)
+	}
+
+	scheme := "http"
+	if cluster.IsMetricsTLSEnabled() {
+		scheme = "https"
+	}
+
+	cli := kubernetes.NewForConfigOrDie(plugin.Config)
+
+	for idx := range pods {
+		pod := pods[idx]
+		if pod.Labels[utils.PodRoleLabelName] != string(utils.PodRoleInstance) {
+			continue
+		}
+
+		path := filepath.Join(metricsDir, fmt.Sprintf("%s-metrics.txt", pod.Name))
+		writer, err := zipper.Create(path)
+		if err != nil {
+			return fmt.Errorf("could not add '%s' to zip: %w", path, err)
+		}
+
+		metrics, err := cli.CoreV1().
+			Pods(pod.Namespace).
+			ProxyGet(
+				scheme,
+				pod.Name,
+				strconv.Itoa(int(url.PostgresMetricsPort)),
+				url.PathMetrics,
+				nil,
+			).
+			DoRaw(ctx)
+		if err != nil {
+			metrics = fmt.Appendf(nil,
+				"# failed to scrape the metrics by proxying to the pod, "+
+					"you might lack permissions to get pods/proxy: %v\n", err)
+		}
+
+		if _, err := writer.Write(metrics); err != nil {
+			return fmt.Errorf("could not write '%s' to zip: %w", path, err)
+		}
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ package restart
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -30,20 +31,42 @@ import (
 
 // NewCmd creates the new "reset" command
 func NewCmd() *cobra.Command {
+	var clusterNames []string
+	var sequential bool
+	var timeout time.Duration
+
 	restartCmd := &cobra.Command{
 		Use:   "restart CLUSTER [INSTANCE]",
 		Short: `Restart a cluster or a single instance in a cluster`,
 		Long: `If only the cluster name is specified, the whole cluster will be restarted, 
 rolling out new configurations if present.
 If a specific instance is specified, only that instance will be restarted, 
-in-place if it is a primary, deleting the pod if it is a replica.`,
-		Args:    cobra.RangeArgs(1, 2),
+in-place if it is a primary, deleting the pod if it is a replica.
+Multiple clusters can be restarted with the --cluster option, optionally one
+after the other with the --sequential option.`,
+		Example: `  kubectl cnpg restart cluster-example
+  kubectl cnpg restart cluster-example 2
+  kubectl cnpg restart --cluster shard-a,shard-b,shard-c --sequential`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(clusterNames) > 0 {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		GroupID: plugin.GroupIDCluster,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			if len(clusterNames) > 0 {
+				return restartClusters(ctx, clusterNames, sequential, timeout)
+			}
+			if sequential {
+				return fmt.Errorf("the --sequential option requires the --cluster option")
+			}
+
 			clusterName := args[0]
 			if len(args) == 1 {
-				return restart(ctx, clusterName)
+				_, err := restart(ctx, clusterName)
+				return err
 			}
 			node := args[1]
 			if _, err := strconv.Atoi(args[1]); err == nil {
@@ -53,5 +76,12 @@ in-place if it is a primary, deleting the pod if it is a replica.`,
 		},
 	}
 
+	restartCmd.Flags().StringSliceVar(&clusterNames, "cluster", nil,
+		"Comma-separated list of clusters to restart, in the given order")
+	restartCmd.Flags().BoolVar(&sequential, "sequential", false,
+		"Wait for each cluster to be restarted and healthy before restarting the next one")
+	restartCmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute,
+		"Maximum time to wait for each cluster to be restarted, used together with --sequential")
+
 	return restartCmd
 }
@@ -26,40 +26,117 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-// restart marks the cluster as needing to restart
-func restart(ctx context.Context, clusterName string) error {
+// restartPollInterval is the interval between two checks of the status of
+// a cluster being restarted
+const restartPollInterval = 5 * time.Second
+
+// restart marks the cluster as needing to restart, returning the value
+// of the restart annotation
+func restart(ctx context.Context, clusterName string) (string, error) {
 	var cluster apiv1.Cluster
 
 	// Get the Cluster object
 	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
 	if err != nil {
-		return fmt.Errorf("while trying to get cluster %v: %w", clusterName, err)
+		return "", fmt.Errorf("while trying to get cluster %v: %w", clusterName, err)
 	}
 
+	restartedAt := time.Now().Format(time.RFC3339)
 	clusterRestarted := cluster.DeepCopy()
 	if clusterRestarted.Annotations == nil {
 		clusterRestarted.Annotations = make(map[string]string)
 	}
-	clusterRestarted.Annotations[utils.ClusterRestartAnnotationName] = time.Now().Format(time.RFC3339)
+	clusterRestarted.Annotations[utils.ClusterRestartAnnotationName] = restartedAt
 	clusterRestarted.ManagedFields = nil
 
 	err = plugin.Client.Patch(ctx, clusterRestarted, client.MergeFrom(&cluster))
 	if err != nil {
-		return fmt.Errorf("while patching cluster %v: %w", clusterName, err)
+		return "", fmt.Errorf("while patching cluster %v: %w", clusterName, err)
 	}
 
 	fmt.Printf("%s restarted\n", clusterRestarted.Name)
+	return restartedAt, nil
+}
+
+// restartClusters restarts a list of clusters. When sequential is true,
+// every cluster needs to be completely restarted and healthy before the
+// next one is restarted
+func restartClusters(ctx context.Context, clusterNames []string, sequential bool, timeout time.Duration) error {
+	for _, clusterName := range clusterNames {
+		restartedAt, err := restart(ctx, clusterName)
+		if err != nil {
+			return err
+		}
+
+		if !sequential {
+			continue
+		}
+
+		fmt.Printf("waiting for %s to be restarted\n", clusterName)
+		if err := waitForRestart(ctx, clusterName, restartedAt, timeout); err != nil {
+			return err
+		}
+		fmt.Printf("%s is healthy\n", clusterName)
+	}
+
+	return nil
+}
+
+// waitForRestart waits for every instance of a cluster to be restarted
+// and for the cluster to be healthy
+func waitForRestart(ctx context.Context, clusterName, restartedAt string, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, restartPollInterval, timeout, false,
+		func(ctx context.Context) (bool, error) {
+			var cluster apiv1.Cluster
+			err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+			if err != nil {
+				return false, err
+			}
+
+			pods, _, err := resources.GetInstancePods(ctx, clusterName)
+			if err != nil {
+				return false, err
+			}
+
+			return isClusterRestarted(&cluster, pods, restartedAt), nil
+		})
+	if err != nil {
+		return fmt.Errorf("while waiting for cluster %v to be restarted: %w", clusterName, err)
+	}
+
 	return nil
 }
 
+// isClusterRestarted checks if every instance of the cluster has been
+// restarted after the passed restart request, and the cluster is healthy
+func isClusterRestarted(cluster *apiv1.Cluster, pods []corev1.Pod, restartedAt string) bool {
+	if cluster.Status.Phase != apiv1.PhaseHealthy || cluster.Status.ReadyInstances != cluster.Spec.Instances {
+		return false
+	}
+
+	if len(pods) != cluster.Spec.Instances {
+		return false
+	}
+
+	for _, pod := range pods {
+		if pod.Annotations[utils.ClusterRestartAnnotationName] != restartedAt || !utils.IsPodReady(pod) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // instanceRestart restarts a given instance, in-place if a primary, deleting the pod if it's a replica
 func instanceRestart(ctx context.Context, clusterName, node string) error {
 	var cluster apiv1.Cluster
@@ -0,0 +1,94 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package restart
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isClusterRestarted", func() {
+	const restartedAt = "2024-10-08T18:31:57Z"
+
+	var cluster *apiv1.Cluster
+
+	newPod := func(restartAnnotation string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.ClusterRestartAnnotationName: restartAnnotation,
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.ContainersReady, Status: status},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 2,
+			},
+			Status: apiv1.ClusterStatus{
+				Phase:          apiv1.PhaseHealthy,
+				ReadyInstances: 2,
+			},
+		}
+	})
+
+	It("returns true when every instance has been restarted and the cluster is healthy", func() {
+		pods := []corev1.Pod{newPod(restartedAt, true), newPod(restartedAt, true)}
+		Expect(isClusterRestarted(cluster, pods, restartedAt)).To(BeTrue())
+	})
+
+	It("returns false when an instance has not been restarted yet", func() {
+		pods := []corev1.Pod{newPod(restartedAt, true), newPod("", true)}
+		Expect(isClusterRestarted(cluster, pods, restartedAt)).To(BeFalse())
+	})
+
+	It("returns false when an instance is not ready", func() {
+		pods := []corev1.Pod{newPod(restartedAt, true), newPod(restartedAt, false)}
+		Expect(isClusterRestarted(cluster, pods, restartedAt)).To(BeFalse())
+	})
+
+	It("returns false when an instance is missing", func() {
+		pods := []corev1.Pod{newPod(restartedAt, true)}
+		Expect(isClusterRestarted(cluster, pods, restartedAt)).To(BeFalse())
+	})
+
+	It("returns false when the cluster is not healthy", func() {
+		cluster.Status.Phase = apiv1.PhaseUpgrade
+		pods := []corev1.Pod{newPod(restartedAt, true), newPod(restartedAt, true)}
+		Expect(isClusterRestarted(cluster, pods, restartedAt)).To(BeFalse())
+	})
+})
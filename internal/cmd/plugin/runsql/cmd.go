@@ -0,0 +1,101 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package runsql
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the "run-sql" command
+func NewCmd() *cobra.Command {
+	var sqlCommand string
+	var sqlFile string
+	var failFast bool
+
+	cmd := &cobra.Command{
+		Use:   "run-sql CLUSTER (--command SQL | --file FILENAME)",
+		Short: "Run SQL on every database of a CloudNativePG cluster",
+		Long: "This command runs the passed SQL on every database of the cluster which is not a template " +
+			"and accepts connections, connecting via psql to the primary instance. " +
+			"The outcome is reported for each database.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		GroupID: plugin.GroupIDDatabase,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+
+			sql, err := getSQL(sqlCommand, sqlFile)
+			if err != nil {
+				return err
+			}
+
+			return Run(cmd.Context(), clusterName, sql, failFast)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&sqlCommand,
+		"command",
+		"c",
+		"",
+		"The SQL to run on every database",
+	)
+
+	cmd.Flags().StringVarP(
+		&sqlFile,
+		"file",
+		"f",
+		"",
+		"The file containing the SQL to run on every database",
+	)
+
+	cmd.Flags().BoolVar(
+		&failFast,
+		"fail-fast",
+		false,
+		"Stop at the first database where the SQL fails",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("command", "file")
+	cmd.MarkFlagsOneRequired("command", "file")
+
+	return cmd
+}
+
+// getSQL gets the SQL to run, either passed directly or read from a file
+func getSQL(sqlCommand, sqlFile string) (string, error) {
+	if sqlFile == "" {
+		return sqlCommand, nil
+	}
+
+	content, err := os.ReadFile(sqlFile) // #nosec
+	if err != nil {
+		return "", fmt.Errorf("while reading the SQL file: %w", err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package runsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cheynewallace/tabby"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/psql"
+)
+
+// listDatabasesQuery selects the databases the SQL should be executed on,
+// skipping the templates and the ones not accepting connections
+const listDatabasesQuery = "SELECT datname FROM pg_catalog.pg_database " +
+	"WHERE datallowconn AND NOT datistemplate ORDER BY datname"
+
+// errExecutionFailed is raised when the SQL failed on at least one database
+var errExecutionFailed = errors.New("the SQL execution failed on one or more databases")
+
+// databaseResult is the outcome of the execution of the SQL on a database
+type databaseResult struct {
+	database string
+	output   string
+	err      error
+}
+
+// Run executes the passed SQL on every database of the cluster, connecting
+// to the primary instance and reporting the outcome for each one of them
+func Run(ctx context.Context, clusterName string, sqlCommand string, failFast bool) error {
+	databases, err := listDatabases(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("while listing the databases: %w", err)
+	}
+
+	results := make([]databaseResult, 0, len(databases))
+	for _, database := range databases {
+		result := runOnDatabase(ctx, clusterName, database, sqlCommand)
+		results = append(results, result)
+		if result.err != nil && failFast {
+			break
+		}
+	}
+
+	printResults(results)
+
+	for _, result := range results {
+		if result.err != nil {
+			return errExecutionFailed
+		}
+	}
+
+	return nil
+}
+
+// listDatabases gets the names of the non-template databases of the cluster
+func listDatabases(ctx context.Context, clusterName string) ([]string, error) {
+	cmd, err := newPsqlCommand(ctx, clusterName, "postgres", listDatabasesQuery, "-qAt")
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDatabaseList(string(output)), nil
+}
+
+// runOnDatabase executes the SQL on one database of the cluster
+func runOnDatabase(ctx context.Context, clusterName, database, sqlCommand string) databaseResult {
+	result := databaseResult{database: database}
+
+	cmd, err := newPsqlCommand(ctx, clusterName, database, sqlCommand, "-v", "ON_ERROR_STOP=1")
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	output, err := cmd.CombinedOutput()
+	result.output = strings.TrimSpace(string(output))
+	result.err = err
+	return result
+}
+
+// newPsqlCommand creates a psql command connecting to the passed database
+// of the primary instance
+func newPsqlCommand(
+	ctx context.Context,
+	clusterName string,
+	database string,
+	sqlCommand string,
+	args ...string,
+) (*psql.Command, error) {
+	return psql.NewCommand(ctx, psql.CommandOptions{
+		Replica:     false,
+		Namespace:   plugin.Namespace,
+		Context:     plugin.KubeContext,
+		AllocateTTY: false,
+		PassStdin:   false,
+		Args:        getPsqlArgs(database, sqlCommand, args...),
+		Name:        clusterName,
+	})
+}
+
+// getPsqlArgs gets the psql arguments needed to run a SQL command
+// on a database
+func getPsqlArgs(database string, sqlCommand string, args ...string) []string {
+	result := []string{
+		"-U",
+		"postgres",
+		"-d",
+		database,
+		"-X",
+		"-c",
+		sqlCommand,
+	}
+	return append(result, args...)
+}
+
+// parseDatabaseList parses the list of databases returned by psql
+func parseDatabaseList(output string) []string {
+	var databases []string
+	for _, line := range strings.Split(output, "\n") {
+		if database := strings.TrimSpace(line); database != "" {
+			databases = append(databases, database)
+		}
+	}
+	return databases
+}
+
+// printResults reports the outcome of the execution on each database
+func printResults(results []databaseResult) {
+	for _, result := range results {
+		if result.output == "" {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "--- %s\n%s\n\n", result.database, result.output)
+	}
+
+	summary := tabby.New()
+	summary.AddHeader("Database", "Result")
+	for _, result := range results {
+		outcome := "OK"
+		if result.err != nil {
+			outcome = fmt.Sprintf("FAILED (%v)", result.err)
+		}
+		summary.AddLine(result.database, outcome)
+	}
+	summary.Print()
+}
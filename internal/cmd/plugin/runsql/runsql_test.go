@@ -0,0 +1,66 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package runsql
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("run-sql", func() {
+	It("parses the list of databases returned by psql", func() {
+		Expect(parseDatabaseList("app\npostgres\n\n reporting \n")).
+			To(Equal([]string{"app", "postgres", "reporting"}))
+		Expect(parseDatabaseList("")).To(BeEmpty())
+	})
+
+	It("composes the psql arguments to run the SQL on a database", func() {
+		Expect(getPsqlArgs("app", "SELECT 1", "-v", "ON_ERROR_STOP=1")).To(Equal([]string{
+			"-U",
+			"postgres",
+			"-d",
+			"app",
+			"-X",
+			"-c",
+			"SELECT 1",
+			"-v",
+			"ON_ERROR_STOP=1",
+		}))
+	})
+
+	It("uses the SQL passed via the command line", func() {
+		Expect(getSQL("SELECT 1", "")).To(Equal("SELECT 1"))
+	})
+
+	It("reads the SQL from a file", func() {
+		sqlFile := filepath.Join(GinkgoT().TempDir(), "migration.sql")
+		Expect(os.WriteFile(sqlFile, []byte("CREATE SCHEMA app;"), 0o600)).To(Succeed())
+
+		Expect(getSQL("", sqlFile)).To(Equal("CREATE SCHEMA app;"))
+	})
+
+	It("fails when the SQL file doesn't exist", func() {
+		_, err := getSQL("", filepath.Join(GinkgoT().TempDir(), "missing.sql"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -20,8 +20,10 @@ SPDX-License-Identifier: Apache-2.0
 package status
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -47,8 +49,38 @@ func NewCmd() *cobra.Command {
 
 			verbose, _ := cmd.Flags().GetCount("verbose")
 			output, _ := cmd.Flags().GetString("output")
+			watch, _ := cmd.Flags().GetBool("watch")
+			lag, _ := cmd.Flags().GetBool("lag")
+			history, _ := cmd.Flags().GetBool("history")
+			interval, _ := cmd.Flags().GetDuration("interval")
 
-			return Status(ctx, clusterName, verbose, plugin.OutputFormat(output))
+			if lag && plugin.OutputFormat(output) != plugin.OutputFormatText {
+				return fmt.Errorf("the --lag option only supports the text output format")
+			}
+			if history && plugin.OutputFormat(output) != plugin.OutputFormatText {
+				return fmt.Errorf("the --history option only supports the text output format")
+			}
+			if lag && history {
+				return fmt.Errorf("the --lag and --history options are mutually exclusive")
+			}
+			if interval <= 0 {
+				return fmt.Errorf("the refresh interval must be greater than zero")
+			}
+
+			status := func(ctx context.Context) error {
+				if lag {
+					return Lag(ctx, clusterName)
+				}
+				if history {
+					return History(ctx, clusterName)
+				}
+				return Status(ctx, clusterName, verbose, plugin.OutputFormat(output))
+			}
+
+			if watch {
+				return Watch(ctx, interval, status)
+			}
+			return status(ctx)
 		},
 	}
 
@@ -56,6 +88,14 @@ func NewCmd() *cobra.Command {
 		"verbose", "v", "Increase verbosity to display more information")
 	statusCmd.Flags().StringP(
 		"output", "o", "text", "Output format. One of text|json")
+	statusCmd.Flags().BoolP(
+		"watch", "w", false, "Refresh the status continuously until interrupted")
+	statusCmd.Flags().Bool(
+		"lag", false, "Only display the replication lag of each replica")
+	statusCmd.Flags().Bool(
+		"history", false, "Only display how the cluster has been created and its major lifecycle events")
+	statusCmd.Flags().Duration(
+		"interval", 2*time.Second, "Refresh interval used together with --watch")
 
 	return statusCmd
 }
@@ -0,0 +1,320 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// historyDetail is a line of the description of the cluster history
+type historyDetail struct {
+	label string
+	value string
+}
+
+// timelineSwitch is an entry of a PostgreSQL timeline history file,
+// describing the point where a new timeline has been created
+type timelineSwitch struct {
+	parentTimeline int
+	timeline       int
+	switchPoint    string
+	reason         string
+}
+
+// History prints how a cluster has been created, and the major events
+// of its life, such as promotions and restores, as recorded in the
+// cluster status and in the timeline history of the primary instance
+func History(ctx context.Context, clusterName string) error {
+	var cluster apiv1.Cluster
+
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while trying to get cluster %s in namespace %s: %w",
+			clusterName, plugin.Namespace, err)
+	}
+
+	fmt.Printf("%s %s/%s\n\n",
+		aurora.Green("History of"),
+		cluster.Namespace,
+		cluster.Name)
+
+	fmt.Println(aurora.Green("Origin"))
+	printHistoryDetails(getBootstrapOrigin(&cluster))
+
+	fmt.Println(aurora.Green("Lifecycle"))
+	printHistoryDetails(getLifecycleEvents(&cluster))
+
+	var errs []error
+	fmt.Println(aurora.Green("Timeline history"))
+	switch {
+	case cluster.Status.TimelineID <= 1:
+		fmt.Println(aurora.Yellow("The cluster never switched timeline").String())
+		fmt.Println()
+
+	default:
+		history, err := getTimelineHistory(ctx, cluster.Name, cluster.Status.TimelineID)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		printTimelineHistory(parseTimelineHistory(history, cluster.Status.TimelineID))
+	}
+
+	if len(errs) > 0 {
+		fmt.Println(aurora.Red("Error(s) extracting history"))
+		for _, err := range errs {
+			fmt.Printf("%s\n", err)
+		}
+	}
+
+	return nil
+}
+
+func printHistoryDetails(details []historyDetail) {
+	summary := tabby.New()
+	for _, detail := range details {
+		summary.AddLine(detail.label, detail.value)
+	}
+	summary.Print()
+	fmt.Println()
+}
+
+func printTimelineHistory(switches []timelineSwitch) {
+	if len(switches) == 0 {
+		fmt.Println(aurora.Yellow("The timeline history is empty").String())
+		fmt.Println()
+		return
+	}
+
+	history := tabby.New()
+	history.AddHeader("Timeline", "Parent Timeline", "Switch Point", "Reason")
+	for _, entry := range switches {
+		history.AddLine(entry.timeline, entry.parentTimeline, entry.switchPoint, entry.reason)
+	}
+	history.Print()
+	fmt.Println()
+}
+
+// getTimelineHistory reads the history file of the passed timeline from
+// the primary instance. The history file of a timeline contains the
+// whole list of the switches which led to it
+func getTimelineHistory(ctx context.Context, clusterName string, timeline int) (string, error) {
+	_, primaryPod, err := resources.GetInstancePods(ctx, clusterName)
+	if err != nil {
+		return "", err
+	}
+	if primaryPod.Name == "" {
+		return "", fmt.Errorf("primary instance not found")
+	}
+
+	return readTimelineHistoryFile(ctx, primaryPod, timeline)
+}
+
+func readTimelineHistoryFile(ctx context.Context, pod corev1.Pod, timeline int) (string, error) {
+	timeout := time.Second * 10
+	history, _, err := utils.ExecCommand(ctx, plugin.ClientInterface, plugin.Config, pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"cat",
+		path.Join(specs.PgWalPath, fmt.Sprintf("%08X.history", timeline)))
+	if err != nil {
+		return "", fmt.Errorf("while reading the history of timeline %d: %w", timeline, err)
+	}
+
+	return history, nil
+}
+
+// parseTimelineHistory parses the content of the history file of the
+// passed timeline. Every line of the file contains the parent timeline,
+// the LSN where the switch happened and the reason of the switch, while
+// the timeline that was created is the parent of the following line
+func parseTimelineHistory(content string, timeline int) []timelineSwitch {
+	var result []timelineSwitch
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 2 {
+			continue
+		}
+
+		parentTimeline, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			continue
+		}
+
+		entry := timelineSwitch{
+			parentTimeline: parentTimeline,
+			switchPoint:    strings.TrimSpace(fields[1]),
+		}
+		if len(fields) == 3 {
+			entry.reason = strings.TrimSpace(fields[2])
+		}
+
+		if len(result) > 0 {
+			result[len(result)-1].timeline = parentTimeline
+		}
+		result = append(result, entry)
+	}
+
+	if len(result) > 0 {
+		result[len(result)-1].timeline = timeline
+	}
+
+	return result
+}
+
+// getBootstrapOrigin describes how the cluster has been created
+func getBootstrapOrigin(cluster *apiv1.Cluster) []historyDetail {
+	bootstrap := cluster.Spec.Bootstrap
+	var details []historyDetail
+
+	switch {
+	case bootstrap != nil && bootstrap.Recovery != nil:
+		recovery := bootstrap.Recovery
+		details = append(details, historyDetail{"Bootstrap method:", "recovery"})
+		switch {
+		case recovery.Backup != nil:
+			details = append(details, historyDetail{"Source:", "backup " + recovery.Backup.Name})
+		case recovery.VolumeSnapshots != nil:
+			details = append(details,
+				historyDetail{"Source:", "volume snapshot " + recovery.VolumeSnapshots.Storage.Name})
+		case recovery.Source != "":
+			details = append(details, historyDetail{"Source:", "external cluster " + recovery.Source})
+		}
+		details = append(details, historyDetail{"Recovery target:", describeRecoveryTarget(recovery.RecoveryTarget)})
+
+	case bootstrap != nil && bootstrap.PgBaseBackup != nil:
+		details = append(details,
+			historyDetail{"Bootstrap method:", "pg_basebackup"},
+			historyDetail{"Source:", "external cluster " + bootstrap.PgBaseBackup.Source},
+		)
+
+	case bootstrap != nil && bootstrap.InitDB != nil && bootstrap.InitDB.Import != nil:
+		importSpec := bootstrap.InitDB.Import
+		details = append(details,
+			historyDetail{"Bootstrap method:", "initdb"},
+			historyDetail{"Import:", fmt.Sprintf("%s import from external cluster %s",
+				importSpec.Type, importSpec.Source.ExternalCluster)},
+		)
+		if len(importSpec.Databases) > 0 {
+			details = append(details, historyDetail{"Imported databases:", strings.Join(importSpec.Databases, ", ")})
+		}
+
+	default:
+		details = append(details, historyDetail{"Bootstrap method:", "initdb"})
+	}
+
+	if cluster.IsReplica() {
+		details = append(details, historyDetail{"Replica of:", cluster.Spec.ReplicaCluster.Source})
+	}
+
+	return details
+}
+
+// describeRecoveryTarget returns a readable description of the point
+// where the recovery has been stopped
+func describeRecoveryTarget(target *apiv1.RecoveryTarget) string {
+	if target == nil {
+		return "end of the available WAL"
+	}
+
+	var parts []string
+	if target.TargetImmediate != nil && *target.TargetImmediate {
+		parts = append(parts, "immediately after the base backup")
+	}
+	if target.TargetTime != "" {
+		parts = append(parts, "time "+target.TargetTime)
+	}
+	if target.TargetXID != "" {
+		parts = append(parts, "transaction "+target.TargetXID)
+	}
+	if target.TargetName != "" {
+		parts = append(parts, "restore point "+target.TargetName)
+	}
+	if target.TargetLSN != "" {
+		parts = append(parts, "LSN "+target.TargetLSN)
+	}
+	if target.TargetTLI != "" {
+		parts = append(parts, "timeline "+target.TargetTLI)
+	}
+	if target.BackupID != "" {
+		parts = append(parts, "backup ID "+target.BackupID)
+	}
+	if len(parts) == 0 {
+		return "end of the available WAL"
+	}
+
+	result := strings.Join(parts, ", ")
+	if target.Exclusive != nil && *target.Exclusive {
+		result += " (exclusive)"
+	}
+	return result
+}
+
+// getLifecycleEvents describes the major events of the life of the
+// cluster, as recorded in its status
+func getLifecycleEvents(cluster *apiv1.Cluster) []historyDetail {
+	details := []historyDetail{
+		{"Created:", cluster.CreationTimestamp.Format(time.RFC3339)},
+		{"Current timeline:", strconv.Itoa(cluster.Status.TimelineID)},
+	}
+
+	if cluster.Status.CurrentPrimary != "" {
+		primary := cluster.Status.CurrentPrimary
+		if cluster.Status.CurrentPrimaryTimestamp != "" {
+			primary = fmt.Sprintf("%s (since %s)", primary, cluster.Status.CurrentPrimaryTimestamp)
+		}
+		details = append(details, historyDetail{"Current primary:", primary})
+	}
+
+	if cluster.Status.LastFailoverTimestamp != "" {
+		details = append(details, historyDetail{"Last failover:", cluster.Status.LastFailoverTimestamp})
+	}
+
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary && cluster.Status.TargetPrimary != "" {
+		details = append(details, historyDetail{"Switching to:", fmt.Sprintf("%s (requested at %s)",
+			cluster.Status.TargetPrimary, cluster.Status.TargetPrimaryTimestamp)})
+	}
+
+	return details
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster history", func() {
+	It("parses the timeline history files", func() {
+		content := "1\t0/3000158\tno recovery target specified\n\n" +
+			"2\t0/5000000\tbefore 2025-01-01 12:00:00+00\n"
+
+		Expect(parseTimelineHistory(content, 3)).To(Equal([]timelineSwitch{
+			{parentTimeline: 1, timeline: 2, switchPoint: "0/3000158", reason: "no recovery target specified"},
+			{parentTimeline: 2, timeline: 3, switchPoint: "0/5000000", reason: "before 2025-01-01 12:00:00+00"},
+		}))
+		Expect(parseTimelineHistory("", 1)).To(BeEmpty())
+	})
+
+	It("describes a cluster created from scratch", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{InitDB: &apiv1.BootstrapInitDB{}},
+			},
+		}
+		Expect(getBootstrapOrigin(cluster)).To(Equal([]historyDetail{
+			{"Bootstrap method:", "initdb"},
+		}))
+	})
+
+	It("describes a cluster restored from a backup", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Backup: &apiv1.BackupSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "backup-1"}},
+						RecoveryTarget: &apiv1.RecoveryTarget{
+							TargetTime: "2025-01-01 12:00:00+00",
+						},
+					},
+				},
+			},
+		}
+		Expect(getBootstrapOrigin(cluster)).To(Equal([]historyDetail{
+			{"Bootstrap method:", "recovery"},
+			{"Source:", "backup backup-1"},
+			{"Recovery target:", "time 2025-01-01 12:00:00+00"},
+		}))
+	})
+})
+
+var _ = DescribeTable("describeRecoveryTarget",
+	func(target *apiv1.RecoveryTarget, expected string) {
+		Expect(describeRecoveryTarget(target)).To(Equal(expected))
+	},
+	Entry("no target", nil, "end of the available WAL"),
+	Entry("empty target", &apiv1.RecoveryTarget{}, "end of the available WAL"),
+	Entry("immediate target", &apiv1.RecoveryTarget{TargetImmediate: ptr.To(true)},
+		"immediately after the base backup"),
+	Entry("exclusive LSN on a timeline",
+		&apiv1.RecoveryTarget{TargetLSN: "0/3000000", TargetTLI: "2", Exclusive: ptr.To(true)},
+		"LSN 0/3000000, timeline 2 (exclusive)"),
+)
@@ -0,0 +1,160 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	"github.com/logrusorgru/aurora/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// clearScreen is the ANSI escape sequence moving the cursor to the top
+// left corner of the terminal and clearing its content
+const clearScreen = "\033[H\033[2J"
+
+// Lag prints the replication lag of every replica of a cluster, as
+// reported by the pg_stat_replication view of the primary instance
+func Lag(ctx context.Context, clusterName string) error {
+	var cluster apiv1.Cluster
+
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while trying to get cluster %s in namespace %s: %w",
+			clusterName, plugin.Namespace, err)
+	}
+
+	status := extractPostgresqlStatus(ctx, cluster)
+	fmt.Printf("%s %s/%s (%s)\n\n",
+		aurora.Green("Replication lag of"),
+		cluster.Namespace,
+		cluster.Name,
+		time.Now().Format(time.RFC3339))
+	status.printReplicationLag()
+
+	if len(status.ErrorList) > 0 {
+		fmt.Println(aurora.Red("Error(s) extracting status"))
+		for _, err := range status.ErrorList {
+			fmt.Printf("%s\n", err)
+		}
+	}
+
+	return nil
+}
+
+// Watch clears the terminal and invokes the passed function every
+// interval, until the context is cancelled or the user interrupts it
+func Watch(ctx context.Context, interval time.Duration, f func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		fmt.Print(clearScreen)
+		if err := f(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (fullStatus *PostgresqlStatus) printReplicationLag() {
+	primaryInstanceStatus := fullStatus.tryGetPrimaryInstance()
+	if primaryInstanceStatus == nil {
+		fmt.Println(aurora.Yellow("Primary instance not found").String())
+		return
+	}
+
+	if len(primaryInstanceStatus.ReplicationInfo) == 0 {
+		fmt.Println(aurora.Yellow("No streaming replicas found").String())
+		return
+	}
+
+	currentLSN := getCurrentLSN(*primaryInstanceStatus)
+
+	status := tabby.New()
+	status.AddHeader(
+		"Name",
+		"Sent Lag (bytes)",
+		"Flush Lag (bytes)",
+		"Replay Lag (bytes)",
+		"Write Lag",
+		"Flush Lag",
+		"Replay Lag",
+		"State",
+		"Sync State",
+	)
+
+	replicationInfo := primaryInstanceStatus.ReplicationInfo
+	sort.Sort(replicationInfo)
+	for _, replication := range replicationInfo {
+		status.AddLine(
+			replication.ApplicationName,
+			getLagBytes(currentLSN, replication.SentLsn),
+			getLagBytes(currentLSN, replication.FlushLsn),
+			getLagBytes(currentLSN, replication.ReplayLsn),
+			replication.WriteLag,
+			replication.FlushLag,
+			replication.ReplayLag,
+			replication.State,
+			replication.SyncState,
+		)
+	}
+	status.Print()
+	fmt.Println()
+}
+
+// getLagBytes returns the number of bytes a replica position is behind
+// the current position of the primary, or an empty string when any of
+// the two is not known
+func getLagBytes(current, position types.LSN) string {
+	currentValue, err := current.Parse()
+	if err != nil {
+		return ""
+	}
+
+	positionValue, err := position.Parse()
+	if err != nil {
+		return ""
+	}
+
+	// The primary position and the replica positions are not taken
+	// atomically, so the replica may look ahead of the primary
+	if positionValue > currentValue {
+		return "0"
+	}
+
+	return strconv.FormatUint(currentValue-positionValue, 10)
+}
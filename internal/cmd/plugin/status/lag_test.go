@@ -0,0 +1,39 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"github.com/cloudnative-pg/machinery/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("getLagBytes",
+	func(current, position types.LSN, expected string) {
+		Expect(getLagBytes(current, position)).To(Equal(expected))
+	},
+	Entry("replica in sync", types.LSN("0/604DE38"), types.LSN("0/604DE38"), "0"),
+	Entry("replica behind the primary", types.LSN("0/6000100"), types.LSN("0/6000000"), "256"),
+	Entry("replica behind across a WAL log", types.LSN("1/0"), types.LSN("0/FFFFFF00"), "256"),
+	Entry("replica apparently ahead of the primary", types.LSN("0/6000000"), types.LSN("0/6000100"), "0"),
+	Entry("unknown replica position", types.LSN("0/6000000"), types.LSN(""), ""),
+	Entry("unknown primary position", types.LSN(""), types.LSN("0/6000000"), ""),
+)
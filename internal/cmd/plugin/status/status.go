@@ -825,6 +825,7 @@ func (fullStatus *PostgresqlStatus) printInstancesStatus() {
 	//              else print "Standby (starting up)"
 	//  else:
 	//  	if it is paused, print "Standby (paused)"
+	//  	else if it is delayed, print "Standby (delayed <delay>)"
 	//  	else if SyncState = sync/quorum print "Standby (sync)"
 	//  	else if SyncState = potential print "Standby (potential sync)"
 	//  	else print "Standby (async)"
@@ -969,6 +970,10 @@ func getReplicaRole(instance postgres.PostgresqlStatus, fullStatus *PostgresqlSt
 		return "Standby (paused)"
 	}
 
+	if instance.MinApplyDelay != "" {
+		return fmt.Sprintf("Standby (delayed %s)", instance.MinApplyDelay)
+	}
+
 	primaryInstanceStatus := fullStatus.tryGetPrimaryInstance()
 	if primaryInstanceStatus == nil {
 		return "Unknown"
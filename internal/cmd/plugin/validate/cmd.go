@@ -0,0 +1,46 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the "validate" command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate PATH...",
+		Short: "Validate CloudNativePG manifests without connecting to a Kubernetes cluster",
+		Long: "This command reads the resources of CloudNativePG contained in the passed YAML or JSON files, " +
+			"walking the passed directories recursively, and runs on them the same defaulting and validation " +
+			"logic of the webhooks of the operator. Errors and warnings are reported for each file, and the " +
+			"command exits with a non-zero status if any resource is invalid. " +
+			"The validations enforced by the CRD schemas are not evaluated.",
+		Args:    cobra.MinimumNArgs(1),
+		GroupID: plugin.GroupIDMiscellaneous,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validate(cmd.Context(), cmd.OutOrStdout(), args)
+		},
+	}
+
+	return cmd
+}
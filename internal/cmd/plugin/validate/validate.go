@@ -0,0 +1,240 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/yaml"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	webhookv1 "github.com/cloudnative-pg/cloudnative-pg/internal/webhook/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// manifestExtensions are the extensions of the files read when walking a directory
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// resourceWebhooks contains the webhooks the operator uses for a kind of resource
+type resourceWebhooks struct {
+	newObject func() runtime.Object
+	defaulter webhook.CustomDefaulter
+	validator webhook.CustomValidator
+}
+
+// webhooksByKind contains the webhooks of every kind of resource validated by the operator
+var webhooksByKind = map[string]resourceWebhooks{
+	apiv1.ClusterKind: {
+		newObject: func() runtime.Object { return &apiv1.Cluster{} },
+		defaulter: &webhookv1.ClusterCustomDefaulter{},
+		validator: &webhookv1.ClusterCustomValidator{},
+	},
+	apiv1.BackupKind: {
+		newObject: func() runtime.Object { return &apiv1.Backup{} },
+		defaulter: &webhookv1.BackupCustomDefaulter{},
+		validator: &webhookv1.BackupCustomValidator{},
+	},
+	"ScheduledBackup": {
+		newObject: func() runtime.Object { return &apiv1.ScheduledBackup{} },
+		defaulter: &webhookv1.ScheduledBackupCustomDefaulter{},
+		validator: &webhookv1.ScheduledBackupCustomValidator{},
+	},
+	apiv1.PoolerKind: {
+		newObject: func() runtime.Object { return &apiv1.Pooler{} },
+		validator: &webhookv1.PoolerCustomValidator{},
+	},
+	apiv1.DatabaseKind: {
+		newObject: func() runtime.Object { return &apiv1.Database{} },
+		defaulter: &webhookv1.DatabaseCustomDefaulter{},
+		validator: &webhookv1.DatabaseCustomValidator{},
+	},
+}
+
+// summary contains the outcome of the validation of the manifests
+type summary struct {
+	files     int
+	resources int
+	invalid   int
+	warnings  int
+}
+
+// validate validates the CloudNativePG resources contained in the passed
+// files and directories, writing the errors and the warnings to out
+func validate(ctx context.Context, out io.Writer, paths []string) error {
+	files, err := getManifestFiles(paths)
+	if err != nil {
+		return err
+	}
+
+	// There's no Kubernetes cluster to detect the capabilities of, so we
+	// assume the VolumeSnapshot CRD is installed as required by the backups
+	// taken with volume snapshots
+	utils.SetVolumeSnapshot(true)
+
+	var result summary
+	for _, file := range files {
+		content, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			return fmt.Errorf("while reading %s: %w", file, err)
+		}
+
+		result.files++
+		validateManifest(ctx, out, file, content, &result)
+	}
+
+	_, _ = fmt.Fprintf(out, "%d resources validated in %d files: %d invalid, %d warnings\n",
+		result.resources, result.files, result.invalid, result.warnings)
+
+	if result.invalid > 0 {
+		return fmt.Errorf("%d invalid resources found", result.invalid)
+	}
+
+	return nil
+}
+
+// getManifestFiles returns the passed files, and the manifest files
+// contained in the passed directories and in their subdirectories
+func getManifestFiles(paths []string) ([]string, error) {
+	var result []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			result = append(result, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(filePath string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && slices.Contains(manifestExtensions, strings.ToLower(filepath.Ext(filePath))) {
+				result = append(result, filePath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// validateManifest validates the CloudNativePG resources contained in the
+// documents of a manifest, ignoring the resources of any other kind
+func validateManifest(ctx context.Context, out io.Writer, file string, content []byte, result *summary) {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		var document runtime.RawExtension
+		if err := decoder.Decode(&document); err != nil {
+			if !errors.Is(err, io.EOF) {
+				result.invalid++
+				_, _ = fmt.Fprintf(out, "%s: error: %v\n", file, err)
+			}
+			return
+		}
+
+		if len(document.Raw) == 0 || string(document.Raw) == "null" {
+			continue
+		}
+
+		var header metav1.PartialObjectMetadata
+		if err := yaml.Unmarshal(document.Raw, &header); err != nil {
+			result.invalid++
+			_, _ = fmt.Fprintf(out, "%s: error: %v\n", file, err)
+			continue
+		}
+
+		webhooks, found := webhooksByKind[header.Kind]
+		if header.GroupVersionKind().GroupVersion() != apiv1.SchemeGroupVersion || !found {
+			continue
+		}
+
+		result.resources++
+		resource := fmt.Sprintf("%s: %s %q", file, header.Kind, header.Name)
+		warnings, errs := validateResource(ctx, webhooks, document.Raw)
+		for _, warning := range warnings {
+			result.warnings++
+			_, _ = fmt.Fprintf(out, "%s: warning: %s\n", resource, warning)
+		}
+		if len(errs) > 0 {
+			result.invalid++
+		}
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(out, "%s: error: %s\n", resource, err)
+		}
+	}
+}
+
+// validateResource applies the defaulting and the validation webhooks to
+// a resource, as it happens when the resource is created, returning the
+// warnings and the errors reported by the operator
+func validateResource(ctx context.Context, webhooks resourceWebhooks, raw []byte) ([]string, []string) {
+	obj := webhooks.newObject()
+	if err := yaml.UnmarshalStrict(raw, obj); err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	if webhooks.defaulter != nil {
+		if err := webhooks.defaulter.Default(ctx, obj); err != nil {
+			return nil, []string{err.Error()}
+		}
+	}
+
+	warnings, err := webhooks.validator.ValidateCreate(ctx, obj)
+	if err == nil {
+		return warnings, nil
+	}
+
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil ||
+		len(statusErr.Status().Details.Causes) == 0 {
+		return warnings, []string{err.Error()}
+	}
+
+	causes := statusErr.Status().Details.Causes
+	errs := make([]string, 0, len(causes))
+	for _, cause := range causes {
+		if cause.Field == "" {
+			errs = append(errs, cause.Message)
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+	}
+
+	return warnings, errs
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate", func() {
+	const validManifest = `apiVersion: postgresql.cnpg.io/v1
+kind: Cluster
+metadata:
+  name: cluster-example
+spec:
+  instances: 3
+  storage:
+    size: 1Gi
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-validated
+`
+
+	var (
+		dir string
+		out *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		out = &bytes.Buffer{}
+	})
+
+	writeManifest := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("accepts valid manifests, ignoring the resources of other kinds", func(ctx SpecContext) {
+		writeManifest("cluster.yaml", validManifest)
+		writeManifest("README.md", "not a manifest")
+
+		Expect(validate(ctx, out, []string{dir})).To(Succeed())
+		Expect(out.String()).To(Equal("1 resources validated in 1 files: 0 invalid, 0 warnings\n"))
+	})
+
+	It("reports the errors of the invalid resources", func(ctx SpecContext) {
+		writeManifest("nested/invalid.yml", `apiVersion: postgresql.cnpg.io/v1
+kind: Cluster
+metadata:
+  name: cluster-invalid
+spec:
+  instances: 3
+  storage:
+    size: 1Gi
+  postgresql:
+    parameters:
+      wal_level: minimal
+`)
+		writeManifest("unknown.yaml", `apiVersion: postgresql.cnpg.io/v1
+kind: Backup
+metadata:
+  name: backup-example
+spec:
+  cluster:
+    name: cluster-example
+  unknownField: true
+`)
+
+		err := validate(ctx, out, []string{dir})
+		Expect(err).To(MatchError("2 invalid resources found"))
+		Expect(out.String()).To(ContainSubstring(
+			filepath.Join(dir, "nested/invalid.yml") + ": Cluster \"cluster-invalid\": error: " +
+				"spec.postgresql.parameters.wal_level"))
+		Expect(out.String()).To(ContainSubstring(
+			filepath.Join(dir, "unknown.yaml") + ": Backup \"backup-example\": error: "))
+		Expect(out.String()).To(ContainSubstring(`unknown field "unknownField"`))
+		Expect(out.String()).To(ContainSubstring("2 resources validated in 2 files: 2 invalid"))
+	})
+
+	It("reports the manifests that cannot be parsed", func(ctx SpecContext) {
+		path := writeManifest("broken.yaml", "kind: [")
+
+		Expect(validate(ctx, out, []string{path})).To(HaveOccurred())
+		Expect(out.String()).To(HavePrefix(path + ": error: "))
+	})
+
+	It("fails when a path doesn't exist", func(ctx SpecContext) {
+		Expect(validate(ctx, out, []string{filepath.Join(dir, "missing")})).To(HaveOccurred())
+	})
+})
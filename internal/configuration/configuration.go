@@ -23,6 +23,7 @@ package configuration
 
 import (
 	"path"
+	"slices"
 	"strings"
 	"time"
 
@@ -47,6 +48,15 @@ const (
 	// DefaultKubernetesClusterDomain is the default value used as
 	// Kubernetes cluster domain.
 	DefaultKubernetesClusterDomain = "cluster.local"
+
+	// DefaultUnschedulableInstancesThreshold is the default time, in seconds,
+	// an instance pod must be unschedulable for to be reported in the status
+	// of the cluster
+	DefaultUnschedulableInstancesThreshold = 60
+
+	// ImageNameDefault is the name used in DisabledDefaults to
+	// prevent the image name of the clusters from being defaulted
+	ImageNameDefault = "imageName"
 )
 
 // DefaultDrainTaints is the default list of taints the operator will watch and treat
@@ -159,12 +169,35 @@ type Data struct {
 	// primary server in CloudNativePG.
 	StandbyTCPUserTimeout int `json:"standbyTcpUserTimeout" env:"STANDBY_TCP_USER_TIMEOUT"`
 
+	// InstanceConnectionRetryTimeout is the maximum time, in seconds, the
+	// instance manager spends retrying a connection to the local PostgreSQL
+	// server while it is not accepting connections (SQLSTATE 57P03), e.g.
+	// while it is starting up, recovering or shutting down. When 0, the
+	// default of the instance manager is used; a negative value disables
+	// the retries.
+	InstanceConnectionRetryTimeout int `json:"instanceConnectionRetryTimeout" env:"INSTANCE_CONNECTION_RETRY_TIMEOUT"` //nolint
+
+	// ApplicationNamePrefix is the prefix of the application_name used
+	// by the connections that the instance manager opens to PostgreSQL.
+	// When empty, the built-in application names are used.
+	ApplicationNamePrefix string `json:"applicationNamePrefix" env:"APPLICATION_NAME_PREFIX"`
+
 	// KubernetesClusterDomain defines the domain suffix for service FQDNs
 	// within the Kubernetes cluster. If left unset, it defaults to `cluster.local`.
 	KubernetesClusterDomain string `json:"kubernetesClusterDomain" env:"KUBERNETES_CLUSTER_DOMAIN"`
 
 	// DrainTaints is a list of taints the operator will watch and treat as Unschedule
 	DrainTaints []string `json:"drainTaints" env:"DRAIN_TAINTS"`
+
+	// DisabledDefaults is a list of the defaults that are not stored in
+	// the specification of the clusters, so that it matches what the users
+	// applied. Currently, only `imageName` is supported.
+	DisabledDefaults []string `json:"disabledDefaults" env:"DISABLED_DEFAULTS"`
+
+	// UnschedulableInstancesThreshold is the time, in seconds, an instance
+	// pod must be unschedulable for to be reported in the `InstancesSchedulable`
+	// condition of the cluster. A negative value disables the reporting.
+	UnschedulableInstancesThreshold int `json:"unschedulableInstancesThreshold" env:"UNSCHEDULABLE_INSTANCES_THRESHOLD"` //nolint
 }
 
 // Current is the configuration used by the operator
@@ -183,6 +216,8 @@ func newDefaultConfig() *Data {
 		StandbyTCPUserTimeout:   0,
 		KubernetesClusterDomain: DefaultKubernetesClusterDomain,
 		DrainTaints:             DefaultDrainTaints,
+
+		UnschedulableInstancesThreshold: DefaultUnschedulableInstancesThreshold,
 	}
 }
 
@@ -211,6 +246,12 @@ func (config *Data) IsLabelInherited(name string) bool {
 	return evaluateGlobPatterns(config.InheritedLabels, name)
 }
 
+// IsDefaultDisabled checks if the default with a certain name should
+// not be applied to the specification of the clusters
+func (config *Data) IsDefaultDisabled(name string) bool {
+	return slices.Contains(config.DisabledDefaults, name)
+}
+
 // GetClustersRolloutDelay gets the delay between roll-outs of different clusters
 func (config *Data) GetClustersRolloutDelay() time.Duration {
 	return time.Duration(config.ClustersRolloutDelay) * time.Second
@@ -222,6 +263,12 @@ func (config *Data) GetInstancesRolloutDelay() time.Duration {
 	return time.Duration(config.InstancesRolloutDelay) * time.Second
 }
 
+// GetUnschedulableInstancesThreshold gets the time an instance pod must be
+// unschedulable for to be reported in the status of the cluster
+func (config *Data) GetUnschedulableInstancesThreshold() time.Duration {
+	return time.Duration(config.UnschedulableInstancesThreshold) * time.Second
+}
+
 // WatchedNamespaces get the list of additional watched namespaces.
 // The result is a list of namespaces specified in the WATCHED_NAMESPACE where
 // each namespace is separated by comma
@@ -151,6 +151,14 @@ var _ = Describe("Annotation and label inheritance", func() {
 		})
 	})
 
+	It("reads the list of disabled defaults", func() {
+		config := newDefaultConfig()
+		Expect(config.IsDefaultDisabled(ImageNameDefault)).To(BeFalse())
+
+		config.ReadConfigMap(map[string]string{"DISABLED_DEFAULTS": "imageName"})
+		Expect(config.IsDefaultDisabled(ImageNameDefault)).To(BeTrue())
+	})
+
 	It("returns correct delay for clusters rollout", func() {
 		config := Data{ClustersRolloutDelay: 10}
 		Expect(config.GetClustersRolloutDelay()).To(Equal(10 * time.Second))
@@ -170,4 +178,11 @@ var _ = Describe("Annotation and label inheritance", func() {
 		config := Data{}
 		Expect(config.GetInstancesRolloutDelay()).To(BeZero())
 	})
+
+	It("reports the unschedulable instances after one minute by default", func() {
+		Expect(newDefaultConfig().GetUnschedulableInstancesThreshold()).To(Equal(time.Minute))
+
+		config := Data{UnschedulableInstancesThreshold: 300}
+		Expect(config.GetUnschedulableInstancesThreshold()).To(Equal(5 * time.Minute))
+	})
 })
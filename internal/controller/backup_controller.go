@@ -44,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
@@ -66,6 +67,11 @@ const backupPhase = ".status.phase"
 // where the name of the cluster is written
 const clusterNameField = ".spec.cluster.name"
 
+// runningBackupRequeueInterval is the interval after which a backup taken by
+// the instance manager is reconciled again while running, to detect when its
+// target Pod is not healthy anymore. It applies whatever the resync interval.
+const runningBackupRequeueInterval = 10 * time.Minute
+
 // ErrPrimaryImageNeedsUpdate is returned when the primary instance is not running with the latest image
 var ErrPrimaryImageNeedsUpdate = fmt.Errorf("primary instance not having expected image, cannot run backup")
 
@@ -78,6 +84,12 @@ type BackupReconciler struct {
 	Recorder record.EventRecorder
 	Plugins  repository.Interface
 
+	// ResyncInterval is the interval after which a backup that is neither
+	// completed nor failed is reconciled again when nothing changed. Zero,
+	// the default, disables the periodic resync. The running backups taken
+	// by the instance manager are anyway reconciled every 10 minutes
+	ResyncInterval time.Duration
+
 	instanceStatusClient remote.InstanceClient
 	vsr                  *volumesnapshot.Reconciler
 }
@@ -190,7 +202,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// When the instance manager is working we have to wait for it to finish
 	if isRunning && backup.Spec.Method.IsManagedByInstance() {
-		return ctrl.Result{RequeueAfter: 10 * time.Minute}, nil
+		return r.requeueRunningBackup(), nil
 	}
 
 	// The backup is ready to start, and before starting it we store
@@ -224,7 +236,10 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	contextLogger.Debug(fmt.Sprintf("object %#q has been reconciled", req.NamespacedName))
 
 	hookResult := postReconcilePluginHooks(ctx, &cluster, &backup)
-	return hookResult.Result, hookResult.Err
+	if hookResult.Err != nil {
+		return hookResult.Result, hookResult.Err
+	}
+	return requeueForResync(hookResult.Result, r.ResyncInterval), nil
 }
 
 func (r *BackupReconciler) startBackupManagedByInstance(
@@ -373,7 +388,8 @@ func (r *BackupReconciler) checkPrerequisites(
 		}
 	}
 
-	if backup.Spec.Method == apiv1.BackupMethodBarmanObjectStore {
+	if backup.Spec.Method == apiv1.BackupMethodBarmanObjectStore ||
+		backup.Spec.Method == apiv1.BackupMethodPgDump {
 		if cluster.Spec.Backup.BarmanObjectStore == nil {
 			const message = "no barmanObjectStore section defined on the target cluster"
 			return flagMissingPrerequisite(message, "ClusterHasNoBarmanSection")
@@ -701,7 +717,9 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context,
 		return nil, err
 	}
 	var backupTarget apiv1.BackupTarget
-	if cluster.Spec.Backup != nil {
+	if cluster.Spec.Backup != nil && backup.Spec.Method != apiv1.BackupMethodPgDump {
+		// Logical dumps are taken on a standby, to avoid loading the
+		// primary, unless the backup explicitly requires otherwise
 		backupTarget = cluster.Spec.Backup.Target
 	}
 	if backup.Spec.Target != "" {
@@ -752,6 +770,13 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context,
 	return &pod, nil
 }
 
+// requeueRunningBackup returns the result used to wait for a running backup
+// taken by the instance manager, which is reconciled again at least every
+// runningBackupRequeueInterval, or earlier if the resync interval is shorter
+func (r *BackupReconciler) requeueRunningBackup() ctrl.Result {
+	return requeueForResync(ctrl.Result{RequeueAfter: runningBackupRequeueInterval}, r.ResyncInterval)
+}
+
 // startInstanceManagerBackup request a backup in a Pod and marks the backup started
 // or failed if needed
 func startInstanceManagerBackup(
@@ -818,6 +843,10 @@ func (r *BackupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manage
 		return err
 	}
 
+	if err := metrics.Registry.Register(newBackupMetricsCollector(mgr.GetClient())); err != nil {
+		return err
+	}
+
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Backup{}).
 		Named("backup").
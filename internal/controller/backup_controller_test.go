@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -538,3 +539,18 @@ var _ = Describe("checkPrerequisites for plugin backups", func() {
 		Expect(stored.Status.Method).To(BeEquivalentTo(apiv1.BackupMethodPlugin))
 	})
 })
+
+var _ = Describe("requeueRunningBackup", func() {
+	It("requeues the running backups every 10 minutes with the default settings", func() {
+		r := &BackupReconciler{}
+		Expect(r.requeueRunningBackup()).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Minute}))
+	})
+
+	It("uses the resync interval only when it is shorter", func() {
+		r := &BackupReconciler{ResyncInterval: time.Minute}
+		Expect(r.requeueRunningBackup()).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+		r.ResyncInterval = time.Hour
+		Expect(r.requeueRunningBackup()).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Minute}))
+	})
+})
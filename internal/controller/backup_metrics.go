@@ -0,0 +1,226 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+const (
+	// backupMetricsTimeout is the maximum time spent listing the
+	// backups when collecting the metrics
+	backupMetricsTimeout = 10 * time.Second
+
+	backupTargetPrimary = "primary"
+	backupTargetStandby = "standby"
+)
+
+// backupMetricsKey identifies the group of backups a metric refers to
+type backupMetricsKey struct {
+	namespace string
+	cluster   string
+	method    string
+	target    string
+}
+
+// backupMetricsCollector exposes the metrics about the backups of the
+// clusters, computing them from the Backup objects when they are scraped
+type backupMetricsCollector struct {
+	cli client.Reader
+	now func() time.Time
+
+	running           *prometheus.Desc
+	runningDuration   *prometheus.Desc
+	completedDuration *prometheus.Desc
+
+	scheduledConsecutiveFailures *prometheus.Desc
+	scheduledLastSuccess         *prometheus.Desc
+	scheduledLastFailure         *prometheus.Desc
+}
+
+// newBackupMetricsCollector creates a collector reading the backups
+// using the passed client
+func newBackupMetricsCollector(cli client.Reader) *backupMetricsCollector {
+	return &backupMetricsCollector{
+		cli: cli,
+		now: time.Now,
+		running: prometheus.NewDesc(
+			"cnpg_backups_running",
+			"Number of backups currently running",
+			[]string{"namespace", "cluster", "method", "target"}, nil,
+		),
+		runningDuration: prometheus.NewDesc(
+			"cnpg_backups_running_duration_seconds",
+			"Time elapsed since the start of the running backup",
+			[]string{"namespace", "cluster", "name", "method", "target"}, nil,
+		),
+		completedDuration: prometheus.NewDesc(
+			"cnpg_backups_completed_duration_seconds_average",
+			"Average duration of the completed backups which still exist",
+			[]string{"namespace", "cluster", "method"}, nil,
+		),
+		scheduledConsecutiveFailures: prometheus.NewDesc(
+			"cnpg_scheduled_backups_consecutive_failures",
+			"Number of consecutive failed backups of the scheduled backup",
+			[]string{"namespace", "cluster", "name"}, nil,
+		),
+		scheduledLastSuccess: prometheus.NewDesc(
+			"cnpg_scheduled_backups_last_success_timestamp_seconds",
+			"Time when the last successful backup of the scheduled backup stopped",
+			[]string{"namespace", "cluster", "name"}, nil,
+		),
+		scheduledLastFailure: prometheus.NewDesc(
+			"cnpg_scheduled_backups_last_failure_timestamp_seconds",
+			"Time when the last failed backup of the scheduled backup stopped",
+			[]string{"namespace", "cluster", "name"}, nil,
+		),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *backupMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.running
+	ch <- c.runningDuration
+	ch <- c.completedDuration
+	ch <- c.scheduledConsecutiveFailures
+	ch <- c.scheduledLastSuccess
+	ch <- c.scheduledLastFailure
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *backupMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), backupMetricsTimeout)
+	defer cancel()
+
+	var backups apiv1.BackupList
+	if err := c.cli.List(ctx, &backups); err != nil {
+		log.Error(err, "while listing the backups to collect their metrics")
+		return
+	}
+
+	var clusters apiv1.ClusterList
+	if err := c.cli.List(ctx, &clusters); err != nil {
+		log.Error(err, "while listing the clusters to collect the backup metrics")
+		return
+	}
+	currentPrimaries := make(map[client.ObjectKey]string, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		currentPrimaries[client.ObjectKeyFromObject(&cluster)] = cluster.Status.CurrentPrimary
+	}
+
+	now := c.now()
+	runningCount := make(map[backupMetricsKey]int)
+	completedTotal := make(map[backupMetricsKey]time.Duration)
+	completedCount := make(map[backupMetricsKey]int)
+	for _, backup := range backups.Items {
+		if backup.Status.StartedAt == nil {
+			continue
+		}
+
+		key := backupMetricsKey{
+			namespace: backup.Namespace,
+			cluster:   backup.Spec.Cluster.Name,
+			method:    string(backup.Spec.Method),
+		}
+
+		switch backup.Status.Phase {
+		case apiv1.BackupPhaseStarted, apiv1.BackupPhaseRunning, apiv1.BackupPhaseFinalizing:
+			clusterKey := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.Cluster.Name}
+			key.target = getBackupTargetRole(&backup, currentPrimaries[clusterKey])
+			runningCount[key]++
+			ch <- prometheus.MustNewConstMetric(
+				c.runningDuration, prometheus.GaugeValue,
+				now.Sub(backup.Status.StartedAt.Time).Seconds(),
+				key.namespace, key.cluster, backup.Name, key.method, key.target,
+			)
+
+		case apiv1.BackupPhaseCompleted:
+			if backup.Status.StoppedAt == nil {
+				continue
+			}
+			completedTotal[key] += backup.Status.StoppedAt.Sub(backup.Status.StartedAt.Time)
+			completedCount[key]++
+		}
+	}
+
+	for key, count := range runningCount {
+		ch <- prometheus.MustNewConstMetric(
+			c.running, prometheus.GaugeValue, float64(count),
+			key.namespace, key.cluster, key.method, key.target,
+		)
+	}
+
+	for key, count := range completedCount {
+		ch <- prometheus.MustNewConstMetric(
+			c.completedDuration, prometheus.GaugeValue,
+			completedTotal[key].Seconds()/float64(count),
+			key.namespace, key.cluster, key.method,
+		)
+	}
+
+	c.collectScheduledBackups(ctx, ch)
+}
+
+// collectScheduledBackups exposes the outcome of the backups created by
+// the scheduled backups
+func (c *backupMetricsCollector) collectScheduledBackups(ctx context.Context, ch chan<- prometheus.Metric) {
+	var scheduledBackups apiv1.ScheduledBackupList
+	if err := c.cli.List(ctx, &scheduledBackups); err != nil {
+		log.Error(err, "while listing the scheduled backups to collect their metrics")
+		return
+	}
+
+	for _, scheduledBackup := range scheduledBackups.Items {
+		labels := []string{scheduledBackup.Namespace, scheduledBackup.Spec.Cluster.Name, scheduledBackup.Name}
+		scheduledBackupStatus := &scheduledBackup.Status
+		ch <- prometheus.MustNewConstMetric(
+			c.scheduledConsecutiveFailures, prometheus.GaugeValue,
+			float64(scheduledBackupStatus.ConsecutiveFailures), labels...,
+		)
+		if scheduledBackupStatus.LastSuccessfulBackupTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.scheduledLastSuccess, prometheus.GaugeValue,
+				float64(scheduledBackupStatus.LastSuccessfulBackupTime.Unix()), labels...,
+			)
+		}
+		if scheduledBackupStatus.LastFailedBackupTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.scheduledLastFailure, prometheus.GaugeValue,
+				float64(scheduledBackupStatus.LastFailedBackupTime.Unix()), labels...,
+			)
+		}
+	}
+}
+
+// getBackupTargetRole returns the role of the instance taking the backup
+func getBackupTargetRole(backup *apiv1.Backup, currentPrimary string) string {
+	if backup.Status.InstanceID != nil && backup.Status.InstanceID.PodName == currentPrimary {
+		return backupTargetPrimary
+	}
+
+	return backupTargetStandby
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backup metrics", func() {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newBackup := func(
+		name string,
+		method apiv1.BackupMethod,
+		phase apiv1.BackupPhase,
+		podName string,
+		startedAt time.Time,
+		stoppedAt *time.Time,
+	) *apiv1.Backup {
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:  method,
+			},
+			Status: apiv1.BackupStatus{
+				Phase:      phase,
+				StartedAt:  &metav1.Time{Time: startedAt},
+				InstanceID: &apiv1.InstanceID{PodName: podName},
+			},
+		}
+		if stoppedAt != nil {
+			backup.Status.StoppedAt = &metav1.Time{Time: *stoppedAt}
+		}
+		return backup
+	}
+
+	It("reports the running backups and the average duration of the completed ones", func() {
+		firstStop := now.Add(-50 * time.Minute)
+		secondStop := now.Add(-20 * time.Minute)
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status:     apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(
+				cluster,
+				newBackup("running-on-standby", apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseRunning,
+					"cluster-example-2", now.Add(-90*time.Second), nil),
+				newBackup("running-on-primary", apiv1.BackupMethodVolumeSnapshot, apiv1.BackupPhaseFinalizing,
+					"cluster-example-1", now.Add(-30*time.Second), nil),
+				newBackup("completed-1", apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted,
+					"cluster-example-2", firstStop.Add(-10*time.Minute), &firstStop),
+				newBackup("completed-2", apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted,
+					"cluster-example-2", secondStop.Add(-20*time.Minute), &secondStop),
+				newBackup("failed", apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseFailed,
+					"cluster-example-2", now.Add(-time.Hour), nil),
+			).
+			Build()
+
+		collector := newBackupMetricsCollector(fakeClient)
+		collector.now = func() time.Time { return now }
+
+		expected := `
+# HELP cnpg_backups_completed_duration_seconds_average Average duration of the completed backups which still exist
+# TYPE cnpg_backups_completed_duration_seconds_average gauge
+cnpg_backups_completed_duration_seconds_average{cluster="cluster-example",method="barmanObjectStore",namespace="default"} 900
+# HELP cnpg_backups_running Number of backups currently running
+# TYPE cnpg_backups_running gauge
+cnpg_backups_running{cluster="cluster-example",method="barmanObjectStore",namespace="default",target="standby"} 1
+cnpg_backups_running{cluster="cluster-example",method="volumeSnapshot",namespace="default",target="primary"} 1
+# HELP cnpg_backups_running_duration_seconds Time elapsed since the start of the running backup
+# TYPE cnpg_backups_running_duration_seconds gauge
+cnpg_backups_running_duration_seconds{cluster="cluster-example",method="barmanObjectStore",name="running-on-standby",namespace="default",target="standby"} 90
+cnpg_backups_running_duration_seconds{cluster="cluster-example",method="volumeSnapshot",name="running-on-primary",namespace="default",target="primary"} 30
+`
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).To(Succeed())
+	})
+
+	It("reports the outcome of the backups of the scheduled backups", func() {
+		lastSuccess := metav1.NewTime(now.Add(-2 * time.Hour))
+		lastFailure := metav1.NewTime(now.Add(-time.Hour))
+		fakeClient := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(
+				&apiv1.ScheduledBackup{
+					ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+					Spec: apiv1.ScheduledBackupSpec{
+						Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+					},
+					Status: apiv1.ScheduledBackupStatus{
+						ConsecutiveFailures:      2,
+						LastSuccessfulBackupTime: &lastSuccess,
+						LastFailedBackupTime:     &lastFailure,
+					},
+				},
+				&apiv1.ScheduledBackup{
+					ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+					Spec: apiv1.ScheduledBackupSpec{
+						Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+					},
+				},
+			).
+			Build()
+
+		expected := `
+# HELP cnpg_scheduled_backups_consecutive_failures Number of consecutive failed backups of the scheduled backup
+# TYPE cnpg_scheduled_backups_consecutive_failures gauge
+cnpg_scheduled_backups_consecutive_failures{cluster="cluster-example",name="daily",namespace="default"} 2
+cnpg_scheduled_backups_consecutive_failures{cluster="cluster-example",name="hourly",namespace="default"} 0
+# HELP cnpg_scheduled_backups_last_failure_timestamp_seconds Time when the last failed backup of the scheduled backup stopped
+# TYPE cnpg_scheduled_backups_last_failure_timestamp_seconds gauge
+cnpg_scheduled_backups_last_failure_timestamp_seconds{cluster="cluster-example",name="daily",namespace="default"} 1.7357292e+09
+# HELP cnpg_scheduled_backups_last_success_timestamp_seconds Time when the last successful backup of the scheduled backup stopped
+# TYPE cnpg_scheduled_backups_last_success_timestamp_seconds gauge
+cnpg_scheduled_backups_last_success_timestamp_seconds{cluster="cluster-example",name="daily",namespace="default"} 1.7357256e+09
+`
+		Expect(testutil.CollectAndCompare(newBackupMetricsCollector(fakeClient), strings.NewReader(expected))).
+			To(Succeed())
+	})
+
+	It("doesn't report anything without backups", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+		Expect(testutil.CollectAndCount(newBackupMetricsCollector(fakeClient))).To(BeZero())
+	})
+})
@@ -31,6 +31,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +45,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -91,6 +93,10 @@ type ClusterReconciler struct {
 	InstanceClient  remote.InstanceClient
 	Plugins         repository.Interface
 
+	// ResyncInterval is the interval after which a cluster is reconciled
+	// again when nothing changed. Zero disables the periodic resync
+	ResyncInterval time.Duration
+
 	drainTaints    []string
 	rolloutManager *rolloutManager.Manager
 }
@@ -126,6 +132,8 @@ var ErrNextLoop = utils.ErrNextLoop
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;delete;patch;create;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;list;watch;delete;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;list;watch;delete;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;delete;get;list;watch;update;patch
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;watch;update;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters/finalizers,verbs=update
@@ -143,6 +151,7 @@ var ErrNextLoop = utils.ErrNextLoop
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=create;patch;update;list;watch;get
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;create;delete;update;patch;list;watch
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;watch;list;patch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs,verbs=get;watch;list
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs,verbs=get;watch;list
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=failoverquorums,verbs=create;get;watch;delete;list
@@ -163,6 +172,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	if cluster == nil {
+		primaryChanges.forget(req.NamespacedName)
 		if err := r.deleteDanglingMonitoringQueries(ctx, req.Namespace); err != nil {
 			contextLogger.Error(
 				err,
@@ -231,7 +241,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Run the inner reconcile loop. Translate any ErrNextLoop to an errorless return
 	result, err := r.reconcile(ctx, cluster)
 	if errors.Is(err, ErrNextLoop) {
-		return result, nil
+		return requeueForPendingPromotion(cluster, result), nil
 	}
 	if errors.Is(err, utils.ErrTerminateLoop) {
 		return ctrl.Result{}, nil
@@ -254,7 +264,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return result, nil
+	return requeueForResync(
+		requeueForPendingPromotion(cluster, requeueAtNextMaintenanceWindow(cluster, result)),
+		r.ResyncInterval,
+	), nil
 }
 
 // Inner reconcile loop. Anything inside can require the reconciliation loop to stop by returning ErrNextLoop
@@ -353,6 +366,7 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 			"in progress, waiting for the operation to complete",
 			"currentPrimary", cluster.Status.CurrentPrimary,
 			"targetPrimary", cluster.Status.TargetPrimary)
+		primaryChanges.observe(cluster)
 
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 	}
@@ -496,7 +510,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		hasHTTPStatus := mostAdvancedInstance.HasHTTPStatus()
 		isPodReady := mostAdvancedInstance.IsPodReady
 
-		if hasHTTPStatus && !isPodReady {
+		// A primary kept not ready by the custom readiness query of the user
+		// won't become ready by waiting, and must still be reconciled
+		if hasHTTPStatus && !isPodReady && !mostAdvancedInstance.IsReadinessQueryFailing {
 			// The readiness probe status from the Kubelet is not updated, so
 			// we need to wait for it to be refreshed
 			contextLogger.Info(
@@ -662,6 +678,10 @@ func (r *ClusterReconciler) handleSwitchover(
 			contextLogger.Info("Waiting for the failover delay to expire")
 			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		}
+		if errors.Is(err, ErrWaitingOnFailoverCooldown) {
+			contextLogger.Info("Waiting for the failover cooldown to expire")
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
 		if errors.Is(err, ErrWalReceiversRunning) {
 			contextLogger.Info("Waiting for all WAL receivers to be down to elect a new primary")
 			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
@@ -687,6 +707,10 @@ func (r *ClusterReconciler) handleSwitchover(
 		}
 	}
 
+	if err := r.resetFailoverCooldownCondition(ctx, cluster); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -749,6 +773,11 @@ func (r *ClusterReconciler) reconcileResources(
 		return *result, nil
 	}
 
+	if err := r.reconcileInstancesSchedulableCondition(ctx, cluster, resources); err != nil {
+		contextLogger.Error(err, "While reporting the unschedulable instances")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
 	if result, err := r.processUnschedulableInstances(ctx, cluster, resources); err != nil {
 		contextLogger.Error(err, "While processing unschedulable instances")
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
@@ -756,6 +785,13 @@ func (r *ClusterReconciler) reconcileResources(
 		return *result, err
 	}
 
+	if result, err := r.reconcileLostStorageInstances(ctx, cluster, resources); err != nil {
+		contextLogger.Error(err, "While processing instances whose storage is lost")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	} else if result != nil {
+		return *result, nil
+	}
+
 	if !resources.allInstancesAreActive() {
 		contextLogger = contextLogger.WithValues(
 			"inactiveInstances", resources.inactiveInstanceNames())
@@ -1066,6 +1102,11 @@ func (r *ClusterReconciler) reconcilePods(
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 	}
 
+	// Has an instance been requested to be promoted?
+	if res, err := r.reconcilePromotionRequests(ctx, cluster, instancesStatus); !res.IsZero() || err != nil {
+		return res, err
+	}
+
 	return r.handleRollingUpdate(ctx, cluster, instancesStatus)
 }
 
@@ -1173,7 +1214,11 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := metrics.Registry.Register(primaryChanges.total); err != nil {
+		return err
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 		}).
@@ -1184,6 +1229,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.mapConfigMapsToClusters()),
@@ -1199,21 +1245,35 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			handler.EnqueueRequestsFromMapFunc(r.mapPoolersToClusters()),
 		).
 		Watches(
-			&corev1.Node{},
-			handler.EnqueueRequestsFromMapFunc(r.mapNodeToClusters()),
-			builder.WithPredicates(r.nodesPredicate()),
+			&apiv1.Database{},
+			handler.EnqueueRequestsFromMapFunc(r.mapDatabasesToClusters()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
 		Watches(
 			&apiv1.ImageCatalog{},
 			handler.EnqueueRequestsFromMapFunc(r.mapImageCatalogsToClusters()),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
-		).
-		Watches(
+		)
+
+	// The cluster-scoped resources can only be watched when the operator
+	// has the permission to do it, otherwise their informers would never
+	// be synchronized and the controller wouldn't start
+	if utils.HaveClusterScopedPermission(utils.NodesPermission) {
+		controllerBuilder = controllerBuilder.Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNodeToClusters()),
+			builder.WithPredicates(r.nodesPredicate()),
+		)
+	}
+	if utils.HaveClusterScopedPermission(utils.ClusterImageCatalogsPermission) {
+		controllerBuilder = controllerBuilder.Watches(
 			&apiv1.ClusterImageCatalog{},
 			handler.EnqueueRequestsFromMapFunc(r.mapClusterImageCatalogsToClusters()),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
-		).
-		Complete(r)
+		)
+	}
+
+	return controllerBuilder.Complete(r)
 }
 
 // jobOwnerIndexFunc maps a job definition to its owning cluster and
@@ -1429,6 +1489,23 @@ func (r *ClusterReconciler) mapPoolersToClusters() handler.MapFunc {
 	}
 }
 
+// mapDatabasesToClusters returns a function mapping database events watched to cluster reconcile requests.
+// This is needed to grant the instance manager access to the secrets referenced by the databases.
+func (r *ClusterReconciler) mapDatabasesToClusters() handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		database, ok := obj.(*apiv1.Database)
+		if !ok || database.Spec.ClusterRef.Name == "" {
+			return nil
+		}
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{
+				Namespace: database.Namespace,
+				Name:      database.Spec.ClusterRef.Name,
+			},
+		}}
+	}
+}
+
 // mapNodeToClusters returns a function mapping cluster events watched to cluster reconcile requests
 func (r *ClusterReconciler) mapConfigMapsToClusters() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -48,6 +48,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
@@ -75,6 +76,11 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = r.reconcileNetworkPolicy(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = r.createOrPatchServiceAccount(ctx, cluster)
 	if err != nil {
 		return err
@@ -102,6 +108,12 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = createOrPatchPrometheusRule(ctx, r.Client, r.DiscoveryClient,
+		specs.NewClusterPrometheusRuleManager(cluster))
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileFailoverQuorumObject(ctx, cluster)
 	if err != nil {
 		return err
@@ -186,7 +198,8 @@ func (r *ClusterReconciler) reconcileSuperuserSecret(ctx context.Context, cluste
 			"*",
 			"postgres",
 			postgresPassword,
-			utils.UserTypeSuperuser)
+			utils.UserTypeSuperuser,
+			nil)
 		cluster.SetInheritedDataAndOwnership(&postgresSecret.ObjectMeta)
 
 		return createOrPatchClusterCredentialSecret(ctx, r.Client, postgresSecret)
@@ -216,7 +229,7 @@ func (r *ClusterReconciler) reconcileSuperuserSecret(ctx context.Context, cluste
 
 func (r *ClusterReconciler) reconcileAppUserSecret(ctx context.Context, cluster *apiv1.Cluster) error {
 	if cluster.ShouldCreateApplicationSecret() {
-		appPassword, err := password.Generate(64, 10, 0, false, true)
+		appPassword, err := r.getApplicationSecretPassword(ctx, cluster)
 		if err != nil {
 			return err
 		}
@@ -227,7 +240,8 @@ func (r *ClusterReconciler) reconcileAppUserSecret(ctx context.Context, cluster
 			cluster.GetApplicationDatabaseName(),
 			cluster.GetApplicationDatabaseOwner(),
 			appPassword,
-			utils.UserTypeApp)
+			utils.UserTypeApp,
+			cluster.Spec.ExternalConnection)
 
 		cluster.SetInheritedDataAndOwnership(&appSecret.ObjectMeta)
 		return createOrPatchClusterCredentialSecret(ctx, r.Client, appSecret)
@@ -235,6 +249,23 @@ func (r *ClusterReconciler) reconcileAppUserSecret(ctx context.Context, cluster
 	return nil
 }
 
+// getApplicationSecretPassword returns the password stored in the existing
+// application secret, so that its connection information can be refreshed
+// without changing it, or a newly generated one
+func (r *ClusterReconciler) getApplicationSecretPassword(ctx context.Context, cluster *apiv1.Cluster) (string, error) {
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetApplicationSecretName()}, &secret)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return "", err
+	}
+
+	if currentPassword := secret.Data["password"]; len(currentPassword) > 0 {
+		return string(currentPassword), nil
+	}
+
+	return password.Generate(64, 10, 0, false, true)
+}
+
 func createOrPatchClusterCredentialSecret(
 	ctx context.Context,
 	cli client.Client,
@@ -258,9 +289,26 @@ func createOrPatchClusterCredentialSecret(
 	patchedSecret := currentSecret.DeepCopy()
 	utils.MergeObjectsMetadata(patchedSecret, proposed)
 
-	// we cannot compare the data due to the password being randomly generated everytime
+	// we cannot compare the whole data due to the password being randomly
+	// generated everytime, but the connection information is refreshed
+	// when the proposed secret has been built with the current password
+	if proposedPassword, ok := proposed.StringData["password"]; ok &&
+		proposedPassword == string(currentSecret.Data["password"]) {
+		for _, key := range specs.SecretConnectionInfoKeys {
+			value, ok := proposed.StringData[key]
+			if !ok || string(currentSecret.Data[key]) == value {
+				continue
+			}
+			if patchedSecret.Data == nil {
+				patchedSecret.Data = make(map[string][]byte)
+			}
+			patchedSecret.Data[key] = []byte(value)
+		}
+	}
+
 	if reflect.DeepEqual(patchedSecret.Labels, currentSecret.Labels) &&
-		reflect.DeepEqual(patchedSecret.Annotations, currentSecret.Annotations) {
+		reflect.DeepEqual(patchedSecret.Annotations, currentSecret.Annotations) &&
+		reflect.DeepEqual(patchedSecret.Data, currentSecret.Data) {
 		return nil
 	}
 
@@ -314,6 +362,7 @@ func (r *ClusterReconciler) reconcilePostgresServices(ctx context.Context, clust
 
 	readService := specs.CreateClusterReadService(*cluster)
 	cluster.SetInheritedDataAndOwnership(&readService.ObjectMeta)
+	specs.ApplyDefaultServiceTemplate(*cluster, apiv1.ServiceSelectorTypeR, readService)
 
 	if err := r.serviceReconciler(ctx, cluster, readService, cluster.IsReadServiceEnabled()); err != nil {
 		return err
@@ -321,6 +370,7 @@ func (r *ClusterReconciler) reconcilePostgresServices(ctx context.Context, clust
 
 	readOnlyService := specs.CreateClusterReadOnlyService(*cluster)
 	cluster.SetInheritedDataAndOwnership(&readOnlyService.ObjectMeta)
+	specs.ApplyDefaultServiceTemplate(*cluster, apiv1.ServiceSelectorTypeRO, readOnlyService)
 
 	if err := r.serviceReconciler(ctx, cluster, readOnlyService, cluster.IsReadOnlyServiceEnabled()); err != nil {
 		return err
@@ -328,6 +378,7 @@ func (r *ClusterReconciler) reconcilePostgresServices(ctx context.Context, clust
 
 	readWriteService := specs.CreateClusterReadWriteService(*cluster)
 	cluster.SetInheritedDataAndOwnership(&readWriteService.ObjectMeta)
+	specs.ApplyDefaultServiceTemplate(*cluster, apiv1.ServiceSelectorTypeRW, readWriteService)
 
 	if err := r.serviceReconciler(ctx, cluster, readWriteService, cluster.IsReadWriteServiceEnabled()); err != nil {
 		return err
@@ -428,6 +479,13 @@ func (r *ClusterReconciler) serviceReconciler(
 		shouldUpdate = true
 	}
 
+	// we ensure that the service type matches, as switching between
+	// types keeps the cluster IP and thus the existing endpoints
+	if proposed.Spec.Type != "" && proposed.Spec.Type != livingService.Spec.Type {
+		setServiceType(&livingService, proposed.Spec.Type)
+		shouldUpdate = true
+	}
+
 	// we ensure we've some space to store the labels and the annotations
 	if livingService.Labels == nil {
 		livingService.Labels = make(map[string]string)
@@ -465,6 +523,26 @@ func (r *ClusterReconciler) serviceReconciler(
 	return ErrNextLoop
 }
 
+// setServiceType changes the type of a service, clearing the fields
+// that the Kubernetes API server only accepts for external services
+func setServiceType(service *corev1.Service, serviceType corev1.ServiceType) {
+	service.Spec.Type = serviceType
+
+	if serviceType != corev1.ServiceTypeLoadBalancer {
+		service.Spec.AllocateLoadBalancerNodePorts = nil
+		service.Spec.LoadBalancerClass = nil
+		service.Spec.LoadBalancerSourceRanges = nil
+		service.Spec.HealthCheckNodePort = 0
+	}
+
+	if serviceType == corev1.ServiceTypeClusterIP {
+		service.Spec.ExternalTrafficPolicy = ""
+		for idx := range service.Spec.Ports {
+			service.Spec.Ports[idx].NodePort = 0
+		}
+	}
+}
+
 // createOrPatchOwnedPodDisruptionBudget ensures that we have a PDB requiring to remove one node at a time
 func (r *ClusterReconciler) createOrPatchOwnedPodDisruptionBudget(
 	ctx context.Context,
@@ -712,6 +790,11 @@ func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv
 		return err
 	}
 
+	var databases apiv1.DatabaseList
+	if err := r.List(ctx, &databases, client.InNamespace(cluster.Namespace)); err != nil {
+		return fmt.Errorf("while listing databases: %w", err)
+	}
+
 	var role rbacv1.Role
 	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}, &role); err != nil {
 		if !apierrs.IsNotFound(err) {
@@ -719,10 +802,10 @@ func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv
 		}
 
 		r.Recorder.Event(cluster, "Normal", "CreatingRole", "Creating Cluster Role")
-		return r.createRole(ctx, cluster, originBackup)
+		return r.createRole(ctx, cluster, originBackup, databases.Items)
 	}
 
-	generatedRole := specs.CreateRole(*cluster, originBackup)
+	generatedRole := specs.CreateRole(*cluster, originBackup, databases.Items)
 	if equality.Semantic.DeepEqual(generatedRole.Rules, role.Rules) {
 		// Everything fine, the two rules have the same content
 		return nil
@@ -1011,9 +1094,88 @@ func createOrPatchPodMonitor(
 	}
 }
 
+type prometheusRuleManager interface {
+	// IsPrometheusRuleEnabled returns a boolean indicating if the PrometheusRule should exists or not
+	IsPrometheusRuleEnabled() bool
+	// BuildPrometheusRule builds a new PrometheusRule object
+	BuildPrometheusRule() *monitoringv1.PrometheusRule
+}
+
+// createOrPatchPrometheusRule creates, updates or deletes the PrometheusRule
+// containing the default alerts of the cluster
+func createOrPatchPrometheusRule(
+	ctx context.Context,
+	cli client.Client,
+	discoveryClient discovery.DiscoveryInterface,
+	manager prometheusRuleManager,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	// Checking for the PrometheusRule Custom Resource Definition in the Kubernetes cluster
+	havePrometheusRuleCRD, err := utils.PrometheusRuleExist(discoveryClient)
+	if err != nil {
+		return err
+	}
+
+	if !havePrometheusRuleCRD {
+		if manager.IsPrometheusRuleEnabled() {
+			// If the PrometheusRule CRD does not exist, but the cluster has the default alerts enabled,
+			// the controller cannot do anything until the CRD is installed
+			contextLogger.Warning("PrometheusRule CRD not present. Cannot create the PrometheusRule object")
+		}
+		return nil
+	}
+
+	expectedPrometheusRule := manager.BuildPrometheusRule()
+	prometheusRule := &monitoringv1.PrometheusRule{}
+	if err := cli.Get(
+		ctx,
+		client.ObjectKeyFromObject(expectedPrometheusRule),
+		prometheusRule,
+	); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while getting the prometheusrule: %w", err)
+		}
+		prometheusRule = nil
+	}
+
+	switch {
+	case !manager.IsPrometheusRuleEnabled() && prometheusRule == nil:
+		return nil
+	case !manager.IsPrometheusRuleEnabled() && prometheusRule != nil:
+		contextLogger.Info("Deleting PrometheusRule")
+		if err := cli.Delete(ctx, prometheusRule); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	case manager.IsPrometheusRuleEnabled() && prometheusRule == nil:
+		contextLogger.Debug("Creating PrometheusRule")
+		return cli.Create(ctx, expectedPrometheusRule)
+	default:
+		origPrometheusRule := prometheusRule.DeepCopy()
+		prometheusRule.Spec = expectedPrometheusRule.Spec
+		// We don't override the current labels/annotations given that there could be data that isn't managed by us
+		utils.MergeObjectsMetadata(prometheusRule, expectedPrometheusRule)
+
+		if reflect.DeepEqual(origPrometheusRule, prometheusRule) {
+			return nil
+		}
+
+		contextLogger.Debug("Patching PrometheusRule")
+		return cli.Patch(ctx, prometheusRule, client.MergeFrom(origPrometheusRule))
+	}
+}
+
 // createRole creates the role
-func (r *ClusterReconciler) createRole(ctx context.Context, cluster *apiv1.Cluster, backupOrigin *apiv1.Backup) error {
-	role := specs.CreateRole(*cluster, backupOrigin)
+func (r *ClusterReconciler) createRole(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backupOrigin *apiv1.Backup,
+	databases []apiv1.Database,
+) error {
+	role := specs.CreateRole(*cluster, backupOrigin, databases)
 	cluster.SetInheritedDataAndOwnership(&role.ObjectMeta)
 
 	err := r.Create(ctx, &role)
@@ -1174,6 +1336,17 @@ func (r *ClusterReconciler) createPrimaryInstance(
 		return ctrl.Result{}, err
 	}
 
+	if isBootstrappingFromRecovery {
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetStatisticsOutdated("The statistics need to be collected after the recovery"),
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	contextLogger.Info("Creating new Job",
 		"jobName", job.Name,
 		"primary", true)
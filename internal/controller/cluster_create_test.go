@@ -819,6 +819,87 @@ var _ = Describe("CreateOrPatchPodMonitor", func() {
 	})
 })
 
+type mockPrometheusRuleManager struct {
+	isEnabled      bool
+	prometheusRule *monitoringv1.PrometheusRule
+}
+
+func (m *mockPrometheusRuleManager) IsPrometheusRuleEnabled() bool {
+	return m.isEnabled
+}
+
+func (m *mockPrometheusRuleManager) BuildPrometheusRule() *monitoringv1.PrometheusRule {
+	return m.prometheusRule.DeepCopy()
+}
+
+var _ = Describe("createOrPatchPrometheusRule", func() {
+	var (
+		fakeCli             k8client.Client
+		fakeDiscoveryClient discovery.DiscoveryInterface
+		manager             *mockPrometheusRuleManager
+		key                 types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		manager = &mockPrometheusRuleManager{
+			isEnabled: true,
+			prometheusRule: &monitoringv1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: monitoringv1.PrometheusRuleSpec{
+					Groups: []monitoringv1.RuleGroup{{Name: "test.rules"}},
+				},
+			},
+		}
+		key = types.NamespacedName{Name: "test", Namespace: "default"}
+
+		fakeCli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+
+		fakeDiscoveryClient = &fakediscovery.FakeDiscovery{
+			Fake: &testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "monitoring.coreos.com/v1",
+						APIResources: []metav1.APIResource{
+							{
+								Name:       "prometheusrules",
+								Kind:       "PrometheusRule",
+								Namespaced: true,
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("creates, updates and deletes the PrometheusRule", func(ctx SpecContext) {
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+		prometheusRule := &monitoringv1.PrometheusRule{}
+		Expect(fakeCli.Get(ctx, key, prometheusRule)).To(Succeed())
+		Expect(prometheusRule.Spec.Groups[0].Name).To(Equal("test.rules"))
+
+		manager.prometheusRule.Spec.Groups[0].Name = "changed.rules"
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+		Expect(fakeCli.Get(ctx, key, prometheusRule)).To(Succeed())
+		Expect(prometheusRule.Spec.Groups[0].Name).To(Equal("changed.rules"))
+
+		manager.isEnabled = false
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+		err := fakeCli.Get(ctx, key, prometheusRule)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("does nothing when the PrometheusRule CRD is not installed", func(ctx SpecContext) {
+		fakeDiscoveryClient = &fakediscovery.FakeDiscovery{Fake: &testing.Fake{}}
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+		err := fakeCli.Get(ctx, key, &monitoringv1.PrometheusRule{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+})
+
 var _ = Describe("createOrPatchClusterCredentialSecret", func() {
 	const (
 		secretName = "test-secret"
@@ -911,6 +992,28 @@ var _ = Describe("createOrPatchClusterCredentialSecret", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(originalSecret.Generation).To(Equal(originalSecret.Generation))
 		})
+
+		It("should refresh the connection information only when the password is unchanged",
+			func(ctx SpecContext) {
+				var currentSecret corev1.Secret
+				key := types.NamespacedName{Name: secretName, Namespace: namespace}
+				Expect(cli.Get(ctx, key, &currentSecret)).To(Succeed())
+				currentSecret.Data = map[string][]byte{"password": []byte("current"), "host": []byte("cluster-rw")}
+				Expect(cli.Update(ctx, &currentSecret)).To(Succeed())
+
+				proposed.Labels = map[string]string{"old": "label"}
+				proposed.Annotations = map[string]string{"old": "annotation"}
+				proposed.StringData = map[string]string{"password": "generated", "host": "db.example.com"}
+				Expect(createOrPatchClusterCredentialSecret(ctx, cli, proposed)).To(Succeed())
+				Expect(cli.Get(ctx, key, &currentSecret)).To(Succeed())
+				Expect(currentSecret.Data).To(HaveKeyWithValue("host", []byte("cluster-rw")))
+
+				proposed.StringData["password"] = "current"
+				Expect(createOrPatchClusterCredentialSecret(ctx, cli, proposed)).To(Succeed())
+				Expect(cli.Get(ctx, key, &currentSecret)).To(Succeed())
+				Expect(currentSecret.Data).To(HaveKeyWithValue("host", []byte("db.example.com")))
+				Expect(currentSecret.Data).To(HaveKeyWithValue("password", []byte("current")))
+			})
 	})
 
 	Context("when the secret exists but is not owned by the cluster", func() {
@@ -1288,10 +1391,66 @@ var _ = Describe("Service Reconciling", func() {
 				Expect(updatedService.Labels).To(HaveKeyWithValue("custom-label", "value"))
 				Expect(updatedService.Annotations).To(HaveKeyWithValue("custom-annotation", "value"))
 			})
+
+			It("should switch the type of the service back to ClusterIP", func() {
+				existingService := proposedService.DeepCopy()
+				existingService.Spec.Type = corev1.ServiceTypeLoadBalancer
+				existingService.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+				existingService.Spec.Ports[0].NodePort = 30432
+				err := serviceClient.Update(ctx, existingService)
+				Expect(err).NotTo(HaveOccurred())
+
+				proposedService.Spec.Type = corev1.ServiceTypeClusterIP
+				err = reconciler.serviceReconciler(ctx, &cluster, proposedService, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedService corev1.Service
+				err = serviceClient.Get(ctx, types.NamespacedName{
+					Name:      proposedService.Name,
+					Namespace: proposedService.Namespace,
+				}, &updatedService)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedService.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+				Expect(updatedService.Spec.ExternalTrafficPolicy).To(BeEmpty())
+				Expect(updatedService.Spec.Ports[0].NodePort).To(BeZero())
+			})
 		})
 	})
 
 	Describe("reconcilePostgresServices", func() {
+		It("should apply the customization of the default services", func() {
+			cluster.Spec.Managed.Services.DefaultServices = []apiv1.DefaultServiceTemplate{
+				{
+					SelectorType: apiv1.ServiceSelectorTypeRW,
+					Type:         corev1.ServiceTypeLoadBalancer,
+					ObjectMeta: apiv1.Metadata{
+						Annotations: map[string]string{"test-annotation": "true"},
+					},
+				},
+			}
+			err := reconciler.reconcilePostgresServices(ctx, &cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			var rwService corev1.Service
+			err = reconciler.Get(
+				ctx,
+				types.NamespacedName{Name: cluster.GetServiceReadWriteName(), Namespace: cluster.Namespace},
+				&rwService,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rwService.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(rwService.Annotations).To(HaveKeyWithValue("test-annotation", "true"))
+
+			var roService corev1.Service
+			err = reconciler.Get(
+				ctx,
+				types.NamespacedName{Name: cluster.GetServiceReadOnlyName(), Namespace: cluster.Namespace},
+				&roService,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(roService.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+		})
+
 		It("should create the default services", func() {
 			err := reconciler.reconcilePostgresServices(ctx, &cluster)
 			Expect(err).NotTo(HaveOccurred())
@@ -22,6 +22,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -36,7 +37,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // reconcileImage processes the image request, executes it, and stores
@@ -67,8 +70,18 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 	// an image of the same major version or if a change in the major
 	// version has been requested.
 	if requestedImageInfo.Image == cluster.Status.PGDataImageInfo.Image {
-		// The requested image is the same as the current one, no action needed
-		return nil, nil
+		// The requested image is the same as the current one. The image used
+		// by the instances may still be different if the user rolled back a
+		// failed major version upgrade, or the upgrade was only a dry-run
+		if cluster.Status.Image == requestedImageInfo.Image {
+			return nil, nil
+		}
+		return nil, status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetImage(requestedImageInfo.Image),
+		)
 	}
 
 	currentMajorVersion := cluster.Status.PGDataImageInfo.MajorVersion
@@ -85,7 +98,20 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 	}
 
 	if currentMajorVersion < requestedMajorVersion {
-		// Major version upgrade requested
+		// Major version upgrade requested. After a dry-run, the instances
+		// keep using the current image until the user confirms the upgrade
+		if cluster.IsMajorUpgradeOnHold() {
+			if cluster.Status.Image == cluster.Status.PGDataImageInfo.Image {
+				return nil, nil
+			}
+			return nil, status.PatchWithOptimisticLock(
+				ctx,
+				r.Client,
+				cluster,
+				status.SetImage(cluster.Status.PGDataImageInfo.Image),
+			)
+		}
+
 		return nil, status.PatchWithOptimisticLock(
 			ctx,
 			r.Client,
@@ -96,6 +122,30 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 
 	// The major versions are the same, but the images are different.
 	// This is a minor version upgrade/downgrade.
+	if cluster.Spec.ImageCatalogRef != nil {
+		if autoUpdate := cluster.GetImageCatalogAutoUpdate(); autoUpdate != nil {
+			inWindow, nextWindow, err := getMaintenanceWindowStatus(autoUpdate, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if !inWindow {
+				contextLogger.Info(
+					"Postponing the image update until the next maintenance window",
+					"currentImage", cluster.Status.PGDataImageInfo.Image,
+					"requestedImage", requestedImageInfo.Image,
+					"nextMaintenanceWindow", nextWindow)
+				return nil, nil
+			}
+		}
+
+		r.Recorder.Eventf(cluster, "Normal", "UpdateImage",
+			"Updating the image from %s to %s, as published in %s/%s",
+			cluster.Status.PGDataImageInfo.Image,
+			requestedImageInfo.Image,
+			cluster.Spec.ImageCatalogRef.Kind,
+			cluster.Spec.ImageCatalogRef.Name)
+	}
+
 	return nil, status.PatchWithOptimisticLock(
 		ctx,
 		r.Client,
@@ -104,6 +154,38 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 		status.SetPGDataImageInfo(&requestedImageInfo))
 }
 
+// getMaintenanceWindowStatus checks whether the passed time is inside one of
+// the maintenance windows in which the new images of the catalog are adopted,
+// returning the start of the next window otherwise
+func getMaintenanceWindowStatus(
+	autoUpdate *apiv1.ImageCatalogAutoUpdate,
+	now time.Time,
+) (bool, time.Time, error) {
+	return utils.GetMaintenanceWindowStatus(autoUpdate.Schedule, autoUpdate.GetDuration(), now)
+}
+
+// requeueAtNextMaintenanceWindow ensures the cluster is reconciled at the
+// start of the next maintenance window, to adopt the image updates that have
+// been postponed
+func requeueAtNextMaintenanceWindow(cluster *apiv1.Cluster, result ctrl.Result) ctrl.Result {
+	// A non-zero result without a delay is already requeued immediately
+	autoUpdate := cluster.GetImageCatalogAutoUpdate()
+	if autoUpdate == nil || (!result.IsZero() && result.RequeueAfter == 0) {
+		return result
+	}
+
+	inWindow, nextWindow, err := getMaintenanceWindowStatus(autoUpdate, time.Now())
+	if err != nil || inWindow {
+		return result
+	}
+
+	if requeueAfter := time.Until(nextWindow); result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+
+	return result
+}
+
 func getImageInfoFromImage(image string) (apiv1.ImageInfo, error) {
 	// Parse the version from the tag
 	imageVersion, err := version.FromTag(reference.New(image).Tag)
@@ -127,7 +209,16 @@ func (r *ClusterReconciler) getRequestedImageInfo(
 			return getImageInfoFromImage(cluster.Spec.ImageName)
 		}
 
-		return apiv1.ImageInfo{}, fmt.Errorf("ImageName is not defined and no catalog is referenced")
+		// The image name has not been defaulted: we keep the image the
+		// cluster is running, so that a change of the default image
+		// doesn't trigger an update, or the default one for new clusters
+		if !configuration.Current.IsDefaultDisabled(configuration.ImageNameDefault) {
+			return apiv1.ImageInfo{}, fmt.Errorf("ImageName is not defined and no catalog is referenced")
+		}
+		if cluster.Status.Image != "" {
+			return getImageInfoFromImage(cluster.Status.Image)
+		}
+		return getImageInfoFromImage(configuration.Current.PostgresImageName)
 	}
 
 	contextLogger = contextLogger.WithValues("catalogRef", cluster.Spec.ImageCatalogRef)
@@ -137,6 +228,14 @@ func (r *ClusterReconciler) getRequestedImageInfo(
 	var catalog apiv1.GenericImageCatalog
 	switch catalogKind {
 	case apiv1.ClusterImageCatalogKind:
+		// Reading a cluster-scoped catalog without the permission to do
+		// it would block waiting for the informer to be synchronized
+		if !utils.HaveClusterScopedPermission(utils.ClusterImageCatalogsPermission) {
+			r.Recorder.Eventf(cluster, "Warning", "DiscoverImage",
+				"The operator lacks the permission to read %v/%v", catalogKind, cluster.Spec.ImageCatalogRef.Name)
+			return apiv1.ImageInfo{}, fmt.Errorf(
+				"the operator lacks the permission to read ClusterImageCatalogs, use an ImageCatalog instead")
+		}
 		catalog = &apiv1.ClusterImageCatalog{}
 	case apiv1.ImageCatalogKind:
 		catalog = &apiv1.ImageCatalog{}
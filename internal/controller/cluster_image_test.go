@@ -21,14 +21,18 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -77,6 +81,35 @@ var _ = Describe("Cluster image detection", func() {
 		Expect(cluster.Status.PGDataImageInfo.MajorVersion).To(Equal(15))
 	})
 
+	It("keeps the running image when the image name is not defaulted", func(ctx SpecContext) {
+		configuration.Current.DisabledDefaults = []string{configuration.ImageNameDefault}
+		DeferCleanup(func() {
+			configuration.Current.DisabledDefaults = nil
+		})
+
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+		}
+		r := newFakeReconcilerFor(cluster, nil)
+
+		// A new cluster uses the default image
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal(configuration.Current.PostgresImageName))
+
+		// A change of the default image doesn't affect the existing clusters
+		cluster.Status.Image = "postgres:15.2"
+		cluster.Status.PGDataImageInfo = &apiv1.ImageInfo{Image: "postgres:15.2", MajorVersion: 15}
+		result, err = r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:15.2"))
+	})
+
 	It("gets the image from an image catalog", func(ctx SpecContext) {
 		// This is slightly more complex, having an image catalog reference
 		// instead of an explicit image name. No major version upgrade have
@@ -222,4 +255,221 @@ var _ = Describe("Cluster image detection", func() {
 		Expect(cluster.Status.PGDataImageInfo.Image).To(Equal("postgres:16.2"))
 		Expect(cluster.Status.PGDataImageInfo.MajorVersion).To(Equal(16))
 	})
+
+	It("restores the running image when a failed major version upgrade is rolled back", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.2",
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17.2",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:16.2",
+					MajorVersion: 16,
+				},
+			},
+		}
+
+		r := newFakeReconcilerFor(cluster, nil)
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+
+		Expect(cluster.Status.Image).To(Equal("postgres:16.2"))
+		Expect(cluster.Status.PGDataImageInfo.Image).To(Equal("postgres:16.2"))
+	})
+
+	It("keeps the running image once the major version upgrade has been checked", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cluster-example",
+				Namespace:  "default",
+				Generation: 3,
+				Annotations: map[string]string{
+					utils.MajorUpgradeDryRunAnnotationName: "enabled",
+				},
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17.2",
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17.2",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:16.2",
+					MajorVersion: 16,
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(apiv1.ConditionMajorUpgrade),
+						Status:             metav1.ConditionFalse,
+						Reason:             string(apiv1.ConditionReasonMajorUpgradeCheckSucceeded),
+						ObservedGeneration: 3,
+					},
+				},
+			},
+		}
+
+		r := newFakeReconcilerFor(cluster, nil)
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:16.2"))
+
+		// Removing the annotation starts the upgrade
+		cluster.Annotations = nil
+		result, err = r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:17.2"))
+	})
+})
+
+var _ = Describe("Cluster image updates from the catalog", func() {
+	newCluster := func(autoUpdate *apiv1.ImageCatalogAutoUpdate) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageCatalogRef: &apiv1.ImageCatalogRef{
+					TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+						APIGroup: &apiv1.SchemeGroupVersion.Group,
+						Name:     "catalog",
+						Kind:     "ImageCatalog",
+					},
+					Major:      17,
+					AutoUpdate: autoUpdate,
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17.4",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:17.4",
+					MajorVersion: 17,
+				},
+			},
+		}
+	}
+
+	catalog := &apiv1.ImageCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "catalog",
+			Namespace: "default",
+		},
+		Spec: apiv1.ImageCatalogSpec{
+			Images: []apiv1.CatalogImage{
+				{
+					Image: "postgres:17.5",
+					Major: 17,
+				},
+			},
+		},
+	}
+
+	It("adopts a new minor version immediately by default, recording an event", func(ctx SpecContext) {
+		cluster := newCluster(nil)
+		r := newFakeReconcilerFor(cluster, catalog.DeepCopy())
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:17.5"))
+		Expect(cluster.Status.PGDataImageInfo.Image).To(Equal("postgres:17.5"))
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("UpdateImage")))
+	})
+
+	It("adopts a new minor version during the maintenance window", func(ctx SpecContext) {
+		cluster := newCluster(&apiv1.ImageCatalogAutoUpdate{Schedule: "* * * * * *"})
+		r := newFakeReconcilerFor(cluster, catalog.DeepCopy())
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:17.5"))
+	})
+
+	It("postpones a new minor version outside the maintenance window", func(ctx SpecContext) {
+		cluster := newCluster(&apiv1.ImageCatalogAutoUpdate{
+			// Once a year, for one second
+			Schedule: "0 0 0 1 1 *",
+			Duration: &metav1.Duration{Duration: time.Second},
+		})
+		r := newFakeReconcilerFor(cluster, catalog.DeepCopy())
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:17.4"))
+		Expect(cluster.Status.PGDataImageInfo.Image).To(Equal("postgres:17.4"))
+	})
+})
+
+var _ = Describe("Maintenance windows", func() {
+	// Every day at 2am, for two hours by default
+	autoUpdate := &apiv1.ImageCatalogAutoUpdate{
+		Schedule: "0 0 2 * * *",
+		Duration: &metav1.Duration{Duration: 2 * time.Hour},
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.March, 10, hour, minute, 0, 0, time.Local)
+	}
+
+	It("detects when the time is inside a window", func() {
+		inWindow, windowStart, err := getMaintenanceWindowStatus(autoUpdate, at(3, 30))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeTrue())
+		Expect(windowStart).To(Equal(at(2, 0)))
+	})
+
+	It("returns the start of the next window otherwise", func() {
+		inWindow, windowStart, err := getMaintenanceWindowStatus(autoUpdate, at(1, 0))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeFalse())
+		Expect(windowStart).To(Equal(at(2, 0)))
+
+		inWindow, windowStart, err = getMaintenanceWindowStatus(autoUpdate, at(4, 30))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeFalse())
+		Expect(windowStart).To(Equal(at(2, 0).AddDate(0, 0, 1)))
+	})
+
+	It("uses a one hour window by default", func() {
+		inWindow, _, err := getMaintenanceWindowStatus(
+			&apiv1.ImageCatalogAutoUpdate{Schedule: autoUpdate.Schedule}, at(3, 30))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeFalse())
+	})
+
+	It("fails with an invalid schedule", func() {
+		_, _, err := getMaintenanceWindowStatus(&apiv1.ImageCatalogAutoUpdate{Schedule: "every day"}, at(3, 30))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("requeues the cluster at the start of the next window", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageCatalogRef: &apiv1.ImageCatalogRef{
+					AutoUpdate: &apiv1.ImageCatalogAutoUpdate{
+						Schedule: "0 0 0 1 1 *",
+						Duration: &metav1.Duration{Duration: time.Second},
+					},
+				},
+			},
+		}
+		result := requeueAtNextMaintenanceWindow(cluster, ctrl.Result{})
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		result = requeueAtNextMaintenanceWindow(cluster, ctrl.Result{RequeueAfter: time.Second})
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+
+		result = requeueAtNextMaintenanceWindow(&apiv1.Cluster{}, ctrl.Result{})
+		Expect(result.IsZero()).To(BeTrue())
+	})
 })
@@ -0,0 +1,159 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// selectedNodeAnnotationName is the annotation set by the scheduler on the
+// PVCs whose volume is provisioned on the node chosen for the Pod
+const selectedNodeAnnotationName = "volume.kubernetes.io/selected-node"
+
+// reconcileLostStorageInstances detects the instances whose storage is lost
+// and, if requested by the user, deletes them together with their PVCs, so
+// that new instances are cloned from the primary
+func (r *ClusterReconciler) reconcileLostStorageInstances(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	for idx := range resources.instances.Items {
+		pod := &resources.instances.Items[idx]
+
+		reason := getLostStorageReason(pod, resources.pvcs.Items, resources.nodes)
+		if reason == "" {
+			continue
+		}
+
+		contextLogger.Warning("Detected an instance whose storage is lost",
+			"pod", pod.Name, "reason", reason, "lostStoragePolicy", cluster.GetLostStoragePolicy())
+		r.Recorder.Eventf(cluster, "Warning", "LostStorage",
+			"The storage of instance %v is lost: %v", pod.Name, reason)
+
+		if cluster.GetLostStoragePolicy() != apiv1.LostStoragePolicyRecreate {
+			continue
+		}
+
+		// The storage of the primary is never deleted: we wait for the
+		// failover to promote another instance, after which the former
+		// primary is handled like any other replica
+		if pod.Name == cluster.Status.CurrentPrimary || pod.Name == cluster.Status.TargetPrimary {
+			contextLogger.Info("Waiting for a failover before recreating the instance whose storage is lost",
+				"pod", pod.Name,
+				"currentPrimary", cluster.Status.CurrentPrimary,
+				"targetPrimary", cluster.Status.TargetPrimary)
+			continue
+		}
+
+		// The new instance will be cloned from the primary, which needs
+		// to be up and running
+		if !isCurrentPrimaryReady(cluster, resources) {
+			contextLogger.Info("Waiting for the primary to be ready before recreating the instance whose storage is lost",
+				"pod", pod.Name, "currentPrimary", cluster.Status.CurrentPrimary)
+			return nil, nil
+		}
+
+		// When the node is gone, the kubelet will never confirm the
+		// termination of the Pod, so we don't wait for it
+		if _, nodeExists := resources.nodes[pod.Spec.NodeName]; resources.nodes != nil &&
+			pod.Spec.NodeName != "" && !nodeExists {
+			if err := r.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !apierrs.IsNotFound(err) {
+				return nil, fmt.Errorf("while force deleting pod %s: %w", pod.Name, err)
+			}
+		}
+
+		if err := r.ensureInstanceIsDeleted(ctx, cluster, pod.Name); err != nil {
+			return nil, err
+		}
+
+		r.Recorder.Eventf(cluster, "Normal", "RecreateInstance",
+			"Deleted instance %v and its PVCs, as its storage is lost. A new instance will be cloned from the primary",
+			pod.Name)
+
+		// We deleted the pod and the PVC group. Give time to the informer cache to notice that.
+		return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	return nil, nil
+}
+
+// getLostStorageReason returns why the storage of the passed instance is
+// considered lost, or an empty string if it is not. The storage is lost
+// when one of the PVCs is in the `Lost` phase, i.e. its volume has been
+// deleted, or when it is stored on a node that doesn't exist anymore, while
+// the Pod is either unschedulable or still assigned to that node
+func getLostStorageReason(
+	pod *corev1.Pod,
+	pvcs []corev1.PersistentVolumeClaim,
+	nodes map[string]corev1.Node,
+) string {
+	for idx := range pvcs {
+		pvc := &pvcs[idx]
+		if pvc.Labels[utils.InstanceNameLabelName] != pod.Name {
+			continue
+		}
+
+		if pvc.Status.Phase == corev1.ClaimLost {
+			return fmt.Sprintf("the volume bound to PVC %s doesn't exist anymore", pvc.Name)
+		}
+
+		// Without the nodes, we can't tell whether they still exist
+		selectedNode := pvc.Annotations[selectedNodeAnnotationName]
+		if selectedNode == "" || nodes == nil {
+			continue
+		}
+		if _, nodeExists := nodes[selectedNode]; nodeExists {
+			continue
+		}
+
+		if utils.IsPodUnschedulable(pod) || pod.Spec.NodeName == selectedNode {
+			return fmt.Sprintf("PVC %s is stored on node %s, which doesn't exist anymore", pvc.Name, selectedNode)
+		}
+	}
+
+	return ""
+}
+
+// isCurrentPrimaryReady checks whether the Pod of the current primary
+// is ready to accept connections
+func isCurrentPrimaryReady(cluster *apiv1.Cluster, resources *managedResources) bool {
+	for idx := range resources.instances.Items {
+		pod := resources.instances.Items[idx]
+		if pod.Name == cluster.Status.CurrentPrimary {
+			return pod.GetDeletionTimestamp() == nil && utils.IsPodReady(pod)
+		}
+	}
+
+	return false
+}
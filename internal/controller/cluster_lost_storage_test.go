@@ -0,0 +1,221 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lost storage detection", func() {
+	const instanceName = "cluster-example-2"
+
+	nodes := map[string]corev1.Node{
+		"node-1": {ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	}
+
+	newPVC := func(selectedNode string, phase corev1.PersistentVolumeClaimPhase) corev1.PersistentVolumeClaim {
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: instanceName,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+				},
+				Annotations: map[string]string{},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: phase,
+			},
+		}
+		if selectedNode != "" {
+			pvc.Annotations[selectedNodeAnnotationName] = selectedNode
+		}
+		return pvc
+	}
+
+	unschedulablePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: instanceName},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodScheduled,
+					Status: corev1.ConditionFalse,
+					Reason: corev1.PodReasonUnschedulable,
+				},
+			},
+		},
+	}
+
+	It("detects PVCs in the Lost phase", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: instanceName}}
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("", corev1.ClaimLost)}
+		Expect(getLostStorageReason(pod, pvcs, nodes)).ToNot(BeEmpty())
+	})
+
+	It("detects unschedulable instances whose PVC is on a node that doesn't exist", func() {
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("node-2", corev1.ClaimBound)}
+		Expect(getLostStorageReason(unschedulablePod, pvcs, nodes)).ToNot(BeEmpty())
+	})
+
+	It("detects instances assigned to the node that doesn't exist", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: instanceName},
+			Spec:       corev1.PodSpec{NodeName: "node-2"},
+		}
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("node-2", corev1.ClaimBound)}
+		Expect(getLostStorageReason(pod, pvcs, nodes)).ToNot(BeEmpty())
+	})
+
+	It("ignores the PVCs stored on existing nodes", func() {
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("node-1", corev1.ClaimBound)}
+		Expect(getLostStorageReason(unschedulablePod, pvcs, nodes)).To(BeEmpty())
+	})
+
+	It("ignores the instances that can run while their PVC node doesn't exist", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: instanceName},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+		}
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("node-2", corev1.ClaimBound)}
+		Expect(getLostStorageReason(pod, pvcs, nodes)).To(BeEmpty())
+	})
+
+	It("doesn't consider the nodes lost when they can't be read", func() {
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("node-2", corev1.ClaimBound)}
+		Expect(getLostStorageReason(unschedulablePod, pvcs, nil)).To(BeEmpty())
+	})
+
+	It("ignores the PVCs of other instances", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-3"}}
+		pvcs := []corev1.PersistentVolumeClaim{newPVC("", corev1.ClaimLost)}
+		Expect(getLostStorageReason(pod, pvcs, nodes)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Lost storage reconciliation", func() {
+	var env *testingEnvironment
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+	})
+
+	newResources := func(cluster *apiv1.Cluster) *managedResources {
+		resources := &managedResources{
+			pvcs: corev1.PersistentVolumeClaimList{
+				Items: generateClusterPVC(env.client, cluster, persistentvolumeclaim.StatusReady),
+			},
+			instances: corev1.PodList{
+				Items: generateFakeClusterPodsWithDefaultClient(env.client, cluster, true),
+			},
+			nodes: map[string]corev1.Node{},
+		}
+		for idx := range resources.pvcs.Items {
+			resources.pvcs.Items[idx].Status.Phase = corev1.ClaimLost
+		}
+		return resources
+	}
+
+	isInstanceExisting := func(ctx SpecContext, cluster *apiv1.Cluster, name string) bool {
+		exists, err := isResourceExisting(
+			ctx,
+			env.client,
+			&corev1.PersistentVolumeClaim{},
+			types.NamespacedName{Name: name, Namespace: cluster.Namespace},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		return exists
+	}
+
+	It("only reports the lost storage by default", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.TargetPrimary = cluster.Name + "-1"
+		})
+		resources := newResources(cluster)
+
+		result, err := env.clusterReconciler.reconcileLostStorageInstances(ctx, cluster, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-2")).To(BeTrue())
+	})
+
+	It("recreates the replicas, but not the primary", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.LostStoragePolicy = apiv1.LostStoragePolicyRecreate
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.TargetPrimary = cluster.Name + "-1"
+		})
+		resources := newResources(cluster)
+		// The storage of the primary is fine
+		resources.pvcs.Items[0].Status.Phase = corev1.ClaimBound
+
+		result, err := env.clusterReconciler.reconcileLostStorageInstances(ctx, cluster, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-1")).To(BeTrue())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-2")).To(BeFalse())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-3")).To(BeTrue())
+	})
+
+	It("waits for a failover when the storage of the primary is lost", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.LostStoragePolicy = apiv1.LostStoragePolicyRecreate
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.TargetPrimary = cluster.Name + "-1"
+		})
+		resources := newResources(cluster)
+		// The storage of the replicas is fine
+		resources.pvcs.Items[1].Status.Phase = corev1.ClaimBound
+		resources.pvcs.Items[2].Status.Phase = corev1.ClaimBound
+
+		result, err := env.clusterReconciler.reconcileLostStorageInstances(ctx, cluster, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-1")).To(BeTrue())
+	})
+
+	It("waits for the primary to be ready before recreating a replica", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.LostStoragePolicy = apiv1.LostStoragePolicyRecreate
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.TargetPrimary = cluster.Name + "-1"
+		})
+		resources := newResources(cluster)
+		resources.pvcs.Items[0].Status.Phase = corev1.ClaimBound
+		resources.instances.Items[0].Status = corev1.PodStatus{}
+
+		result, err := env.clusterReconciler.reconcileLostStorageInstances(ctx, cluster, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(isInstanceExisting(ctx, cluster, cluster.Name+"-2")).To(BeTrue())
+	})
+})
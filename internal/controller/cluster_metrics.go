@@ -0,0 +1,96 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+const (
+	primaryChangeFailover   = "failover"
+	primaryChangeSwitchover = "switchover"
+)
+
+// primaryChanges counts the changes of the primary instance of the clusters
+// observed by the operator
+var primaryChanges = newPrimaryChangesRecorder()
+
+// primaryChangesRecorder counts the changes of the primary instance, telling
+// the automatic failovers apart from the switchovers, including the ones
+// requested by the user. Every change is identified by the timestamp of the
+// target primary, so that it is counted only once while it is in progress.
+type primaryChangesRecorder struct {
+	mu       sync.Mutex
+	observed map[types.NamespacedName]string
+
+	total *prometheus.CounterVec
+}
+
+// newPrimaryChangesRecorder creates a new recorder of the primary changes
+func newPrimaryChangesRecorder() *primaryChangesRecorder {
+	return &primaryChangesRecorder{
+		observed: make(map[types.NamespacedName]string),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cnpg_cluster_failovers_total",
+			Help: "Number of changes of the primary instance, by type (failover or switchover)",
+		}, []string{"namespace", "cluster", "type"}),
+	}
+}
+
+// observe counts the change of the primary instance in progress in the
+// passed cluster, if any and if it has not been counted yet
+func (p *primaryChangesRecorder) observe(cluster *apiv1.Cluster) {
+	clusterStatus := &cluster.Status
+	if clusterStatus.CurrentPrimary == "" ||
+		clusterStatus.TargetPrimary == "" ||
+		clusterStatus.TargetPrimary == clusterStatus.CurrentPrimary ||
+		clusterStatus.TargetPrimary == apiv1.PendingFailoverMarker {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	if p.observed[key] == clusterStatus.TargetPrimaryTimestamp {
+		return
+	}
+	p.observed[key] = clusterStatus.TargetPrimaryTimestamp
+
+	changeType := primaryChangeSwitchover
+	if clusterStatus.Phase == apiv1.PhaseFailOver {
+		changeType = primaryChangeFailover
+	}
+	p.total.WithLabelValues(cluster.Namespace, cluster.Name, changeType).Inc()
+}
+
+// forget removes the passed cluster from the recorder, once it has been deleted
+func (p *primaryChangesRecorder) forget(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.observed, key)
+	p.total.DeletePartialMatch(prometheus.Labels{"namespace": key.Namespace, "cluster": key.Name})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Primary changes metrics", func() {
+	var recorder *primaryChangesRecorder
+
+	newCluster := func(phase, currentPrimary, targetPrimary, timestamp string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status: apiv1.ClusterStatus{
+				Phase:                  phase,
+				CurrentPrimary:         currentPrimary,
+				TargetPrimary:          targetPrimary,
+				TargetPrimaryTimestamp: timestamp,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		recorder = newPrimaryChangesRecorder()
+	})
+
+	It("counts every change of the primary only once, by type", func() {
+		failover := newCluster(apiv1.PhaseFailOver, "cluster-example-1", "cluster-example-2", "t1")
+		recorder.observe(failover)
+		recorder.observe(failover)
+
+		switchover := newCluster(apiv1.PhaseSwitchover, "cluster-example-2", "cluster-example-3", "t2")
+		recorder.observe(switchover)
+
+		expected := `
+# HELP cnpg_cluster_failovers_total Number of changes of the primary instance, by type (failover or switchover)
+# TYPE cnpg_cluster_failovers_total counter
+cnpg_cluster_failovers_total{cluster="cluster-example",namespace="default",type="failover"} 1
+cnpg_cluster_failovers_total{cluster="cluster-example",namespace="default",type="switchover"} 1
+`
+		Expect(testutil.CollectAndCompare(recorder.total, strings.NewReader(expected))).To(Succeed())
+	})
+
+	It("ignores the clusters without a change of the primary in progress", func() {
+		recorder.observe(newCluster(apiv1.PhaseHealthy, "cluster-example-1", "cluster-example-1", "t1"))
+		recorder.observe(newCluster(apiv1.PhaseFirstPrimary, "", "cluster-example-1", "t1"))
+		recorder.observe(newCluster(apiv1.PhaseFailOver, "cluster-example-1", apiv1.PendingFailoverMarker, "t1"))
+		Expect(testutil.CollectAndCount(recorder.total)).To(BeZero())
+	})
+
+	It("forgets the deleted clusters", func() {
+		recorder.observe(newCluster(apiv1.PhaseFailOver, "cluster-example-1", "cluster-example-2", "t1"))
+		Expect(testutil.CollectAndCount(recorder.total)).To(Equal(1))
+
+		recorder.forget(types.NamespacedName{Namespace: "default", Name: "cluster-example"})
+		Expect(testutil.CollectAndCount(recorder.total)).To(BeZero())
+		Expect(recorder.observed).To(BeEmpty())
+	})
+})
@@ -0,0 +1,99 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileNetworkPolicy creates or patches the NetworkPolicy of the instance
+// pods when it is enabled, and deletes it otherwise. A NetworkPolicy with the
+// same name which is not owned by the cluster is never changed
+func (r *ClusterReconciler) reconcileNetworkPolicy(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+
+	var oldNetworkPolicy networkingv1.NetworkPolicy
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace},
+		&oldNetworkPolicy); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while getting NetworkPolicy: %w", err)
+		}
+
+		if !cluster.IsNetworkPolicyEnabled() {
+			return nil
+		}
+
+		r.Recorder.Event(cluster, "Normal", "CreatingNetworkPolicy",
+			fmt.Sprintf("Creating NetworkPolicy %s", cluster.Name))
+		if err = r.Create(ctx, specs.BuildNetworkPolicy(cluster, configuration.Current.OperatorNamespace)); err != nil {
+			return fmt.Errorf("while creating NetworkPolicy: %w", err)
+		}
+		return nil
+	}
+
+	if owner, owned := IsOwnedByCluster(&oldNetworkPolicy); !owned || owner != cluster.Name {
+		if cluster.IsNetworkPolicyEnabled() {
+			contextLogger.Warning("Skipping the NetworkPolicy reconciliation, as a NetworkPolicy "+
+				"with the same name is not owned by the cluster", "name", oldNetworkPolicy.Name)
+			r.Recorder.Event(cluster, "Warning", "NetworkPolicyNotOwned",
+				fmt.Sprintf("NetworkPolicy %s is not owned by the cluster and won't be managed",
+					oldNetworkPolicy.Name))
+		}
+		return nil
+	}
+
+	if !cluster.IsNetworkPolicyEnabled() {
+		contextLogger.Info("Deleting NetworkPolicy", "name", oldNetworkPolicy.Name)
+		if err := r.Delete(ctx, &oldNetworkPolicy); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while deleting NetworkPolicy: %w", err)
+		}
+		return nil
+	}
+
+	networkPolicy := specs.BuildNetworkPolicy(cluster, configuration.Current.OperatorNamespace)
+	patchedNetworkPolicy := oldNetworkPolicy.DeepCopy()
+	patchedNetworkPolicy.Spec = networkPolicy.Spec
+	utils.MergeObjectsMetadata(patchedNetworkPolicy, networkPolicy)
+
+	if reflect.DeepEqual(patchedNetworkPolicy.Spec, oldNetworkPolicy.Spec) &&
+		reflect.DeepEqual(patchedNetworkPolicy.ObjectMeta, oldNetworkPolicy.ObjectMeta) {
+		return nil
+	}
+
+	r.Recorder.Event(cluster, "Normal", "UpdatingNetworkPolicy",
+		fmt.Sprintf("Updating NetworkPolicy %s", networkPolicy.Name))
+	if err := r.Patch(ctx, patchedNetworkPolicy, client.MergeFrom(&oldNetworkPolicy)); err != nil {
+		return fmt.Errorf("while patching NetworkPolicy: %w", err)
+	}
+
+	return nil
+}
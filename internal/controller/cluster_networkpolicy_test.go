@@ -0,0 +1,91 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy reconciliation", func() {
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		cluster = newFakeCNPGCluster(env.client, newFakeNamespace(env.client), func(cluster *apiv1.Cluster) {
+			cluster.Spec.Managed = &apiv1.ManagedConfiguration{
+				NetworkPolicy: &apiv1.ManagedNetworkPolicy{Enabled: true},
+			}
+		})
+	})
+
+	It("creates, updates and deletes the NetworkPolicy", func(ctx SpecContext) {
+		var networkPolicy networkingv1.NetworkPolicy
+		Expect(env.clusterReconciler.reconcileNetworkPolicy(ctx, cluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)).To(Succeed())
+		owner, owned := IsOwnedByCluster(&networkPolicy)
+		Expect(owned).To(BeTrue())
+		Expect(owner).To(Equal(cluster.Name))
+		Expect(networkPolicy.Spec.Ingress).To(HaveLen(3))
+
+		cluster.Spec.Managed.NetworkPolicy.AdditionalIngress = []networkingv1.NetworkPolicyIngressRule{
+			{From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}},
+		}
+		Expect(env.clusterReconciler.reconcileNetworkPolicy(ctx, cluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)).To(Succeed())
+		Expect(networkPolicy.Spec.Ingress).To(HaveLen(4))
+
+		cluster.Spec.Managed.NetworkPolicy.Enabled = false
+		Expect(env.clusterReconciler.reconcileNetworkPolicy(ctx, cluster)).To(Succeed())
+		err := env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("doesn't change a NetworkPolicy not owned by the cluster", func(ctx SpecContext) {
+		userNetworkPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+		Expect(env.client.Create(ctx, userNetworkPolicy)).To(Succeed())
+
+		var networkPolicy networkingv1.NetworkPolicy
+		Expect(env.clusterReconciler.reconcileNetworkPolicy(ctx, cluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)).To(Succeed())
+		Expect(networkPolicy.OwnerReferences).To(BeEmpty())
+		Expect(networkPolicy.Spec.Ingress).To(BeEmpty())
+
+		cluster.Spec.Managed.NetworkPolicy.Enabled = false
+		Expect(env.clusterReconciler.reconcileNetworkPolicy(ctx, cluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)).To(Succeed())
+	})
+})
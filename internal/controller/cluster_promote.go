@@ -0,0 +1,256 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// promotionRequestTimeout is the time an instance annotated to be promoted
+// has to become ready to be promoted, before the request is removed
+const promotionRequestTimeout = 10 * time.Minute
+
+// promotionRequestRequeueInterval is the interval at which the instance
+// annotated to be promoted is checked while the request is pending
+const promotionRequestRequeueInterval = 5 * time.Second
+
+// reconcilePromotionRequests handles the instances annotated to be promoted,
+// switching the primary over to them and removing the annotation once done.
+// While the annotated instance is not ready to be promoted the reconciliation
+// continues, so that it doesn't block the rolling updates the instance may
+// be waiting for, and the request is reported in the PromotionRequestPending
+// condition until it expires
+func (r *ClusterReconciler) reconcilePromotionRequests(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var requested *postgres.PostgresqlStatus
+	for idx := range instancesStatus.Items {
+		item := &instancesStatus.Items[idx]
+		if !isPodPromotionRequested(ctx, item.Pod) {
+			continue
+		}
+
+		// The instance is already the primary: the request has been fulfilled
+		if item.Pod.Name == cluster.Status.CurrentPrimary && item.Pod.Name == cluster.Status.TargetPrimary {
+			contextLogger.Info("Instance promoted, removing the promotion request", "podName", item.Pod.Name)
+			if err := r.removePromotionAnnotation(ctx, item.Pod); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		if requested == nil {
+			requested = item
+		}
+	}
+
+	pendingCondition := meta.FindStatusCondition(cluster.Status.Conditions,
+		string(apiv1.ConditionPromotionRequestPending))
+	isPending := pendingCondition != nil && pendingCondition.Status == metav1.ConditionTrue
+
+	if requested == nil {
+		if isPending {
+			return ctrl.Result{}, r.setPromotionRequestNotPending(ctx, cluster,
+				apiv1.ConditionReasonPromotionRequestCanceled, "The promotion request has been removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the running switchover or failover to complete
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return ctrl.Result{}, nil
+	}
+
+	// A fenced instance will never be ready to be promoted
+	if cluster.IsInstanceFenced(requested.Pod.Name) {
+		return ctrl.Result{}, r.dropPromotionRequest(ctx, cluster, requested.Pod,
+			apiv1.ConditionReasonPromotionRequestRejected,
+			fmt.Sprintf("Instance %s can't be promoted as it is fenced", requested.Pod.Name))
+	}
+
+	// We refuse to promote an instance that is not streaming from
+	// the primary, as we did for the switchovers triggered by a
+	// rolling update
+	if !requested.IsPodReady || !requested.IsWalReceiverActive {
+		if isPending && time.Since(pendingCondition.LastTransitionTime.Time) > promotionRequestTimeout {
+			return ctrl.Result{}, r.dropPromotionRequest(ctx, cluster, requested.Pod,
+				apiv1.ConditionReasonPromotionRequestExpired,
+				fmt.Sprintf("Instance %s was not ready to be promoted after %s",
+					requested.Pod.Name, promotionRequestTimeout))
+		}
+
+		contextLogger.Info(
+			"Instance requested to be promoted is not ready to be promoted, waiting",
+			"podName", requested.Pod.Name,
+			"isPodReady", requested.IsPodReady,
+			"isWalReceiverActive", requested.IsWalReceiverActive,
+		)
+		if isPending {
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Eventf(cluster, "Normal", "PromotionPending",
+			"Waiting for %s to be ready and streaming from the primary before promoting it",
+			requested.Pod.Name)
+		return ctrl.Result{}, status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+			Type:   string(apiv1.ConditionPromotionRequestPending),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonPromotionWaitingForInstance),
+			Message: fmt.Sprintf(
+				"Instance %s is not ready or not streaming from the primary, the request "+
+					"expires after %s", requested.Pod.Name, promotionRequestTimeout),
+		})
+	}
+
+	contextLogger.Info("Switching over to the instance requested to be promoted",
+		"currentPrimary", cluster.Status.CurrentPrimary,
+		"targetPrimary", requested.Pod.Name)
+	r.Recorder.Eventf(cluster, "Normal", "Switchover",
+		"Initiating switchover to %s, as requested by the %s annotation",
+		requested.Pod.Name, utils.PromoteInstanceAnnotationName)
+
+	if err := status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		func(cluster *apiv1.Cluster) {
+			cluster.Status.TargetPrimary = requested.Pod.Name
+			cluster.Status.TargetPrimaryTimestamp = pgTime.GetCurrentTimestamp()
+		},
+		status.SetPhase(apiv1.PhaseSwitchover, fmt.Sprintf("Switching over to %v", requested.Pod.Name)),
+		status.SetClusterReadyCondition,
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if isPending {
+		if err := r.setPromotionRequestNotPending(ctx, cluster,
+			apiv1.ConditionReasonPromotionSwitchoverStarted,
+			fmt.Sprintf("Switching over to %s", requested.Pod.Name)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+}
+
+// dropPromotionRequest removes the promotion request from an instance that
+// can't be promoted, reporting the reason
+func (r *ClusterReconciler) dropPromotionRequest(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pod *corev1.Pod,
+	reason apiv1.ConditionReason,
+	message string,
+) error {
+	log.FromContext(ctx).Warning("Removing the promotion request", "podName", pod.Name, "reason", message)
+	r.Recorder.Event(cluster, "Warning", "PromotionRequestRemoved", message)
+	if err := r.removePromotionAnnotation(ctx, pod); err != nil {
+		return err
+	}
+
+	return r.setPromotionRequestNotPending(ctx, cluster, reason, message)
+}
+
+// setPromotionRequestNotPending reports in the cluster status that no
+// promotion request is waiting for its instance
+func (r *ClusterReconciler) setPromotionRequestNotPending(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	reason apiv1.ConditionReason,
+	message string,
+) error {
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionPromotionRequestPending),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: message,
+	})
+}
+
+// requeueForPendingPromotion ensures the cluster is reconciled again while a
+// promotion request is waiting for its instance, whose WAL receiver status is
+// not reflected in any watched resource
+func requeueForPendingPromotion(cluster *apiv1.Cluster, result ctrl.Result) ctrl.Result {
+	// A non-zero result without a delay is already requeued immediately
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionPromotionRequestPending)) ||
+		(!result.IsZero() && result.RequeueAfter == 0) {
+		return result
+	}
+
+	if result.RequeueAfter == 0 || promotionRequestRequeueInterval < result.RequeueAfter {
+		result.RequeueAfter = promotionRequestRequeueInterval
+	}
+
+	return result
+}
+
+// removePromotionAnnotation removes the promotion request from a Pod
+func (r *ClusterReconciler) removePromotionAnnotation(ctx context.Context, pod *corev1.Pod) error {
+	origPod := pod.DeepCopy()
+	delete(pod.Annotations, utils.PromoteInstanceAnnotationName)
+	return r.Patch(ctx, pod, client.MergeFrom(origPod))
+}
+
+// isPodPromotionRequested checks if a Pod has been requested to be promoted
+// looking at its annotation
+func isPodPromotionRequested(ctx context.Context, pod *corev1.Pod) bool {
+	logger := log.FromContext(ctx)
+
+	if pod == nil {
+		return false
+	}
+
+	s, ok := pod.Annotations[utils.PromoteInstanceAnnotationName]
+	if !ok || s == "" {
+		return false
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		logger.Warning("Invalid promote annotation content, skipping",
+			"podName", pod.Name,
+			"value", s,
+			"err", err.Error())
+		return false
+	}
+
+	return v
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Promotion requests", func() {
+	DescribeTable(
+		"promote annotation parsing",
+		func(ctx SpecContext, hasAnnotation bool, value string, isRequested bool) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			}
+
+			if hasAnnotation {
+				pod.Annotations[utils.PromoteInstanceAnnotationName] = value
+			}
+
+			Expect(isPodPromotionRequested(ctx, pod)).To(Equal(isRequested))
+		},
+		Entry("promotion requested", true, "true", true),
+		Entry("promotion not requested", true, "false", false),
+		Entry("instance without annotation", false, "", false),
+		Entry("instance with an invalid annotation", true, "maybe", false),
+	)
+
+	var (
+		cluster    *apiv1.Cluster
+		primary    *corev1.Pod
+		replica    *corev1.Pod
+		reconciler *ClusterReconciler
+	)
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		primary = newPod("cluster-example-1")
+		replica = newPod("cluster-example-2")
+		replica.Annotations[utils.PromoteInstanceAnnotationName] = "true"
+	})
+
+	JustBeforeEach(func() {
+		cli := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, primary, replica).
+			WithStatusSubresource(&apiv1.Cluster{}).
+			Build()
+		reconciler = &ClusterReconciler{
+			Client:   cli,
+			Recorder: record.NewFakeRecorder(120),
+		}
+	})
+
+	instancesStatus := func(walReceiverActive bool) postgres.PostgresqlStatusList {
+		return postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: primary, IsPrimary: true, IsPodReady: true},
+				{Pod: replica, IsPodReady: true, IsWalReceiverActive: walReceiverActive},
+			},
+		}
+	}
+
+	It("switches the primary over to the annotated instance", func(ctx SpecContext) {
+		res, err := reconciler.reconcilePromotionRequests(ctx, cluster, instancesStatus(true))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeFalse())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-2"))
+		Expect(updatedCluster.Status.Phase).To(Equal(apiv1.PhaseSwitchover))
+	})
+
+	It("waits for the annotated instance to be streaming, without blocking the reconciliation",
+		func(ctx SpecContext) {
+			res, err := reconciler.reconcilePromotionRequests(ctx, cluster, instancesStatus(false))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+
+			var updatedCluster apiv1.Cluster
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+			Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-1"))
+			Expect(meta.IsStatusConditionTrue(updatedCluster.Status.Conditions,
+				string(apiv1.ConditionPromotionRequestPending))).To(BeTrue())
+			Expect(requeueForPendingPromotion(&updatedCluster, ctrl.Result{}).RequeueAfter).
+				To(Equal(promotionRequestRequeueInterval))
+
+			// once the instance is streaming, the switchover starts
+			res, err = reconciler.reconcilePromotionRequests(ctx, &updatedCluster, instancesStatus(true))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeFalse())
+
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+			Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-2"))
+			condition := meta.FindStatusCondition(updatedCluster.Status.Conditions,
+				string(apiv1.ConditionPromotionRequestPending))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPromotionSwitchoverStarted)))
+			Expect(requeueForPendingPromotion(&updatedCluster, ctrl.Result{})).To(BeZero())
+		})
+
+	When("the annotated instance doesn't become ready in time", func() {
+		BeforeEach(func() {
+			cluster.Status.Conditions = []metav1.Condition{
+				{
+					Type:               string(apiv1.ConditionPromotionRequestPending),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(apiv1.ConditionReasonPromotionWaitingForInstance),
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-promotionRequestTimeout - time.Minute)),
+				},
+			}
+		})
+
+		It("removes the expired request", func(ctx SpecContext) {
+			res, err := reconciler.reconcilePromotionRequests(ctx, cluster, instancesStatus(false))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+
+			var updatedPod corev1.Pod
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(replica), &updatedPod)).To(Succeed())
+			Expect(updatedPod.Annotations).ToNot(HaveKey(utils.PromoteInstanceAnnotationName))
+
+			var updatedCluster apiv1.Cluster
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+			Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-1"))
+			condition := meta.FindStatusCondition(updatedCluster.Status.Conditions,
+				string(apiv1.ConditionPromotionRequestPending))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPromotionRequestExpired)))
+		})
+	})
+
+	When("the annotated instance is fenced", func() {
+		BeforeEach(func() {
+			cluster.Annotations = map[string]string{
+				utils.FencedInstanceAnnotation: `["cluster-example-2"]`,
+			}
+		})
+
+		It("rejects the request", func(ctx SpecContext) {
+			res, err := reconciler.reconcilePromotionRequests(ctx, cluster, instancesStatus(true))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+
+			var updatedPod corev1.Pod
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(replica), &updatedPod)).To(Succeed())
+			Expect(updatedPod.Annotations).ToNot(HaveKey(utils.PromoteInstanceAnnotationName))
+
+			var updatedCluster apiv1.Cluster
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+			Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-1"))
+			Expect(meta.FindStatusCondition(updatedCluster.Status.Conditions,
+				string(apiv1.ConditionPromotionRequestPending)).Reason).
+				To(Equal(string(apiv1.ConditionReasonPromotionRequestRejected)))
+		})
+	})
+
+	When("the annotated instance is already the primary", func() {
+		BeforeEach(func() {
+			cluster.Status.CurrentPrimary = "cluster-example-2"
+			cluster.Status.TargetPrimary = "cluster-example-2"
+		})
+
+		It("removes the annotation", func(ctx SpecContext) {
+			res, err := reconciler.reconcilePromotionRequests(ctx, cluster, instancesStatus(true))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+
+			var updatedPod corev1.Pod
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(replica), &updatedPod)).To(Succeed())
+			Expect(updatedPod.Annotations).ToNot(HaveKey(utils.PromoteInstanceAnnotationName))
+		})
+	})
+})
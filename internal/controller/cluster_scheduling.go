@@ -0,0 +1,115 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileInstancesSchedulableCondition reports in the status of the cluster,
+// and with an event, the instance pods that have been unschedulable for longer
+// than the configured threshold, together with the reason reported by the
+// Kubernetes scheduler. The condition is reset once every pod is scheduled.
+func (r *ClusterReconciler) reconcileInstancesSchedulableCondition(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+) error {
+	threshold := configuration.Current.GetUnschedulableInstancesThreshold()
+	if threshold < 0 {
+		return nil
+	}
+
+	unschedulable := getUnschedulableInstancesReasons(resources.instances.Items, threshold, time.Now())
+	currentCondition := meta.FindStatusCondition(cluster.Status.Conditions,
+		string(apiv1.ConditionInstancesSchedulable))
+
+	if len(unschedulable) == 0 {
+		if currentCondition == nil || currentCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+			Type:    string(apiv1.ConditionInstancesSchedulable),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonInstancesScheduled),
+			Message: "Every instance pod has been scheduled",
+		})
+	}
+
+	message := fmt.Sprintf("Instance pods unschedulable for more than %v: %s",
+		threshold, strings.Join(unschedulable, "; "))
+	if currentCondition != nil && currentCondition.Status == metav1.ConditionFalse &&
+		currentCondition.Message == message {
+		return nil
+	}
+
+	log.FromContext(ctx).Warning("Some instance pods cannot be scheduled", "reasons", unschedulable)
+	r.Recorder.Event(cluster, "Warning", "UnschedulableInstances", message)
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionInstancesSchedulable),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonInstancesUnschedulable),
+		Message: message,
+	})
+}
+
+// getUnschedulableInstancesReasons returns, sorted by pod name, the reasons
+// why the passed pods have been unschedulable for longer than the threshold,
+// as reported by the Kubernetes scheduler
+func getUnschedulableInstancesReasons(pods []corev1.Pod, threshold time.Duration, now time.Time) []string {
+	var result []string
+	for idx := range pods {
+		pod := &pods[idx]
+		if pod.GetDeletionTimestamp() != nil || !utils.IsPodUnschedulable(pod) {
+			continue
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodScheduled {
+				continue
+			}
+
+			if now.Sub(condition.LastTransitionTime.Time) < threshold {
+				break
+			}
+
+			result = append(result, fmt.Sprintf("%s: %s", pod.Name, condition.Message))
+			break
+		}
+	}
+
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unschedulable instances reporting", func() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newPod := func(name string, unschedulableSince time.Time) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodScheduled,
+						Status:             corev1.ConditionFalse,
+						Reason:             corev1.PodReasonUnschedulable,
+						Message:            "0/3 nodes are available: 3 Insufficient cpu.",
+						LastTransitionTime: metav1.NewTime(unschedulableSince),
+					},
+				},
+			},
+		}
+	}
+
+	It("reports only the pods unschedulable for longer than the threshold", func() {
+		pods := []corev1.Pod{
+			newPod("cluster-example-3", now.Add(-5*time.Minute)),
+			newPod("cluster-example-2", now.Add(-2*time.Minute)),
+			newPod("cluster-example-4", now.Add(-10*time.Second)),
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"}},
+		}
+
+		Expect(getUnschedulableInstancesReasons(pods, time.Minute, now)).To(Equal([]string{
+			"cluster-example-2: 0/3 nodes are available: 3 Insufficient cpu.",
+			"cluster-example-3: 0/3 nodes are available: 3 Insufficient cpu.",
+		}))
+	})
+
+	It("sets and resets the condition of the cluster", func(ctx SpecContext) {
+		env := buildTestEnvironment()
+		cluster := newFakeCNPGCluster(env.client, newFakeNamespace(env.client))
+		resources := &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{
+				newPod(cluster.Name+"-2", time.Now().Add(-time.Hour)),
+			}},
+		}
+
+		Expect(env.clusterReconciler.reconcileInstancesSchedulableCondition(ctx, cluster, resources)).
+			To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionInstancesSchedulable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonInstancesUnschedulable)))
+		Expect(condition.Message).To(ContainSubstring("3 Insufficient cpu"))
+
+		resources.instances.Items = nil
+		Expect(env.clusterReconciler.reconcileInstancesSchedulableCondition(ctx, cluster, resources)).
+			To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		condition = meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionInstancesSchedulable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonInstancesScheduled)))
+	})
+})
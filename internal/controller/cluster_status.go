@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"runtime"
 	"sort"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
@@ -51,7 +52,8 @@ import (
 // managedResources contains the resources that are created a cluster
 // and need to be managed by the controller
 type managedResources struct {
-	// nodes this is a map composed of [nodeName]corev1.Node
+	// nodes this is a map composed of [nodeName]corev1.Node, nil when
+	// the operator lacks the permission to read the nodes
 	nodes     map[string]corev1.Node
 	instances corev1.PodList
 	pvcs      corev1.PersistentVolumeClaimList
@@ -141,6 +143,11 @@ func (r *ClusterReconciler) getManagedResources(
 }
 
 func (r *ClusterReconciler) getNodes(ctx context.Context) (map[string]corev1.Node, error) {
+	if !utils.HaveClusterScopedPermission(utils.NodesPermission) {
+		log.FromContext(ctx).Debug("Missing the permission to read the nodes, skipping node-dependent features")
+		return nil, nil
+	}
+
 	var nodes corev1.NodeList
 	if err := r.List(ctx, &nodes); err != nil {
 		return nil, err
@@ -277,6 +284,17 @@ func (r *ClusterReconciler) updateResourceStatus(
 		cluster.Spec.PostgresConfiguration.SyncReplicaElectionConstraint,
 	)
 
+	// Huge pages
+	cluster.Status.HugePagesFallback = cluster.Spec.PostgresConfiguration.HugePages != nil &&
+		!areHugePagesAvailable(cluster, resources.nodes)
+	if cluster.Status.HugePagesFallback && !existingClusterStatus.HugePagesFallback {
+		contextLogger.Warning("No node provides the requested huge pages, running the instances without them",
+			"resource", cluster.Spec.PostgresConfiguration.HugePages.GetResourceName())
+		r.Recorder.Eventf(cluster, "Warning", "HugePagesUnavailable",
+			"No node provides the requested %v, running the instances without huge pages",
+			cluster.Spec.PostgresConfiguration.HugePages.GetResourceName())
+	}
+
 	// Services
 	cluster.Status.WriteService = cluster.GetServiceReadWriteName()
 	cluster.Status.ReadService = cluster.GetServiceReadName()
@@ -374,6 +392,31 @@ func (r *ClusterReconciler) updateResourceStatus(
 	return nil
 }
 
+// areHugePagesAvailable checks whether at least one of the nodes can
+// provide the huge pages needed by the shared memory of an instance
+func areHugePagesAvailable(cluster *apiv1.Cluster, nodes map[string]corev1.Node) bool {
+	// Without the nodes we can't tell, and we leave the
+	// scheduler to find a node providing them
+	if nodes == nil {
+		return true
+	}
+
+	hugePagesRequest, err := specs.GetHugePagesRequest(cluster)
+	if err != nil {
+		return false
+	}
+
+	resourceName := cluster.Spec.PostgresConfiguration.HugePages.GetResourceName()
+	for _, node := range nodes {
+		allocatable, ok := node.Status.Allocatable[resourceName]
+		if ok && allocatable.Cmp(hugePagesRequest) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // removeConditionsWithInvalidReason will remove every condition which has a not valid
 // reason from the K8s API point-of-view
 func (r *ClusterReconciler) removeConditionsWithInvalidReason(ctx context.Context, cluster *apiv1.Cluster) error {
@@ -583,6 +626,17 @@ func (r *ClusterReconciler) refreshConfigMapResourceVersions(ctx context.Context
 		}
 	}
 
+	if configMaps := cluster.GetAuthenticationRulesConfigMaps(); configMaps.Len() > 0 {
+		versions.AuthenticationRules = make(map[string]string)
+		for _, configMapName := range configMaps.ToList() {
+			version, err := r.getConfigMapResourceVersion(ctx, cluster, configMapName)
+			if err != nil {
+				return err
+			}
+			versions.AuthenticationRules[configMapName] = version
+		}
+	}
+
 	cluster.Status.ConfigMapResourceVersion = versions
 
 	return nil
@@ -676,6 +730,17 @@ func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, c
 		}
 	}
 
+	if secrets := cluster.GetAuthenticationRulesSecrets(); secrets.Len() > 0 {
+		versions.AuthenticationRules = make(map[string]string)
+		for _, secretName := range secrets.ToList() {
+			version, err = r.getSecretResourceVersion(ctx, cluster, secretName)
+			if err != nil {
+				return err
+			}
+			versions.AuthenticationRules[secretName] = version
+		}
+	}
+
 	cluster.Status.SecretsResourceVersion = versions
 
 	return nil
@@ -730,6 +795,20 @@ func (r *ClusterReconciler) setPrimaryInstance(
 	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
 }
 
+// setFailoverPrimaryInstance sets the target primary at the beginning
+// of an automated failover, recording when the failover has been initiated
+func (r *ClusterReconciler) setFailoverPrimaryInstance(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podName string,
+) error {
+	origCluster := cluster.DeepCopy()
+	cluster.Status.TargetPrimary = podName
+	cluster.Status.TargetPrimaryTimestamp = pgTime.GetCurrentTimestamp()
+	cluster.Status.LastFailoverTimestamp = cluster.Status.TargetPrimaryTimestamp
+	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
 // RegisterPhase update phase in the status cluster with the
 // proper reason
 func (r *ClusterReconciler) RegisterPhase(ctx context.Context,
@@ -757,11 +836,20 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 
 	// we extract the instances reported state
 	for _, item := range statuses.Items {
-		cluster.Status.InstancesReportedState[apiv1.PodName(item.Pod.Name)] = apiv1.InstanceReportedState{
-			IsPrimary:  item.IsPrimary,
-			TimeLineID: item.TimeLineID,
-			IP:         item.Pod.Status.PodIP,
+		state := apiv1.InstanceReportedState{
+			IsPrimary:     item.IsPrimary,
+			TimeLineID:    item.TimeLineID,
+			IP:            item.Pod.Status.PodIP,
+			MinApplyDelay: item.MinApplyDelay,
 		}
+		if rules := item.AuthenticationRules; rules != nil {
+			state.AuthenticationRules = &apiv1.AuthenticationRulesStatus{
+				SecretVersions:    rules.SecretVersions,
+				ConfigMapVersions: rules.ConfigMapVersions,
+				Error:             rules.Error,
+			}
+		}
+		cluster.Status.InstancesReportedState[apiv1.PodName(item.Pod.Name)] = state
 	}
 
 	// we update any relevant cluster status that depends on the primary instance
@@ -814,12 +902,108 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		})
 	}
 
+	setTransactionIDAgeCondition(cluster, statuses)
+	setInstancesRunnableCondition(cluster, statuses)
+
 	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
 		return r.Status().Update(ctx, cluster)
 	}
 	return nil
 }
 
+// setTransactionIDAgeCondition sets the TransactionIDAge condition depending
+// on how close the oldest unfrozen transaction and multixact IDs reported
+// by the primary instance are to an anti-wraparound vacuum.
+// The message doesn't report the ages, which change continuously and
+// would require a status update at every reconciliation loop: they are
+// exposed by the cnpg_collector_max_xid_age and cnpg_collector_max_mxid_age
+// metrics instead.
+func setTransactionIDAgeCondition(cluster *apiv1.Cluster, statuses postgres.PostgresqlStatusList) {
+	var primary *postgres.PostgresqlStatus
+	for idx := range statuses.Items {
+		if statuses.Items[idx].IsPrimary {
+			primary = &statuses.Items[idx]
+			break
+		}
+	}
+
+	// the primary didn't report the transaction ID ages, i.e. because it is
+	// not running or because it is managed by an older instance manager
+	if primary == nil || primary.AutovacuumFreezeMaxAge == 0 || primary.AutovacuumMultixactFreezeMaxAge == 0 {
+		return
+	}
+
+	threshold := int64(cluster.Spec.Monitoring.GetWraparoundWarningThreshold())
+	xidLimit := primary.AutovacuumFreezeMaxAge * threshold / 100
+	mxidLimit := primary.AutovacuumMultixactFreezeMaxAge * threshold / 100
+
+	var exceeded []string
+	if primary.MaxXIDAge >= xidLimit {
+		exceeded = append(exceeded, fmt.Sprintf(
+			"the oldest transaction ID age reached %d%% of autovacuum_freeze_max_age (%d)",
+			threshold, primary.AutovacuumFreezeMaxAge))
+	}
+	if primary.MaxMXIDAge >= mxidLimit {
+		exceeded = append(exceeded, fmt.Sprintf(
+			"the oldest multixact ID age reached %d%% of autovacuum_multixact_freeze_max_age (%d)",
+			threshold, primary.AutovacuumMultixactFreezeMaxAge))
+	}
+
+	if len(exceeded) > 0 {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionTransactionIDAge),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonWraparoundThresholdExceeded),
+			Message: "In at least one database " + strings.Join(exceeded, " and "),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   string(apiv1.ConditionTransactionIDAge),
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonWraparoundThresholdNotExceeded),
+		Message: fmt.Sprintf(
+			"The oldest transaction ID and multixact ID ages are below the %d%% warning threshold",
+			threshold),
+	})
+}
+
+// setInstancesRunnableCondition sets the InstancesRunnable condition
+// depending on the instances marked as permanently failed by the startup
+// failure policy. The condition is only set once an instance failed.
+func setInstancesRunnableCondition(cluster *apiv1.Cluster, statuses postgres.PostgresqlStatusList) {
+	var failed []string
+	for _, item := range statuses.Items {
+		if item.IsPermanentlyFailed {
+			failed = append(failed, item.Pod.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:   string(apiv1.ConditionInstancesRunnable),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonInstancesPermanentlyFailed),
+			Message: fmt.Sprintf("PostgreSQL crashed too many times on %s, and is not started anymore: "+
+				"delete the Pods once the issue is solved", strings.Join(failed, ", ")),
+		})
+		return
+	}
+
+	if meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionInstancesRunnable)) == nil {
+		return
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    string(apiv1.ConditionInstancesRunnable),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonNoInstancePermanentlyFailed),
+		Message: "No instance is marked as permanently failed",
+	})
+}
+
 // getPodsTopology returns a map with all the information about the pods topology
 func getPodsTopology(
 	ctx context.Context,
@@ -21,13 +21,16 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
@@ -388,3 +391,165 @@ var _ = Describe("updateClusterStatusThatRequiresInstancesState tests", func() {
 		Expect(state2.IP).To(Equal("192.168.1.2"))
 	})
 })
+
+var _ = Describe("setInstancesRunnableCondition", func() {
+	newStatuses := func(failed ...bool) postgres.PostgresqlStatusList {
+		var result postgres.PostgresqlStatusList
+		for idx, isFailed := range failed {
+			result.Items = append(result.Items, postgres.PostgresqlStatus{
+				Pod:                 &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", idx+1)}},
+				IsPermanentlyFailed: isFailed,
+			})
+		}
+		return result
+	}
+
+	It("doesn't set the condition when no instance ever failed", func() {
+		cluster := &apiv1.Cluster{}
+		setInstancesRunnableCondition(cluster, newStatuses(false, false))
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionInstancesRunnable))).To(BeNil())
+	})
+
+	It("sets and resets the condition", func() {
+		cluster := &apiv1.Cluster{}
+		setInstancesRunnableCondition(cluster, newStatuses(false, true, true))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionInstancesRunnable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonInstancesPermanentlyFailed)))
+		Expect(condition.Message).To(ContainSubstring("pod-2, pod-3"))
+
+		setInstancesRunnableCondition(cluster, newStatuses(false, false, false))
+		condition = meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionInstancesRunnable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonNoInstancePermanentlyFailed)))
+	})
+})
+
+var _ = Describe("setTransactionIDAgeCondition", func() {
+	newStatuses := func(xidAge, mxidAge int64) postgres.PostgresqlStatusList {
+		return postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:                             &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+					IsPrimary:                       true,
+					MaxXIDAge:                       xidAge,
+					MaxMXIDAge:                      mxidAge,
+					AutovacuumFreezeMaxAge:          200000000,
+					AutovacuumMultixactFreezeMaxAge: 400000000,
+				},
+				{
+					Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}},
+				},
+			},
+		}
+	}
+
+	It("doesn't set the condition when the primary hasn't reported the ages", func() {
+		cluster := &apiv1.Cluster{}
+		setTransactionIDAgeCondition(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}}, IsPrimary: true},
+			},
+		})
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionTransactionIDAge))).To(BeNil())
+	})
+
+	It("sets the condition to true when the ages are below the threshold", func() {
+		cluster := &apiv1.Cluster{}
+		setTransactionIDAgeCondition(cluster, newStatuses(100000000, 1000))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionTransactionIDAge))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWraparoundThresholdNotExceeded)))
+	})
+
+	It("keeps the same message while the ages change", func() {
+		cluster := &apiv1.Cluster{}
+		setTransactionIDAgeCondition(cluster, newStatuses(100000000, 1000))
+		previous := cluster.Status.DeepCopy()
+
+		setTransactionIDAgeCondition(cluster, newStatuses(100001000, 1200))
+		Expect(cluster.Status).To(Equal(*previous))
+
+		setTransactionIDAgeCondition(cluster, newStatuses(180000000, 1000))
+		previous = cluster.Status.DeepCopy()
+
+		setTransactionIDAgeCondition(cluster, newStatuses(185000000, 1000))
+		Expect(cluster.Status).To(Equal(*previous))
+	})
+
+	It("sets the condition to false when the transaction ID age crosses the default threshold", func() {
+		cluster := &apiv1.Cluster{}
+		setTransactionIDAgeCondition(cluster, newStatuses(180000000, 1000))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionTransactionIDAge))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWraparoundThresholdExceeded)))
+		Expect(condition.Message).To(ContainSubstring("autovacuum_freeze_max_age"))
+		Expect(condition.Message).ToNot(ContainSubstring("autovacuum_multixact_freeze_max_age"))
+	})
+
+	It("sets the condition to false when the multixact ID age crosses a custom threshold", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					WraparoundWarningThreshold: ptr.To(int32(50)),
+				},
+			},
+		}
+		setTransactionIDAgeCondition(cluster, newStatuses(1000, 200000000))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionTransactionIDAge))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("autovacuum_multixact_freeze_max_age"))
+	})
+})
+
+var _ = Describe("Huge pages availability", func() {
+	cluster := &apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			PostgresConfiguration: apiv1.PostgresConfiguration{
+				HugePages: &apiv1.HugePagesConfiguration{},
+				Parameters: map[string]string{
+					"shared_buffers": "1GB",
+				},
+			},
+		},
+	}
+
+	newNode := func(name string, hugePages string) corev1.Node {
+		node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if hugePages != "" {
+			node.Status.Allocatable = corev1.ResourceList{
+				"hugepages-2Mi": resource.MustParse(hugePages),
+			}
+		}
+		return node
+	}
+
+	It("finds the nodes providing enough huge pages", func() {
+		nodes := map[string]corev1.Node{
+			"node-1": newNode("node-1", ""),
+			"node-2": newNode("node-2", "2Gi"),
+		}
+		Expect(areHugePagesAvailable(cluster, nodes)).To(BeTrue())
+	})
+
+	It("ignores the nodes without enough huge pages", func() {
+		nodes := map[string]corev1.Node{
+			"node-1": newNode("node-1", ""),
+			"node-2": newNode("node-2", "512Mi"),
+		}
+		Expect(areHugePagesAvailable(cluster, nodes)).To(BeFalse())
+	})
+
+	It("leaves the scheduler to find the huge pages when the nodes can't be read", func() {
+		Expect(areHugePagesAvailable(cluster, nil)).To(BeTrue())
+	})
+})
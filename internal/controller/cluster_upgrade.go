@@ -29,13 +29,17 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/replication"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -59,8 +63,11 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 	// The following code works under the assumption that podList.Items list is ordered
 	// by lag (primary first)
 
-	// upgrade all the replicas starting from the more lagged
+	// upgrade all the replicas starting from the more lagged, taking down
+	// at most the allowed number of them at the same time
+	maxReplicaUpdates := getReplicaUpdateBatchSize(ctx, cluster, podList)
 	var primaryPostgresqlStatus *postgres.PostgresqlStatus
+	var updatedReplicas int
 	for i := len(podList.Items) - 1; i >= 0; i-- {
 		postgresqlStatus := podList.Items[i]
 
@@ -79,17 +86,21 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			continue
 		}
 
-		managerResult := r.rolloutManager.CoordinateRollout(client.ObjectKeyFromObject(cluster), postgresqlStatus.Pod.Name)
-		if !managerResult.RolloutAllowed {
-			r.Recorder.Eventf(
-				cluster,
-				"Normal",
-				"RolloutDelayed",
-				"Rollout of pod %s have been delayed for %s",
-				postgresqlStatus.Pod.Name,
-				managerResult.TimeToWait.String(),
-			)
-			return false, errRolloutDelayed
+		// The replicas of the same batch are rolled out together
+		if updatedReplicas == 0 {
+			managerResult := r.rolloutManager.CoordinateRollout(
+				client.ObjectKeyFromObject(cluster), postgresqlStatus.Pod.Name)
+			if !managerResult.RolloutAllowed {
+				r.Recorder.Eventf(
+					cluster,
+					"Normal",
+					"RolloutDelayed",
+					"Rollout of pod %s have been delayed for %s",
+					postgresqlStatus.Pod.Name,
+					managerResult.TimeToWait.String(),
+				)
+				return false, errRolloutDelayed
+			}
 		}
 
 		restartMessage := fmt.Sprintf("Restarting instance %s, because: %s",
@@ -98,7 +109,18 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			return false, fmt.Errorf("postgresqlStatus pod name: %s, %w", postgresqlStatus.Pod.Name, err)
 		}
 
-		return true, r.upgradePod(ctx, cluster, postgresqlStatus.Pod, restartMessage)
+		if err := r.upgradePod(ctx, cluster, postgresqlStatus.Pod, restartMessage); err != nil {
+			return false, err
+		}
+
+		updatedReplicas++
+		if updatedReplicas >= maxReplicaUpdates {
+			return true, nil
+		}
+	}
+
+	if updatedReplicas > 0 {
+		return true, nil
 	}
 
 	// report an error if there is no primary. This condition should never happen because
@@ -116,7 +138,7 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 	// we first check whether a restart is needed given the provided condition
 	podRollout := isInstanceNeedingRollout(ctx, *primaryPostgresqlStatus, cluster)
 	if !podRollout.required {
-		return false, nil
+		return false, r.markPrimaryUpdateCompleted(ctx, cluster)
 	}
 
 	// if the primary instance is marked for restart due to hot standby sensitive parameter decrease,
@@ -141,7 +163,32 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 	}
 
 	return r.updatePrimaryPod(ctx, cluster, podList, *primaryPostgresqlStatus.Pod,
-		podRollout.canBeInPlace, podRollout.primaryForceRecreate, podRollout.reason)
+		podRollout.getPrimaryUpdateMethod(cluster), podRollout.canBeInPlace,
+		podRollout.primaryForceRecreate, podRollout.reason)
+}
+
+// getReplicaUpdateBatchSize returns the number of replicas that can be
+// updated at the same time, keeping enough of them online to satisfy
+// the synchronous replication requirements of the cluster
+func getReplicaUpdateBatchSize(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+) int {
+	var replicas int
+	for _, item := range podList.Items {
+		if item.Pod.Name != cluster.Status.CurrentPrimary && !cluster.IsInstanceFenced(item.Pod.Name) {
+			replicas++
+		}
+	}
+
+	// We always allow a replica to be updated, as it happens with the
+	// default configuration
+	batchSize := min(
+		cluster.GetMaxConcurrentReplicaUpdates(),
+		replicas-replication.GetExpectedSyncReplicasNumber(ctx, cluster),
+	)
+	return max(batchSize, 1)
 }
 
 func (r *ClusterReconciler) updatePrimaryPod(
@@ -149,6 +196,7 @@ func (r *ClusterReconciler) updatePrimaryPod(
 	cluster *apiv1.Cluster,
 	podList *postgres.PostgresqlStatusList,
 	primaryPod corev1.Pod,
+	updateMethod apiv1.PrimaryUpdateMethod,
 	inPlacePossible bool,
 	forceRecreate bool,
 	reason rolloutReason,
@@ -158,17 +206,52 @@ func (r *ClusterReconciler) updatePrimaryPod(
 	// we need to check whether a manual switchover is required
 	contextLogger = contextLogger.WithValues("primaryPod", primaryPod.Name)
 	if cluster.GetPrimaryUpdateStrategy() == apiv1.PrimaryUpdateStrategySupervised {
-		contextLogger.Info("Waiting for the user to request a switchover to complete the rolling update",
-			"reason", reason)
-		err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForUser, "User must issue a supervised switchover")
-		if err != nil {
-			return false, err
+		if !utils.IsPrimaryUpdateApproved(&cluster.ObjectMeta) {
+			contextLogger.Info("Waiting for the user to request a switchover or to approve "+
+				"the primary update to complete the rolling update",
+				"reason", reason)
+			if err := r.requestPrimaryUpdateApproval(ctx, cluster, reason); err != nil {
+				return false, err
+			}
+			err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForUser, "User must issue a supervised switchover")
+			if err != nil {
+				return false, err
+			}
+
+			return true, nil
 		}
 
-		return true, nil
+		contextLogger.Info("The user approved the update of the primary instance", "reason", reason)
+
+		// The approval is consumed only once the update has been started,
+		// so that it is still valid when the update needs to be retried
+		done, err := r.startPrimaryPodUpdate(ctx, cluster, podList, primaryPod, updateMethod,
+			inPlacePossible, forceRecreate, reason)
+		if err != nil || !done {
+			return done, err
+		}
+		return done, r.consumePrimaryUpdateApproval(ctx, cluster)
 	}
 
-	if cluster.GetPrimaryUpdateMethod() == apiv1.PrimaryUpdateMethodRestart || forceRecreate {
+	return r.startPrimaryPodUpdate(ctx, cluster, podList, primaryPod, updateMethod,
+		inPlacePossible, forceRecreate, reason)
+}
+
+// startPrimaryPodUpdate updates the primary instance, either restarting it
+// or switching over to a replica, returning true when the update started
+func (r *ClusterReconciler) startPrimaryPodUpdate(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+	primaryPod corev1.Pod,
+	updateMethod apiv1.PrimaryUpdateMethod,
+	inPlacePossible bool,
+	forceRecreate bool,
+	reason rolloutReason,
+) (bool, error) {
+	contextLogger := log.FromContext(ctx).WithValues("primaryPod", primaryPod.Name)
+
+	if updateMethod == apiv1.PrimaryUpdateMethodRestart || forceRecreate {
 		if inPlacePossible {
 			// In-place restart is possible
 			if err := r.updateRestartAnnotation(ctx, cluster, primaryPod); err != nil {
@@ -192,17 +275,18 @@ func (r *ClusterReconciler) updatePrimaryPod(
 
 	// if the cluster has more than one instance, we should trigger a switchover before upgrading
 	if cluster.Status.Instances > 1 && len(podList.Items) > 1 {
-		// If this is not a replica cluster, podList.Items[1] is the first replica,
-		// as the pod list is sorted in the same order we use for switchover / failover.
+		// If this is not a replica cluster, the first instance of the list other
+		// than the primary is the first replica, as the pod list is sorted in the
+		// same order we use for switchover / failover.
 		// This may not be true for replica clusters, where every instance is a replica
 		// from the PostgreSQL point-of-view.
-		targetInstance := podList.Items[1]
-
-		// If this is a replica cluster, the target primary we chose may be
-		// the one we're trying to upgrade, as the list isn't sorted. In
-		// this case, we promote the first instance of the list
-		if targetInstance.Pod.Name == primaryPod.Name {
-			targetInstance = podList.Items[0]
+		// The instances excluded from the failover are never promoted.
+		targetInstance := getSwitchoverTarget(cluster, podList, primaryPod.Name)
+		if targetInstance == nil {
+			contextLogger.Info("No instance is eligible for promotion, interrupting the primaryPodUpdate",
+				"updateReason", reason,
+				"currentPrimary", primaryPod.Name)
+			return false, nil
 		}
 
 		// Before promoting a replica, the instance manager will wait for the WAL receiver
@@ -242,6 +326,75 @@ func (r *ClusterReconciler) updatePrimaryPod(
 	return true, r.upgradePod(ctx, cluster, &primaryPod, reason)
 }
 
+// getSwitchoverTarget returns the first instance of the list, other than
+// the primary, that can be promoted, or nil if there is none
+func getSwitchoverTarget(
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+	primaryName string,
+) *postgres.PostgresqlStatus {
+	for idx := range podList.Items {
+		name := podList.Items[idx].Pod.Name
+		if name != primaryName && cluster.IsFailoverCandidate(name) {
+			return &podList.Items[idx]
+		}
+	}
+
+	return nil
+}
+
+// requestPrimaryUpdateApproval reports in the cluster status that the replicas
+// have been updated, and the primary instance is waiting for the user approval
+func (r *ClusterReconciler) requestPrimaryUpdateApproval(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	reason rolloutReason,
+) error {
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:   string(apiv1.ConditionPrimaryUpdateApproved),
+		Status: metav1.ConditionFalse,
+		Reason: string(apiv1.ConditionReasonPrimaryUpdateWaitingForApproval),
+		Message: fmt.Sprintf(
+			"The primary instance needs to be updated (%s): issue a switchover or "+
+				"set the %s annotation to %q",
+			reason, utils.ApprovePrimaryUpdateAnnotationName, "enabled"),
+	})
+}
+
+// consumePrimaryUpdateApproval removes the approval annotation from the cluster,
+// so that the next update of the primary instance needs to be approved again
+func (r *ClusterReconciler) consumePrimaryUpdateApproval(ctx context.Context, cluster *apiv1.Cluster) error {
+	origCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, utils.ApprovePrimaryUpdateAnnotationName)
+	if err := r.Patch(ctx, cluster, client.MergeFrom(origCluster)); err != nil {
+		return fmt.Errorf("while removing the primary update approval: %w", err)
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionPrimaryUpdateApproved),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonPrimaryUpdateApproved),
+		Message: "The update of the primary instance has been approved",
+	})
+}
+
+// markPrimaryUpdateCompleted reports in the cluster status that the primary
+// instance doesn't need to be updated anymore, i.e. because the user issued
+// a switchover
+func (r *ClusterReconciler) markPrimaryUpdateCompleted(ctx context.Context, cluster *apiv1.Cluster) error {
+	// we only report the completion if we previously were waiting for an approval
+	if !meta.IsStatusConditionFalse(cluster.Status.Conditions, string(apiv1.ConditionPrimaryUpdateApproved)) {
+		return nil
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionPrimaryUpdateApproved),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonPrimaryUpdateApproved),
+		Message: "The primary instance has been updated",
+	})
+}
+
 func (r *ClusterReconciler) updateRestartAnnotation(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -275,9 +428,26 @@ type rollout struct {
 	needsChangeOperatorImage bool
 	needsChangeOperandImage  bool
 
+	// needsConfigurationRestart is true when PostgreSQL only
+	// needs a restart to apply some configuration changes
+	needsConfigurationRestart bool
+
 	reason string
 }
 
+// getPrimaryUpdateMethod gets the method to be used to apply
+// this rollout to the primary instance
+func (r rollout) getPrimaryUpdateMethod(cluster *apiv1.Cluster) apiv1.PrimaryUpdateMethod {
+	switch {
+	case r.needsChangeOperandImage:
+		return cluster.GetPrimaryUpdateMethodForImageChange()
+	case r.needsConfigurationRestart:
+		return cluster.GetPrimaryUpdateMethodForConfigurationChange()
+	default:
+		return cluster.GetPrimaryUpdateMethod()
+	}
+}
+
 type rolloutChecker func(
 	ctx context.Context,
 	pod *corev1.Pod,
@@ -310,9 +480,10 @@ func isInstanceNeedingRollout(
 
 	if status.PendingRestart {
 		return rollout{
-			required:     true,
-			reason:       "Postgres needs a restart to apply some configuration changes",
-			canBeInPlace: true,
+			required:                  true,
+			reason:                    "Postgres needs a restart to apply some configuration changes",
+			canBeInPlace:              true,
+			needsConfigurationRestart: true,
 		}
 	}
 
@@ -407,7 +578,7 @@ func hasValidPodSpec(pod *corev1.Pod) bool {
 }
 
 func checkPodNeedsUpdatedTopology(_ context.Context, pod *corev1.Pod, cluster *apiv1.Cluster) (rollout, error) {
-	if reflect.DeepEqual(cluster.Spec.TopologySpreadConstraints, pod.Spec.TopologySpreadConstraints) {
+	if reflect.DeepEqual(specs.CreateTopologySpreadConstraints(*cluster), pod.Spec.TopologySpreadConstraints) {
 		return rollout{}, nil
 	}
 
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8client "sigs.k8s.io/controller-runtime/pkg/client"
@@ -179,6 +180,7 @@ var _ = Describe("Pod upgrade", Ordered, func() {
 		Expect(rollout.reason).To(Equal("Postgres needs a restart to apply some configuration changes"))
 		Expect(rollout.needsChangeOperandImage).To(BeFalse())
 		Expect(rollout.needsChangeOperatorImage).To(BeFalse())
+		Expect(rollout.needsConfigurationRestart).To(BeTrue())
 	})
 
 	It("requires pod rollout if executable does not have a hash", func(ctx SpecContext) {
@@ -740,6 +742,180 @@ var _ = Describe("Test pod rollout due to topology", func() {
 	})
 })
 
+var _ = Describe("Primary update method of a rollout", func() {
+	cluster := &apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodRestart,
+			PrimaryUpdateMethodOverrides: &apiv1.PrimaryUpdateMethodOverrides{
+				Image: apiv1.PrimaryUpdateMethodSwitchover,
+			},
+		},
+	}
+
+	It("uses the method for image changes when the operand image changes", func() {
+		podRollout := rollout{required: true, needsChangeOperandImage: true}
+		Expect(podRollout.getPrimaryUpdateMethod(cluster)).To(Equal(apiv1.PrimaryUpdateMethodSwitchover))
+	})
+
+	It("uses the method for configuration changes when only a restart is needed", func() {
+		podRollout := rollout{required: true, canBeInPlace: true, needsConfigurationRestart: true}
+		Expect(podRollout.getPrimaryUpdateMethod(cluster)).To(Equal(apiv1.PrimaryUpdateMethodRestart))
+	})
+
+	It("uses the cluster-wide method for any other change", func() {
+		podRollout := rollout{required: true, needsChangeOperatorImage: true}
+		Expect(podRollout.getPrimaryUpdateMethod(cluster)).To(Equal(apiv1.PrimaryUpdateMethodRestart))
+	})
+})
+
+var _ = Describe("Replica update batch size", func() {
+	newPodList := func(names ...string) *postgres.PostgresqlStatusList {
+		podList := &postgres.PostgresqlStatusList{}
+		for _, name := range names {
+			podList.Items = append(podList.Items, postgres.PostgresqlStatus{
+				Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			})
+		}
+		return podList
+	}
+
+	podList := newPodList("cluster-example-1", "cluster-example-2", "cluster-example-3",
+		"cluster-example-4", "cluster-example-5")
+
+	newCluster := func(maxConcurrentReplicaUpdates int) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaxConcurrentReplicaUpdates: maxConcurrentReplicaUpdates,
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+			},
+		}
+	}
+
+	It("updates one replica at a time by default", func(ctx SpecContext) {
+		Expect(getReplicaUpdateBatchSize(ctx, newCluster(0), podList)).To(Equal(1))
+	})
+
+	It("updates up to the requested number of replicas", func(ctx SpecContext) {
+		Expect(getReplicaUpdateBatchSize(ctx, newCluster(3), podList)).To(Equal(3))
+		Expect(getReplicaUpdateBatchSize(ctx, newCluster(10), podList)).To(Equal(4))
+	})
+
+	It("keeps the required synchronous replicas online", func(ctx SpecContext) {
+		cluster := newCluster(4)
+		cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+			Number: 2,
+		}
+		Expect(getReplicaUpdateBatchSize(ctx, cluster, podList)).To(Equal(2))
+
+		cluster.Spec.PostgresConfiguration.Synchronous.Number = 4
+		Expect(getReplicaUpdateBatchSize(ctx, cluster, podList)).To(Equal(1))
+	})
+
+	It("doesn't count the fenced instances", func(ctx SpecContext) {
+		cluster := newCluster(4)
+		cluster.Annotations = map[string]string{
+			utils.FencedInstanceAnnotation: `["cluster-example-4","cluster-example-5"]`,
+		}
+		cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+			Number: 1,
+		}
+		Expect(getReplicaUpdateBatchSize(ctx, cluster, podList)).To(Equal(1))
+	})
+})
+
+var _ = Describe("Supervised primary update approval", func() {
+	var (
+		env     *testingEnvironment
+		cluster *apiv1.Cluster
+		pod     corev1.Pod
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		env = buildTestEnvironment()
+		cluster = newFakeCNPGCluster(env.client, newFakeNamespace(env.client), func(cluster *apiv1.Cluster) {
+			cluster.Spec.PrimaryUpdateStrategy = apiv1.PrimaryUpdateStrategySupervised
+			cluster.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodRestart
+		})
+		pod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name + "-1",
+				Namespace: cluster.Namespace,
+			},
+		}
+		Expect(env.client.Create(ctx, &pod)).To(Succeed())
+	})
+
+	updatePrimary := func(ctx context.Context) {
+		done, err := env.clusterReconciler.updatePrimaryPod(ctx, cluster, &postgres.PostgresqlStatusList{},
+			pod, apiv1.PrimaryUpdateMethodRestart, true, false, "new image")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(env.client.Get(ctx, k8client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+	}
+
+	It("waits for the user approval before updating the primary", func(ctx SpecContext) {
+		updatePrimary(ctx)
+
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForUser))
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionPrimaryUpdateApproved))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPrimaryUpdateWaitingForApproval)))
+	})
+
+	It("updates the primary once approved, consuming the approval", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{utils.ApprovePrimaryUpdateAnnotationName: "enabled"}
+		Expect(env.client.Update(ctx, cluster)).To(Succeed())
+
+		updatePrimary(ctx)
+
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseInplacePrimaryRestart))
+		Expect(cluster.Annotations).ToNot(HaveKey(utils.ApprovePrimaryUpdateAnnotationName))
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions,
+			string(apiv1.ConditionPrimaryUpdateApproved))).To(BeTrue())
+	})
+
+	It("keeps the approval when the update of the primary can't start", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{utils.ApprovePrimaryUpdateAnnotationName: "enabled"}
+		Expect(env.client.Update(ctx, cluster)).To(Succeed())
+		cluster.Status.Instances = 2
+
+		replica := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name + "-2",
+				Namespace: cluster.Namespace,
+			},
+		}
+		podList := &postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: &pod, IsPrimary: true, IsPodReady: true},
+				{Pod: &replica, IsPodReady: true, IsWalReceiverActive: false},
+			},
+		}
+
+		done, err := env.clusterReconciler.updatePrimaryPod(ctx, cluster, podList,
+			pod, apiv1.PrimaryUpdateMethodSwitchover, false, false, "new image")
+		Expect(err).To(MatchError(errLogShippingReplicaElected))
+		Expect(done).To(BeFalse())
+
+		Expect(env.client.Get(ctx, k8client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		Expect(cluster.Annotations).To(HaveKeyWithValue(utils.ApprovePrimaryUpdateAnnotationName, "enabled"))
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions,
+			string(apiv1.ConditionPrimaryUpdateApproved))).To(BeFalse())
+	})
+
+	It("reports the completion when the primary has been updated by a switchover", func(ctx SpecContext) {
+		updatePrimary(ctx)
+
+		Expect(env.clusterReconciler.markPrimaryUpdateCompleted(ctx, cluster)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions,
+			string(apiv1.ConditionPrimaryUpdateApproved))).To(BeTrue())
+	})
+})
+
 var _ = Describe("hasValidPodSpec", func() {
 	var pod *corev1.Pod
 
@@ -51,6 +51,10 @@ type PoolerReconciler struct {
 	DiscoveryClient discovery.DiscoveryInterface
 	Scheme          *runtime.Scheme
 	Recorder        record.EventRecorder
+
+	// ResyncInterval is the interval after which a pooler is reconciled
+	// again when nothing changed. Zero disables the periodic resync
+	ResyncInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=poolers,verbs=get;list;watch;create;update;patch;delete
@@ -126,7 +130,11 @@ func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Take the required actions to align the spec with the collected status
-	return ctrl.Result{}, r.updateOwnedObjects(ctx, &pooler, resources)
+	if err := r.updateOwnedObjects(ctx, &pooler, resources); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return requeueForResync(ctrl.Result{}, r.ResyncInterval), nil
 }
 
 // SetupWithManager setup this controller inside the controller manager
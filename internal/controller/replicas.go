@@ -28,10 +28,13 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -44,6 +47,11 @@ var ErrWalReceiversRunning = fmt.Errorf("wal receivers are still running")
 // elapsed yet
 var ErrWaitingOnFailOverDelay = fmt.Errorf("current primary isn't healthy, waiting for the delay before triggering a failover") //nolint: lll
 
+// ErrWaitingOnFailoverCooldown is raised when the primary server can't be elected because the
+// .spec.failover.cooldown since the last automated failover hasn't elapsed yet
+var ErrWaitingOnFailoverCooldown = fmt.Errorf(
+	"current primary isn't healthy, waiting for the failover cooldown to elapse before triggering a failover")
+
 // reconcileTargetPrimaryFromPods sets the name of the target primary from the Pods status if needed
 // this function will return the name of the new primary selected for promotion.
 // Returns the name of the primary if any changes was made and any error encountered.
@@ -96,8 +104,13 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 ) (string, error) {
 	contextLogger := log.FromContext(ctx)
 
-	mostAdvancedInstance := status.Items[0]
-	if cluster.Status.TargetPrimary == mostAdvancedInstance.Pod.Name {
+	if cluster.Status.TargetPrimary == status.Items[0].Pod.Name {
+		return "", nil
+	}
+
+	mostAdvancedInstance := getMostAdvancedFailoverCandidate(cluster, status)
+	if mostAdvancedInstance == nil {
+		contextLogger.Info("No instance is eligible for promotion, the failover is not possible")
 		return "", nil
 	}
 
@@ -127,6 +140,10 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 	// (if is still alive) to shut down by setting the apiv1.PendingFailoverMarker as
 	// target primary.
 	if cluster.Status.TargetPrimary == cluster.Status.CurrentPrimary {
+		if err := r.enforceFailoverCooldown(ctx, cluster); err != nil {
+			return "", err
+		}
+
 		contextLogger.Info("Current primary isn't healthy, initiating a failover")
 		status.LogStatus(ctx)
 		contextLogger.Debug("Cluster status before initiating the failover", "instances", resources.instances)
@@ -136,7 +153,7 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 			fmt.Sprintf("Initiating a failover from %v", cluster.Status.CurrentPrimary)); err != nil {
 			return "", err
 		}
-		err := r.setPrimaryInstance(ctx, cluster, apiv1.PendingFailoverMarker)
+		err := r.setFailoverPrimaryInstance(ctx, cluster, apiv1.PendingFailoverMarker)
 		if err != nil {
 			return "", err
 		}
@@ -198,6 +215,10 @@ func (r *ClusterReconciler) isNodeUnschedulableOrBeingDrained(
 	ctx context.Context,
 	nodeName string,
 ) (bool, error) {
+	if !utils.HaveClusterScopedPermission(utils.NodesPermission) {
+		return false, nil
+	}
+
 	var node corev1.Node
 	err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
 	if err != nil {
@@ -239,6 +260,20 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 		return "", nil
 	}
 
+	// When the replicas need to be drained first, we wait for the ones running
+	// on nodes being drained to be rescheduled before switching over
+	if cluster.GetDrainOrder() == apiv1.DrainOrderReplicasFirst {
+		replicasOnDrainingNodes := r.getPodsOnNodesBeingDrained(ctx, podsOnOtherNodes)
+		if len(replicasOnDrainingNodes) > 0 {
+			contextLogger.Info("Current primary is running on unschedulable node, "+
+				"waiting for the replicas on nodes being drained to be rescheduled first",
+				"currentPrimary", primaryPod.Pod.Name,
+				"primaryNode", primaryPod.Node,
+				"replicasOnDrainingNodes", replicasOnDrainingNodes)
+			return "", nil
+		}
+	}
+
 	// In case we have failed pods, we try to do a switchover, because pods could be in this state
 	// (e.g. Pending) because something is preventing pods to be scheduled successfully, e.g. draining the primary node
 	// while a maintenance window is in progress and reusePVC is set to false, in this case a replica would be terminated
@@ -256,6 +291,11 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 			continue
 		}
 
+		// If the candidate has been excluded from the failover, skip it
+		if !cluster.IsFailoverCandidate(candidate.Pod.Name) {
+			continue
+		}
+
 		// If the candidate has not established a connection to the current primary, skip it
 		if !candidate.IsWalReceiverActive {
 			continue
@@ -288,6 +328,30 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 	return "", nil
 }
 
+// getPodsOnNodesBeingDrained returns the names of the passed Pods
+// running on a node being drained
+func (r *ClusterReconciler) getPodsOnNodesBeingDrained(
+	ctx context.Context,
+	status postgres.PostgresqlStatusList,
+) []string {
+	contextLogger := log.FromContext(ctx)
+
+	var result []string
+	for _, item := range status.Items {
+		beingDrained, err := r.isNodeUnschedulableOrBeingDrained(ctx, item.Node)
+		if err != nil {
+			contextLogger.Error(err, "while checking if the node of an instance is being drained",
+				"pod", item.Pod.Name, "node", item.Node)
+			continue
+		}
+		if beingDrained {
+			result = append(result, item.Pod.Name)
+		}
+	}
+
+	return result
+}
+
 // reconcileTargetPrimaryForReplicaCluster sets the name of the target designated
 // primary from the Pods status if needed this function will return the name of the
 // new primary selected for promotion
@@ -313,6 +377,10 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForReplicaCluster(
 		return "", err
 	}
 
+	if err := r.enforceFailoverCooldown(ctx, cluster); err != nil {
+		return "", err
+	}
+
 	// The designated primary is not correctly working, and we need to elect a new one
 	// but before doing that we need to wait for all the WAL receivers to be
 	// terminated. This is needed to avoid losing the WAL data that is being received
@@ -321,19 +389,41 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForReplicaCluster(
 		return "", ErrWalReceiversRunning
 	}
 
+	newPrimary := getMostAdvancedFailoverCandidate(cluster, status)
+	if newPrimary == nil {
+		contextLogger.Info("No instance is eligible for promotion, the failover is not possible")
+		return "", nil
+	}
+
 	contextLogger.Info("Current target primary isn't healthy, failing over",
-		"newPrimary", status.Items[0].Pod.Name)
+		"newPrimary", newPrimary.Pod.Name)
 	status.LogStatus(ctx)
 	contextLogger.Debug("Cluster status before failover", "instances", resources.instances)
 	r.Recorder.Eventf(cluster, "Normal", "FailingOver",
 		"Current target primary isn't healthy, failing over from %v to %v",
-		cluster.Status.TargetPrimary, status.Items[0].Pod.Name)
+		cluster.Status.TargetPrimary, newPrimary.Pod.Name)
 	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseFailOver,
-		fmt.Sprintf("Failing over to %v", status.Items[0].Pod.Name)); err != nil {
+		fmt.Sprintf("Failing over to %v", newPrimary.Pod.Name)); err != nil {
 		return "", err
 	}
 
-	return status.Items[0].Pod.Name, r.setPrimaryInstance(ctx, cluster, status.Items[0].Pod.Name)
+	return newPrimary.Pod.Name, r.setFailoverPrimaryInstance(ctx, cluster, newPrimary.Pod.Name)
+}
+
+// getMostAdvancedFailoverCandidate returns the first instance of the sorted
+// list that can be promoted, skipping the ones excluded from the failover,
+// or nil if there is none
+func getMostAdvancedFailoverCandidate(
+	cluster *apiv1.Cluster,
+	status postgres.PostgresqlStatusList,
+) *postgres.PostgresqlStatus {
+	for idx := range status.Items {
+		if cluster.IsFailoverCandidate(status.Items[idx].Pod.Name) {
+			return &status.Items[idx]
+		}
+	}
+
+	return nil
 }
 
 // GetPodsNotOnPrimaryNode filters out only pods that are not on the same node as the primary one
@@ -405,6 +495,60 @@ func (r *ClusterReconciler) evaluateFailoverDelay(
 	return nil
 }
 
+// enforceFailoverCooldown prevents an automated failover until the failover
+// cooldown has elapsed since the last one, reporting it in the conditions of
+// the cluster. It returns ErrWaitingOnFailoverCooldown while the failover is
+// being prevented.
+func (r *ClusterReconciler) enforceFailoverCooldown(ctx context.Context, cluster *apiv1.Cluster) error {
+	cooldown := cluster.GetFailoverCooldown()
+	if cooldown == 0 || cluster.Status.LastFailoverTimestamp == "" {
+		return nil
+	}
+
+	sinceLastFailover, err := pgTime.DifferenceBetweenTimestamps(
+		pgTime.GetCurrentTimestamp(),
+		cluster.Status.LastFailoverTimestamp,
+	)
+	if err != nil {
+		return err
+	}
+
+	if sinceLastFailover >= cooldown {
+		return r.resetFailoverCooldownCondition(ctx, cluster)
+	}
+
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionFailoverCooldown)) {
+		log.FromContext(ctx).Info("Current primary isn't healthy, but the failover cooldown hasn't elapsed yet",
+			"lastFailover", cluster.Status.LastFailoverTimestamp, "cooldown", cooldown)
+		if err := status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+			Type:   string(apiv1.ConditionFailoverCooldown),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonFailoverPrevented),
+			Message: fmt.Sprintf("An automated failover is prevented until %v have elapsed since the last one (%s)",
+				cooldown, cluster.Status.LastFailoverTimestamp),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return ErrWaitingOnFailoverCooldown
+}
+
+// resetFailoverCooldownCondition reports that the failover cooldown is not
+// preventing any automated failover, if it was doing it before
+func (r *ClusterReconciler) resetFailoverCooldownCondition(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionFailoverCooldown)) {
+		return nil
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionFailoverCooldown),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonFailoverAllowed),
+		Message: "The failover cooldown is not preventing any automated failover",
+	})
+}
+
 // findDeletableInstance get the Pod who is supposed to be deleted when the cluster is scaled down
 func findDeletableInstance(cluster *apiv1.Cluster, instances []corev1.Pod) string {
 	resultIdx := -1
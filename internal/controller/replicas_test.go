@@ -20,10 +20,17 @@ SPDX-License-Identifier: Apache-2.0
 package controller
 
 import (
+	"time"
+
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
@@ -154,3 +161,150 @@ var _ = Describe("Check pods not on primary node", func() {
 		Expect(GetPodsNotOnPrimaryNode(statusList2, &statusList2.Items[0]).Items).ToNot(BeEmpty())
 	})
 })
+
+var _ = Describe("Pods on nodes being drained", func() {
+	const drainTaint = "node.kubernetes.io/drain"
+
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-3"},
+			Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{{Key: drainTaint, Effect: corev1.TaintEffectNoSchedule}},
+			},
+		},
+	}
+
+	newStatus := func(podName, nodeName string) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Node: nodeName,
+			Pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName}},
+		}
+	}
+
+	It("detects the Pods running on unschedulable or tainted nodes", func(ctx SpecContext) {
+		r := &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(&nodes[0], &nodes[1], &nodes[2]).
+				Build(),
+			drainTaints: []string{drainTaint},
+		}
+
+		status := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("pod-1", "node-1"),
+				newStatus("pod-2", "node-2"),
+				newStatus("pod-3", "node-3"),
+				newStatus("pod-4", "node-missing"),
+			},
+		}
+		Expect(r.getPodsOnNodesBeingDrained(ctx, status)).To(Equal([]string{"pod-2", "pod-3"}))
+	})
+})
+
+var _ = Describe("Failover cooldown", func() {
+	var (
+		env     *testingEnvironment
+		cluster *apiv1.Cluster
+	)
+
+	newCluster := func(lastFailover time.Time) *apiv1.Cluster {
+		return newFakeCNPGCluster(env.client, newFakeNamespace(env.client), func(cluster *apiv1.Cluster) {
+			cluster.Spec.Failover = &apiv1.FailoverConfiguration{
+				Cooldown: &metav1.Duration{Duration: 10 * time.Minute},
+			}
+			cluster.Status.LastFailoverTimestamp = lastFailover.Format(metav1.RFC3339Micro)
+		})
+	}
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+	})
+
+	It("does nothing when no cooldown has been configured", func(ctx SpecContext) {
+		cluster = newFakeCNPGCluster(env.client, newFakeNamespace(env.client), func(cluster *apiv1.Cluster) {
+			cluster.Status.LastFailoverTimestamp = pgTime.GetCurrentTimestamp()
+		})
+		Expect(env.clusterReconciler.enforceFailoverCooldown(ctx, cluster)).To(Succeed())
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionFailoverCooldown))).To(BeNil())
+	})
+
+	It("prevents a failover while the cooldown is active", func(ctx SpecContext) {
+		cluster = newCluster(time.Now().Add(-time.Minute))
+		Expect(env.clusterReconciler.enforceFailoverCooldown(ctx, cluster)).
+			To(MatchError(ErrWaitingOnFailoverCooldown))
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionFailoverCooldown))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonFailoverPrevented)))
+	})
+
+	It("allows the failover once the cooldown has elapsed", func(ctx SpecContext) {
+		cluster = newCluster(time.Now().Add(-time.Minute))
+		Expect(env.clusterReconciler.enforceFailoverCooldown(ctx, cluster)).
+			To(MatchError(ErrWaitingOnFailoverCooldown))
+
+		cluster.Status.LastFailoverTimestamp = time.Now().Add(-time.Hour).Format(metav1.RFC3339Micro)
+		Expect(env.clusterReconciler.enforceFailoverCooldown(ctx, cluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionFailoverCooldown))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonFailoverAllowed)))
+	})
+})
+
+var _ = Describe("Failover candidates", func() {
+	newStatus := func(names ...string) postgres.PostgresqlStatusList {
+		var result postgres.PostgresqlStatusList
+		for _, name := range names {
+			result.Items = append(result.Items, postgres.PostgresqlStatus{
+				Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			})
+		}
+		return result
+	}
+
+	It("chooses the most advanced instance by default", func() {
+		candidate := getMostAdvancedFailoverCandidate(&apiv1.Cluster{}, newStatus("pod-2", "pod-3"))
+		Expect(candidate).ToNot(BeNil())
+		Expect(candidate.Pod.Name).To(Equal("pod-2"))
+	})
+
+	It("skips the instances excluded from the failover", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Failover: &apiv1.FailoverConfiguration{ExcludedInstances: []string{"pod-2"}},
+			},
+		}
+		candidate := getMostAdvancedFailoverCandidate(cluster, newStatus("pod-2", "pod-3"))
+		Expect(candidate).ToNot(BeNil())
+		Expect(candidate.Pod.Name).To(Equal("pod-3"))
+
+		Expect(getMostAdvancedFailoverCandidate(cluster, newStatus("pod-2"))).To(BeNil())
+	})
+
+	It("never switches over to an excluded instance", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Failover: &apiv1.FailoverConfiguration{ExcludedInstances: []string{"pod-2"}},
+			},
+		}
+		status := newStatus("pod-1", "pod-2", "pod-3")
+		target := getSwitchoverTarget(cluster, &status, "pod-1")
+		Expect(target).ToNot(BeNil())
+		Expect(target.Pod.Name).To(Equal("pod-3"))
+
+		status = newStatus("pod-1", "pod-2")
+		Expect(getSwitchoverTarget(cluster, &status, "pod-1")).To(BeNil())
+	})
+})
@@ -0,0 +1,46 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// requeueForResync schedules a new reconciliation after the passed resync
+// interval, unless the passed result already requires an earlier one.
+// A non-positive interval disables the periodic resync.
+func requeueForResync(result ctrl.Result, interval time.Duration) ctrl.Result {
+	if interval <= 0 {
+		return result
+	}
+
+	// A non-zero result without a delay is already requeued immediately
+	if !result.IsZero() && result.RequeueAfter == 0 {
+		return result
+	}
+
+	if result.RequeueAfter == 0 || interval < result.RequeueAfter {
+		result.RequeueAfter = interval
+	}
+
+	return result
+}
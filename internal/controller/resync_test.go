@@ -0,0 +1,50 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("requeueForResync", func() {
+	It("doesn't change the result when the resync is disabled", func() {
+		Expect(requeueForResync(ctrl.Result{}, 0)).To(Equal(ctrl.Result{}))
+		Expect(requeueForResync(ctrl.Result{RequeueAfter: time.Minute}, -time.Second)).
+			To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+	})
+
+	It("requeues a successful reconciliation after the interval", func() {
+		Expect(requeueForResync(ctrl.Result{}, time.Hour)).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+	})
+
+	It("keeps the earliest requeue", func() {
+		Expect(requeueForResync(ctrl.Result{RequeueAfter: time.Minute}, time.Hour)).
+			To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+		Expect(requeueForResync(ctrl.Result{RequeueAfter: 2 * time.Hour}, time.Hour)).
+			To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+		Expect(requeueForResync(ctrl.Result{Requeue: true}, time.Hour)).
+			To(Equal(ctrl.Result{Requeue: true}))
+	})
+})
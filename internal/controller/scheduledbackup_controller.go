@@ -35,8 +35,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -123,6 +128,15 @@ func (r *ScheduledBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	result, err := r.reconcileBackupFailures(ctx, &scheduledBackup)
+	if err != nil {
+		contextLogger.Error(err, "Cannot check the outcome of the last backup")
+		return ctrl.Result{}, err
+	}
+	if result != nil {
+		return *result, nil
+	}
+
 	return ReconcileScheduledBackup(ctx, r.Recorder, r.Client, &scheduledBackup)
 }
 
@@ -191,7 +205,7 @@ func ReconcileScheduledBackup(
 	if scheduledBackup.Status.LastCheckTime == nil && scheduledBackup.IsImmediate() {
 		// we populate the status (lastCheckTime...) by following the same rules of the scheduled backup
 		event.Eventf(scheduledBackup, "Normal", "BackupSchedule", "Scheduled immediate backup now: %v", now)
-		return createBackup(ctx, event, cli, scheduledBackup, now, now, schedule, true)
+		return createBackup(ctx, event, cli, scheduledBackup, now, now, schedule, true, false)
 	}
 
 	if scheduledBackup.Status.LastCheckTime == nil {
@@ -222,10 +236,11 @@ func ReconcileScheduledBackup(
 		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
 	}
 
-	return createBackup(ctx, event, cli, scheduledBackup, nextTime, now, schedule, false)
+	return createBackup(ctx, event, cli, scheduledBackup, nextTime, now, schedule, false, false)
 }
 
-// createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly
+// createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly.
+// When retry is true, the backup replaces the last one, which failed
 func createBackup(
 	ctx context.Context,
 	event record.EventRecorder,
@@ -235,6 +250,7 @@ func createBackup(
 	now time.Time,
 	schedule cron.Schedule,
 	immediate bool,
+	retry bool,
 ) (ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
@@ -253,9 +269,8 @@ func createBackup(
 	metadata.Labels[utils.ImmediateBackupLabelName] = strconv.FormatBool(immediate)
 	metadata.Labels[utils.ParentScheduledBackupLabelName] = scheduledBackup.GetName()
 
-	switch scheduledBackup.Spec.BackupOwnerReference {
-	case "cluster":
-		var cluster apiv1.Cluster
+	var cluster apiv1.Cluster
+	if scheduledBackup.Spec.BackupOwnerReference == "cluster" || scheduledBackup.ShouldInheritClusterMetadata() {
 		if err := cli.Get(
 			ctx,
 			types.NamespacedName{Name: scheduledBackup.Spec.Cluster.Name, Namespace: scheduledBackup.Namespace},
@@ -263,6 +278,14 @@ func createBackup(
 		); err != nil {
 			return ctrl.Result{}, err
 		}
+	}
+
+	if scheduledBackup.ShouldInheritClusterMetadata() {
+		cluster.SetInheritedData(&backup.ObjectMeta)
+	}
+
+	switch scheduledBackup.Spec.BackupOwnerReference {
+	case "cluster":
 		cluster.SetInheritedDataAndOwnership(&backup.ObjectMeta)
 	case "self":
 		utils.SetAsOwnedBy(&backup.ObjectMeta, scheduledBackup.ObjectMeta, scheduledBackup.TypeMeta)
@@ -297,6 +320,11 @@ func createBackup(
 	scheduledBackup.Status.NextScheduleTime = &metav1.Time{
 		Time: nextBackupTime,
 	}
+	if retry {
+		scheduledBackup.Status.Retries++
+	} else {
+		scheduledBackup.Status.Retries = 0
+	}
 
 	if err := cli.Status().Patch(ctx, scheduledBackup, client.MergeFrom(origScheduled)); err != nil {
 		if apierrs.IsConflict(err) {
@@ -363,6 +391,52 @@ func (r *ScheduledBackupReconciler) SetupWithManager(
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
 		For(&apiv1.ScheduledBackup{}).
+		Watches(
+			&apiv1.Backup{},
+			handler.EnqueueRequestsFromMapFunc(r.mapBackupToScheduledBackup),
+			builder.WithPredicates(scheduledBackupsDonePredicate),
+		).
 		Named("scheduled-backup").
 		Complete(r)
 }
+
+// scheduledBackupsDonePredicate filters the backups created by a scheduled
+// backup which just completed or failed
+var scheduledBackupsDonePredicate = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return false
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldBackup, oldOk := e.ObjectOld.(*apiv1.Backup)
+		newBackup, newOk := e.ObjectNew.(*apiv1.Backup)
+		if !oldOk || !newOk {
+			return false
+		}
+		if _, ok := newBackup.Labels[utils.ParentScheduledBackupLabelName]; !ok {
+			return false
+		}
+		return !oldBackup.Status.IsDone() && newBackup.Status.IsDone()
+	},
+}
+
+// mapBackupToScheduledBackup returns the scheduled backup which created
+// the passed backup, if any
+func (r *ScheduledBackupReconciler) mapBackupToScheduledBackup(
+	_ context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	name, ok := obj.GetLabels()[utils.ParentScheduledBackupLabelName]
+	if !ok || name == "" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}},
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileBackupFailures records in the status of the scheduled backup the
+// outcome of the last backup it created, and retries it according to the
+// failure policy when it failed. A non-nil result is returned when the
+// reconciliation loop has to stop here
+func (r *ScheduledBackupReconciler) reconcileBackupFailures(
+	ctx context.Context,
+	scheduledBackup *apiv1.ScheduledBackup,
+) (*ctrl.Result, error) {
+	lastBackup, err := r.getLastChildBackup(ctx, scheduledBackup)
+	if err != nil {
+		return nil, err
+	}
+	if lastBackup == nil || !lastBackup.Status.IsDone() {
+		return nil, nil
+	}
+
+	if lastBackup.Name != scheduledBackup.Status.LastBackupName {
+		if err := r.recordBackupOutcome(ctx, scheduledBackup, lastBackup); err != nil {
+			return nil, err
+		}
+	}
+
+	if lastBackup.Status.Phase != apiv1.BackupPhaseFailed {
+		return nil, nil
+	}
+
+	return r.retryFailedBackup(ctx, scheduledBackup, time.Now())
+}
+
+// getLastChildBackup gets the last backup created by the scheduled backup,
+// if any
+func (r *ScheduledBackupReconciler) getLastChildBackup(
+	ctx context.Context,
+	scheduledBackup *apiv1.ScheduledBackup,
+) (*apiv1.Backup, error) {
+	var backups apiv1.BackupList
+	if err := r.List(ctx, &backups,
+		client.InNamespace(scheduledBackup.Namespace),
+		client.MatchingLabels{utils.ParentScheduledBackupLabelName: scheduledBackup.Name},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the backups created by the scheduled backup: %w", err)
+	}
+	if len(backups.Items) == 0 {
+		return nil, nil
+	}
+
+	lastBackup := slices.MaxFunc(backups.Items, func(a, b apiv1.Backup) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return &lastBackup, nil
+}
+
+// recordBackupOutcome updates the count of the consecutive failed backups
+// with the outcome of the passed backup, reporting the scheduled backup as
+// failing when the failure threshold is reached
+func (r *ScheduledBackupReconciler) recordBackupOutcome(
+	ctx context.Context,
+	scheduledBackup *apiv1.ScheduledBackup,
+	backup *apiv1.Backup,
+) error {
+	origScheduled := scheduledBackup.DeepCopy()
+	scheduledBackupStatus := &scheduledBackup.Status
+	scheduledBackupStatus.LastBackupName = backup.Name
+
+	outcomeTime := metav1.Now()
+	if backup.Status.StoppedAt != nil {
+		outcomeTime = *backup.Status.StoppedAt
+	}
+
+	if backup.Status.Phase == apiv1.BackupPhaseCompleted {
+		scheduledBackupStatus.ConsecutiveFailures = 0
+		scheduledBackupStatus.Retries = 0
+		scheduledBackupStatus.LastSuccessfulBackupTime = &outcomeTime
+	} else {
+		scheduledBackupStatus.ConsecutiveFailures++
+		scheduledBackupStatus.LastFailedBackupTime = &outcomeTime
+	}
+
+	if err := r.Status().Patch(ctx, scheduledBackup, client.MergeFrom(origScheduled)); err != nil {
+		return err
+	}
+
+	if backup.Status.Phase == apiv1.BackupPhaseFailed && scheduledBackup.IsFailing() {
+		log.FromContext(ctx).Warning("Too many consecutive failed backups",
+			"consecutiveFailures", scheduledBackupStatus.ConsecutiveFailures,
+			"backupName", backup.Name)
+		r.Recorder.Eventf(scheduledBackup, "Warning", "ConsecutiveBackupFailures",
+			"%d consecutive backups failed, the last one being %s: %s",
+			scheduledBackupStatus.ConsecutiveFailures, backup.Name, backup.Status.Error)
+	}
+
+	return r.reconcileScheduledBackupsCondition(ctx, scheduledBackup)
+}
+
+// retryFailedBackup creates a new backup in place of the failed one, after
+// the backoff time, unless the maximum number of retries has been reached
+// or the next scheduled backup comes first
+func (r *ScheduledBackupReconciler) retryFailedBackup(
+	ctx context.Context,
+	scheduledBackup *apiv1.ScheduledBackup,
+	now time.Time,
+) (*ctrl.Result, error) {
+	scheduledBackupStatus := &scheduledBackup.Status
+	if scheduledBackupStatus.Retries >= scheduledBackup.GetMaxRetries() ||
+		scheduledBackupStatus.LastCheckTime == nil || scheduledBackupStatus.LastFailedBackupTime == nil {
+		return nil, nil
+	}
+
+	schedule, err := cron.Parse(scheduledBackup.GetSchedule())
+	if err != nil {
+		return nil, err
+	}
+
+	nextScheduleTime := schedule.Next(scheduledBackupStatus.LastCheckTime.Time)
+	retryTime := scheduledBackupStatus.LastFailedBackupTime.Add(
+		scheduledBackup.GetRetryBackoff(scheduledBackupStatus.Retries))
+	if !retryTime.Before(nextScheduleTime) || !now.Before(nextScheduleTime) {
+		// The next scheduled backup takes the place of the retry
+		return nil, nil
+	}
+
+	if now.Before(retryTime) {
+		return &ctrl.Result{RequeueAfter: retryTime.Sub(now)}, nil
+	}
+
+	r.Recorder.Eventf(scheduledBackup, "Normal", "BackupRetry",
+		"Retrying the failed backup %s (retry %d of %d)", scheduledBackupStatus.LastBackupName,
+		scheduledBackupStatus.Retries+1, scheduledBackup.GetMaxRetries())
+	result, err := createBackup(ctx, r.Recorder, r.Client, scheduledBackup, now, now, schedule, false, true)
+	return &result, err
+}
+
+// reconcileScheduledBackupsCondition reports in the status of the cluster the
+// scheduled backups which reached their threshold of consecutive failed
+// backups. The condition is reset once none of them is failing.
+func (r *ScheduledBackupReconciler) reconcileScheduledBackupsCondition(
+	ctx context.Context,
+	scheduledBackup *apiv1.ScheduledBackup,
+) error {
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: scheduledBackup.Namespace,
+		Name:      scheduledBackup.Spec.Cluster.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	var scheduledBackups apiv1.ScheduledBackupList
+	if err := r.List(ctx, &scheduledBackups, client.InNamespace(scheduledBackup.Namespace)); err != nil {
+		return err
+	}
+
+	failing := getFailingScheduledBackups(scheduledBackups.Items, cluster.Name, scheduledBackup)
+	currentCondition := meta.FindStatusCondition(cluster.Status.Conditions,
+		string(apiv1.ConditionScheduledBackupsSucceeding))
+
+	if len(failing) == 0 {
+		if currentCondition == nil || currentCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		return status.PatchConditionsWithOptimisticLock(ctx, r.Client, &cluster, metav1.Condition{
+			Type:    string(apiv1.ConditionScheduledBackupsSucceeding),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonScheduledBackupsSucceeding),
+			Message: "No scheduled backup is failing",
+		})
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, &cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionScheduledBackupsSucceeding),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonScheduledBackupsFailing),
+		Message: "Scheduled backups with too many consecutive failures: " + strings.Join(failing, ", "),
+	})
+}
+
+// getFailingScheduledBackups returns, sorted by name, the scheduled backups of
+// the passed cluster which reached their threshold of consecutive failures.
+// The passed updated scheduled backup takes the place of its cached copy
+func getFailingScheduledBackups(
+	scheduledBackups []apiv1.ScheduledBackup,
+	clusterName string,
+	updated *apiv1.ScheduledBackup,
+) []string {
+	var result []string
+	for idx := range scheduledBackups {
+		scheduledBackup := &scheduledBackups[idx]
+		if scheduledBackup.Name == updated.Name {
+			scheduledBackup = updated
+		}
+
+		if scheduledBackup.Spec.Cluster.Name == clusterName && scheduledBackup.IsFailing() {
+			result = append(result, scheduledBackup.Name)
+		}
+	}
+
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,194 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduled backups failures", func() {
+	const namespace = "default"
+
+	var (
+		cli             client.Client
+		recorder        *record.FakeRecorder
+		reconciler      *ScheduledBackupReconciler
+		cluster         *apiv1.Cluster
+		scheduledBackup *apiv1.ScheduledBackup
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace},
+		}
+		scheduledBackup = &apiv1.ScheduledBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: namespace},
+			Spec: apiv1.ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				Cluster:  apiv1.LocalObjectReference{Name: cluster.Name},
+				FailurePolicy: &apiv1.ScheduledBackupFailurePolicy{
+					MaxRetries:       2,
+					FailureThreshold: 2,
+				},
+			},
+		}
+		cli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.ScheduledBackup{}, &apiv1.Backup{}).
+			WithObjects(cluster, scheduledBackup).
+			Build()
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &ScheduledBackupReconciler{Client: cli, Recorder: recorder}
+	})
+
+	newBackup := func(name string, phase apiv1.BackupPhase, stoppedAt time.Time) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{utils.ParentScheduledBackupLabelName: scheduledBackup.Name},
+			},
+			Spec: apiv1.BackupSpec{Cluster: apiv1.LocalObjectReference{Name: cluster.Name}},
+			Status: apiv1.BackupStatus{
+				Phase:     phase,
+				StoppedAt: ptr.To(metav1.NewTime(stoppedAt)),
+				Error:     "connection refused",
+			},
+		}
+	}
+
+	getCondition := func(ctx SpecContext) *metav1.Condition {
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+		return meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionScheduledBackupsSucceeding))
+	}
+
+	It("counts the consecutive failures and reports the failing scheduled backups", func(ctx SpecContext) {
+		stoppedAt := time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC)
+
+		Expect(reconciler.recordBackupOutcome(ctx, scheduledBackup,
+			newBackup("daily-1", apiv1.BackupPhaseFailed, stoppedAt))).To(Succeed())
+		Expect(scheduledBackup.Status.ConsecutiveFailures).To(Equal(1))
+		Expect(scheduledBackup.Status.LastBackupName).To(Equal("daily-1"))
+		Expect(scheduledBackup.Status.LastFailedBackupTime.Time).To(BeTemporally("==", stoppedAt))
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(getCondition(ctx)).To(BeNil())
+
+		Expect(reconciler.recordBackupOutcome(ctx, scheduledBackup,
+			newBackup("daily-2", apiv1.BackupPhaseFailed, stoppedAt))).To(Succeed())
+		Expect(scheduledBackup.Status.ConsecutiveFailures).To(Equal(2))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConsecutiveBackupFailures")))
+		condition := getCondition(ctx)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonScheduledBackupsFailing)))
+		Expect(condition.Message).To(ContainSubstring("daily"))
+
+		Expect(reconciler.recordBackupOutcome(ctx, scheduledBackup,
+			newBackup("daily-3", apiv1.BackupPhaseCompleted, stoppedAt))).To(Succeed())
+		Expect(scheduledBackup.Status.ConsecutiveFailures).To(BeZero())
+		Expect(scheduledBackup.Status.LastSuccessfulBackupTime.Time).To(BeTemporally("==", stoppedAt))
+		condition = getCondition(ctx)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonScheduledBackupsSucceeding)))
+	})
+
+	It("retries a failed backup after the backoff time", func(ctx SpecContext) {
+		lastCheck := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		failedAt := lastCheck.Add(10 * time.Minute)
+		scheduledBackup.Status.LastCheckTime = ptr.To(metav1.NewTime(lastCheck))
+		scheduledBackup.Status.LastBackupName = "daily-1"
+		scheduledBackup.Status.LastFailedBackupTime = ptr.To(metav1.NewTime(failedAt))
+
+		result, err := reconciler.retryFailedBackup(ctx, scheduledBackup, failedAt.Add(time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).To(Equal(4 * time.Minute))
+
+		retryTime := failedAt.Add(apiv1.DefaultScheduledBackupRetryBackoff)
+		result, err = reconciler.retryFailedBackup(ctx, scheduledBackup, retryTime)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("BackupRetry")))
+		Expect(scheduledBackup.Status.Retries).To(Equal(1))
+
+		var backups apiv1.BackupList
+		Expect(cli.List(ctx, &backups, client.InNamespace(namespace))).To(Succeed())
+		Expect(backups.Items).To(HaveLen(1))
+		Expect(backups.Items[0].Labels).To(
+			HaveKeyWithValue(utils.ParentScheduledBackupLabelName, scheduledBackup.Name))
+	})
+
+	It("doesn't retry a failed backup when the retries have been exhausted", func(ctx SpecContext) {
+		lastCheck := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		scheduledBackup.Status.LastCheckTime = ptr.To(metav1.NewTime(lastCheck))
+		scheduledBackup.Status.LastFailedBackupTime = ptr.To(metav1.NewTime(lastCheck))
+		scheduledBackup.Status.Retries = 2
+
+		result, err := reconciler.retryFailedBackup(ctx, scheduledBackup, lastCheck.Add(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("leaves the place of the retry to the next scheduled backup", func(ctx SpecContext) {
+		lastCheck := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		scheduledBackup.Status.LastCheckTime = ptr.To(metav1.NewTime(lastCheck))
+		scheduledBackup.Status.LastFailedBackupTime = ptr.To(metav1.NewTime(lastCheck.Add(23*time.Hour + 58*time.Minute)))
+
+		result, err := reconciler.retryFailedBackup(ctx, scheduledBackup, lastCheck.Add(23*time.Hour+59*time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("gets the failing scheduled backups of a cluster", func() {
+		newScheduledBackup := func(name, clusterName string, consecutiveFailures int) apiv1.ScheduledBackup {
+			return apiv1.ScheduledBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       apiv1.ScheduledBackupSpec{Cluster: apiv1.LocalObjectReference{Name: clusterName}},
+				Status:     apiv1.ScheduledBackupStatus{ConsecutiveFailures: consecutiveFailures},
+			}
+		}
+
+		updated := newScheduledBackup("hourly", "cluster-example", 0)
+		items := []apiv1.ScheduledBackup{
+			newScheduledBackup("weekly", "cluster-example", 5),
+			newScheduledBackup("hourly", "cluster-example", 3),
+			newScheduledBackup("daily", "cluster-example", 3),
+			newScheduledBackup("monthly", "cluster-example", 1),
+			newScheduledBackup("other", "another-cluster", 4),
+		}
+
+		Expect(getFailingScheduledBackups(items, "cluster-example", &updated)).To(Equal([]string{"daily", "weekly"}))
+	})
+})
@@ -0,0 +1,138 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// isAnalyzeNeeded tells whether the statistics of the databases need to be
+// collected, according to the StatisticsUpToDate condition set by the
+// operator. A running analyze is needed too, as the instance manager could
+// have been restarted while it was in progress
+func isAnalyzeNeeded(cluster *apiv1.Cluster) bool {
+	if !cluster.IsAutomaticAnalyzeEnabled() {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionStatisticsUpToDate))
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		return false
+	}
+
+	return condition.Reason == string(apiv1.ConditionReasonAnalyzePending) ||
+		condition.Reason == string(apiv1.ConditionReasonAnalyzeRunning)
+}
+
+// reconcileAutomaticAnalyze collects, on the primary, the statistics of every
+// database after a major version upgrade or a recovery, as requested by the
+// operator. The statistics are collected in background, as they may take
+// a long time on big databases, and the progress is reported in the
+// StatisticsUpToDate condition. A replica cluster can't run ANALYZE, and
+// receives the statistics from its source: a pending request is cleared
+func (r *InstanceReconciler) reconcileAutomaticAnalyze(ctx context.Context, cluster *apiv1.Cluster) {
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return
+	}
+
+	if !isAnalyzeNeeded(cluster) {
+		return
+	}
+
+	if cluster.IsReplica() {
+		if err := status.PatchConditionsWithOptimisticLock(ctx, r.client, cluster, metav1.Condition{
+			Type:    string(apiv1.ConditionStatisticsUpToDate),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonAnalyzeNotNeeded),
+			Message: "The statistics are replicated from the source cluster",
+		}); err != nil {
+			contextLogger.Error(err, "while clearing the automatic analyze request of the replica cluster")
+		}
+		return
+	}
+
+	if !r.analyzeRunning.CompareAndSwap(false, true) {
+		return
+	}
+
+	if err := status.PatchConditionsWithOptimisticLock(ctx, r.client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionStatisticsUpToDate),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonAnalyzeRunning),
+		Message: "Collecting the statistics of the databases",
+	}); err != nil {
+		contextLogger.Error(err, "while reporting the start of the automatic analyze")
+		// Let the next reconciliation loop start it
+		r.analyzeRunning.Store(false)
+		return
+	}
+
+	// The cluster is passed to the goroutine as a copy, as the reconciliation
+	// loop keeps using its own one
+	inStages := cluster.IsAutomaticAnalyzeInStages()
+	analyzedCluster := cluster.DeepCopy()
+	go func() {
+		defer r.analyzeRunning.Store(false)
+
+		analyzeErr := r.instance.Analyze(ctx, inStages)
+		if analyzeErr != nil {
+			contextLogger.Error(analyzeErr, "while collecting the statistics of the databases")
+		} else {
+			contextLogger.Info("The statistics of the databases have been collected")
+		}
+
+		if err := status.PatchConditionsWithOptimisticLock(
+			ctx, r.client, analyzedCluster, buildStatisticsUpToDateCondition(analyzeErr),
+		); err != nil {
+			contextLogger.Error(err, "while reporting the result of the automatic analyze")
+		}
+	}()
+}
+
+// buildStatisticsUpToDateCondition builds the condition reporting the
+// result of the automatic analyze
+func buildStatisticsUpToDateCondition(analyzeErr error) metav1.Condition {
+	if analyzeErr != nil {
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionStatisticsUpToDate),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonAnalyzeFailed),
+			Message: fmt.Sprintf("The statistics could not be collected, run ANALYZE manually: %s",
+				analyzeErr.Error()),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    string(apiv1.ConditionStatisticsUpToDate),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonAnalyzeCompleted),
+		Message: "The statistics of every database have been collected",
+	}
+}
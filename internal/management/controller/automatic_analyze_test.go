@@ -0,0 +1,151 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Automatic analyze", func() {
+	statisticsCondition := func(conditionStatus metav1.ConditionStatus, reason apiv1.ConditionReason) metav1.Condition {
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionStatisticsUpToDate),
+			Status: conditionStatus,
+			Reason: string(reason),
+		}
+	}
+
+	It("is not needed without the StatisticsUpToDate condition", func() {
+		Expect(isAnalyzeNeeded(&apiv1.Cluster{})).To(BeFalse())
+	})
+
+	It("is needed when requested by the operator or interrupted", func() {
+		cluster := &apiv1.Cluster{}
+		status.SetStatisticsOutdated("after the recovery")(cluster)
+		Expect(isAnalyzeNeeded(cluster)).To(BeTrue())
+
+		cluster.Status.Conditions = []metav1.Condition{
+			statisticsCondition(metav1.ConditionFalse, apiv1.ConditionReasonAnalyzeRunning),
+		}
+		Expect(isAnalyzeNeeded(cluster)).To(BeTrue())
+	})
+
+	It("is not repeated once completed or failed", func() {
+		cluster := &apiv1.Cluster{}
+		cluster.Status.Conditions = []metav1.Condition{
+			statisticsCondition(metav1.ConditionTrue, apiv1.ConditionReasonAnalyzeCompleted),
+		}
+		Expect(isAnalyzeNeeded(cluster)).To(BeFalse())
+
+		cluster.Status.Conditions = []metav1.Condition{
+			statisticsCondition(metav1.ConditionFalse, apiv1.ConditionReasonAnalyzeFailed),
+		}
+		Expect(isAnalyzeNeeded(cluster)).To(BeFalse())
+	})
+
+	It("is not requested nor run when disabled", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					AutomaticAnalyze: &apiv1.AutomaticAnalyzeConfiguration{Enabled: ptr.To(false)},
+				},
+			},
+		}
+		status.SetStatisticsOutdated("after the recovery")(cluster)
+		Expect(cluster.Status.Conditions).To(BeEmpty())
+
+		cluster.Status.Conditions = []metav1.Condition{
+			statisticsCondition(metav1.ConditionFalse, apiv1.ConditionReasonAnalyzePending),
+		}
+		Expect(isAnalyzeNeeded(cluster)).To(BeFalse())
+	})
+
+	It("is not requested in replica clusters", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{Enabled: ptr.To(true), Source: "source"},
+			},
+		}
+		status.SetStatisticsOutdated("after the recovery")(cluster)
+		Expect(cluster.Status.Conditions).To(BeEmpty())
+	})
+
+	It("clears a pending request in replica clusters", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{Enabled: ptr.To(true), Source: "source"},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				Conditions: []metav1.Condition{
+					statisticsCondition(metav1.ConditionFalse, apiv1.ConditionReasonAnalyzePending),
+				},
+			},
+		}
+		cli := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+		r := &InstanceReconciler{
+			client: cli,
+			instance: postgres.NewInstance().
+				WithNamespace("default").
+				WithPodName("cluster-example-1").
+				WithClusterName("cluster-example"),
+		}
+
+		r.reconcileAutomaticAnalyze(ctx, cluster)
+		Expect(r.analyzeRunning.Load()).To(BeFalse())
+
+		var liveCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+		condition := meta.FindStatusCondition(liveCluster.Status.Conditions,
+			string(apiv1.ConditionStatisticsUpToDate))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonAnalyzeNotNeeded)))
+	})
+
+	It("reports the result of the analyze in the condition", func() {
+		condition := buildStatisticsUpToDateCondition(nil)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonAnalyzeCompleted)))
+
+		condition = buildStatisticsUpToDateCondition(fmt.Errorf("connection refused"))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonAnalyzeFailed)))
+		Expect(condition.Message).To(ContainSubstring("connection refused"))
+	})
+})
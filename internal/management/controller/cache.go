@@ -31,6 +31,8 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/manager/walrestore"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 )
 
 // updateCacheFromCluster will update the internal cache with the cluster
@@ -107,6 +109,8 @@ func (r *InstanceReconciler) shouldUpdateWALArchiveSettingsCache(
 		contextLogger.Error(err, "while getting backup credentials")
 		return false
 	}
+	envArchive = specs.AppendBarmanEndpointCAEnv(
+		envArchive, cluster.Spec.Backup.BarmanObjectStore, postgres.BarmanBackupEndpointCACertificateLocation)
 
 	cache.Store(cache.WALArchiveKey, envArchive)
 	return false
@@ -23,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -115,9 +116,15 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
-	// If everything is reconciled, we're done here
+	// If everything is reconciled, we're done here. When user mappings
+	// use credentials secrets, we keep checking them to apply the changes.
 	if database.Generation == database.Status.ObservedGeneration {
-		return ctrl.Result{}, nil
+		if len(database.GetUserMappingsSecretNames()) == 0 {
+			return ctrl.Result{}, nil
+		}
+		if !r.userMappingsSecretsChanged(ctx, &database) {
+			return ctrl.Result{RequeueAfter: databaseReconciliationInterval}, nil
+		}
 	}
 
 	// Fetch the Cluster from the cache
@@ -181,6 +188,19 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{RequeueAfter: databaseReconciliationInterval}, nil
 }
 
+// userMappingsSecretsChanged checks if the secrets used by the user
+// mappings changed since the latest reconciliation of the database
+func (r *DatabaseReconciler) userMappingsSecretsChanged(ctx context.Context, database *apiv1.Database) bool {
+	secretsVersion, err := r.getUserMappingsSecretsResourceVersion(ctx, database)
+	if err != nil {
+		// The error will be reported by the reconciliation
+		log.FromContext(ctx).Debug("Cannot check the user mappings secrets", "error", err)
+		return true
+	}
+
+	return !maps.Equal(secretsVersion, database.Status.SecretsResourceVersion)
+}
+
 func (r *DatabaseReconciler) evaluateDropDatabase(ctx context.Context, db *apiv1.Database) error {
 	if db.Spec.ReclaimPolicy != apiv1.DatabaseReclaimDelete {
 		return nil
@@ -269,6 +289,16 @@ func (r *DatabaseReconciler) reconcileDatabaseResource(ctx context.Context, obj
 			return ErrFailedDatabaseObjectReconciliation
 		}
 	}
+	for _, status := range obj.Status.UserMappings {
+		if !status.Applied {
+			return ErrFailedDatabaseObjectReconciliation
+		}
+	}
+	for _, status := range obj.Status.Tables {
+		if !status.Applied {
+			return ErrFailedDatabaseObjectReconciliation
+		}
+	}
 
 	return nil
 }
@@ -281,6 +311,7 @@ func (r *DatabaseReconciler) reconcileDatabaseObjects(
 	objectCount += len(obj.Spec.Extensions)
 	objectCount += len(obj.Spec.FDWs)
 	objectCount += len(obj.Spec.Servers)
+	objectCount += len(obj.Spec.Tables)
 
 	if objectCount == 0 {
 		return nil
@@ -294,7 +325,15 @@ func (r *DatabaseReconciler) reconcileDatabaseObjects(
 	obj.Status.Schemas = schemaObjectManager.reconcileList(ctx, db, obj.Spec.Schemas)
 	obj.Status.Extensions = extensionObjectManager.reconcileList(ctx, db, obj.Spec.Extensions)
 	obj.Status.FDWs = fdwObjectManager.reconcileList(ctx, db, obj.Spec.FDWs)
+
+	// The user mappings of the servers to be removed are dropped before
+	// the servers themselves, the others after the servers are created
+	droppedMappings, _ := r.reconcileUserMappings(ctx, db, obj, apiv1.EnsureAbsent)
 	obj.Status.Servers = serverObjectManager.reconcileList(ctx, db, obj.Spec.Servers)
+	userMappings, secretsVersion := r.reconcileUserMappings(ctx, db, obj, apiv1.EnsurePresent)
+	obj.Status.UserMappings = append(droppedMappings, userMappings...)
+	obj.Status.SecretsResourceVersion = secretsVersion
+	obj.Status.Tables = reconcileTables(ctx, db, obj.Spec.Tables)
 
 	return nil
 }
@@ -306,8 +345,17 @@ func (r *DatabaseReconciler) reconcilePostgresDatabase(ctx context.Context, db *
 	}
 
 	if dbExists {
-		return updateDatabase(ctx, db, obj)
+		err = updateDatabase(ctx, db, obj)
+	} else {
+		err = createDatabase(ctx, db, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := reconcileDatabaseTimeouts(ctx, db, obj); err != nil {
+		return err
 	}
 
-	return createDatabase(ctx, db, obj)
+	return reconcileDatabasePrivileges(ctx, db, obj)
 }
@@ -372,7 +372,23 @@ func createDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSp
 	}
 	contextLogger.Info("created schema", "name", schema.Name)
 
-	return nil
+	if len(schema.Privileges) == 0 {
+		return nil
+	}
+
+	owner := schema.Owner
+	if len(owner) == 0 {
+		info, err := getDatabaseSchemaInfo(ctx, db, schema)
+		if err != nil {
+			return err
+		}
+		if info == nil {
+			return fmt.Errorf("schema %q not found after its creation", schema.Name)
+		}
+		owner = info.Owner
+	}
+
+	return reconcileSchemaPrivileges(ctx, db, schema, owner)
 }
 
 func updateDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec, info *schemaInfo) error {
@@ -391,7 +407,12 @@ func updateDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSp
 		contextLogger.Info("altered schema owner", "name", schema.Name, "owner", schema.Owner)
 	}
 
-	return nil
+	owner := info.Owner
+	if len(schema.Owner) > 0 {
+		owner = schema.Owner
+	}
+
+	return reconcileSchemaPrivileges(ctx, db, schema, owner)
 }
 
 func dropDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec) error {
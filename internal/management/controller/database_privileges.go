@@ -0,0 +1,110 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// detectEffectiveDatabasePrivilegesSQL returns the privileges the role
+// can use on the database, including the ones granted to PUBLIC, to the
+// roles it is a member of, and the ones implied by the ownership
+const detectEffectiveDatabasePrivilegesSQL = `
+SELECT p.privilege
+FROM unnest(ARRAY['CONNECT', 'TEMPORARY', 'CREATE']) AS p(privilege)
+WHERE pg_catalog.has_database_privilege($2, $1, p.privilege)
+`
+
+// detectGrantedDatabasePrivilegesSQL returns the privileges directly
+// granted to the role on the database, which are the ones that can
+// be revoked from it
+const detectGrantedDatabasePrivilegesSQL = `
+SELECT a.privilege_type
+FROM pg_catalog.pg_database d,
+	pg_catalog.aclexplode(d.datacl) a
+WHERE d.datname = $1
+	AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+`
+
+// reconcileDatabasePrivileges grants the privileges on the database that the
+// roles listed in the specification can't use and, when requested, revokes
+// the ones that are directly granted and not listed
+func reconcileDatabasePrivileges(ctx context.Context, db *sql.DB, obj *apiv1.Database) error {
+	for _, privileges := range obj.Spec.Privileges {
+		if err := reconcileDatabasePrivilegesForRole(
+			ctx, db, obj.Spec.Name, privileges, obj.Spec.RevokeUnlistedPrivileges,
+		); err != nil {
+			return fmt.Errorf("while reconciling the privileges of role %q on database %q: %w",
+				privileges.Role, obj.Spec.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func reconcileDatabasePrivilegesForRole(
+	ctx context.Context,
+	db *sql.DB,
+	databaseName string,
+	spec apiv1.DatabasePrivilegeSpec,
+	revokeUnlisted bool,
+) error {
+	contextLogger := log.FromContext(ctx).WithValues("database", databaseName, "role", spec.Role)
+	desiredPrivileges := toPrivilegeNames(spec.Privileges)
+
+	effectivePrivileges, err := queryPrivileges(
+		ctx, db, detectEffectiveDatabasePrivilegesSQL, databaseName, spec.Role)
+	if err != nil {
+		return err
+	}
+	toGrant, _ := calculatePrivilegesDiff(desiredPrivileges, []*stringset.Data{effectivePrivileges})
+
+	var toRevoke []string
+	if revokeUnlisted {
+		grantedPrivileges, err := queryPrivileges(
+			ctx, db, detectGrantedDatabasePrivilegesSQL, databaseName, spec.Role)
+		if err != nil {
+			return err
+		}
+		_, toRevoke = calculatePrivilegesDiff(desiredPrivileges, []*stringset.Data{grantedPrivileges})
+	}
+
+	if err := applyPrivileges(
+		ctx, db,
+		"", fmt.Sprintf("DATABASE %s", pgx.Identifier{databaseName}.Sanitize()),
+		pgx.Identifier{spec.Role}.Sanitize(),
+		toGrant, toRevoke,
+	); err != nil {
+		return err
+	}
+	if len(toGrant) > 0 || len(toRevoke) > 0 {
+		contextLogger.Info("reconciled database privileges", "granted", toGrant, "revoked", toRevoke)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managed database privileges SQL", func() {
+	var (
+		dbMock   sqlmock.Sqlmock
+		db       *sql.DB
+		database *apiv1.Database
+		err      error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		database = &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Name: "app",
+				Privileges: []apiv1.DatabasePrivilegeSpec{
+					{
+						Role: "reader",
+						Privileges: []apiv1.DatabasePrivilege{
+							apiv1.DatabasePrivilegeConnect,
+							apiv1.DatabasePrivilegeCreate,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("grants the privileges the role can't use", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectEffectiveDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege"}).AddRow("CONNECT").AddRow("TEMPORARY"))
+		dbMock.ExpectExec(`GRANT CREATE ON DATABASE "app" TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(reconcileDatabasePrivileges(ctx, db, database)).To(Succeed())
+	})
+
+	It("does nothing when the role can already use the privileges", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectEffectiveDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege"}).AddRow("CONNECT").AddRow("CREATE"))
+
+		Expect(reconcileDatabasePrivileges(ctx, db, database)).To(Succeed())
+	})
+
+	It("revokes the unlisted privileges only when requested", func(ctx SpecContext) {
+		database.Spec.RevokeUnlistedPrivileges = true
+		database.Spec.Privileges[0].Privileges = []apiv1.DatabasePrivilege{apiv1.DatabasePrivilegeConnect}
+
+		dbMock.ExpectQuery(detectEffectiveDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege"}).
+				AddRow("CONNECT").AddRow("TEMPORARY").AddRow("CREATE"))
+		dbMock.ExpectQuery(detectGrantedDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).
+				AddRow("CONNECT").AddRow("TEMPORARY").AddRow("CREATE"))
+		dbMock.ExpectExec(`REVOKE CREATE, TEMPORARY ON DATABASE "app" FROM "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(reconcileDatabasePrivileges(ctx, db, database)).To(Succeed())
+	})
+
+	It("revokes every privilege from a role listed without privileges", func(ctx SpecContext) {
+		database.Spec.RevokeUnlistedPrivileges = true
+		database.Spec.Privileges[0].Privileges = nil
+
+		dbMock.ExpectQuery(detectEffectiveDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege"}).AddRow("CONNECT").AddRow("TEMPORARY"))
+		dbMock.ExpectQuery(detectGrantedDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("CONNECT"))
+		dbMock.ExpectExec(`REVOKE CONNECT ON DATABASE "app" FROM "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(reconcileDatabasePrivileges(ctx, db, database)).To(Succeed())
+	})
+
+	It("reports the errors", func(ctx SpecContext) {
+		testError := fmt.Errorf("test error")
+		dbMock.ExpectQuery(detectEffectiveDatabasePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege"}))
+		dbMock.ExpectExec(`GRANT CONNECT, CREATE ON DATABASE "app" TO "reader"`).
+			WillReturnError(testError)
+
+		err := reconcileDatabasePrivileges(ctx, db, database)
+		Expect(err).To(MatchError(testError))
+	})
+})
@@ -0,0 +1,284 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+const detectSchemaPrivilegesSQL = `
+SELECT a.privilege_type
+FROM pg_catalog.pg_namespace n,
+	pg_catalog.aclexplode(n.nspacl) a
+WHERE n.nspname = $1
+	AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+`
+
+const detectTablePrivilegesSQL = `
+SELECT c.relname, COALESCE(a.privilege_type, '')
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN LATERAL pg_catalog.aclexplode(c.relacl) a
+	ON a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+WHERE n.nspname = $1
+	AND c.relkind IN ('r', 'p', 'v', 'm', 'f')
+`
+
+const detectDefaultTablePrivilegesSQL = `
+SELECT a.privilege_type
+FROM pg_catalog.pg_default_acl d
+JOIN pg_catalog.pg_namespace n ON n.oid = d.defaclnamespace,
+	pg_catalog.aclexplode(d.defaclacl) a
+WHERE n.nspname = $1
+	AND d.defaclrole = n.nspowner
+	AND d.defaclobjtype = 'r'
+	AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+`
+
+// reconcileSchemaPrivileges makes the privileges of every role listed in the
+// schema specification match the desired ones. The owner is the role owning
+// the schema, which is used to set the default privileges for new tables
+func reconcileSchemaPrivileges(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec, owner string) error {
+	for _, privileges := range schema.Privileges {
+		if err := reconcileSchemaPrivilegesForRole(ctx, db, schema.Name, owner, privileges); err != nil {
+			return fmt.Errorf("while reconciling the privileges of role %q on schema %q: %w",
+				privileges.Role, schema.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func reconcileSchemaPrivilegesForRole(
+	ctx context.Context,
+	db *sql.DB,
+	schemaName string,
+	owner string,
+	spec apiv1.SchemaPrivilegeSpec,
+) error {
+	contextLogger := log.FromContext(ctx).WithValues("schema", schemaName, "role", spec.Role)
+	sanitizedSchema := pgx.Identifier{schemaName}.Sanitize()
+	sanitizedRole := pgx.Identifier{spec.Role}.Sanitize()
+
+	currentSchemaPrivileges, err := queryPrivileges(ctx, db, detectSchemaPrivilegesSQL, schemaName, spec.Role)
+	if err != nil {
+		return err
+	}
+	toGrant, toRevoke := calculatePrivilegesDiff(
+		toPrivilegeNames(spec.Schema), []*stringset.Data{currentSchemaPrivileges})
+	if err := applyPrivileges(
+		ctx, db,
+		"", fmt.Sprintf("SCHEMA %s", sanitizedSchema), sanitizedRole,
+		toGrant, toRevoke,
+	); err != nil {
+		return err
+	}
+	if len(toGrant) > 0 || len(toRevoke) > 0 {
+		contextLogger.Info("reconciled schema privileges", "granted", toGrant, "revoked", toRevoke)
+	}
+
+	desiredTablePrivileges := toPrivilegeNames(spec.Tables)
+
+	if !spec.SkipExistingTables {
+		currentTablePrivileges, err := queryTablePrivileges(ctx, db, schemaName, spec.Role)
+		if err != nil {
+			return err
+		}
+		toGrant, toRevoke := calculatePrivilegesDiff(desiredTablePrivileges, currentTablePrivileges)
+		if err := applyPrivileges(
+			ctx, db,
+			"", fmt.Sprintf("ALL TABLES IN SCHEMA %s", sanitizedSchema), sanitizedRole,
+			toGrant, toRevoke,
+		); err != nil {
+			return err
+		}
+		if len(toGrant) > 0 || len(toRevoke) > 0 {
+			contextLogger.Info("reconciled table privileges", "granted", toGrant, "revoked", toRevoke)
+		}
+	}
+
+	currentDefaultPrivileges, err := queryPrivileges(ctx, db, detectDefaultTablePrivilegesSQL, schemaName, spec.Role)
+	if err != nil {
+		return err
+	}
+	toGrant, toRevoke = calculatePrivilegesDiff(
+		desiredTablePrivileges, []*stringset.Data{currentDefaultPrivileges})
+	if err := applyPrivileges(
+		ctx, db,
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s ",
+			pgx.Identifier{owner}.Sanitize(), sanitizedSchema),
+		"TABLES", sanitizedRole,
+		toGrant, toRevoke,
+	); err != nil {
+		return err
+	}
+	if len(toGrant) > 0 || len(toRevoke) > 0 {
+		contextLogger.Info("reconciled default table privileges", "granted", toGrant, "revoked", toRevoke)
+	}
+
+	return nil
+}
+
+// queryPrivileges returns the set of privileges returned by a query
+// accepting the object name and the role name as parameters
+func queryPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	objectName string,
+	roleName string,
+) (*stringset.Data, error) {
+	rows, err := db.QueryContext(ctx, query, objectName, roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := stringset.New()
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+		result.Put(privilege)
+	}
+
+	return result, rows.Err()
+}
+
+// queryTablePrivileges returns, for every table in the schema, the set
+// of privileges held by the role
+func queryTablePrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	schemaName string,
+	roleName string,
+) ([]*stringset.Data, error) {
+	rows, err := db.QueryContext(ctx, detectTablePrivilegesSQL, schemaName, roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	tables := make(map[string]*stringset.Data)
+	for rows.Next() {
+		var tableName, privilege string
+		if err := rows.Scan(&tableName, &privilege); err != nil {
+			return nil, err
+		}
+
+		privileges, ok := tables[tableName]
+		if !ok {
+			privileges = stringset.New()
+			tables[tableName] = privileges
+		}
+		if privilege != "" {
+			privileges.Put(privilege)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*stringset.Data, 0, len(tables))
+	for _, privileges := range tables {
+		result = append(result, privileges)
+	}
+	return result, nil
+}
+
+// calculatePrivilegesDiff returns the desired privileges missing from at
+// least one of the objects, and the sorted list of the privileges held on
+// at least one of the objects that are not desired
+func calculatePrivilegesDiff(desired []string, current []*stringset.Data) (toGrant, toRevoke []string) {
+	undesired := stringset.New()
+	for _, privileges := range current {
+		for _, privilege := range privileges.ToList() {
+			if !slices.Contains(desired, privilege) {
+				undesired.Put(privilege)
+			}
+		}
+	}
+
+	for _, privilege := range desired {
+		if slices.Contains(toGrant, privilege) {
+			continue
+		}
+		for _, privileges := range current {
+			if !privileges.Has(privilege) {
+				toGrant = append(toGrant, privilege)
+				break
+			}
+		}
+	}
+
+	return toGrant, undesired.ToSortedList()
+}
+
+// applyPrivileges runs the GRANT and REVOKE statements needed to reconcile the
+// privileges of a role on an object. The prefix is prepended to the statements
+// and is used to alter the default privileges
+func applyPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	prefix string,
+	object string,
+	sanitizedRole string,
+	toGrant []string,
+	toRevoke []string,
+) error {
+	if len(toGrant) > 0 {
+		mutation := fmt.Sprintf("%sGRANT %s ON %s TO %s", prefix, strings.Join(toGrant, ", "), object, sanitizedRole)
+		if _, err := db.ExecContext(ctx, mutation); err != nil {
+			return fmt.Errorf("granting privileges: %w", err)
+		}
+	}
+
+	if len(toRevoke) > 0 {
+		mutation := fmt.Sprintf("%sREVOKE %s ON %s FROM %s", prefix, strings.Join(toRevoke, ", "), object, sanitizedRole)
+		if _, err := db.ExecContext(ctx, mutation); err != nil {
+			return fmt.Errorf("revoking privileges: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toPrivilegeNames[T ~string](privileges []T) []string {
+	result := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		result[i] = string(privilege)
+	}
+	return result
+}
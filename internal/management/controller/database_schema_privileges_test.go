@@ -0,0 +1,188 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("calculatePrivilegesDiff", func() {
+	It("grants the privileges missing from at least one object", func() {
+		toGrant, toRevoke := calculatePrivilegesDiff(
+			[]string{"SELECT", "INSERT"},
+			[]*stringset.Data{
+				stringset.From([]string{"SELECT", "INSERT"}),
+				stringset.From([]string{"SELECT"}),
+			})
+		Expect(toGrant).To(Equal([]string{"INSERT"}))
+		Expect(toRevoke).To(BeEmpty())
+	})
+
+	It("revokes the privileges which are not desired", func() {
+		toGrant, toRevoke := calculatePrivilegesDiff(
+			[]string{"SELECT"},
+			[]*stringset.Data{
+				stringset.From([]string{"SELECT", "UPDATE"}),
+				stringset.From([]string{"SELECT", "DELETE"}),
+			})
+		Expect(toGrant).To(BeEmpty())
+		Expect(toRevoke).To(Equal([]string{"DELETE", "UPDATE"}))
+	})
+
+	It("does nothing when there are no objects", func() {
+		toGrant, toRevoke := calculatePrivilegesDiff([]string{"SELECT"}, nil)
+		Expect(toGrant).To(BeEmpty())
+		Expect(toRevoke).To(BeEmpty())
+	})
+
+	It("revokes everything when no privilege is desired", func() {
+		toGrant, toRevoke := calculatePrivilegesDiff(
+			nil,
+			[]*stringset.Data{stringset.From([]string{"USAGE", "CREATE"})})
+		Expect(toGrant).To(BeEmpty())
+		Expect(toRevoke).To(Equal([]string{"CREATE", "USAGE"}))
+	})
+})
+
+var _ = Describe("Managed schema privileges SQL", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+		schema apiv1.SchemaSpec
+		err    error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		schema = apiv1.SchemaSpec{
+			DatabaseObjectSpec: apiv1.DatabaseObjectSpec{
+				Name:   "app",
+				Ensure: "present",
+			},
+			Owner: "owner",
+			Privileges: []apiv1.SchemaPrivilegeSpec{
+				{
+					Role:   "reader",
+					Schema: []apiv1.SchemaPrivilege{apiv1.SchemaPrivilegeUsage, apiv1.SchemaPrivilegeCreate},
+					Tables: []apiv1.TablePrivilege{"SELECT"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("reconciles the privileges on the schema, its tables and the default ones", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectSchemaPrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("USAGE"))
+		dbMock.ExpectExec(`GRANT CREATE ON SCHEMA "app" TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"relname", "privilege_type"}).
+				AddRow("t1", "SELECT").
+				AddRow("t1", "INSERT").
+				AddRow("t2", ""))
+		dbMock.ExpectExec(`GRANT SELECT ON ALL TABLES IN SCHEMA "app" TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`REVOKE INSERT ON ALL TABLES IN SCHEMA "app" FROM "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDefaultTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("UPDATE"))
+		dbMock.ExpectExec(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "app" ` +
+			`GRANT SELECT ON TABLES TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "app" ` +
+			`REVOKE UPDATE ON TABLES FROM "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(updateDatabaseSchema(ctx, db, schema, &schemaInfo{Name: "app", Owner: "owner"})).To(Succeed())
+	})
+
+	It("does nothing when the privileges are already reconciled", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectSchemaPrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("USAGE").AddRow("CREATE"))
+		dbMock.ExpectQuery(detectTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"relname", "privilege_type"}).AddRow("t1", "SELECT"))
+		dbMock.ExpectQuery(detectDefaultTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("SELECT"))
+
+		Expect(updateDatabaseSchema(ctx, db, schema, &schemaInfo{Name: "app", Owner: "owner"})).To(Succeed())
+	})
+
+	It("leaves the existing tables untouched when requested", func(ctx SpecContext) {
+		schema.Privileges[0].SkipExistingTables = true
+
+		dbMock.ExpectQuery(detectSchemaPrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("USAGE").AddRow("CREATE"))
+		dbMock.ExpectQuery(detectDefaultTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+		dbMock.ExpectExec(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "app" ` +
+			`GRANT SELECT ON TABLES TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(updateDatabaseSchema(ctx, db, schema, &schemaInfo{Name: "app", Owner: "owner"})).To(Succeed())
+	})
+
+	It("uses the current owner of a newly created schema for the default privileges", func(ctx SpecContext) {
+		schema.Owner = ""
+		schema.Privileges[0].Schema = nil
+		schema.Privileges[0].Tables = nil
+		schema.Privileges[0].SkipExistingTables = true
+
+		dbMock.ExpectExec(`CREATE SCHEMA "app" `).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectQuery(detectDatabaseSchemaSQL).WithArgs("app").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "owner"}).AddRow("app", "postgres"))
+		dbMock.ExpectQuery(detectSchemaPrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+		dbMock.ExpectQuery(detectDefaultTablePrivilegesSQL).WithArgs("app", "reader").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("SELECT"))
+		dbMock.ExpectExec(`ALTER DEFAULT PRIVILEGES FOR ROLE "postgres" IN SCHEMA "app" ` +
+			`REVOKE SELECT ON TABLES FROM "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(createDatabaseSchema(ctx, db, schema)).To(Succeed())
+	})
+
+	It("reports the role when the reconciliation fails", func(ctx SpecContext) {
+		testError := fmt.Errorf("test error")
+		dbMock.ExpectQuery(detectSchemaPrivilegesSQL).WithArgs("app", "reader").
+			WillReturnError(testError)
+
+		err := updateDatabaseSchema(ctx, db, schema, &schemaInfo{Name: "app", Owner: "owner"})
+		Expect(err).To(MatchError(testError))
+		Expect(err.Error()).To(ContainSubstring(`role "reader"`))
+	})
+})
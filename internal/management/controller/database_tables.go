@@ -0,0 +1,183 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// toastStorageParameterPrefix is the prefix of the storage parameters
+// applying to the TOAST table of a table
+const toastStorageParameterPrefix = "toast."
+
+// table is an existing table whose storage parameters are managed
+type table struct {
+	apiv1.TableSpec
+}
+
+type tableInfo struct {
+	// The storage parameters of the table, including the ones of
+	// its TOAST table with the "toast." prefix
+	StorageParameters map[string]string `json:"storageParameters"`
+}
+
+// GetName returns the name used in the status of the table
+func (t table) GetName() string {
+	return fmt.Sprintf("%s.%s", t.getSchema(), t.Name)
+}
+
+// GetEnsure returns the ensure option of the table, which is always
+// present as tables are not created nor dropped by the operator
+func (t table) GetEnsure() apiv1.EnsureOption {
+	return apiv1.EnsurePresent
+}
+
+func (t table) getSchema() string {
+	if t.Schema == "" {
+		return "public"
+	}
+	return t.Schema
+}
+
+func (t table) sanitizedName() string {
+	return pgx.Identifier{t.getSchema(), t.Name}.Sanitize()
+}
+
+// tableObjectManager is the manager of the storage parameters of the tables
+var tableObjectManager = databaseObjectManager[table, tableInfo]{
+	get:    getDatabaseTableInfo,
+	create: failMissingDatabaseTable,
+	update: updateDatabaseTable,
+	drop:   func(context.Context, *sql.DB, table) error { return nil },
+}
+
+// reconcileTables reconciles the storage parameters of the tables of the database
+func reconcileTables(ctx context.Context, db *sql.DB, specs []apiv1.TableSpec) []apiv1.DatabaseObjectStatus {
+	tables := make([]table, len(specs))
+	for idx := range specs {
+		tables[idx] = table{TableSpec: specs[idx]}
+	}
+	return tableObjectManager.reconcileList(ctx, db, tables)
+}
+
+const detectDatabaseTableSQL = `
+SELECT c.reloptions, t.reloptions
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_class t ON t.oid = c.reltoastrelid
+WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p', 'm')
+`
+
+func getDatabaseTableInfo(ctx context.Context, db *sql.DB, t table) (*tableInfo, error) {
+	var tableOptionsRaw, toastOptionsRaw pq.StringArray
+
+	if err := db.QueryRowContext(
+		ctx, detectDatabaseTableSQL,
+		t.getSchema(), t.Name).Scan(&tableOptionsRaw, &toastOptionsRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while scanning if table %q exists: %w", t.GetName(), err)
+	}
+
+	storageParameters, err := parseOptions(tableOptionsRaw)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing storage parameters of table %q: %w", t.GetName(), err)
+	}
+
+	toastStorageParameters, err := parseOptions(toastOptionsRaw)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing storage parameters of the TOAST table of %q: %w", t.GetName(), err)
+	}
+	for name, value := range toastStorageParameters {
+		storageParameters[toastStorageParameterPrefix+name] = value
+	}
+
+	return &tableInfo{StorageParameters: storageParameters}, nil
+}
+
+// failMissingDatabaseTable is invoked when the table doesn't exist,
+// as it is not created by the operator
+func failMissingDatabaseTable(_ context.Context, _ *sql.DB, t table) error {
+	return fmt.Errorf("table %q does not exist", t.GetName())
+}
+
+// sanitizeStorageParameterName quotes the name of a storage parameter,
+// which may be qualified by a namespace like in "toast.autovacuum_enabled"
+func sanitizeStorageParameterName(name string) string {
+	return pgx.Identifier(strings.SplitN(name, ".", 2)).Sanitize()
+}
+
+// calculateAlterTableClauses returns the clauses needed to make the
+// storage parameters of a table match the passed specification
+func calculateAlterTableClauses(storageParameters []apiv1.OptionSpec, existing map[string]string) []string {
+	var setClauses, resetClauses []string
+	for _, parameter := range storageParameters {
+		value, exists := existing[parameter.Name]
+		switch {
+		case parameter.Ensure == apiv1.EnsureAbsent && exists:
+			resetClauses = append(resetClauses, sanitizeStorageParameterName(parameter.Name))
+		case parameter.Ensure != apiv1.EnsureAbsent && (!exists || value != parameter.Value):
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s",
+				sanitizeStorageParameterName(parameter.Name), pq.QuoteLiteral(parameter.Value)))
+		}
+	}
+	sort.Strings(setClauses)
+	sort.Strings(resetClauses)
+
+	var clauses []string
+	if len(setClauses) > 0 {
+		clauses = append(clauses, "SET ("+strings.Join(setClauses, ", ")+")")
+	}
+	if len(resetClauses) > 0 {
+		clauses = append(clauses, "RESET ("+strings.Join(resetClauses, ", ")+")")
+	}
+	return clauses
+}
+
+// updateDatabaseTable changes the storage parameters of a table
+func updateDatabaseTable(ctx context.Context, db *sql.DB, t table, info *tableInfo) error {
+	contextLogger := log.FromContext(ctx)
+
+	clauses := calculateAlterTableClauses(t.StorageParameters, info.StorageParameters)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	alterTableSQL := fmt.Sprintf("ALTER TABLE %s %s", t.sanitizedName(), strings.Join(clauses, ", "))
+	if _, err := db.ExecContext(ctx, alterTableSQL); err != nil {
+		contextLogger.Error(err, "while altering table storage parameters", "query", alterTableSQL)
+		return fmt.Errorf("altering storage parameters of table %w", err)
+	}
+	contextLogger.Info("altered table storage parameters", "table", t.GetName())
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managed Table SQL", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+		tbl    table
+		err    error
+
+		testError error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		tbl = table{
+			TableSpec: apiv1.TableSpec{
+				Name:   "events",
+				Schema: "app",
+				StorageParameters: []apiv1.OptionSpec{
+					{Name: "autovacuum_vacuum_scale_factor", Value: "0.01", Ensure: apiv1.EnsurePresent},
+					{Name: "toast.autovacuum_enabled", Value: "false", Ensure: apiv1.EnsurePresent},
+					{Name: "autovacuum_vacuum_cost_limit", Ensure: apiv1.EnsureAbsent},
+				},
+			},
+		}
+
+		testError = fmt.Errorf("test error")
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("uses the public schema by default", func() {
+		tbl.Schema = ""
+		Expect(tbl.GetName()).To(Equal("public.events"))
+		Expect(tbl.sanitizedName()).To(Equal(`"public"."events"`))
+	})
+
+	Context("getDatabaseTableInfo", func() {
+		It("returns the storage parameters of the table and of its TOAST table", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseTableSQL).
+				WithArgs("app", "events").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"reloptions", "reloptions"}).
+						AddRow("{autovacuum_vacuum_scale_factor=0.2,fillfactor=90}", "{autovacuum_enabled=true}"),
+				)
+			info, err := getDatabaseTableInfo(ctx, db, tbl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).ToNot(BeNil())
+			Expect(info.StorageParameters).To(Equal(map[string]string{
+				"autovacuum_vacuum_scale_factor": "0.2",
+				"fillfactor":                     "90",
+				"toast.autovacuum_enabled":       "true",
+			}))
+		})
+
+		It("returns no storage parameters when none is set", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseTableSQL).
+				WithArgs("app", "events").
+				WillReturnRows(sqlmock.NewRows([]string{"reloptions", "reloptions"}).AddRow(nil, nil))
+			info, err := getDatabaseTableInfo(ctx, db, tbl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).ToNot(BeNil())
+			Expect(info.StorageParameters).To(BeEmpty())
+		})
+
+		It("returns nil when the table does not exist", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseTableSQL).
+				WithArgs("app", "events").
+				WillReturnRows(sqlmock.NewRows([]string{"reloptions", "reloptions"}))
+			info, err := getDatabaseTableInfo(ctx, db, tbl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+	})
+
+	Context("updateDatabaseTable", func() {
+		It("sets and resets the storage parameters which differ", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec(`ALTER TABLE "app"."events" SET ("autovacuum_vacuum_scale_factor" = '0.01', ` +
+					`"toast"."autovacuum_enabled" = 'false'), RESET ("autovacuum_vacuum_cost_limit")`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(updateDatabaseTable(ctx, db, tbl, &tableInfo{StorageParameters: map[string]string{
+				"autovacuum_vacuum_scale_factor": "0.2",
+				"autovacuum_vacuum_cost_limit":   "1000",
+			}})).To(Succeed())
+		})
+
+		It("does nothing when the storage parameters already match", func(ctx SpecContext) {
+			Expect(updateDatabaseTable(ctx, db, tbl, &tableInfo{StorageParameters: map[string]string{
+				"autovacuum_vacuum_scale_factor": "0.01",
+				"toast.autovacuum_enabled":       "false",
+				"fillfactor":                     "90",
+			}})).To(Succeed())
+		})
+
+		It("fails when the table could not be altered", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec(`ALTER TABLE "app"."events" SET ("autovacuum_vacuum_scale_factor" = '0.01', ` +
+					`"toast"."autovacuum_enabled" = 'false')`).
+				WillReturnError(testError)
+			Expect(updateDatabaseTable(ctx, db, tbl, &tableInfo{StorageParameters: map[string]string{}})).
+				To(MatchError(testError))
+		})
+	})
+
+	Context("reconcileTables", func() {
+		It("reports the tables which do not exist as failed", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseTableSQL).
+				WithArgs("app", "events").
+				WillReturnRows(sqlmock.NewRows([]string{"reloptions", "reloptions"}))
+			statuses := reconcileTables(ctx, db, []apiv1.TableSpec{tbl.TableSpec})
+			Expect(statuses).To(HaveLen(1))
+			Expect(statuses[0].Name).To(Equal("app.events"))
+			Expect(statuses[0].Applied).To(BeFalse())
+			Expect(statuses[0].Message).To(ContainSubstring("does not exist"))
+		})
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// detectDatabaseSettingsSQL returns the settings of the database that
+// apply to every role, as set with `ALTER DATABASE ... SET`
+const detectDatabaseSettingsSQL = `
+SELECT s.setconfig
+FROM pg_catalog.pg_db_role_setting s
+JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+WHERE d.datname = $1 AND s.setrole = 0
+`
+
+// databaseTimeout is the desired value of a timeout of the database,
+// which is empty when the timeout must be reset
+type databaseTimeout struct {
+	parameter string
+	value     string
+}
+
+// getDatabaseTimeouts returns the desired value of each timeout of the database
+func getDatabaseTimeouts(timeouts *apiv1.DatabaseTimeoutsConfiguration) []databaseTimeout {
+	result := []databaseTimeout{
+		{parameter: "statement_timeout"},
+		{parameter: "lock_timeout"},
+		{parameter: "idle_in_transaction_session_timeout"},
+	}
+	for idx, value := range []*metav1.Duration{
+		timeouts.StatementTimeout,
+		timeouts.LockTimeout,
+		timeouts.IdleInTransactionSessionTimeout,
+	} {
+		if value != nil {
+			result[idx].value = fmt.Sprintf("%dms", value.Milliseconds())
+		}
+	}
+
+	return result
+}
+
+// getDatabaseSettings returns the settings of the database that apply
+// to every role
+func getDatabaseSettings(ctx context.Context, db *sql.DB, dbname string) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, detectDatabaseSettingsSQL, dbname)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var settings pq.StringArray
+		if err := rows.Scan(&settings); err != nil {
+			return nil, err
+		}
+		for _, setting := range settings {
+			name, value, _ := strings.Cut(setting, "=")
+			result[name] = value
+		}
+	}
+
+	return result, rows.Err()
+}
+
+// reconcileDatabaseTimeouts sets the timeouts of the database, resetting
+// the ones which are not specified, when the timeouts are managed
+func reconcileDatabaseTimeouts(ctx context.Context, db *sql.DB, obj *apiv1.Database) error {
+	if obj.Spec.Timeouts == nil {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	existing, err := getDatabaseSettings(ctx, db, obj.Spec.Name)
+	if err != nil {
+		return fmt.Errorf("while reading the settings of database %q: %w", obj.Spec.Name, err)
+	}
+
+	for _, timeout := range getDatabaseTimeouts(obj.Spec.Timeouts) {
+		parameter, value := timeout.parameter, timeout.value
+		currentValue, found := existing[parameter]
+
+		var query string
+		switch {
+		case value == "" && found:
+			query = fmt.Sprintf("ALTER DATABASE %s RESET %s",
+				pgx.Identifier{obj.Spec.Name}.Sanitize(), parameter)
+		case value != "" && (!found || currentValue != value):
+			query = fmt.Sprintf("ALTER DATABASE %s SET %s = %s",
+				pgx.Identifier{obj.Spec.Name}.Sanitize(), parameter, pq.QuoteLiteral(value))
+		default:
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			contextLogger.Error(err, "while altering database", "query", query)
+			return fmt.Errorf("while setting %s of database %q: %w", parameter, obj.Spec.Name, err)
+		}
+		contextLogger.Info("altered database timeout", "database", obj.Spec.Name,
+			"parameter", parameter, "value", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managed database timeouts", func() {
+	var (
+		dbMock   sqlmock.Sqlmock
+		db       *sql.DB
+		database *apiv1.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		database = &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Name: "app",
+				Timeouts: &apiv1.DatabaseTimeoutsConfiguration{
+					StatementTimeout: &metav1.Duration{Duration: 30 * time.Second},
+					LockTimeout:      &metav1.Duration{Duration: 0},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	expectSettings := func(settings ...string) {
+		rows := sqlmock.NewRows([]string{"setconfig"})
+		if len(settings) > 0 {
+			rows.AddRow(pq.StringArray(settings))
+		}
+		dbMock.ExpectQuery(detectDatabaseSettingsSQL).WithArgs("app").WillReturnRows(rows)
+	}
+
+	It("doesn't manage the timeouts unless requested", func(ctx SpecContext) {
+		database.Spec.Timeouts = nil
+		Expect(reconcileDatabaseTimeouts(ctx, db, database)).To(Succeed())
+	})
+
+	It("sets the missing timeouts and resets the ones not listed", func(ctx SpecContext) {
+		expectSettings("idle_in_transaction_session_timeout=1min", "search_path=app")
+		dbMock.ExpectExec(`ALTER DATABASE "app" SET statement_timeout = '30000ms'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`ALTER DATABASE "app" SET lock_timeout = '0ms'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`ALTER DATABASE "app" RESET idle_in_transaction_session_timeout`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(reconcileDatabaseTimeouts(ctx, db, database)).To(Succeed())
+	})
+
+	It("only corrects the timeouts that drifted", func(ctx SpecContext) {
+		expectSettings("statement_timeout=5s", "lock_timeout=0ms")
+		dbMock.ExpectExec(`ALTER DATABASE "app" SET statement_timeout = '30000ms'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(reconcileDatabaseTimeouts(ctx, db, database)).To(Succeed())
+	})
+
+	It("does nothing when the timeouts are already set", func(ctx SpecContext) {
+		expectSettings("statement_timeout=30000ms", "lock_timeout=0ms")
+		Expect(reconcileDatabaseTimeouts(ctx, db, database)).To(Succeed())
+	})
+
+	It("reports the errors while altering the database", func(ctx SpecContext) {
+		expectSettings()
+		dbMock.ExpectExec(`ALTER DATABASE "app" SET statement_timeout = '30000ms'`).
+			WillReturnError(fmt.Errorf("test error"))
+
+		Expect(reconcileDatabaseTimeouts(ctx, db, database)).To(MatchError(ContainSubstring("statement_timeout")))
+	})
+})
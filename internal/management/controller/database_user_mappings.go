@@ -0,0 +1,269 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/utils"
+)
+
+// publicUserMapping is the name of the user mapping applying
+// to every role without a specific one
+const publicUserMapping = "public"
+
+// userMapping is a user mapping as it should be applied to
+// a foreign server, with the credentials taken from the secret
+type userMapping struct {
+	apiv1.UserMappingSpec
+
+	// The name of the foreign server
+	server string
+
+	// The options to be applied, including the credentials
+	options []apiv1.OptionSpec
+}
+
+type userMappingInfo struct {
+	Options map[string]string `json:"options"`
+}
+
+// GetName returns the name used in the status of the user mapping
+func (u userMapping) GetName() string {
+	return fmt.Sprintf("%s/%s", u.server, u.User)
+}
+
+// GetEnsure returns the ensure option of the user mapping
+func (u userMapping) GetEnsure() apiv1.EnsureOption {
+	return u.Ensure
+}
+
+// isPublic is true if the user mapping is the one applying to every role
+func (u userMapping) isPublic() bool {
+	return strings.EqualFold(u.User, publicUserMapping)
+}
+
+// sanitizedUser returns the user name to be used in the SQL statements
+func (u userMapping) sanitizedUser() string {
+	if u.isPublic() {
+		return "PUBLIC"
+	}
+	return pgx.Identifier{u.User}.Sanitize()
+}
+
+// userMappingObjectManager is the manager of the user mapping objects
+var userMappingObjectManager = databaseObjectManager[userMapping, userMappingInfo]{
+	get:    getDatabaseUserMappingInfo,
+	create: createDatabaseUserMapping,
+	update: updateDatabaseUserMapping,
+	drop:   dropDatabaseUserMapping,
+}
+
+// reconcileUserMappings reconciles the user mappings of the servers having the
+// passed ensure option, and returns their status together with the resource version
+// of the credentials secrets used
+func (r *DatabaseReconciler) reconcileUserMappings(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Database,
+	serverEnsure apiv1.EnsureOption,
+) ([]apiv1.DatabaseObjectStatus, map[string]string) {
+	var (
+		statuses      []apiv1.DatabaseObjectStatus
+		mappings      []userMapping
+		secretVersion = make(map[string]string)
+	)
+
+	for _, server := range obj.Spec.Servers {
+		if server.Ensure != serverEnsure {
+			continue
+		}
+
+		for _, spec := range server.UserMappings {
+			mapping := userMapping{
+				UserMappingSpec: spec,
+				server:          server.Name,
+				options:         spec.Options,
+			}
+
+			// Removing the server requires every mapping to be dropped
+			if serverEnsure == apiv1.EnsureAbsent {
+				mapping.Ensure = apiv1.EnsureAbsent
+			}
+
+			if mapping.Ensure == apiv1.EnsurePresent && spec.CredentialsSecret != nil {
+				credentials, version, err := r.getUserMappingCredentials(ctx, obj.Namespace, spec.CredentialsSecret.Name)
+				if err != nil {
+					statuses = append(statuses, createFailedStatus(mapping.GetName(), err.Error()))
+					continue
+				}
+				mapping.options = append(credentials, spec.Options...)
+				secretVersion[spec.CredentialsSecret.Name] = version
+			}
+
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	return append(statuses, userMappingObjectManager.reconcileList(ctx, db, mappings)...), secretVersion
+}
+
+// getUserMappingCredentials reads the credentials secret of a user mapping, returning
+// the corresponding options and the resource version of the secret
+func (r *DatabaseReconciler) getUserMappingCredentials(
+	ctx context.Context,
+	namespace string,
+	secretName string,
+) ([]apiv1.OptionSpec, string, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		return nil, "", fmt.Errorf("while getting the credentials secret %q: %w", secretName, err)
+	}
+
+	username, password, err := utils.GetUserPasswordFromSecret(&secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("while reading the credentials secret %q: %w", secretName, err)
+	}
+
+	return []apiv1.OptionSpec{
+		{Name: "user", Value: username, Ensure: apiv1.EnsurePresent},
+		{Name: "password", Value: password, Ensure: apiv1.EnsurePresent},
+	}, secret.ResourceVersion, nil
+}
+
+// getUserMappingsSecretsResourceVersion returns the current resource version
+// of the secrets used by the user mappings of the database
+func (r *DatabaseReconciler) getUserMappingsSecretsResourceVersion(
+	ctx context.Context,
+	obj *apiv1.Database,
+) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, secretName := range obj.GetUserMappingsSecretNames() {
+		var secret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: obj.Namespace, Name: secretName}, &secret); err != nil {
+			return nil, fmt.Errorf("while getting the credentials secret %q: %w", secretName, err)
+		}
+		result[secretName] = secret.ResourceVersion
+	}
+	return result, nil
+}
+
+const detectDatabaseUserMappingSQL = `
+SELECT umoptions
+FROM pg_catalog.pg_user_mappings
+WHERE srvname = $1 AND usename = $2
+`
+
+func getDatabaseUserMappingInfo(ctx context.Context, db *sql.DB, mapping userMapping) (*userMappingInfo, error) {
+	var optionsRaw pq.StringArray
+
+	user := mapping.User
+	if mapping.isPublic() {
+		user = publicUserMapping
+	}
+
+	if err := db.QueryRowContext(
+		ctx, detectDatabaseUserMappingSQL,
+		mapping.server, user).Scan(&optionsRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while scanning if user mapping %q exists: %w", mapping.GetName(), err)
+	}
+
+	opts, err := parseOptions(optionsRaw)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing options of user mapping %q: %w", mapping.GetName(), err)
+	}
+
+	return &userMappingInfo{Options: opts}, nil
+}
+
+// createDatabaseUserMapping creates a user mapping for a foreign server
+func createDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping) error {
+	contextLogger := log.FromContext(ctx)
+
+	var sqlCreateUserMapping strings.Builder
+	sqlCreateUserMapping.WriteString(fmt.Sprintf("CREATE USER MAPPING FOR %s SERVER %s",
+		mapping.sanitizedUser(),
+		pgx.Identifier{mapping.server}.Sanitize()))
+
+	if opts := extractOptionsClauses(mapping.options); len(opts) > 0 {
+		sqlCreateUserMapping.WriteString(" OPTIONS (" + strings.Join(opts, ", ") + ")")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlCreateUserMapping.String()); err != nil {
+		// The query is not logged as it may contain a password
+		contextLogger.Error(err, "while creating user mapping", "server", mapping.server, "user", mapping.User)
+		return err
+	}
+	contextLogger.Info("created user mapping", "server", mapping.server, "user", mapping.User)
+
+	return nil
+}
+
+// updateDatabaseUserMapping alters the options of a user mapping, re-applying
+// the credentials when they changed in the secret or in the database
+func updateDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping, info *userMappingInfo) error {
+	contextLogger := log.FromContext(ctx)
+
+	toUpdateOpts := calculateAlterOptionsClauses(mapping.options, info.Options)
+	if len(toUpdateOpts) == 0 {
+		return nil
+	}
+
+	changeOptionSQL := fmt.Sprintf(
+		"ALTER USER MAPPING FOR %s SERVER %s OPTIONS (%s)",
+		mapping.sanitizedUser(),
+		pgx.Identifier{mapping.server}.Sanitize(),
+		strings.Join(toUpdateOpts, ", "),
+	)
+	if _, err := db.ExecContext(ctx, changeOptionSQL); err != nil {
+		return fmt.Errorf("altering options of user mapping %w", err)
+	}
+	contextLogger.Info("altered user mapping options", "server", mapping.server, "user", mapping.User)
+
+	return nil
+}
+
+// dropDatabaseUserMapping drops a user mapping from a foreign server
+func dropDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping) error {
+	contextLogger := log.FromContext(ctx)
+	query := fmt.Sprintf("DROP USER MAPPING IF EXISTS FOR %s SERVER %s",
+		mapping.sanitizedUser(),
+		pgx.Identifier{mapping.server}.Sanitize())
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping user mapping", "query", query)
+		return err
+	}
+	contextLogger.Info("dropped user mapping", "server", mapping.server, "user", mapping.User)
+	return nil
+}
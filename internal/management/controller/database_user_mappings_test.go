@@ -0,0 +1,271 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managed User Mapping SQL", func() {
+	var (
+		dbMock  sqlmock.Sqlmock
+		db      *sql.DB
+		mapping userMapping
+		err     error
+
+		testError error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		mapping = userMapping{
+			UserMappingSpec: apiv1.UserMappingSpec{
+				User:   "app",
+				Ensure: apiv1.EnsurePresent,
+			},
+			server: "testserver",
+			options: []apiv1.OptionSpec{
+				{Name: "user", Value: "remote", Ensure: apiv1.EnsurePresent},
+				{Name: "password", Value: "secret", Ensure: apiv1.EnsurePresent},
+			},
+		}
+
+		testError = fmt.Errorf("test error")
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	Context("getDatabaseUserMappingInfo", func() {
+		It("returns info when the user mapping exists", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseUserMappingSQL).
+				WithArgs("testserver", "app").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"umoptions"}).
+						AddRow("{user=remote,password=secret}"),
+				)
+			info, err := getDatabaseUserMappingInfo(ctx, db, mapping)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).ToNot(BeNil())
+			Expect(info.Options).To(Equal(map[string]string{"user": "remote", "password": "secret"}))
+		})
+
+		It("looks for the public user mapping regardless of the case", func(ctx SpecContext) {
+			mapping.User = "PUBLIC"
+			dbMock.
+				ExpectQuery(detectDatabaseUserMappingSQL).
+				WithArgs("testserver", "public").
+				WillReturnRows(sqlmock.NewRows([]string{"umoptions"}))
+			info, err := getDatabaseUserMappingInfo(ctx, db, mapping)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+	})
+
+	Context("createDatabaseUserMapping", func() {
+		It("creates the user mapping with its options", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("CREATE USER MAPPING FOR \"app\" SERVER \"testserver\"" +
+					" OPTIONS (\"user\" 'remote', \"password\" 'secret')").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(createDatabaseUserMapping(ctx, db, mapping)).To(Succeed())
+		})
+
+		It("creates the public user mapping", func(ctx SpecContext) {
+			mapping.User = "public"
+			mapping.options = nil
+			dbMock.
+				ExpectExec("CREATE USER MAPPING FOR PUBLIC SERVER \"testserver\"").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(createDatabaseUserMapping(ctx, db, mapping)).To(Succeed())
+		})
+
+		It("fails when the user mapping could not be created", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("CREATE USER MAPPING FOR \"app\" SERVER \"testserver\"" +
+					" OPTIONS (\"user\" 'remote', \"password\" 'secret')").
+				WillReturnError(testError)
+			Expect(createDatabaseUserMapping(ctx, db, mapping)).To(Equal(testError))
+		})
+	})
+
+	Context("updateDatabaseUserMapping", func() {
+		It("does nothing when the user mapping is up to date", func(ctx SpecContext) {
+			Expect(updateDatabaseUserMapping(ctx, db, mapping, &userMappingInfo{
+				Options: map[string]string{"user": "remote", "password": "secret"},
+			})).To(Succeed())
+		})
+
+		It("re-applies the password when it is changed", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("ALTER USER MAPPING FOR \"app\" SERVER \"testserver\" OPTIONS (SET \"password\" 'secret')").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(updateDatabaseUserMapping(ctx, db, mapping, &userMappingInfo{
+				Options: map[string]string{"user": "remote", "password": "drifted"},
+			})).To(Succeed())
+		})
+
+		It("adds the missing options", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("ALTER USER MAPPING FOR \"app\" SERVER \"testserver\" OPTIONS " +
+					"(ADD \"user\" 'remote', ADD \"password\" 'secret')").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(updateDatabaseUserMapping(ctx, db, mapping, &userMappingInfo{})).To(Succeed())
+		})
+	})
+
+	Context("dropDatabaseUserMapping", func() {
+		It("drops the user mapping", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("DROP USER MAPPING IF EXISTS FOR \"app\" SERVER \"testserver\"").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(dropDatabaseUserMapping(ctx, db, mapping)).To(Succeed())
+		})
+
+		It("returns an error when the DROP statement failed", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("DROP USER MAPPING IF EXISTS FOR \"app\" SERVER \"testserver\"").
+				WillReturnError(testError)
+			Expect(dropDatabaseUserMapping(ctx, db, mapping)).To(Equal(testError))
+		})
+	})
+})
+
+var _ = Describe("User mappings reconciliation", func() {
+	var (
+		dbMock   sqlmock.Sqlmock
+		db       *sql.DB
+		database *apiv1.Database
+		r        *DatabaseReconciler
+		err      error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		database = &apiv1.Database{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db-one",
+				Namespace: "default",
+			},
+			Spec: apiv1.DatabaseSpec{
+				Name: "db-one",
+				Servers: []apiv1.ServerSpec{
+					{
+						DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "testserver", Ensure: apiv1.EnsurePresent},
+						FdwName:            "postgres_fdw",
+						UserMappings: []apiv1.UserMappingSpec{
+							{
+								User:              "app",
+								Ensure:            apiv1.EnsurePresent,
+								CredentialsSecret: &apiv1.LocalObjectReference{Name: "remote-credentials"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "remote-credentials",
+				Namespace: "default",
+			},
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("remote"),
+				corev1.BasicAuthPasswordKey: []byte("secret"),
+			},
+		}
+
+		r = &DatabaseReconciler{
+			Client: fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(secret).
+				Build(),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("creates the user mappings using the credentials secret", func(ctx SpecContext) {
+		dbMock.
+			ExpectQuery(detectDatabaseUserMappingSQL).
+			WithArgs("testserver", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"umoptions"}))
+		dbMock.
+			ExpectExec("CREATE USER MAPPING FOR \"app\" SERVER \"testserver\"" +
+				" OPTIONS (\"user\" 'remote', \"password\" 'secret')").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		statuses, secretsVersion := r.reconcileUserMappings(ctx, db, database, apiv1.EnsurePresent)
+		Expect(statuses).To(ConsistOf(apiv1.DatabaseObjectStatus{Name: "testserver/app", Applied: true}))
+		Expect(secretsVersion).To(HaveKey("remote-credentials"))
+		Expect(r.userMappingsSecretsChanged(ctx, database)).To(BeTrue())
+
+		database.Status.SecretsResourceVersion = secretsVersion
+		Expect(r.userMappingsSecretsChanged(ctx, database)).To(BeFalse())
+	})
+
+	It("drops the user mappings of the servers being removed", func(ctx SpecContext) {
+		database.Spec.Servers[0].Ensure = apiv1.EnsureAbsent
+		dbMock.
+			ExpectQuery(detectDatabaseUserMappingSQL).
+			WithArgs("testserver", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"umoptions"}).AddRow("{user=remote,password=secret}"))
+		dbMock.
+			ExpectExec("DROP USER MAPPING IF EXISTS FOR \"app\" SERVER \"testserver\"").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		statuses, _ := r.reconcileUserMappings(ctx, db, database, apiv1.EnsurePresent)
+		Expect(statuses).To(BeEmpty())
+
+		statuses, secretsVersion := r.reconcileUserMappings(ctx, db, database, apiv1.EnsureAbsent)
+		Expect(statuses).To(ConsistOf(apiv1.DatabaseObjectStatus{Name: "testserver/app", Applied: true}))
+		Expect(secretsVersion).To(BeEmpty())
+	})
+
+	It("reports a failure when the credentials secret does not exist", func(ctx SpecContext) {
+		database.Spec.Servers[0].UserMappings[0].CredentialsSecret.Name = "missing"
+
+		statuses, _ := r.reconcileUserMappings(ctx, db, database, apiv1.EnsurePresent)
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].Applied).To(BeFalse())
+		Expect(statuses[0].Message).To(ContainSubstring("missing"))
+	})
+})
@@ -0,0 +1,213 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// authenticationRules are the pg_hba.conf and pg_ident.conf rules loaded
+// from the Secrets and ConfigMaps referenced in the cluster
+type authenticationRules struct {
+	hba   []string
+	ident []string
+
+	// the resource versions of the objects containing the rules.
+	// Map keys are the object names, map values are the versions
+	secretVersions    map[string]string
+	configMapVersions map[string]string
+}
+
+// reconcileAuthenticationRules loads the pg_hba.conf and pg_ident.conf rules
+// from the Secrets and ConfigMaps referenced in the cluster, publishing the
+// versions of the applied objects in the status of the instance. When the
+// rules cannot be loaded or are not valid, they are refused, as reloading
+// PostgreSQL with them could break the connectivity: the previously applied
+// rules are kept, and the error is reported with an event and in the status
+func (r *InstanceReconciler) reconcileAuthenticationRules(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) authenticationRules {
+	postgresConfig := &cluster.Spec.PostgresConfiguration
+	if postgresConfig.PgHBARefs == nil && postgresConfig.PgIdentRefs == nil {
+		r.authenticationRules = authenticationRules{}
+		r.instance.SetAuthenticationRulesStatus(nil)
+		return r.authenticationRules
+	}
+
+	rules, err := r.loadAuthenticationRules(ctx, cluster)
+	if err != nil {
+		currentStatus := r.instance.GetAuthenticationRulesStatus()
+		if currentStatus == nil || currentStatus.Error != err.Error() {
+			log.FromContext(ctx).Warning("Refusing the referenced authentication rules", "err", err)
+			r.recorder.Eventf(cluster, "Warning", "InvalidAuthenticationRules",
+				"Refusing the referenced authentication rules: %v", err)
+		}
+		r.instance.SetAuthenticationRulesStatus(r.authenticationRules.buildStatus(err))
+		return r.authenticationRules
+	}
+
+	r.authenticationRules = rules
+	r.instance.SetAuthenticationRulesStatus(rules.buildStatus(nil))
+	return rules
+}
+
+// buildStatus builds the status of the authentication rules reported
+// by the instance
+func (rules authenticationRules) buildStatus(err error) *postgres.AuthenticationRulesStatus {
+	status := &postgres.AuthenticationRulesStatus{
+		SecretVersions:    rules.secretVersions,
+		ConfigMapVersions: rules.configMapVersions,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// loadAuthenticationRules loads and validates the rules contained in the
+// Secrets and ConfigMaps referenced in the cluster. When requireSCRAMOverSSL
+// is enabled, the pg_hba.conf rules weakening it are refused like the
+// inline ones are by the webhook
+func (r *InstanceReconciler) loadAuthenticationRules(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (authenticationRules, error) {
+	rules := authenticationRules{
+		secretVersions:    make(map[string]string),
+		configMapVersions: make(map[string]string),
+	}
+
+	var err error
+	postgresConfig := &cluster.Spec.PostgresConfiguration
+	validateHBARule := postgres.ValidateHBARule
+	if postgresConfig.RequireSCRAMOverSSL {
+		validateHBARule = func(rule string) error {
+			if err := postgres.ValidateHBARule(rule); err != nil {
+				return err
+			}
+			if err := postgres.ValidateHBARuleSCRAMOverSSL(rule); err != nil {
+				return fmt.Errorf("%w when requireSCRAMOverSSL is enabled, in %q", err, rule)
+			}
+			return nil
+		}
+	}
+	rules.hba, err = r.loadAuthenticationRulesFromRefs(ctx, postgresConfig.PgHBARefs, &rules, validateHBARule)
+	if err != nil {
+		return authenticationRules{}, fmt.Errorf("while loading the pg_hba.conf rules: %w", err)
+	}
+
+	rules.ident, err = r.loadAuthenticationRulesFromRefs(
+		ctx, postgresConfig.PgIdentRefs, &rules, postgres.ValidateIdentRule)
+	if err != nil {
+		return authenticationRules{}, fmt.Errorf("while loading the pg_ident.conf rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// loadAuthenticationRulesFromRefs loads the rules contained in the passed
+// references, first the Secrets then the ConfigMaps, recording the versions
+// of the objects that have been read
+func (r *InstanceReconciler) loadAuthenticationRulesFromRefs(
+	ctx context.Context,
+	refs *apiv1.AuthenticationRulesRefs,
+	rules *authenticationRules,
+	validate func(string) error,
+) ([]string, error) {
+	if refs == nil {
+		return nil, nil
+	}
+
+	var result []string
+	for _, ref := range refs.SecretRefs {
+		var secret corev1.Secret
+		if err := r.client.Get(ctx, types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: r.instance.GetNamespaceName(),
+		}, &secret); err != nil {
+			return nil, fmt.Errorf("while getting secret %s: %w", ref.Name, err)
+		}
+
+		content, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("missing key %s in secret %s", ref.Key, ref.Name)
+		}
+
+		lines, err := parseAuthenticationRules(string(content), validate)
+		if err != nil {
+			return nil, fmt.Errorf("in key %s of secret %s: %w", ref.Key, ref.Name, err)
+		}
+		result = append(result, lines...)
+		rules.secretVersions[ref.Name] = secret.ResourceVersion
+	}
+
+	for _, ref := range refs.ConfigMapRefs {
+		var configMap corev1.ConfigMap
+		if err := r.client.Get(ctx, types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: r.instance.GetNamespaceName(),
+		}, &configMap); err != nil {
+			return nil, fmt.Errorf("while getting config map %s: %w", ref.Name, err)
+		}
+
+		content, ok := configMap.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("missing key %s in config map %s", ref.Key, ref.Name)
+		}
+
+		lines, err := parseAuthenticationRules(content, validate)
+		if err != nil {
+			return nil, fmt.Errorf("in key %s of config map %s: %w", ref.Key, ref.Name, err)
+		}
+		result = append(result, lines...)
+		rules.configMapVersions[ref.Name] = configMap.ResourceVersion
+	}
+
+	return result, nil
+}
+
+// parseAuthenticationRules splits the passed content in rules, one per line,
+// skipping the empty ones and validating the others
+func parseAuthenticationRules(content string, validate func(string) error) ([]string, error) {
+	var result []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if err := validate(line); err != nil {
+			return nil, err
+		}
+		result = append(result, line)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authentication rules from the referenced objects", func() {
+	var (
+		hbaSecret   *corev1.Secret
+		identConfig *corev1.ConfigMap
+		cluster     *apiv1.Cluster
+		recorder    *record.FakeRecorder
+		r           *InstanceReconciler
+	)
+
+	BeforeEach(func() {
+		hbaSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hba-rules", Namespace: "default"},
+			Data: map[string][]byte{
+				"rules": []byte("hostssl app app 10.0.0.0/8 scram-sha-256\n\nhost all all 10.0.0.0/8 reject\n"),
+			},
+		}
+		identConfig = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ident-rules", Namespace: "default"},
+			Data: map[string]string{
+				"rules": "mymap /^(.*)@example\\.com$ \\1",
+			},
+		}
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgHBARefs: &apiv1.AuthenticationRulesRefs{
+						SecretRefs: []apiv1.SecretKeySelector{
+							{LocalObjectReference: apiv1.LocalObjectReference{Name: "hba-rules"}, Key: "rules"},
+						},
+					},
+					PgIdentRefs: &apiv1.AuthenticationRulesRefs{
+						ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+							{LocalObjectReference: apiv1.LocalObjectReference{Name: "ident-rules"}, Key: "rules"},
+						},
+					},
+				},
+			},
+		}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		r = &InstanceReconciler{
+			client: fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(hbaSecret, identConfig).
+				Build(),
+			recorder: recorder,
+			instance: postgres.NewInstance().
+				WithNamespace("default").
+				WithPodName("cluster-example-1").
+				WithClusterName("cluster-example"),
+		}
+	})
+
+	It("loads the rules and reports the versions of the objects", func(ctx SpecContext) {
+		rules := r.reconcileAuthenticationRules(ctx, cluster)
+		Expect(rules.hba).To(Equal([]string{
+			"hostssl app app 10.0.0.0/8 scram-sha-256",
+			"host all all 10.0.0.0/8 reject",
+		}))
+		Expect(rules.ident).To(Equal([]string{"mymap /^(.*)@example\\.com$ \\1"}))
+
+		status := r.instance.GetAuthenticationRulesStatus()
+		Expect(status).ToNot(BeNil())
+		Expect(status.SecretVersions).To(HaveKey("hba-rules"))
+		Expect(status.ConfigMapVersions).To(HaveKey("ident-rules"))
+		Expect(status.Error).To(BeEmpty())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("keeps the previous rules when the new ones are not valid", func(ctx SpecContext) {
+		previous := r.reconcileAuthenticationRules(ctx, cluster)
+
+		hbaSecret.Data["rules"] = []byte("host all all 10.0.0.0/8 scram")
+		Expect(r.client.Update(ctx, hbaSecret)).To(Succeed())
+
+		Expect(r.reconcileAuthenticationRules(ctx, cluster)).To(Equal(previous))
+		status := r.instance.GetAuthenticationRulesStatus()
+		Expect(status.Error).To(ContainSubstring("unknown authentication method"))
+		Expect(status.SecretVersions).To(Equal(previous.secretVersions))
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAuthenticationRules")))
+
+		// The same error is not reported again
+		r.reconcileAuthenticationRules(ctx, cluster)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("refuses the rules weakening requireSCRAMOverSSL", func(ctx SpecContext) {
+		cluster.Spec.PostgresConfiguration.RequireSCRAMOverSSL = true
+		previous := r.reconcileAuthenticationRules(ctx, cluster)
+		Expect(previous.hba).To(HaveLen(2))
+		Expect(r.instance.GetAuthenticationRulesStatus().Error).To(BeEmpty())
+
+		for _, rule := range []string{"host all all 0.0.0.0/0 md5", "hostnossl all all all trust"} {
+			hbaSecret.Data["rules"] = []byte(rule)
+			Expect(r.client.Update(ctx, hbaSecret)).To(Succeed())
+
+			Expect(r.reconcileAuthenticationRules(ctx, cluster)).To(Equal(previous))
+			status := r.instance.GetAuthenticationRulesStatus()
+			Expect(status.Error).To(ContainSubstring("requireSCRAMOverSSL"))
+			Expect(status.Error).To(ContainSubstring(rule))
+		}
+
+		// The same rules are accepted without requireSCRAMOverSSL
+		cluster.Spec.PostgresConfiguration.RequireSCRAMOverSSL = false
+		Expect(r.reconcileAuthenticationRules(ctx, cluster).hba).To(Equal([]string{"hostnossl all all all trust"}))
+	})
+
+	It("refuses the rules when a referenced object is missing", func(ctx SpecContext) {
+		cluster.Spec.PostgresConfiguration.PgHBARefs.SecretRefs[0].Name = "missing"
+
+		rules := r.reconcileAuthenticationRules(ctx, cluster)
+		Expect(rules.hba).To(BeEmpty())
+		Expect(r.instance.GetAuthenticationRulesStatus().Error).To(ContainSubstring("missing"))
+	})
+
+	It("resets the status when no object is referenced", func(ctx SpecContext) {
+		r.reconcileAuthenticationRules(ctx, cluster)
+
+		cluster.Spec.PostgresConfiguration.PgHBARefs = nil
+		cluster.Spec.PostgresConfiguration.PgIdentRefs = nil
+		Expect(r.reconcileAuthenticationRules(ctx, cluster)).To(Equal(authenticationRules{}))
+		Expect(r.instance.GetAuthenticationRulesStatus()).To(BeNil())
+	})
+})
@@ -0,0 +1,535 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// defaultCollationOID is the OID of the collation representing the
+// default collation of the database
+const defaultCollationOID = 100
+
+// collationVersionMismatch is a collation whose version recorded in the
+// catalog differs from the one provided by the operating system
+type collationVersionMismatch struct {
+	oid int64
+	// the name of the collation, empty for the default collation of the database
+	name            string
+	recordedVersion string
+	actualVersion   string
+}
+
+const detectCollationVersionMismatchSQL = `
+SELECT c.oid::bigint, c.oid::pg_catalog.regcollation::text,
+	c.collversion, COALESCE(pg_catalog.pg_collation_actual_version(c.oid), '')
+FROM pg_catalog.pg_collation c
+WHERE c.collversion IS NOT NULL
+	AND c.collversion IS DISTINCT FROM pg_catalog.pg_collation_actual_version(c.oid)
+`
+
+// detectDatabaseCollationVersionMismatchSQL is only available from PostgreSQL 15,
+// where the version of the default collation is tracked in pg_database
+const detectDatabaseCollationVersionMismatchSQL = `
+SELECT 100::bigint, '',
+	d.datcollversion, COALESCE(pg_catalog.pg_database_collation_actual_version(d.oid), '')
+FROM pg_catalog.pg_database d
+WHERE d.datname = pg_catalog.current_database()
+	AND d.datcollversion IS NOT NULL
+	AND d.datcollversion IS DISTINCT FROM pg_catalog.pg_database_collation_actual_version(d.oid)
+`
+
+// detectIndexesUsingCollationsSQL lists the user indexes depending on
+// any of the passed collations
+const detectIndexesUsingCollationsSQL = `
+SELECT DISTINCT i.indexrelid::pg_catalog.regclass::text
+FROM pg_catalog.pg_index i,
+	pg_catalog.unnest(i.indcollation::pg_catalog.oid[]) AS coll(oid)
+WHERE coll.oid = ANY($1::pg_catalog.oid[])
+	AND i.indexrelid >= 16384
+ORDER BY 1
+`
+
+// collationVersionsRetryInterval is the time after which a failed check
+// of the collation versions is retried
+const collationVersionsRetryInterval = time.Hour
+
+// collationReindexMaxAttempts is the number of maintenance windows in which
+// the rebuild of the affected indexes is attempted before giving up
+const collationReindexMaxAttempts = 3
+
+// errCollationReindexWindowEnded is returned when the maintenance window ends
+// before every affected index has been rebuilt
+var errCollationReindexWindowEnded = errors.New("the maintenance window ended")
+
+// collationVersionsStatus tracks the check of the collation versions. It is
+// shared with the goroutine rebuilding the affected indexes
+type collationVersionsStatus struct {
+	mu sync.Mutex
+
+	// the policy with which the collation versions have been checked,
+	// empty if they have not been successfully checked yet
+	checkedWith apiv1.CollationVersionMismatchPolicy
+
+	// the policy with which the check is to be repeated, and when
+	retryWith apiv1.CollationVersionMismatchPolicy
+	retryAt   time.Time
+
+	// the number of maintenance windows in which the rebuild failed
+	reindexFailures int
+
+	// true while the affected indexes are being rebuilt
+	reindexRunning bool
+}
+
+// isCheckNeeded tells whether the collation versions need to be checked
+// with the passed policy
+func (s *collationVersionsStatus) isCheckNeeded(policy apiv1.CollationVersionMismatchPolicy, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reindexRunning || s.checkedWith == policy {
+		return false
+	}
+
+	return s.retryWith != policy || !now.Before(s.retryAt)
+}
+
+// setResult records the result of the check with the passed policy. A
+// failed check is repeated after collationVersionsRetryInterval
+func (s *collationVersionsStatus) setResult(policy apiv1.CollationVersionMismatchPolicy, err error) {
+	if err != nil {
+		s.setRetryAt(policy, time.Now().Add(collationVersionsRetryInterval))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reindexRunning = false
+	s.reindexFailures = 0
+	s.checkedWith = policy
+	s.retryWith = ""
+	s.retryAt = time.Time{}
+}
+
+// setRetryAt records that the check with the passed policy is to be
+// repeated at the passed time
+func (s *collationVersionsStatus) setRetryAt(policy apiv1.CollationVersionMismatchPolicy, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reindexRunning = false
+	s.checkedWith = ""
+	s.retryWith = policy
+	s.retryAt = at
+}
+
+// tryStartReindex records that the affected indexes are being rebuilt,
+// returning false if they are already being rebuilt
+func (s *collationVersionsStatus) tryStartReindex() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reindexRunning {
+		return false
+	}
+	s.reindexRunning = true
+	return true
+}
+
+// addReindexFailure records a failed rebuild, returning the number of
+// the failed ones
+func (s *collationVersionsStatus) addReindexFailure() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reindexFailures++
+	return s.reindexFailures
+}
+
+// collationReindexPlan holds the indexes of a database to be rebuilt because
+// of a collation version mismatch, and the collations whose version is to be
+// refreshed afterwards
+type collationReindexPlan struct {
+	db           *sql.DB
+	databaseName string
+	mismatches   []collationVersionMismatch
+	indexes      []string
+}
+
+// detectCollationReindexPlan detects the collations whose version changed
+// in the passed database and logs the indexes depending on them. When the
+// policy requires the indexes to be rebuilt, it returns the plan to do it
+func detectCollationReindexPlan(
+	ctx context.Context,
+	db *sql.DB,
+	databaseName string,
+	pgMajor uint64,
+	policy apiv1.CollationVersionMismatchPolicy,
+) (*collationReindexPlan, error) {
+	contextLogger := log.FromContext(ctx).WithValues("database", databaseName)
+
+	mismatches, err := detectCollationVersionMismatches(ctx, db, pgMajor)
+	if err != nil {
+		return nil, fmt.Errorf("while detecting collation version mismatches: %w", err)
+	}
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	oids := make([]int64, len(mismatches))
+	for i, mismatch := range mismatches {
+		oids[i] = mismatch.oid
+		contextLogger.Warning("Detected a collation version mismatch",
+			"collation", mismatch.getDisplayName(),
+			"recordedVersion", mismatch.recordedVersion,
+			"actualVersion", mismatch.actualVersion)
+	}
+
+	indexes, err := detectIndexesUsingCollations(ctx, db, oids)
+	if err != nil {
+		return nil, fmt.Errorf("while detecting the indexes affected by a collation version mismatch: %w", err)
+	}
+	if len(indexes) > 0 {
+		contextLogger.Warning("Indexes depending on a collation whose version changed might be corrupted",
+			"indexes", indexes, "policy", policy)
+	}
+
+	if policy != apiv1.CollationVersionMismatchReindex {
+		return nil, nil
+	}
+
+	return &collationReindexPlan{
+		db:           db,
+		databaseName: databaseName,
+		mismatches:   mismatches,
+		indexes:      indexes,
+	}, nil
+}
+
+// execute rebuilds the affected indexes and, when all of them have been
+// rebuilt, refreshes the collation versions. It uses a dedicated connection,
+// and doesn't start rebuilding an index after the passed end of the
+// maintenance window
+func (plan *collationReindexPlan) execute(ctx context.Context, windowEnd time.Time) error {
+	contextLogger := log.FromContext(ctx).WithValues("database", plan.databaseName)
+
+	conn, err := plan.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("while getting a connection to database %s: %w", plan.databaseName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			contextLogger.Error(err, "while closing the connection used to rebuild the indexes")
+		}
+	}()
+
+	for _, index := range plan.indexes {
+		if !time.Now().Before(windowEnd) {
+			return errCollationReindexWindowEnded
+		}
+
+		contextLogger.Info("Rebuilding index", "index", index)
+		// the index name is quoted by PostgreSQL when converted from regclass
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("REINDEX INDEX CONCURRENTLY %s", index)); err != nil {
+			return fmt.Errorf("while rebuilding index %s in database %s: %w", index, plan.databaseName, err)
+		}
+	}
+
+	for _, mismatch := range plan.mismatches {
+		query := fmt.Sprintf("ALTER COLLATION %s REFRESH VERSION", mismatch.name)
+		if mismatch.oid == defaultCollationOID {
+			query = fmt.Sprintf("ALTER DATABASE %s REFRESH COLLATION VERSION",
+				pgx.Identifier{plan.databaseName}.Sanitize())
+		}
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("while refreshing the version of collation %s in database %s: %w",
+				mismatch.getDisplayName(), plan.databaseName, err)
+		}
+		contextLogger.Info("Refreshed collation version",
+			"collation", mismatch.getDisplayName(), "version", mismatch.actualVersion)
+	}
+
+	return nil
+}
+
+// reconcileCollationReindex records the result of the check of the collation
+// versions. With the reindex policy, the affected indexes are rebuilt in
+// background during the configured maintenance window, as rebuilding them
+// may take a long time on big databases, and the progress is reported in the
+// CollationVersionsUpToDate condition. The check is recorded as done when
+// all of them have been rebuilt, or when the rebuild failed in
+// collationReindexMaxAttempts windows
+func (r *InstanceReconciler) reconcileCollationReindex(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	policy apiv1.CollationVersionMismatchPolicy,
+	plans []collationReindexPlan,
+	checkErr error,
+) {
+	contextLogger := log.FromContext(ctx)
+
+	if checkErr != nil {
+		r.collationVersions.setResult(policy, checkErr)
+		return
+	}
+
+	if len(plans) == 0 {
+		r.collationVersions.setResult(policy, nil)
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionCollationVersionsUpToDate))
+		if condition != nil && condition.Status != metav1.ConditionTrue {
+			r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionTrue,
+				apiv1.ConditionReasonCollationReindexCompleted,
+				"No index depends on a collation whose version changed")
+		}
+		return
+	}
+
+	window := cluster.Spec.PostgresConfiguration.CollationReindexWindow
+	if window == nil {
+		r.collationVersions.setResult(policy, errors.New("no maintenance window"))
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+			apiv1.ConditionReasonCollationReindexFailed,
+			"The indexes depending on a collation whose version changed can't be rebuilt "+
+				"without a maintenance window, set .spec.postgresql.collationReindexWindow")
+		return
+	}
+
+	inWindow, windowStart, err := utils.GetMaintenanceWindowStatus(window.Schedule, window.GetDuration(), time.Now())
+	if err != nil {
+		r.collationVersions.setResult(policy, err)
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+			apiv1.ConditionReasonCollationReindexFailed, err.Error())
+		return
+	}
+
+	if !inWindow {
+		contextLogger.Info("Postponing the rebuild of the indexes affected by a collation version mismatch "+
+			"until the next maintenance window", "nextMaintenanceWindow", windowStart)
+		r.collationVersions.setRetryAt(policy, windowStart)
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+			apiv1.ConditionReasonCollationReindexPending,
+			fmt.Sprintf("%d indexes depending on a collation whose version changed will be rebuilt "+
+				"in the maintenance window starting at %s", countCollationReindexIndexes(plans),
+				windowStart.UTC().Format(time.RFC3339)))
+		return
+	}
+
+	if !r.collationVersions.tryStartReindex() {
+		return
+	}
+
+	r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+		apiv1.ConditionReasonCollationReindexRunning,
+		fmt.Sprintf("Rebuilding %d indexes depending on a collation whose version changed",
+			countCollationReindexIndexes(plans)))
+
+	// The cluster is passed to the goroutine as a copy, as the reconciliation
+	// loop keeps using its own one
+	reindexedCluster := cluster.DeepCopy()
+	windowEnd := windowStart.Add(window.GetDuration())
+	go func() {
+		var reindexErr error
+		for i := range plans {
+			if reindexErr = plans[i].execute(ctx, windowEnd); reindexErr != nil {
+				break
+			}
+		}
+		r.completeCollationReindex(ctx, reindexedCluster, policy, window, windowEnd, reindexErr)
+	}()
+}
+
+// completeCollationReindex records and reports the result of the rebuild of
+// the affected indexes. An interrupted or failed rebuild is resumed in the
+// next maintenance window, unless it already failed too many times
+func (r *InstanceReconciler) completeCollationReindex(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	policy apiv1.CollationVersionMismatchPolicy,
+	window *apiv1.CollationReindexWindow,
+	windowEnd time.Time,
+	reindexErr error,
+) {
+	contextLogger := log.FromContext(ctx)
+
+	if reindexErr == nil {
+		contextLogger.Info("The indexes affected by a collation version mismatch have been rebuilt")
+		r.collationVersions.setResult(policy, nil)
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionTrue,
+			apiv1.ConditionReasonCollationReindexCompleted,
+			"The indexes depending on a collation whose version changed have been rebuilt, "+
+				"and the collation versions refreshed")
+		return
+	}
+
+	nextWindow := windowEnd.Add(collationVersionsRetryInterval)
+	if _, windowStart, err := utils.GetMaintenanceWindowStatus(
+		window.Schedule, window.GetDuration(), windowEnd,
+	); err == nil {
+		nextWindow = windowStart
+	}
+
+	if errors.Is(reindexErr, errCollationReindexWindowEnded) {
+		contextLogger.Info("The maintenance window ended before rebuilding every index affected by "+
+			"a collation version mismatch", "nextMaintenanceWindow", nextWindow)
+		r.collationVersions.setRetryAt(policy, nextWindow)
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+			apiv1.ConditionReasonCollationReindexPending,
+			fmt.Sprintf("The maintenance window ended before rebuilding every index depending on a "+
+				"collation whose version changed, the rebuild continues in the window starting at %s",
+				nextWindow.UTC().Format(time.RFC3339)))
+		return
+	}
+
+	contextLogger.Error(reindexErr, "while rebuilding the indexes affected by a collation version mismatch")
+	failures := r.collationVersions.addReindexFailure()
+	if failures >= collationReindexMaxAttempts {
+		// Give up, reporting the error, until the policy changes or
+		// the instance manager is restarted
+		r.collationVersions.setResult(policy, nil)
+		r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+			apiv1.ConditionReasonCollationReindexFailed,
+			fmt.Sprintf("The indexes depending on a collation whose version changed could not be rebuilt "+
+				"in %d maintenance windows, rebuild them manually and refresh the collation versions: %s",
+				failures, reindexErr.Error()))
+		return
+	}
+
+	r.collationVersions.setRetryAt(policy, nextWindow)
+	r.patchCollationVersionsCondition(ctx, cluster, metav1.ConditionFalse,
+		apiv1.ConditionReasonCollationReindexFailed,
+		fmt.Sprintf("The rebuild of the indexes depending on a collation whose version changed failed "+
+			"(attempt %d of %d), it will be retried in the window starting at %s: %s",
+			failures, collationReindexMaxAttempts, nextWindow.UTC().Format(time.RFC3339), reindexErr.Error()))
+}
+
+// patchCollationVersionsCondition reports the rebuild of the affected indexes
+// in the CollationVersionsUpToDate condition of the cluster
+func (r *InstanceReconciler) patchCollationVersionsCondition(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	conditionStatus metav1.ConditionStatus,
+	reason apiv1.ConditionReason,
+	message string,
+) {
+	if err := status.PatchConditionsWithOptimisticLock(ctx, r.client, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionCollationVersionsUpToDate),
+		Status:  conditionStatus,
+		Reason:  string(reason),
+		Message: message,
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "while reporting the rebuild of the indexes affected by "+
+			"a collation version mismatch")
+	}
+}
+
+func countCollationReindexIndexes(plans []collationReindexPlan) int {
+	result := 0
+	for i := range plans {
+		result += len(plans[i].indexes)
+	}
+	return result
+}
+
+func (mismatch collationVersionMismatch) getDisplayName() string {
+	if mismatch.oid == defaultCollationOID {
+		return "default"
+	}
+	return mismatch.name
+}
+
+func detectCollationVersionMismatches(
+	ctx context.Context,
+	db *sql.DB,
+	pgMajor uint64,
+) ([]collationVersionMismatch, error) {
+	queries := []string{detectCollationVersionMismatchSQL}
+	if pgMajor >= 15 {
+		queries = append(queries, detectDatabaseCollationVersionMismatchSQL)
+	}
+
+	var result []collationVersionMismatch
+	for _, query := range queries {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var mismatch collationVersionMismatch
+			if err := rows.Scan(
+				&mismatch.oid,
+				&mismatch.name,
+				&mismatch.recordedVersion,
+				&mismatch.actualVersion,
+			); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			result = append(result, mismatch)
+		}
+
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func detectIndexesUsingCollations(ctx context.Context, db *sql.DB, oids []int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, detectIndexesUsingCollationsSQL, pq.Array(oids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []string
+	for rows.Next() {
+		var index string
+		if err := rows.Scan(&index); err != nil {
+			return nil, err
+		}
+		result = append(result, index)
+	}
+
+	return result, rows.Err()
+}
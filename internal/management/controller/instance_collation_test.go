@@ -0,0 +1,240 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("collation version reconciliation", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+		err    error
+	)
+
+	mismatchColumns := []string{"oid", "name", "collversion", "actual_version"}
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("does nothing when the collation versions match", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns))
+		dbMock.ExpectQuery(detectDatabaseCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns))
+
+		Expect(detectCollationReindexPlan(ctx, db, "app", 16, apiv1.CollationVersionMismatchReindex)).
+			To(BeNil())
+	})
+
+	It("only detects the affected indexes with the warn policy", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns).AddRow(int64(12345), `"en-x-icu"`, "153.14", "153.120"))
+		dbMock.ExpectQuery(detectIndexesUsingCollationsSQL).
+			WithArgs("{12345}").
+			WillReturnRows(sqlmock.NewRows([]string{"index"}).AddRow("users_name_idx"))
+
+		Expect(detectCollationReindexPlan(ctx, db, "app", 14, apiv1.CollationVersionMismatchWarn)).
+			To(BeNil())
+	})
+
+	It("rebuilds the indexes and refreshes the versions with the reindex policy", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns).AddRow(int64(12345), `"en-x-icu"`, "153.14", "153.120"))
+		dbMock.ExpectQuery(detectDatabaseCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns).AddRow(int64(100), "", "2.28", "2.36"))
+		dbMock.ExpectQuery(detectIndexesUsingCollationsSQL).
+			WithArgs("{12345,100}").
+			WillReturnRows(sqlmock.NewRows([]string{"index"}).
+				AddRow("users_name_idx").
+				AddRow(`"Orders_pkey"`))
+
+		plan, err := detectCollationReindexPlan(ctx, db, "app", 16, apiv1.CollationVersionMismatchReindex)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan).ToNot(BeNil())
+		Expect(plan.indexes).To(Equal([]string{"users_name_idx", `"Orders_pkey"`}))
+
+		dbMock.ExpectExec("REINDEX INDEX CONCURRENTLY users_name_idx").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`REINDEX INDEX CONCURRENTLY "Orders_pkey"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`ALTER COLLATION "en-x-icu" REFRESH VERSION`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`ALTER DATABASE "app" REFRESH COLLATION VERSION`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(plan.execute(ctx, time.Now().Add(time.Hour))).To(Succeed())
+	})
+
+	It("doesn't refresh the versions when an index can't be rebuilt", func(ctx SpecContext) {
+		dbMock.ExpectQuery(detectCollationVersionMismatchSQL).
+			WillReturnRows(sqlmock.NewRows(mismatchColumns).AddRow(int64(12345), `"en-x-icu"`, "153.14", "153.120"))
+		dbMock.ExpectQuery(detectIndexesUsingCollationsSQL).
+			WithArgs("{12345}").
+			WillReturnRows(sqlmock.NewRows([]string{"index"}).AddRow("users_name_idx"))
+		dbMock.ExpectExec("REINDEX INDEX CONCURRENTLY users_name_idx").
+			WillReturnError(sql.ErrConnDone)
+
+		plan, err := detectCollationReindexPlan(ctx, db, "app", 14, apiv1.CollationVersionMismatchReindex)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.execute(ctx, time.Now().Add(time.Hour))).To(MatchError(sql.ErrConnDone))
+	})
+
+	It("doesn't rebuild the indexes after the end of the maintenance window", func(ctx SpecContext) {
+		plan := collationReindexPlan{db: db, databaseName: "app", indexes: []string{"users_name_idx"}}
+		Expect(plan.execute(ctx, time.Now())).To(MatchError(errCollationReindexWindowEnded))
+	})
+
+	Context("with the reindex policy", func() {
+		var (
+			cluster *apiv1.Cluster
+			cli     client.Client
+			r       *InstanceReconciler
+			plans   []collationReindexPlan
+		)
+
+		// A window open all day long, and one that is basically never open
+		openWindow := &apiv1.CollationReindexWindow{
+			Schedule: "0 0 0 * * *",
+			Duration: &metav1.Duration{Duration: 24 * time.Hour},
+		}
+		closedWindow := &apiv1.CollationReindexWindow{
+			Schedule: "0 0 0 1 1 *",
+			Duration: &metav1.Duration{Duration: time.Second},
+		}
+
+		isReindexRunning := func() bool {
+			r.collationVersions.mu.Lock()
+			defer r.collationVersions.mu.Unlock()
+			return r.collationVersions.reindexRunning
+		}
+
+		getCondition := func(ctx SpecContext) *metav1.Condition {
+			var liveCluster apiv1.Cluster
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+			return meta.FindStatusCondition(liveCluster.Status.Conditions,
+				string(apiv1.ConditionCollationVersionsUpToDate))
+		}
+
+		BeforeEach(func() {
+			cluster = &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+				Spec: apiv1.ClusterSpec{
+					PostgresConfiguration: apiv1.PostgresConfiguration{
+						CollationVersionMismatch: apiv1.CollationVersionMismatchReindex,
+						CollationReindexWindow:   openWindow,
+					},
+				},
+			}
+			cli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build()
+			r = &InstanceReconciler{client: cli}
+			plans = []collationReindexPlan{
+				{db: db, databaseName: "app", indexes: []string{"users_name_idx"}},
+			}
+		})
+
+		It("waits for the maintenance window", func(ctx SpecContext) {
+			cluster.Spec.PostgresConfiguration.CollationReindexWindow = closedWindow
+			r.reconcileCollationReindex(ctx, cluster, apiv1.CollationVersionMismatchReindex, plans, nil)
+
+			Expect(isReindexRunning()).To(BeFalse())
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex, time.Now())).To(BeFalse())
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex,
+				time.Now().AddDate(1, 0, 0))).To(BeTrue())
+			Expect(getCondition(ctx).Reason).To(Equal(string(apiv1.ConditionReasonCollationReindexPending)))
+		})
+
+		It("records the check when the indexes have been rebuilt", func(ctx SpecContext) {
+			dbMock.ExpectExec("REINDEX INDEX CONCURRENTLY users_name_idx").
+				WillDelayFor(100 * time.Millisecond).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			r.reconcileCollationReindex(ctx, cluster, apiv1.CollationVersionMismatchReindex, plans, nil)
+
+			// the rebuild is in progress, and can't be started twice
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex, time.Now())).To(BeFalse())
+			Expect(r.collationVersions.tryStartReindex()).To(BeFalse())
+			Expect(getCondition(ctx).Reason).To(Equal(string(apiv1.ConditionReasonCollationReindexRunning)))
+
+			Eventually(isReindexRunning).Should(BeFalse())
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex,
+				time.Now().AddDate(1, 0, 0))).To(BeFalse())
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchWarn, time.Now())).To(BeTrue())
+			condition := getCondition(ctx)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonCollationReindexCompleted)))
+		})
+
+		It("retries a failed rebuild in the next windows, and then gives up", func(ctx SpecContext) {
+			for attempt := 1; attempt <= collationReindexMaxAttempts; attempt++ {
+				dbMock.ExpectExec("REINDEX INDEX CONCURRENTLY users_name_idx").
+					WillReturnError(sql.ErrConnDone)
+				r.reconcileCollationReindex(ctx, cluster, apiv1.CollationVersionMismatchReindex, plans, nil)
+				Eventually(isReindexRunning).Should(BeFalse())
+
+				condition := getCondition(ctx)
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonCollationReindexFailed)))
+				if attempt < collationReindexMaxAttempts {
+					Expect(condition.Message).To(ContainSubstring("it will be retried"))
+					Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex,
+						time.Now())).To(BeFalse())
+					Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex,
+						time.Now().AddDate(0, 0, 1))).To(BeTrue())
+				} else {
+					Expect(condition.Message).To(ContainSubstring("rebuild them manually"))
+				}
+			}
+
+			Expect(r.collationVersions.isCheckNeeded(apiv1.CollationVersionMismatchReindex,
+				time.Now().AddDate(1, 0, 0))).To(BeFalse())
+		})
+
+		It("reports when no maintenance window is configured", func(ctx SpecContext) {
+			cluster.Spec.PostgresConfiguration.CollationReindexWindow = nil
+			r.reconcileCollationReindex(ctx, cluster, apiv1.CollationVersionMismatchReindex, plans, nil)
+
+			Expect(isReindexRunning()).To(BeFalse())
+			Expect(getCondition(ctx).Reason).To(Equal(string(apiv1.ConditionReasonCollationReindexFailed)))
+		})
+	})
+})
@@ -27,6 +27,7 @@ import (
 	"math"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"time"
 
@@ -277,12 +278,18 @@ func (r *InstanceReconciler) Reconcile(
 		return reconcile.Result{}, err
 	}
 
+	// The object store is checked in the background, as it can take
+	// long when it is not reachable
+	r.instance.TriggerObjectStoreChecker(cluster.DeepCopy())
+
 	// IMPORTANT
 	// From now on, the database can be assumed as running. Every operation
 	// needing the database to be up should be put below this line.
 
 	r.configureSlotReplicator(cluster)
 
+	r.reconcileAutomaticAnalyze(ctx, cluster)
+
 	postgresDB, err := r.instance.ConnectionPool().Connection("postgres")
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("while getting the postgres connection: %w", err)
@@ -296,6 +303,12 @@ func (r *InstanceReconciler) Reconcile(
 		return result, err
 	}
 
+	if err := r.instance.ReconcileSourceReplicationSlot(ctx, r.client, cluster); err != nil {
+		contextLogger.Error(err, "while reconciling the replication slot in the source cluster")
+	}
+
+	logicalSlotsCleanupAfter := r.reconcileLogicalSlotsCleanup(ctx, postgresDB, cluster)
+
 	if r.instance.GetPodName() == cluster.Status.CurrentPrimary {
 		result, err := roles.Reconcile(ctx, r.instance, cluster, r.client)
 		if err != nil || !result.IsZero() {
@@ -346,7 +359,41 @@ func (r *InstanceReconciler) Reconcile(
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
-	return reconcile.Result{}, nil
+	// The orphaned logical replication slots need to be dropped when they
+	// reach the inactivity timeout, even if nothing else changes
+	return reconcile.Result{RequeueAfter: logicalSlotsCleanupAfter}, nil
+}
+
+// reconcileLogicalSlotsCleanup drops the orphaned logical replication slots,
+// returning the time after which the cleanup needs to run again, or zero if
+// there is no orphaned slot waiting for the timeout. The errors are only
+// logged, as they must not prevent the rest of the reconciliation.
+func (r *InstanceReconciler) reconcileLogicalSlotsCleanup(
+	ctx context.Context,
+	db *sql.DB,
+	cluster *apiv1.Cluster,
+) time.Duration {
+	contextLogger := log.FromContext(ctx)
+
+	pgVersion, err := r.instance.GetPgVersion()
+	if err != nil {
+		contextLogger.Error(err, "while getting the PostgreSQL version, skipping the logical slots cleanup")
+		return 0
+	}
+
+	nextCheck, err := r.logicalSlotsCleaner.Reconcile(
+		ctx,
+		r.instance.GetPodName(),
+		db,
+		pgVersion.Major,
+		cluster,
+		r.recorder,
+	)
+	if err != nil {
+		contextLogger.Error(err, "while cleaning up the orphaned logical replication slots")
+	}
+
+	return nextCheck
 }
 
 func (r *InstanceReconciler) configureSlotReplicator(cluster *apiv1.Cluster) {
@@ -391,12 +438,17 @@ func (r *InstanceReconciler) refreshConfigurationFiles(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 ) (reloadNeeded bool, err error) {
-	reloadNeeded, err = r.refreshPGHBA(ctx, cluster)
+	rules := r.reconcileAuthenticationRules(ctx, cluster)
+
+	reloadNeeded, err = r.refreshPGHBA(ctx, cluster, rules.hba)
 	if err != nil {
 		return false, err
 	}
 
-	reloadIdent, err := r.instance.RefreshPGIdent(ctx, cluster.Spec.PostgresConfiguration.PgIdent)
+	reloadIdent, err := r.instance.RefreshPGIdent(
+		ctx,
+		slices.Concat(cluster.Spec.PostgresConfiguration.PgIdent, rules.ident),
+	)
 	if err != nil {
 		return false, err
 	}
@@ -596,10 +648,24 @@ func (r *InstanceReconciler) reconcileOldPrimary(
 
 	contextLogger.Info("This is the former primary instance. Shutting it down to allow it to be demoted to a replica.")
 
+	// The target primary may be waiting for us to record the shutdown checkpoint.
+	// This is done by the lifecycle manager once PostgreSQL is down and before
+	// the termination of the instance manager is requested.
+	var afterShutdown func(ctx context.Context)
+	if cluster.ShouldWaitForShutdownLSN() {
+		afterShutdown = func(ctx context.Context) {
+			storeCtx, cancel := context.WithTimeout(ctx, storeShutdownLSNTimeout)
+			defer cancel()
+			if err := r.storePrimaryShutdownLSN(storeCtx, cluster); err != nil {
+				contextLogger.Error(err, "while recording the shutdown checkpoint location")
+			}
+		}
+	}
+
 	// Perform a fast shutdown on the instance and wait for the instance manager to stop.
 	// The fast shutdown process will be preceded by a CHECKPOINT.
 	// When the Pod restarts, it will be demoted to act as a replica of the new primary.
-	r.Instance().RequestFastImmediateShutdown()
+	r.Instance().RequestFastImmediateShutdown(afterShutdown)
 
 	// We wait for the lifecycle manager to have received the immediate shutdown request
 	// and, having processed it, to request the termination of the instance manager.
@@ -650,6 +716,15 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 		}
 	}
 
+	collationPolicy := cluster.GetCollationVersionMismatchPolicy()
+	collationVersionsCheckNeeded := r.collationVersions.isCheckNeeded(collationPolicy, time.Now())
+	var collationReindexPlans []collationReindexPlan
+	var collationCheckErr error
+	pgVersion, err := r.instance.GetPgVersion()
+	if err != nil {
+		return fmt.Errorf("unable to get the PostgreSQL version: %w", err)
+	}
+
 	databases, errors := r.getAllAccessibleDatabases(ctx, db)
 	for _, databaseName := range databases {
 		db, err := r.instance.ConnectionPool().Connection(databaseName)
@@ -664,11 +739,26 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 					fmt.Errorf("could not reconcile extensions for database %s: %w", databaseName, err))
 			}
 		}
+		if collationVersionsCheckNeeded {
+			// a failure here must not block the reconciliation loop, the check
+			// will be retried later
+			plan, err := detectCollationReindexPlan(ctx, db, databaseName, pgVersion.Major, collationPolicy)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "while checking collation versions", "database", databaseName)
+				collationCheckErr = err
+			} else if plan != nil {
+				collationReindexPlans = append(collationReindexPlans, *plan)
+			}
+		}
 	}
 	if errors != nil {
 		return fmt.Errorf("got errors while reconciling databases: %v", errors)
 	}
 
+	if collationVersionsCheckNeeded {
+		r.reconcileCollationReindex(ctx, cluster, collationPolicy, collationReindexPlans, collationCheckErr)
+	}
+
 	for _, extension := range postgres.ManagedExtensions {
 		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
 		r.extensionStatus[extension.Name] = extensionIsUsed
@@ -1002,6 +1092,7 @@ func (r *InstanceReconciler) reconcileInstance(cluster *apiv1.Cluster) {
 	r.instance.MaxSwitchoverDelay = cluster.GetMaxSwitchoverDelay()
 	r.instance.MaxStopDelay = cluster.GetMaxStopDelay()
 	r.instance.SmartStopDelay = cluster.GetSmartShutdownTimeout()
+	r.instance.ShutdownMode = cluster.GetShutdownMode()
 	r.instance.RequiresDesignatedPrimaryTransition = detectRequiresDesignatedPrimaryTransition()
 	r.instance.Cluster = cluster
 }
@@ -1149,16 +1240,32 @@ func (r *InstanceReconciler) reconcilePrimary(ctx context.Context, cluster *apiv
 			return err
 		}
 
+		// The designated primary of a replica cluster is already
+		// the current primary, and is being detached from its source
+		isReplicaClusterPromotion := cluster.Status.CurrentPrimary == r.instance.GetPodName()
+		if isReplicaClusterPromotion {
+			if err := clusterstatus.PatchConditionsWithOptimisticLock(
+				ctx, r.client, cluster, externalcluster.PromotionStartedCondition); err != nil {
+				return err
+			}
+		}
+
 		cluster.LogTimestampsWithMessage(ctx, "Setting myself as primary")
 		if err := r.handlePromotion(ctx, cluster); err != nil {
 			return err
 		}
+		r.replicaConfigurationRemoved = false
+	}
+
+	if err := r.reconcileReplicaConfigurationRemoval(ctx, cluster); err != nil {
+		return err
 	}
 
 	// if the currentPrimary doesn't match the PodName we set the correct value.
 	if cluster.Status.CurrentPrimary != r.instance.GetPodName() {
 		cluster.Status.CurrentPrimary = r.instance.GetPodName()
 		cluster.Status.CurrentPrimaryTimestamp = pgTime.GetCurrentTimestamp()
+		cluster.Status.PrimaryShutdownLSN = ""
 
 		if err := r.client.Status().Patch(ctx, cluster, client.MergeFrom(oldCluster)); err != nil {
 			return err
@@ -1181,6 +1288,10 @@ func (r *InstanceReconciler) reconcilePrimary(ctx context.Context, cluster *apiv
 			cluster.Spec.ReplicaCluster.PromotionToken)
 	}
 
+	if err := r.reconcileAbortedSwitchover(ctx, cluster); err != nil {
+		return err
+	}
+
 	// If it is already the current primary, everything is ok
 	return nil
 }
@@ -1195,6 +1306,14 @@ func (r *InstanceReconciler) handlePromotion(ctx context.Context, cluster *apiv1
 		if err != nil {
 			return err
 		}
+
+		// during a switchover, the former primary records the location
+		// of its shutdown checkpoint, that we need to replay before promoting
+		if cluster.ShouldWaitForShutdownLSN() && cluster.Status.Phase != apiv1.PhaseFailOver {
+			if err := r.waitForPrimaryShutdownLSN(ctx, cluster); err != nil {
+				return err
+			}
+		}
 	}
 
 	contextLogger.Info("I'm the target primary, applying WALs and promoting my instance")
@@ -1206,6 +1325,33 @@ func (r *InstanceReconciler) handlePromotion(ctx context.Context, cluster *apiv1
 	return nil
 }
 
+// reconcileReplicaConfigurationRemoval removes, once after each promotion
+// and once after the instance manager starts, the replication settings
+// that the primary inherited from the replica it was. The promotion of a
+// replica cluster is reported as completed only after the removal, as the
+// former replication source is referenced until then
+func (r *InstanceReconciler) reconcileReplicaConfigurationRemoval(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !r.replicaConfigurationRemoved {
+		changed, err := r.instance.RemoveReplicaConfiguration()
+		if err != nil {
+			return fmt.Errorf("while removing the replication settings: %w", err)
+		}
+		if changed {
+			if err := r.instance.Reload(ctx); err != nil {
+				return fmt.Errorf("while reloading the configuration without the replication settings: %w", err)
+			}
+		}
+		r.replicaConfigurationRemoved = true
+	}
+
+	if !externalcluster.IsPromotionRunning(cluster) {
+		return nil
+	}
+
+	return clusterstatus.PatchConditionsWithOptimisticLock(
+		ctx, r.client, cluster, externalcluster.PromotionCompletedCondition)
+}
+
 // Reconciler designated primary logic for replica clusters
 func (r *InstanceReconciler) reconcileDesignatedPrimary(
 	ctx context.Context,
@@ -1347,7 +1493,7 @@ func (r *InstanceReconciler) reconcileUser(ctx context.Context, username string,
 	return nil
 }
 
-func (r *InstanceReconciler) refreshPGHBA(ctx context.Context, cluster *apiv1.Cluster) (
+func (r *InstanceReconciler) refreshPGHBA(ctx context.Context, cluster *apiv1.Cluster, additionalRules []string) (
 	postgresHBAChanged bool,
 	err error,
 ) {
@@ -1370,7 +1516,7 @@ func (r *InstanceReconciler) refreshPGHBA(ctx context.Context, cluster *apiv1.Cl
 		ldapBindPassword = string(ldapBindPasswordByte)
 	}
 	// Generate pg_hba.conf file
-	return r.instance.RefreshPGHBA(ctx, cluster, ldapBindPassword)
+	return r.instance.RefreshPGHBA(ctx, cluster, ldapBindPassword, additionalRules)
 }
 
 func (r *InstanceReconciler) shouldRequeueForMissingTopology(
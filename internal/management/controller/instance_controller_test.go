@@ -0,0 +1,91 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	externalcluster "github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/replicaclusterswitch"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replication settings removal after a promotion", func() {
+	var (
+		cluster *apiv1.Cluster
+		cli     client.Client
+		r       *InstanceReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		cli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+
+		instance := postgres.NewInstance().
+			WithNamespace("default").
+			WithPodName("cluster-example-1").
+			WithClusterName("cluster-example")
+		instance.PgData = GinkgoT().TempDir()
+		r = &InstanceReconciler{client: cli, instance: instance}
+	})
+
+	getPromotionCondition := func(ctx SpecContext) *metav1.Condition {
+		var liveCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+		return meta.FindStatusCondition(liveCluster.Status.Conditions,
+			string(apiv1.ConditionReplicaClusterPromotion))
+	}
+
+	It("doesn't report an unrelated promotion", func(ctx SpecContext) {
+		Expect(r.reconcileReplicaConfigurationRemoval(ctx, cluster)).To(Succeed())
+		Expect(r.replicaConfigurationRemoved).To(BeTrue())
+		Expect(getPromotionCondition(ctx)).To(BeNil())
+	})
+
+	It("completes the promotion of a replica cluster once the settings are removed", func(ctx SpecContext) {
+		cluster.Status.Conditions = []metav1.Condition{externalcluster.PromotionStartedCondition}
+		Expect(cli.Status().Update(ctx, cluster)).To(Succeed())
+		Expect(externalcluster.IsPromotionRunning(cluster)).To(BeTrue())
+
+		Expect(r.reconcileReplicaConfigurationRemoval(ctx, cluster)).To(Succeed())
+		Expect(r.replicaConfigurationRemoved).To(BeTrue())
+
+		condition := getPromotionCondition(ctx)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPromotionCompleted)))
+	})
+})
@@ -34,7 +34,9 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/controller"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/archiver"
+	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // verifyPgDataCoherenceForPrimary will abort the execution if the current server is a primary
@@ -190,6 +192,78 @@ func (r *InstanceReconciler) ReconcileTablespaces(
 				"instance", r.instance.GetPodName(), "tablespace", tbsName)
 			return fmt.Errorf("while creating data dir in tablespace %s: %w", mountPoint, err)
 		}
+
+		if tbsConfig.IsEphemeral() {
+			if err := r.restoreEphemeralTablespace(specs.LocationForTablespace(tbsName)); err != nil {
+				contextLogger.Error(err,
+					"could not restore the ephemeral tablespace directory",
+					"instance", r.instance.GetPodName(), "tablespace", tbsName)
+				return fmt.Errorf("while restoring ephemeral tablespace %s: %w", tbsName, err)
+			}
+		}
 	}
 	return nil
 }
+
+// restoreEphemeralTablespace recreates the version directory inside the
+// location of an ephemeral tablespace that PostgreSQL already knows about.
+// The content of the volume is lost every time the Pod is recreated, and
+// without that directory PostgreSQL can't create temporary files in it
+func (r *InstanceReconciler) restoreEphemeralTablespace(location string) error {
+	inUse, err := isTablespaceLocationInUse(r.instance.PgData, location)
+	if err != nil || !inUse {
+		return err
+	}
+
+	versionDirectory, err := r.getTablespaceVersionDirectory()
+	if err != nil {
+		return err
+	}
+
+	return fileutils.EnsureDirectoryExists(filepath.Join(location, versionDirectory))
+}
+
+// getTablespaceVersionDirectory returns the name of the directory PostgreSQL
+// creates inside each tablespace location, i.e. PG_<major>_<catalog version>
+func (r *InstanceReconciler) getTablespaceVersionDirectory() (string, error) {
+	majorVersion, err := postgresutils.GetMajorVersionFromPgData(r.instance.PgData)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := r.instance.GetPgControldata()
+	if err != nil {
+		return "", err
+	}
+
+	catalogVersion, err := utils.ParsePgControldataOutput(out).GetCatalogVersionNumber()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("PG_%d_%s", majorVersion, catalogVersion), nil
+}
+
+// isTablespaceLocationInUse checks whether any tablespace defined in the
+// passed PGDATA points to the passed location
+func isTablespaceLocationInUse(pgData, location string) (bool, error) {
+	entries, err := os.ReadDir(filepath.Join(pgData, "pg_tblspc"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(pgData, "pg_tblspc", entry.Name()))
+		if err != nil {
+			continue
+		}
+		if filepath.Clean(target) == filepath.Clean(location) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
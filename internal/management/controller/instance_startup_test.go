@@ -0,0 +1,56 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isTablespaceLocationInUse", func() {
+	var pgData string
+
+	BeforeEach(func() {
+		pgData = GinkgoT().TempDir()
+	})
+
+	It("returns false when there is no pg_tblspc directory", func() {
+		inUse, err := isTablespaceLocationInUse(pgData, "/var/lib/postgresql/tablespaces/tmp/data")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeFalse())
+	})
+
+	It("detects the tablespaces pointing to the location", func() {
+		Expect(os.Mkdir(filepath.Join(pgData, "pg_tblspc"), 0o700)).To(Succeed())
+		Expect(os.Symlink("/var/lib/postgresql/tablespaces/tmp/data",
+			filepath.Join(pgData, "pg_tblspc", "16385"))).To(Succeed())
+
+		inUse, err := isTablespaceLocationInUse(pgData, "/var/lib/postgresql/tablespaces/tmp/data")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeTrue())
+
+		inUse, err = isTablespaceLocationInUse(pgData, "/var/lib/postgresql/tablespaces/other/data")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeFalse())
+	})
+})
@@ -0,0 +1,193 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	clusterstatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// storeShutdownLSNTimeout is the time given to the former primary to
+	// record the location of its shutdown checkpoint in the cluster status
+	storeShutdownLSNTimeout = 10 * time.Second
+
+	// shutdownLSNPollInterval is the interval between two checks of the
+	// WAL location replayed by the target primary
+	shutdownLSNPollInterval = 1 * time.Second
+
+	// switchoverAbortedPhaseReason is the prefix of the phase reason set
+	// when a switchover is aborted
+	switchoverAbortedPhaseReason = "Switchover aborted"
+)
+
+// errSwitchoverAborted is raised when the target primary has not replayed
+// the shutdown checkpoint of the former primary in time
+var errSwitchoverAborted = errors.New("switchover aborted, the shutdown checkpoint " +
+	"of the former primary has not been replayed in time")
+
+// storePrimaryShutdownLSN records in the cluster status the location of the
+// shutdown checkpoint of this instance, which has just been shut down as the
+// former primary during a switchover
+func (r *InstanceReconciler) storePrimaryShutdownLSN(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+
+	out, err := r.instance.GetPgControldata()
+	if err != nil {
+		return fmt.Errorf("while reading the shutdown checkpoint location [pg_controldata]: %w", err)
+	}
+
+	controlData := utils.ParsePgControldataOutput(out)
+	state := utils.PgDataState(controlData.GetDatabaseClusterState())
+	if !state.IsShutdown(ctx) {
+		return fmt.Errorf("the former primary was not shut down cleanly (state: %s)", state)
+	}
+
+	shutdownLSN := controlData.GetLatestCheckpointLocation()
+	contextLogger.Info("Recording the shutdown checkpoint location of the former primary",
+		"primaryShutdownLSN", shutdownLSN)
+
+	return clusterstatus.PatchWithOptimisticLock(ctx, r.client, cluster,
+		func(cluster *apiv1.Cluster) {
+			// The switchover may have been aborted in the meantime
+			if cluster.Status.TargetPrimary == r.instance.GetPodName() {
+				return
+			}
+			cluster.Status.PrimaryShutdownLSN = shutdownLSN
+		},
+	)
+}
+
+// waitForPrimaryShutdownLSN waits for this instance, which is the target
+// primary of a switchover, to replay the WAL up to the shutdown checkpoint
+// of the former primary. The switchover is aborted if this doesn't happen
+// in time.
+func (r *InstanceReconciler) waitForPrimaryShutdownLSN(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+
+	timeout := cluster.GetShutdownLSNTimeout()
+	contextLogger.Info("Waiting for the shutdown checkpoint of the former primary to be replayed",
+		"timeout", timeout)
+
+	err := wait.PollUntilContextTimeout(ctx, shutdownLSNPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			var livingCluster apiv1.Cluster
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(cluster), &livingCluster); err != nil {
+				contextLogger.Warning("Error while reading the cluster status, will retry", "err", err)
+				return false, nil
+			}
+
+			shutdownLSN := types.LSN(livingCluster.Status.PrimaryShutdownLSN)
+			if shutdownLSN == "" {
+				contextLogger.Info("The former primary has not recorded its shutdown checkpoint yet")
+				return false, nil
+			}
+
+			replayLSN, err := r.instance.GetLastReplayLSN()
+			if err != nil {
+				contextLogger.Warning("Error while reading the last replayed WAL location, will retry", "err", err)
+				return false, nil
+			}
+
+			if !isShutdownLSNReplayed(shutdownLSN, replayLSN) {
+				contextLogger.Info("The shutdown checkpoint of the former primary has not been replayed yet",
+					"primaryShutdownLSN", shutdownLSN,
+					"replayLSN", replayLSN)
+				return false, nil
+			}
+
+			contextLogger.Info("The shutdown checkpoint of the former primary has been replayed",
+				"primaryShutdownLSN", shutdownLSN,
+				"replayLSN", replayLSN)
+			return true, nil
+		})
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+
+	return r.abortSwitchover(ctx, cluster)
+}
+
+// abortSwitchover restores the former primary as the target primary,
+// letting it restart as the primary instance of the cluster
+func (r *InstanceReconciler) abortSwitchover(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Warning("Aborting the switchover, the former primary will be restarted as primary",
+		"currentPrimary", cluster.Status.CurrentPrimary,
+		"targetPrimary", cluster.Status.TargetPrimary)
+
+	if err := clusterstatus.PatchWithOptimisticLock(ctx, r.client, cluster,
+		func(cluster *apiv1.Cluster) {
+			cluster.Status.TargetPrimary = cluster.Status.CurrentPrimary
+			cluster.Status.PrimaryShutdownLSN = ""
+		},
+		clusterstatus.SetPhase(apiv1.PhaseSwitchover,
+			fmt.Sprintf("%s: shutdown checkpoint of the former primary not replayed in time by %s",
+				switchoverAbortedPhaseReason, r.instance.GetPodName())),
+	); err != nil {
+		return fmt.Errorf("while aborting the switchover: %w", err)
+	}
+
+	return errSwitchoverAborted
+}
+
+// reconcileAbortedSwitchover restores the healthy phase once this instance,
+// being the former primary of an aborted switchover, is the primary again
+func (r *InstanceReconciler) reconcileAbortedSwitchover(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Status.Phase != apiv1.PhaseSwitchover ||
+		!strings.HasPrefix(cluster.Status.PhaseReason, switchoverAbortedPhaseReason) ||
+		cluster.Status.CurrentPrimary != r.instance.GetPodName() ||
+		cluster.Status.TargetPrimary != r.instance.GetPodName() {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("The former primary is running as primary again after the aborted switchover")
+	return clusterstatus.PatchWithOptimisticLock(
+		ctx,
+		r.client,
+		cluster,
+		clusterstatus.SetPhase(apiv1.PhaseHealthy, "Former primary restarted after an aborted switchover"),
+		clusterstatus.SetClusterReadyCondition,
+	)
+}
+
+// isShutdownLSNReplayed checks whether the passed replay location includes
+// the shutdown checkpoint of the former primary
+func isShutdownLSNReplayed(shutdownLSN, replayLSN types.LSN) bool {
+	if _, err := replayLSN.Parse(); err != nil {
+		return false
+	}
+	return !replayLSN.Less(shutdownLSN)
+}
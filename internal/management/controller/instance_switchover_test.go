@@ -0,0 +1,105 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shutdown checkpoint replay detection", func() {
+	DescribeTable("compares the shutdown checkpoint with the replay location",
+		func(shutdownLSN, replayLSN string, expected bool) {
+			Expect(isShutdownLSNReplayed(types.LSN(shutdownLSN), types.LSN(replayLSN))).To(Equal(expected))
+		},
+		Entry("replay behind the shutdown checkpoint", "0/3000FF0", "0/3000CC0", false),
+		Entry("replay at the shutdown checkpoint", "0/3000FF0", "0/3000FF0", true),
+		Entry("replay past the shutdown checkpoint", "0/3000FF0", "0/3001068", true),
+		Entry("replay on a later segment", "0/3000FF0", "1/0", true),
+		Entry("invalid replay location", "0/3000FF0", "", false),
+	)
+})
+
+var _ = Describe("Phase restoration after an aborted switchover", func() {
+	var (
+		cluster *apiv1.Cluster
+		cli     client.Client
+		r       *InstanceReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+				Phase:          apiv1.PhaseSwitchover,
+				PhaseReason: switchoverAbortedPhaseReason +
+					": shutdown checkpoint of the former primary not replayed in time by cluster-example-2",
+			},
+		}
+		cli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+
+		instance := postgres.NewInstance().
+			WithNamespace("default").
+			WithPodName("cluster-example-1").
+			WithClusterName("cluster-example")
+		r = &InstanceReconciler{client: cli, instance: instance}
+	})
+
+	getPhase := func(ctx SpecContext) string {
+		var liveCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+		return liveCluster.Status.Phase
+	}
+
+	It("restores the healthy phase when the former primary is the primary again", func(ctx SpecContext) {
+		Expect(r.reconcileAbortedSwitchover(ctx, cluster)).To(Succeed())
+		Expect(getPhase(ctx)).To(Equal(apiv1.PhaseHealthy))
+	})
+
+	It("doesn't touch a switchover in progress", func(ctx SpecContext) {
+		cluster.Status.PhaseReason = "Switching over to cluster-example-1"
+		Expect(cli.Status().Update(ctx, cluster)).To(Succeed())
+
+		Expect(r.reconcileAbortedSwitchover(ctx, cluster)).To(Succeed())
+		Expect(getPhase(ctx)).To(Equal(apiv1.PhaseSwitchover))
+	})
+
+	It("waits for this instance to be the current primary", func(ctx SpecContext) {
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+		Expect(cli.Status().Update(ctx, cluster)).To(Succeed())
+
+		Expect(r.reconcileAbortedSwitchover(ctx, cluster)).To(Succeed())
+		Expect(getPhase(ctx)).To(Equal(apiv1.PhaseSwitchover))
+	})
+})
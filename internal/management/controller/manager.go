@@ -29,10 +29,12 @@ import (
 	"go.uber.org/atomic"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/repository"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/reconciler"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/concurrency"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/metricserver"
@@ -44,12 +46,30 @@ import (
 // ConfigMap is applied when needed
 type InstanceReconciler struct {
 	client        ctrl.Client
+	recorder      record.EventRecorder
 	instance      *postgres.Instance
 	runningImages *stringset.Data
 
 	secretVersions  map[string]string
 	extensionStatus map[string]bool
 
+	// the check of the collation versions used by the indexes
+	collationVersions collationVersionsStatus
+
+	// the last valid rules loaded from the Secrets and ConfigMaps
+	// referenced in the pg_hba.conf and pg_ident.conf configuration
+	authenticationRules authenticationRules
+
+	// true once the replication settings have been removed from the
+	// configuration of this primary instance, after it was promoted
+	replicaConfigurationRemoved bool
+
+	// true while the automatic analyze is collecting the statistics
+	analyzeRunning atomic.Bool
+
+	// tracks the orphaned logical replication slots to be dropped
+	logicalSlotsCleaner *reconciler.LogicalSlotsCleaner
+
 	systemInitialization  *concurrency.Executed
 	firstReconcileDone    atomic.Bool
 	metricsServerExporter *metricserver.Exporter
@@ -62,12 +82,15 @@ type InstanceReconciler struct {
 func NewInstanceReconciler(
 	instance *postgres.Instance,
 	client ctrl.Client,
+	recorder record.EventRecorder,
 	metricsExporter *metricserver.Exporter,
 	pluginRepository repository.Interface,
 ) *InstanceReconciler {
 	return &InstanceReconciler{
 		instance:              instance,
 		client:                client,
+		recorder:              recorder,
+		logicalSlotsCleaner:   reconciler.NewLogicalSlotsCleaner(),
 		secretVersions:        make(map[string]string),
 		extensionStatus:       make(map[string]bool),
 		systemInitialization:  concurrency.NewExecuted(),
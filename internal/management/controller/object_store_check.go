@@ -0,0 +1,218 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	barmanCommand "github.com/cloudnative-pg/barman-cloud/pkg/command"
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+const (
+	// objectStoreCheckTimeout is the maximum time the check of the
+	// object store is allowed to take
+	objectStoreCheckTimeout = 30 * time.Second
+
+	// objectStoreCheckRetryInterval is the time after which a failed
+	// check is repeated, even if the configuration didn't change, as
+	// the credentials could have been fixed in the referenced secrets
+	objectStoreCheckRetryInterval = 5 * time.Minute
+)
+
+// objectStoreCheckStatus tracks the last check of the object store
+type objectStoreCheckStatus struct {
+	// the hash of the configuration which has been checked
+	configurationHash string
+
+	// when the last check failed, zero if it succeeded
+	failedAt time.Time
+}
+
+// isCheckNeeded tells whether the object store with the passed configuration
+// hash needs to be checked
+func (s *objectStoreCheckStatus) isCheckNeeded(configurationHash string, now time.Time) bool {
+	if s.configurationHash != configurationHash {
+		return true
+	}
+
+	return !s.failedAt.IsZero() && now.Sub(s.failedAt) >= objectStoreCheckRetryInterval
+}
+
+// ObjectStoreChecker is a Kubernetes manager.Runnable verifying, on the
+// primary, that the object store used to back up the cluster is accessible
+// whenever its configuration changes, and reporting the result in the
+// cluster conditions. This gives immediate feedback about a wrong
+// configuration, instead of waiting for the first backup to fail.
+// The check runs in the background, so that an unreachable object store
+// doesn't delay the reconciliation of the instance.
+//
+// c.f. https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#Runnable
+type ObjectStoreChecker struct {
+	instance *postgresManagement.Instance
+	client   ctrl.Client
+
+	// the last check of the object store
+	status objectStoreCheckStatus
+}
+
+// NewObjectStoreChecker creates a new ObjectStoreChecker
+func NewObjectStoreChecker(instance *postgresManagement.Instance, client ctrl.Client) *ObjectStoreChecker {
+	return &ObjectStoreChecker{
+		instance: instance,
+		client:   client,
+	}
+}
+
+// Start starts running the ObjectStoreChecker
+func (c *ObjectStoreChecker) Start(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx).WithName("object_store_checker")
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	go func() {
+		// The ticker repeats the failed checks, even if the cluster
+		// doesn't change in the meantime
+		ticker := time.NewTicker(objectStoreCheckRetryInterval)
+		defer func() {
+			ticker.Stop()
+			contextLogger.Info("Terminated ObjectStoreChecker loop")
+		}()
+
+		var cluster *apiv1.Cluster
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case cluster = <-c.instance.ObjectStoreCheckerChan():
+			case <-ticker.C:
+			}
+
+			if cluster != nil {
+				c.reconcile(ctx, cluster)
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile checks the object store of the passed cluster, if its
+// configuration changed since the last check, or the last check failed
+// longer than the retry interval ago
+func (c *ObjectStoreChecker) reconcile(ctx context.Context, cluster *apiv1.Cluster) {
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.Status.CurrentPrimary != c.instance.GetPodName() ||
+		cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+		c.status = objectStoreCheckStatus{}
+		return
+	}
+
+	configuration := cluster.Spec.Backup.BarmanObjectStore
+	configurationHash, err := hash.ComputeHash(configuration)
+	if err != nil {
+		contextLogger.Error(err, "while computing the hash of the object store configuration")
+		return
+	}
+	if !c.status.isCheckNeeded(configurationHash, time.Now()) {
+		return
+	}
+
+	checkErr := c.checkObjectStore(ctx, cluster, configuration)
+	c.status = objectStoreCheckStatus{configurationHash: configurationHash}
+	if checkErr != nil {
+		contextLogger.Warning("The object store used for backups is not accessible", "err", checkErr)
+		c.status.failedAt = time.Now()
+	}
+
+	if err := status.PatchConditionsWithOptimisticLock(
+		ctx, c.client, cluster, buildObjectStoreAccessibleCondition(checkErr),
+	); err != nil {
+		contextLogger.Error(err, "while reporting the result of the object store check")
+		// Let the next check report it
+		c.status = objectStoreCheckStatus{}
+	}
+}
+
+// checkObjectStore lists the backups in the object store, which requires
+// both the bucket to be reachable and the credentials to be valid
+func (c *ObjectStoreChecker) checkObjectStore(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, objectStoreCheckTimeout)
+	defer cancel()
+
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		c.client,
+		cluster.Namespace,
+		configuration,
+		os.Environ())
+	if err != nil {
+		return fmt.Errorf("while getting the backup credentials: %w", err)
+	}
+	env = specs.AppendBarmanEndpointCAEnv(env, configuration, postgres.BarmanBackupEndpointCACertificateLocation)
+
+	serverName := configuration.ServerName
+	if serverName == "" {
+		serverName = cluster.Name
+	}
+
+	if _, err := barmanCommand.GetBackupList(ctx, configuration, serverName, env); err != nil {
+		return fmt.Errorf("while listing the backups: %w", err)
+	}
+
+	return nil
+}
+
+// buildObjectStoreAccessibleCondition builds the condition reporting the
+// result of the check of the object store
+func buildObjectStoreAccessibleCondition(checkErr error) metav1.Condition {
+	if checkErr != nil {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionObjectStoreAccessible),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonObjectStoreNotAccessible),
+			Message: checkErr.Error(),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    string(apiv1.ConditionObjectStoreAccessible),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonObjectStoreAccessible),
+		Message: "The backups in the object store can be listed",
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	barmanApi "github.com/cloudnative-pg/barman-cloud/pkg/api"
+	machineryapi "github.com/cloudnative-pg/machinery/pkg/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object store check", func() {
+	now := time.Now()
+
+	It("checks the object store when the configuration changes", func() {
+		checkStatus := objectStoreCheckStatus{}
+		Expect(checkStatus.isCheckNeeded("first", now)).To(BeTrue())
+
+		checkStatus.configurationHash = "first"
+		Expect(checkStatus.isCheckNeeded("first", now)).To(BeFalse())
+		Expect(checkStatus.isCheckNeeded("second", now)).To(BeTrue())
+	})
+
+	It("repeats a failed check after the retry interval", func() {
+		checkStatus := objectStoreCheckStatus{configurationHash: "first", failedAt: now}
+		Expect(checkStatus.isCheckNeeded("first", now.Add(time.Minute))).To(BeFalse())
+		Expect(checkStatus.isCheckNeeded("first", now.Add(objectStoreCheckRetryInterval))).To(BeTrue())
+	})
+
+	It("publishes the result of the check in the cluster conditions", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://bucket/backups",
+						BarmanCredentials: barmanApi.BarmanCredentials{
+							AWS: &barmanApi.S3Credentials{
+								AccessKeyIDReference: &machineryapi.SecretKeySelector{
+									LocalObjectReference: machineryapi.LocalObjectReference{Name: "missing"},
+									Key:                  "ACCESS_KEY_ID",
+								},
+								SecretAccessKeyReference: &machineryapi.SecretKeySelector{
+									LocalObjectReference: machineryapi.LocalObjectReference{Name: "missing"},
+									Key:                  "ACCESS_SECRET_KEY",
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+			},
+		}
+		cli := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+		checker := NewObjectStoreChecker(
+			postgres.NewInstance().
+				WithNamespace("default").
+				WithPodName("cluster-example-1").
+				WithClusterName("cluster-example"),
+			cli,
+		)
+
+		checker.reconcile(ctx, cluster.DeepCopy())
+		Expect(checker.status.failedAt).ToNot(BeZero())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		condition := meta.FindStatusCondition(updatedCluster.Status.Conditions,
+			string(apiv1.ConditionObjectStoreAccessible))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("while getting the backup credentials"))
+
+		// The check is not repeated until the configuration changes
+		// or the retry interval elapses
+		Expect(checker.status.isCheckNeeded(checker.status.configurationHash, time.Now())).To(BeFalse())
+	})
+
+	It("forgets the last check when the instance is not the primary", func(ctx SpecContext) {
+		checker := NewObjectStoreChecker(postgres.NewInstance().WithPodName("cluster-example-2"), nil)
+		checker.status = objectStoreCheckStatus{configurationHash: "first"}
+
+		checker.reconcile(ctx, &apiv1.Cluster{
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		})
+		Expect(checker.status).To(BeZero())
+	})
+
+	It("reports the result of the check in the condition", func() {
+		condition := buildObjectStoreAccessibleCondition(nil)
+		Expect(condition.Type).To(Equal(string(apiv1.ConditionObjectStoreAccessible)))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonObjectStoreAccessible)))
+
+		condition = buildObjectStoreAccessibleCondition(fmt.Errorf("access denied"))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonObjectStoreNotAccessible)))
+		Expect(condition.Message).To(Equal("access denied"))
+	})
+})
@@ -69,6 +69,35 @@ var _ = Describe("DatabaseRole implementation test", func() {
 		Expect(res).To(BeFalse())
 	})
 
+	DescribeTable("should detect the drift of the role attributes",
+		func(drift func(role *DatabaseRole)) {
+			config := apiv1.RoleConfiguration{
+				Name:        "subscriber",
+				Login:       true,
+				Replication: true,
+			}
+			role := DatabaseRole{
+				Name:            "subscriber",
+				Inherit:         true,
+				Login:           true,
+				Replication:     true,
+				ConnectionLimit: config.ConnectionLimit,
+			}
+			Expect(role.isEquivalentTo(config)).To(BeTrue())
+
+			drift(&role)
+			Expect(role.isEquivalentTo(config)).To(BeFalse())
+		},
+		Entry("superuser", func(role *DatabaseRole) { role.Superuser = true }),
+		Entry("createdb", func(role *DatabaseRole) { role.CreateDB = true }),
+		Entry("createrole", func(role *DatabaseRole) { role.CreateRole = true }),
+		Entry("inherit", func(role *DatabaseRole) { role.Inherit = false }),
+		Entry("login", func(role *DatabaseRole) { role.Login = false }),
+		Entry("replication", func(role *DatabaseRole) { role.Replication = false }),
+		Entry("bypassrls", func(role *DatabaseRole) { role.BypassRLS = true }),
+		Entry("connectionLimit", func(role *DatabaseRole) { role.ConnectionLimit = 10 }),
+	)
+
 	It("should return true when the inRole are same but not same order", func() {
 		role := DatabaseRole{
 			Name:    "abc",
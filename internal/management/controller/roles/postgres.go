@@ -24,12 +24,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/lib/pq"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
 // List the available roles excluding all the roles that start with `pg_`
@@ -177,6 +180,43 @@ func Delete(ctx context.Context, db *sql.DB, role DatabaseRole) error {
 	return nil
 }
 
+// CheckPasswordEncryption checks that PostgreSQL is hashing the passwords
+// with the requested settings, which are applied with the configuration
+// reload. An error is returned when the reload hasn't been applied yet, so
+// that no password is hashed with the previous settings
+func CheckPasswordEncryption(
+	ctx context.Context,
+	db *sql.DB,
+	passwordEncryption *apiv1.PasswordEncryptionConfiguration,
+) error {
+	if passwordEncryption == nil {
+		return nil
+	}
+
+	var method, scramIterations string
+	if err := db.QueryRowContext(ctx,
+		"SELECT current_setting('password_encryption'), "+
+			"COALESCE(current_setting('scram_iterations', true), '')",
+	).Scan(&method, &scramIterations); err != nil {
+		return fmt.Errorf("while checking the password encryption settings: %w", err)
+	}
+
+	if passwordEncryption.Method != "" && method != string(passwordEncryption.Method) {
+		return fmt.Errorf("password_encryption is %q instead of %q, waiting for the configuration reload",
+			method, passwordEncryption.Method)
+	}
+
+	// scram_iterations is not available before PostgreSQL 16, where
+	// the setting is ignored
+	if passwordEncryption.ScramIterations != nil && scramIterations != "" &&
+		scramIterations != strconv.Itoa(int(*passwordEncryption.ScramIterations)) {
+		return fmt.Errorf("scram_iterations is %s instead of %d, waiting for the configuration reload",
+			scramIterations, *passwordEncryption.ScramIterations)
+	}
+
+	return nil
+}
+
 // GetLastTransactionID get the last xmin for the role, to help keep track of
 // whether the role has been changed in on the Database since last reconciliation
 func GetLastTransactionID(ctx context.Context, db *sql.DB, role DatabaseRole) (int64, error) {
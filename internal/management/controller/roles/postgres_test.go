@@ -32,6 +32,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/lib/pq"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 
@@ -537,4 +538,37 @@ var _ = Describe("Postgres RoleManager implementation test", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(transID).To(BeEquivalentTo(1321))
 	})
+
+	It("checks the password encryption settings in use", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		settingsQuery := "SELECT current_setting('password_encryption'), " +
+			"COALESCE(current_setting('scram_iterations', true), '')"
+		passwordEncryption := &apiv1.PasswordEncryptionConfiguration{
+			Method:          apiv1.PasswordEncryptionMethodScramSHA256,
+			ScramIterations: ptr.To(int32(10000)),
+		}
+
+		Expect(CheckPasswordEncryption(ctx, db, nil)).To(Succeed())
+
+		mock.ExpectQuery(settingsQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"method", "iterations"}).AddRow("scram-sha-256", "10000"))
+		Expect(CheckPasswordEncryption(ctx, db, passwordEncryption)).To(Succeed())
+
+		mock.ExpectQuery(settingsQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"method", "iterations"}).AddRow("scram-sha-256", "4096"))
+		Expect(CheckPasswordEncryption(ctx, db, passwordEncryption)).ToNot(Succeed())
+
+		mock.ExpectQuery(settingsQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"method", "iterations"}).AddRow("md5", ""))
+		Expect(CheckPasswordEncryption(ctx, db, passwordEncryption)).ToNot(Succeed())
+
+		// scram_iterations is not available before PostgreSQL 16
+		mock.ExpectQuery(settingsQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"method", "iterations"}).AddRow("scram-sha-256", ""))
+		Expect(CheckPasswordEncryption(ctx, db, passwordEncryption)).To(Succeed())
+
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
 })
@@ -158,6 +158,10 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 	if err != nil {
 		return fmt.Errorf("while getting superuser connection: %w", err)
 	}
+	if err := CheckPasswordEncryption(
+		ctx, superUserDB, remoteCluster.Spec.PostgresConfiguration.PasswordEncryption); err != nil {
+		return err
+	}
 	appliedState, irreconcilableRoles, err := sr.synchronizeRoles(ctx, superUserDB, config, rolePasswords)
 	if err != nil {
 		return fmt.Errorf("while syncrhonizing managed roles: %w", err)
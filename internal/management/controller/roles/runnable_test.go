@@ -315,6 +315,41 @@ var _ = Describe("Role synchronizer tests", func() {
 		})
 	})
 
+	When("the login of a role is disabled", func() {
+		It("it will alter the role with NOLOGIN without dropping it", func(ctx context.Context) {
+			managedConf := apiv1.ManagedConfiguration{
+				Roles: []apiv1.RoleConfiguration{
+					{
+						Name:            "role_to_ignore",
+						Ensure:          apiv1.EnsurePresent,
+						Superuser:       true,
+						Inherit:         ptr.To(false),
+						Comment:         "This is a custom role in the DB",
+						CreateDB:        true,
+						CreateRole:      true,
+						Login:           false,
+						ConnectionLimit: -1,
+					},
+				},
+			}
+			alterStmt := fmt.Sprintf(
+				"ALTER ROLE \"%s\" NOBYPASSRLS CREATEDB CREATEROLE NOINHERIT NOLOGIN NOREPLICATION SUPERUSER CONNECTION LIMIT -1 ",
+				"role_to_ignore")
+			mock.ExpectExec(alterStmt).WillReturnResult(sqlmock.NewResult(2, 3))
+			rows := mock.NewRows([]string{"xmin"}).AddRow("12")
+			lastTransactionQuery := "SELECT xmin FROM pg_catalog.pg_authid WHERE rolname = $1"
+			mock.ExpectQuery(lastTransactionQuery).WithArgs("role_to_ignore").WillReturnRows(rows)
+			_, rolesWithErrors, err := roleSynchronizer.synchronizeRoles(ctx, db, &managedConf,
+				map[string]apiv1.PasswordState{
+					"role_to_ignore": {
+						TransactionID: 11, // defined in the mock query to the DB above
+					},
+				})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rolesWithErrors).To(BeEmpty())
+		})
+	})
+
 	When("role configurations are unrealizable", func() {
 		It("it will carry on and capture postgres errors per role", func(ctx context.Context) {
 			managedConf := apiv1.ManagedConfiguration{
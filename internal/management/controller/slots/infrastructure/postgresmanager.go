@@ -22,6 +22,7 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -78,6 +79,58 @@ func List(ctx context.Context, db *sql.DB, config *apiv1.ReplicationSlotsConfigu
 	return status, nil
 }
 
+// ListLogical lists the logical replication slots. On PostgreSQL 17 and
+// newer versions, the time each slot has been inactive since is included
+func ListLogical(ctx context.Context, db *sql.DB, pgMajor uint64) (ReplicationSlotList, error) {
+	inactiveSince := "NULL::timestamptz"
+	if pgMajor >= 17 {
+		inactiveSince = "inactive_since"
+	}
+
+	rows, err := db.QueryContext(
+		ctx,
+		fmt.Sprintf(`SELECT slot_name, slot_type, active, coalesce(restart_lsn::TEXT, '') AS restart_lsn,
+            xmin IS NOT NULL OR catalog_xmin IS NOT NULL AS holds_xmin,
+            %s AS inactive_since
+            FROM pg_catalog.pg_replication_slots
+            WHERE NOT temporary AND slot_type = 'logical'`, inactiveSince),
+	)
+	if err != nil {
+		return ReplicationSlotList{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var status ReplicationSlotList
+	for rows.Next() {
+		var slot ReplicationSlot
+		var inactiveSince sql.NullTime
+		err := rows.Scan(
+			&slot.SlotName,
+			&slot.Type,
+			&slot.Active,
+			&slot.RestartLSN,
+			&slot.HoldsXmin,
+			&inactiveSince,
+		)
+		if err != nil {
+			return ReplicationSlotList{}, err
+		}
+		if inactiveSince.Valid {
+			slot.InactiveSince = &inactiveSince.Time
+		}
+
+		status.Items = append(status.Items, slot)
+	}
+
+	if rows.Err() != nil {
+		return ReplicationSlotList{}, rows.Err()
+	}
+
+	return status, nil
+}
+
 // Update the replication slot
 func Update(ctx context.Context, db *sql.DB, slot ReplicationSlot) error {
 	contextLog := log.FromContext(ctx).WithName("updateSlot")
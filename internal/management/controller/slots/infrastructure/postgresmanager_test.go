@@ -22,6 +22,7 @@ package infrastructure
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
@@ -125,6 +126,51 @@ var _ = Describe("PostgresManager", func() {
 		})
 	})
 
+	Context("ListLogical", func() {
+		const expectedSQL = "^SELECT (.+) FROM pg_catalog.pg_replication_slots (.+) slot_type = 'logical'"
+
+		It("should successfully list logical replication slots", func(ctx SpecContext) {
+			rows := sqlmock.NewRows(
+				[]string{"slot_name", "slot_type", "active", "restart_lsn", "holds_xmin", "inactive_since"}).
+				AddRow("sub1", string(SlotTypeLogical), false, "lsn1", true, nil)
+
+			mock.ExpectQuery(expectedSQL).
+				WillReturnRows(rows)
+
+			result, err := ListLogical(ctx, db, 16)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Items).To(Equal([]ReplicationSlot{{
+				SlotName:   "sub1",
+				Type:       SlotTypeLogical,
+				RestartLSN: "lsn1",
+				HoldsXmin:  true,
+			}}))
+		})
+
+		It("should include the inactivity time since PostgreSQL 17", func(ctx SpecContext) {
+			inactiveSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			rows := sqlmock.NewRows(
+				[]string{"slot_name", "slot_type", "active", "restart_lsn", "holds_xmin", "inactive_since"}).
+				AddRow("sub1", string(SlotTypeLogical), false, "lsn1", true, inactiveSince)
+
+			mock.ExpectQuery("inactive_since AS inactive_since").
+				WillReturnRows(rows)
+
+			result, err := ListLogical(ctx, db, 17)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Items).To(HaveLen(1))
+			Expect(result.Items[0].InactiveSince).To(HaveValue(Equal(inactiveSince)))
+		})
+
+		It("should return error when database query fails", func(ctx SpecContext) {
+			mock.ExpectQuery(expectedSQL).
+				WillReturnError(errors.New("mock error"))
+
+			_, err := ListLogical(ctx, db, 17)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("Update", func() {
 		const expectedSQL = "SELECT pg_catalog.pg_replication_slot_advance"
 
@@ -19,11 +19,18 @@ SPDX-License-Identifier: Apache-2.0
 
 package infrastructure
 
+import "time"
+
 // SlotType represents the type of replication slot
 type SlotType string
 
-// SlotTypePhysical represents the physical replication slot
-const SlotTypePhysical SlotType = "physical"
+const (
+	// SlotTypePhysical represents the physical replication slot
+	SlotTypePhysical SlotType = "physical"
+
+	// SlotTypeLogical represents the logical replication slot
+	SlotTypeLogical SlotType = "logical"
+)
 
 // ReplicationSlot represents a single replication slot
 type ReplicationSlot struct {
@@ -33,6 +40,10 @@ type ReplicationSlot struct {
 	RestartLSN string   `json:"restartLSN,omitempty"`
 	IsHA       bool     `json:"isHA,omitempty"`
 	HoldsXmin  bool     `json:"holdsXmin,omitempty"`
+
+	// InactiveSince is the time the slot has been inactive since, as
+	// tracked by PostgreSQL 17 and newer versions
+	InactiveSince *time.Time `json:"inactiveSince,omitempty"`
 }
 
 // ReplicationSlotList contains a list of replication slots
@@ -0,0 +1,157 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reconciler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/infrastructure"
+)
+
+// logicalSlotDropRetryInterval is the time after which dropping an orphaned
+// logical replication slot is retried, when it fails
+const logicalSlotDropRetryInterval = time.Minute
+
+// LogicalSlotsCleaner drops, on the primary, the logical replication slots
+// that don't belong to a declared consumer and have been inactive for longer
+// than the configured timeout. Since PostgreSQL 17, the time a slot has been
+// inactive since is the one tracked by PostgreSQL. With the older versions,
+// it is tracked in memory, so that the timeout is only measured from when the
+// slot was first seen inactive by this instance manager.
+type LogicalSlotsCleaner struct {
+	// the time every orphaned logical replication slot has been seen
+	// inactive since
+	inactiveSince map[string]time.Time
+
+	// now returns the current time, and can be replaced in tests
+	now func() time.Time
+}
+
+// NewLogicalSlotsCleaner creates a new LogicalSlotsCleaner
+func NewLogicalSlotsCleaner() *LogicalSlotsCleaner {
+	return &LogicalSlotsCleaner{
+		inactiveSince: make(map[string]time.Time),
+		now:           time.Now,
+	}
+}
+
+// Reconcile drops the orphaned logical replication slots of the instance,
+// if the automatic cleanup is enabled and the instance is the primary.
+// An event is emitted on the cluster when an orphaned slot is detected,
+// and another one before dropping it. A slot that can't be dropped is
+// retried later, without stopping the cleanup of the other ones. The
+// returned duration is the time after which the cleanup needs to run
+// again, or zero if there is no orphaned slot left.
+func (c *LogicalSlotsCleaner) Reconcile(
+	ctx context.Context,
+	instanceName string,
+	db *sql.DB,
+	pgMajor uint64,
+	cluster *apiv1.Cluster,
+	recorder record.EventRecorder,
+) (time.Duration, error) {
+	var config *apiv1.LogicalSlotsCleanupConfiguration
+	if cluster.Spec.ReplicationSlots != nil {
+		config = cluster.Spec.ReplicationSlots.LogicalSlotsCleanup
+	}
+
+	// The logical replication slots of a replica cluster are either
+	// synchronized from the source or unusable, so they are left alone
+	if !config.GetEnabled() || cluster.IsReplica() || cluster.Status.CurrentPrimary != instanceName {
+		clear(c.inactiveSince)
+		return 0, nil
+	}
+
+	contextLogger := log.FromContext(ctx).WithName("logical_slots_cleanup")
+
+	slots, err := infrastructure.ListLogical(ctx, db, pgMajor)
+	if err != nil {
+		return 0, fmt.Errorf("while listing the logical replication slots: %w", err)
+	}
+
+	now := c.now()
+	timeout := config.GetInactiveTimeout()
+	var nextCheck time.Duration
+	scheduleCheck := func(after time.Duration) {
+		if nextCheck == 0 || after < nextCheck {
+			nextCheck = after
+		}
+	}
+
+	orphanedSlots := make(map[string]bool, len(slots.Items))
+	for _, slot := range slots.Items {
+		if slot.Active || config.IsDeclaredConsumer(slot.SlotName) {
+			continue
+		}
+		orphanedSlots[slot.SlotName] = true
+
+		inactiveSince, found := c.inactiveSince[slot.SlotName]
+		if slot.InactiveSince != nil {
+			inactiveSince = *slot.InactiveSince
+		} else if !found {
+			inactiveSince = now
+		}
+		c.inactiveSince[slot.SlotName] = inactiveSince
+
+		if !found {
+			contextLogger.Info("Detected an inactive logical replication slot without a declared consumer",
+				"slot", slot.SlotName, "inactiveSince", inactiveSince, "inactiveTimeout", timeout)
+			recorder.Eventf(cluster, "Normal", "OrphanedLogicalSlot",
+				"Logical replication slot %q is inactive and has no declared consumer: "+
+					"it will be dropped if it stays inactive for %v", slot.SlotName, timeout)
+		}
+
+		if remaining := timeout - now.Sub(inactiveSince); remaining > 0 {
+			scheduleCheck(remaining)
+			continue
+		}
+
+		contextLogger.Info("Dropping orphaned logical replication slot",
+			"slot", slot.SlotName, "inactiveSince", inactiveSince)
+		recorder.Eventf(cluster, "Warning", "DroppingOrphanedLogicalSlot",
+			"Dropping logical replication slot %q, inactive since %v and without a declared consumer",
+			slot.SlotName, inactiveSince.Format(time.RFC3339))
+		if err := infrastructure.Delete(ctx, db, slot); err != nil {
+			contextLogger.Error(err, "while dropping the orphaned logical replication slot, retrying later",
+				"slot", slot.SlotName, "retryInterval", logicalSlotDropRetryInterval)
+			scheduleCheck(logicalSlotDropRetryInterval)
+			continue
+		}
+		delete(c.inactiveSince, slot.SlotName)
+		delete(orphanedSlots, slot.SlotName)
+	}
+
+	// Forget the slots that have been dropped, became active again
+	// or have been declared in the meantime
+	for slotName := range c.inactiveSince {
+		if !orphanedSlots[slotName] {
+			delete(c.inactiveSince, slotName)
+		}
+	}
+
+	return nextCheck, nil
+}
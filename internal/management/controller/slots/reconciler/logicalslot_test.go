@@ -0,0 +1,161 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reconciler
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/infrastructure"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Orphaned logical replication slots cleanup", func() {
+	const listLogicalSQL = "^SELECT (.+) FROM pg_catalog.pg_replication_slots (.+) slot_type = 'logical'"
+
+	var (
+		db       *sql.DB
+		mock     sqlmock.Sqlmock
+		recorder *record.FakeRecorder
+		cleaner  *LogicalSlotsCleaner
+		cluster  *apiv1.Cluster
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder = record.NewFakeRecorder(10)
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cleaner = NewLogicalSlotsCleaner()
+		cleaner.now = func() time.Time { return now }
+
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					LogicalSlotsCleanup: &apiv1.LogicalSlotsCleanupConfiguration{
+						Enabled:         true,
+						Consumers:       []string{"declared"},
+						InactiveTimeout: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "instance1",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	logicalSlotColumns := []string{"slot_name", "slot_type", "active", "restart_lsn", "holds_xmin", "inactive_since"}
+	expectLogicalSlots := func(slots map[string]bool) {
+		rows := sqlmock.NewRows(logicalSlotColumns)
+		for name, active := range slots {
+			rows.AddRow(name, string(infrastructure.SlotTypeLogical), active, "0/1000", true, nil)
+		}
+		mock.ExpectQuery(listLogicalSQL).WillReturnRows(rows)
+	}
+
+	It("does nothing when disabled or not on the primary", func(ctx SpecContext) {
+		Expect(cleaner.Reconcile(ctx, "instance2", db, 16, cluster, recorder)).To(BeZero())
+
+		cluster.Spec.ReplicationSlots.LogicalSlotsCleanup.Enabled = false
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(BeZero())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("drops an orphaned slot only after the inactive timeout", func(ctx SpecContext) {
+		expectLogicalSlots(map[string]bool{"orphaned": false, "declared": false, "active": true})
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(Equal(time.Hour))
+		Expect(recorder.Events).To(Receive(ContainSubstring("OrphanedLogicalSlot")))
+
+		now = now.Add(30 * time.Minute)
+		expectLogicalSlots(map[string]bool{"orphaned": false, "declared": false, "active": true})
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(Equal(30 * time.Minute))
+		Expect(recorder.Events).To(BeEmpty())
+
+		now = now.Add(time.Hour)
+		expectLogicalSlots(map[string]bool{"orphaned": false, "declared": false, "active": true})
+		mock.ExpectExec("SELECT pg_catalog.pg_drop_replication_slot").WithArgs("orphaned").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("DroppingOrphanedLogicalSlot")))
+		Expect(cleaner.inactiveSince).To(BeEmpty())
+	})
+
+	It("restarts the timeout when a slot becomes active again", func(ctx SpecContext) {
+		expectLogicalSlots(map[string]bool{"orphaned": false})
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(Equal(time.Hour))
+
+		now = now.Add(30 * time.Minute)
+		expectLogicalSlots(map[string]bool{"orphaned": true})
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(BeZero())
+		Expect(cleaner.inactiveSince).To(BeEmpty())
+
+		now = now.Add(time.Hour)
+		expectLogicalSlots(map[string]bool{"orphaned": false})
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 16, cluster, recorder)).To(Equal(time.Hour))
+		Expect(cleaner.inactiveSince).To(HaveKeyWithValue("orphaned", now))
+	})
+
+	It("uses the inactivity time tracked by PostgreSQL 17", func(ctx SpecContext) {
+		mock.ExpectQuery(listLogicalSQL).WillReturnRows(sqlmock.NewRows(logicalSlotColumns).
+			AddRow("orphaned", string(infrastructure.SlotTypeLogical), false, "0/1000", true,
+				now.Add(-2*time.Hour)).
+			AddRow("recent", string(infrastructure.SlotTypeLogical), false, "0/1000", true,
+				now.Add(-15*time.Minute)))
+		mock.ExpectExec("SELECT pg_catalog.pg_drop_replication_slot").WithArgs("orphaned").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 17, cluster, recorder)).To(Equal(45 * time.Minute))
+		Expect(cleaner.inactiveSince).To(HaveKeyWithValue("recent", now.Add(-15*time.Minute)))
+		Expect(cleaner.inactiveSince).ToNot(HaveKey("orphaned"))
+	})
+
+	It("keeps dropping the other slots when one of them can't be dropped", func(ctx SpecContext) {
+		mock.ExpectQuery(listLogicalSQL).WillReturnRows(sqlmock.NewRows(logicalSlotColumns).
+			AddRow("failing", string(infrastructure.SlotTypeLogical), false, "0/1000", true,
+				now.Add(-2*time.Hour)).
+			AddRow("orphaned", string(infrastructure.SlotTypeLogical), false, "0/1000", true,
+				now.Add(-2*time.Hour)))
+		mock.ExpectExec("SELECT pg_catalog.pg_drop_replication_slot").WithArgs("failing").
+			WillReturnError(errors.New("slot in use"))
+		mock.ExpectExec("SELECT pg_catalog.pg_drop_replication_slot").WithArgs("orphaned").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		Expect(cleaner.Reconcile(ctx, "instance1", db, 17, cluster, recorder)).
+			To(Equal(logicalSlotDropRetryInterval))
+		Expect(cleaner.inactiveSince).To(HaveKey("failing"))
+		Expect(cleaner.inactiveSince).ToNot(HaveKey("orphaned"))
+	})
+})
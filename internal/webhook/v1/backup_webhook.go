@@ -172,6 +172,8 @@ func (v *BackupCustomValidator) validate(r *apiv1.Backup) field.ErrorList {
 		))
 	}
 
+	result = append(result, validatePgDump(r)...)
+
 	if value := r.Annotations[utils.BackupVolumeSnapshotDeadlineAnnotationName]; value != "" {
 		_, err := strconv.Atoi(value)
 		if err != nil {
@@ -185,3 +187,52 @@ func (v *BackupCustomValidator) validate(r *apiv1.Backup) field.ErrorList {
 
 	return result
 }
+
+// validatePgDump checks the options of the logical dumps taken with
+// the pgDump method
+func validatePgDump(r *apiv1.Backup) field.ErrorList {
+	pgDumpPath := field.NewPath("spec", "pgDump")
+
+	if r.Spec.Method != apiv1.BackupMethodPgDump {
+		if r.Spec.PgDump != nil {
+			return field.ErrorList{field.Invalid(
+				pgDumpPath,
+				r.Spec.PgDump,
+				"can be specified only if the backup method is pgDump",
+			)}
+		}
+		return nil
+	}
+
+	var result field.ErrorList
+	if r.Spec.PgDump == nil {
+		result = append(result, field.Required(
+			pgDumpPath,
+			"cannot be empty when the backup method is pgDump",
+		))
+	} else if r.Spec.PgDump.SchemaOnly && r.Spec.PgDump.DataOnly {
+		result = append(result, field.Invalid(
+			pgDumpPath.Child("dataOnly"),
+			r.Spec.PgDump.DataOnly,
+			"cannot be used together with schemaOnly",
+		))
+	}
+
+	if r.Spec.Online != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "online"),
+			r.Spec.Online,
+			"Online parameter can be specified only if the backup method is volumeSnapshot",
+		))
+	}
+
+	if r.Spec.OnlineConfiguration != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "onlineConfiguration"),
+			r.Spec.OnlineConfiguration,
+			"OnlineConfiguration parameter can be specified only if the backup method is volumeSnapshot",
+		))
+	}
+
+	return result
+}
@@ -118,4 +118,59 @@ var _ = Describe("Backup webhook validate", func() {
 		result := v.validate(backup)
 		Expect(result).To(BeEmpty())
 	})
+
+	It("requires the pgDump section with the pgDump method", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodPgDump,
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.pgDump"))
+
+		backup.Spec.PgDump = &apiv1.BackupPgDumpConfiguration{Database: "app", SchemaOnly: true}
+		Expect(v.validate(backup)).To(BeEmpty())
+	})
+
+	It("complains if schemaOnly and dataOnly are both set", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodPgDump,
+				PgDump: &apiv1.BackupPgDumpConfiguration{
+					Database:   "app",
+					SchemaOnly: true,
+					DataOnly:   true,
+				},
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.pgDump.dataOnly"))
+	})
+
+	It("complains if the pgDump section is set with another method", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodBarmanObjectStore,
+				PgDump: &apiv1.BackupPgDumpConfiguration{Database: "app"},
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.pgDump"))
+	})
+
+	It("complains if online is set on a pgDump backup", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodPgDump,
+				PgDump: &apiv1.BackupPgDumpConfiguration{Database: "app"},
+				Online: ptr.To(true),
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.online"))
+	})
 })
@@ -23,9 +23,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"net"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	barmanWebhooks "github.com/cloudnative-pg/barman-cloud/pkg/api/webhooks"
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
@@ -35,12 +39,16 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/types"
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	validationutil "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -110,7 +118,12 @@ func (v *ClusterCustomValidator) ValidateCreate(_ context.Context, obj runtime.O
 	clusterLog.Info("Validation for Cluster upon creation", "name", cluster.GetName(), "namespace",
 		cluster.GetNamespace())
 
-	allErrs := v.validate(cluster)
+	// The locale options are only used by initdb, and are immutable
+	// afterward, so their combination is only validated on creation
+	allErrs := append(
+		v.validate(cluster),
+		v.validateInitDBLocale(cluster)...,
+	)
 	allWarnings := v.getAdmissionWarnings(cluster)
 
 	if len(allErrs) == 0 {
@@ -180,10 +193,13 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateRecoveryApplicationDatabase,
 		v.validatePgBaseBackupApplicationDatabase,
 		v.validateImport,
+		v.validateImportDump,
 		v.validateSuperuserSecret,
 		v.validateCerts,
 		v.validateBootstrapMethod,
 		v.validateImageName,
+		v.validateImageCatalogAutoUpdate,
+		v.validateCollationReindexWindow,
 		v.validateImagePullPolicy,
 		v.validateRecoveryTarget,
 		v.validatePrimaryUpdateStrategy,
@@ -193,27 +209,52 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateWalStorageSize,
 		v.validateEphemeralVolumeSource,
 		v.validateTablespaceStorageSize,
+		v.validateEphemeralTablespaces,
 		v.validateName,
 		v.validateTablespaceNames,
 		v.validateBootstrapPgBaseBackupSource,
 		v.validateTablespaceBackupSnapshot,
 		v.validateBootstrapRecoverySource,
 		v.validateBootstrapRecoveryDataSource,
+		v.validateBootstrapRecoveryParameters,
 		v.validateExternalClusters,
 		v.validateTolerations,
 		v.validateAntiAffinity,
 		v.validateReplicaMode,
 		v.validateBackupConfiguration,
 		v.validateRetentionPolicy,
+		v.validateRecoveryJobResources,
+		v.validatePostBackupHook,
 		v.validateConfiguration,
 		v.validateSynchronousReplicaConfiguration,
 		v.validateFailoverQuorumAlphaAnnotation,
 		v.validateFailoverQuorum,
+		v.validateFailoverCooldown,
+		v.validateFailoverExcludedInstances,
+		v.validateDelayedReplicas,
+		v.validateStartupFailurePolicy,
+		v.validateTerminationGracePeriod,
+		v.validateTopology,
+		v.validateWALArchiving,
 		v.validateLDAP,
+		v.validateSSL,
+		v.validateLogging,
+		v.validateHugePages,
+		v.validateIdleSessions,
+		v.validateAutovacuum,
+		v.validatePasswordEncryption,
+		v.validateSCRAMOverSSL,
+		v.validateExternalConnection,
+		v.validatePrimaryConnInfoParameters,
 		v.validateReplicationSlots,
 		v.validateSynchronizeLogicalDecoding,
+		v.validateLogicalSlotsCleanup,
 		v.validateEnv,
+		v.validateInitContainers,
 		v.validateManagedServices,
+		v.validateManagedNetworkPolicy,
+		v.validatePodMonitorScrapeTimeout,
+		v.validateDefaultAlertsThresholds,
 		v.validateManagedRoles,
 		v.validateManagedExtensions,
 		v.validateResources,
@@ -222,6 +263,7 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validatePromotionToken,
 		v.validatePluginConfiguration,
 		v.validateLivenessPingerProbe,
+		v.validateReadinessQuery,
 		v.validateExtensions,
 	}
 
@@ -248,6 +290,8 @@ func (v *ClusterCustomValidator) validateClusterChanges(r, old *apiv1.Cluster) (
 		v.validateWalStorageChange,
 		v.validateTablespacesChange,
 		v.validateUnixPermissionIdentifierChange,
+		v.validateWalSegmentSizeChange,
+		v.validateInitDBLocaleChange,
 		v.validateReplicationSlotsChange,
 		v.validateWALLevelChange,
 		v.validateReplicaClusterChange,
@@ -259,6 +303,477 @@ func (v *ClusterCustomValidator) validateClusterChanges(r, old *apiv1.Cluster) (
 	return allErrs
 }
 
+// tlsProtocolVersionsOrder is the list of the TLS protocol versions
+// supported by PostgreSQL, from the oldest to the newest one
+var tlsProtocolVersionsOrder = []apiv1.TLSProtocolVersion{
+	apiv1.TLSProtocolVersion10,
+	apiv1.TLSProtocolVersion11,
+	apiv1.TLSProtocolVersion12,
+	apiv1.TLSProtocolVersion13,
+}
+
+// validateSSL validates the TLS hardening configuration
+func (v *ClusterCustomValidator) validateSSL(r *apiv1.Cluster) field.ErrorList {
+	ssl := r.Spec.PostgresConfiguration.SSL
+	if ssl == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "ssl")
+
+	for _, setting := range []struct {
+		name      string
+		parameter string
+		value     string
+	}{
+		{name: "minProtocolVersion", parameter: postgres.ParameterSSLMinProtocolVersion,
+			value: string(ssl.MinProtocolVersion)},
+		{name: "maxProtocolVersion", parameter: postgres.ParameterSSLMaxProtocolVersion,
+			value: string(ssl.MaxProtocolVersion)},
+		{name: "ciphers", parameter: postgres.ParameterSSLCiphers, value: ssl.Ciphers},
+	} {
+		if setting.value == "" {
+			continue
+		}
+
+		if _, found := r.Spec.PostgresConfiguration.Parameters[setting.parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", setting.parameter),
+				r.Spec.PostgresConfiguration.Parameters[setting.parameter],
+				fmt.Sprintf("cannot be set together with %s", basePath.Child(setting.name))))
+		}
+	}
+
+	minIndex := slices.Index(tlsProtocolVersionsOrder, ssl.MinProtocolVersion)
+	if ssl.MinProtocolVersion != "" && minIndex < 0 {
+		result = append(result, field.NotSupported(
+			basePath.Child("minProtocolVersion"), ssl.MinProtocolVersion, tlsProtocolVersionsOrder))
+	}
+
+	maxIndex := slices.Index(tlsProtocolVersionsOrder, ssl.MaxProtocolVersion)
+	if ssl.MaxProtocolVersion != "" && maxIndex < 0 {
+		result = append(result, field.NotSupported(
+			basePath.Child("maxProtocolVersion"), ssl.MaxProtocolVersion, tlsProtocolVersionsOrder))
+	}
+
+	if minIndex >= 0 && maxIndex >= 0 && minIndex > maxIndex {
+		result = append(result, field.Invalid(
+			basePath.Child("minProtocolVersion"),
+			ssl.MinProtocolVersion,
+			fmt.Sprintf("cannot be greater than %s", basePath.Child("maxProtocolVersion"))))
+	}
+
+	if strings.ContainsAny(ssl.Ciphers, "'\\\n") {
+		result = append(result, field.Invalid(
+			basePath.Child("ciphers"),
+			ssl.Ciphers,
+			"the list of ciphers cannot contain quotes, backslashes or newlines"))
+	}
+
+	return result
+}
+
+// validateLogging validates the typed logging configuration
+func (v *ClusterCustomValidator) validateLogging(r *apiv1.Cluster) field.ErrorList {
+	logging := r.Spec.PostgresConfiguration.Logging
+	if logging == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "logging")
+
+	for _, setting := range []struct {
+		name      string
+		parameter string
+		isSet     bool
+	}{
+		{name: "slowQueryThreshold", parameter: postgres.ParameterLogMinDurationStatement,
+			isSet: logging.SlowQueryThreshold != nil},
+		{name: "logStatement", parameter: postgres.ParameterLogStatement, isSet: logging.LogStatement != ""},
+		{name: "logConnections", parameter: postgres.ParameterLogConnections, isSet: logging.LogConnections != nil},
+		{name: "logDisconnections", parameter: postgres.ParameterLogDisconnections,
+			isSet: logging.LogDisconnections != nil},
+	} {
+		if !setting.isSet {
+			continue
+		}
+
+		if _, found := r.Spec.PostgresConfiguration.Parameters[setting.parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", setting.parameter),
+				r.Spec.PostgresConfiguration.Parameters[setting.parameter],
+				fmt.Sprintf("cannot be set together with %s", basePath.Child(setting.name))))
+		}
+	}
+
+	if logging.SlowQueryThreshold != nil && logging.SlowQueryThreshold.Duration < 0 {
+		result = append(result, field.Invalid(
+			basePath.Child("slowQueryThreshold"),
+			logging.SlowQueryThreshold.String(),
+			"slow query threshold cannot be negative"))
+	}
+
+	return result
+}
+
+// validateAutovacuum validates the tuning options of the autovacuum daemon,
+// using the same ranges accepted by PostgreSQL for the corresponding parameters
+func (v *ClusterCustomValidator) validateAutovacuum(r *apiv1.Cluster) field.ErrorList {
+	autovacuum := r.Spec.PostgresConfiguration.Autovacuum
+	if autovacuum == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "autovacuum")
+
+	for _, setting := range []struct {
+		name      string
+		parameter string
+		isSet     bool
+	}{
+		{name: "naptime", parameter: postgres.ParameterAutovacuumNaptime,
+			isSet: autovacuum.Naptime != nil},
+		{name: "maxWorkers", parameter: postgres.ParameterAutovacuumMaxWorkers,
+			isSet: autovacuum.MaxWorkers != nil},
+		{name: "vacuumCostDelay", parameter: postgres.ParameterAutovacuumVacuumCostDelay,
+			isSet: autovacuum.VacuumCostDelay != nil},
+		{name: "vacuumCostLimit", parameter: postgres.ParameterAutovacuumVacuumCostLimit,
+			isSet: autovacuum.VacuumCostLimit != nil},
+	} {
+		if !setting.isSet {
+			continue
+		}
+
+		if _, found := r.Spec.PostgresConfiguration.Parameters[setting.parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", setting.parameter),
+				r.Spec.PostgresConfiguration.Parameters[setting.parameter],
+				fmt.Sprintf("cannot be set together with %s", basePath.Child(setting.name))))
+		}
+	}
+
+	if naptime := autovacuum.Naptime; naptime != nil {
+		if naptime.Duration < time.Second || naptime.Duration > 2147483*time.Second ||
+			naptime.Duration%time.Second != 0 {
+			result = append(result, field.Invalid(
+				basePath.Child("naptime"),
+				naptime.String(),
+				"naptime must be a whole number of seconds between 1s and 2147483s"))
+		}
+	}
+
+	if maxWorkers := autovacuum.MaxWorkers; maxWorkers != nil && (*maxWorkers < 1 || *maxWorkers > 262143) {
+		result = append(result, field.Invalid(
+			basePath.Child("maxWorkers"),
+			*maxWorkers,
+			"the number of autovacuum workers must be between 1 and 262143"))
+	}
+
+	if costDelay := autovacuum.VacuumCostDelay; costDelay != nil &&
+		(costDelay.Duration < 0 || costDelay.Duration > 100*time.Millisecond) {
+		result = append(result, field.Invalid(
+			basePath.Child("vacuumCostDelay"),
+			costDelay.String(),
+			"the cost delay must be between 0 and 100ms"))
+	}
+
+	if costLimit := autovacuum.VacuumCostLimit; costLimit != nil &&
+		(*costLimit < -1 || *costLimit == 0 || *costLimit > 10000) {
+		result = append(result, field.Invalid(
+			basePath.Child("vacuumCostLimit"),
+			*costLimit,
+			"the cost limit must be either -1 or between 1 and 10000"))
+	}
+
+	return result
+}
+
+// validateIdleSessions validates the timeouts of the idle sessions
+func (v *ClusterCustomValidator) validateIdleSessions(r *apiv1.Cluster) field.ErrorList {
+	idleSessions := r.Spec.PostgresConfiguration.IdleSessions
+	if idleSessions == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "idleSessions")
+
+	for _, setting := range []struct {
+		name      string
+		parameter string
+		value     *metav1.Duration
+	}{
+		{name: "sessionTimeout", parameter: postgres.ParameterIdleSessionTimeout,
+			value: idleSessions.SessionTimeout},
+		{name: "inTransactionTimeout", parameter: postgres.ParameterIdleInTransactionSessionTimeout,
+			value: idleSessions.InTransactionTimeout},
+	} {
+		if setting.value == nil {
+			continue
+		}
+
+		if _, found := r.Spec.PostgresConfiguration.Parameters[setting.parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", setting.parameter),
+				r.Spec.PostgresConfiguration.Parameters[setting.parameter],
+				fmt.Sprintf("cannot be set together with %s", basePath.Child(setting.name))))
+		}
+
+		// PostgreSQL expresses these timeouts in milliseconds, and a
+		// shorter value would silently disable the timeout
+		if setting.value.Duration < 0 ||
+			(setting.value.Duration > 0 && setting.value.Duration < time.Millisecond) {
+			result = append(result, field.Invalid(
+				basePath.Child(setting.name),
+				setting.value.String(),
+				"timeout must be either zero or at least one millisecond"))
+		}
+
+		if setting.value.Duration.Milliseconds() > math.MaxInt32 {
+			result = append(result, field.Invalid(
+				basePath.Child(setting.name),
+				setting.value.String(),
+				fmt.Sprintf("timeout must not exceed %d milliseconds", math.MaxInt32)))
+		}
+	}
+
+	if idleSessions.SessionTimeout != nil {
+		if pgMajor, err := r.GetPostgresqlMajorVersion(); err == nil && pgMajor < 14 {
+			result = append(result, field.Invalid(
+				basePath.Child("sessionTimeout"),
+				idleSessions.SessionTimeout.String(),
+				"idle session timeout requires PostgreSQL 14 or newer"))
+		}
+	}
+
+	return result
+}
+
+// validatePasswordEncryption validates the settings used to hash the passwords
+func (v *ClusterCustomValidator) validatePasswordEncryption(r *apiv1.Cluster) field.ErrorList {
+	passwordEncryption := r.Spec.PostgresConfiguration.PasswordEncryption
+	if passwordEncryption == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "passwordEncryption")
+
+	for _, setting := range []struct {
+		name      string
+		parameter string
+		isSet     bool
+	}{
+		{name: "method", parameter: postgres.ParameterPasswordEncryption,
+			isSet: passwordEncryption.Method != ""},
+		{name: "scramIterations", parameter: postgres.ParameterScramIterations,
+			isSet: passwordEncryption.ScramIterations != nil},
+	} {
+		if !setting.isSet {
+			continue
+		}
+
+		if _, found := r.Spec.PostgresConfiguration.Parameters[setting.parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", setting.parameter),
+				r.Spec.PostgresConfiguration.Parameters[setting.parameter],
+				fmt.Sprintf("cannot be set together with %s", basePath.Child(setting.name))))
+		}
+	}
+
+	if passwordEncryption.ScramIterations != nil {
+		if *passwordEncryption.ScramIterations < 1 {
+			result = append(result, field.Invalid(
+				basePath.Child("scramIterations"),
+				*passwordEncryption.ScramIterations,
+				"the number of iterations must be positive"))
+		}
+		if passwordEncryption.Method == apiv1.PasswordEncryptionMethodMD5 {
+			result = append(result, field.Invalid(
+				basePath.Child("scramIterations"),
+				*passwordEncryption.ScramIterations,
+				"the number of iterations is only used by the scram-sha-256 method"))
+		}
+	}
+
+	return result
+}
+
+// validateSCRAMOverSSL checks that every password authentication can
+// happen with SCRAM-SHA-256 over TLS, which makes the SCRAM channel binding
+// available to the clients: every password must be hashed with
+// SCRAM-SHA-256, and the user-defined rules must not accept connections
+// without TLS or authenticate them with a method other than SCRAM
+func (v *ClusterCustomValidator) validateSCRAMOverSSL(r *apiv1.Cluster) field.ErrorList {
+	postgresConfig := r.Spec.PostgresConfiguration
+	if !postgresConfig.RequireSCRAMOverSSL {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql")
+	const detail = "requireSCRAMOverSSL is enabled"
+
+	passwordEncryptionPath := basePath.Child("passwordEncryption", "method")
+	var passwordEncryption string
+	switch {
+	case postgresConfig.PasswordEncryption != nil && postgresConfig.PasswordEncryption.Method != "":
+		passwordEncryption = string(postgresConfig.PasswordEncryption.Method)
+	case postgresConfig.Parameters[postgres.ParameterPasswordEncryption] != "":
+		passwordEncryptionPath = basePath.Child("parameters", postgres.ParameterPasswordEncryption)
+		passwordEncryption = postgresConfig.Parameters[postgres.ParameterPasswordEncryption]
+	default:
+		// md5 is the default up to PostgreSQL 13
+		if pgMajor, err := r.GetPostgresqlMajorVersion(); err == nil && pgMajor < 14 {
+			passwordEncryption = string(apiv1.PasswordEncryptionMethodMD5)
+		}
+	}
+	if passwordEncryption == string(apiv1.PasswordEncryptionMethodMD5) {
+		result = append(result, field.Invalid(
+			passwordEncryptionPath,
+			passwordEncryption,
+			fmt.Sprintf("the passwords must be hashed with %s when %s",
+				apiv1.PasswordEncryptionMethodScramSHA256, detail)))
+	}
+
+	for idx, rule := range postgresConfig.PgHBA {
+		if err := postgres.ValidateHBARuleSCRAMOverSSL(rule); err != nil {
+			result = append(result, field.Invalid(
+				basePath.Child("pg_hba").Index(idx),
+				rule,
+				fmt.Sprintf("%s when %s", err.Error(), detail)))
+		}
+	}
+
+	if postgresConfig.LDAP != nil {
+		result = append(result, field.Invalid(
+			basePath.Child("ldap"),
+			"",
+			fmt.Sprintf("the LDAP authentication can't be used when %s", detail)))
+	}
+
+	return result
+}
+
+// validateExternalConnection checks that the host of the external
+// connection is a valid DNS name or IP address
+func (v *ClusterCustomValidator) validateExternalConnection(r *apiv1.Cluster) field.ErrorList {
+	externalConnection := r.Spec.ExternalConnection
+	if externalConnection == nil || net.ParseIP(externalConnection.Host) != nil {
+		return nil
+	}
+
+	if errs := validationutil.IsDNS1123Subdomain(externalConnection.Host); len(errs) > 0 {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec", "externalConnection", "host"),
+			externalConnection.Host,
+			"the external host must be a valid DNS name or IP address")}
+	}
+
+	return nil
+}
+
+// validatePrimaryConnInfoParameters validates the parameters appended to
+// the connection string used by the replicas to reach the primary, which
+// can't replace the endpoint and the credentials managed by the operator
+func (v *ClusterCustomValidator) validatePrimaryConnInfoParameters(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "primaryConnInfoParameters")
+
+	for name := range r.Spec.PostgresConfiguration.PrimaryConnInfoParameters {
+		switch {
+		case !isValidConnInfoKeyword(name):
+			result = append(result, field.Invalid(
+				basePath.Key(name),
+				name,
+				"the parameter name must contain only lowercase letters, digits and underscores"))
+
+		case postgres.IsPrimaryConnInfoParameterReserved(name):
+			result = append(result, field.Forbidden(
+				basePath.Key(name),
+				"the parameter is managed by the operator, as it defines the endpoint or the credentials"))
+		}
+	}
+
+	return result
+}
+
+// isValidConnInfoKeyword checks if the passed string can be used as the
+// keyword of a connection string parameter
+func isValidConnInfoKeyword(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateHugePages validates the huge pages managed by the operator
+func (v *ClusterCustomValidator) validateHugePages(r *apiv1.Cluster) field.ErrorList {
+	hugePages := r.Spec.PostgresConfiguration.HugePages
+	if hugePages == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "hugePages")
+
+	for _, parameter := range []string{postgres.ParameterHugePages, postgres.ParameterHugePageSize} {
+		if value, found := r.Spec.PostgresConfiguration.Parameters[parameter]; found {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", parameter),
+				value,
+				fmt.Sprintf("cannot be set together with %s", basePath)))
+		}
+	}
+
+	// Before PostgreSQL 14, huge_page_size is not available and the
+	// shared memory is allocated in huge pages of the default size of
+	// the node, which is usually 2Mi
+	if hugePages.PageSize != "" && hugePages.PageSize != "2Mi" {
+		if pgMajor, err := r.GetPostgresqlMajorVersion(); err == nil && pgMajor < 14 {
+			result = append(result, field.Invalid(
+				basePath.Child("pageSize"),
+				hugePages.PageSize,
+				"huge pages larger than 2Mi require PostgreSQL 14 or newer"))
+		}
+	}
+
+	for _, resources := range []struct {
+		path *field.Path
+		list corev1.ResourceList
+	}{
+		{path: field.NewPath("spec", "resources", "requests"), list: r.Spec.Resources.Requests},
+		{path: field.NewPath("spec", "resources", "limits"), list: r.Spec.Resources.Limits},
+	} {
+		for name := range resources.list {
+			if strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+				result = append(result, field.Forbidden(
+					resources.path.Child(string(name)),
+					fmt.Sprintf("cannot be set together with %s, which computes the huge pages request", basePath)))
+			}
+		}
+	}
+
+	if _, err := specs.GetHugePagesRequest(r); err != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "postgresql", "parameters", sharedBuffersParameter),
+			r.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter],
+			fmt.Sprintf("cannot compute the huge pages request: %v", err)))
+	}
+
+	return result
+}
+
 // validateLDAP validates the ldap postgres configuration
 func (v *ClusterCustomValidator) validateLDAP(r *apiv1.Cluster) field.ErrorList {
 	// No validating if not specified
@@ -304,6 +819,54 @@ func (v *ClusterCustomValidator) validateEnv(r *apiv1.Cluster) field.ErrorList {
 	return result
 }
 
+// validateInitContainers checks that the user-provided init containers
+// don't clash with the ones of the operator and don't touch the volumes
+// used by PostgreSQL
+func (v *ClusterCustomValidator) validateInitContainers(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	reservedVolumes := stringset.From([]string{"pgdata", "pg-wal"})
+	for _, tablespace := range r.Spec.Tablespaces {
+		reservedVolumes.Put(specs.VolumeMountNameForTablespace(tablespace.Name))
+	}
+
+	names := stringset.New()
+	basePath := field.NewPath("spec", "initContainers")
+	for i, container := range r.Spec.InitContainers {
+		containerPath := basePath.Index(i)
+
+		switch {
+		case container.Name == "":
+			result = append(result, field.Required(containerPath.Child("name"), "init container name is required"))
+		case container.Name == specs.BootstrapControllerContainerName || container.Name == specs.PostgresContainerName:
+			result = append(
+				result,
+				field.Invalid(containerPath.Child("name"),
+					container.Name,
+					"the usage of this container name is reserved for the operator"))
+		case names.Has(container.Name):
+			result = append(result, field.Duplicate(containerPath.Child("name"), container.Name))
+		}
+		names.Put(container.Name)
+
+		if container.Image == "" {
+			result = append(result, field.Required(containerPath.Child("image"), "init container image is required"))
+		}
+
+		for j, volumeMount := range container.VolumeMounts {
+			if reservedVolumes.Has(volumeMount.Name) {
+				result = append(
+					result,
+					field.Invalid(containerPath.Child("volumeMounts").Index(j).Child("name"),
+						volumeMount.Name,
+						"init containers cannot mount the volumes holding the PostgreSQL data"))
+			}
+		}
+	}
+
+	return result
+}
+
 // isReservedEnvironmentVariable detects if a certain environment variable
 // is reserved for the usage of the operator
 func isReservedEnvironmentVariable(name string) bool {
@@ -385,6 +948,101 @@ func (v *ClusterCustomValidator) validateInitDB(r *apiv1.Cluster) field.ErrorLis
 	return result
 }
 
+const (
+	// localeProviderLibc is the locale provider using the C library
+	localeProviderLibc = "libc"
+
+	// localeProviderICU is the locale provider using the ICU library
+	localeProviderICU = "icu"
+
+	// localeProviderBuiltin is the locale provider built into PostgreSQL
+	localeProviderBuiltin = "builtin"
+)
+
+// validateInitDBLocale validates the combination of the locale options
+// passed to initdb, and that they are supported by the PostgreSQL version
+func (v *ClusterCustomValidator) validateInitDBLocale(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.InitDB == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	initDB := r.Spec.Bootstrap.InitDB
+	basePath := field.NewPath("spec", "bootstrap", "initdb")
+
+	// The minimum PostgreSQL version supporting each option
+	minimumVersions := map[string]int{
+		localeProviderLibc:    15,
+		localeProviderICU:     15,
+		localeProviderBuiltin: 17,
+	}
+	requireVersion := func(path *field.Path, value string, version int) {
+		pgMajor, err := r.GetPostgresqlMajorVersion()
+		if err != nil {
+			// The validation error will be already raised by the
+			// validateImageName function
+			return
+		}
+		if pgMajor < version {
+			result = append(result, field.Invalid(
+				path,
+				value,
+				fmt.Sprintf("requires PostgreSQL %d or newer", version)))
+		}
+	}
+
+	switch initDB.LocaleProvider {
+	case "":
+	case localeProviderLibc, localeProviderICU, localeProviderBuiltin:
+		requireVersion(basePath.Child("localeProvider"), initDB.LocaleProvider,
+			minimumVersions[initDB.LocaleProvider])
+	default:
+		result = append(result, field.NotSupported(
+			basePath.Child("localeProvider"),
+			initDB.LocaleProvider,
+			[]string{localeProviderLibc, localeProviderICU, localeProviderBuiltin}))
+	}
+
+	// Only the initdb of PostgreSQL 15 requires the ICU locale to be
+	// explicitly set, while the newer ones derive it from the environment
+	if initDB.LocaleProvider == localeProviderICU && initDB.IcuLocale == "" && initDB.Locale == "" {
+		if pgMajor, err := r.GetPostgresqlMajorVersion(); err == nil && pgMajor == 15 {
+			result = append(result, field.Required(
+				basePath.Child("icuLocale"),
+				"the icu locale provider of PostgreSQL 15 requires an ICU locale, set via icuLocale or locale"))
+		}
+	}
+
+	if initDB.LocaleProvider == localeProviderBuiltin && initDB.BuiltinLocale == "" && initDB.Locale == "" {
+		result = append(result, field.Required(
+			basePath.Child("builtinLocale"),
+			"the builtin locale provider requires a locale, set via builtinLocale or locale"))
+	}
+
+	for _, option := range []struct {
+		name     string
+		value    string
+		provider string
+	}{
+		{name: "icuLocale", value: initDB.IcuLocale, provider: localeProviderICU},
+		{name: "icuRules", value: initDB.IcuRules, provider: localeProviderICU},
+		{name: "builtinLocale", value: initDB.BuiltinLocale, provider: localeProviderBuiltin},
+	} {
+		if option.value != "" && initDB.LocaleProvider != option.provider {
+			result = append(result, field.Invalid(
+				basePath.Child(option.name),
+				option.value,
+				fmt.Sprintf("requires localeProvider to be set to %s", option.provider)))
+		}
+	}
+
+	if initDB.IcuRules != "" {
+		requireVersion(basePath.Child("icuRules"), initDB.IcuRules, 16)
+	}
+
+	return result
+}
+
 func (v *ClusterCustomValidator) validateImport(r *apiv1.Cluster) field.ErrorList {
 	// If it's not configured, everything is ok
 	if r.Spec.Bootstrap == nil {
@@ -415,6 +1073,48 @@ func (v *ClusterCustomValidator) validateImport(r *apiv1.Cluster) field.ErrorLis
 	}
 }
 
+// validateImportDump is used to ensure that the source of a logical
+// dump bootstrap is correctly defined
+func (v *ClusterCustomValidator) validateImportDump(r *apiv1.Cluster) field.ErrorList {
+	if !r.IsImportDumpBootstrap() {
+		return nil
+	}
+
+	var result field.ErrorList
+	initDB := r.Spec.Bootstrap.InitDB
+	path := field.NewPath("spec", "bootstrap", "initdb", "importDump")
+
+	if initDB.Import != nil {
+		result = append(
+			result,
+			field.Forbidden(
+				path,
+				"importDump cannot be used together with import"))
+	}
+
+	sourceName := initDB.ImportDump.Source.ExternalCluster
+	externalCluster, found := r.ExternalCluster(sourceName)
+	switch {
+	case !found:
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("source", "externalCluster"),
+				sourceName,
+				fmt.Sprintf("External cluster %v not found", sourceName)))
+	case externalCluster.BarmanObjectStore == nil:
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("source", "externalCluster"),
+				sourceName,
+				fmt.Sprintf("External cluster %v cannot be used to import a logical dump: "+
+					"the Barman object store configuration is missing", sourceName)))
+	}
+
+	return result
+}
+
 func (v *ClusterCustomValidator) validateMicroservice(s *apiv1.Import) field.ErrorList {
 	var result field.ErrorList
 
@@ -721,6 +1421,45 @@ func (v *ClusterCustomValidator) validateBootstrapRecoverySource(r *apiv1.Cluste
 	return result
 }
 
+// validateBootstrapRecoveryParameters is used to ensure that the parameters
+// requested for the recovery don't change the ones managed by the operator
+func (v *ClusterCustomValidator) validateBootstrapRecoveryParameters(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	parametersPath := field.NewPath("spec", "bootstrap", "recovery", "parameters")
+
+	pgMajor, err := r.GetPostgresqlMajorVersion()
+	checkNames := err == nil && !utils.IsParametersValidationSkipped(&r.ObjectMeta)
+
+	for key, value := range r.Spec.Bootstrap.Recovery.Parameters {
+		if _, isFixed := postgres.FixedConfigurationParameters[key]; isFixed {
+			result = append(
+				result,
+				field.Invalid(
+					parametersPath.Key(key),
+					value,
+					"Can't set fixed configuration parameter"))
+			continue
+		}
+
+		if checkNames && !postgres.IsKnownParameter(key, pgMajor) {
+			result = append(
+				result,
+				field.Invalid(
+					parametersPath.Key(key),
+					value,
+					fmt.Sprintf("Unrecognized configuration parameter for PostgreSQL %d, "+
+						"set the %q annotation to %q to use it anyway",
+						pgMajor, utils.SkipParametersValidation, "enabled")))
+		}
+	}
+
+	return result
+}
+
 // validateBootstrapRecoveryDataSource is used to ensure that the data
 // source is correctly defined
 func (v *ClusterCustomValidator) validateBootstrapRecoveryDataSource(r *apiv1.Cluster) field.ErrorList {
@@ -890,6 +1629,13 @@ func (v *ClusterCustomValidator) validateResources(r *apiv1.Cluster) field.Error
 
 	hugePages, hugePagesErrors := validateHugePagesResources(r)
 	result = append(result, hugePagesErrors...)
+	if managedHugePages := r.Spec.PostgresConfiguration.HugePages; managedHugePages != nil {
+		// The huge pages managed by the operator are checked by validateHugePages,
+		// here we only take into account their quantity
+		if hugePagesRequest, err := specs.GetHugePagesRequest(r); err == nil {
+			hugePages[managedHugePages.GetResourceName()] = hugePagesRequest
+		}
+	}
 	if cpuRequests.IsZero() && cpuLimits.IsZero() && memoryRequests.IsZero() && memoryLimits.IsZero() &&
 		len(hugePages) > 0 {
 		result = append(result, field.Forbidden(
@@ -1060,6 +1806,364 @@ func (v *ClusterCustomValidator) validateFailoverQuorum(r *apiv1.Cluster) field.
 	return result
 }
 
+// validateFailoverCooldown checks that the failover cooldown is not negative
+func (v *ClusterCustomValidator) validateFailoverCooldown(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Failover == nil || r.Spec.Failover.Cooldown == nil {
+		return nil
+	}
+
+	if r.Spec.Failover.Cooldown.Duration < 0 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "failover", "cooldown"),
+				r.Spec.Failover.Cooldown.String(),
+				"the failover cooldown cannot be negative"),
+		}
+	}
+
+	return nil
+}
+
+// validateStartupFailurePolicy checks that the window of the startup
+// failure policy is positive
+func (v *ClusterCustomValidator) validateStartupFailurePolicy(r *apiv1.Cluster) field.ErrorList {
+	policy := r.Spec.StartupFailurePolicy
+	if policy == nil || policy.Window == nil {
+		return nil
+	}
+
+	if policy.Window.Duration <= 0 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "startupFailurePolicy", "window"),
+				policy.Window.String(),
+				"the window of the startup failure policy must be positive"),
+		}
+	}
+
+	return nil
+}
+
+// validateFailoverExcludedInstances checks that the instances excluded from
+// the failover are correctly named, and that at least one replica remains
+// eligible for promotion
+func (v *ClusterCustomValidator) validateFailoverExcludedInstances(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Failover == nil || len(r.Spec.Failover.ExcludedInstances) == 0 {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "failover", "excludedInstances")
+	excluded := make(map[string]bool, len(r.Spec.Failover.ExcludedInstances))
+	for idx, name := range r.Spec.Failover.ExcludedInstances {
+		switch {
+		case name == "":
+			result = append(result, field.Invalid(basePath.Index(idx), name,
+				"the name of an excluded instance cannot be empty"))
+		case excluded[name]:
+			result = append(result, field.Duplicate(basePath.Index(idx), name))
+		case !isInstanceNameOfCluster(r.Name, name):
+			result = append(result, field.Invalid(basePath.Index(idx), name,
+				fmt.Sprintf("not the name of an instance of the cluster, like %s", specs.GetInstanceName(r.Name, 1))))
+		}
+		excluded[name] = true
+	}
+
+	if len(excluded) >= r.Spec.Instances-1 {
+		result = append(result, field.Invalid(basePath, r.Spec.Failover.ExcludedInstances,
+			fmt.Sprintf("at least one replica must remain eligible for promotion: "+
+				"at most %d instances can be excluded from the failover with %d instances",
+				max(r.Spec.Instances-2, 0), r.Spec.Instances)))
+	}
+
+	return result
+}
+
+// isInstanceNameOfCluster checks if the passed name can be the one of an
+// instance of the cluster, i.e. the name of the cluster followed by the
+// serial number of the instance
+func isInstanceNameOfCluster(clusterName, name string) bool {
+	serial, found := strings.CutPrefix(name, clusterName+"-")
+	if !found {
+		return false
+	}
+
+	nodeSerial, err := strconv.Atoi(serial)
+	return err == nil && nodeSerial > 0 && specs.GetInstanceName(clusterName, nodeSerial) == name
+}
+
+// validateDelayedReplicas checks the configuration of the delayed replicas,
+// which are never promoted: together with the instances excluded from the
+// failover, they must leave at least one replica eligible for promotion
+func (v *ClusterCustomValidator) validateDelayedReplicas(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.DelayedReplicas == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "delayedReplicas")
+
+	switch minApplyDelay := r.Spec.DelayedReplicas.MinApplyDelay.Duration; {
+	case minApplyDelay <= 0:
+		result = append(result, field.Invalid(basePath.Child("minApplyDelay"),
+			r.Spec.DelayedReplicas.MinApplyDelay.String(),
+			"the delay must be greater than zero"))
+	case minApplyDelay.Milliseconds() > math.MaxInt32:
+		result = append(result, field.Invalid(basePath.Child("minApplyDelay"),
+			r.Spec.DelayedReplicas.MinApplyDelay.String(),
+			fmt.Sprintf("the delay must not exceed %d milliseconds, about 24 days", math.MaxInt32)))
+	}
+
+	notPromotable := make(map[string]bool, len(r.Spec.DelayedReplicas.Instances))
+	for idx, name := range r.Spec.DelayedReplicas.Instances {
+		switch {
+		case name == "":
+			result = append(result, field.Invalid(basePath.Child("instances").Index(idx), name,
+				"the name of a delayed replica cannot be empty"))
+		case notPromotable[name]:
+			result = append(result, field.Duplicate(basePath.Child("instances").Index(idx), name))
+		case name == r.Status.CurrentPrimary:
+			result = append(result, field.Invalid(basePath.Child("instances").Index(idx), name,
+				"the current primary cannot be a delayed replica"))
+		}
+		notPromotable[name] = true
+	}
+
+	if r.Spec.Failover != nil {
+		for _, name := range r.Spec.Failover.ExcludedInstances {
+			notPromotable[name] = true
+		}
+	}
+
+	if len(notPromotable) >= r.Spec.Instances-1 {
+		result = append(result, field.Invalid(basePath.Child("instances"), r.Spec.DelayedReplicas.Instances,
+			fmt.Sprintf("at least one replica must remain eligible for promotion: "+
+				"at most %d instances can be delayed or excluded from the failover with %d instances",
+				max(r.Spec.Instances-2, 0), r.Spec.Instances)))
+	}
+
+	return result
+}
+
+// validateTopology checks that the topology keys used to generate the
+// topology spread constraints are not empty, and that they don't clash
+// with the topology spread constraints specified by the user
+func (v *ClusterCustomValidator) validateTopology(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Topology == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "topology", "topologyKeys")
+	whenUnsatisfiable := r.Spec.Topology.GetWhenUnsatisfiable()
+	for idx, topologyKey := range r.Spec.Topology.TopologyKeys {
+		if topologyKey == "" {
+			result = append(result, field.Invalid(basePath.Index(idx), topologyKey,
+				"the topology key cannot be empty"))
+		}
+	}
+
+	for idx, topologyKey := range r.Spec.Topology.GetTopologyKeys() {
+		for _, constraint := range r.Spec.TopologySpreadConstraints {
+			if constraint.TopologyKey == topologyKey && constraint.WhenUnsatisfiable == whenUnsatisfiable {
+				result = append(result, field.Invalid(basePath.Index(idx), topologyKey,
+					fmt.Sprintf("a topology spread constraint with the same topology key and "+
+						"whenUnsatisfiable %q is already specified in topologySpreadConstraints",
+						whenUnsatisfiable)))
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// validateWALArchiving checks that the expected backlog is specified
+// when the WAL archiving is paused, so that the free space in the WAL
+// volume can be verified before pausing it
+func (v *ClusterCustomValidator) validateWALArchiving(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.WALArchiving == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	expectedBacklog := r.Spec.WALArchiving.ExpectedBacklog
+	basePath := field.NewPath("spec", "walArchiving", "expectedBacklog")
+	switch {
+	case expectedBacklog != nil && expectedBacklog.Sign() <= 0:
+		result = append(result,
+			field.Invalid(basePath, expectedBacklog.String(), "the expected backlog must be positive"))
+	case expectedBacklog == nil && r.Spec.WALArchiving.Paused:
+		result = append(result,
+			field.Required(basePath, "the expected backlog is required to pause the WAL archiving"))
+	}
+
+	return append(result, v.validateWALCommands(r)...)
+}
+
+// validateWALCommands checks that the user-provided archive and restore
+// commands are set together, and refer to the WAL file they work on
+func (v *ClusterCustomValidator) validateWALCommands(r *apiv1.Cluster) field.ErrorList {
+	archiveCommand := r.Spec.WALArchiving.ArchiveCommand
+	restoreCommand := r.Spec.WALArchiving.RestoreCommand
+	if archiveCommand == "" && restoreCommand == "" {
+		return nil
+	}
+
+	basePath := field.NewPath("spec", "walArchiving")
+	switch {
+	case archiveCommand == "":
+		return field.ErrorList{
+			field.Required(basePath.Child("archiveCommand"),
+				"the archive command is required together with the restore command"),
+		}
+	case restoreCommand == "":
+		return field.ErrorList{
+			field.Required(basePath.Child("restoreCommand"),
+				"the restore command is required together with the archive command"),
+		}
+	}
+
+	var result field.ErrorList
+	if !strings.Contains(archiveCommand, "%p") {
+		result = append(result, field.Invalid(basePath.Child("archiveCommand"), archiveCommand,
+			"the archive command must contain the %p placeholder for the path of the WAL file"))
+	}
+	if !strings.Contains(restoreCommand, "%f") || !strings.Contains(restoreCommand, "%p") {
+		result = append(result, field.Invalid(basePath.Child("restoreCommand"), restoreCommand,
+			"the restore command must contain the %f and %p placeholders for the name of the WAL file "+
+				"and the path where it must be copied"))
+	}
+	if pluginName := r.GetEnabledWALArchivePluginName(); pluginName != "" {
+		result = append(result, field.Invalid(basePath.Child("archiveCommand"), archiveCommand,
+			fmt.Sprintf("the WAL files are already archived by the %q plugin", pluginName)))
+	}
+
+	return result
+}
+
+// validateTerminationGracePeriod checks that the instance pods are given
+// enough time to terminate for the instance manager to stop PostgreSQL
+func (v *ClusterCustomValidator) validateTerminationGracePeriod(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.TerminationGracePeriodSeconds == nil {
+		return nil
+	}
+
+	if gracePeriod := *r.Spec.TerminationGracePeriodSeconds; gracePeriod < int64(r.GetMaxStopDelay()) {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "terminationGracePeriodSeconds"),
+				gracePeriod,
+				fmt.Sprintf("the termination grace period cannot be shorter than stopDelay (%d seconds), "+
+					"otherwise the pods could be killed while PostgreSQL is shutting down", r.GetMaxStopDelay())),
+		}
+	}
+
+	return nil
+}
+
+// validateImageCatalogAutoUpdate checks the maintenance windows in which the
+// new images of the catalog are adopted
+func (v *ClusterCustomValidator) validateImageCatalogAutoUpdate(r *apiv1.Cluster) field.ErrorList {
+	autoUpdate := r.GetImageCatalogAutoUpdate()
+	if autoUpdate == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "imageCatalogRef", "autoUpdate")
+
+	if _, err := cron.Parse(autoUpdate.Schedule); err != nil {
+		result = append(result,
+			field.Invalid(basePath.Child("schedule"), autoUpdate.Schedule, err.Error()))
+	}
+
+	if autoUpdate.Duration != nil && autoUpdate.Duration.Duration <= 0 {
+		result = append(result,
+			field.Invalid(basePath.Child("duration"), autoUpdate.Duration.Duration.String(),
+				"the duration of the maintenance windows must be positive"))
+	}
+
+	return result
+}
+
+// validateCollationReindexWindow checks the maintenance windows in which
+// the indexes affected by a collation version mismatch are rebuilt
+func (v *ClusterCustomValidator) validateCollationReindexWindow(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "postgresql", "collationReindexWindow")
+
+	window := r.Spec.PostgresConfiguration.CollationReindexWindow
+	if window == nil {
+		if r.Spec.PostgresConfiguration.CollationVersionMismatch == apiv1.CollationVersionMismatchReindex {
+			result = append(result,
+				field.Required(basePath, "the reindex collation version mismatch policy requires a maintenance window"))
+		}
+		return result
+	}
+
+	if _, err := cron.Parse(window.Schedule); err != nil {
+		result = append(result,
+			field.Invalid(basePath.Child("schedule"), window.Schedule, err.Error()))
+	}
+
+	if window.Duration != nil && window.Duration.Duration <= 0 {
+		result = append(result,
+			field.Invalid(basePath.Child("duration"), window.Duration.Duration.String(),
+				"the duration of the maintenance windows must be positive"))
+	}
+
+	return result
+}
+
+// validateParametersNames checks that every configuration parameter is
+// recognized by the PostgreSQL major version in use
+func validateParametersNames(parameters map[string]string, pgMajor int) field.ErrorList {
+	var result field.ErrorList
+
+	for key, value := range parameters {
+		if postgres.IsKnownParameter(key, pgMajor) {
+			continue
+		}
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", key),
+				value,
+				fmt.Sprintf("Unrecognized configuration parameter for PostgreSQL %d, "+
+					"set the %q annotation to %q to use it anyway",
+					pgMajor, utils.SkipParametersValidation, "enabled")))
+	}
+
+	return result
+}
+
+// validateMemoryRelativeParameters checks the memory parameters expressed as a
+// percentage of the memory limit of the pods
+func validateMemoryRelativeParameters(parameters map[string]string, memoryLimit int64) field.ErrorList {
+	var result field.ErrorList
+
+	for key, value := range parameters {
+		if _, isMemoryRelative := postgres.MemoryRelativeParameters[key]; !isMemoryRelative ||
+			!postgres.IsMemoryRelativeValue(value) {
+			continue
+		}
+
+		parameterPath := field.NewPath("spec", "postgresql", "parameters", key)
+		if _, err := postgres.ParseMemoryPercentage(value); err != nil {
+			result = append(result, field.Invalid(parameterPath, value, err.Error()))
+			continue
+		}
+
+		if memoryLimit <= 0 {
+			result = append(result, field.Invalid(parameterPath, value,
+				"A memory limit is required in spec.resources.limits to express this parameter as a percentage"))
+		}
+	}
+
+	return result
+}
+
 // validateConfiguration determines whether a PostgreSQL configuration is valid
 func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
@@ -1099,6 +2203,14 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 	}
 	sanitizedParameters := postgres.CreatePostgresqlConfiguration(info).GetConfigurationParameters()
 
+	if !utils.IsParametersValidationSkipped(&r.ObjectMeta) {
+		result = append(result, validateParametersNames(r.Spec.PostgresConfiguration.Parameters, pgMajor)...)
+	}
+
+	result = append(result, validateMemoryRelativeParameters(
+		r.Spec.PostgresConfiguration.Parameters,
+		r.Spec.Resources.Limits.Memory().Value())...)
+
 	for key, value := range r.Spec.PostgresConfiguration.Parameters {
 		_, isFixed := postgres.FixedConfigurationParameters[key]
 		sanitizedValue, presentInSanitizedConfiguration := sanitizedParameters[key]
@@ -1147,7 +2259,9 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 		}
 	}
 
-	if value := r.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter]; value != "" {
+	// shared_buffers values expressed as a percentage are checked by validateMemoryRelativeParameters
+	if value := r.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter]; value != "" &&
+		!postgres.IsMemoryRelativeValue(value) {
 		if _, err := parsePostgresQuantityValue(value); err != nil {
 			result = append(
 				result,
@@ -1183,6 +2297,8 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 				"`wal_log_hints` must be set to `on` when `instances` > 1"))
 	}
 
+	result = append(result, validateReservedConnections(r.Spec.PostgresConfiguration.Parameters, pgMajor)...)
+
 	// verify the postgres setting min_wal_size < max_wal_size < volume size
 	result = append(result, validateWalSizeConfiguration(
 		r.Spec.PostgresConfiguration, r.Spec.WalStorage.GetSizeOrNil())...)
@@ -1214,6 +2330,63 @@ func tryParseBooleanPostgresParameter(r *apiv1.Cluster, parameterName string) (*
 	return &value, nil
 }
 
+// validateReservedConnections verifies that the connection slots reserved to
+// the superusers and to the privileged roles leave room for the other
+// connections, as PostgreSQL would otherwise refuse to start
+func validateReservedConnections(parameters map[string]string, pgMajor int) field.ErrorList {
+	const (
+		maxConnectionsDefault               = 100
+		superuserReservedConnectionsDefault = postgres.MinSuperuserReservedConnections
+	)
+
+	var result field.ErrorList
+
+	parseConnections := func(key string, defaultValue int) (int, bool) {
+		value, ok := parameters[key]
+		if !ok || value == "" {
+			return defaultValue, true
+		}
+
+		connections, err := strconv.Atoi(value)
+		if err != nil || connections < 0 {
+			result = append(result,
+				field.Invalid(
+					field.NewPath("spec", "postgresql", "parameters", key),
+					value,
+					fmt.Sprintf("Invalid value for configuration parameter %s, must be a non-negative integer", key)))
+			return 0, false
+		}
+		return connections, true
+	}
+
+	maxConnections, maxConnectionsOk := parseConnections(postgres.ParameterMaxConnections, maxConnectionsDefault)
+	superuserReservedConnections, superuserReservedConnectionsOk := parseConnections(
+		postgres.ParameterSuperuserReservedConnections, superuserReservedConnectionsDefault)
+	reservedConnections := 0
+	reservedConnectionsOk := true
+	if pgMajor >= 16 {
+		reservedConnections, reservedConnectionsOk = parseConnections(postgres.ParameterReservedConnections, 0)
+	}
+	if !maxConnectionsOk || !superuserReservedConnectionsOk || !reservedConnectionsOk {
+		return result
+	}
+
+	// Lower values are raised to the minimum by the operator
+	superuserReservedConnections = max(superuserReservedConnections, postgres.MinSuperuserReservedConnections)
+
+	if superuserReservedConnections+reservedConnections >= maxConnections {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", postgres.ParameterMaxConnections),
+				maxConnections,
+				fmt.Sprintf("must be greater than the sum of %s (%d) and %s (%d)",
+					postgres.ParameterSuperuserReservedConnections, superuserReservedConnections,
+					postgres.ParameterReservedConnections, reservedConnections)))
+	}
+
+	return result
+}
+
 // validateWalSizeConfiguration verifies that min_wal_size < max_wal_size < wal volume size
 func validateWalSizeConfiguration(
 	postgresConfig apiv1.PostgresConfiguration, walVolumeSize *resource.Quantity,
@@ -1335,7 +2508,8 @@ func parsePostgresQuantityValue(value string) (resource.Quantity, error) {
 func (v *ClusterCustomValidator) validateConfigurationChange(r, old *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
 
-	if old.Spec.ImageName != r.Spec.ImageName && r.Spec.PrimaryUpdateMethod == apiv1.PrimaryUpdateMethodSwitchover {
+	if old.Spec.ImageName != r.Spec.ImageName &&
+		r.GetPrimaryUpdateMethodForImageChange() == apiv1.PrimaryUpdateMethodSwitchover {
 		diff := utils.CollectDifferencesFromMaps(old.Spec.PostgresConfiguration.Parameters,
 			r.Spec.PostgresConfiguration.Parameters)
 		if len(diff) > 0 {
@@ -1346,7 +2520,7 @@ func (v *ClusterCustomValidator) validateConfigurationChange(r, old *apiv1.Clust
 					field.NewPath("spec", "imageName"),
 					r.Spec.ImageName,
 					fmt.Sprintf("Can't change image name and configuration at the same time when "+
-						"`primaryUpdateMethod` is set to `switchover`. "+
+						"the primary update method for image changes is `switchover`. "+
 						"There are differences in PostgreSQL configuration parameters: %s", jsonDiff)))
 			return result
 		}
@@ -1625,6 +2799,9 @@ func (v *ClusterCustomValidator) validateTablespaceStorageSize(r *apiv1.Cluster)
 	var result field.ErrorList
 
 	for idx, tablespaceConf := range r.Spec.Tablespaces {
+		if tablespaceConf.IsEphemeral() {
+			continue
+		}
 		result = append(result,
 			validateStorageConfigurationSize(
 				*field.NewPath("spec", "tablespaces").Index(idx),
@@ -1634,6 +2811,42 @@ func (v *ClusterCustomValidator) validateTablespaceStorageSize(r *apiv1.Cluster)
 	return result
 }
 
+// validateEphemeralTablespaces checks that only temporary tablespaces are
+// placed on ephemeral volumes, and that their configuration is unambiguous
+func (v *ClusterCustomValidator) validateEphemeralTablespaces(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	for idx, tablespaceConf := range r.Spec.Tablespaces {
+		if !tablespaceConf.IsEphemeral() {
+			continue
+		}
+
+		structPath := field.NewPath("spec", "tablespaces").Index(idx)
+		if !tablespaceConf.Temporary {
+			result = append(result, field.Invalid(
+				structPath.Child("ephemeral"),
+				tablespaceConf.Name,
+				"only temporary tablespaces can be placed on ephemeral storage"))
+		}
+
+		if tablespaceConf.Storage.Size != "" || tablespaceConf.Storage.PersistentVolumeClaimTemplate != nil {
+			result = append(result, field.Invalid(
+				structPath.Child("storage"),
+				tablespaceConf.Name,
+				"Conflicting settings: provide either storage or ephemeral, not both."))
+		}
+
+		if tablespaceConf.Ephemeral.EmptyDir != nil && tablespaceConf.Ephemeral.VolumeClaimTemplate != nil {
+			result = append(result, field.Invalid(
+				structPath.Child("ephemeral"),
+				tablespaceConf.Name,
+				"Conflicting settings: provide either emptyDir or volumeClaimTemplate, not both."))
+		}
+	}
+
+	return result
+}
+
 func validateStorageConfigurationSize(
 	structPath field.Path,
 	storageConfiguration apiv1.StorageConfiguration,
@@ -1711,6 +2924,17 @@ func (v *ClusterCustomValidator) validateTablespacesChange(r, old *apiv1.Cluster
 	for idx, oldConf := range old.Spec.Tablespaces {
 		name := oldConf.Name
 		if newConf := r.GetTablespaceConfiguration(name); newConf != nil {
+			if oldConf.IsEphemeral() != newConf.IsEphemeral() {
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "tablespaces").Index(idx).Child("ephemeral"),
+						name,
+						"a tablespace cannot be moved between ephemeral and persistent storage"))
+				continue
+			}
+			if newConf.IsEphemeral() {
+				continue
+			}
 			errs = append(errs, validateStorageConfigurationChange(
 				field.NewPath("spec", "tablespaces").Index(idx),
 				oldConf.Storage,
@@ -1907,11 +3131,73 @@ func (v *ClusterCustomValidator) validateUnixPermissionIdentifierChange(r, old *
 			"GID is an immutable field in the spec"))
 	}
 
-	if r.Spec.PostgresUID != old.Spec.PostgresUID {
-		result = append(result, field.Invalid(
-			field.NewPath("spec", "postgresUID"),
-			r.Spec.PostgresUID,
-			"UID is an immutable field in the spec"))
+	if r.Spec.PostgresUID != old.Spec.PostgresUID {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "postgresUID"),
+			r.Spec.PostgresUID,
+			"UID is an immutable field in the spec"))
+	}
+
+	return result
+}
+
+// validateWalSegmentSizeChange forbids changing the WAL segment size, which is
+// chosen by initdb when the cluster is created
+func (v *ClusterCustomValidator) validateWalSegmentSizeChange(r, old *apiv1.Cluster) field.ErrorList {
+	getWalSegmentSize := func(cluster *apiv1.Cluster) int {
+		if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.InitDB == nil {
+			return 0
+		}
+		return cluster.Spec.Bootstrap.InitDB.WalSegmentSize
+	}
+
+	if walSegmentSize := getWalSegmentSize(r); walSegmentSize != getWalSegmentSize(old) {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "initdb", "walSegmentSize"),
+				walSegmentSize,
+				"the WAL segment size is set by initdb and is immutable after the cluster creation"),
+		}
+	}
+
+	return nil
+}
+
+// validateInitDBLocaleChange forbids changing the locale options, which are
+// used by initdb when the cluster is created
+func (v *ClusterCustomValidator) validateInitDBLocaleChange(r, old *apiv1.Cluster) field.ErrorList {
+	getInitDB := func(cluster *apiv1.Cluster) apiv1.BootstrapInitDB {
+		if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.InitDB == nil {
+			return apiv1.BootstrapInitDB{}
+		}
+		return *cluster.Spec.Bootstrap.InitDB
+	}
+
+	var result field.ErrorList
+	newInitDB := getInitDB(r)
+	oldInitDB := getInitDB(old)
+	basePath := field.NewPath("spec", "bootstrap", "initdb")
+
+	for _, option := range []struct {
+		name     string
+		newValue string
+		oldValue string
+	}{
+		{name: "encoding", newValue: newInitDB.Encoding, oldValue: oldInitDB.Encoding},
+		{name: "locale", newValue: newInitDB.Locale, oldValue: oldInitDB.Locale},
+		{name: "localeCollate", newValue: newInitDB.LocaleCollate, oldValue: oldInitDB.LocaleCollate},
+		{name: "localeCType", newValue: newInitDB.LocaleCType, oldValue: oldInitDB.LocaleCType},
+		{name: "localeProvider", newValue: newInitDB.LocaleProvider, oldValue: oldInitDB.LocaleProvider},
+		{name: "icuLocale", newValue: newInitDB.IcuLocale, oldValue: oldInitDB.IcuLocale},
+		{name: "icuRules", newValue: newInitDB.IcuRules, oldValue: oldInitDB.IcuRules},
+		{name: "builtinLocale", newValue: newInitDB.BuiltinLocale, oldValue: oldInitDB.BuiltinLocale},
+	} {
+		if option.newValue != option.oldValue {
+			result = append(result, field.Invalid(
+				basePath.Child(option.name),
+				option.newValue,
+				"the locale options are set by initdb and are immutable after the cluster creation"))
+		}
 	}
 
 	return result
@@ -2023,7 +3309,7 @@ func (v *ClusterCustomValidator) validateReplicaClusterExternalClusters(r *apiv1
 	}
 
 	// Check that the externalCluster references are correct
-	_, found := r.ExternalCluster(replicaClusterConf.Source)
+	source, found := r.ExternalCluster(replicaClusterConf.Source)
 	if !found {
 		result = append(
 			result,
@@ -2033,6 +3319,18 @@ func (v *ClusterCustomValidator) validateReplicaClusterExternalClusters(r *apiv1
 				fmt.Sprintf("External cluster %v not found", replicaClusterConf.Source)))
 	}
 
+	// The replication slot is managed by connecting to the source, which
+	// is not possible when the WAL files are only read from an object store
+	if replicaClusterConf.PrimarySlotName != "" && found && len(source.ConnectionParameters) == 0 {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "replica", "primarySlotName"),
+				replicaClusterConf.PrimarySlotName,
+				fmt.Sprintf("requires the external cluster %v to have connectionParameters, "+
+					"to stream the WAL files from it", replicaClusterConf.Source)))
+	}
+
 	if len(replicaClusterConf.Self) > 0 {
 		_, found := r.ExternalCluster(replicaClusterConf.Self)
 		if !found {
@@ -2184,6 +3482,66 @@ func (v *ClusterCustomValidator) validateRetentionPolicy(r *apiv1.Cluster) field
 	)
 }
 
+// validatePostBackupHook checks that the timeout of the post-backup hook
+// is positive
+func (v *ClusterCustomValidator) validatePostBackupHook(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Backup == nil || r.Spec.Backup.PostBackupHook == nil {
+		return nil
+	}
+
+	timeout := r.Spec.Backup.PostBackupHook.Timeout
+	if timeout != nil && timeout.Duration <= 0 {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec", "backup", "postBackupHook", "timeout"),
+			timeout.String(),
+			"must be greater than zero"),
+		}
+	}
+
+	return nil
+}
+
+// validateRecoveryJobResources validates the resources of the jobs
+// restoring a backup
+func (v *ClusterCustomValidator) validateRecoveryJobResources(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Backup == nil || r.Spec.Backup.Resources == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	resources := r.Spec.Backup.Resources
+	path := field.NewPath("spec", "backup", "resources")
+
+	for _, name := range slices.Sorted(maps.Keys(resources.Requests)) {
+		request := resources.Requests[name]
+		limit, ok := resources.Limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			result = append(result, field.Invalid(
+				path.Child("requests", string(name)),
+				request.String(),
+				fmt.Sprintf("%s request is greater than the limit", name),
+			))
+		}
+	}
+
+	// The jobs recovering from a backup run PostgreSQL, which needs to
+	// allocate its shared memory, unless it is made of huge pages
+	memoryLimits := resources.Limits.Memory()
+	rawSharedBuffer := r.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter]
+	if rawSharedBuffer != "" && r.Spec.PostgresConfiguration.HugePages == nil {
+		sharedBuffers, err := parsePostgresQuantityValue(rawSharedBuffer)
+		if err == nil && !hasEnoughMemoryForSharedBuffers(sharedBuffers, memoryLimits, nil) {
+			result = append(result, field.Invalid(
+				path.Child("limits", "memory"),
+				memoryLimits.String(),
+				"Memory limit is lower than PostgreSQL `shared_buffers` value",
+			))
+		}
+	}
+
+	return result
+}
+
 func (v *ClusterCustomValidator) validateReplicationSlots(r *apiv1.Cluster) field.ErrorList {
 	if r.Spec.ReplicationSlots == nil {
 		r.Spec.ReplicationSlots = &apiv1.ReplicationSlotsConfiguration{
@@ -2213,6 +3571,44 @@ func (v *ClusterCustomValidator) validateReplicationSlots(r *apiv1.Cluster) fiel
 	return nil
 }
 
+// validateLogicalSlotsCleanup checks the configuration of the automatic
+// cleanup of the orphaned logical replication slots. A minimum timeout is
+// enforced, so that a subscriber being restarted or briefly disconnected
+// doesn't lose its slot
+func (v *ClusterCustomValidator) validateLogicalSlotsCleanup(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.ReplicationSlots == nil || r.Spec.ReplicationSlots.LogicalSlotsCleanup == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	config := r.Spec.ReplicationSlots.LogicalSlotsCleanup
+	basePath := field.NewPath("spec", "replicationSlots", "logicalSlotsCleanup")
+
+	if config.InactiveTimeout != nil && config.InactiveTimeout.Duration < time.Minute {
+		result = append(result, field.Invalid(
+			basePath.Child("inactiveTimeout"),
+			config.InactiveTimeout.Duration.String(),
+			"must be at least 1m"))
+	}
+
+	seen := stringset.New()
+	for idx, consumer := range config.Consumers {
+		if len(consumer) == 0 {
+			result = append(result, field.Invalid(
+				basePath.Child("consumers").Index(idx),
+				consumer,
+				"the name of a logical replication slot cannot be empty"))
+			continue
+		}
+		if seen.Has(consumer) {
+			result = append(result, field.Duplicate(basePath.Child("consumers").Index(idx), consumer))
+		}
+		seen.Put(consumer)
+	}
+
+	return result
+}
+
 func (v *ClusterCustomValidator) validateSynchronizeLogicalDecoding(r *apiv1.Cluster) field.ErrorList {
 	replicationSlots := r.Spec.ReplicationSlots
 	if replicationSlots.HighAvailability == nil || !replicationSlots.HighAvailability.SynchronizeLogicalDecoding {
@@ -2356,6 +3752,8 @@ func (v *ClusterCustomValidator) validateManagedServices(r *apiv1.Cluster) field
 		))
 	}
 
+	errs = append(errs, validateDefaultServices(basePath.Child("defaultServices"), managedServices)...)
+
 	names := make([]string, len(managedServices.Additional))
 	for idx := range managedServices.Additional {
 		additionalService := &managedServices.Additional[idx]
@@ -2392,6 +3790,39 @@ func (v *ClusterCustomValidator) validateManagedServices(r *apiv1.Cluster) field
 	return errs
 }
 
+func validateDefaultServices(path *field.Path, managedServices *apiv1.ManagedServices) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := make(map[apiv1.ServiceSelectorType]bool, len(managedServices.DefaultServices))
+	for idx := range managedServices.DefaultServices {
+		template := &managedServices.DefaultServices[idx]
+		templatePath := path.Index(idx)
+
+		if seen[template.SelectorType] {
+			errs = append(errs, field.Duplicate(templatePath.Child("selectorType"), template.SelectorType))
+		}
+		seen[template.SelectorType] = true
+
+		if slices.Contains(managedServices.DisabledDefaultServices, template.SelectorType) {
+			errs = append(errs, field.Invalid(
+				templatePath.Child("selectorType"),
+				template.SelectorType,
+				"cannot customize a disabled default service",
+			))
+		}
+
+		if template.ObjectMeta.Name != "" {
+			errs = append(errs, field.Invalid(
+				templatePath.Child("metadata", "name"),
+				template.ObjectMeta.Name,
+				"the name of the default services is reserved for operator use",
+			))
+		}
+	}
+
+	return errs
+}
+
 func validateServiceTemplate(
 	path *field.Path,
 	nameRequired bool,
@@ -2414,6 +3845,129 @@ func validateServiceTemplate(
 	return errs
 }
 
+// validateManagedNetworkPolicy validates the rules added by the user to the
+// generated NetworkPolicy. The rules allowing the traffic between the
+// instances are always generated, and network policies only allow traffic,
+// so the additional rules cannot block the replication
+func (v *ClusterCustomValidator) validateManagedNetworkPolicy(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Managed == nil || r.Spec.Managed.NetworkPolicy == nil {
+		return nil
+	}
+
+	networkPolicy := r.Spec.Managed.NetworkPolicy
+	basePath := field.NewPath("spec", "managed", "networkPolicy")
+	var errs field.ErrorList
+
+	errs = append(errs, validation.ValidateLabelSelector(
+		networkPolicy.ApplicationNamespaceSelector,
+		validation.LabelSelectorValidationOptions{},
+		basePath.Child("applicationNamespaceSelector"),
+	)...)
+
+	for idx, rule := range networkPolicy.AdditionalIngress {
+		rulePath := basePath.Child("additionalIngress").Index(idx)
+		errs = append(errs, validateNetworkPolicyPorts(rulePath.Child("ports"), rule.Ports)...)
+		errs = append(errs, validateNetworkPolicyPeers(rulePath.Child("from"), rule.From)...)
+	}
+
+	for idx, rule := range networkPolicy.AdditionalEgress {
+		rulePath := basePath.Child("additionalEgress").Index(idx)
+		errs = append(errs, validateNetworkPolicyPorts(rulePath.Child("ports"), rule.Ports)...)
+		errs = append(errs, validateNetworkPolicyPeers(rulePath.Child("to"), rule.To)...)
+	}
+
+	return errs
+}
+
+// validateNetworkPolicyPorts validates the ports of a NetworkPolicy rule
+func validateNetworkPolicyPorts(path *field.Path, ports []networkingv1.NetworkPolicyPort) field.ErrorList {
+	var errs field.ErrorList
+
+	supportedProtocols := []corev1.Protocol{corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP}
+	for idx, port := range ports {
+		portPath := path.Index(idx)
+
+		if port.Protocol != nil && !slices.Contains(supportedProtocols, *port.Protocol) {
+			errs = append(errs, field.NotSupported(portPath.Child("protocol"), *port.Protocol, supportedProtocols))
+		}
+
+		if port.Port == nil {
+			if port.EndPort != nil {
+				errs = append(errs, field.Invalid(portPath.Child("endPort"), *port.EndPort,
+					"may not be specified when `port` is not specified"))
+			}
+			continue
+		}
+
+		if port.Port.Type == intstr.String {
+			for _, msg := range validationutil.IsValidPortName(port.Port.StrVal) {
+				errs = append(errs, field.Invalid(portPath.Child("port"), port.Port.StrVal, msg))
+			}
+			if port.EndPort != nil {
+				errs = append(errs, field.Invalid(portPath.Child("endPort"), *port.EndPort,
+					"may not be specified when `port` is a named port"))
+			}
+			continue
+		}
+
+		for _, msg := range validationutil.IsValidPortNum(port.Port.IntValue()) {
+			errs = append(errs, field.Invalid(portPath.Child("port"), port.Port.IntVal, msg))
+		}
+		if port.EndPort != nil && *port.EndPort < port.Port.IntVal {
+			errs = append(errs, field.Invalid(portPath.Child("endPort"), *port.EndPort,
+				"must be greater than or equal to `port`"))
+		}
+	}
+
+	return errs
+}
+
+// validateNetworkPolicyPeers validates the peers of a NetworkPolicy rule
+func validateNetworkPolicyPeers(path *field.Path, peers []networkingv1.NetworkPolicyPeer) field.ErrorList {
+	var errs field.ErrorList
+
+	for idx, peer := range peers {
+		peerPath := path.Index(idx)
+		if peer.IPBlock == nil {
+			if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+				errs = append(errs, field.Required(peerPath,
+					"one of `podSelector`, `namespaceSelector` or `ipBlock` must be specified"))
+			}
+			errs = append(errs, validation.ValidateLabelSelector(peer.PodSelector,
+				validation.LabelSelectorValidationOptions{}, peerPath.Child("podSelector"))...)
+			errs = append(errs, validation.ValidateLabelSelector(peer.NamespaceSelector,
+				validation.LabelSelectorValidationOptions{}, peerPath.Child("namespaceSelector"))...)
+			continue
+		}
+
+		if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+			errs = append(errs, field.Forbidden(peerPath,
+				"`ipBlock` may not be combined with `podSelector` or `namespaceSelector`"))
+		}
+
+		ipBlockPath := peerPath.Child("ipBlock")
+		_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+		if err != nil {
+			errs = append(errs, field.Invalid(ipBlockPath.Child("cidr"), peer.IPBlock.CIDR, err.Error()))
+			continue
+		}
+
+		for exceptIdx, except := range peer.IPBlock.Except {
+			exceptPath := ipBlockPath.Child("except").Index(exceptIdx)
+			exceptIP, _, err := net.ParseCIDR(except)
+			if err != nil {
+				errs = append(errs, field.Invalid(exceptPath, except, err.Error()))
+				continue
+			}
+			if !cidr.Contains(exceptIP) {
+				errs = append(errs, field.Invalid(exceptPath, except, "must be within the `cidr` range"))
+			}
+		}
+	}
+
+	return errs
+}
+
 // validateManagedRoles validate the environment variables settings proposed by the user
 func (v *ClusterCustomValidator) validateManagedRoles(r *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
@@ -2519,6 +4073,13 @@ func (v *ClusterCustomValidator) getAdmissionWarnings(r *apiv1.Cluster) admissio
 	list = append(list, getRetentionPolicyWarnings(r)...)
 	list = append(list, getStorageWarnings(r)...)
 	list = append(list, getSharedBuffersWarnings(r)...)
+	list = append(list, getParametersRemovalWarnings(r)...)
+	list = append(list, getSSLWarnings(r)...)
+	list = append(list, getIdleSessionsWarnings(r)...)
+	list = append(list, getReservedConnectionsWarnings(r)...)
+	list = append(list, getPasswordEncryptionWarnings(r)...)
+	list = append(list, getPrimaryConnInfoParametersWarnings(r)...)
+	list = append(list, getShutdownWarnings(r)...)
 	return append(list, getDeprecatedMonitoringFieldsWarnings(r)...)
 }
 
@@ -2616,6 +4177,218 @@ func getRetentionPolicyWarnings(r *apiv1.Cluster) admission.Warnings {
 	return result
 }
 
+func getParametersRemovalWarnings(r *apiv1.Cluster) admission.Warnings {
+	if utils.IsParametersValidationSkipped(&r.ObjectMeta) {
+		return nil
+	}
+
+	pgMajor, err := r.GetPostgresqlMajorVersion()
+	if err != nil {
+		return nil
+	}
+
+	var result admission.Warnings
+	for key := range r.Spec.PostgresConfiguration.Parameters {
+		if removedIn, ok := postgres.GetParameterRemovalVersion(key, pgMajor); ok {
+			result = append(
+				result,
+				fmt.Sprintf("%s is deprecated and has been removed in PostgreSQL %d, "+
+					"it must be dropped before a major upgrade",
+					field.NewPath("spec", "postgresql", "parameters", key).String(), removedIn),
+			)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// weakCipherKeywords are the OpenSSL keywords identifying the ciphers
+// not providing authentication or encryption, or using broken algorithms
+var weakCipherKeywords = stringset.From([]string{
+	"NULL", "eNULL", "aNULL", "ADH", "AECDH", "EXP", "EXPORT", "LOW", "DES", "3DES", "RC2", "RC4", "MD5",
+})
+
+// getReservedConnectionsWarnings warns about the connection slots reserved to
+// the superusers being fewer than the ones needed by the instance manager, as
+// the operator raises them to the minimum
+func getReservedConnectionsWarnings(r *apiv1.Cluster) admission.Warnings {
+	value, ok := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterSuperuserReservedConnections]
+	if !ok {
+		return nil
+	}
+
+	superuserReservedConnections, err := strconv.Atoi(value)
+	if err != nil || superuserReservedConnections >= postgres.MinSuperuserReservedConnections {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(
+		"%s is set to %d, but the operator needs at least %d connections reserved to the superusers "+
+			"to manage the instances: %d will be used instead",
+		field.NewPath("spec", "postgresql", "parameters", postgres.ParameterSuperuserReservedConnections),
+		superuserReservedConnections, postgres.MinSuperuserReservedConnections,
+		postgres.MinSuperuserReservedConnections)}
+}
+
+// minFastShutdownWindow is the minimum time needed by the fast shutdown
+// of PostgreSQL to archive and stream the remaining WAL files
+const minFastShutdownWindow = 15
+
+// getShutdownWarnings warns about the shutdown timeouts which don't
+// leave enough time for the fast shutdown after the smart one
+func getShutdownWarnings(r *apiv1.Cluster) admission.Warnings {
+	stopDelay := r.GetMaxStopDelay()
+	smartShutdownTimeout := r.GetSmartShutdownTimeout()
+
+	if smartShutdownTimeout >= stopDelay {
+		return admission.Warnings{fmt.Sprintf(
+			"%s (%d seconds) is not shorter than %s (%d seconds): the smart shutdown will be skipped "+
+				"and PostgreSQL will be stopped with a fast shutdown",
+			field.NewPath("spec", "smartShutdownTimeout"), smartShutdownTimeout,
+			field.NewPath("spec", "stopDelay"), stopDelay)}
+	}
+
+	if stopDelay-smartShutdownTimeout < minFastShutdownWindow {
+		return admission.Warnings{fmt.Sprintf(
+			"%s leaves only %d seconds to the fast shutdown after the smart one, which should be at least "+
+				"%d seconds to archive the remaining WAL files: consider increasing %s",
+			field.NewPath("spec", "smartShutdownTimeout"), stopDelay-smartShutdownTimeout,
+			minFastShutdownWindow, field.NewPath("spec", "stopDelay"))}
+	}
+
+	return nil
+}
+
+// getPasswordEncryptionWarnings warns about the password hashing settings
+// which are not supported, or deprecated, in the PostgreSQL version in use
+func getPasswordEncryptionWarnings(r *apiv1.Cluster) admission.Warnings {
+	passwordEncryption := r.Spec.PostgresConfiguration.PasswordEncryption
+	if passwordEncryption == nil {
+		return nil
+	}
+
+	pgMajor, err := r.GetPostgresqlMajorVersion()
+	if err != nil {
+		return nil
+	}
+
+	var result admission.Warnings
+	basePath := field.NewPath("spec", "postgresql", "passwordEncryption")
+	if passwordEncryption.ScramIterations != nil && pgMajor < 16 {
+		result = append(result, fmt.Sprintf(
+			"%s requires PostgreSQL 16 or newer and will be ignored: the passwords will be hashed "+
+				"with the default number of iterations of PostgreSQL %d",
+			basePath.Child("scramIterations"), pgMajor))
+	}
+	if passwordEncryption.Method == apiv1.PasswordEncryptionMethodMD5 && pgMajor >= 18 {
+		result = append(result, fmt.Sprintf(
+			"%s is set to md5, which is deprecated since PostgreSQL 18: consider using scram-sha-256",
+			basePath.Child("method")))
+	}
+
+	return result
+}
+
+// getPrimaryConnInfoParametersWarnings warns about the parameters of the
+// connection to the primary which affect the way the replicas are monitored
+func getPrimaryConnInfoParametersWarnings(r *apiv1.Cluster) admission.Warnings {
+	if _, found := r.Spec.PostgresConfiguration.PrimaryConnInfoParameters["application_name"]; !found {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(
+		"%s overrides the application name of the replicas, which is used by the operator "+
+			"to match them in pg_stat_replication and in synchronous_standby_names: the "+
+			"replication status and the synchronous replication will not work as expected",
+		field.NewPath("spec", "postgresql", "primaryConnInfoParameters").Key("application_name"))}
+}
+
+// getSSLWarnings warns about the TLS settings considered insecure,
+// whether they are set with the typed fields or with the parameters
+func getSSLWarnings(r *apiv1.Cluster) admission.Warnings {
+	var result admission.Warnings
+
+	minProtocolVersion := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterSSLMinProtocolVersion]
+	minProtocolVersionPath := field.NewPath("spec", "postgresql", "parameters", postgres.ParameterSSLMinProtocolVersion)
+	ciphers := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterSSLCiphers]
+	ciphersPath := field.NewPath("spec", "postgresql", "parameters", postgres.ParameterSSLCiphers)
+	if ssl := r.Spec.PostgresConfiguration.SSL; ssl != nil {
+		if ssl.MinProtocolVersion != "" {
+			minProtocolVersion = string(ssl.MinProtocolVersion)
+			minProtocolVersionPath = field.NewPath("spec", "postgresql", "ssl", "minProtocolVersion")
+		}
+		if ssl.Ciphers != "" {
+			ciphers = ssl.Ciphers
+			ciphersPath = field.NewPath("spec", "postgresql", "ssl", "ciphers")
+		}
+	}
+
+	switch apiv1.TLSProtocolVersion(minProtocolVersion) {
+	case apiv1.TLSProtocolVersion10, apiv1.TLSProtocolVersion11:
+		result = append(result, fmt.Sprintf(
+			"%s is set to %s, which is deprecated and insecure, consider using %s or newer",
+			minProtocolVersionPath, minProtocolVersion, apiv1.TLSProtocolVersion12))
+	}
+
+	if weakCiphers := getWeakCiphers(ciphers); len(weakCiphers) > 0 {
+		result = append(result, fmt.Sprintf(
+			"%s allows insecure ciphers (%s), consider excluding them",
+			ciphersPath, strings.Join(weakCiphers, ", ")))
+	}
+
+	return result
+}
+
+// poolerServerIdleTimeout is the default time after which PgBouncer
+// closes the idle server connections (`server_idle_timeout`)
+const poolerServerIdleTimeout = 10 * time.Minute
+
+// getIdleSessionsWarnings warns about an idle session timeout shorter than
+// the time after which the poolers close their idle server connections
+func getIdleSessionsWarnings(r *apiv1.Cluster) admission.Warnings {
+	idleSessions := r.Spec.PostgresConfiguration.IdleSessions
+	if idleSessions == nil || idleSessions.SessionTimeout == nil {
+		return nil
+	}
+
+	timeout := idleSessions.SessionTimeout.Duration
+	if timeout <= 0 || timeout >= poolerServerIdleTimeout {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(
+		"%s is set to %s, which is shorter than the default server_idle_timeout of PgBouncer (%s): "+
+			"the idle server connections of the poolers could be terminated by PostgreSQL, "+
+			"consider lowering server_idle_timeout in the Pooler configuration",
+		field.NewPath("spec", "postgresql", "idleSessions", "sessionTimeout"),
+		idleSessions.SessionTimeout.Duration, poolerServerIdleTimeout)}
+}
+
+// getWeakCiphers returns the entries of an OpenSSL cipher list which are
+// allowing a weak cipher, ignoring the ones excluding them
+func getWeakCiphers(ciphers string) []string {
+	var result []string
+	for _, entry := range strings.FieldsFunc(ciphers, func(r rune) bool {
+		return r == ':' || r == ',' || r == ' '
+	}) {
+		if strings.HasPrefix(entry, "!") || strings.HasPrefix(entry, "-") {
+			continue
+		}
+
+		for _, keyword := range strings.FieldsFunc(entry, func(r rune) bool {
+			return r == '+' || r == '-'
+		}) {
+			if weakCipherKeywords.Has(keyword) {
+				result = append(result, entry)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
 func getSharedBuffersWarnings(r *apiv1.Cluster) admission.Warnings {
 	var result admission.Warnings
 
@@ -2632,6 +4405,86 @@ func getSharedBuffersWarnings(r *apiv1.Cluster) admission.Warnings {
 	return result
 }
 
+// validatePodMonitorScrapeTimeout checks that the scrape interval and
+// timeout of the PodMonitor are at least one millisecond, the precision
+// used by Prometheus, and that the timeout is not greater than the
+// interval, as the Prometheus operator would reject the generated PodMonitor
+func (v *ClusterCustomValidator) validatePodMonitorScrapeTimeout(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Monitoring == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "monitoring")
+	interval, timeout := r.Spec.Monitoring.PodMonitorInterval, r.Spec.Monitoring.PodMonitorScrapeTimeout
+
+	durations := []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{name: "podMonitorInterval", value: interval},
+		{name: "podMonitorScrapeTimeout", value: timeout},
+	}
+	for _, duration := range durations {
+		if duration.value != nil && duration.value.Duration < time.Millisecond {
+			result = append(result, field.Invalid(
+				basePath.Child(duration.name),
+				duration.value.String(),
+				"must be at least 1ms"))
+		}
+	}
+	if len(result) > 0 || interval == nil || timeout == nil {
+		return result
+	}
+
+	if timeout.Duration > interval.Duration {
+		result = append(result, field.Invalid(
+			basePath.Child("podMonitorScrapeTimeout"),
+			timeout.String(),
+			fmt.Sprintf("cannot be greater than podMonitorInterval (%s)", interval.String())))
+	}
+
+	return result
+}
+
+// validateDefaultAlertsThresholds checks that the thresholds of the default
+// alerts are positive
+func (v *ClusterCustomValidator) validateDefaultAlertsThresholds(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Monitoring == nil || r.Spec.Monitoring.DefaultAlertsThresholds == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	thresholds := r.Spec.Monitoring.DefaultAlertsThresholds
+	basePath := field.NewPath("spec", "monitoring", "defaultAlertsThresholds")
+
+	durations := []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{name: "replicationLag", value: thresholds.ReplicationLag},
+		{name: "backupAge", value: thresholds.BackupAge},
+		{name: "certificateExpiration", value: thresholds.CertificateExpiration},
+	}
+	for _, duration := range durations {
+		if duration.value != nil && duration.value.Duration <= 0 {
+			result = append(result, field.Invalid(
+				basePath.Child(duration.name),
+				duration.value.String(),
+				"must be greater than zero"))
+		}
+	}
+
+	if thresholds.ReplicationSlotRetainedWAL != nil && thresholds.ReplicationSlotRetainedWAL.Sign() <= 0 {
+		result = append(result, field.Invalid(
+			basePath.Child("replicationSlotRetainedWAL"),
+			thresholds.ReplicationSlotRetainedWAL.String(),
+			"must be greater than zero"))
+	}
+
+	return result
+}
+
 func getDeprecatedMonitoringFieldsWarnings(r *apiv1.Cluster) admission.Warnings {
 	var result admission.Warnings
 
@@ -2782,6 +4635,20 @@ func (v *ClusterCustomValidator) validateLivenessPingerProbe(r *apiv1.Cluster) f
 	return nil
 }
 
+// validateReadinessQuery checks that the custom query gating the
+// readiness of the primary is only set in the readiness probe
+func (v *ClusterCustomValidator) validateReadinessQuery(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Probes == nil || r.Spec.Probes.Startup == nil || r.Spec.Probes.Startup.Query == nil {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Forbidden(
+			field.NewPath("spec", "probes", "startup", "query"),
+			"the custom query is only supported by the readiness probe"),
+	}
+}
+
 func (v *ClusterCustomValidator) validateExtensions(r *apiv1.Cluster) field.ErrorList {
 	ensureNotEmptyOrDuplicate := func(path *field.Path, list *stringset.Data, value string) *field.Error {
 		if value == "" {
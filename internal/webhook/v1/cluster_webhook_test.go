@@ -23,6 +23,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -31,8 +32,10 @@ import (
 	pgversion "github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -358,6 +361,125 @@ var _ = Describe("initdb options validation", func() {
 	})
 })
 
+var _ = Describe("initdb locale options validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(imageName string, initDB *apiv1.BootstrapInitDB) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: imageName,
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: initDB,
+				},
+			},
+		}
+	}
+
+	It("doesn't complain if there isn't a configuration", func() {
+		Expect(v.validateInitDBLocale(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts the libc locale options", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			Encoding:      "UTF8",
+			LocaleCollate: "en_US.UTF-8",
+			LocaleCType:   "en_US.UTF-8",
+		})
+		Expect(v.validateInitDBLocale(cluster)).To(BeEmpty())
+	})
+
+	It("accepts the icu locale provider with an ICU locale", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			LocaleProvider: "icu",
+			IcuLocale:      "en-US",
+			IcuRules:       "&a < g",
+		})
+		Expect(v.validateInitDBLocale(cluster)).To(BeEmpty())
+	})
+
+	It("complains if the icu locale provider has no ICU locale in PostgreSQL 15", func() {
+		cluster := newCluster("postgres:15", &apiv1.BootstrapInitDB{
+			LocaleProvider: "icu",
+		})
+		result := v.validateInitDBLocale(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.icuLocale"))
+	})
+
+	It("accepts the icu locale provider without an ICU locale since PostgreSQL 16", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			LocaleProvider: "icu",
+		})
+		Expect(v.validateInitDBLocale(cluster)).To(BeEmpty())
+	})
+
+	It("only validates the combination of the locale options on creation", func(ctx SpecContext) {
+		oldCluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			IcuLocale: "en-US",
+		})
+		cluster := oldCluster.DeepCopy()
+
+		_, err := v.ValidateCreate(ctx, cluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.bootstrap.initdb.icuLocale")))
+
+		_, err = v.ValidateUpdate(ctx, oldCluster, cluster)
+		Expect(err).ToNot(MatchError(ContainSubstring("spec.bootstrap.initdb.icuLocale")))
+	})
+
+	It("complains if the ICU options are used without the icu locale provider", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			IcuLocale: "en-US",
+			IcuRules:  "&a < g",
+		})
+		Expect(v.validateInitDBLocale(cluster)).To(HaveLen(2))
+	})
+
+	It("complains if the builtin locale provider has no locale", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			LocaleProvider: "builtin",
+		})
+		result := v.validateInitDBLocale(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.builtinLocale"))
+	})
+
+	It("complains about unknown locale providers", func() {
+		cluster := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			LocaleProvider: "glibc",
+		})
+		result := v.validateInitDBLocale(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.localeProvider"))
+	})
+
+	It("complains if the locale provider is not supported by the PostgreSQL version", func() {
+		cluster := newCluster("postgres:16", &apiv1.BootstrapInitDB{
+			LocaleProvider: "builtin",
+			BuiltinLocale:  "C.UTF-8",
+		})
+		result := v.validateInitDBLocale(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.localeProvider"))
+	})
+
+	It("complains when the locale options are changed", func() {
+		clusterOld := newCluster("postgres:17", &apiv1.BootstrapInitDB{
+			Encoding:       "UTF8",
+			LocaleProvider: "icu",
+			IcuLocale:      "en-US",
+		})
+		clusterNew := clusterOld.DeepCopy()
+		Expect(v.validateInitDBLocaleChange(clusterNew, clusterOld)).To(BeEmpty())
+
+		clusterNew.Spec.Bootstrap.InitDB.IcuLocale = "it-IT"
+		clusterNew.Spec.Bootstrap.InitDB.Encoding = "LATIN1"
+		Expect(v.validateInitDBLocaleChange(clusterNew, clusterOld)).To(HaveLen(2))
+	})
+})
+
 var _ = Describe("ImagePullPolicy validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -799,6 +921,103 @@ var _ = Describe("configuration change validation", func() {
 		Expect(v.validateConfiguration(cluster)).To(HaveLen(1))
 	})
 
+	It("should reject an unrecognized configuration parameter", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"transaction_timeout":    "1h",
+						"pg_stat_statements.max": "10000",
+					},
+				},
+			},
+		}
+
+		errors := v.validateConfiguration(cluster)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.postgresql.parameters.transaction_timeout"))
+	})
+
+	It("should accept an unrecognized configuration parameter when the validation is skipped", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.SkipParametersValidation: "enabled",
+				},
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"transaction_timeout": "1h",
+					},
+				},
+			},
+		}
+
+		Expect(v.validateConfiguration(cluster)).To(BeEmpty())
+	})
+
+	It("should accept memory parameters expressed as a percentage of the memory limit", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"shared_buffers": "25%",
+						"work_mem":       "2.5%",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		}
+
+		Expect(v.validateConfiguration(cluster)).To(BeEmpty())
+	})
+
+	It("should reject memory parameters expressed as a percentage without a memory limit", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"maintenance_work_mem": "10%",
+					},
+				},
+			},
+		}
+
+		errors := v.validateConfiguration(cluster)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.postgresql.parameters.maintenance_work_mem"))
+	})
+
+	It("should reject invalid percentages of the memory limit", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"work_mem":       "150%",
+						"shared_buffers": "many%",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		}
+
+		Expect(v.validateConfiguration(cluster)).To(HaveLen(2))
+	})
+
 	It("should reject minimal wal_level when backup is configured", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -1075,6 +1294,21 @@ var _ = Describe("configuration change validation", func() {
 		Expect(v.validateWALLevelChange(cluster, oldCluster)).To(BeEmpty())
 	})
 
+	It("complains when changing the WAL segment size", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{},
+				},
+			},
+		}
+		clusterNew := clusterOld.DeepCopy()
+		Expect(v.validateWalSegmentSizeChange(clusterNew, clusterOld)).To(BeEmpty())
+
+		clusterNew.Spec.Bootstrap.InitDB.WalSegmentSize = 64
+		Expect(v.validateWalSegmentSizeChange(clusterNew, clusterOld)).To(HaveLen(1))
+	})
+
 	It("complains when changing image and settings simultaneously if PrimaryUpdateMethodSwitchover", func() {
 		clusterOld := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -1095,6 +1329,33 @@ var _ = Describe("configuration change validation", func() {
 		Expect(v.validateConfigurationChange(clusterNew, clusterOld)).To(HaveLen(1))
 	})
 
+	It("uses the primary update method for image changes when validating a configuration change", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:10.4",
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover,
+				PrimaryUpdateMethodOverrides: &apiv1.PrimaryUpdateMethodOverrides{
+					Image: apiv1.PrimaryUpdateMethodRestart,
+				},
+				ImageName: "postgres:10.5",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"shared_buffers": "4G",
+					},
+				},
+			},
+		}
+		Expect(v.validateConfigurationChange(clusterNew, clusterOld)).To(BeEmpty())
+
+		clusterNew.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodRestart
+		clusterNew.Spec.PrimaryUpdateMethodOverrides.Image = apiv1.PrimaryUpdateMethodSwitchover
+		Expect(v.validateConfigurationChange(clusterNew, clusterOld)).To(HaveLen(1))
+	})
+
 	Describe("wal_log_hints", func() {
 		It("should reject wal_log_hints set to an invalid value", func() {
 			cluster := &apiv1.Cluster{
@@ -2338,6 +2599,42 @@ var _ = Describe("bootstrap recovery validation", func() {
 		Expect(result).To(BeEmpty())
 	})
 
+	It("accepts the parameters requested for the recovery", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Parameters: map[string]string{
+							"max_parallel_workers": "16",
+							"maintenance_work_mem": "1GB",
+						},
+					},
+				},
+			},
+		}
+
+		Expect(v.validateBootstrapRecoveryParameters(cluster)).To(BeEmpty())
+	})
+
+	It("complains when the parameters requested for the recovery are fixed or unknown", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Parameters: map[string]string{
+							"restore_command": "cp /tmp/%f %p",
+							"unknown_param":   "on",
+						},
+					},
+				},
+			},
+		}
+
+		Expect(v.validateBootstrapRecoveryParameters(cluster)).To(HaveLen(2))
+	})
+
 	Context("does not complain when bootstrap recovery source matches one of the names of external clusters", func() {
 		When("using a barman object store configuration", func() {
 			recoveryCluster := &apiv1.Cluster{
@@ -3157,6 +3454,29 @@ var _ = Describe("validate the replica cluster external clusters", func() {
 		Expect(result).ToNot(BeEmpty())
 	})
 
+	It("complains when the primary slot name is set without streaming from the source", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Source:          "test",
+					PrimarySlotName: "downstream",
+				},
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name: "test",
+					},
+				},
+			},
+		}
+
+		result := v.validateReplicaClusterExternalClusters(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.replica.primarySlotName"))
+
+		cluster.Spec.ExternalClusters[0].ConnectionParameters = map[string]string{"host": "origin-rw"}
+		Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+	})
+
 	It("complains when the external cluster doesn't exist (self)", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -3482,6 +3802,65 @@ var _ = Describe("validation of imports", func() {
 	})
 })
 
+var _ = Describe("validation of logical dump imports", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(externalCluster apiv1.ExternalCluster) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						ImportDump: &apiv1.ImportDump{
+							Source: apiv1.ImportSource{ExternalCluster: "dumps"},
+							Path:   "app.dump",
+						},
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{externalCluster},
+			},
+		}
+	}
+
+	It("accepts an external cluster with an object store", func() {
+		cluster := newCluster(apiv1.ExternalCluster{
+			Name: "dumps",
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/dumps",
+			},
+		})
+		Expect(v.validateImportDump(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a missing external cluster", func() {
+		cluster := newCluster(apiv1.ExternalCluster{Name: "other"})
+		Expect(v.validateImportDump(cluster)).To(HaveLen(1))
+	})
+
+	It("rejects an external cluster without an object store", func() {
+		cluster := newCluster(apiv1.ExternalCluster{Name: "dumps"})
+		Expect(v.validateImportDump(cluster)).To(HaveLen(1))
+	})
+
+	It("rejects importDump together with import", func() {
+		cluster := newCluster(apiv1.ExternalCluster{
+			Name: "dumps",
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/dumps",
+			},
+		})
+		cluster.Spec.Bootstrap.InitDB.Import = &apiv1.Import{
+			Type:      apiv1.MicroserviceSnapshotType,
+			Databases: []string{"app"},
+		}
+		Expect(v.validateImportDump(cluster)).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("validation of replication slots configuration", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -3837,25 +4216,100 @@ var _ = Describe("Environment variables validation", func() {
 	})
 })
 
-var _ = Describe("Storage configuration validation", func() {
+var _ = Describe("Init containers validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
 		v = &ClusterCustomValidator{}
 	})
 
-	When("a ClusterSpec is given", func() {
-		It("produces one error if storage is not set at all", func() {
-			cluster := &apiv1.Cluster{
-				Spec: apiv1.ClusterSpec{
-					StorageConfiguration: apiv1.StorageConfiguration{},
+	It("accepts valid init containers", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:  "preflight",
+						Image: "busybox:latest",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "scratch-data", MountPath: "/run"},
+						},
+					},
 				},
-			}
-			Expect(v.validateStorageSize(cluster)).To(HaveLen(1))
-		})
+			},
+		}
 
-		It("succeeds if storage size is set", func() {
-			cluster := &apiv1.Cluster{
-				Spec: apiv1.ClusterSpec{
+		Expect(v.validateInitContainers(cluster)).To(BeEmpty())
+	})
+
+	It("rejects init containers with a reserved or duplicate name", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				InitContainers: []corev1.Container{
+					{Name: "bootstrap-controller", Image: "busybox:latest"},
+					{Name: "preflight", Image: "busybox:latest"},
+					{Name: "preflight", Image: "busybox:latest"},
+				},
+			},
+		}
+
+		errors := v.validateInitContainers(cluster)
+		Expect(errors).To(HaveLen(2))
+		Expect(errors[0].Field).To(Equal("spec.initContainers[0].name"))
+		Expect(errors[1].Field).To(Equal("spec.initContainers[2].name"))
+	})
+
+	It("rejects init containers without a name or an image", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				InitContainers: []corev1.Container{{}},
+			},
+		}
+
+		Expect(v.validateInitContainers(cluster)).To(HaveLen(2))
+	})
+
+	It("rejects init containers mounting the PostgreSQL volumes", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{Name: "tbs1"},
+				},
+				InitContainers: []corev1.Container{
+					{
+						Name:  "preflight",
+						Image: "busybox:latest",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "pgdata", MountPath: "/var/lib/postgresql/data"},
+							{Name: "pg-wal", MountPath: "/var/lib/postgresql/wal"},
+							{Name: "tbs1", MountPath: "/var/lib/postgresql/tablespaces/tbs1"},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(v.validateInitContainers(cluster)).To(HaveLen(3))
+	})
+})
+
+var _ = Describe("Storage configuration validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	When("a ClusterSpec is given", func() {
+		It("produces one error if storage is not set at all", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{},
+				},
+			}
+			Expect(v.validateStorageSize(cluster)).To(HaveLen(1))
+		})
+
+		It("succeeds if storage size is set", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
 					StorageConfiguration: apiv1.StorageConfiguration{
 						Size: "1G",
 					},
@@ -4545,6 +4999,20 @@ var _ = Describe("validateResources", func() {
 		errors := v.validateResources(cluster)
 		Expect(errors).To(BeEmpty())
 	})
+	It("returns no errors when the managed huge pages contain shared_buffers", func() {
+		cluster.Spec.Resources.Requests["memory"] = resource.MustParse("256Mi")
+		cluster.Spec.PostgresConfiguration.HugePages = &apiv1.HugePagesConfiguration{}
+		cluster.Spec.PostgresConfiguration.Parameters["shared_buffers"] = "1GB"
+		errors := v.validateResources(cluster)
+		Expect(errors).To(BeEmpty())
+	})
+
+	It("returns an error when the managed huge pages are used without CPU or memory", func() {
+		cluster.Spec.PostgresConfiguration.HugePages = &apiv1.HugePagesConfiguration{}
+		errors := v.validateResources(cluster)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Detail).To(Equal("HugePages require cpu or memory"))
+	})
 })
 
 var _ = Describe("Tablespaces validation", func() {
@@ -4817,6 +5285,85 @@ var _ = Describe("Tablespaces validation", func() {
 		Expect(v.validateClusterChanges(cluster, oldCluster)).To(HaveLen(1))
 	})
 
+	It("should accept temporary tablespaces on ephemeral storage", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster1",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "10Gi",
+				},
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name:      "tmp",
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{},
+					},
+				},
+			},
+		}
+		Expect(v.validate(cluster)).To(BeEmpty())
+	})
+
+	It("should reject ephemeral storage for non-temporary tablespaces", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name:      "tmp",
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{},
+					},
+				},
+			},
+		}
+		Expect(v.validateEphemeralTablespaces(cluster)).To(HaveLen(1))
+	})
+
+	It("should reject conflicting ephemeral tablespace settings", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name:      "tmp",
+						Temporary: true,
+						Storage: apiv1.StorageConfiguration{
+							Size: "1Gi",
+						},
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{
+							EmptyDir:            &corev1.EmptyDirVolumeSource{},
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateEphemeralTablespaces(cluster)).To(HaveLen(2))
+	})
+
+	It("should produce an error if a tablespace is moved to ephemeral storage", func() {
+		oldCluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					createFakeTemporaryTbsConf("my-tablespace1"),
+				},
+			},
+		}
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name:      "my-tablespace1",
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{},
+					},
+				},
+			},
+		}
+		Expect(v.validateTablespacesChange(cluster, oldCluster)).To(HaveLen(1))
+	})
+
 	It("should not complain when the backup section refers to a tbs that is defined", func() {
 		cluster := &apiv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -5046,6 +5593,58 @@ var _ = Describe("validateManagedServices", func() {
 			Expect(errs[0].Field).To(Equal("spec.managed.services.disabledDefaultServices"))
 		})
 	})
+
+	Context("default services customization validation", func() {
+		It("should allow customizing the default services", func() {
+			cluster.Spec.Managed.Services.DefaultServices = []apiv1.DefaultServiceTemplate{
+				{
+					SelectorType: apiv1.ServiceSelectorTypeRW,
+					Type:         corev1.ServiceTypeLoadBalancer,
+					ObjectMeta: apiv1.Metadata{
+						Annotations: map[string]string{"test-annotation": "true"},
+					},
+				},
+				{SelectorType: apiv1.ServiceSelectorTypeRO},
+			}
+			Expect(v.validateManagedServices(cluster)).To(BeEmpty())
+		})
+
+		It("should not allow customizing the same service twice", func() {
+			cluster.Spec.Managed.Services.DefaultServices = []apiv1.DefaultServiceTemplate{
+				{SelectorType: apiv1.ServiceSelectorTypeRW},
+				{SelectorType: apiv1.ServiceSelectorTypeRW},
+			}
+			errs := v.validateManagedServices(cluster)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.defaultServices[1].selectorType"))
+		})
+
+		It("should not allow customizing a disabled service", func() {
+			cluster.Spec.Managed.Services.DisabledDefaultServices = []apiv1.ServiceSelectorType{
+				apiv1.ServiceSelectorTypeRO,
+			}
+			cluster.Spec.Managed.Services.DefaultServices = []apiv1.DefaultServiceTemplate{
+				{SelectorType: apiv1.ServiceSelectorTypeRO},
+			}
+			errs := v.validateManagedServices(cluster)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.defaultServices[0].selectorType"))
+		})
+
+		It("should not allow renaming a default service", func() {
+			cluster.Spec.Managed.Services.DefaultServices = []apiv1.DefaultServiceTemplate{
+				{
+					SelectorType: apiv1.ServiceSelectorTypeRW,
+					ObjectMeta:   apiv1.Metadata{Name: "my-service"},
+				},
+			}
+			errs := v.validateManagedServices(cluster)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.defaultServices[0].metadata.name"))
+		})
+	})
 })
 
 var _ = Describe("ServiceTemplate Validation", func() {
@@ -5274,6 +5873,39 @@ var _ = Describe("liveness probe validation", func() {
 	})
 })
 
+var _ = Describe("readiness query validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	query := &apiv1.ProbeQuery{SQL: "SELECT EXISTS (SELECT FROM app.migrations)"}
+
+	It("accepts the query in the readiness probe", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Probes: &apiv1.ProbesConfiguration{
+					Readiness: &apiv1.ProbeWithStrategy{Query: query},
+				},
+			},
+		}
+		Expect(v.validateReadinessQuery(cluster)).To(BeEmpty())
+	})
+
+	It("rejects the query in the startup probe", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Probes: &apiv1.ProbesConfiguration{
+					Startup: &apiv1.ProbeWithStrategy{Query: query},
+				},
+			},
+		}
+		errs := v.validateReadinessQuery(cluster)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.probes.startup.query"))
+	})
+})
+
 var _ = Describe("validateExtensions", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -5584,6 +6216,58 @@ var _ = Describe("getRetentionPolicyWarnings", func() {
 	})
 })
 
+var _ = Describe("getParametersRemovalWarnings", func() {
+	It("returns no warnings when the parameters are available in later versions", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:15",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"work_mem": "16MB",
+					},
+				},
+			},
+		}
+		Expect(getParametersRemovalWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("returns a warning when a parameter is removed in a later version", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:15",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"vacuum_defer_cleanup_age": "1000",
+					},
+				},
+			},
+		}
+		warnings := getParametersRemovalWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("spec.postgresql.parameters.vacuum_defer_cleanup_age"))
+		Expect(warnings[0]).To(ContainSubstring("PostgreSQL 16"))
+	})
+
+	It("returns no warnings when the validation is skipped", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.SkipParametersValidation: "enabled",
+				},
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:15",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"vacuum_defer_cleanup_age": "1000",
+					},
+				},
+			},
+		}
+		Expect(getParametersRemovalWarnings(cluster)).To(BeEmpty())
+	})
+})
+
 var _ = Describe("getStorageWarnings", func() {
 	It("returns no warnings when storage is properly configured", func() {
 		cluster := &apiv1.Cluster{
@@ -5863,3 +6547,1487 @@ var _ = Describe("failoverQuorum validation", func() {
 		Expect(errList).To(HaveLen(1))
 	})
 })
+
+var _ = Describe("validateFailoverCooldown", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts a cluster without failover configuration", func() {
+		Expect(v.validateFailoverCooldown(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts a positive cooldown", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Failover: &apiv1.FailoverConfiguration{
+					Cooldown: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		}
+		Expect(v.validateFailoverCooldown(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a negative cooldown", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Failover: &apiv1.FailoverConfiguration{
+					Cooldown: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+		}
+		errList := v.validateFailoverCooldown(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.failover.cooldown"))
+	})
+})
+
+var _ = Describe("validateStartupFailurePolicy", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts a policy with the default window", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StartupFailurePolicy: &apiv1.StartupFailurePolicyConfiguration{MaxRestartAttempts: 3},
+			},
+		}
+		Expect(v.validateStartupFailurePolicy(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a window which is not positive", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StartupFailurePolicy: &apiv1.StartupFailurePolicyConfiguration{
+					MaxRestartAttempts: 3,
+					Window:             &metav1.Duration{},
+				},
+			},
+		}
+		errList := v.validateStartupFailurePolicy(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.startupFailurePolicy.window"))
+	})
+})
+
+var _ = Describe("validateFailoverExcludedInstances", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(instances int, excluded ...string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Instances: instances,
+				Failover: &apiv1.FailoverConfiguration{
+					ExcludedInstances: excluded,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without excluded instances", func() {
+		Expect(v.validateFailoverExcludedInstances(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateFailoverExcludedInstances(newCluster(1))).To(BeEmpty())
+	})
+
+	It("accepts exclusions leaving at least one eligible replica", func() {
+		Expect(v.validateFailoverExcludedInstances(newCluster(3, "cluster-example-3"))).To(BeEmpty())
+		Expect(v.validateFailoverExcludedInstances(
+			newCluster(4, "cluster-example-3", "cluster-example-4"))).To(BeEmpty())
+	})
+
+	It("rejects exclusions leaving no eligible replica", func() {
+		errList := v.validateFailoverExcludedInstances(newCluster(2, "cluster-example-2"))
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.failover.excludedInstances"))
+
+		Expect(v.validateFailoverExcludedInstances(
+			newCluster(3, "cluster-example-2", "cluster-example-3"))).To(HaveLen(1))
+	})
+
+	It("rejects empty and duplicate names", func() {
+		errList := v.validateFailoverExcludedInstances(
+			newCluster(5, "", "cluster-example-3", "cluster-example-3"))
+		Expect(errList).To(HaveLen(2))
+		Expect(errList[0].Field).To(Equal("spec.failover.excludedInstances[0]"))
+		Expect(errList[1].Type).To(Equal(field.ErrorTypeDuplicate))
+	})
+
+	It("rejects the names that are not instances of the cluster", func() {
+		errList := v.validateFailoverExcludedInstances(
+			newCluster(10, "other-cluster-3", "cluster-example", "cluster-example-0", "cluster-example-03",
+				"cluster-example-x"))
+		Expect(errList).To(HaveLen(5))
+		for idx := range errList {
+			Expect(errList[idx].Field).To(Equal(fmt.Sprintf("spec.failover.excludedInstances[%d]", idx)))
+		}
+	})
+})
+
+var _ = Describe("validateSSL", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(ssl *apiv1.SSLConfiguration, parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					SSL:        ssl,
+					Parameters: parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without TLS configuration", func() {
+		Expect(v.validateSSL(newCluster(nil, nil))).To(BeEmpty())
+	})
+
+	It("accepts a valid TLS configuration", func() {
+		cluster := newCluster(&apiv1.SSLConfiguration{
+			MinProtocolVersion: apiv1.TLSProtocolVersion12,
+			MaxProtocolVersion: apiv1.TLSProtocolVersion13,
+			Ciphers:            "HIGH:!aNULL:!MD5",
+		}, nil)
+		Expect(v.validateSSL(cluster)).To(BeEmpty())
+	})
+
+	It("rejects unknown protocol versions", func() {
+		cluster := newCluster(&apiv1.SSLConfiguration{
+			MinProtocolVersion: "SSLv3",
+			MaxProtocolVersion: "TLSv2",
+		}, nil)
+		errList := v.validateSSL(cluster)
+		Expect(errList).To(HaveLen(2))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.ssl.minProtocolVersion"))
+		Expect(errList[1].Field).To(Equal("spec.postgresql.ssl.maxProtocolVersion"))
+	})
+
+	It("rejects a minimum protocol version greater than the maximum one", func() {
+		cluster := newCluster(&apiv1.SSLConfiguration{
+			MinProtocolVersion: apiv1.TLSProtocolVersion13,
+			MaxProtocolVersion: apiv1.TLSProtocolVersion12,
+		}, nil)
+		errList := v.validateSSL(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.ssl.minProtocolVersion"))
+	})
+
+	It("rejects ciphers containing quotes", func() {
+		cluster := newCluster(&apiv1.SSLConfiguration{
+			Ciphers: "HIGH'; shared_preload_libraries = 'evil",
+		}, nil)
+		errList := v.validateSSL(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.ssl.ciphers"))
+	})
+
+	It("rejects settings also specified as parameters", func() {
+		cluster := newCluster(&apiv1.SSLConfiguration{
+			MinProtocolVersion: apiv1.TLSProtocolVersion12,
+		}, map[string]string{
+			"ssl_min_protocol_version": "TLSv1.2",
+			"ssl_ciphers":              "HIGH",
+		})
+		errList := v.validateSSL(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.ssl_min_protocol_version"))
+	})
+})
+
+var _ = Describe("validateLogging", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(logging *apiv1.PostgresLoggingConfiguration, parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Logging:    logging,
+					Parameters: parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without logging configuration", func() {
+		Expect(v.validateLogging(newCluster(nil, nil))).To(BeEmpty())
+	})
+
+	It("accepts a valid logging configuration", func() {
+		cluster := newCluster(&apiv1.PostgresLoggingConfiguration{
+			SlowQueryThreshold: &metav1.Duration{Duration: 500 * time.Millisecond},
+			LogStatement:       apiv1.StatementLoggingLevelDDL,
+			LogConnections:     ptr.To(true),
+			LogDisconnections:  ptr.To(false),
+		}, map[string]string{
+			"log_lock_waits": "on",
+		})
+		Expect(v.validateLogging(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a negative slow query threshold", func() {
+		cluster := newCluster(&apiv1.PostgresLoggingConfiguration{
+			SlowQueryThreshold: &metav1.Duration{Duration: -time.Second},
+		}, nil)
+		errList := v.validateLogging(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.logging.slowQueryThreshold"))
+	})
+
+	It("rejects settings also specified as parameters", func() {
+		cluster := newCluster(&apiv1.PostgresLoggingConfiguration{
+			LogConnections: ptr.To(true),
+		}, map[string]string{
+			"log_connections":            "on",
+			"log_min_duration_statement": "1s",
+		})
+		errList := v.validateLogging(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.log_connections"))
+	})
+})
+
+var _ = Describe("validateIdleSessions", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(idleSessions *apiv1.IdleSessionsConfiguration, parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					IdleSessions: idleSessions,
+					Parameters:   parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts a valid configuration", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			SessionTimeout:       &metav1.Duration{Duration: time.Hour},
+			InTransactionTimeout: &metav1.Duration{},
+		}, nil)
+		Expect(v.validateIdleSessions(cluster)).To(BeEmpty())
+		Expect(getIdleSessionsWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("rejects negative and sub-millisecond timeouts", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			SessionTimeout:       &metav1.Duration{Duration: -time.Second},
+			InTransactionTimeout: &metav1.Duration{Duration: time.Microsecond},
+		}, nil)
+		errList := v.validateIdleSessions(cluster)
+		Expect(errList).To(HaveLen(2))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.idleSessions.sessionTimeout"))
+		Expect(errList[1].Field).To(Equal("spec.postgresql.idleSessions.inTransactionTimeout"))
+	})
+
+	It("rejects timeouts not fitting in the PostgreSQL settings", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			SessionTimeout:       &metav1.Duration{Duration: 25 * 24 * time.Hour},
+			InTransactionTimeout: &metav1.Duration{Duration: math.MaxInt32 * time.Millisecond},
+		}, nil)
+		errList := v.validateIdleSessions(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.idleSessions.sessionTimeout"))
+	})
+
+	It("rejects the settings also specified as parameters", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			InTransactionTimeout: &metav1.Duration{Duration: time.Minute},
+		}, map[string]string{
+			"idle_in_transaction_session_timeout": "1min",
+		})
+		errList := v.validateIdleSessions(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.idle_in_transaction_session_timeout"))
+	})
+
+	It("rejects the idle session timeout before PostgreSQL 14", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			SessionTimeout: &metav1.Duration{Duration: time.Hour},
+		}, nil)
+		cluster.Spec.ImageName = "postgres:13"
+		Expect(v.validateIdleSessions(cluster)).To(HaveLen(1))
+	})
+
+	It("warns when the idle session timeout is shorter than the one of the poolers", func() {
+		cluster := newCluster(&apiv1.IdleSessionsConfiguration{
+			SessionTimeout: &metav1.Duration{Duration: time.Minute},
+		}, nil)
+		Expect(getIdleSessionsWarnings(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateAutovacuum", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(autovacuum *apiv1.AutovacuumConfiguration, parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Autovacuum: autovacuum,
+					Parameters: parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts a valid configuration", func() {
+		cluster := newCluster(&apiv1.AutovacuumConfiguration{
+			Naptime:         &metav1.Duration{Duration: 30 * time.Second},
+			MaxWorkers:      ptr.To(int32(6)),
+			VacuumCostDelay: &metav1.Duration{Duration: 500 * time.Microsecond},
+			VacuumCostLimit: ptr.To(int32(-1)),
+		}, nil)
+		Expect(v.validateAutovacuum(cluster)).To(BeEmpty())
+	})
+
+	It("rejects the values outside of the ranges accepted by PostgreSQL", func() {
+		cluster := newCluster(&apiv1.AutovacuumConfiguration{
+			Naptime:         &metav1.Duration{Duration: 1500 * time.Millisecond},
+			MaxWorkers:      ptr.To(int32(0)),
+			VacuumCostDelay: &metav1.Duration{Duration: time.Second},
+			VacuumCostLimit: ptr.To(int32(0)),
+		}, nil)
+		errList := v.validateAutovacuum(cluster)
+		Expect(errList).To(HaveLen(4))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.autovacuum.naptime"))
+		Expect(errList[1].Field).To(Equal("spec.postgresql.autovacuum.maxWorkers"))
+		Expect(errList[2].Field).To(Equal("spec.postgresql.autovacuum.vacuumCostDelay"))
+		Expect(errList[3].Field).To(Equal("spec.postgresql.autovacuum.vacuumCostLimit"))
+	})
+
+	It("rejects the settings also specified as parameters", func() {
+		cluster := newCluster(&apiv1.AutovacuumConfiguration{
+			MaxWorkers: ptr.To(int32(5)),
+		}, map[string]string{
+			"autovacuum_max_workers": "3",
+			"autovacuum_naptime":     "1min",
+		})
+		errList := v.validateAutovacuum(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.autovacuum_max_workers"))
+	})
+})
+
+var _ = Describe("validateTerminationGracePeriod", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts clusters without a termination grace period", func() {
+		Expect(v.validateTerminationGracePeriod(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts a termination grace period longer than the stop delay", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaxStopDelay:                  1800,
+				TerminationGracePeriodSeconds: ptr.To(int64(1860)),
+			},
+		}
+		Expect(v.validateTerminationGracePeriod(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a termination grace period shorter than the stop delay", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				TerminationGracePeriodSeconds: ptr.To(int64(600)),
+			},
+		}
+		errList := v.validateTerminationGracePeriod(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.terminationGracePeriodSeconds"))
+	})
+})
+
+var _ = Describe("getShutdownWarnings", func() {
+	It("doesn't warn with the default timeouts", func() {
+		Expect(getShutdownWarnings(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("warns when the smart shutdown timeout is not shorter than the stop delay", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaxStopDelay:         60,
+				SmartShutdownTimeout: ptr.To(int32(60)),
+			},
+		}
+		Expect(getShutdownWarnings(cluster)).To(HaveLen(1))
+	})
+
+	It("warns when the fast shutdown has too little time", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaxStopDelay:         60,
+				SmartShutdownTimeout: ptr.To(int32(50)),
+			},
+		}
+		Expect(getShutdownWarnings(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validatePasswordEncryption", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(
+		passwordEncryption *apiv1.PasswordEncryptionConfiguration,
+		parameters map[string]string,
+	) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PasswordEncryption: passwordEncryption,
+					Parameters:         parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts a valid configuration", func() {
+		cluster := newCluster(&apiv1.PasswordEncryptionConfiguration{
+			Method:          apiv1.PasswordEncryptionMethodScramSHA256,
+			ScramIterations: ptr.To(int32(10000)),
+		}, nil)
+		Expect(v.validatePasswordEncryption(cluster)).To(BeEmpty())
+		Expect(getPasswordEncryptionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("rejects the settings also specified as parameters", func() {
+		cluster := newCluster(&apiv1.PasswordEncryptionConfiguration{
+			ScramIterations: ptr.To(int32(10000)),
+		}, map[string]string{
+			"scram_iterations": "4096",
+		})
+		errList := v.validatePasswordEncryption(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.scram_iterations"))
+	})
+
+	It("rejects the SCRAM iterations together with the md5 method", func() {
+		cluster := newCluster(&apiv1.PasswordEncryptionConfiguration{
+			Method:          apiv1.PasswordEncryptionMethodMD5,
+			ScramIterations: ptr.To(int32(10000)),
+		}, nil)
+		errList := v.validatePasswordEncryption(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.passwordEncryption.scramIterations"))
+	})
+
+	It("warns when the SCRAM iterations are not supported by the PostgreSQL version", func() {
+		cluster := newCluster(&apiv1.PasswordEncryptionConfiguration{
+			ScramIterations: ptr.To(int32(10000)),
+		}, nil)
+		cluster.Spec.ImageName = "postgres:15"
+		Expect(v.validatePasswordEncryption(cluster)).To(BeEmpty())
+		Expect(getPasswordEncryptionWarnings(cluster)).To(HaveLen(1))
+	})
+
+	It("warns when using md5 with PostgreSQL 18", func() {
+		cluster := newCluster(&apiv1.PasswordEncryptionConfiguration{
+			Method: apiv1.PasswordEncryptionMethodMD5,
+		}, nil)
+		cluster.Spec.ImageName = "postgres:18"
+		Expect(getPasswordEncryptionWarnings(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validatePrimaryConnInfoParameters", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PrimaryConnInfoParameters: parameters,
+				},
+			},
+		}
+	}
+
+	It("accepts the parameters not managed by the operator", func() {
+		cluster := newCluster(map[string]string{
+			"gssencmode": "disable",
+			"options":    "-c wal_sender_timeout=0",
+		})
+		Expect(v.validatePrimaryConnInfoParameters(cluster)).To(BeEmpty())
+		Expect(getPrimaryConnInfoParametersWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("rejects the parameters defining the endpoint and the credentials", func() {
+		cluster := newCluster(map[string]string{
+			"host":    "elsewhere",
+			"sslmode": "disable",
+		})
+		errList := v.validatePrimaryConnInfoParameters(cluster)
+		Expect(errList).To(HaveLen(2))
+		for _, err := range errList {
+			Expect(err.Type).To(Equal(field.ErrorTypeForbidden))
+		}
+	})
+
+	It("rejects the invalid parameter names", func() {
+		cluster := newCluster(map[string]string{
+			"gssencmode=disable host": "elsewhere",
+		})
+		errList := v.validatePrimaryConnInfoParameters(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+
+	It("warns when the application name is overridden", func() {
+		cluster := newCluster(map[string]string{
+			"application_name": "standby",
+		})
+		Expect(v.validatePrimaryConnInfoParameters(cluster)).To(BeEmpty())
+		Expect(getPrimaryConnInfoParametersWarnings(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateHugePages", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(parameters map[string]string, resources corev1.ResourceList) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					HugePages:  &apiv1.HugePagesConfiguration{Mode: apiv1.HugePagesModeOn},
+					Parameters: parameters,
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: resources,
+					Limits:   resources,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without managed huge pages", func() {
+		Expect(v.validateHugePages(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts a valid configuration", func() {
+		cluster := newCluster(map[string]string{
+			"shared_buffers": "25%",
+		}, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		})
+		Expect(v.validateHugePages(cluster)).To(BeEmpty())
+	})
+
+	It("rejects the huge_pages parameter", func() {
+		cluster := newCluster(map[string]string{
+			"huge_pages": "off",
+		}, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		})
+		errList := v.validateHugePages(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.huge_pages"))
+	})
+
+	It("rejects the huge_page_size parameter", func() {
+		cluster := newCluster(map[string]string{
+			"huge_page_size": "2MB",
+		}, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		})
+		errList := v.validateHugePages(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.huge_page_size"))
+	})
+
+	It("accepts 1Gi pages only since PostgreSQL 14", func() {
+		cluster := newCluster(nil, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		})
+		cluster.Spec.PostgresConfiguration.HugePages.PageSize = "1Gi"
+		cluster.Spec.ImageName = "postgres:14.4"
+		Expect(v.validateHugePages(cluster)).To(BeEmpty())
+
+		cluster.Spec.ImageName = "postgres:13.8"
+		errList := v.validateHugePages(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.hugePages.pageSize"))
+	})
+
+	It("rejects the huge pages set in the resources", func() {
+		cluster := newCluster(nil, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+			"hugepages-2Mi":       resource.MustParse("1Gi"),
+		})
+		errList := v.validateHugePages(cluster)
+		Expect(errList).To(HaveLen(2))
+		Expect(errList[0].Field).To(Equal("spec.resources.requests.hugepages-2Mi"))
+		Expect(errList[1].Field).To(Equal("spec.resources.limits.hugepages-2Mi"))
+	})
+
+	It("rejects a shared_buffers percentage without a memory limit", func() {
+		cluster := newCluster(map[string]string{
+			"shared_buffers": "25%",
+		}, corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		})
+		errList := v.validateHugePages(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.postgresql.parameters.shared_buffers"))
+	})
+})
+
+var _ = Describe("getSSLWarnings", func() {
+	It("does not warn about the default configuration", func() {
+		Expect(getSSLWarnings(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("does not warn about a hardened configuration", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					SSL: &apiv1.SSLConfiguration{
+						MinProtocolVersion: apiv1.TLSProtocolVersion12,
+						Ciphers:            "HIGH:!aNULL:!eNULL:!MD5:!3DES",
+					},
+				},
+			},
+		}
+		Expect(getSSLWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("warns about insecure typed settings", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					SSL: &apiv1.SSLConfiguration{
+						MinProtocolVersion: apiv1.TLSProtocolVersion11,
+						Ciphers:            "HIGH:MEDIUM:+3DES:!aNULL:RC4-MD5",
+					},
+				},
+			},
+		}
+		warnings := getSSLWarnings(cluster)
+		Expect(warnings).To(HaveLen(2))
+		Expect(warnings[0]).To(ContainSubstring("spec.postgresql.ssl.minProtocolVersion"))
+		Expect(warnings[1]).To(ContainSubstring("spec.postgresql.ssl.ciphers"))
+		Expect(warnings[1]).To(ContainSubstring("+3DES, RC4-MD5"))
+	})
+
+	It("warns about insecure parameters", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"ssl_min_protocol_version": "TLSv1",
+					},
+				},
+			},
+		}
+		warnings := getSSLWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("spec.postgresql.parameters.ssl_min_protocol_version"))
+	})
+})
+
+var _ = Describe("validateReservedConnections", func() {
+	It("accepts the default values", func() {
+		Expect(validateReservedConnections(nil, 17)).To(BeEmpty())
+	})
+
+	It("accepts reserved connections leaving room for the other ones", func() {
+		Expect(validateReservedConnections(map[string]string{
+			"max_connections":                "200",
+			"superuser_reserved_connections": "10",
+			"reserved_connections":           "20",
+		}, 17)).To(BeEmpty())
+	})
+
+	It("warns about fewer connections reserved to the superusers than the minimum", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{"superuser_reserved_connections": "1"},
+				},
+			},
+		}
+		Expect(validateReservedConnections(cluster.Spec.PostgresConfiguration.Parameters, 17)).To(BeEmpty())
+		Expect(getReservedConnectionsWarnings(cluster)).To(HaveLen(1))
+
+		cluster.Spec.PostgresConfiguration.Parameters["superuser_reserved_connections"] = "3"
+		Expect(getReservedConnectionsWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("checks max_connections against the enforced minimum of superuser connections", func() {
+		errs := validateReservedConnections(map[string]string{
+			"max_connections":                "3",
+			"superuser_reserved_connections": "1",
+		}, 17)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.parameters.max_connections"))
+	})
+
+	It("rejects reserved connections exceeding max_connections", func() {
+		errs := validateReservedConnections(map[string]string{
+			"max_connections":                "20",
+			"superuser_reserved_connections": "10",
+			"reserved_connections":           "10",
+		}, 17)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.parameters.max_connections"))
+	})
+
+	It("ignores reserved_connections before PostgreSQL 16", func() {
+		Expect(validateReservedConnections(map[string]string{
+			"max_connections":      "20",
+			"reserved_connections": "30",
+		}, 15)).To(BeEmpty())
+	})
+
+	It("rejects values which are not integers", func() {
+		errs := validateReservedConnections(map[string]string{
+			"max_connections": "many",
+		}, 17)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.parameters.max_connections"))
+	})
+})
+
+var _ = Describe("validateImageCatalogAutoUpdate", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(autoUpdate *apiv1.ImageCatalogAutoUpdate) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageCatalogRef: &apiv1.ImageCatalogRef{
+					Major:      17,
+					AutoUpdate: autoUpdate,
+				},
+			},
+		}
+	}
+
+	It("accepts clusters adopting the new images immediately", func() {
+		Expect(v.validateImageCatalogAutoUpdate(newCluster(nil))).To(BeEmpty())
+		Expect(v.validateImageCatalogAutoUpdate(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts valid maintenance windows", func() {
+		Expect(v.validateImageCatalogAutoUpdate(newCluster(&apiv1.ImageCatalogAutoUpdate{
+			Schedule: "0 0 2 * * sun",
+			Duration: &metav1.Duration{Duration: 2 * time.Hour},
+		}))).To(BeEmpty())
+	})
+
+	It("rejects invalid schedules and durations", func() {
+		errs := v.validateImageCatalogAutoUpdate(newCluster(&apiv1.ImageCatalogAutoUpdate{
+			Schedule: "every sunday",
+			Duration: &metav1.Duration{Duration: -time.Hour},
+		}))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.imageCatalogRef.autoUpdate.schedule"))
+		Expect(errs[1].Field).To(Equal("spec.imageCatalogRef.autoUpdate.duration"))
+	})
+})
+
+var _ = Describe("validateCollationReindexWindow", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(
+		policy apiv1.CollationVersionMismatchPolicy,
+		window *apiv1.CollationReindexWindow,
+	) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					CollationVersionMismatch: policy,
+					CollationReindexWindow:   window,
+				},
+			},
+		}
+	}
+
+	It("accepts clusters only warning about the mismatches", func() {
+		Expect(v.validateCollationReindexWindow(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateCollationReindexWindow(newCluster(apiv1.CollationVersionMismatchWarn, nil))).To(BeEmpty())
+	})
+
+	It("requires a maintenance window to rebuild the indexes", func() {
+		errs := v.validateCollationReindexWindow(newCluster(apiv1.CollationVersionMismatchReindex, nil))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.collationReindexWindow"))
+
+		Expect(v.validateCollationReindexWindow(newCluster(apiv1.CollationVersionMismatchReindex,
+			&apiv1.CollationReindexWindow{
+				Schedule: "0 0 2 * * sun",
+				Duration: &metav1.Duration{Duration: 2 * time.Hour},
+			}))).To(BeEmpty())
+	})
+
+	It("rejects invalid schedules and durations", func() {
+		errs := v.validateCollationReindexWindow(newCluster(apiv1.CollationVersionMismatchReindex,
+			&apiv1.CollationReindexWindow{
+				Schedule: "every sunday",
+				Duration: &metav1.Duration{Duration: -time.Hour},
+			}))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.collationReindexWindow.schedule"))
+		Expect(errs[1].Field).To(Equal("spec.postgresql.collationReindexWindow.duration"))
+	})
+})
+
+var _ = Describe("validateLogicalSlotsCleanup", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(config *apiv1.LogicalSlotsCleanupConfiguration) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					LogicalSlotsCleanup: config,
+				},
+			},
+		}
+	}
+
+	It("accepts a missing configuration", func() {
+		Expect(v.validateLogicalSlotsCleanup(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateLogicalSlotsCleanup(newCluster(nil))).To(BeEmpty())
+	})
+
+	It("accepts a valid configuration", func() {
+		cluster := newCluster(&apiv1.LogicalSlotsCleanupConfiguration{
+			Enabled:         true,
+			Consumers:       []string{"sub_orders", "debezium"},
+			InactiveTimeout: &metav1.Duration{Duration: 6 * time.Hour},
+		})
+		Expect(v.validateLogicalSlotsCleanup(cluster)).To(BeEmpty())
+	})
+
+	It("rejects an inactive timeout shorter than one minute", func() {
+		cluster := newCluster(&apiv1.LogicalSlotsCleanupConfiguration{
+			Enabled:         true,
+			InactiveTimeout: &metav1.Duration{Duration: 30 * time.Second},
+		})
+		result := v.validateLogicalSlotsCleanup(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.replicationSlots.logicalSlotsCleanup.inactiveTimeout"))
+	})
+
+	It("rejects empty and duplicated consumers", func() {
+		cluster := newCluster(&apiv1.LogicalSlotsCleanupConfiguration{
+			Enabled:   true,
+			Consumers: []string{"sub_orders", "", "sub_orders"},
+		})
+		result := v.validateLogicalSlotsCleanup(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.replicationSlots.logicalSlotsCleanup.consumers[1]"))
+		Expect(result[1].Type).To(Equal(field.ErrorTypeDuplicate))
+	})
+})
+
+var _ = Describe("validatePodMonitorScrapeTimeout", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(interval, timeout *metav1.Duration) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					PodMonitorInterval:      interval,
+					PodMonitorScrapeTimeout: timeout,
+				},
+			},
+		}
+	}
+
+	It("accepts a missing or partial configuration", func() {
+		Expect(v.validatePodMonitorScrapeTimeout(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validatePodMonitorScrapeTimeout(newCluster(&metav1.Duration{Duration: 2 * time.Minute}, nil))).
+			To(BeEmpty())
+		Expect(v.validatePodMonitorScrapeTimeout(newCluster(nil, &metav1.Duration{Duration: 30 * time.Second}))).
+			To(BeEmpty())
+	})
+
+	It("accepts a scrape timeout not greater than the interval", func() {
+		Expect(v.validatePodMonitorScrapeTimeout(newCluster(
+			&metav1.Duration{Duration: time.Minute}, &metav1.Duration{Duration: 30 * time.Second}))).To(BeEmpty())
+		Expect(v.validatePodMonitorScrapeTimeout(newCluster(
+			&metav1.Duration{Duration: time.Minute}, &metav1.Duration{Duration: 60 * time.Second}))).To(BeEmpty())
+	})
+
+	It("rejects a scrape timeout greater than the interval", func() {
+		result := v.validatePodMonitorScrapeTimeout(newCluster(
+			&metav1.Duration{Duration: 30 * time.Second}, &metav1.Duration{Duration: time.Minute}))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.monitoring.podMonitorScrapeTimeout"))
+	})
+
+	It("rejects the durations below the precision of Prometheus", func() {
+		result := v.validatePodMonitorScrapeTimeout(newCluster(
+			&metav1.Duration{Duration: -time.Minute}, &metav1.Duration{Duration: time.Microsecond}))
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.monitoring.podMonitorInterval"))
+		Expect(result[1].Field).To(Equal("spec.monitoring.podMonitorScrapeTimeout"))
+	})
+
+	It("doesn't warn about the scrape settings", func() {
+		Expect(getDeprecatedMonitoringFieldsWarnings(newCluster(&metav1.Duration{Duration: 2 * time.Minute}, nil))).
+			To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateSCRAMOverSSL", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(imageName string, postgresConfig apiv1.PostgresConfiguration) *apiv1.Cluster {
+		postgresConfig.RequireSCRAMOverSSL = true
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName:             imageName,
+				PostgresConfiguration: postgresConfig,
+			},
+		}
+	}
+
+	It("doesn't validate anything when SCRAM over TLS isn't required", func() {
+		cluster := newCluster("postgres:13", apiv1.PostgresConfiguration{
+			PgHBA: []string{"hostnossl all all all md5"},
+		})
+		cluster.Spec.PostgresConfiguration.RequireSCRAMOverSSL = false
+		Expect(v.validateSCRAMOverSSL(cluster)).To(BeEmpty())
+	})
+
+	It("accepts the passwords hashed with SCRAM-SHA-256", func() {
+		Expect(v.validateSCRAMOverSSL(newCluster("postgres:17", apiv1.PostgresConfiguration{
+			PgHBA: []string{"hostssl app app 10.0.0.0/8 scram-sha-256"},
+		}))).To(BeEmpty())
+		Expect(v.validateSCRAMOverSSL(newCluster("postgres:13", apiv1.PostgresConfiguration{
+			PasswordEncryption: &apiv1.PasswordEncryptionConfiguration{
+				Method: apiv1.PasswordEncryptionMethodScramSHA256,
+			},
+		}))).To(BeEmpty())
+	})
+
+	It("rejects the passwords hashed with md5", func() {
+		result := v.validateSCRAMOverSSL(newCluster("postgres:13", apiv1.PostgresConfiguration{}))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.passwordEncryption.method"))
+
+		result = v.validateSCRAMOverSSL(newCluster("postgres:17", apiv1.PostgresConfiguration{
+			Parameters: map[string]string{"password_encryption": "md5"},
+		}))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.parameters.password_encryption"))
+	})
+
+	It("rejects the rules without TLS and the LDAP authentication", func() {
+		result := v.validateSCRAMOverSSL(newCluster("postgres:17", apiv1.PostgresConfiguration{
+			PgHBA: []string{"host all all all scram-sha-256", "hostnossl all all all scram-sha-256"},
+			LDAP:  &apiv1.LDAPConfig{Server: "ldap.example.com"},
+		}))
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.postgresql.pg_hba[1]"))
+		Expect(result[1].Field).To(Equal("spec.postgresql.ldap"))
+	})
+
+	It("rejects the rules authenticating without SCRAM", func() {
+		result := v.validateSCRAMOverSSL(newCluster("postgres:17", apiv1.PostgresConfiguration{
+			PgHBA: []string{
+				"# a comment",
+				"hostssl all all 10.0.0.0/8 trust",
+				"hostssl all all 10.0.0.1 255.255.255.255 password",
+				"hostssl all app all cert",
+				"local all all trust",
+				"hostssl all all 10.0.0.1 255.255.255.255 scram-sha-256",
+				"hostnossl all all all reject",
+				"host all bad 0.0.0.0/0 reject",
+			},
+		}))
+		Expect(result).To(HaveLen(4))
+		Expect(result[0].Field).To(Equal("spec.postgresql.pg_hba[1]"))
+		Expect(result[1].Field).To(Equal("spec.postgresql.pg_hba[2]"))
+		Expect(result[2].Field).To(Equal("spec.postgresql.pg_hba[3]"))
+		Expect(result[3].Field).To(Equal("spec.postgresql.pg_hba[4]"))
+	})
+})
+
+var _ = Describe("validatePostBackupHook", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(timeout *metav1.Duration) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					PostBackupHook: &apiv1.BackupHookConfiguration{
+						Command: []string{"/bin/true"},
+						Timeout: timeout,
+					},
+				},
+			},
+		}
+	}
+
+	It("accepts a missing hook or a positive timeout", func() {
+		Expect(v.validatePostBackupHook(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validatePostBackupHook(newCluster(nil))).To(BeEmpty())
+		Expect(v.validatePostBackupHook(newCluster(&metav1.Duration{Duration: time.Minute}))).To(BeEmpty())
+	})
+
+	It("rejects a timeout that is not positive", func() {
+		for _, timeout := range []time.Duration{0, -time.Minute} {
+			result := v.validatePostBackupHook(newCluster(&metav1.Duration{Duration: timeout}))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.backup.postBackupHook.timeout"))
+		}
+	})
+})
+
+var _ = Describe("validateDefaultAlertsThresholds", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(thresholds *apiv1.DefaultAlertsThresholds) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					EnableDefaultAlerts:     true,
+					DefaultAlertsThresholds: thresholds,
+				},
+			},
+		}
+	}
+
+	It("accepts missing or positive thresholds", func() {
+		Expect(v.validateDefaultAlertsThresholds(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateDefaultAlertsThresholds(newCluster(nil))).To(BeEmpty())
+		Expect(v.validateDefaultAlertsThresholds(newCluster(&apiv1.DefaultAlertsThresholds{
+			ReplicationLag:             &metav1.Duration{Duration: time.Minute},
+			BackupAge:                  &metav1.Duration{Duration: 48 * time.Hour},
+			ReplicationSlotRetainedWAL: ptr.To(resource.MustParse("1Gi")),
+			CertificateExpiration:      &metav1.Duration{Duration: time.Hour},
+		}))).To(BeEmpty())
+	})
+
+	It("rejects thresholds that are not positive", func() {
+		result := v.validateDefaultAlertsThresholds(newCluster(&apiv1.DefaultAlertsThresholds{
+			ReplicationLag:             &metav1.Duration{},
+			BackupAge:                  &metav1.Duration{Duration: -time.Hour},
+			ReplicationSlotRetainedWAL: ptr.To(resource.MustParse("0")),
+		}))
+		Expect(result).To(HaveLen(3))
+		Expect(result[0].Field).To(Equal("spec.monitoring.defaultAlertsThresholds.replicationLag"))
+		Expect(result[1].Field).To(Equal("spec.monitoring.defaultAlertsThresholds.backupAge"))
+		Expect(result[2].Field).To(Equal("spec.monitoring.defaultAlertsThresholds.replicationSlotRetainedWAL"))
+	})
+})
+
+var _ = Describe("validateTopology", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts a cluster without topology configuration", func() {
+		Expect(v.validateTopology(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts a topology not clashing with the user-defined constraints", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Topology: &apiv1.TopologyConfiguration{
+					TopologyKeys: []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+				},
+			},
+		}
+		Expect(v.validateTopology(cluster)).To(BeEmpty())
+	})
+
+	It("rejects empty topology keys", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Topology: &apiv1.TopologyConfiguration{TopologyKeys: []string{""}},
+			},
+		}
+		errList := v.validateTopology(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.topology.topologyKeys[0]"))
+	})
+
+	It("rejects a topology clashing with the user-defined constraints", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Topology: &apiv1.TopologyConfiguration{},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{TopologyKey: apiv1.DefaultTopologySpreadKey, WhenUnsatisfiable: corev1.DoNotSchedule},
+				},
+			},
+		}
+		errList := v.validateTopology(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.topology.topologyKeys[0]"))
+	})
+})
+
+var _ = Describe("validateWALArchiving", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(paused bool, expectedBacklog string) *apiv1.Cluster {
+		config := &apiv1.WALArchivingConfiguration{Paused: paused}
+		if expectedBacklog != "" {
+			config.ExpectedBacklog = ptr.To(resource.MustParse(expectedBacklog))
+		}
+		return &apiv1.Cluster{Spec: apiv1.ClusterSpec{WALArchiving: config}}
+	}
+
+	It("accepts a valid configuration", func() {
+		Expect(v.validateWALArchiving(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateWALArchiving(newCluster(false, ""))).To(BeEmpty())
+		Expect(v.validateWALArchiving(newCluster(true, "10Gi"))).To(BeEmpty())
+	})
+
+	It("requires the expected backlog to pause the archiving", func() {
+		errList := v.validateWALArchiving(newCluster(true, ""))
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Type).To(Equal(field.ErrorTypeRequired))
+	})
+
+	It("rejects a non-positive expected backlog", func() {
+		Expect(v.validateWALArchiving(newCluster(false, "0"))).To(HaveLen(1))
+		Expect(v.validateWALArchiving(newCluster(true, "-1Gi"))).To(HaveLen(1))
+	})
+
+	It("accepts the archive and restore commands set together", func() {
+		cluster := newCluster(false, "")
+		cluster.Spec.WALArchiving.ArchiveCommand = "wal-upload %p %f"
+		cluster.Spec.WALArchiving.RestoreCommand = "wal-download %f %p"
+		Expect(v.validateWALArchiving(cluster)).To(BeEmpty())
+	})
+
+	It("requires the archive and restore commands together", func() {
+		cluster := newCluster(false, "")
+		cluster.Spec.WALArchiving.ArchiveCommand = "wal-upload %p"
+		errList := v.validateWALArchiving(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Type).To(Equal(field.ErrorTypeRequired))
+		Expect(errList[0].Field).To(Equal("spec.walArchiving.restoreCommand"))
+
+		cluster.Spec.WALArchiving.ArchiveCommand = ""
+		cluster.Spec.WALArchiving.RestoreCommand = "wal-download %f %p"
+		errList = v.validateWALArchiving(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.walArchiving.archiveCommand"))
+	})
+
+	It("requires the placeholders of the WAL file", func() {
+		cluster := newCluster(false, "")
+		cluster.Spec.WALArchiving.ArchiveCommand = "wal-upload"
+		cluster.Spec.WALArchiving.RestoreCommand = "wal-download %f"
+		Expect(v.validateWALArchiving(cluster)).To(HaveLen(2))
+	})
+
+	It("rejects the commands when a plugin archives the WAL files", func() {
+		cluster := newCluster(false, "")
+		cluster.Spec.WALArchiving.ArchiveCommand = "wal-upload %p"
+		cluster.Spec.WALArchiving.RestoreCommand = "wal-download %f %p"
+		cluster.Spec.Plugins = []apiv1.PluginConfiguration{
+			{Name: "archiver.example.com", IsWALArchiver: ptr.To(true)},
+		}
+		errList := v.validateWALArchiving(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.walArchiving.archiveCommand"))
+	})
+})
+
+var _ = Describe("validateRecoveryJobResources", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(requests, limits corev1.ResourceList) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					Resources: &corev1.ResourceRequirements{Requests: requests, Limits: limits},
+				},
+			},
+		}
+	}
+
+	It("accepts a valid configuration", func() {
+		Expect(v.validateRecoveryJobResources(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateRecoveryJobResources(newCluster(
+			corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+			corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		))).To(BeEmpty())
+	})
+
+	It("rejects requests greater than the limits", func() {
+		errList := v.validateRecoveryJobResources(newCluster(
+			corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		))
+		Expect(errList).To(HaveLen(2))
+		Expect(errList[0].Field).To(Equal("spec.backup.resources.requests.cpu"))
+		Expect(errList[1].Field).To(Equal("spec.backup.resources.requests.memory"))
+	})
+
+	It("rejects a memory limit lower than shared_buffers", func() {
+		cluster := newCluster(nil, corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		})
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{sharedBuffersParameter: "2GB"}
+		errList := v.validateRecoveryJobResources(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.backup.resources.limits.memory"))
+
+		cluster.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter] = "512MB"
+		Expect(v.validateRecoveryJobResources(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateDelayedReplicas", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(instances int, delayed ...string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: instances,
+				DelayedReplicas: &apiv1.DelayedReplicasConfiguration{
+					Instances:     delayed,
+					MinApplyDelay: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+			},
+		}
+	}
+
+	It("accepts a valid configuration", func() {
+		Expect(v.validateDelayedReplicas(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateDelayedReplicas(newCluster(3, "cluster-example-3"))).To(BeEmpty())
+	})
+
+	It("requires a positive delay", func() {
+		cluster := newCluster(3, "cluster-example-3")
+		cluster.Spec.DelayedReplicas.MinApplyDelay.Duration = 0
+		errList := v.validateDelayedReplicas(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.delayedReplicas.minApplyDelay"))
+	})
+
+	It("rejects a delay that PostgreSQL can't represent", func() {
+		cluster := newCluster(3, "cluster-example-3")
+		cluster.Spec.DelayedReplicas.MinApplyDelay.Duration = 25 * 24 * time.Hour
+		errList := v.validateDelayedReplicas(cluster)
+		Expect(errList).To(HaveLen(1))
+		Expect(errList[0].Field).To(Equal("spec.delayedReplicas.minApplyDelay"))
+
+		cluster.Spec.DelayedReplicas.MinApplyDelay.Duration = 24 * 24 * time.Hour
+		Expect(v.validateDelayedReplicas(cluster)).To(BeEmpty())
+	})
+
+	It("rejects empty, duplicated and primary instances", func() {
+		errList := v.validateDelayedReplicas(newCluster(6,
+			"", "cluster-example-3", "cluster-example-3", "cluster-example-1"))
+		Expect(errList).To(HaveLen(3))
+		Expect(errList[0].Field).To(Equal("spec.delayedReplicas.instances[0]"))
+		Expect(errList[1].Type).To(Equal(field.ErrorTypeDuplicate))
+		Expect(errList[2].Field).To(Equal("spec.delayedReplicas.instances[3]"))
+	})
+
+	It("leaves at least one replica eligible for promotion", func() {
+		Expect(v.validateDelayedReplicas(newCluster(2, "cluster-example-2"))).To(HaveLen(1))
+
+		cluster := newCluster(3, "cluster-example-3")
+		cluster.Spec.Failover = &apiv1.FailoverConfiguration{
+			ExcludedInstances: []string{"cluster-example-2"},
+		}
+		Expect(v.validateDelayedReplicas(cluster)).To(HaveLen(1))
+
+		cluster.Spec.Failover.ExcludedInstances = []string{"cluster-example-3"}
+		Expect(v.validateDelayedReplicas(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateExternalConnection", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(host string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ExternalConnection: &apiv1.ExternalConnectionConfiguration{Host: host},
+			},
+		}
+	}
+
+	It("accepts the clusters without an external connection", func() {
+		Expect(v.validateExternalConnection(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("accepts DNS names and IP addresses", func() {
+		Expect(v.validateExternalConnection(newCluster("db.example.com"))).To(BeEmpty())
+		Expect(v.validateExternalConnection(newCluster("10.0.0.1"))).To(BeEmpty())
+		Expect(v.validateExternalConnection(newCluster("fd00::1"))).To(BeEmpty())
+	})
+
+	It("rejects invalid host names", func() {
+		Expect(v.validateExternalConnection(newCluster("db.example.com:5432"))).To(HaveLen(1))
+		Expect(v.validateExternalConnection(newCluster("Not_A_Host"))).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateManagedNetworkPolicy", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(networkPolicy apiv1.ManagedNetworkPolicy) *apiv1.Cluster {
+		networkPolicy.Enabled = true
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{NetworkPolicy: &networkPolicy},
+			},
+		}
+	}
+
+	newPort := func(protocol corev1.Protocol, port intstr.IntOrString, endPort *int32) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port, EndPort: endPort}
+	}
+
+	It("accepts the clusters without a network policy", func() {
+		Expect(v.validateManagedNetworkPolicy(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateManagedNetworkPolicy(newCluster(apiv1.ManagedNetworkPolicy{}))).To(BeEmpty())
+	})
+
+	It("accepts valid additional rules", func() {
+		cluster := newCluster(apiv1.ManagedNetworkPolicy{
+			ApplicationNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "app"}},
+			AdditionalIngress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{newPort(corev1.ProtocolTCP, intstr.FromString("metrics"), nil)},
+				},
+			},
+			AdditionalEgress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						newPort(corev1.ProtocolTCP, intstr.FromInt32(9000), ptr.To(int32(9001))),
+					},
+				},
+			},
+		})
+		Expect(v.validateManagedNetworkPolicy(cluster)).To(BeEmpty())
+	})
+
+	It("rejects invalid ports", func() {
+		cluster := newCluster(apiv1.ManagedNetworkPolicy{
+			AdditionalIngress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{
+						newPort("ICMP", intstr.FromInt32(5432), nil),
+						newPort(corev1.ProtocolTCP, intstr.FromInt32(70000), nil),
+						newPort(corev1.ProtocolTCP, intstr.FromInt32(9000), ptr.To(int32(8000))),
+						newPort(corev1.ProtocolTCP, intstr.FromString("metrics"), ptr.To(int32(8000))),
+					},
+				},
+			},
+		})
+		Expect(v.validateManagedNetworkPolicy(cluster)).To(HaveLen(4))
+	})
+
+	It("rejects invalid peers", func() {
+		cluster := newCluster(apiv1.ManagedNetworkPolicy{
+			ApplicationNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"-invalid": "app"}},
+			AdditionalEgress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"192.168.0.0/16"}}},
+						{
+							IPBlock:     &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+							PodSelector: &metav1.LabelSelector{},
+						},
+					},
+				},
+			},
+		})
+		Expect(v.validateManagedNetworkPolicy(cluster)).To(HaveLen(5))
+	})
+})
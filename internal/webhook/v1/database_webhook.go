@@ -22,10 +22,12 @@ package v1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -156,6 +158,8 @@ func (v *DatabaseCustomValidator) validate(d *apiv1.Database) (allErrs field.Err
 		v.validateSchemas,
 		v.validateFDWs,
 		v.validateForeignServers,
+		v.validateTables,
+		v.validateTimeouts,
 	}
 
 	for _, validate := range validations {
@@ -210,6 +214,20 @@ func (v *DatabaseCustomValidator) validateSchemas(d *apiv1.Database) field.Error
 		}
 
 		schemaNames.Put(name)
+
+		roleNames := stringset.New()
+		for j, privileges := range schemaSpec.Privileges {
+			if roleNames.Has(privileges.Role) {
+				result = append(
+					result,
+					field.Duplicate(
+						field.NewPath("spec", "schemas").Index(i).Child("privileges").Index(j).Child("role"),
+						privileges.Role,
+					),
+				)
+			}
+			roleNames.Put(privileges.Role)
+		}
 	}
 
 	return result
@@ -248,11 +266,115 @@ func (v *DatabaseCustomValidator) validateForeignServers(d *apiv1.Database) fiel
 
 		allErrs = append(allErrs,
 			validateNameOptionsUsages(itemPath, server.Name, server.Options, server.Usages, nameSet)...)
+
+		allErrs = append(allErrs, validateUserMappings(itemPath.Child("userMappings"), server.UserMappings)...)
 	}
 
 	return allErrs
 }
 
+// validateUserMappings validates the user mappings of a foreign server: each user
+// can be mapped only once, and the options provided by the credentials secret
+// cannot be specified explicitly.
+func validateUserMappings(basePath *field.Path, userMappings []apiv1.UserMappingSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	userSet := stringset.New()
+	for i, userMapping := range userMappings {
+		itemPath := basePath.Index(i)
+
+		if userSet.Has(userMapping.User) {
+			errs = append(errs, field.Duplicate(itemPath.Child("user"), userMapping.User))
+		}
+		userSet.Put(userMapping.User)
+
+		optionNames := stringset.New()
+		for j, option := range userMapping.Options {
+			optionPath := itemPath.Child("options").Index(j).Child("name")
+			if optionNames.Has(option.Name) {
+				errs = append(errs, field.Duplicate(optionPath, option.Name))
+			}
+			optionNames.Put(option.Name)
+
+			if userMapping.CredentialsSecret != nil && (option.Name == "user" || option.Name == "password") {
+				errs = append(errs, field.Invalid(
+					optionPath,
+					option.Name,
+					"this option is provided by credentialsSecret and cannot be set explicitly",
+				))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateTables validates the tables of the database: each table can be
+// listed only once, and each storage parameter can be managed only once.
+func (v *DatabaseCustomValidator) validateTables(d *apiv1.Database) field.ErrorList {
+	var errs field.ErrorList
+
+	basePath := field.NewPath("spec", "tables")
+	tableNames := stringset.New()
+	for i, table := range d.Spec.Tables {
+		itemPath := basePath.Index(i)
+
+		schema := table.Schema
+		if schema == "" {
+			schema = "public"
+		}
+		qualifiedName := schema + "." + table.Name
+		if tableNames.Has(qualifiedName) {
+			errs = append(errs, field.Duplicate(itemPath.Child("name"), qualifiedName))
+		}
+		tableNames.Put(qualifiedName)
+
+		parameterNames := stringset.New()
+		for j, parameter := range table.StorageParameters {
+			if parameterNames.Has(parameter.Name) {
+				errs = append(errs, field.Duplicate(
+					itemPath.Child("storageParameters").Index(j).Child("name"),
+					parameter.Name,
+				))
+			}
+			parameterNames.Put(parameter.Name)
+		}
+	}
+
+	return errs
+}
+
+// validateTimeouts validates the timeouts of the database, which PostgreSQL
+// expresses in milliseconds
+func (v *DatabaseCustomValidator) validateTimeouts(d *apiv1.Database) field.ErrorList {
+	timeouts := d.Spec.Timeouts
+	if timeouts == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	basePath := field.NewPath("spec", "timeouts")
+	for _, setting := range []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{name: "statementTimeout", value: timeouts.StatementTimeout},
+		{name: "lockTimeout", value: timeouts.LockTimeout},
+		{name: "idleInTransactionSessionTimeout", value: timeouts.IdleInTransactionSessionTimeout},
+	} {
+		// a value shorter than a millisecond would silently disable the timeout
+		if setting.value != nil && (setting.value.Duration < 0 ||
+			(setting.value.Duration > 0 && setting.value.Duration < time.Millisecond)) {
+			errs = append(errs, field.Invalid(
+				basePath.Child(setting.name),
+				setting.value.String(),
+				"timeout must be either zero or at least one millisecond"))
+		}
+	}
+
+	return errs
+}
+
 // validateServerFDWReference ensures the server references an existing FDW (and is non-empty).
 func (v *DatabaseCustomValidator) validateServerFDWReference(
 	fdwNames *stringset.Data,
@@ -20,6 +20,9 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -137,6 +140,23 @@ var _ = Describe("Database validation", func() {
 		expectDuplicateErrors(errs, map[string]string{"spec.schemas[2].name": "test_two"})
 	})
 
+	It("complains if there are duplicate roles in the privileges of a schema", func() {
+		schema := createSchemaSpec("test_one")
+		schema.Privileges = []apiv1.SchemaPrivilegeSpec{
+			{Role: "reader", Tables: []apiv1.TablePrivilege{"SELECT"}},
+			{Role: "writer", Tables: []apiv1.TablePrivilege{"INSERT"}},
+			{Role: "reader", Schema: []apiv1.SchemaPrivilege{apiv1.SchemaPrivilegeUsage}},
+		}
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Schemas: []apiv1.SchemaSpec{schema},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf("spec.schemas[0].privileges[2].role"))
+		expectDuplicateErrors(errs, map[string]string{"spec.schemas[0].privileges[2].role": "reader"})
+	})
+
 	It("doesn't complain with distinct FDWs and usage names", func() {
 		db := &apiv1.Database{
 			Spec: apiv1.DatabaseSpec{
@@ -319,4 +339,92 @@ var _ = Describe("Database validation", func() {
 			"spec.servers[1].name":            "server1",
 		})
 	})
+
+	It("complains for duplicate user mappings within a single foreign server", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				FDWs: []apiv1.FDWSpec{{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "fdw1"}}},
+				Servers: []apiv1.ServerSpec{
+					{
+						FdwName:            "fdw1",
+						DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "server1", Ensure: apiv1.EnsurePresent},
+						UserMappings:       []apiv1.UserMappingSpec{{User: "app"}, {User: "app"}},
+					},
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf("spec.servers[0].userMappings[1].user"))
+		expectDuplicateErrors(errs, map[string]string{"spec.servers[0].userMappings[1].user": "app"})
+	})
+
+	It("complains when a user mapping sets the options provided by the credentials secret", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				FDWs: []apiv1.FDWSpec{{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "fdw1"}}},
+				Servers: []apiv1.ServerSpec{
+					{
+						FdwName:            "fdw1",
+						DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "server1", Ensure: apiv1.EnsurePresent},
+						UserMappings: []apiv1.UserMappingSpec{
+							{
+								User:              "app",
+								CredentialsSecret: &apiv1.LocalObjectReference{Name: "remote-credentials"},
+								Options:           []apiv1.OptionSpec{{Name: "password", Value: "secret"}},
+							},
+							{
+								User:    "PUBLIC",
+								Options: []apiv1.OptionSpec{{Name: "password", Value: "secret"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf("spec.servers[0].userMappings[0].options[0].name"))
+	})
+
+	It("complains for duplicate tables and storage parameters", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Tables: []apiv1.TableSpec{
+					{
+						Name: "events",
+						StorageParameters: []apiv1.OptionSpec{
+							{Name: "autovacuum_vacuum_scale_factor", Value: "0.01"},
+							{Name: "autovacuum_vacuum_scale_factor", Value: "0.02"},
+						},
+					},
+					{Name: "events", Schema: "public"},
+					{Name: "events", Schema: "app"},
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf(
+			"spec.tables[0].storageParameters[1].name",
+			"spec.tables[1].name",
+		))
+		expectDuplicateErrors(errs, map[string]string{
+			"spec.tables[0].storageParameters[1].name": "autovacuum_vacuum_scale_factor",
+			"spec.tables[1].name":                      "public.events",
+		})
+	})
+
+	It("complains for timeouts shorter than a millisecond", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Timeouts: &apiv1.DatabaseTimeoutsConfiguration{
+					StatementTimeout:                &metav1.Duration{Duration: 30 * time.Second},
+					LockTimeout:                     &metav1.Duration{Duration: time.Microsecond},
+					IdleInTransactionSessionTimeout: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+		}
+		Expect(extractErrorFields(v.validate(db))).To(ConsistOf(
+			"spec.timeouts.lockTimeout",
+			"spec.timeouts.idleInTransactionSessionTimeout",
+		))
+	})
 })
@@ -220,6 +220,33 @@ func (v *PoolerCustomValidator) validatePgBouncer(r *apiv1.Pooler) field.ErrorLi
 		result = append(result, v.validatePgbouncerGenericParameters(r)...)
 	}
 
+	if r.Spec.PgBouncer != nil && len(r.Spec.PgBouncer.Databases) > 0 {
+		result = append(result, v.validatePgBouncerDatabases(r)...)
+	}
+
+	return result
+}
+
+// validatePgBouncerDatabases validates the additional databases exposed
+// by PgBouncer
+func (v *PoolerCustomValidator) validatePgBouncerDatabases(r *apiv1.Pooler) field.ErrorList {
+	var result field.ErrorList
+
+	names := stringset.New()
+	for idx, database := range r.Spec.PgBouncer.Databases {
+		path := field.NewPath("spec", "pgbouncer", "databases").Index(idx).Child("name")
+		switch {
+		case database.Name == "":
+			result = append(result, field.Required(path, "must specify the name of the database"))
+		case database.Name == "pgbouncer":
+			result = append(result,
+				field.Invalid(path, database.Name, "the name is reserved to the PgBouncer admin console"))
+		case names.Has(database.Name):
+			result = append(result, field.Duplicate(path, database.Name))
+		}
+		names.Put(database.Name)
+	}
+
 	return result
 }
 
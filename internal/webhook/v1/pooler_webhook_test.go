@@ -138,4 +138,36 @@ var _ = Describe("Pooler validation", func() {
 		}
 		Expect(v.validatePgbouncerGenericParameters(pooler)).To(BeEmpty())
 	})
+
+	It("does complain when a database uses a reserved or duplicated name", func() {
+		pooler := &apiv1.Pooler{
+			Spec: apiv1.PoolerSpec{
+				PgBouncer: &apiv1.PgBouncerSpec{
+					Databases: []apiv1.PgBouncerDatabase{
+						{Name: "pgbouncer"},
+						{Name: "app_transaction", DBName: "app"},
+						{Name: "app_transaction", DBName: "app"},
+					},
+				},
+			},
+		}
+		errs := v.validatePgBouncerDatabases(pooler)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.pgbouncer.databases[0].name"))
+		Expect(errs[1].Field).To(Equal("spec.pgbouncer.databases[2].name"))
+	})
+
+	It("does not complain when given valid databases", func() {
+		pooler := &apiv1.Pooler{
+			Spec: apiv1.PoolerSpec{
+				PgBouncer: &apiv1.PgBouncerSpec{
+					Databases: []apiv1.PgBouncerDatabase{
+						{Name: "app_transaction", DBName: "app", PoolMode: apiv1.PgBouncerPoolModeTransaction},
+						{Name: "app"},
+					},
+				},
+			},
+		}
+		Expect(v.validatePgBouncerDatabases(pooler)).To(BeEmpty())
+	})
 })
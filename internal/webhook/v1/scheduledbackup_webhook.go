@@ -189,5 +189,45 @@ func (v *ScheduledBackupCustomValidator) validate(r *apiv1.ScheduledBackup) (adm
 		))
 	}
 
+	result = append(result, v.validateFailurePolicy(r)...)
+
 	return warnings, result
 }
+
+// validateFailurePolicy checks the backoff time of the retries of the
+// failed backups
+func (v *ScheduledBackupCustomValidator) validateFailurePolicy(r *apiv1.ScheduledBackup) field.ErrorList {
+	failurePolicy := r.Spec.FailurePolicy
+	if failurePolicy == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "failurePolicy")
+	if failurePolicy.Backoff != nil && failurePolicy.Backoff.Duration <= 0 {
+		result = append(result, field.Invalid(
+			path.Child("backoff"),
+			failurePolicy.Backoff.String(),
+			"the backoff time must be positive",
+		))
+	}
+
+	if failurePolicy.MaxBackoff != nil && failurePolicy.MaxBackoff.Duration <= 0 {
+		result = append(result, field.Invalid(
+			path.Child("maxBackoff"),
+			failurePolicy.MaxBackoff.String(),
+			"the maximum backoff time must be positive",
+		))
+	}
+
+	if len(result) == 0 && failurePolicy.Backoff != nil && failurePolicy.MaxBackoff != nil &&
+		failurePolicy.Backoff.Duration > failurePolicy.MaxBackoff.Duration {
+		result = append(result, field.Invalid(
+			path.Child("backoff"),
+			failurePolicy.Backoff.String(),
+			"the backoff time cannot be greater than the maximum backoff time",
+		))
+	}
+
+	return result
+}
@@ -20,6 +20,9 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -126,4 +129,50 @@ var _ = Describe("Validate schedule", func() {
 		Expect(result).To(HaveLen(1))
 		Expect(result[0].Field).To(Equal("spec.onlineConfiguration"))
 	})
+	It("complains if the backoff time of the failure policy is not positive", func() {
+		scheduledBackup := &apiv1.ScheduledBackup{
+			Spec: apiv1.ScheduledBackupSpec{
+				Schedule: "* * * * * *",
+				FailurePolicy: &apiv1.ScheduledBackupFailurePolicy{
+					Backoff: &metav1.Duration{},
+				},
+			},
+		}
+		warnings, result := v.validate(scheduledBackup)
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.failurePolicy.backoff"))
+	})
+
+	It("complains if the backoff time is greater than the maximum one", func() {
+		scheduledBackup := &apiv1.ScheduledBackup{
+			Spec: apiv1.ScheduledBackupSpec{
+				Schedule: "* * * * * *",
+				FailurePolicy: &apiv1.ScheduledBackupFailurePolicy{
+					Backoff:    &metav1.Duration{Duration: time.Hour},
+					MaxBackoff: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+		warnings, result := v.validate(scheduledBackup)
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.failurePolicy.backoff"))
+	})
+
+	It("doesn't complain with a valid failure policy", func() {
+		scheduledBackup := &apiv1.ScheduledBackup{
+			Spec: apiv1.ScheduledBackupSpec{
+				Schedule: "* * * * * *",
+				FailurePolicy: &apiv1.ScheduledBackupFailurePolicy{
+					MaxRetries: 3,
+					Backoff:    &metav1.Duration{Duration: time.Minute},
+					MaxBackoff: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+		warnings, result := v.validate(scheduledBackup)
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(BeEmpty())
+	})
 })
@@ -74,7 +74,8 @@ const (
 
 	pgBouncerIniTemplateString = `
 [databases]
-* = host={{.Pooler.Spec.Cluster.Name}}-{{.Pooler.Spec.Type}}
+{{ .Databases -}}
+* = host={{ .Host }}
 
 [pgbouncer]
 pool_mode = {{ .Pooler.Spec.PgBouncer.PoolMode }}
@@ -186,6 +187,7 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		parameters["auth_file"] = authFilePath
 	}
 
+	host := fmt.Sprintf("%s-%s", pooler.Spec.Cluster.Name, pooler.Spec.Type)
 	templateData := struct {
 		Pooler            *apiv1.Pooler
 		AuthQuery         string
@@ -193,6 +195,8 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		AuthQueryPassword string
 		AuthDBName        string
 		Parameters        string
+		Host              string
+		Databases         string
 		PgHba             []string
 	}{
 		Pooler:            pooler,
@@ -208,6 +212,8 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		// Also, we want the list of parameters inside the PgBouncer configuration
 		// to be stable.
 		Parameters: stringifyPgBouncerParameters(parameters),
+		Host:       host,
+		Databases:  stringifyPgBouncerDatabases(host, pooler.Spec.PgBouncer.Databases),
 		PgHba:      pooler.Spec.PgBouncer.PgHBA,
 	}
 
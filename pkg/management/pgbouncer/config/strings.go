@@ -24,6 +24,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
 // stringifyPgBouncerParameters will take map of PgBouncer parameters and emit
@@ -42,6 +44,27 @@ func stringifyPgBouncerParameters(parameters map[string]string) (paramsString st
 	return paramsString
 }
 
+// stringifyPgBouncerDatabases emits the entries of the [databases] section
+// for the additional databases of the Pooler, preserving their order.
+// They are placed before the fallback one, forwarding the connections
+// to any other database
+func stringifyPgBouncerDatabases(host string, databases []apiv1.PgBouncerDatabase) (databasesString string) {
+	for _, database := range databases {
+		databasesString += fmt.Sprintf("%s = host=%s dbname=%s", database.Name, host, database.GetDBName())
+		if database.PoolMode != "" {
+			databasesString += fmt.Sprintf(" pool_mode=%s", database.PoolMode)
+		}
+		if database.PoolSize != nil {
+			databasesString += fmt.Sprintf(" pool_size=%d", *database.PoolSize)
+		}
+		if database.MaxDBConnections != nil {
+			databasesString += fmt.Sprintf(" max_db_connections=%d", *database.MaxDBConnections)
+		}
+		databasesString += "\n"
+	}
+	return databasesString
+}
+
 // buildPgBouncerParameters will build a PgBouncer configuration applying any
 // default parameters and forcing any required parameter needed for the
 // controller to work correctly
@@ -20,6 +20,10 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -47,4 +51,26 @@ var _ = Describe("PgBouncer configuration", func() {
 		Expect(params).NotTo(MatchRegexp("^pool_mode.*"))
 		Expect(params).NotTo(MatchRegexp("^pid_file.*"))
 	})
+
+	It("can build the additional databases", func() {
+		databases := stringifyPgBouncerDatabases("cluster-example-rw", []apiv1.PgBouncerDatabase{
+			{
+				Name:     "app_transaction",
+				DBName:   "app",
+				PoolMode: apiv1.PgBouncerPoolModeTransaction,
+				PoolSize: ptr.To(int32(20)),
+			},
+			{
+				Name:             "app",
+				MaxDBConnections: ptr.To(int32(50)),
+			},
+		})
+		Expect(databases).To(Equal(
+			"app_transaction = host=cluster-example-rw dbname=app pool_mode=transaction pool_size=20\n" +
+				"app = host=cluster-example-rw dbname=app max_db_connections=50\n"))
+	})
+
+	It("doesn't emit anything without additional databases", func() {
+		Expect(stringifyPgBouncerDatabases("cluster-example-rw", nil)).To(BeEmpty())
+	})
 })
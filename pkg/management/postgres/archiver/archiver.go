@@ -150,6 +150,14 @@ func Run(
 		return errSwitchoverInProgress
 	}
 
+	paused, err := IsPaused(cluster, path.Join(pgData, "pg_wal"))
+	if err != nil {
+		contextLog.Warning("Refusing to pause WAL archiving", "walName", walName, "err", err)
+	}
+	if paused {
+		return ErrWALArchivingPaused
+	}
+
 	return internalRun(ctx, pgData, cluster, walName)
 }
 
@@ -175,6 +183,17 @@ func internalRun(
 		return nil
 	}
 
+	// The user can replace barman-cloud with a custom command
+	if archiveCommand := cluster.GetWALArchiveCommand(); archiveCommand != "" {
+		if err := runArchiveCommand(ctx, archiveCommand, pgData, walName); err != nil {
+			return fmt.Errorf("while running the archive command: %w", err)
+		}
+		contextLog.Info("Archived WAL file with the archive command",
+			"walName", walName,
+			"totalTime", time.Since(startTime))
+		return nil
+	}
+
 	// Request Barman Cloud to archive this WAL
 	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
 		// Backup not configured, skipping WAL
@@ -0,0 +1,53 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// archiveCommandName is the name used in the logs for the output
+// of the user-provided archive command
+const archiveCommandName = "archive-command"
+
+// runArchiveCommand archives the passed WAL file with the archive command
+// provided by the user, which is run by the shell after replacing its
+// placeholders
+func runArchiveCommand(ctx context.Context, archiveCommand, pgData, walName string) error {
+	walPath := walName
+	if !filepath.IsAbs(walPath) {
+		walPath = filepath.Join(pgData, walPath)
+	}
+
+	command := postgres.ExpandWALCommand(archiveCommand, walPath, filepath.Base(walPath))
+	log.FromContext(ctx).Debug("Archiving WAL file with the archive command",
+		"walName", walName, "command", command)
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command) // #nosec G204
+	cmd.Dir = pgData
+	return execlog.RunBuffering(cmd, archiveCommandName)
+}
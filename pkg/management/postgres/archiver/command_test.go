@@ -0,0 +1,51 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL archive command", func() {
+	var pgData, archive string
+
+	BeforeEach(func() {
+		pgData = GinkgoT().TempDir()
+		archive = GinkgoT().TempDir()
+		Expect(os.Mkdir(filepath.Join(pgData, "pg_wal"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pgData, "pg_wal", "000000010000000000000001"),
+			[]byte("wal"), 0o600)).To(Succeed())
+	})
+
+	It("archives the WAL file passed by PostgreSQL", func(ctx SpecContext) {
+		Expect(runArchiveCommand(ctx, "cp %p "+archive+"/%f", pgData,
+			"pg_wal/000000010000000000000001")).To(Succeed())
+		Expect(filepath.Join(archive, "000000010000000000000001")).To(BeARegularFile())
+	})
+
+	It("reports the failure of the command", func(ctx SpecContext) {
+		Expect(runArchiveCommand(ctx, "exit 1", pgData,
+			"pg_wal/000000010000000000000001")).ToNot(Succeed())
+	})
+})
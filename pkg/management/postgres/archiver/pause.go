@@ -0,0 +1,67 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/system/compatibility"
+)
+
+// ErrWALArchivingPaused is raised when a WAL file is not archived because
+// the WAL archiving has been paused by the user. PostgreSQL retains the
+// WAL file and retries archiving it later.
+var ErrWALArchivingPaused = errors.New("WAL archiving paused")
+
+// getAvailableDiskSpace returns the free space of the filesystem holding
+// the passed path, and can be replaced in tests
+var getAvailableDiskSpace = compatibility.GetAvailableDiskSpace
+
+// IsPaused checks if the WAL archiving is paused. A pause requested by the
+// user is refused, and the WAL files keep being archived, unless the volume
+// holding the WAL files has more free space than the expected backlog: in
+// that case an error describing why the pause has been refused is returned.
+func IsPaused(cluster *apiv1.Cluster, walPath string) (bool, error) {
+	if !cluster.IsWALArchivingPaused() {
+		return false, nil
+	}
+
+	expectedBacklog := cluster.Spec.WALArchiving.ExpectedBacklog
+	if expectedBacklog == nil || expectedBacklog.Sign() <= 0 {
+		return false, fmt.Errorf("no expected backlog has been specified")
+	}
+
+	available, err := getAvailableDiskSpace(walPath)
+	if err != nil {
+		return false, fmt.Errorf("while checking the space available for the WAL files: %w", err)
+	}
+
+	if available <= uint64(expectedBacklog.Value()) { // #nosec G115
+		return false, fmt.Errorf("the WAL volume has %s available, not more than the expected backlog of %s",
+			resource.NewQuantity(int64(available), resource.BinarySI), // #nosec G115
+			expectedBacklog.String())
+	}
+
+	return true, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/system/compatibility"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL archiving pause", func() {
+	var available uint64
+
+	BeforeEach(func() {
+		available = 20 * 1024 * 1024 * 1024
+		getAvailableDiskSpace = func(string) (uint64, error) {
+			return available, nil
+		}
+		DeferCleanup(func() {
+			getAvailableDiskSpace = compatibility.GetAvailableDiskSpace
+		})
+	})
+
+	newCluster := func(paused bool, expectedBacklog string) *apiv1.Cluster {
+		config := &apiv1.WALArchivingConfiguration{Paused: paused}
+		if expectedBacklog != "" {
+			config.ExpectedBacklog = ptr.To(resource.MustParse(expectedBacklog))
+		}
+		return &apiv1.Cluster{Spec: apiv1.ClusterSpec{WALArchiving: config}}
+	}
+
+	It("is not paused unless requested", func() {
+		paused, err := IsPaused(&apiv1.Cluster{}, "/pg_wal")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(paused).To(BeFalse())
+
+		paused, err = IsPaused(newCluster(false, "10Gi"), "/pg_wal")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(paused).To(BeFalse())
+	})
+
+	It("is paused when the WAL volume has room for the expected backlog", func() {
+		paused, err := IsPaused(newCluster(true, "10Gi"), "/pg_wal")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(paused).To(BeTrue())
+	})
+
+	It("refuses to pause when the WAL volume lacks space for the expected backlog", func() {
+		available = 5 * 1024 * 1024 * 1024
+		paused, err := IsPaused(newCluster(true, "10Gi"), "/pg_wal")
+		Expect(err).To(MatchError(ContainSubstring("not more than the expected backlog of 10Gi")))
+		Expect(paused).To(BeFalse())
+	})
+
+	It("refuses to pause without an expected backlog", func() {
+		paused, err := IsPaused(newCluster(true, ""), "/pg_wal")
+		Expect(err).To(HaveOccurred())
+		Expect(paused).To(BeFalse())
+	})
+
+	It("refuses to pause when the available space cannot be checked", func() {
+		getAvailableDiskSpace = func(string) (uint64, error) {
+			return 0, errors.New("boom")
+		}
+		paused, err := IsPaused(newCluster(true, "10Gi"), "/pg_wal")
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(paused).To(BeFalse())
+	})
+})
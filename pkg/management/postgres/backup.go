@@ -21,10 +21,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"reflect"
 	"slices"
+	"syscall"
 	"time"
 
 	barmanBackup "github.com/cloudnative-pg/barman-cloud/pkg/backup"
@@ -40,17 +43,23 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 
 	// this is needed to correctly open the sql connection with the pgx driver
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// defaultBarmanBackupJobs is the number of parallel jobs used by
+// barman-cloud-backup to upload the backup when not configured
+const defaultBarmanBackupJobs = 2
+
 // We wait up to 10 minutes to have a WAL archived correctly
 var retryUntilWalArchiveWorking = wait.Backoff{
 	Duration: 60 * time.Second,
@@ -126,6 +135,8 @@ func (b *BackupCommand) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("cannot recover backup credentials: %w", err)
 	}
+	b.Env = specs.AppendBarmanEndpointCAEnv(
+		b.Env, b.Cluster.Spec.Backup.BarmanObjectStore, postgres.BarmanBackupEndpointCACertificateLocation)
 
 	// Run the actual backup process
 	go b.run(ctx)
@@ -133,6 +144,24 @@ func (b *BackupCommand) Start(ctx context.Context) error {
 	return nil
 }
 
+// describeKilledBackupProcess explains the failure of a backup process that
+// has been killed, which usually happens when the container of the instance
+// runs out of memory and the kernel chooses the backup process as the victim
+func describeKilledBackupProcess(err error) error {
+	var exitError *exec.ExitError
+	if !errors.As(err, &exitError) {
+		return err
+	}
+
+	waitStatus, ok := exitError.Sys().(syscall.WaitStatus)
+	if !ok || !waitStatus.Signaled() || waitStatus.Signal() != syscall.SIGKILL {
+		return err
+	}
+
+	return fmt.Errorf("the backup process has been killed, probably because the instance ran out of memory, "+
+		"consider increasing the memory resources of the cluster: %w", err)
+}
+
 func (b *BackupCommand) retryWithRefreshedCluster(
 	ctx context.Context,
 	cb func() error,
@@ -191,6 +220,7 @@ func (b *BackupCommand) takeBackup(ctx context.Context) error {
 		postgres.BackupTemporaryDirectory,
 	)
 	if err != nil {
+		err = describeKilledBackupProcess(err)
 		b.Log.Error(err, "Error while taking barman backup", "err", err)
 		return err
 	}
@@ -221,6 +251,8 @@ func (b *BackupCommand) takeBackup(ctx context.Context) error {
 		b.Log.Error(err, "Can't update the cluster with the completed backup data")
 	}
 
+	RunPhysicalPostBackupHook(ctx, b.Cluster, b.Backup, b.Recorder)
+
 	return nil
 }
 
@@ -306,8 +338,12 @@ func (b *BackupCommand) setupBackupStatus() {
 	backupStatus.EndpointCA = barmanConfiguration.EndpointCA
 	backupStatus.EndpointURL = barmanConfiguration.EndpointURL
 	backupStatus.DestinationPath = barmanConfiguration.DestinationPath
+	backupStatus.Jobs = ptr.To(int32(defaultBarmanBackupJobs))
 	if barmanConfiguration.Data != nil {
 		backupStatus.Encryption = string(barmanConfiguration.Data.Encryption)
+		if barmanConfiguration.Data.Jobs != nil {
+			backupStatus.Jobs = ptr.To(*barmanConfiguration.Data.Jobs)
+		}
 	}
 	// Set the barman server name as specified by the user.
 	// If not explicitly configured use the cluster name
@@ -370,6 +406,8 @@ func deleteBackupsNotInCatalog(
 	for id, backup := range backups.Items {
 		if backup.Spec.Cluster.Name != cluster.GetName() ||
 			backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			// logical dumps are not part of the barman catalog
+			backup.Spec.Method == apiv1.BackupMethodPgDump ||
 			!useSameBackupLocation(&backup.Status, cluster) {
 			continue
 		}
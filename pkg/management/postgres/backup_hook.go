@@ -0,0 +1,181 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// postBackupHookName is the name used in the logs for the output
+// of the post-backup hook
+const postBackupHookName = "post-backup-hook"
+
+// postBackupHookInheritedEnv is the list of the variables of the instance
+// manager environment that are passed to the post-backup hook. Everything
+// else, like the credentials to access the object store, is not inherited.
+var postBackupHookInheritedEnv = []string{
+	"PATH",
+	"HOME",
+	"TMPDIR",
+	"TZ",
+	"LANG",
+	"LC_ALL",
+	"PGDATA",
+	"PGHOST",
+	"PGPORT",
+	"POD_NAME",
+	"NAMESPACE",
+	"CLUSTER_NAME",
+}
+
+// RunPhysicalPostBackupHook executes the post-backup hook configured in the
+// cluster, if any, after a physical backup. The backup status doesn't report
+// the size of physical backups, so the size is not passed to the hook.
+func RunPhysicalPostBackupHook(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	recorder record.EventRecorder,
+) {
+	runPostBackupHook(ctx, cluster, backup, nil, recorder)
+}
+
+// runPostBackupHook executes the post-backup hook configured in the cluster,
+// if any, passing the metadata of the completed backup in the environment.
+// A failure of the hook is logged and reported with an event, but doesn't
+// change the outcome of the backup.
+func runPostBackupHook(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	backupSize *int64,
+	recorder record.EventRecorder,
+) {
+	if !hasPostBackupHook(cluster) {
+		return
+	}
+
+	contextLogger := log.FromContext(ctx)
+	hook := cluster.Spec.Backup.PostBackupHook
+	env := slices.Concat(
+		filterPostBackupHookEnv(os.Environ()),
+		getPostBackupHookEnv(backup, backupSize),
+	)
+	if err := runBackupHook(ctx, hook, env); err != nil {
+		contextLogger.Error(err, "Post-backup hook failed", "command", hook.Command)
+		recorder.Eventf(backup, "Warning", "PostBackupHookFailed", "Post-backup hook failed: %v", err)
+		return
+	}
+
+	contextLogger.Info("Post-backup hook completed", "command", hook.Command)
+}
+
+// hasPostBackupHook checks if the cluster has a post-backup hook
+func hasPostBackupHook(cluster *apiv1.Cluster) bool {
+	return cluster.Spec.Backup != nil && cluster.Spec.Backup.PostBackupHook != nil
+}
+
+// filterPostBackupHookEnv returns the variables of the passed environment
+// that are inherited by the post-backup hook
+func filterPostBackupHookEnv(env []string) []string {
+	result := make([]string, 0, len(postBackupHookInheritedEnv))
+	for _, variable := range env {
+		name, _, _ := strings.Cut(variable, "=")
+		if slices.Contains(postBackupHookInheritedEnv, name) {
+			result = append(result, variable)
+		}
+	}
+
+	return result
+}
+
+// runBackupHook executes the command of the passed hook with the passed
+// environment, killing it if it doesn't complete within its timeout
+func runBackupHook(ctx context.Context, hook *apiv1.BackupHookConfiguration, env []string) error {
+	if len(hook.Command) == 0 {
+		return fmt.Errorf("the backup hook has no command")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hook.GetTimeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...) // #nosec G204
+	cmd.Env = env
+	if err := execlog.RunBuffering(cmd, postBackupHookName); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out after %v: %w", hook.GetTimeout(), err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// getPostBackupHookEnv returns the environment variables describing the
+// completed backup that are passed to the post-backup hook. The size of
+// the backup is empty when it is not known.
+func getPostBackupHookEnv(backup *apiv1.Backup, backupSize *int64) []string {
+	formatTime := func(t *metav1.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	size := ""
+	if backupSize != nil {
+		size = strconv.FormatInt(*backupSize, 10)
+	}
+
+	backupStatus := &backup.Status
+	result := []string{
+		"BACKUP_NAME=" + backup.Name,
+		"BACKUP_METHOD=" + string(backupStatus.Method),
+		"BACKUP_ID=" + backupStatus.BackupID,
+		"BACKUP_DESTINATION_PATH=" + backupStatus.DestinationPath,
+		"BACKUP_SERVER_NAME=" + backupStatus.ServerName,
+		"BACKUP_SIZE=" + size,
+		"BACKUP_BEGIN_LSN=" + backupStatus.BeginLSN,
+		"BACKUP_END_LSN=" + backupStatus.EndLSN,
+		"BACKUP_BEGIN_WAL=" + backupStatus.BeginWal,
+		"BACKUP_END_WAL=" + backupStatus.EndWal,
+		"BACKUP_STARTED_AT=" + formatTime(backupStatus.StartedAt),
+		"BACKUP_STOPPED_AT=" + formatTime(backupStatus.StoppedAt),
+	}
+	if backupStatus.DumpObjectPath != "" {
+		result = append(result, "BACKUP_DUMP_OBJECT_PATH="+backupStatus.DumpObjectPath)
+	}
+
+	return result
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Post-backup hook", func() {
+	var (
+		backup   *apiv1.Backup
+		cluster  *apiv1.Cluster
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		startedAt := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+		stoppedAt := metav1.NewTime(time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example"},
+			Status: apiv1.BackupStatus{
+				Method:          apiv1.BackupMethodBarmanObjectStore,
+				BackupID:        "20240101T100000",
+				DestinationPath: "s3://backups/",
+				ServerName:      "cluster-example",
+				BeginLSN:        "0/2000028",
+				EndLSN:          "0/2000138",
+				BeginWal:        "000000010000000000000002",
+				EndWal:          "000000010000000000000002",
+				StartedAt:       &startedAt,
+				StoppedAt:       &stoppedAt,
+			},
+		}
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{},
+			},
+		}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("builds the environment from the metadata of the backup", func() {
+		Expect(getPostBackupHookEnv(backup, ptr.To(int64(1024)))).To(Equal([]string{
+			"BACKUP_NAME=backup-example",
+			"BACKUP_METHOD=barmanObjectStore",
+			"BACKUP_ID=20240101T100000",
+			"BACKUP_DESTINATION_PATH=s3://backups/",
+			"BACKUP_SERVER_NAME=cluster-example",
+			"BACKUP_SIZE=1024",
+			"BACKUP_BEGIN_LSN=0/2000028",
+			"BACKUP_END_LSN=0/2000138",
+			"BACKUP_BEGIN_WAL=000000010000000000000002",
+			"BACKUP_END_WAL=000000010000000000000002",
+			"BACKUP_STARTED_AT=2024-01-01T10:00:00Z",
+			"BACKUP_STOPPED_AT=2024-01-01T10:05:00Z",
+		}))
+
+		backup.Status.DumpObjectPath = "s3://backups/cluster-example/dumps/backup-example.dump"
+		Expect(getPostBackupHookEnv(backup, nil)).To(ContainElements(
+			"BACKUP_SIZE=",
+			"BACKUP_DUMP_OBJECT_PATH=s3://backups/cluster-example/dumps/backup-example.dump"))
+	})
+
+	It("only inherits the allowed variables of the instance manager environment", func() {
+		Expect(filterPostBackupHookEnv([]string{
+			"PATH=/usr/bin",
+			"PGDATA=/var/lib/postgresql/data/pgdata",
+			"AWS_ACCESS_KEY_ID=key",
+			"AWS_SECRET_ACCESS_KEY=secret",
+			"PATHS=/tmp",
+		})).To(Equal([]string{
+			"PATH=/usr/bin",
+			"PGDATA=/var/lib/postgresql/data/pgdata",
+		}))
+	})
+
+	It("doesn't pass the credentials of the object store to the command", func(ctx SpecContext) {
+		GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		outputFile := filepath.Join(GinkgoT().TempDir(), "output")
+		cluster.Spec.Backup.PostBackupHook = &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/sh", "-c", `echo "$BACKUP_SIZE:$AWS_SECRET_ACCESS_KEY" > ` + outputFile},
+		}
+
+		runPostBackupHook(ctx, cluster, backup, ptr.To(int64(1024)), recorder)
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(os.ReadFile(outputFile)).To(BeEquivalentTo("1024:\n"))
+	})
+
+	It("doesn't pass a size for the physical backups", func(ctx SpecContext) {
+		outputFile := filepath.Join(GinkgoT().TempDir(), "output")
+		cluster.Spec.Backup.PostBackupHook = &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/sh", "-c", `echo "size:$BACKUP_SIZE" > ` + outputFile},
+		}
+
+		RunPhysicalPostBackupHook(ctx, cluster, backup, recorder)
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(os.ReadFile(outputFile)).To(BeEquivalentTo("size:\n"))
+	})
+
+	It("runs the command passing the backup metadata", func(ctx SpecContext) {
+		outputFile := filepath.Join(GinkgoT().TempDir(), "output")
+		cluster.Spec.Backup.PostBackupHook = &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/sh", "-c", `echo "$BACKUP_ID" > ` + outputFile},
+		}
+
+		runPostBackupHook(ctx, cluster, backup, nil, recorder)
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(os.ReadFile(outputFile)).To(BeEquivalentTo("20240101T100000\n"))
+	})
+
+	It("reports a failure of the command with an event", func(ctx SpecContext) {
+		cluster.Spec.Backup.PostBackupHook = &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/sh", "-c", "exit 1"},
+		}
+
+		runPostBackupHook(ctx, cluster, backup, nil, recorder)
+		Expect(recorder.Events).To(Receive(ContainSubstring("PostBackupHookFailed")))
+	})
+
+	It("kills the command when the timeout expires", func(ctx SpecContext) {
+		hook := &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/sleep", "10"},
+			Timeout: &metav1.Duration{Duration: 100 * time.Millisecond},
+		}
+
+		Expect(runBackupHook(ctx, hook, nil)).To(MatchError(ContainSubstring("timed out")))
+	})
+
+	It("does nothing when no hook is configured", func(ctx SpecContext) {
+		runPostBackupHook(ctx, cluster, backup, nil, recorder)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logicalimport"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// PgDumpBackupCommand represents a logical dump of a single database that
+// is being taken with pg_dump, and uploaded to the object store of the cluster
+type PgDumpBackupCommand struct {
+	Cluster  *apiv1.Cluster
+	Backup   *apiv1.Backup
+	Client   client.Client
+	Recorder record.EventRecorder
+	Env      []string
+	Log      log.Logger
+	Instance *Instance
+}
+
+// NewPgDumpBackupCommand initializes a PgDumpBackupCommand object
+func NewPgDumpBackupCommand(
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	client client.Client,
+	recorder record.EventRecorder,
+	instance *Instance,
+	log log.Logger,
+) *PgDumpBackupCommand {
+	return &PgDumpBackupCommand{
+		Cluster:  cluster,
+		Backup:   backup,
+		Client:   client,
+		Recorder: recorder,
+		Env:      os.Environ(),
+		Instance: instance,
+		Log:      log,
+	}
+}
+
+// Start initiates the logical dump, which is taken in background
+func (b *PgDumpBackupCommand) Start(ctx context.Context) error {
+	b.setupBackupStatus()
+
+	if err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		return fmt.Errorf("can't set backup as running: %v", err)
+	}
+
+	barmanConfiguration := b.Cluster.Spec.Backup.BarmanObjectStore
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		b.Client,
+		b.Cluster.Namespace,
+		barmanConfiguration,
+		b.Env)
+	if err != nil {
+		return fmt.Errorf("cannot recover backup credentials: %w", err)
+	}
+	b.Env = specs.AppendBarmanEndpointCAEnv(
+		env, barmanConfiguration, postgres.BarmanBackupEndpointCACertificateLocation)
+
+	go b.run(ctx)
+
+	return nil
+}
+
+// run takes the logical dump and updates the status of the backup. This
+// method will take long time and is supposed to run inside a dedicated
+// goroutine.
+func (b *PgDumpBackupCommand) run(ctx context.Context) {
+	ctx = log.IntoContext(
+		ctx,
+		log.FromContext(ctx).
+			WithValues(
+				"backupName", b.Backup.Name,
+				"backupNamespace", b.Backup.Namespace,
+			),
+	)
+
+	b.Recorder.Event(b.Backup, "Normal", "Starting", "Logical dump started")
+
+	objectPath, size, err := logicalimport.ExportDump(
+		ctx,
+		b.Instance.ConnectionPool(),
+		b.Backup.Spec.PgDump,
+		b.Cluster.Spec.Backup.BarmanObjectStore,
+		b.Backup.Status.ServerName,
+		b.Backup.Name,
+		b.Env,
+	)
+	if err != nil {
+		err = describeKilledBackupProcess(err)
+		b.Log.Error(err, "Logical dump failed")
+		b.Recorder.Event(b.Backup, "Normal", "Failed", "Logical dump failed")
+
+		// The cluster is not passed, as a logical dump has no effect on
+		// the recoverability of the cluster
+		_ = status.FlagBackupAsFailed(ctx, b.Client, b.Backup, nil, err)
+		return
+	}
+
+	b.Log.Info("Logical dump completed", "objectPath", objectPath, "size", size)
+	b.Recorder.Event(b.Backup, "Normal", "Completed", "Logical dump completed")
+
+	b.Backup.Status.DumpObjectPath = objectPath
+	b.Backup.Status.SetAsCompleted()
+	if err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		b.Log.Error(err, "Can't set backup status as completed")
+	}
+
+	runPostBackupHook(ctx, b.Cluster, b.Backup, &size, b.Recorder)
+}
+
+// setupBackupStatus configures the status of the logical dump from the
+// object store configuration of the cluster
+func (b *PgDumpBackupCommand) setupBackupStatus() {
+	barmanConfiguration := b.Cluster.Spec.Backup.BarmanObjectStore
+	backupStatus := b.Backup.GetStatus()
+
+	backupStatus.BackupName = b.Backup.Name
+	backupStatus.BarmanCredentials = barmanConfiguration.BarmanCredentials
+	backupStatus.EndpointCA = barmanConfiguration.EndpointCA
+	backupStatus.EndpointURL = barmanConfiguration.EndpointURL
+	backupStatus.DestinationPath = barmanConfiguration.DestinationPath
+	backupStatus.ServerName = barmanConfiguration.ServerName
+	if backupStatus.ServerName == "" {
+		backupStatus.ServerName = b.Cluster.Name
+	}
+	backupStatus.StartedAt = ptr.To(metav1.Now())
+	backupStatus.Phase = apiv1.BackupPhaseRunning
+}
@@ -21,7 +21,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"strings"
 
 	barmanBackup "github.com/cloudnative-pg/barman-cloud/pkg/backup"
@@ -207,3 +209,56 @@ var _ = Describe("generate backup options", func() {
 				))
 	})
 })
+
+var _ = Describe("setup backup status", func() {
+	var backupCommand BackupCommand
+
+	BeforeEach(func() {
+		backupCommand = BackupCommand{
+			Cluster: &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test"},
+				Spec: apiv1.ClusterSpec{
+					Backup: &apiv1.BackupConfiguration{
+						BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://bucket/path",
+						},
+					},
+				},
+			},
+			Backup: &apiv1.Backup{},
+		}
+	})
+
+	It("records the default number of parallel jobs", func() {
+		backupCommand.setupBackupStatus()
+		Expect(backupCommand.Backup.Status.Jobs).To(HaveValue(BeEquivalentTo(2)))
+		Expect(backupCommand.Backup.Status.ServerName).To(Equal("test-cluster"))
+	})
+
+	It("records the configured number of parallel jobs", func() {
+		backupCommand.Cluster.Spec.Backup.BarmanObjectStore.Data = &apiv1.DataBackupConfiguration{
+			Jobs: ptr.To(int32(8)),
+		}
+		backupCommand.setupBackupStatus()
+		Expect(backupCommand.Backup.Status.Jobs).To(HaveValue(BeEquivalentTo(8)))
+	})
+})
+
+var _ = Describe("describeKilledBackupProcess", func() {
+	It("explains why a backup process has been killed", func(ctx SpecContext) {
+		err := exec.CommandContext(ctx, "sh", "-c", "kill -9 $$").Run()
+		Expect(err).To(HaveOccurred())
+
+		described := describeKilledBackupProcess(err)
+		Expect(described).To(MatchError(ContainSubstring("ran out of memory")))
+		Expect(errors.Is(described, err)).To(BeTrue())
+	})
+
+	It("leaves the other errors untouched", func(ctx SpecContext) {
+		err := exec.CommandContext(ctx, "sh", "-c", "exit 1").Run()
+		Expect(describeKilledBackupProcess(err)).To(BeIdenticalTo(err))
+
+		err = errors.New("generic error")
+		Expect(describeKilledBackupProcess(err)).To(BeIdenticalTo(err))
+	})
+})
@@ -25,8 +25,11 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	postgresClient "github.com/cloudnative-pg/cnpg-i/pkg/postgres"
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
@@ -100,7 +103,12 @@ func (instance *Instance) RefreshConfigurationFilesFromCluster(
 }
 
 // GeneratePostgresqlHBA generates the pg_hba.conf content with the LDAP configuration if configured.
-func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBindPassword string) (string, error) {
+// The additional rules are appended to the ones defined in the cluster.
+func (instance *Instance) GeneratePostgresqlHBA(
+	cluster *apiv1.Cluster,
+	ldapBindPassword string,
+	additionalRules []string,
+) (string, error) {
 	majorVersion, err := cluster.GetPostgresqlMajorVersion()
 	if err != nil {
 		return "", err
@@ -113,24 +121,33 @@ func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBind
 	//
 	// See:
 	// https://www.postgresql.org/docs/14/release-14.html
+	//
+	// SCRAM-SHA-256 is always used when it is required over TLS.
 	defaultAuthenticationMethod := "scram-sha-256"
-	if majorVersion < 14 {
+	requireSCRAMOverSSL := cluster.Spec.PostgresConfiguration.RequireSCRAMOverSSL
+	if majorVersion < 14 && !requireSCRAMOverSSL {
 		defaultAuthenticationMethod = "md5"
 	}
 
 	return postgres.CreateHBARules(
-		cluster.Spec.PostgresConfiguration.PgHBA,
+		slices.Concat(cluster.Spec.PostgresConfiguration.PgHBA, additionalRules),
 		defaultAuthenticationMethod,
-		buildLDAPConfigString(cluster, ldapBindPassword))
+		buildLDAPConfigString(cluster, ldapBindPassword),
+		requireSCRAMOverSSL)
 }
 
 // RefreshPGHBA generates and writes down the pg_hba.conf file
-func (instance *Instance) RefreshPGHBA(ctx context.Context, cluster *apiv1.Cluster, ldapBindPassword string) (
+func (instance *Instance) RefreshPGHBA(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	ldapBindPassword string,
+	additionalRules []string,
+) (
 	postgresHBAChanged bool,
 	err error,
 ) {
 	// Generate pg_hba.conf file
-	pgHBAContent, err := instance.GeneratePostgresqlHBA(cluster, ldapBindPassword)
+	pgHBAContent, err := instance.GeneratePostgresqlHBA(cluster, ldapBindPassword, additionalRules)
 	if err != nil {
 		return false, nil
 	}
@@ -252,9 +269,15 @@ func (instance *Instance) RefreshPGIdent(
 }
 
 // UpdateReplicaConfiguration updates the override.conf or recovery.conf file for the proper version
-// of PostgreSQL, using the specified connection string to connect to the primary server
-func UpdateReplicaConfiguration(pgData, primaryConnInfo, slotName string) (changed bool, err error) {
-	changed, err = configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName)
+// of PostgreSQL, using the specified connection string to connect to the primary server.
+// When enforceReadOnly is true, sessions are made read-only by default, and a non-zero
+// minApplyDelay makes the replica apply the changes received from the primary with a delay.
+func UpdateReplicaConfiguration(
+	pgData, primaryConnInfo, slotName string,
+	enforceReadOnly bool,
+	minApplyDelay time.Duration,
+) (changed bool, err error) {
+	changed, err = configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName, enforceReadOnly, minApplyDelay)
 	if err != nil {
 		return changed, err
 	}
@@ -264,9 +287,14 @@ func UpdateReplicaConfiguration(pgData, primaryConnInfo, slotName string) (chang
 
 // configurePostgresOverrideConfFile writes the content of override.conf file, including
 // replication information. The “primary_slot_name` parameter will be generated only when the parameter slotName is not
-// empty.
+// empty, `default_transaction_read_only` only when enforceReadOnly is true, and `recovery_min_apply_delay`
+// only when minApplyDelay is not zero.
 // Returns a boolean indicating if any changes were done and any errors encountered
-func configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName string) (changed bool, err error) {
+func configurePostgresOverrideConfFile(
+	pgData, primaryConnInfo, slotName string,
+	enforceReadOnly bool,
+	minApplyDelay time.Duration,
+) (changed bool, err error) {
 	targetFile := path.Join(pgData, constants.PostgresqlOverrideConfigurationFile)
 	options := map[string]string{
 		"restore_command": fmt.Sprintf(
@@ -280,6 +308,14 @@ func configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName string)
 		options["primary_slot_name"] = slotName
 	}
 
+	if enforceReadOnly {
+		options[defaultTransactionReadOnlyParameter] = "on"
+	}
+
+	if minApplyDelay != 0 {
+		options[postgres.ParameterRecoveryMinApplyDelay] = fmt.Sprintf("%dms", minApplyDelay.Milliseconds())
+	}
+
 	// Ensure that override.conf file contains just the above options
 	changed, err = configfile.WritePostgresConfiguration(targetFile, options)
 	if err != nil {
@@ -293,6 +329,37 @@ func configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName string)
 	return changed, nil
 }
 
+// removeReplicaConfiguration removes the streaming replication settings
+// and the `default_transaction_read_only` enforcement from the override.conf
+// file of a promoted instance, so that new sessions are allowed to write
+// again and the former replication source is not referenced anymore.
+// Returns a boolean indicating if any changes were done and any errors encountered
+func removeReplicaConfiguration(pgData string) (changed bool, err error) {
+	targetFile := path.Join(pgData, constants.PostgresqlOverrideConfigurationFile)
+	if exists, err := fileutils.FileExists(targetFile); err != nil || !exists {
+		return false, err
+	}
+
+	changed, err = configfile.UpdatePostgresConfigurationFile(
+		targetFile,
+		nil,
+		"primary_conninfo",
+		"primary_slot_name",
+		defaultTransactionReadOnlyParameter,
+		postgres.ParameterRecoveryMinApplyDelay,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if changed {
+		log.Info("Removed the replication settings from the promoted instance",
+			"filename", constants.PostgresqlOverrideConfigurationFile)
+	}
+
+	return changed, nil
+}
+
 // createStandbySignal creates a standby.signal file for PostgreSQL 12 and beyond
 func createStandbySignal(pgData string) error {
 	emptyFile, err := os.Create(filepath.Clean(filepath.Join(pgData, "standby.signal")))
@@ -415,6 +482,11 @@ func createPostgresqlConfiguration(
 	// Set cluster name
 	info.ClusterName = cluster.Name
 
+	// Set the memory limit, used by the parameters expressed as a percentage
+	if memoryLimit := cluster.Spec.Resources.Limits.Memory(); memoryLimit != nil {
+		info.MemoryLimit = memoryLimit.Value()
+	}
+
 	// Set temporary tablespaces
 	for _, tablespace := range cluster.Spec.Tablespaces {
 		if tablespace.Temporary {
@@ -435,6 +507,77 @@ func createPostgresqlConfiguration(
 		)
 	}
 
+	// Set the TLS hardening settings
+	if ssl := cluster.Spec.PostgresConfiguration.SSL; ssl != nil {
+		info.SSLMinProtocolVersion = string(ssl.MinProtocolVersion)
+		info.SSLMaxProtocolVersion = string(ssl.MaxProtocolVersion)
+		info.SSLCiphers = ssl.Ciphers
+	}
+
+	// Set the typed logging settings
+	if logging := cluster.Spec.PostgresConfiguration.Logging; logging != nil {
+		if logging.SlowQueryThreshold != nil {
+			info.LogMinDurationStatement = fmt.Sprintf("%dms", logging.SlowQueryThreshold.Milliseconds())
+		}
+		info.LogStatement = string(logging.LogStatement)
+		if logging.LogConnections != nil {
+			info.LogConnections = postgres.FormatPostgresConfigBoolean(*logging.LogConnections)
+		}
+		if logging.LogDisconnections != nil {
+			info.LogDisconnections = postgres.FormatPostgresConfigBoolean(*logging.LogDisconnections)
+		}
+	}
+
+	// Set the usage and the size of the huge pages. When they are not
+	// available on any node, the pods don't request them, and PostgreSQL
+	// must not try to use them
+	if hugePages := cluster.Spec.PostgresConfiguration.HugePages; hugePages != nil {
+		info.HugePages = string(hugePages.GetMode())
+		if cluster.Status.HugePagesFallback {
+			info.HugePages = string(apiv1.HugePagesModeOff)
+		} else {
+			pageSize := hugePages.GetPageSize()
+			info.HugePageSize = fmt.Sprintf("%dkB", pageSize.Value()/1024)
+		}
+	}
+
+	// Set the idle sessions timeouts
+	if idleSessions := cluster.Spec.PostgresConfiguration.IdleSessions; idleSessions != nil {
+		if idleSessions.SessionTimeout != nil {
+			info.IdleSessionTimeout = fmt.Sprintf("%dms", idleSessions.SessionTimeout.Milliseconds())
+		}
+		if idleSessions.InTransactionTimeout != nil {
+			info.IdleInTransactionSessionTimeout = fmt.Sprintf("%dms",
+				idleSessions.InTransactionTimeout.Milliseconds())
+		}
+	}
+
+	// Set the password hashing settings. The role synchronizer waits for
+	// them to be reloaded before setting the passwords of the managed roles
+	if passwordEncryption := cluster.Spec.PostgresConfiguration.PasswordEncryption; passwordEncryption != nil {
+		info.PasswordEncryption = string(passwordEncryption.Method)
+		if passwordEncryption.ScramIterations != nil {
+			info.ScramIterations = strconv.Itoa(int(*passwordEncryption.ScramIterations))
+		}
+	}
+
+	// Set the autovacuum tuning options
+	if autovacuum := cluster.Spec.PostgresConfiguration.Autovacuum; autovacuum != nil {
+		if autovacuum.Naptime != nil {
+			info.AutovacuumNaptime = fmt.Sprintf("%ds", int64(autovacuum.Naptime.Seconds()))
+		}
+		if autovacuum.MaxWorkers != nil {
+			info.AutovacuumMaxWorkers = strconv.Itoa(int(*autovacuum.MaxWorkers))
+		}
+		if autovacuum.VacuumCostDelay != nil {
+			info.AutovacuumVacuumCostDelay = strconv.FormatFloat(
+				float64(autovacuum.VacuumCostDelay.Duration)/float64(time.Millisecond), 'f', -1, 64) + "ms"
+		}
+		if autovacuum.VacuumCostLimit != nil {
+			info.AutovacuumVacuumCostLimit = strconv.Itoa(int(*autovacuum.VacuumCostLimit))
+		}
+	}
+
 	// Setup minimum replay delay if we're on a replica cluster
 	if cluster.IsReplica() && cluster.Spec.ReplicaCluster.MinApplyDelay != nil {
 		info.RecoveryMinApplyDelay = cluster.Spec.ReplicaCluster.MinApplyDelay.Duration
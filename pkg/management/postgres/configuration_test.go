@@ -21,6 +21,8 @@ package postgres
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -32,6 +34,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -287,3 +290,64 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(config).ToNot(ContainSubstring("recovery_min_apply_delay"))
 	})
 })
+
+var _ = Describe("read-only enforcement on replicas", func() {
+	var pgData string
+
+	BeforeEach(func() {
+		pgData = GinkgoT().TempDir()
+	})
+
+	readOverrideConf := func() string {
+		content, err := os.ReadFile(filepath.Join(pgData, constants.PostgresqlOverrideConfigurationFile))
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	It("sets default_transaction_read_only only when requested", func() {
+		_, err := UpdateReplicaConfiguration(pgData, "host=primary", "", false, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readOverrideConf()).ToNot(ContainSubstring("default_transaction_read_only"))
+
+		changed, err := UpdateReplicaConfiguration(pgData, "host=primary", "", true, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(readOverrideConf()).To(ContainSubstring("default_transaction_read_only = 'on'"))
+	})
+
+	It("sets recovery_min_apply_delay only for the delayed replicas", func() {
+		_, err := UpdateReplicaConfiguration(pgData, "host=primary", "", false, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readOverrideConf()).ToNot(ContainSubstring("recovery_min_apply_delay"))
+
+		changed, err := UpdateReplicaConfiguration(pgData, "host=primary", "", false, 90*time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(readOverrideConf()).To(ContainSubstring("recovery_min_apply_delay = '5400000ms'"))
+	})
+
+	It("removes the replication settings after a promotion", func() {
+		_, err := UpdateReplicaConfiguration(pgData, "host=primary", "slot", true, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		changed, err := removeReplicaConfiguration(pgData)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		content := readOverrideConf()
+		Expect(content).ToNot(ContainSubstring("default_transaction_read_only"))
+		Expect(content).ToNot(ContainSubstring("primary_conninfo"))
+		Expect(content).ToNot(ContainSubstring("primary_slot_name"))
+		Expect(content).ToNot(ContainSubstring("recovery_min_apply_delay"))
+		Expect(content).To(ContainSubstring("restore_command"))
+
+		changed, err = removeReplicaConfiguration(pgData)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("does nothing when the override.conf file doesn't exist", func() {
+		changed, err := removeReplicaConfiguration(pgData)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+})
@@ -21,11 +21,67 @@ package postgres
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
+const (
+	// ApplicationNameInstanceManager is the application_name used by the
+	// instance manager connection pool, when no prefix is configured
+	ApplicationNameInstanceManager = "cnpg-instance-manager"
+
+	// ApplicationNameMetricsExporter is the application_name used by the
+	// metrics exporter, when no prefix is configured
+	ApplicationNameMetricsExporter = "cnpg_metrics_exporter"
+
+	// DefaultConnectionRetryTimeout is the default maximum time the instance
+	// manager spends retrying a connection to the local PostgreSQL server
+	// while it is starting up or recovering
+	DefaultConnectionRetryTimeout = 10 * time.Second
+)
+
+// GetApplicationName gets the application_name to be used by an instance
+// manager component connecting to PostgreSQL. When the operator has been
+// configured with an application name prefix, the name of the component is
+// appended to it; otherwise the default name is returned
+func GetApplicationName(component, defaultName string) string {
+	prefix := pool.GetApplicationNamePrefix()
+	if len(prefix) == 0 {
+		return defaultName
+	}
+
+	return prefix + component
+}
+
+// GetConnectionRetryTimeout returns the maximum time the instance manager
+// spends retrying a connection to the local PostgreSQL server while it is
+// starting up or recovering. It can be configured in seconds through the
+// CNPG_CONNECTION_RETRY_TIMEOUT environment variable, with a negative value
+// disabling the retries, and defaults to DefaultConnectionRetryTimeout.
+func GetConnectionRetryTimeout() time.Duration {
+	value := os.Getenv("CNPG_CONNECTION_RETRY_TIMEOUT")
+	if len(value) == 0 {
+		return DefaultConnectionRetryTimeout
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return DefaultConnectionRetryTimeout
+	}
+	if seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
 // buildPrimaryConnInfo builds the connection string to connect to primaryHostname
 func buildPrimaryConnInfo(primaryHostname, applicationName string) string {
 	// We should have been using configfile.CreateConnectionString
@@ -41,3 +97,94 @@ func buildPrimaryConnInfo(primaryHostname, applicationName string) string {
 		"sslmode=verify-ca"
 	return primaryConnInfo
 }
+
+// buildStandbyConnInfo builds the connection string used by a standby to
+// reach the primary through the read-write service. The passed options are
+// followed by the TCP keepalives, if enabled, and, last, by the options requested by the
+// user, to override the ones set by the operator. The cluster may be nil
+// when not known yet
+func buildStandbyConnInfo(cluster *apiv1.Cluster, clusterName, podName string, options ...string) string {
+	result := strings.Join(append([]string{buildPrimaryConnInfo(clusterName+"-rw", podName)}, options...), " ")
+
+	if cluster != nil {
+		if keepalivesConnInfo := buildKeepalivesConnInfo(cluster); keepalivesConnInfo != "" {
+			result = fmt.Sprintf("%s %s", result, keepalivesConnInfo)
+		}
+	}
+
+	standbyTCPUserTimeout := os.Getenv("CNPG_STANDBY_TCP_USER_TIMEOUT")
+	if len(standbyTCPUserTimeout) > 0 {
+		result = fmt.Sprintf("%s tcp_user_timeout=%s", result, quoteConnInfoValue(standbyTCPUserTimeout))
+	}
+
+	if cluster != nil {
+		if customConnInfo := buildCustomConnInfo(cluster); customConnInfo != "" {
+			result = fmt.Sprintf("%s %s", result, customConnInfo)
+		}
+	}
+
+	return result
+}
+
+// buildKeepalivesConnInfo builds the connection string options enabling the
+// TCP keepalives on the connection to the primary, mirroring the
+// tcp_keepalives_* parameters used by the cluster. Nothing is added unless
+// the user set any of them
+func buildKeepalivesConnInfo(cluster *apiv1.Cluster) string {
+	parameters := cluster.Spec.PostgresConfiguration.Parameters
+	if !postgres.IsTCPKeepalivesConfigured(parameters) {
+		return ""
+	}
+
+	getParameter := func(name string) string {
+		if value, ok := parameters[name]; ok {
+			return value
+		}
+		return postgres.TCPKeepalivesDefaultSettings[name]
+	}
+
+	options := []string{"keepalives=1"}
+
+	if idle, err := postgres.ParsePostgresConfigTime(
+		getParameter(postgres.ParameterTCPKeepalivesIdle), time.Second); err == nil {
+		options = append(options, fmt.Sprintf("keepalives_idle=%d", int(idle.Seconds())))
+	}
+	if interval, err := postgres.ParsePostgresConfigTime(
+		getParameter(postgres.ParameterTCPKeepalivesInterval), time.Second); err == nil {
+		options = append(options, fmt.Sprintf("keepalives_interval=%d", int(interval.Seconds())))
+	}
+	if count, err := strconv.Atoi(strings.TrimSpace(getParameter(postgres.ParameterTCPKeepalivesCount))); err == nil {
+		options = append(options, fmt.Sprintf("keepalives_count=%d", count))
+	}
+
+	return strings.Join(options, " ")
+}
+
+// buildCustomConnInfo builds the connection string options requested by the
+// user to be appended to the connection string used to reach the primary.
+// The parameters reserved for the operator are skipped, while the other ones
+// are sorted to keep the connection string stable across reconciliations
+func buildCustomConnInfo(cluster *apiv1.Cluster) string {
+	parameters := cluster.Spec.PostgresConfiguration.PrimaryConnInfoParameters
+
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		if postgres.IsPrimaryConnInfoParameterReserved(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	options := make([]string, 0, len(names))
+	for _, name := range names {
+		options = append(options, fmt.Sprintf("%s=%s", name, quoteConnInfoValue(parameters[name])))
+	}
+
+	return strings.Join(options, " ")
+}
+
+// quoteConnInfoValue quotes a value to be used in a connection string
+func quoteConnInfoValue(value string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `'`, `\'`) + "'"
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TCP keepalives of the connection to the primary", func() {
+	It("doesn't change the connection unless a tcp_keepalives_* parameter is set", func() {
+		Expect(buildKeepalivesConnInfo(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("uses the managed defaults for the tcp_keepalives_* parameters not set", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"tcp_keepalives_idle": "30",
+					},
+				},
+			},
+		}
+		Expect(buildKeepalivesConnInfo(cluster)).To(Equal(
+			"keepalives=1 keepalives_idle=30 keepalives_interval=10 keepalives_count=6"))
+	})
+
+	It("mirrors the tcp_keepalives_* parameters of the cluster", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"tcp_keepalives_idle":     "2min",
+						"tcp_keepalives_interval": "5s",
+						"tcp_keepalives_count":    "3",
+					},
+				},
+			},
+		}
+		Expect(buildKeepalivesConnInfo(cluster)).To(Equal(
+			"keepalives=1 keepalives_idle=120 keepalives_interval=5 keepalives_count=3"))
+	})
+
+	It("skips the options that cannot be parsed", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"tcp_keepalives_idle":  "soon",
+						"tcp_keepalives_count": "many",
+					},
+				},
+			},
+		}
+		Expect(buildKeepalivesConnInfo(cluster)).To(Equal("keepalives=1 keepalives_interval=10"))
+	})
+})
+
+var _ = Describe("Application name of the instance manager connections", func() {
+	It("uses the default name when no prefix is configured", func() {
+		GinkgoT().Setenv("CNPG_APPLICATION_NAME_PREFIX", "")
+		Expect(GetApplicationName("instance_manager", ApplicationNameInstanceManager)).
+			To(Equal("cnpg-instance-manager"))
+	})
+
+	It("appends the component name to the configured prefix", func() {
+		GinkgoT().Setenv("CNPG_APPLICATION_NAME_PREFIX", "cnpg_operator_")
+		Expect(GetApplicationName("instance_manager", ApplicationNameInstanceManager)).
+			To(Equal("cnpg_operator_instance_manager"))
+		Expect(GetApplicationName("metrics_exporter", ApplicationNameMetricsExporter)).
+			To(Equal("cnpg_operator_metrics_exporter"))
+	})
+})
+
+var _ = Describe("Retry timeout of the instance manager connections", func() {
+	It("uses the default timeout when not configured or invalid", func() {
+		GinkgoT().Setenv("CNPG_CONNECTION_RETRY_TIMEOUT", "")
+		Expect(GetConnectionRetryTimeout()).To(Equal(DefaultConnectionRetryTimeout))
+
+		GinkgoT().Setenv("CNPG_CONNECTION_RETRY_TIMEOUT", "ten")
+		Expect(GetConnectionRetryTimeout()).To(Equal(DefaultConnectionRetryTimeout))
+	})
+
+	It("uses the configured timeout, disabling the retries when negative", func() {
+		GinkgoT().Setenv("CNPG_CONNECTION_RETRY_TIMEOUT", "30")
+		Expect(GetConnectionRetryTimeout()).To(Equal(30 * time.Second))
+
+		GinkgoT().Setenv("CNPG_CONNECTION_RETRY_TIMEOUT", "-1")
+		Expect(GetConnectionRetryTimeout()).To(BeZero())
+	})
+})
+
+var _ = Describe("Custom options of the connection to the primary", func() {
+	It("doesn't add anything by default", func() {
+		Expect(buildCustomConnInfo(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("adds the requested options in a stable order, quoting their values", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PrimaryConnInfoParameters: map[string]string{
+						"options":          "-c statement_timeout=0",
+						"gssencmode":       "disable",
+						"application_name": `it's`,
+					},
+				},
+			},
+		}
+		Expect(buildCustomConnInfo(cluster)).To(Equal(
+			`application_name='it\'s' gssencmode='disable' options='-c statement_timeout=0'`))
+	})
+
+	It("skips the options reserved for the operator", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PrimaryConnInfoParameters: map[string]string{
+						"host":       "elsewhere",
+						"sslmode":    "disable",
+						"gssencmode": "disable",
+					},
+				},
+			},
+		}
+		Expect(buildCustomConnInfo(cluster)).To(Equal("gssencmode='disable'"))
+	})
+
+	It("adds them to the connection string used by the new replicas too", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PrimaryConnInfoParameters: map[string]string{
+						"gssencmode": "disable",
+					},
+				},
+			},
+		}
+		info := InitInfo{ClusterName: "cluster-example", PodName: "cluster-example-2"}
+		instance := NewInstance().WithClusterName("cluster-example").WithPodName("cluster-example-2")
+		instance.Cluster = cluster
+
+		primaryConnInfo := info.GetPrimaryConnInfo(cluster)
+		Expect(primaryConnInfo).To(HavePrefix(buildPrimaryConnInfo("cluster-example-rw", "cluster-example-2")))
+		Expect(primaryConnInfo).ToNot(ContainSubstring("keepalives"))
+		Expect(primaryConnInfo).To(HaveSuffix("gssencmode='disable'"))
+		Expect(instance.GetPrimaryConnInfo()).To(Equal(strings.Replace(
+			primaryConnInfo, "sslmode=verify-ca", "sslmode=verify-ca dbname=postgres", 1)))
+	})
+})
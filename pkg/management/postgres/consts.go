@@ -22,3 +22,7 @@ package postgres
 // pgWalDirectory is the name of the pg_wal directory inside
 // PGDATA
 const pgWalDirectory = "pg_wal"
+
+// defaultTransactionReadOnlyParameter is the name of the parameter used
+// to make the sessions opened on a replica read-only by default
+const defaultTransactionReadOnlyParameter = "default_transaction_read_only"
@@ -33,6 +33,7 @@ import (
 	"sort"
 	"time"
 
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
 	"github.com/cloudnative-pg/cnpg-i/pkg/postgres"
 	"github.com/cloudnative-pg/machinery/pkg/execlog"
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
@@ -40,6 +41,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	"github.com/jackc/pgx/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -50,6 +52,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logicalimport"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 )
 
@@ -483,7 +486,7 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 	// Detect an initdb bootstrap with import
 	isImportBootstrap := cluster.Spec.Bootstrap != nil &&
 		cluster.Spec.Bootstrap.InitDB != nil &&
-		cluster.Spec.Bootstrap.InitDB.Import != nil
+		(cluster.Spec.Bootstrap.InitDB.Import != nil || cluster.Spec.Bootstrap.InitDB.ImportDump != nil)
 
 	if applied, err := instance.RefreshConfigurationFilesFromCluster(
 		ctx,
@@ -497,7 +500,7 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 	}
 
 	// Prepare the managed configuration file (override.conf)
-	primaryConnInfo := info.GetPrimaryConnInfo()
+	primaryConnInfo := info.GetPrimaryConnInfo(cluster)
 
 	if isImportBootstrap {
 		// Write a special configuration for the import phase
@@ -506,7 +509,7 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 		}
 	} else {
 		// Write standard replication configuration
-		if _, err = configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, ""); err != nil {
+		if _, err = configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, "", false, 0); err != nil {
 			return fmt.Errorf("while configuring Postgres for replication: %w", err)
 		}
 	}
@@ -518,13 +521,20 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 			return fmt.Errorf("while configuring new instance: %w", err)
 		}
 
-		if isImportBootstrap {
+		if cluster.Spec.Bootstrap.InitDB.Import != nil {
 			err = executeLogicalImport(ctx, typedClient, instance, cluster)
 			if err != nil {
 				return fmt.Errorf("while executing logical import: %w", err)
 			}
 		}
 
+		if cluster.IsImportDumpBootstrap() {
+			err = executeDumpImport(ctx, typedClient, instance, cluster)
+			if err != nil {
+				return fmt.Errorf("while executing logical dump import: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -533,7 +543,7 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 	// In case of import bootstrap, we restore the standard configuration file content
 	if isImportBootstrap {
 		// Write standard replication configuration
-		if _, err = configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, ""); err != nil {
+		if _, err = configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, "", false, 0); err != nil {
 			return fmt.Errorf("while configuring Postgres for replication: %w", err)
 		}
 
@@ -572,6 +582,59 @@ func executeLogicalImport(
 	}
 }
 
+func executeDumpImport(
+	ctx context.Context,
+	client ctrl.Client,
+	instance *Instance,
+	cluster *apiv1.Cluster,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	importDump := cluster.Spec.Bootstrap.InitDB.ImportDump
+	server, ok := cluster.ExternalCluster(importDump.Source.ExternalCluster)
+	if !ok || server.BarmanObjectStore == nil {
+		return fmt.Errorf("missing barman object store configuration for source: %v",
+			importDump.Source.ExternalCluster)
+	}
+
+	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(
+		ctx,
+		client,
+		cluster.Namespace,
+		server.BarmanObjectStore,
+		os.Environ())
+	if err != nil {
+		return err
+	}
+
+	// The progress is reported in the cluster status as a best effort,
+	// as a failure to do it shouldn't prevent the import from working
+	reportProgress := func(condition metav1.Condition) {
+		if err := status.PatchConditionsWithOptimisticLock(ctx, client, cluster, condition); err != nil {
+			contextLogger.Error(err, "Error while reporting the logical dump import progress",
+				"reason", condition.Reason)
+		}
+	}
+
+	destinationPool := instance.ConnectionPool()
+	defer destinationPool.ShutdownConnections()
+
+	reportProgress(apiv1.DumpDownloadingCondition)
+	if err := logicalimport.DownloadDump(ctx, cluster, env); err != nil {
+		reportProgress(apiv1.BuildDumpImportFailedCondition(err))
+		return err
+	}
+
+	reportProgress(apiv1.DumpRestoringCondition)
+	if err := logicalimport.RestoreDump(ctx, cluster, destinationPool); err != nil {
+		reportProgress(apiv1.BuildDumpImportFailedCondition(err))
+		return err
+	}
+
+	reportProgress(apiv1.DumpImportSucceededCondition)
+	return nil
+}
+
 func getConnectionPoolerForExternalCluster(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
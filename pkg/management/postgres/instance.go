@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"math"
 	"os"
@@ -67,6 +68,7 @@ const (
 	pgIsReady         = "pg_isready"
 	pgCtlTimeout      = "40000000" // greater than one year in seconds, big enough to simulate an infinite timeout
 	pgControlDataName = "pg_controldata"
+	vacuumdbName      = "vacuumdb"
 
 	pqPingOk         = 0 // server is accepting connections
 	pqPingReject     = 1 // server is alive but rejecting connections
@@ -181,6 +183,12 @@ type Instance struct {
 	// instanceCommandChan is a channel for requesting actions on the instance
 	instanceCommandChan chan InstanceCommand
 
+	// afterFastImmediateShutdown is invoked by the lifecycle manager once
+	// PostgreSQL has been shut down on request, before the instance manager
+	// is terminated. It is set before sending the request on the command
+	// channel, which is unbuffered.
+	afterFastImmediateShutdown func(ctx context.Context)
+
 	// InstanceManagerIsUpgrading tells if there is an instance manager upgrade in process
 	InstanceManagerIsUpgrading atomic.Bool
 
@@ -193,6 +201,9 @@ type Instance struct {
 	// SmartStopDelay is used to control PostgreSQL smart shutdown timeout
 	SmartStopDelay int32
 
+	// ShutdownMode is the mode used to shut down PostgreSQL during the planned stops
+	ShutdownMode apiv1.ShutdownMode
+
 	// RequiresDesignatedPrimaryTransition indicates if this instance is a primary that needs to become
 	// a designatedPrimary
 	RequiresDesignatedPrimaryTransition bool
@@ -209,6 +220,19 @@ type Instance struct {
 	// fenced entails mightBeUnavailable ( entails as in logical consequence)
 	fenced atomic.Bool
 
+	// readinessQueryFailing specifies whether the custom query of the
+	// readiness probe is not returning the expected result
+	readinessQueryFailing atomic.Bool
+
+	// permanentlyFailed specifies whether the instance has been marked as
+	// permanently failed by the startup failure policy, and PostgreSQL is
+	// not started anymore
+	permanentlyFailed atomic.Bool
+
+	// authenticationRules is the status of the authentication rules loaded
+	// from the Secrets and ConfigMaps referenced in the cluster
+	authenticationRules atomic.Pointer[postgres.AuthenticationRulesStatus]
+
 	// slotsReplicatorChan is used to send replication slot configuration to the slot replicator
 	slotsReplicatorChan chan *apiv1.ReplicationSlotsConfiguration
 
@@ -218,6 +242,13 @@ type Instance struct {
 	// tablespaceSynchronizerChan is used to send tablespace configuration to the tablespace synchronizer
 	tablespaceSynchronizerChan chan map[string]apiv1.TablespaceConfiguration
 
+	// objectStoreCheckerChan is used to send the cluster to the checker of the object store
+	objectStoreCheckerChan chan *apiv1.Cluster
+
+	// sourceReplicationSlot is the outcome of the last attempt to manage the
+	// replication slot in the source cluster of a replica cluster
+	sourceReplicationSlot sourceReplicationSlotStatus
+
 	// StatusPortTLS enables TLS on the status port used to communicate with the operator
 	StatusPortTLS bool
 
@@ -293,6 +324,42 @@ func (instance *Instance) SetFencing(enabled bool) {
 	}
 }
 
+// IsReadinessQueryFailing checks whether the custom query of the readiness
+// probe is keeping the instance not ready
+func (instance *Instance) IsReadinessQueryFailing() bool {
+	return instance.readinessQueryFailing.Load()
+}
+
+// SetReadinessQueryFailing marks whether the custom query of the readiness
+// probe is keeping the instance not ready
+func (instance *Instance) SetReadinessQueryFailing(failing bool) {
+	instance.readinessQueryFailing.Store(failing)
+}
+
+// IsPermanentlyFailed checks whether the instance has been marked as
+// permanently failed by the startup failure policy
+func (instance *Instance) IsPermanentlyFailed() bool {
+	return instance.permanentlyFailed.Load()
+}
+
+// SetPermanentlyFailed marks whether the instance has been marked as
+// permanently failed by the startup failure policy
+func (instance *Instance) SetPermanentlyFailed(failed bool) {
+	instance.permanentlyFailed.Store(failed)
+}
+
+// GetAuthenticationRulesStatus gets the status of the authentication rules
+// loaded from the Secrets and ConfigMaps referenced in the cluster
+func (instance *Instance) GetAuthenticationRulesStatus() *postgres.AuthenticationRulesStatus {
+	return instance.authenticationRules.Load()
+}
+
+// SetAuthenticationRulesStatus sets the status of the authentication rules
+// loaded from the Secrets and ConfigMaps referenced in the cluster
+func (instance *Instance) SetAuthenticationRulesStatus(status *postgres.AuthenticationRulesStatus) {
+	instance.authenticationRules.Store(status)
+}
+
 // SetCanCheckReadiness marks whether the instance should be checked for readiness
 func (instance *Instance) SetCanCheckReadiness(enabled bool) {
 	instance.canCheckReadiness.Store(enabled)
@@ -357,6 +424,18 @@ func (instance *Instance) TablespaceSynchronizerChan() <-chan map[string]apiv1.T
 	return instance.tablespaceSynchronizerChan
 }
 
+// TriggerObjectStoreChecker sends the cluster to the checker of the object store
+func (instance *Instance) TriggerObjectStoreChecker(cluster *apiv1.Cluster) {
+	go func() {
+		instance.objectStoreCheckerChan <- cluster
+	}()
+}
+
+// ObjectStoreCheckerChan returns the communication channel to the checker of the object store
+func (instance *Instance) ObjectStoreCheckerChan() <-chan *apiv1.Cluster {
+	return instance.objectStoreCheckerChan
+}
+
 // VerifyPgDataCoherence checks the PGDATA is correctly configured in terms
 // of file rights and users
 func (instance *Instance) VerifyPgDataCoherence(ctx context.Context) error {
@@ -402,6 +481,7 @@ func NewInstance() *Instance {
 		slotsReplicatorChan:        make(chan *apiv1.ReplicationSlotsConfiguration),
 		roleSynchronizerChan:       make(chan *apiv1.ManagedConfiguration),
 		tablespaceSynchronizerChan: make(chan map[string]apiv1.TablespaceConfiguration),
+		objectStoreCheckerChan:     make(chan *apiv1.Cluster),
 	}
 }
 
@@ -559,6 +639,33 @@ func (instance *Instance) Shutdown(ctx context.Context, options shutdownOptions)
 	return nil
 }
 
+// TryShuttingDown shuts down the instance for a planned stop using the
+// configured shutdown mode. The "smart" mode, which is the default, escalates
+// to "fast" as TryShuttingDownSmartFast does, while the "fast" and "immediate"
+// modes are requested directly.
+func (instance *Instance) TryShuttingDown(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	var mode shutdownMode
+	switch instance.ShutdownMode {
+	case apiv1.ShutdownModeFast:
+		mode = shutdownModeFast
+	case apiv1.ShutdownModeImmediate:
+		mode = shutdownModeImmediate
+	default:
+		return instance.TryShuttingDownSmartFast(ctx)
+	}
+
+	contextLogger.Info("Requesting shutdown of the PostgreSQL instance", "mode", mode)
+	if err := instance.Shutdown(ctx, shutdownOptions{Mode: mode, Wait: true}); err != nil {
+		contextLogger.Error(err, "Error while shutting down the PostgreSQL instance")
+		return err
+	}
+
+	contextLogger.Info("PostgreSQL instance shut down")
+	return nil
+}
+
 // TryShuttingDownSmartFast first attempts to shut down the instance using the "smart" mode,
 // which is preceded by a CHECKPOINT. If this fails or the specified timeout expires,
 // it issues an "fast" shutdown request and waits for completion.
@@ -721,7 +828,18 @@ func (instance *Instance) Run() (*execlog.StreamingCmd, error) {
 	postgresCmd.Env = instance.buildPostgresEnv()
 	compatibility.AddInstanceRunCommands(postgresCmd)
 
-	streamingCmd, err := execlog.RunStreamingNoWait(postgresCmd, GetPostgresExecutableName())
+	// The standard error of the postmaster is also kept in the PostgreSQL
+	// log tail, as it contains the reason of a failed start up
+	logger := log.WithName(GetPostgresExecutableName())
+	streamingCmd, err := execlog.RunStreamingNoWaitWithWriter(
+		postgresCmd,
+		GetPostgresExecutableName(),
+		&execlog.LogWriter{Logger: logger.WithValues(execlog.PipeKey, execlog.StdOut)},
+		io.MultiWriter(
+			&execlog.LogWriter{Logger: logger.WithValues(execlog.PipeKey, execlog.StdErr)},
+			logpipe.PostgresTail,
+		),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -845,8 +963,8 @@ func (instance *Instance) GetPgVersion() (semver.Version, error) {
 
 // ConnectionPool gets or initializes the connection pool for this instance
 func (instance *Instance) ConnectionPool() pool.Pooler {
-	const applicationName = "cnpg-instance-manager"
 	if instance.pool == nil {
+		applicationName := GetApplicationName("instance_manager", ApplicationNameInstanceManager)
 		socketDir := GetSocketDir()
 		dsn := fmt.Sprintf(
 			"host=%s port=%v user=%v sslmode=disable application_name=%v",
@@ -856,7 +974,7 @@ func (instance *Instance) ConnectionPool() pool.Pooler {
 			applicationName,
 		)
 
-		instance.pool = pool.NewPostgresqlConnectionPool(dsn)
+		instance.pool = pool.NewPostgresqlConnectionPoolWithRetry(dsn, GetConnectionRetryTimeout())
 	}
 
 	return instance.pool
@@ -901,7 +1019,8 @@ func (instance *Instance) Demote(ctx context.Context, cluster *apiv1.Cluster) er
 
 	contextLogger.Info("Demoting instance", "pgpdata", instance.PgData)
 	slotName := cluster.GetSlotNameFromInstanceName(instance.GetPodName())
-	_, err := UpdateReplicaConfiguration(instance.PgData, instance.GetPrimaryConnInfo(), slotName)
+	_, err := UpdateReplicaConfiguration(instance.PgData, instance.GetPrimaryConnInfo(), slotName,
+		cluster.Spec.PostgresConfiguration.EnforceReplicaReadOnly, cluster.GetMinApplyDelay(instance.GetPodName()))
 	return err
 }
 
@@ -912,7 +1031,7 @@ func (instance *Instance) WaitForPrimaryAvailable(ctx context.Context) error {
 	log.Info("Waiting for the new primary to be available",
 		"primaryConnInfo", primaryConnInfo)
 
-	db, err := sql.Open("pgx", primaryConnInfo)
+	db, err := pool.NewDBConnection(primaryConnInfo, pool.ConnectionProfilePostgresql)
 	if err != nil {
 		return err
 	}
@@ -1143,7 +1262,7 @@ func (instance *Instance) Rewind(ctx context.Context) error {
 	}
 
 	// make sure restore_command is set in override.conf
-	if _, err := configurePostgresOverrideConfFile(instance.PgData, primaryConnInfo, ""); err != nil {
+	if _, err := configurePostgresOverrideConfFile(instance.PgData, primaryConnInfo, "", false, 0); err != nil {
 		return err
 	}
 
@@ -1176,6 +1295,32 @@ func (instance *Instance) Rewind(ctx context.Context) error {
 	return nil
 }
 
+// Analyze collects the statistics of every database of the instance using
+// `vacuumdb`. When inStages is true, the statistics are collected with
+// `--analyze-in-stages`, producing usable statistics faster
+func (instance *Instance) Analyze(ctx context.Context, inStages bool) error {
+	contextLogger := log.FromContext(ctx)
+
+	options := []string{
+		"-U", "postgres",
+		"--all",
+	}
+	if inStages {
+		options = append(options, "--analyze-in-stages")
+	} else {
+		options = append(options, "--analyze-only")
+	}
+
+	contextLogger.Info("Collecting the statistics of the databases", "options", options)
+
+	vacuumdbCmd := exec.CommandContext(ctx, vacuumdbName, options...) // #nosec G204
+	if err := execlog.RunStreaming(vacuumdbCmd, vacuumdbName); err != nil {
+		return fmt.Errorf("error executing vacuumdb: %w", err)
+	}
+
+	return nil
+}
+
 // PgIsReady gets the status from the pg_isready command
 func PgIsReady() error {
 	// We just use the environment variables we already have
@@ -1277,7 +1422,10 @@ func (instance *Instance) GetArchitecture() string {
 
 // RequestFastImmediateShutdown request the lifecycle manager to shut down
 // PostgreSQL using the fast strategy and then the immediate strategy.
-func (instance *Instance) RequestFastImmediateShutdown() {
+// The passed function, if not nil, is invoked once PostgreSQL is down and
+// before the lifecycle manager requests the termination of the instance manager.
+func (instance *Instance) RequestFastImmediateShutdown(afterShutdown func(ctx context.Context)) {
+	instance.afterFastImmediateShutdown = afterShutdown
 	instance.instanceCommandChan <- shutDownFastImmediate
 }
 
@@ -1397,15 +1545,7 @@ func (instance *Instance) DropConnections() error {
 
 // GetPrimaryConnInfo returns the DSN to reach the primary
 func (instance *Instance) GetPrimaryConnInfo() string {
-	result := buildPrimaryConnInfo(instance.GetClusterName()+"-rw", instance.GetPodName()) + " dbname=postgres"
-
-	standbyTCPUserTimeout := os.Getenv("CNPG_STANDBY_TCP_USER_TIMEOUT")
-	if len(standbyTCPUserTimeout) > 0 {
-		result = fmt.Sprintf("%s tcp_user_timeout='%s'", result,
-			strings.ReplaceAll(strings.ReplaceAll(standbyTCPUserTimeout, `\`, `\\`), `'`, `\'`))
-	}
-
-	return result
+	return buildStandbyConnInfo(instance.Cluster, instance.GetClusterName(), instance.GetPodName(), "dbname=postgres")
 }
 
 // HandleInstanceCommandRequests execute a command requested by the reconciliation
@@ -1436,11 +1576,15 @@ func (instance *Instance) HandleInstanceCommandRequests(
 		}
 		return false, nil
 	case restartSmartFast:
-		return true, instance.TryShuttingDownSmartFast(ctx)
+		return true, instance.TryShuttingDown(ctx)
 	case shutDownFastImmediate:
 		if err := instance.TryShuttingDownFastImmediate(ctx); err != nil {
 			contextLogger.Error(err, "error shutting down instance, proceeding")
 		}
+		if instance.afterFastImmediateShutdown != nil {
+			instance.afterFastImmediateShutdown(ctx)
+			instance.afterFastImmediateShutdown = nil
+		}
 		return false, nil
 	default:
 		return false, fmt.Errorf("unrecognized request: %s", req)
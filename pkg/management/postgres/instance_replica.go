@@ -21,12 +21,17 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
 )
 
 // RefreshReplicaConfiguration writes the PostgreSQL correct
@@ -62,10 +67,17 @@ func (instance *Instance) RefreshReplicaConfiguration(
 	return changed || result, err
 }
 
+// RemoveReplicaConfiguration removes the replication settings left in the
+// configuration of a promoted instance by the replica it was
+func (instance *Instance) RemoveReplicaConfiguration() (changed bool, err error) {
+	return removeReplicaConfiguration(instance.PgData)
+}
+
 func (instance *Instance) writeReplicaConfigurationForReplica(cluster *apiv1.Cluster) (changed bool, err error) {
 	slotName := cluster.GetSlotNameFromInstanceName(instance.GetPodName())
 	primaryConnInfo := instance.GetPrimaryConnInfo()
-	return UpdateReplicaConfiguration(instance.PgData, primaryConnInfo, slotName)
+	return UpdateReplicaConfiguration(instance.PgData, primaryConnInfo, slotName,
+		cluster.Spec.PostgresConfiguration.EnforceReplicaReadOnly, cluster.GetMinApplyDelay(instance.GetPodName()))
 }
 
 func (instance *Instance) writeReplicaConfigurationForDesignatedPrimary(
@@ -84,5 +96,161 @@ func (instance *Instance) writeReplicaConfigurationForDesignatedPrimary(
 		return false, err
 	}
 
-	return UpdateReplicaConfiguration(instance.PgData, connectionString, "")
+	return UpdateReplicaConfiguration(instance.PgData, connectionString, cluster.Spec.ReplicaCluster.PrimarySlotName,
+		cluster.Spec.PostgresConfiguration.EnforceReplicaReadOnly, 0)
+}
+
+// sourceReplicationSlotTimeout is the maximum time spent managing the
+// replication slot in the source cluster, so that an unreachable source
+// doesn't stall the reconciliation loop
+const sourceReplicationSlotTimeout = 5 * time.Second
+
+// sourceReplicationSlotRetryInterval is the time to wait before contacting
+// the source cluster again after a failure
+const sourceReplicationSlotRetryInterval = time.Minute
+
+// sourceReplicationSlotStatus is the outcome of the last attempt to manage
+// the replication slot in the source cluster
+type sourceReplicationSlotStatus struct {
+	// configuration is the configuration of the slot that was reconciled
+	configuration string
+
+	// failedAt is the time of the failure, or zero if the attempt succeeded
+	failedAt time.Time
+}
+
+// ReconcileSourceReplicationSlot manages, in the source cluster, the
+// physical replication slot used by the designated primary, if it has been
+// configured. The slot is created, unless the designated primary is already
+// streaming with it, and dropped once the replica cluster has been promoted.
+// The source is contacted again only when the configuration changes, or
+// after sourceReplicationSlotRetryInterval when the previous attempt failed.
+func (instance *Instance) ReconcileSourceReplicationSlot(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) error {
+	if cluster.Spec.ReplicaCluster == nil || cluster.Spec.ReplicaCluster.PrimarySlotName == "" ||
+		cluster.Status.CurrentPrimary != instance.GetPodName() {
+		return nil
+	}
+
+	slotName := cluster.Spec.ReplicaCluster.PrimarySlotName
+	promoted := !cluster.IsReplica()
+	server, ok := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+	if !ok {
+		return fmt.Errorf("missing external cluster")
+	}
+
+	configuration, err := json.Marshal(struct {
+		Server   apiv1.ExternalCluster `json:"server"`
+		SlotName string                `json:"slotName"`
+		Promoted bool                  `json:"promoted"`
+	}{Server: server, SlotName: slotName, Promoted: promoted})
+	if err != nil {
+		return err
+	}
+
+	status := instance.sourceReplicationSlot
+	if status.configuration == string(configuration) &&
+		(status.failedAt.IsZero() || time.Since(status.failedAt) < sourceReplicationSlotRetryInterval) {
+		return nil
+	}
+
+	if !promoted {
+		// an active WAL receiver implies the slot already exists
+		active, err := instance.IsWALReceiverActive()
+		if err != nil {
+			return err
+		}
+		if active {
+			instance.sourceReplicationSlot = sourceReplicationSlotStatus{configuration: string(configuration)}
+			return nil
+		}
+	}
+
+	if err := instance.reconcileSourceReplicationSlot(ctx, cli, &server, slotName, promoted); err != nil {
+		instance.sourceReplicationSlot = sourceReplicationSlotStatus{
+			configuration: string(configuration),
+			failedAt:      time.Now(),
+		}
+		return err
+	}
+
+	instance.sourceReplicationSlot = sourceReplicationSlotStatus{configuration: string(configuration)}
+	return nil
+}
+
+// reconcileSourceReplicationSlot connects to the source cluster, creating
+// the replication slot or, when the replica cluster has been promoted,
+// dropping it
+func (instance *Instance) reconcileSourceReplicationSlot(
+	ctx context.Context,
+	cli client.Client,
+	server *apiv1.ExternalCluster,
+	slotName string,
+	promoted bool,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, sourceReplicationSlotTimeout)
+	defer cancel()
+
+	modifiedServer := server.DeepCopy()
+	delete(modifiedServer.ConnectionParameters, "dbname")
+	if modifiedServer.ConnectionParameters == nil {
+		modifiedServer.ConnectionParameters = make(map[string]string)
+	}
+	if _, ok := modifiedServer.ConnectionParameters["connect_timeout"]; !ok {
+		modifiedServer.ConnectionParameters["connect_timeout"] = strconv.Itoa(
+			int(sourceReplicationSlotTimeout.Seconds()))
+	}
+
+	connectionString, err := external.ConfigureConnectionToServer(
+		ctx, cli, instance.GetNamespaceName(), modifiedServer)
+	if err != nil {
+		return err
+	}
+
+	sourcePool := pool.NewPostgresqlConnectionPool(connectionString)
+	defer sourcePool.ShutdownConnections()
+
+	db, err := sourcePool.Connection("postgres")
+	if err != nil {
+		return fmt.Errorf("while connecting to the source cluster: %w", err)
+	}
+
+	if promoted {
+		return dropSourceReplicationSlot(ctx, db, slotName)
+	}
+	return createSourceReplicationSlot(ctx, db, slotName)
+}
+
+// createSourceReplicationSlot creates the passed physical replication slot,
+// reserving the WAL immediately, unless it already exists
+func createSourceReplicationSlot(ctx context.Context, db *sql.DB, slotName string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		`SELECT pg_catalog.pg_create_physical_replication_slot($1, true)
+		WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_replication_slots WHERE slot_name = $1)`,
+		slotName,
+	); err != nil {
+		return fmt.Errorf("while creating the replication slot %q in the source cluster: %w", slotName, err)
+	}
+
+	return nil
+}
+
+// dropSourceReplicationSlot drops the passed physical replication slot, if
+// it exists and nobody is streaming with it anymore
+func dropSourceReplicationSlot(ctx context.Context, db *sql.DB, slotName string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		`SELECT pg_catalog.pg_drop_replication_slot(slot_name)
+		FROM pg_catalog.pg_replication_slots
+		WHERE slot_name = $1 AND slot_type = 'physical' AND NOT active`,
+		slotName,
+	); err != nil {
+		return fmt.Errorf("while dropping the replication slot %q in the source cluster: %w", slotName, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,117 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replication slot in the source cluster", func() {
+	const createSlotQuery = "SELECT pg_catalog.pg_create_physical_replication_slot\\(\\$1, true\\)"
+
+	It("creates the slot unless it already exists", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectExec(createSlotQuery).WithArgs("downstream").WillReturnResult(sqlmock.NewResult(0, 1))
+		Expect(createSourceReplicationSlot(ctx, db, "downstream")).To(Succeed())
+
+		mock.ExpectExec(createSlotQuery).WithArgs("downstream").WillReturnError(errors.New("permission denied"))
+		Expect(createSourceReplicationSlot(ctx, db, "downstream")).To(MatchError(ContainSubstring("downstream")))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("drops the slot unless it is still active", func(ctx SpecContext) {
+		const dropSlotQuery = "SELECT pg_catalog.pg_drop_replication_slot\\(slot_name\\)"
+
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectExec(dropSlotQuery).WithArgs("downstream").WillReturnResult(sqlmock.NewResult(0, 1))
+		Expect(dropSourceReplicationSlot(ctx, db, "downstream")).To(Succeed())
+
+		mock.ExpectExec(dropSlotQuery).WithArgs("downstream").WillReturnError(errors.New("permission denied"))
+		Expect(dropSourceReplicationSlot(ctx, db, "downstream")).To(MatchError(ContainSubstring("downstream")))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't contact the source when no slot has been configured", func(ctx SpecContext) {
+		instance := NewInstance().WithPodName("cluster-example-1")
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Enabled: ptr.To(true),
+					Source:  "origin",
+				},
+			},
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).To(Succeed())
+
+		cluster.Spec.ReplicaCluster.PrimarySlotName = "downstream"
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).To(Succeed())
+	})
+
+	It("contacts the source again only when the configuration changes", func(ctx SpecContext) {
+		instance := NewInstance().WithPodName("cluster-example-1")
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Enabled:         ptr.To(false),
+					Source:          "origin",
+					PrimarySlotName: "downstream",
+				},
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name:                 "origin",
+						ConnectionParameters: map[string]string{"host": "origin-rw.invalid"},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+
+		// the source host can't be resolved, so the first attempt fails
+		// and the failure is remembered
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).ToNot(Succeed())
+		Expect(instance.sourceReplicationSlot.failedAt).ToNot(BeZero())
+
+		// the source isn't contacted again before the retry interval
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).To(Succeed())
+
+		// a successful attempt isn't repeated
+		instance.sourceReplicationSlot.failedAt = time.Time{}
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).To(Succeed())
+
+		// changing the configuration makes the instance contact the source
+		cluster.Spec.ReplicaCluster.PrimarySlotName = "other"
+		Expect(instance.ReconcileSourceReplicationSlot(ctx, nil, cluster)).ToNot(Succeed())
+	})
+})
@@ -233,6 +233,28 @@ var _ = Describe("check atomic bool", func() {
 	})
 })
 
+var _ = Describe("fast immediate shutdown requests", func() {
+	It("runs the requested function after the shutdown, only once", func(ctx SpecContext) {
+		instance := NewInstance()
+		instance.PgData = GinkgoT().TempDir()
+
+		invocations := 0
+		go instance.RequestFastImmediateShutdown(func(context.Context) {
+			invocations++
+		})
+
+		req := <-instance.GetInstanceCommandChan()
+		restartNeeded, err := instance.HandleInstanceCommandRequests(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restartNeeded).To(BeFalse())
+		Expect(invocations).To(Equal(1))
+
+		_, err = instance.HandleInstanceCommandRequests(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(invocations).To(Equal(1))
+	})
+})
+
 var _ = Describe("ALTER SYSTEM enable and disable in PostgreSQL <17", func() {
 	var instance Instance
 	var autoConfFile string
@@ -92,6 +92,7 @@ func (info InitInfo) Join(ctx context.Context, cluster *apiv1.Cluster) error {
 	}
 
 	slotName := cluster.GetSlotNameFromInstanceName(info.PodName)
-	_, err := UpdateReplicaConfiguration(info.PgData, info.GetPrimaryConnInfo(), slotName)
+	_, err := UpdateReplicaConfiguration(info.PgData, info.GetPrimaryConnInfo(cluster), slotName,
+		cluster.Spec.PostgresConfiguration.EnforceReplicaReadOnly, cluster.GetMinApplyDelay(info.PodName))
 	return err
 }
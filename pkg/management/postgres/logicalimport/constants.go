@@ -34,6 +34,8 @@ type (
 const (
 	pgDump           executable = "pg_dump"
 	pgRestore        executable = "pg_restore"
+	psql             executable = "psql"
+	python           executable = "python3"
 	postgresDatabase            = "postgres"
 	dumpDirectory               = specs.PgDataPath + "/dumps"
 )
@@ -365,7 +365,7 @@ func (ds *databaseSnapshotter) dropExtensionsFromDatabase(
 // based on the configuration of the cluster. It returns a slice of strings representing
 // the sections to execute. These sections are labeled as "pre-data", "data", and "post-data".
 func (ds *databaseSnapshotter) getSectionsToExecute() []section {
-	if ds.cluster.Spec.Bootstrap.InitDB.Import.SchemaOnly {
+	if importSpec := ds.cluster.Spec.Bootstrap.InitDB.Import; importSpec != nil && importSpec.SchemaOnly {
 		return []section{
 			sectionPreData,
 			sectionPostData,
@@ -0,0 +1,243 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	barmanCommand "github.com/cloudnative-pg/barman-cloud/pkg/command"
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+)
+
+// barmanCloudDownloadScript downloads a single object from an object store
+// through the cloud interface of barman-cloud, which is shipped with the
+// operand images and already knows how to deal with every supported provider.
+// The barman-cloud commands can only download WAL files and base backups,
+// so the script relies on the argument parser and the cloud interface shared
+// by all of them, and fails with a clear error when the installed version
+// doesn't provide them.
+// It accepts the same arguments of the barman-cloud commands, followed by the
+// path of the object, relative to the destination path, and the local path
+// where the object should be written.
+const barmanCloudDownloadScript = `
+import posixpath
+import sys
+
+try:
+    from barman.clients.cloud_cli import create_argument_parser
+    from barman.cloud_providers import get_cloud_interface
+except ImportError as error:
+    sys.exit("unsupported barman-cloud version, can't download the dump: %s" % error)
+
+parser, _, _ = create_argument_parser(description="Download a file from the object store")
+parser.add_argument("object_path")
+parser.add_argument("local_path")
+config = parser.parse_args()
+
+cloud_interface = get_cloud_interface(config)
+key = posixpath.join(cloud_interface.path or "", config.object_path)
+cloud_interface.download_file(key, config.local_path, None)
+`
+
+// DownloadDump downloads the logical dump referenced by the `importDump`
+// section of the cluster into the dumps directory. The passed environment
+// needs to contain the credentials to access the object store.
+func DownloadDump(ctx context.Context, cluster *apiv1.Cluster, env []string) error {
+	contextLogger := log.FromContext(ctx)
+	initDB := cluster.Spec.Bootstrap.InitDB
+
+	server, found := cluster.ExternalCluster(initDB.ImportDump.Source.ExternalCluster)
+	if !found {
+		return fmt.Errorf("missing external cluster: %v", initDB.ImportDump.Source.ExternalCluster)
+	}
+	if server.BarmanObjectStore == nil {
+		return fmt.Errorf("missing barman object store configuration for source: %v", server.Name)
+	}
+
+	if err := createDumpsDirectory(); err != nil {
+		return err
+	}
+
+	options, err := buildDownloadDumpOptions(ctx, server, initDB.ImportDump.Path,
+		generateFileNameForDatabase(initDB.Database))
+	if err != nil {
+		return err
+	}
+
+	contextLogger.Info("Downloading the logical dump from the object store",
+		"destinationPath", server.BarmanObjectStore.DestinationPath,
+		"path", initDB.ImportDump.Path)
+
+	downloadCommand := exec.Command(python, options...) // #nosec
+	downloadCommand.Env = env
+	if err := execlog.RunStreaming(downloadCommand, python); err != nil {
+		return fmt.Errorf("error while downloading the logical dump: %w", err)
+	}
+
+	return nil
+}
+
+func buildDownloadDumpOptions(
+	ctx context.Context,
+	server apiv1.ExternalCluster,
+	objectPath string,
+	localPath string,
+) ([]string, error) {
+	options := []string{"-c", barmanCloudDownloadScript}
+	if len(server.BarmanObjectStore.EndpointURL) > 0 {
+		options = append(options, "--endpoint-url", server.BarmanObjectStore.EndpointURL)
+	}
+
+	options, err := barmanCommand.AppendCloudProviderOptionsFromConfiguration(ctx, options, server.BarmanObjectStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(
+		options,
+		server.BarmanObjectStore.DestinationPath,
+		server.GetServerName(),
+		objectPath,
+		localPath,
+	), nil
+}
+
+// RestoreDump restores the logical dump previously downloaded by DownloadDump
+// into the application database
+func RestoreDump(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	destination pool.Pooler,
+) error {
+	contextLogger := log.FromContext(ctx)
+	ds := databaseSnapshotter{cluster: cluster}
+	initDB := cluster.Spec.Bootstrap.InitDB
+
+	contextLogger.Info("starting logical dump restore process",
+		"format", initDB.ImportDump.GetFormat())
+
+	if err := ds.dropExtensionsFromDatabase(
+		ctx,
+		destination,
+		initDB.Database,
+	); err != nil {
+		return err
+	}
+
+	switch format := initDB.ImportDump.GetFormat(); format {
+	case apiv1.ImportDumpFormatPlain:
+		if err := ds.importPlainDatabaseContent(
+			ctx,
+			destination,
+			initDB.Database,
+			initDB.Owner,
+		); err != nil {
+			return err
+		}
+	case apiv1.ImportDumpFormatCustom:
+		if err := ds.importDatabaseContent(
+			ctx,
+			destination,
+			initDB.Database,
+			initDB.Database,
+			initDB.Owner,
+			sectionOptions{fallbackOptions: initDB.ImportDump.PgRestoreExtraOptions},
+		); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unrecognized dump format %s", format)
+	}
+
+	if err := cleanDumpDirectory(); err != nil {
+		return err
+	}
+
+	return ds.analyze(ctx, destination, []string{initDB.Database})
+}
+
+// importPlainDatabaseContent executes a plain-text SQL script produced by
+// `pg_dump` in the target database, with the privileges of the owner
+func (ds *databaseSnapshotter) importPlainDatabaseContent(
+	ctx context.Context,
+	target pool.Pooler,
+	targetDatabase string,
+	owner string,
+) (err error) {
+	contextLogger := log.FromContext(ctx)
+
+	// The script may contain "CREATE EXTENSION" and/or "COMMENT ON EXTENSION"
+	// commands, and to execute them we'll generically need to be superusers
+	// on the target database.
+	contextLogger.Info("temporarily granting superuser permission to owner user",
+		"owner", owner)
+
+	db, err := target.Connection(targetDatabase)
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.Exec(fmt.Sprintf("ALTER USER %s SUPERUSER", pgx.Identifier{owner}.Sanitize())); err != nil {
+		return err
+	}
+
+	// The permission is removed even if the script fails, as the owner
+	// must not be left with superuser privileges
+	defer func() {
+		contextLogger.Info("removing superuser permission from owner user",
+			"owner", owner)
+		_, revokeErr := db.Exec(fmt.Sprintf("ALTER USER %s NOSUPERUSER", pgx.Identifier{owner}.Sanitize()))
+		if revokeErr == nil {
+			return
+		}
+		if err != nil {
+			contextLogger.Error(revokeErr, "while removing superuser permission from owner user",
+				"owner", owner)
+			return
+		}
+		err = revokeErr
+	}()
+
+	options := []string{
+		"-U", "postgres",
+		"-d", targetDatabase,
+		"-v", "ON_ERROR_STOP=1",
+		"-c", fmt.Sprintf("SET ROLE %s", pgx.Identifier{owner}.Sanitize()),
+		"-f", generateFileNameForDatabase(targetDatabase),
+	}
+
+	contextLogger.Info("Running psql",
+		"cmd", psql,
+		"options", options)
+
+	psqlCommand := exec.Command(psql, options...) // #nosec
+	if err := execlog.RunStreaming(psqlCommand, psql); err != nil {
+		return fmt.Errorf("error while executing the SQL script: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	barmanApi "github.com/cloudnative-pg/barman-cloud/pkg/api"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("logical dump download options", func() {
+	It("passes the object store configuration to barman-cloud", func(ctx SpecContext) {
+		server := apiv1.ExternalCluster{
+			Name: "dumps",
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/dumps",
+				EndpointURL:     "https://minio:9000",
+				BarmanCredentials: barmanApi.BarmanCredentials{
+					AWS: &barmanApi.S3Credentials{},
+				},
+			},
+		}
+
+		options, err := buildDownloadDumpOptions(ctx, server, "2024/app.dump", "/tmp/app.dump")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal([]string{
+			"-c", barmanCloudDownloadScript,
+			"--endpoint-url", "https://minio:9000",
+			"--cloud-provider", "aws-s3",
+			"s3://bucket/dumps",
+			"dumps",
+			"2024/app.dump",
+			"/tmp/app.dump",
+		}))
+	})
+
+	It("uses the server name of the object store when specified", func(ctx SpecContext) {
+		server := apiv1.ExternalCluster{
+			Name: "dumps",
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/dumps",
+				ServerName:      "origin",
+			},
+		}
+
+		options, err := buildDownloadDumpOptions(ctx, server, "app.sql", "/tmp/app.sql")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options[len(options)-4:]).To(Equal([]string{
+			"s3://bucket/dumps",
+			"origin",
+			"app.sql",
+			"/tmp/app.sql",
+		}))
+	})
+})
+
+var _ = Describe("plain logical dump import", func() {
+	It("removes the superuser permission from the owner when the script fails", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectExec(`ALTER USER "app" SUPERUSER`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`ALTER USER "app" NOSUPERUSER`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// the dump file doesn't exist, so the script fails
+		ds := databaseSnapshotter{}
+		err = ds.importPlainDatabaseContent(ctx, fakePooler{db: db}, "app-not-existing", "app")
+		Expect(err).To(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,208 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+
+	barmanCommand "github.com/cloudnative-pg/barman-cloud/pkg/command"
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+)
+
+// barmanCloudUploadScript is the counterpart of barmanCloudDownloadScript,
+// uploading the content of its standard input to the object store. It accepts
+// the same arguments of the barman-cloud commands, followed by the path of the
+// object, relative to the destination path.
+const barmanCloudUploadScript = `
+import posixpath
+import sys
+
+try:
+    from barman.clients.cloud_cli import create_argument_parser
+    from barman.cloud_providers import get_cloud_interface
+except ImportError as error:
+    sys.exit("unsupported barman-cloud version, can't upload the dump: %s" % error)
+
+parser, _, _ = create_argument_parser(description="Upload a file to the object store")
+parser.add_argument("object_path")
+config = parser.parse_args()
+
+cloud_interface = get_cloud_interface(config)
+key = posixpath.join(cloud_interface.path or "", config.object_path)
+cloud_interface.upload_fileobj(sys.stdin.buffer, key)
+`
+
+// GetExportObjectPath returns the path, relative to the destination path of
+// the object store, where the logical dump of a database taken by a backup
+// is uploaded. It can be used as the `path` of an `importDump` section.
+func GetExportObjectPath(serverName, backupName, database string) string {
+	return path.Join(serverName, "dumps", backupName, database+".dump")
+}
+
+// ExportDump takes a custom-format logical dump of a single database with
+// `pg_dump` and streams it to the passed object store, in the path returned
+// by GetExportObjectPath, without storing it in the instance volumes. The
+// passed environment needs to contain the credentials to access the object
+// store. It returns the path of the object and the size of the dump in bytes.
+func ExportDump(
+	ctx context.Context,
+	source pool.Pooler,
+	configuration *apiv1.BackupPgDumpConfiguration,
+	barmanConfiguration *apiv1.BarmanObjectStoreConfiguration,
+	serverName string,
+	backupName string,
+	env []string,
+) (string, int64, error) {
+	contextLogger := log.FromContext(ctx)
+
+	objectPath := GetExportObjectPath(serverName, backupName, configuration.Database)
+	uploadOptions, err := buildUploadDumpOptions(ctx, barmanConfiguration, serverName, objectPath)
+	if err != nil {
+		return "", 0, err
+	}
+
+	dumpOptions := buildExportDumpOptions(source.GetDsn(configuration.Database), configuration)
+	contextLogger.Info("Running pg_dump, streaming the logical dump to the object store",
+		"cmd", pgDump,
+		"options", dumpOptions,
+		"destinationPath", barmanConfiguration.DestinationPath,
+		"path", objectPath)
+
+	pgDumpCommand := exec.Command(pgDump, dumpOptions...)   // #nosec
+	uploadCommand := exec.Command(python, uploadOptions...) // #nosec
+	uploadCommand.Env = env
+	size, err := streamCommandOutput(pgDumpCommand, pgDump, uploadCommand, python)
+	if err != nil {
+		return "", 0, fmt.Errorf("error while exporting the logical dump: %w", err)
+	}
+
+	return objectPath, size, nil
+}
+
+// streamCommandOutput runs the source command, writing its standard output
+// to the standard input of the sink command. If the source command fails,
+// the sink command is killed before reaching the end of its input, so that
+// it can't take a partial output as a complete one. It returns the number
+// of bytes streamed.
+func streamCommandOutput(source *exec.Cmd, sourceName string, sink *exec.Cmd, sinkName string) (int64, error) {
+	sinkInput, err := sink.StdinPipe()
+	if err != nil {
+		return 0, err
+	}
+	sinkStreaming, err := execlog.RunStreamingNoWait(sink, sinkName)
+	if err != nil {
+		return 0, err
+	}
+	abortSink := func() {
+		_ = sink.Process.Kill()
+		_ = sinkInput.Close()
+		_ = sinkStreaming.Wait()
+	}
+
+	sourceOutput, err := source.StdoutPipe()
+	if err != nil {
+		abortSink()
+		return 0, err
+	}
+	source.Stderr = &execlog.LogWriter{
+		Logger: log.WithName(sourceName).WithValues(execlog.PipeKey, execlog.StdErr),
+	}
+	if err := source.Start(); err != nil {
+		abortSink()
+		return 0, err
+	}
+
+	size, err := io.Copy(sinkInput, sourceOutput)
+	if err != nil {
+		// the sink stopped reading its input, and the source
+		// would block writing its output
+		_ = source.Process.Kill()
+		_ = source.Wait()
+		abortSink()
+		return 0, fmt.Errorf("while streaming the output of %s to %s: %w", sourceName, sinkName, err)
+	}
+
+	if err := source.Wait(); err != nil {
+		abortSink()
+		return 0, fmt.Errorf("error in %s: %w", sourceName, err)
+	}
+
+	if err := sinkInput.Close(); err != nil {
+		abortSink()
+		return 0, err
+	}
+	if err := sinkStreaming.Wait(); err != nil {
+		return 0, fmt.Errorf("error in %s: %w", sinkName, err)
+	}
+
+	return size, nil
+}
+
+func buildExportDumpOptions(
+	dsn string,
+	configuration *apiv1.BackupPgDumpConfiguration,
+) []string {
+	options := []string{
+		"-Fc",
+		"-d", dsn,
+		"-v",
+	}
+
+	switch {
+	case configuration.SchemaOnly:
+		options = append(options, "--schema-only")
+	case configuration.DataOnly:
+		options = append(options, "--data-only")
+	}
+
+	return options
+}
+
+func buildUploadDumpOptions(
+	ctx context.Context,
+	barmanConfiguration *apiv1.BarmanObjectStoreConfiguration,
+	serverName string,
+	objectPath string,
+) ([]string, error) {
+	options := []string{"-c", barmanCloudUploadScript}
+	if len(barmanConfiguration.EndpointURL) > 0 {
+		options = append(options, "--endpoint-url", barmanConfiguration.EndpointURL)
+	}
+
+	options, err := barmanCommand.AppendCloudProviderOptionsFromConfiguration(ctx, options, barmanConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(
+		options,
+		barmanConfiguration.DestinationPath,
+		serverName,
+		objectPath,
+	), nil
+}
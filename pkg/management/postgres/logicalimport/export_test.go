@@ -0,0 +1,106 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	barmanApi "github.com/cloudnative-pg/barman-cloud/pkg/api"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("logical dump export", func() {
+	It("stores the dump under the server name of the object store", func() {
+		Expect(GetExportObjectPath("cluster-example", "dump-app", "app")).
+			To(Equal("cluster-example/dumps/dump-app/app.dump"))
+	})
+
+	It("takes a custom-format dump of the whole database by default", func() {
+		options := buildExportDumpOptions("dbname=app", &apiv1.BackupPgDumpConfiguration{Database: "app"})
+		Expect(options).To(Equal([]string{
+			"-Fc",
+			"-d", "dbname=app",
+			"-v",
+		}))
+	})
+
+	It("dumps only the schema or only the data when requested", func() {
+		options := buildExportDumpOptions("dbname=app",
+			&apiv1.BackupPgDumpConfiguration{Database: "app", SchemaOnly: true})
+		Expect(options).To(ContainElement("--schema-only"))
+		Expect(options).ToNot(ContainElement("--data-only"))
+
+		options = buildExportDumpOptions("dbname=app",
+			&apiv1.BackupPgDumpConfiguration{Database: "app", DataOnly: true})
+		Expect(options).To(ContainElement("--data-only"))
+		Expect(options).ToNot(ContainElement("--schema-only"))
+	})
+
+	It("passes the object store configuration to barman-cloud", func(ctx SpecContext) {
+		configuration := &apiv1.BarmanObjectStoreConfiguration{
+			DestinationPath: "s3://bucket/backups",
+			EndpointURL:     "https://minio:9000",
+			BarmanCredentials: barmanApi.BarmanCredentials{
+				AWS: &barmanApi.S3Credentials{},
+			},
+		}
+
+		options, err := buildUploadDumpOptions(ctx, configuration, "cluster-example",
+			"cluster-example/dumps/dump-app/app.dump")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal([]string{
+			"-c", barmanCloudUploadScript,
+			"--endpoint-url", "https://minio:9000",
+			"--cloud-provider", "aws-s3",
+			"s3://bucket/backups",
+			"cluster-example",
+			"cluster-example/dumps/dump-app/app.dump",
+		}))
+	})
+
+	It("streams the output of the dump to the uploader", func() {
+		destination := filepath.Join(GinkgoT().TempDir(), "app.dump")
+
+		size, err := streamCommandOutput(
+			exec.Command("sh", "-c", "echo dump-content"), "source",
+			exec.Command("sh", "-c", "cat > "+destination), "sink")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(BeEquivalentTo(len("dump-content\n")))
+		Expect(os.ReadFile(destination)).To(BeEquivalentTo("dump-content\n"))
+	})
+
+	It("doesn't let the uploader complete when the dump fails", func() {
+		directory := GinkgoT().TempDir()
+		partial := filepath.Join(directory, "partial")
+		destination := filepath.Join(directory, "app.dump")
+
+		_, err := streamCommandOutput(
+			exec.Command("sh", "-c", "echo dump-content; exit 1"), "source",
+			exec.Command("sh", "-c", "cat > "+partial+" && mv "+partial+" "+destination), "sink")
+		Expect(err).To(MatchError(ContainSubstring("error in source")))
+		Expect(destination).ToNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,81 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logpipe
+
+import (
+	"strings"
+	"sync"
+)
+
+// defaultTailSize is the number of lines kept by PostgresTail
+const defaultTailSize = 10
+
+// PostgresTail contains the latest lines logged by PostgreSQL, both on
+// its standard error and through the logging collector
+var PostgresTail = NewTail(defaultTailSize)
+
+// Tail keeps in memory the latest lines of a log stream, so that they
+// can be reported when a process fails
+type Tail struct {
+	mu    sync.Mutex
+	size  int
+	lines []string
+}
+
+// NewTail creates a new Tail keeping the given number of lines
+func NewTail(size int) *Tail {
+	return &Tail{
+		size:  size,
+		lines: make([]string, 0, size),
+	}
+}
+
+// Write implements the io.Writer interface, adding a line to the tail
+func (t *Tail) Write(p []byte) (int, error) {
+	t.Add(string(p))
+	return len(p), nil
+}
+
+// Add adds a line to the tail, discarding the oldest one when full
+func (t *Tail) Add(line string) {
+	line = strings.TrimRight(line, "\n")
+	if line == "" || t.size <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.lines) == t.size {
+		copy(t.lines, t.lines[1:])
+		t.lines = t.lines[:t.size-1]
+	}
+	t.lines = append(t.lines, line)
+}
+
+// Lines returns the lines in the tail, from the oldest to the newest one
+func (t *Tail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]string, len(t.lines))
+	copy(result, t.lines)
+	return result
+}
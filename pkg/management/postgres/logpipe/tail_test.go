@@ -0,0 +1,45 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logpipe
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("log tail", func() {
+	It("keeps the latest lines", func() {
+		tail := NewTail(2)
+		tail.Add("first")
+		Expect(tail.Lines()).To(Equal([]string{"first"}))
+
+		_, err := tail.Write([]byte("second\n"))
+		Expect(err).ToNot(HaveOccurred())
+		tail.Add("third")
+		Expect(tail.Lines()).To(Equal([]string{"second", "third"}))
+	})
+
+	It("ignores empty lines", func() {
+		tail := NewTail(2)
+		tail.Add("")
+		tail.Add("\n")
+		Expect(tail.Lines()).To(BeEmpty())
+	})
+})
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package logpipe
 
 import (
+	"fmt"
+
 	"github.com/cloudnative-pg/machinery/pkg/log"
 )
 
@@ -39,4 +41,8 @@ type LogRecordWriter struct{}
 // Write writes the PostgreSQL log record to the instance manager logger
 func (writer *LogRecordWriter) Write(record NamedRecord) {
 	log.WithName(record.GetName()).Info(logRecordKey, logRecordKey, record)
+
+	if loggingRecord, ok := record.(*LoggingRecord); ok && loggingRecord.Message != "" {
+		PostgresTail.Add(fmt.Sprintf("%s: %s", loggingRecord.ErrorSeverity, loggingRecord.Message))
+	}
 }
@@ -480,7 +480,10 @@ func createMonitoringTx(conn *sql.DB) (*sql.Tx, error) {
 	}()
 
 	// Set the application name
-	_, err = tx.Exec("SET application_name TO cnpg_metrics_exporter")
+	_, err = tx.Exec(
+		"SELECT set_config('application_name', $1, false)",
+		postgres.GetApplicationName("metrics_exporter", postgres.ApplicationNameMetricsExporter),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,7 @@ package pool
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	// this is needed to correctly open the sql connection with the pgx driver. Do not remove this import.
@@ -43,3 +44,20 @@ func NewDBConnection(connectionString string, profile ConnectionProfile) (*sql.D
 
 	return sql.Open("pgx", stdlib.RegisterConnConfig(conf))
 }
+
+// newRetryingDBConnection creates a postgres connection like NewDBConnection,
+// retrying for up to retryTimeout the connections refused because
+// PostgreSQL is starting up or recovering
+func newRetryingDBConnection(
+	connectionString string,
+	profile ConnectionProfile,
+	retryTimeout time.Duration,
+) (*sql.DB, error) {
+	conf, err := pgx.ParseConfig(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	profile.Enrich(conf)
+
+	return sql.OpenDB(newRetryingConnector(stdlib.GetConnector(*conf), retryTimeout)), nil
+}
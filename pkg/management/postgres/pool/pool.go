@@ -25,6 +25,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sync"
+	"time"
 
 	// this is needed to correctly open the sql connection with the pgx driver
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -51,6 +52,10 @@ type ConnectionPool struct {
 	// The configuration to be used
 	connectionProfile ConnectionProfile
 
+	// The maximum time spent retrying a connection while PostgreSQL is
+	// not accepting connections yet. Zero disables the retries.
+	connectionRetryTimeout time.Duration
+
 	// A map of connection for every used database
 	connectionMap      map[string]*sql.DB
 	connectionMapMutex sync.Mutex
@@ -62,6 +67,16 @@ func NewPostgresqlConnectionPool(baseConnectionString string) *ConnectionPool {
 	return newConnectionPool(baseConnectionString, ConnectionProfilePostgresql)
 }
 
+// NewPostgresqlConnectionPoolWithRetry creates a new connectionMap of
+// connections given the base connection string, targeting a PostgreSQL
+// server. The connections refused while the server is starting up or
+// recovering are retried for up to retryTimeout.
+func NewPostgresqlConnectionPoolWithRetry(baseConnectionString string, retryTimeout time.Duration) *ConnectionPool {
+	pool := newConnectionPool(baseConnectionString, ConnectionProfilePostgresql)
+	pool.connectionRetryTimeout = retryTimeout
+	return pool
+}
+
 // NewPgbouncerConnectionPool creates a new connectionMap of connections given
 // the base connection string
 func NewPgbouncerConnectionPool(baseConnectionString string) *ConnectionPool {
@@ -111,7 +126,14 @@ func (pool *ConnectionPool) ShutdownConnections() {
 // Unix domain socket to a database with a certain name
 func (pool *ConnectionPool) newConnection(dbname string) (*sql.DB, error) {
 	dsn := pool.GetDsn(dbname)
-	db, err := NewDBConnection(dsn, pool.connectionProfile)
+
+	var db *sql.DB
+	var err error
+	if pool.connectionRetryTimeout > 0 {
+		db, err = newRetryingDBConnection(dsn, pool.connectionProfile, pool.connectionRetryTimeout)
+	} else {
+		db, err = NewDBConnection(dsn, pool.connectionProfile)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("cannot create connection connectionMap: %w", err)
 	}
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package pool
 
 import (
+	"time"
+
 	_ "github.com/lib/pq"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -36,6 +38,15 @@ var _ = Describe("Connection pool test", func() {
 		_ = conn.Close()
 	})
 
+	It("can create a new connection retrying while PostgreSQL is starting up", func() {
+		pool := NewPostgresqlConnectionPoolWithRetry("host=127.0.0.1", 10*time.Second)
+
+		conn, err := pool.newConnection("test")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conn).ToNot(BeNil())
+		_ = conn.Close()
+	})
+
 	It("is initially empty", func() {
 		pool := NewPostgresqlConnectionPool("host=127.0.0.1")
 		Expect(pool.connectionMap).To(BeEmpty())
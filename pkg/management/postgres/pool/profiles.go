@@ -19,7 +19,22 @@ SPDX-License-Identifier: Apache-2.0
 
 package pool
 
-import "github.com/jackc/pgx/v5"
+import (
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// applicationNamePrefixEnvVar is the environment variable holding the
+	// prefix of the application_name used by the instance manager
+	applicationNamePrefixEnvVar = "CNPG_APPLICATION_NAME_PREFIX"
+
+	// applicationNameComponent is the name of the component appended to
+	// the prefix for the connections not choosing their own application_name
+	applicationNameComponent = "instance_manager"
+)
 
 var (
 	// ConnectionProfilePostgresql is the connection profile to be used for PostgreSQL
@@ -39,6 +54,7 @@ type connectionProfilePostgresql profile
 
 func (connectionProfilePostgresql) Enrich(config *pgx.ConnConfig) {
 	fillDefaultParameters(config)
+	applyApplicationNamePrefix(config)
 
 	// We don't want to be stuck on queries if synchronous replicas
 	// are still not alive and kicking. The next reconciliation loop
@@ -85,3 +101,24 @@ func fillDefaultParameters(config *pgx.ConnConfig) {
 	// when it's needed
 	config.RuntimeParams["datestyle"] = "ISO"
 }
+
+// GetApplicationNamePrefix gets the prefix of the application_name of the
+// connections opened by the instance manager, as configured in the operator.
+// An empty string means that no prefix has been configured
+func GetApplicationNamePrefix() string {
+	return os.Getenv(applicationNamePrefixEnvVar)
+}
+
+// applyApplicationNamePrefix makes sure the application_name of the
+// connection starts with the configured prefix, so that every connection
+// opened by the instance manager can be recognized in pg_stat_activity.
+// The physical replication connections are left alone, as their
+// application_name must match synchronous_standby_names
+func applyApplicationNamePrefix(config *pgx.ConnConfig) {
+	prefix := GetApplicationNamePrefix()
+	if len(prefix) == 0 || strings.HasPrefix(config.RuntimeParams["application_name"], prefix) {
+		return
+	}
+
+	config.RuntimeParams["application_name"] = prefix + applicationNameComponent
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pool
+
+import (
+	"github.com/jackc/pgx/v5"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Application name prefix of the connections", func() {
+	parseConfig := func(connectionString string) *pgx.ConnConfig {
+		config, err := pgx.ParseConfig(connectionString)
+		Expect(err).ToNot(HaveOccurred())
+		return config
+	}
+
+	It("keeps the application name when no prefix is configured", func() {
+		GinkgoT().Setenv("CNPG_APPLICATION_NAME_PREFIX", "")
+		config := parseConfig("host=127.0.0.1 application_name=cluster-example-1")
+		ConnectionProfilePostgresql.Enrich(config)
+		Expect(config.RuntimeParams).To(HaveKeyWithValue("application_name", "cluster-example-1"))
+	})
+
+	It("applies the prefix to the connections not already using it", func() {
+		GinkgoT().Setenv("CNPG_APPLICATION_NAME_PREFIX", "cnpg_operator_")
+
+		config := parseConfig("host=127.0.0.1 application_name=cluster-example-1")
+		ConnectionProfilePostgresql.Enrich(config)
+		Expect(config.RuntimeParams).To(HaveKeyWithValue("application_name", "cnpg_operator_instance_manager"))
+
+		config = parseConfig("host=127.0.0.1")
+		ConnectionProfilePostgresql.Enrich(config)
+		Expect(config.RuntimeParams).To(HaveKeyWithValue("application_name", "cnpg_operator_instance_manager"))
+
+		config = parseConfig("host=127.0.0.1 application_name=cnpg_operator_metrics_exporter")
+		ConnectionProfilePostgresql.Enrich(config)
+		Expect(config.RuntimeParams).To(HaveKeyWithValue("application_name", "cnpg_operator_metrics_exporter"))
+	})
+
+	It("doesn't change the application name of the physical replication connections", func() {
+		GinkgoT().Setenv("CNPG_APPLICATION_NAME_PREFIX", "cnpg_operator_")
+		config := parseConfig("host=127.0.0.1 application_name=cluster-example-1")
+		ConnectionProfilePostgresqlPhysicalReplication.Enrich(config)
+		Expect(config.RuntimeParams).To(HaveKeyWithValue("application_name", "cluster-example-1"))
+	})
+})
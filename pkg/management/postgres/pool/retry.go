@@ -0,0 +1,109 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// sqlStateCannotConnectNow is the error code returned by PostgreSQL
+	// when it is not accepting connections, i.e. while it is starting up,
+	// recovering, or shutting down
+	sqlStateCannotConnectNow = "57P03"
+
+	// connectionRetryInitialBackoff is the delay before the first
+	// connection retry, doubled at every further attempt
+	connectionRetryInitialBackoff = 100 * time.Millisecond
+
+	// connectionRetryMaxBackoff caps the delay between two connection
+	// attempts
+	connectionRetryMaxBackoff = 2 * time.Second
+)
+
+// retryingConnector is a driver.Connector retrying the connections that
+// are refused because PostgreSQL is not accepting connections yet, with an
+// exponential backoff. The retries are bounded by a timeout, so that a
+// server stuck in that state is still reported as an error.
+type retryingConnector struct {
+	driver.Connector
+
+	// the maximum time spent retrying a connection
+	timeout time.Duration
+
+	// sleep waits for the passed duration, and can be replaced in tests
+	sleep func(ctx context.Context, duration time.Duration) error
+}
+
+func newRetryingConnector(connector driver.Connector, timeout time.Duration) *retryingConnector {
+	return &retryingConnector{
+		Connector: connector,
+		timeout:   timeout,
+		sleep:     sleepWithContext,
+	}
+}
+
+// Connect opens a new connection, retrying while PostgreSQL is not
+// accepting connections yet
+func (connector *retryingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	backoff := connectionRetryInitialBackoff
+	remaining := connector.timeout
+
+	for {
+		conn, err := connector.Connector.Connect(ctx)
+		if err == nil || !isCannotConnectNow(err) || remaining < backoff {
+			return conn, err
+		}
+
+		log.FromContext(ctx).Debug("PostgreSQL is not accepting connections yet, retrying",
+			"error", err.Error(), "backoff", backoff)
+		if sleepErr := connector.sleep(ctx, backoff); sleepErr != nil {
+			return nil, err
+		}
+
+		remaining -= backoff
+		backoff = min(2*backoff, connectionRetryMaxBackoff)
+	}
+}
+
+// isCannotConnectNow checks if the passed error has been raised because
+// PostgreSQL is not accepting connections yet
+func isCannotConnectNow(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == sqlStateCannotConnectNow
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeConnector is a driver.Connector failing with the passed
+// errors before succeeding
+type fakeConnector struct {
+	driver.Connector
+	errors   []error
+	attempts int
+}
+
+func (connector *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	connector.attempts++
+	if len(connector.errors) == 0 {
+		return nil, nil
+	}
+
+	err := connector.errors[0]
+	connector.errors = connector.errors[1:]
+	return nil, err
+}
+
+var _ = Describe("Connection retries", func() {
+	startingUp := fmt.Errorf("failed to connect: %w", &pgconn.PgError{
+		Code:    sqlStateCannotConnectNow,
+		Message: "the database system is starting up",
+	})
+
+	var (
+		sleeps []time.Duration
+		retry  func(*fakeConnector, time.Duration) *retryingConnector
+	)
+
+	BeforeEach(func() {
+		sleeps = nil
+		retry = func(fake *fakeConnector, timeout time.Duration) *retryingConnector {
+			connector := newRetryingConnector(fake, timeout)
+			connector.sleep = func(_ context.Context, duration time.Duration) error {
+				sleeps = append(sleeps, duration)
+				return nil
+			}
+			return connector
+		}
+	})
+
+	It("retries with an exponential backoff while PostgreSQL is starting up", func(ctx SpecContext) {
+		fake := &fakeConnector{errors: []error{startingUp, startingUp, startingUp}}
+		_, err := retry(fake, 10*time.Second).Connect(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fake.attempts).To(Equal(4))
+		Expect(sleeps).To(Equal([]time.Duration{
+			100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		}))
+	})
+
+	It("caps the backoff and gives up after the timeout", func(ctx SpecContext) {
+		fake := &fakeConnector{}
+		for range 20 {
+			fake.errors = append(fake.errors, startingUp)
+		}
+
+		_, err := retry(fake, 5*time.Second).Connect(ctx)
+		Expect(err).To(MatchError(startingUp))
+		Expect(sleeps).To(Equal([]time.Duration{
+			100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+			800 * time.Millisecond, 1600 * time.Millisecond,
+		}))
+	})
+
+	It("doesn't retry other errors", func(ctx SpecContext) {
+		fake := &fakeConnector{errors: []error{errors.New("connection refused")}}
+		_, err := retry(fake, 10*time.Second).Connect(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(fake.attempts).To(Equal(1))
+		Expect(sleeps).To(BeEmpty())
+	})
+
+	It("stops retrying when the context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		fake := &fakeConnector{errors: []error{startingUp, startingUp}}
+		_, err := newRetryingConnector(fake, 10*time.Second).Connect(ctx)
+		Expect(err).To(MatchError(startingUp))
+		Expect(fake.attempts).To(Equal(1))
+	})
+})
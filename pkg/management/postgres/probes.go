@@ -23,11 +23,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -41,9 +44,11 @@ import (
 // GetStatus Extract the status of this PostgreSQL database
 func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err error) {
 	result = &postgres.PostgresqlStatus{
-		Pod:                    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: instance.GetPodName()}},
-		InstanceManagerVersion: versions.Version,
-		MightBeUnavailable:     instance.MightBeUnavailable(),
+		Pod:                     &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: instance.GetPodName()}},
+		InstanceManagerVersion:  versions.Version,
+		MightBeUnavailable:      instance.MightBeUnavailable(),
+		IsReadinessQueryFailing: instance.IsReadinessQueryFailing(),
+		AuthenticationRules:     instance.GetAuthenticationRulesStatus(),
 	}
 
 	// this deferred function may override the error returned. Take extra care.
@@ -63,6 +68,13 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 		}
 	}()
 
+	if instance.IsPermanentlyFailed() {
+		// PostgreSQL is not started anymore, and the operator needs to know
+		// why to report it to the user
+		result.IsPermanentlyFailed = true
+		return result, nil
+	}
+
 	if instance.PgRewindIsRunning {
 		// We know that pg_rewind is running, so we exit with the proper status
 		// updated, and we can provide that information to the user.
@@ -261,6 +273,12 @@ func (instance *Instance) fillStatus(result *postgres.PostgresqlStatus) error {
 		return err
 	}
 
+	if result.IsPrimary {
+		if err := fillTransactionIDAgeStatus(superUserDB, result); err != nil {
+			return err
+		}
+	}
+
 	if err := instance.fillBasebackupStats(superUserDB, result); err != nil {
 		return err
 	}
@@ -373,6 +391,26 @@ func fillArchiverStatus(superUserDB *sql.DB, result *postgres.PostgresqlStatus)
 	)
 }
 
+// fillTransactionIDAgeStatus get information about the age of the oldest
+// unfrozen transaction and multixact IDs across all the databases
+func fillTransactionIDAgeStatus(superUserDB *sql.DB, result *postgres.PostgresqlStatus) error {
+	row := superUserDB.QueryRow(
+		`
+		SELECT
+			COALESCE(max(pg_catalog.age(datfrozenxid)), 0),
+			COALESCE(max(pg_catalog.mxid_age(datminmxid)), 0),
+			pg_catalog.current_setting('autovacuum_freeze_max_age')::bigint,
+			pg_catalog.current_setting('autovacuum_multixact_freeze_max_age')::bigint
+		FROM pg_catalog.pg_database
+		`)
+
+	return row.Scan(&result.MaxXIDAge,
+		&result.MaxMXIDAge,
+		&result.AutovacuumFreezeMaxAge,
+		&result.AutovacuumMultixactFreezeMaxAge,
+	)
+}
+
 // fillReplicationSlotsStatus get information about the replication slots
 func (instance *Instance) fillReplicationSlotsStatus(result *postgres.PostgresqlStatus) error {
 	if !result.IsPrimary {
@@ -532,10 +570,21 @@ func (instance *Instance) fillStatusFromReplica(result *postgres.PostgresqlStatu
 			"(SELECT timeline_id FROM pg_catalog.pg_control_checkpoint()), " +
 			"COALESCE(pg_catalog.pg_last_wal_receive_lsn()::varchar, ''), " +
 			"COALESCE(pg_catalog.pg_last_wal_replay_lsn()::varchar, ''), " +
-			"pg_catalog.pg_is_wal_replay_paused()")
-	if err := row.Scan(&result.TimeLineID, &result.ReceivedLsn, &result.ReplayLsn, &result.ReplayPaused); err != nil {
+			"pg_catalog.pg_is_wal_replay_paused(), " +
+			"pg_catalog.current_setting('recovery_min_apply_delay')")
+	var minApplyDelay string
+	if err := row.Scan(
+		&result.TimeLineID,
+		&result.ReceivedLsn,
+		&result.ReplayLsn,
+		&result.ReplayPaused,
+		&minApplyDelay,
+	); err != nil {
 		return err
 	}
+	if minApplyDelay != "0" {
+		result.MinApplyDelay = minApplyDelay
+	}
 
 	// Sometimes pg_last_wal_replay_lsn is getting evaluated after
 	// pg_last_wal_receive_lsn and this, if other WALs are received,
@@ -572,6 +621,22 @@ func (instance *Instance) IsWALReceiverActive() (bool, error) {
 	return result, nil
 }
 
+// GetLastReplayLSN returns the last WAL location replayed by this replica
+func (instance *Instance) GetLastReplayLSN() (types.LSN, error) {
+	superUserDB, err := instance.GetSuperUserDB()
+	if err != nil {
+		return "", err
+	}
+
+	var result types.LSN
+	row := superUserDB.QueryRow("SELECT COALESCE(pg_catalog.pg_last_wal_replay_lsn()::varchar, '')")
+	if err := row.Scan(&result); err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
 // PgStatWal is a representation of the pg_stat_wal table, introduced in PostgreSQL 14.
 type PgStatWal struct {
 	WalRecords     int64
@@ -688,3 +753,39 @@ func GetReadyWALFiles() (fileNames []string, err error) {
 
 	return fileNames, nil
 }
+
+// GetOldestReadyWALFileTime returns the time when the oldest WAL file that is
+// still waiting to be archived has been marked as ready, or the zero time if
+// no WAL file is waiting. This tells for how long the archiver is lagging behind.
+func GetOldestReadyWALFileTime() (time.Time, error) {
+	return getOldestReadyWALFileTime(specs.PgWalArchiveStatusPath)
+}
+
+func getOldestReadyWALFileTime(archiveStatusPath string) (time.Time, error) {
+	entries, err := os.ReadDir(archiveStatusPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var oldest time.Time
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".ready" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			// the WAL file has been archived in the meantime
+			continue
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+	}
+
+	return oldest, nil
+}
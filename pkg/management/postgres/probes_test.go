@@ -21,7 +21,10 @@ package postgres
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blang/semver"
@@ -92,6 +95,29 @@ var _ = Describe("probes", func() {
 		Expect(status.IsArchivingWAL).To(BeFalse())
 	})
 
+	It("fillTransactionIDAgeStatus should set the transaction ID ages", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*`).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"max_xid_age",
+				"max_mxid_age",
+				"autovacuum_freeze_max_age",
+				"autovacuum_multixact_freeze_max_age",
+			},
+			).AddRow(int64(150000000), int64(1000), int64(200000000), int64(400000000)))
+
+		status := &postgres.PostgresqlStatus{}
+		Expect(fillTransactionIDAgeStatus(db, status)).To(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(status.MaxXIDAge).To(Equal(int64(150000000)))
+		Expect(status.MaxMXIDAge).To(Equal(int64(1000)))
+		Expect(status.AutovacuumFreezeMaxAge).To(Equal(int64(200000000)))
+		Expect(status.AutovacuumMultixactFreezeMaxAge).To(Equal(int64(400000000)))
+	})
+
 	Context("Fill basebackup stats", func() {
 		It("set the information", func() {
 			instance := (&Instance{
@@ -143,4 +169,42 @@ var _ = Describe("probes", func() {
 			Expect(status.PgStatBasebackupsInfo[0].TablespacesStreamed).To(Equal(int64(1)))
 		})
 	})
+
+	Context("getOldestReadyWALFileTime", func() {
+		var archiveStatusPath string
+
+		touch := func(name string, modTime time.Time) {
+			fileName := filepath.Join(archiveStatusPath, name)
+			Expect(os.WriteFile(fileName, nil, 0o600)).To(Succeed())
+			Expect(os.Chtimes(fileName, modTime, modTime)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			archiveStatusPath = GinkgoT().TempDir()
+		})
+
+		It("returns the zero time when no WAL file is waiting to be archived", func() {
+			touch("000000010000000000000001.done", time.Now().Add(-time.Hour))
+
+			oldest, err := getOldestReadyWALFileTime(archiveStatusPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oldest.IsZero()).To(BeTrue())
+		})
+
+		It("returns the time of the oldest WAL file marked as ready", func() {
+			oldestTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+			touch("000000010000000000000001.done", oldestTime.Add(-time.Hour))
+			touch("000000010000000000000002.ready", oldestTime)
+			touch("000000010000000000000003.ready", oldestTime.Add(time.Minute))
+
+			oldest, err := getOldestReadyWALFileTime(archiveStatusPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oldest).To(BeTemporally("==", oldestTime))
+		})
+
+		It("fails when the directory doesn't exist", func() {
+			_, err := getOldestReadyWALFileTime(filepath.Join(archiveStatusPath, "missing"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
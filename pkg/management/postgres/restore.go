@@ -59,6 +59,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -199,7 +200,8 @@ func (info InitInfo) concludeRestore(
 		}
 
 		// TODO: Using a replication slot on replica cluster is not supported (yet?)
-		_, err = UpdateReplicaConfiguration(info.PgData, connectionString, "")
+		_, err = UpdateReplicaConfiguration(info.PgData, connectionString, "",
+			cluster.Spec.PostgresConfiguration.EnforceReplicaReadOnly, 0)
 		return err
 	}
 
@@ -594,6 +596,12 @@ func (info InitInfo) loadBackupFromReference(
 		return nil, nil, err
 	}
 
+	if backup.Spec.Method == apiv1.BackupMethodPgDump {
+		return nil, nil, fmt.Errorf(
+			"backup %s is a logical dump and cannot be used to recover a cluster, use importDump instead",
+			backup.Name)
+	}
+
 	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(
 		ctx,
 		typedClient,
@@ -690,9 +698,13 @@ func (info InitInfo) writeRecoveryConfiguration(cluster *apiv1.Cluster, recovery
 	if err != nil {
 		return err
 	}
+	recoveryParams, err := loadEnforcedParameters(cluster.Spec.Bootstrap.Recovery.Parameters)
+	if err != nil {
+		return err
+	}
 	enforcedParams := make(map[string]string)
 	for _, param := range pgControldataSettingsToParamsMap {
-		value := max(clusterParams[param], controldataParams[param])
+		value := max(clusterParams[param], controldataParams[param], recoveryParams[param])
 		enforcedParams[param] = strconv.Itoa(value)
 	}
 	changed, err := configfile.UpdatePostgresConfigurationFile(
@@ -711,6 +723,14 @@ func (info InitInfo) writeRecoveryConfiguration(cluster *apiv1.Cluster, recovery
 		return fmt.Errorf("cannot write recovery config for enforced parameters: %w", err)
 	}
 
+	// The parameters requested for the recovery only, like the ones
+	// increasing the parallelism, are written after the steady-state ones
+	// and will be replaced when the instances will regenerate the
+	// configuration after the promotion
+	if err := writeRecoveryParameters(info.PgData, cluster.Spec.Bootstrap.Recovery.Parameters); err != nil {
+		return err
+	}
+
 	// Append restore_command to the end of the
 	// custom configs file
 	err = fileutils.AppendStringToFile(
@@ -735,6 +755,36 @@ func (info InitInfo) writeRecoveryConfiguration(cluster *apiv1.Cluster, recovery
 		0o600)
 }
 
+// writeRecoveryParameters sets, in the custom configuration file, the
+// PostgreSQL parameters to be used during the recovery only. The enforced
+// parameters are skipped, as they are aligned with pg_controldata
+func writeRecoveryParameters(pgData string, params map[string]string) error {
+	enforcedParams := stringset.New()
+	for _, param := range pgControldataSettingsToParamsMap {
+		enforcedParams.Put(param)
+	}
+
+	recoveryParams := make(map[string]string, len(params))
+	for key, value := range params {
+		if !enforcedParams.Has(key) {
+			recoveryParams[key] = value
+		}
+	}
+	if len(recoveryParams) == 0 {
+		return nil
+	}
+
+	if _, err := configfile.UpdatePostgresConfigurationFile(
+		path.Join(pgData, constants.PostgresqlCustomConfigurationFile),
+		recoveryParams,
+	); err != nil {
+		return fmt.Errorf("cannot write recovery config for recovery parameters: %w", err)
+	}
+
+	log.Info("Applied the PostgreSQL parameters requested for the recovery", "recoveryParams", recoveryParams)
+	return nil
+}
+
 // LoadEnforcedParametersFromPgControldata will parse the output of pg_controldata in order to get
 // the values of all the hot standby sensible parameters
 func LoadEnforcedParametersFromPgControldata(pgData string) (map[string]int, error) {
@@ -777,10 +827,15 @@ func LoadEnforcedParametersFromPgControldata(pgData string) (map[string]int, err
 func LoadEnforcedParametersFromCluster(
 	cluster *apiv1.Cluster,
 ) (map[string]int, error) {
-	clusterParams := cluster.Spec.PostgresConfiguration.Parameters
+	return loadEnforcedParameters(cluster.Spec.PostgresConfiguration.Parameters)
+}
+
+// loadEnforcedParameters extracts the enforced parameters from a set of
+// PostgreSQL configuration parameters
+func loadEnforcedParameters(params map[string]string) (map[string]int, error) {
 	enforcedParams := map[string]int{}
 	for _, param := range pgControldataSettingsToParamsMap {
-		value, found := clusterParams[param]
+		value, found := params[param]
 		if !found {
 			continue
 		}
@@ -840,7 +895,7 @@ func (info InitInfo) WriteInitialPostgresqlConf(ctx context.Context, cluster *ap
 		WithNamespace(info.Namespace).
 		WithClusterName(info.ClusterName)
 
-	_, err = temporaryInstance.RefreshPGHBA(ctx, cluster, "")
+	_, err = temporaryInstance.RefreshPGHBA(ctx, cluster, "", nil)
 	if err != nil {
 		return fmt.Errorf("while generating pg_hba.conf: %w", err)
 	}
@@ -941,9 +996,9 @@ func (info InitInfo) ConfigureInstanceAfterRestore(ctx context.Context, cluster
 		return err
 	}
 
-	primaryConnInfo := info.GetPrimaryConnInfo()
+	primaryConnInfo := info.GetPrimaryConnInfo(cluster)
 	slotName := cluster.GetSlotNameFromInstanceName(info.PodName)
-	if _, err := configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, slotName); err != nil {
+	if _, err := configurePostgresOverrideConfFile(info.PgData, primaryConnInfo, slotName, false, 0); err != nil {
 		return fmt.Errorf("while configuring replica: %w", err)
 	}
 
@@ -962,9 +1017,10 @@ func (info InitInfo) ConfigureInstanceAfterRestore(ctx context.Context, cluster
 	})
 }
 
-// GetPrimaryConnInfo returns the DSN to reach the primary
-func (info InitInfo) GetPrimaryConnInfo() string {
-	return buildPrimaryConnInfo(info.ClusterName+"-rw", info.PodName)
+// GetPrimaryConnInfo returns the DSN to reach the primary, built like the
+// one used by the running instances
+func (info InitInfo) GetPrimaryConnInfo(cluster *apiv1.Cluster) string {
+	return buildStandbyConnInfo(cluster, info.ClusterName, info.PodName)
 }
 
 func (info *InitInfo) checkBackupDestination(
@@ -978,7 +1034,7 @@ func (info *InitInfo) checkBackupDestination(
 	}
 
 	// Get environment from cache
-	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(ctx,
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(ctx,
 		client,
 		cluster.Namespace,
 		cluster.Spec.Backup.BarmanObjectStore,
@@ -986,6 +1042,8 @@ func (info *InitInfo) checkBackupDestination(
 	if err != nil {
 		return fmt.Errorf("can't get credentials for cluster %v: %w", cluster.Name, err)
 	}
+	env = specs.AppendBarmanEndpointCAEnv(
+		env, cluster.Spec.Backup.BarmanObjectStore, postgresSpec.BarmanBackupEndpointCACertificateLocation)
 	if len(env) == 0 {
 		return nil
 	}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/utils/strings/slices"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -206,4 +207,32 @@ var _ = Describe("testing restore InitInfo methods", func() {
 		Expect(enforcedParamsInPGData).To(HaveLen(1))
 		Expect(enforcedParamsInPGData["max_connections"]).To(Equal(200))
 	})
+	It("writes the parameters requested for the recovery", func() {
+		Expect(os.MkdirAll(pgData, 0o700)).To(Succeed())
+		customConfFile := path.Join(pgData, constants.PostgresqlCustomConfigurationFile)
+		Expect(os.WriteFile(customConfFile, []byte("maintenance_work_mem = '64MB'\n"), 0o600)).To(Succeed())
+
+		Expect(writeRecoveryParameters(pgData, map[string]string{
+			"maintenance_work_mem": "1GB",
+			"max_parallel_workers": "16",
+			"max_worker_processes": "32",
+		})).To(Succeed())
+
+		content, err := os.ReadFile(customConfFile) // nolint: gosec
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("maintenance_work_mem = '1GB'"))
+		Expect(string(content)).ToNot(ContainSubstring("maintenance_work_mem = '64MB'"))
+		Expect(string(content)).To(ContainSubstring("max_parallel_workers = '16'"))
+		// The enforced parameters are aligned with pg_controldata separately
+		Expect(string(content)).ToNot(ContainSubstring("max_worker_processes"))
+	})
+
+	It("parses the enforced params requested for the recovery", func() {
+		enforcedParams, err := loadEnforcedParameters(map[string]string{
+			"max_worker_processes": "32",
+			"max_parallel_workers": "16",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enforcedParams).To(Equal(map[string]int{"max_worker_processes": 32}))
+	})
 })
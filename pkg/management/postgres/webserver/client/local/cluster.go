@@ -37,6 +37,11 @@ type ClusterClient interface {
 	// An empty errMessage means that the archive process was successful.
 	// Returns any error encountered during the request.
 	SetWALArchiveStatusCondition(ctx context.Context, errMessage string) error
+
+	// SetWALArchivePausedCondition sets the wal-archive status condition
+	// to report that the archiving has been paused, with the passed message.
+	// Returns any error encountered during the request.
+	SetWALArchivePausedCondition(ctx context.Context, message string) error
 }
 
 // clusterClientImpl a client to interact with the uncategorized endpoints
@@ -45,11 +50,20 @@ type clusterClientImpl struct {
 }
 
 func (c *clusterClientImpl) SetWALArchiveStatusCondition(ctx context.Context, errMessage string) error {
-	contextLogger := log.FromContext(ctx).WithValues("endpoint", url.PathWALArchiveStatusCondition)
-
-	asr := webserver.ArchiveStatusRequest{
+	return c.postWALArchiveStatus(ctx, webserver.ArchiveStatusRequest{
 		Error: errMessage,
-	}
+	})
+}
+
+func (c *clusterClientImpl) SetWALArchivePausedCondition(ctx context.Context, message string) error {
+	return c.postWALArchiveStatus(ctx, webserver.ArchiveStatusRequest{
+		Error:  message,
+		Paused: true,
+	})
+}
+
+func (c *clusterClientImpl) postWALArchiveStatus(ctx context.Context, asr webserver.ArchiveStatusRequest) error {
+	contextLogger := log.FromContext(ctx).WithValues("endpoint", url.PathWALArchiveStatusCondition)
 
 	encoded, err := json.Marshal(&asr)
 	if err != nil {
@@ -45,6 +45,11 @@ type BackupClient interface {
 		pod *corev1.Pod,
 		sbq webserver.StopBackupRequest,
 	) (*webserver.Response[webserver.BackupResultData], error)
+	RunPostBackupHook(
+		ctx context.Context,
+		pod *corev1.Pod,
+		pbhr webserver.PostBackupHookRequest,
+	) (*webserver.Response[struct{}], error)
 }
 
 // backupClientImpl a client to interact with the instance backup endpoints
@@ -112,3 +117,27 @@ func (c *backupClientImpl) Stop(
 	}
 	return executeRequestWithError[webserver.BackupResultData](ctx, c.cli, req, true)
 }
+
+// RunPostBackupHook requests the instance to run the post-backup hook of
+// a completed backup
+func (c *backupClientImpl) RunPostBackupHook(
+	ctx context.Context,
+	pod *corev1.Pod,
+	pbhr webserver.PostBackupHookRequest,
+) (*webserver.Response[struct{}], error) {
+	scheme := GetStatusSchemeFromPod(pod)
+	httpURL := url.Build(scheme.ToString(), pod.Status.PodIP, url.PathPgBackupHook, url.StatusPort)
+	// Marshalling the payload to JSON
+	jsonBody, err := json.Marshal(pbhr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal post-backup hook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, httpURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return executeRequestWithError[struct{}](ctx, c.cli, req, false)
+}
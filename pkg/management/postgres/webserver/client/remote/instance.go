@@ -91,6 +91,10 @@ type instanceClientImpl struct {
 	*http.Client
 }
 
+// ErrInstancePermanentlyFailed is reported as the status error of an
+// instance marked as permanently failed by the startup failure policy
+var ErrInstancePermanentlyFailed = errors.New("instance permanently failed, PostgreSQL is not started anymore")
+
 // An StatusError reports an unsuccessful attempt to retrieve an instance status
 type StatusError struct {
 	StatusCode int
@@ -144,6 +148,11 @@ func (r *instanceClientImpl) getReplicaStatusFromPodViaHTTP(
 			return false
 		}
 
+		// The instance won't start PostgreSQL until the Pod is recreated
+		if errors.Is(err, ErrInstancePermanentlyFailed) {
+			return false
+		}
+
 		contextLog.Debug("Error while requesting the status of an instance, retrying",
 			"pod", pod.Name,
 			"error", err)
@@ -338,6 +347,10 @@ func (r *instanceClientImpl) rawInstanceStatusRequest(
 		return result
 	}
 
+	if result.IsPermanentlyFailed {
+		result.Error = ErrInstancePermanentlyFailed
+	}
+
 	return result
 }
 
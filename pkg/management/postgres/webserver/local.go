@@ -175,6 +175,21 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 		}
 		_, _ = fmt.Fprint(w, "OK")
 
+	case apiv1.BackupMethodPgDump:
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+			http.Error(w, "Barman object store not configured in the cluster", http.StatusConflict)
+			return
+		}
+
+		if err := ws.startPgDumpBackup(ctx, cluster, &backup); err != nil {
+			http.Error(
+				w,
+				fmt.Sprintf("error while requesting logical dump: %v", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		_, _ = fmt.Fprint(w, "OK")
+
 	case apiv1.BackupMethodPlugin:
 		if backup.Spec.PluginConfiguration.IsEmpty() {
 			http.Error(w, "Plugin backup not configured in the cluster", http.StatusConflict)
@@ -231,20 +246,57 @@ func (ws *localWebserverEndpoints) startBarmanBackup(
 	return nil
 }
 
+func (ws *localWebserverEndpoints) startPgDumpBackup(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) error {
+	backupLog := log.WithValues(
+		"backupName", backup.Name,
+		"backupNamespace", backup.Namespace)
+
+	backupCommand := postgres.NewPgDumpBackupCommand(
+		cluster,
+		backup,
+		ws.typedClient,
+		ws.eventRecorder,
+		ws.instance,
+		backupLog,
+	)
+	if err := backupCommand.Start(ctx); err != nil {
+		return fmt.Errorf("while starting logical dump: %w", err)
+	}
+
+	return nil
+}
+
 func (ws *localWebserverEndpoints) startPluginBackup(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 	backup *apiv1.Backup,
 ) {
-	NewPluginBackupCommand(cluster, backup, ws.typedClient, ws.eventRecorder).Start(ctx)
+	NewPluginBackupCommand(cluster, backup, ws.typedClient, ws.eventRecorder, ws.instance).Start(ctx)
 }
 
 // ArchiveStatusRequest is the request body for the archive status endpoint
 type ArchiveStatusRequest struct {
 	Error string `json:"error,omitempty"`
+
+	// Paused is true when the WAL file has not been archived because
+	// the archiving has been paused
+	Paused bool `json:"paused,omitempty"`
 }
 
 func (asr *ArchiveStatusRequest) getContinuousArchivingCondition() metav1.Condition {
+	if asr.Paused {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionContinuousArchiving),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonContinuousArchivingPaused),
+			Message: asr.Error,
+		}
+	}
+
 	if asr.Error != "" {
 		return metav1.Condition{
 			Type:    string(apiv1.ConditionContinuousArchiving),
@@ -0,0 +1,145 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"math"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PgStatCheckpointMetrics contains the checkpoint counters, that are
+// exposed by pg_stat_bgwriter up to PG 16 and by pg_stat_checkpointer
+// since PG 17, under the same names
+type PgStatCheckpointMetrics struct {
+	CheckpointsTimed     prometheus.Gauge
+	CheckpointsRequested prometheus.Gauge
+	WriteTime            prometheus.Gauge
+	SyncTime             prometheus.Gauge
+	BuffersWritten       prometheus.Gauge
+}
+
+func newPgStatCheckpointMetrics(subsystem string) PgStatCheckpointMetrics {
+	return PgStatCheckpointMetrics{
+		CheckpointsTimed: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "checkpoints_timed",
+			Help:      "Number of scheduled checkpoints that have been performed",
+		}),
+		CheckpointsRequested: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "checkpoints_requested",
+			Help:      "Number of requested checkpoints that have been performed",
+		}),
+		WriteTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "checkpoint_write_time",
+			Help: "Total amount of time that has been spent in the portion of checkpoint processing " +
+				"where files are written to disk, in milliseconds",
+		}),
+		SyncTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "checkpoint_sync_time",
+			Help: "Total amount of time that has been spent in the portion of checkpoint processing " +
+				"where files are synchronized to disk, in milliseconds",
+		}),
+		BuffersWritten: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "checkpoint_buffers_written",
+			Help:      "Number of buffers written during checkpoints",
+		}),
+	}
+}
+
+func (m PgStatCheckpointMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.CheckpointsTimed.Desc()
+	ch <- m.CheckpointsRequested.Desc()
+	ch <- m.WriteTime.Desc()
+	ch <- m.SyncTime.Desc()
+	ch <- m.BuffersWritten.Desc()
+}
+
+func (m PgStatCheckpointMetrics) collect(ch chan<- prometheus.Metric) {
+	ch <- m.CheckpointsTimed
+	ch <- m.CheckpointsRequested
+	ch <- m.WriteTime
+	ch <- m.SyncTime
+	ch <- m.BuffersWritten
+}
+
+// getCheckpointStatsQuery returns the query extracting the checkpoint
+// counters for the passed PostgreSQL major version, as PG 17 moved them
+// from pg_stat_bgwriter to pg_stat_checkpointer
+func getCheckpointStatsQuery(pgMajor uint64) string {
+	if pgMajor >= 17 {
+		return `SELECT num_timed, num_requested, write_time, sync_time, buffers_written
+			FROM pg_catalog.pg_stat_checkpointer`
+	}
+
+	return `SELECT checkpoints_timed, checkpoints_req, checkpoint_write_time,
+			checkpoint_sync_time, buffers_checkpoint
+		FROM pg_catalog.pg_stat_bgwriter`
+}
+
+func (e *Exporter) collectCheckpointStats(db *sql.DB, pgMajor uint64) {
+	var timed, requested, buffersWritten int64
+	var writeTime, syncTime float64
+	row := db.QueryRow(getCheckpointStatsQuery(pgMajor))
+	if err := row.Scan(&timed, &requested, &writeTime, &syncTime, &buffersWritten); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.CheckpointStats").Inc()
+		return
+	}
+
+	e.Metrics.PgStatCheckpointMetrics.CheckpointsTimed.Set(float64(timed))
+	e.Metrics.PgStatCheckpointMetrics.CheckpointsRequested.Set(float64(requested))
+	e.Metrics.PgStatCheckpointMetrics.WriteTime.Set(writeTime)
+	e.Metrics.PgStatCheckpointMetrics.SyncTime.Set(syncTime)
+	e.Metrics.PgStatCheckpointMetrics.BuffersWritten.Set(float64(buffersWritten))
+}
+
+func (e *Exporter) collectBufferCacheHitRatio(db *sql.DB) {
+	var ratio sql.NullFloat64
+	row := db.QueryRow(
+		`SELECT sum(blks_hit)::float8 / NULLIF(sum(blks_hit) + sum(blks_read), 0)
+		FROM pg_catalog.pg_stat_database`)
+	if err := row.Scan(&ratio); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.BufferCacheHitRatio").Inc()
+		return
+	}
+
+	// No block has been requested yet, the ratio is not defined
+	if !ratio.Valid {
+		e.Metrics.BufferCacheHitRatio.Set(math.NaN())
+		return
+	}
+
+	e.Metrics.BufferCacheHitRatio.Set(ratio.Float64)
+}
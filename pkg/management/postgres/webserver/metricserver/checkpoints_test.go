@@ -0,0 +1,124 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"math"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// gatherGaugeValues returns the values of the passed gauges, indexed by name
+func gatherGaugeValues(collectors ...prometheus.Collector) map[string]float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors...)
+	metrics, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	values := make(map[string]float64, len(metrics))
+	for _, metric := range metrics {
+		values[metric.GetName()] = metric.GetMetric()[0].GetGauge().GetValue()
+	}
+	return values
+}
+
+var _ = Describe("buffer cache and checkpoint metrics", func() {
+	var exporter *Exporter
+
+	BeforeEach(func() {
+		cache.Delete(cache.ClusterKey)
+		exporter = NewExporter(postgres.NewInstance(), fakePluginCollector{})
+	})
+
+	It("collects the buffer cache hit ratio", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_stat_database`).
+			WillReturnRows(sqlmock.NewRows([]string{"ratio"}).AddRow(0.75))
+
+		exporter.collectBufferCacheHitRatio(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.BufferCacheHitRatio)).
+			To(HaveKeyWithValue("cnpg_collector_buffer_cache_hit_ratio", BeEquivalentTo(0.75)))
+	})
+
+	It("reports an undefined buffer cache hit ratio when no block has been requested", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_stat_database`).
+			WillReturnRows(sqlmock.NewRows([]string{"ratio"}).AddRow(nil))
+
+		exporter.collectBufferCacheHitRatio(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		values := gatherGaugeValues(exporter.Metrics.BufferCacheHitRatio)
+		Expect(math.IsNaN(values["cnpg_collector_buffer_cache_hit_ratio"])).To(BeTrue())
+	})
+
+	DescribeTable("collects the checkpoint counters",
+		func(pgMajor uint64, view string) {
+			db, mock, err := sqlmock.New()
+			Expect(err).ToNot(HaveOccurred())
+
+			rows := sqlmock.NewRows([]string{"timed", "requested", "write_time", "sync_time", "buffers_written"}).
+				AddRow(int64(10), int64(2), 1500.5, 20.25, int64(4096))
+			mock.ExpectQuery(`.*` + view).WillReturnRows(rows)
+
+			exporter.collectCheckpointStats(db, pgMajor)
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			checkpointMetrics := exporter.Metrics.PgStatCheckpointMetrics
+			values := gatherGaugeValues(
+				checkpointMetrics.CheckpointsTimed,
+				checkpointMetrics.CheckpointsRequested,
+				checkpointMetrics.WriteTime,
+				checkpointMetrics.SyncTime,
+				checkpointMetrics.BuffersWritten,
+			)
+			Expect(values).To(HaveKeyWithValue("cnpg_collector_checkpoints_timed", BeEquivalentTo(10)))
+			Expect(values).To(HaveKeyWithValue("cnpg_collector_checkpoints_requested", BeEquivalentTo(2)))
+			Expect(values).To(HaveKeyWithValue("cnpg_collector_checkpoint_write_time", BeEquivalentTo(1500.5)))
+			Expect(values).To(HaveKeyWithValue("cnpg_collector_checkpoint_sync_time", BeEquivalentTo(20.25)))
+			Expect(values).To(HaveKeyWithValue("cnpg_collector_checkpoint_buffers_written", BeEquivalentTo(4096)))
+		},
+		Entry("from pg_stat_bgwriter before PostgreSQL 17", uint64(16), "pg_stat_bgwriter"),
+		Entry("from pg_stat_checkpointer since PostgreSQL 17", uint64(17), "pg_stat_checkpointer"),
+	)
+
+	It("reports an error when the checkpoint counters cannot be collected", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_stat_checkpointer`).WillReturnError(sqlmock.ErrCancelled)
+
+		exporter.collectCheckpointStats(db, 17)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.Error)).
+			To(HaveKeyWithValue("cnpg_collector_last_collection_error", BeEquivalentTo(1)))
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"slices"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DatabaseMetrics contains the metrics about the space used by the
+// databases, useful for storage forecasting
+type DatabaseMetrics struct {
+	SizeBytes               *prometheus.GaugeVec
+	TableBloatEstimateBytes *prometheus.GaugeVec
+}
+
+func newDatabaseMetrics(subsystem string) DatabaseMetrics {
+	return DatabaseMetrics{
+		SizeBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "database_size_bytes",
+			Help:      "Disk space used by the database, in bytes (pg_database_size)",
+		}, []string{"datname"}),
+		TableBloatEstimateBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_bloat_estimate_bytes",
+			Help: "Estimated space used by the dead tuples of the table, in bytes, computed " +
+				"as the share of dead tuples over the size of the table (from pg_stat_user_tables). " +
+				"Only available for the biggest tables, when enabled",
+		}, []string{"datname", "schemaname", "relname"}),
+	}
+}
+
+func (m DatabaseMetrics) describe(ch chan<- *prometheus.Desc) {
+	m.SizeBytes.Describe(ch)
+	m.TableBloatEstimateBytes.Describe(ch)
+}
+
+func (m DatabaseMetrics) collect(ch chan<- prometheus.Metric) {
+	m.SizeBytes.Collect(ch)
+	m.TableBloatEstimateBytes.Collect(ch)
+}
+
+const databaseSizesQuery = `SELECT datname, pg_catalog.pg_database_size(datname)::float8
+	FROM pg_catalog.pg_database
+	WHERE datallowconn`
+
+func (e *Exporter) collectDatabaseSizes(db *sql.DB) {
+	sizeBytes := e.Metrics.DatabaseMetrics.SizeBytes
+	sizeBytes.Reset()
+
+	rows, err := db.Query(databaseSizesQuery)
+	if err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.DatabaseSizes").Inc()
+		return
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var datname string
+		var size float64
+		if err := rows.Scan(&datname, &size); err != nil {
+			log.Error(err, "unable to collect metrics")
+			e.Metrics.Error.Set(1)
+			e.Metrics.PgCollectionErrors.WithLabelValues("Collect.DatabaseSizes").Inc()
+			return
+		}
+		sizeBytes.WithLabelValues(datname).Set(size)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.DatabaseSizes").Inc()
+	}
+}
+
+const bloatDatabasesQuery = `SELECT datname
+	FROM pg_catalog.pg_database
+	WHERE datallowconn AND NOT datistemplate`
+
+// tableBloatQuery estimates the space used by the dead tuples of the biggest
+// tables of a database, assuming that dead and live tuples have the same size
+const tableBloatQuery = `SELECT schemaname, relname,
+	pg_catalog.pg_relation_size(relid)::float8 AS size_bytes,
+	CASE WHEN n_live_tup + n_dead_tup > 0
+		THEN pg_catalog.pg_relation_size(relid)::float8 * n_dead_tup / (n_live_tup + n_dead_tup)
+		ELSE 0
+	END AS bloat_bytes
+	FROM pg_catalog.pg_stat_user_tables
+	ORDER BY size_bytes DESC
+	LIMIT $1`
+
+// tableBloatEstimate is the estimate of the bloat of a table
+type tableBloatEstimate struct {
+	datname    string
+	schemaname string
+	relname    string
+	sizeBytes  float64
+	bloatBytes float64
+}
+
+// collectTableBloatEstimate estimates the bloat of the maxTables biggest
+// tables across all the databases, connecting to each of them. To keep the
+// cardinality of the metric in check, the other tables are not reported
+func (e *Exporter) collectTableBloatEstimate(
+	db *sql.DB,
+	maxTables int32,
+	connect func(dbname string) (*sql.DB, error),
+) {
+	bloatBytes := e.Metrics.DatabaseMetrics.TableBloatEstimateBytes
+	bloatBytes.Reset()
+
+	if maxTables <= 0 {
+		return
+	}
+
+	const errorLabel = "Collect.TableBloatEstimate"
+	reportError := func(err error) {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues(errorLabel).Inc()
+	}
+
+	databases, err := queryStrings(db, bloatDatabasesQuery)
+	if err != nil {
+		reportError(err)
+		return
+	}
+
+	var estimates []tableBloatEstimate
+	for _, datname := range databases {
+		conn, err := connect(datname)
+		if err != nil {
+			reportError(err)
+			continue
+		}
+
+		databaseEstimates, err := queryTableBloatEstimates(conn, datname, maxTables)
+		if err != nil {
+			reportError(err)
+			continue
+		}
+		estimates = append(estimates, databaseEstimates...)
+	}
+
+	slices.SortStableFunc(estimates, func(a, b tableBloatEstimate) int {
+		switch {
+		case a.sizeBytes > b.sizeBytes:
+			return -1
+		case a.sizeBytes < b.sizeBytes:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if len(estimates) > int(maxTables) {
+		estimates = estimates[:maxTables]
+	}
+
+	for _, estimate := range estimates {
+		bloatBytes.WithLabelValues(estimate.datname, estimate.schemaname, estimate.relname).
+			Set(estimate.bloatBytes)
+	}
+}
+
+func queryTableBloatEstimates(db *sql.DB, datname string, maxTables int32) ([]tableBloatEstimate, error) {
+	rows, err := db.Query(tableBloatQuery, maxTables)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []tableBloatEstimate
+	for rows.Next() {
+		estimate := tableBloatEstimate{datname: datname}
+		if err := rows.Scan(
+			&estimate.schemaname,
+			&estimate.relname,
+			&estimate.sizeBytes,
+			&estimate.bloatBytes,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, estimate)
+	}
+
+	return result, rows.Err()
+}
+
+func queryStrings(db *sql.DB, query string) ([]string, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("database metrics", func() {
+	var exporter *Exporter
+
+	BeforeEach(func() {
+		cache.Delete(cache.ClusterKey)
+		exporter = NewExporter(postgres.NewInstance(), fakePluginCollector{})
+	})
+
+	It("collects the size of every database", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`.*pg_database_size`).WillReturnRows(
+			sqlmock.NewRows([]string{"datname", "size"}).
+				AddRow("postgres", 7000000.0).
+				AddRow("app", 50000000.0))
+
+		exporter.collectDatabaseSizes(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(gatherGaugeVecValues(exporter.Metrics.DatabaseMetrics.SizeBytes)).To(Equal(map[string]float64{
+			"postgres": 7000000,
+			"app":      50000000,
+		}))
+	})
+
+	It("doesn't estimate the table bloat when disabled", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		exporter.collectTableBloatEstimate(db, 0, func(string) (*sql.DB, error) {
+			return nil, fmt.Errorf("unexpected connection")
+		})
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeVecValues(exporter.Metrics.DatabaseMetrics.TableBloatEstimateBytes)).To(BeEmpty())
+	})
+
+	It("estimates the bloat of the biggest tables across all the databases", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`SELECT datname`).WillReturnRows(
+			sqlmock.NewRows([]string{"datname"}).AddRow("app").AddRow("billing"))
+
+		tableColumns := []string{"schemaname", "relname", "size_bytes", "bloat_bytes"}
+		appDB, appMock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		appMock.ExpectQuery(`.*pg_stat_user_tables`).WithArgs(int32(2)).WillReturnRows(
+			sqlmock.NewRows(tableColumns).
+				AddRow("public", "orders", 9000.0, 900.0).
+				AddRow("public", "customers", 1000.0, 0.0))
+		billingDB, billingMock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		billingMock.ExpectQuery(`.*pg_stat_user_tables`).WithArgs(int32(2)).WillReturnRows(
+			sqlmock.NewRows(tableColumns).
+				AddRow("public", "invoices", 5000.0, 2500.0))
+
+		connections := map[string]*sql.DB{"app": appDB, "billing": billingDB}
+		exporter.collectTableBloatEstimate(db, 2, func(dbname string) (*sql.DB, error) {
+			return connections[dbname], nil
+		})
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(appMock.ExpectationsWereMet()).To(Succeed())
+		Expect(billingMock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(gatherGaugeVecValues(exporter.Metrics.DatabaseMetrics.TableBloatEstimateBytes)).To(Equal(
+			map[string]float64{
+				"app,orders,public":       900,
+				"billing,invoices,public": 2500,
+			}))
+	})
+
+	It("keeps estimating the bloat when a database can't be reached", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`SELECT datname`).WillReturnRows(
+			sqlmock.NewRows([]string{"datname"}).AddRow("broken").AddRow("app"))
+
+		appDB, appMock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		appMock.ExpectQuery(`.*pg_stat_user_tables`).WillReturnRows(
+			sqlmock.NewRows([]string{"schemaname", "relname", "size_bytes", "bloat_bytes"}).
+				AddRow("public", "orders", 9000.0, 900.0))
+
+		exporter.collectTableBloatEstimate(db, 20, func(dbname string) (*sql.DB, error) {
+			if dbname == "broken" {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return appDB, nil
+		})
+		Expect(appMock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeVecValues(exporter.Metrics.DatabaseMetrics.TableBloatEstimateBytes)).To(HaveLen(1))
+	})
+})
@@ -24,6 +24,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"time"
@@ -44,6 +45,10 @@ import (
 // or the operator
 const PrometheusNamespace = "cnpg"
 
+// certificateExpirationLayout is the layout of the expiration of the
+// certificates in the status of the cluster
+const certificateExpirationLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
 var synchronousStandbyNamesRegex = regexp.MustCompile(`(?:ANY|FIRST) ([0-9]+) \(.*\)`)
 
 // Exporter exports a set of metrics and collectors on a given postgres instance
@@ -72,6 +77,10 @@ type metrics struct {
 	SyncReplicas                 *prometheus.GaugeVec
 	ReplicaCluster               prometheus.Gauge
 	PgWALArchiveStatus           *prometheus.GaugeVec
+	PgWALArchiveOldestReadyAge   prometheus.Gauge
+	PgWALUnarchivedBytes         prometheus.Gauge
+	PgWALAvailableBytes          prometheus.Gauge
+	WALArchivingPaused           prometheus.Gauge
 	PgWALDirectory               *prometheus.GaugeVec
 	PgVersion                    *prometheus.GaugeVec
 	FirstRecoverabilityPoint     prometheus.Gauge
@@ -80,6 +89,13 @@ type metrics struct {
 	FencingOn                    prometheus.Gauge
 	PgStatWalMetrics             PgStatWalMetrics
 	NodesUsed                    prometheus.Gauge
+	CertificateExpiration        *prometheus.GaugeVec
+	MaxXIDAge                    prometheus.Gauge
+	MaxMXIDAge                   prometheus.Gauge
+	BufferCacheHitRatio          prometheus.Gauge
+	PgStatCheckpointMetrics      PgStatCheckpointMetrics
+	ReplicationSlotsMetrics      ReplicationSlotsMetrics
+	DatabaseMetrics              DatabaseMetrics
 }
 
 // PgStatWalMetrics is available from PG14+
@@ -164,6 +180,34 @@ func newMetrics() *metrics {
 			Help: fmt.Sprintf("Number of WAL segments in the '%s' directory (ready, done)",
 				specs.PgWalArchiveStatusPath),
 		}, []string{"value"}),
+		PgWALArchiveOldestReadyAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "pg_wal_archive_oldest_ready_age_seconds",
+			Help: fmt.Sprintf("Age in seconds of the oldest WAL segment marked as ready in the '%s' directory "+
+				"(0 if no WAL segment is waiting to be archived)",
+				specs.PgWalArchiveStatusPath),
+		}),
+		PgWALUnarchivedBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "pg_wal_unarchived_bytes",
+			Help: "Amount of WAL, in bytes, written by the primary after the end of the " +
+				"last archived WAL segment (NaN if no WAL segment has been archived yet)",
+		}),
+		PgWALAvailableBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "pg_wal_available_bytes",
+			Help: fmt.Sprintf("Space available, in bytes, in the volume holding the '%s' directory",
+				specs.PgWalPath),
+		}),
+		WALArchivingPaused: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "wal_archiving_paused",
+			Help:      "1 if the WAL archiving is paused on this instance, 0 otherwise",
+		}),
 		PgVersion: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: PrometheusNamespace,
 			Subsystem: subsystem,
@@ -213,6 +257,37 @@ func newMetrics() *metrics {
 				"implying the absence of High Availability (HA). Ideally this value " +
 				"should match the number of instances in the cluster.",
 		}),
+		CertificateExpiration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "certificate_expiration_timestamp_seconds",
+			Help: "The expiration of the certificates used by the cluster as a unix timestamp, " +
+				"as reported in the status of the cluster",
+		}, []string{"secret"}),
+		MaxXIDAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "max_xid_age",
+			Help: "Age of the oldest unfrozen transaction ID across all databases " +
+				"(max(age(datfrozenxid)) from pg_database)",
+		}),
+		MaxMXIDAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "max_mxid_age",
+			Help: "Age of the oldest unfrozen multixact ID across all databases " +
+				"(max(mxid_age(datminmxid)) from pg_database)",
+		}),
+		BufferCacheHitRatio: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "buffer_cache_hit_ratio",
+			Help: "Ratio of the blocks found in the shared buffers over the blocks requested " +
+				"across all databases (blks_hit / (blks_hit + blks_read) from pg_stat_database)",
+		}),
+		PgStatCheckpointMetrics: newPgStatCheckpointMetrics(subsystem),
+		ReplicationSlotsMetrics: newReplicationSlotsMetrics(subsystem),
+		DatabaseMetrics:         newDatabaseMetrics(subsystem),
 		PgStatWalMetrics: PgStatWalMetrics{
 			WalRecords: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: PrometheusNamespace,
@@ -285,6 +360,10 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.SyncReplicas.Describe(ch)
 	ch <- e.Metrics.ReplicaCluster.Desc()
 	e.Metrics.PgWALArchiveStatus.Describe(ch)
+	ch <- e.Metrics.PgWALArchiveOldestReadyAge.Desc()
+	ch <- e.Metrics.PgWALUnarchivedBytes.Desc()
+	ch <- e.Metrics.PgWALAvailableBytes.Desc()
+	ch <- e.Metrics.WALArchivingPaused.Desc()
 	e.Metrics.PgWALDirectory.Describe(ch)
 	e.Metrics.PgVersion.Describe(ch)
 	e.Metrics.FirstRecoverabilityPoint.Describe(ch)
@@ -292,6 +371,13 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.LastFailedBackupTimestamp.Describe(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Describe(ch)
 	e.Metrics.NodesUsed.Describe(ch)
+	e.Metrics.CertificateExpiration.Describe(ch)
+	e.Metrics.MaxXIDAge.Describe(ch)
+	e.Metrics.MaxMXIDAge.Describe(ch)
+	e.Metrics.BufferCacheHitRatio.Describe(ch)
+	e.Metrics.PgStatCheckpointMetrics.describe(ch)
+	e.Metrics.ReplicationSlotsMetrics.describe(ch)
+	e.Metrics.DatabaseMetrics.describe(ch)
 
 	if e.queries != nil {
 		e.queries.Describe(ch)
@@ -329,6 +415,10 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.Metrics.SyncReplicas.Collect(ch)
 	ch <- e.Metrics.ReplicaCluster
 	e.Metrics.PgWALArchiveStatus.Collect(ch)
+	ch <- e.Metrics.PgWALArchiveOldestReadyAge
+	ch <- e.Metrics.PgWALUnarchivedBytes
+	ch <- e.Metrics.PgWALAvailableBytes
+	ch <- e.Metrics.WALArchivingPaused
 	e.Metrics.PgWALDirectory.Collect(ch)
 	e.Metrics.PgVersion.Collect(ch)
 	e.Metrics.FirstRecoverabilityPoint.Collect(ch)
@@ -336,6 +426,13 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.Metrics.LastFailedBackupTimestamp.Collect(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Collect(ch)
 	e.Metrics.NodesUsed.Collect(ch)
+	e.Metrics.CertificateExpiration.Collect(ch)
+	e.Metrics.MaxXIDAge.Collect(ch)
+	e.Metrics.MaxMXIDAge.Collect(ch)
+	e.Metrics.BufferCacheHitRatio.Collect(ch)
+	e.Metrics.PgStatCheckpointMetrics.collect(ch)
+	e.Metrics.ReplicationSlotsMetrics.collect(ch)
+	e.Metrics.DatabaseMetrics.collect(ch)
 
 	if version, _ := e.instance.GetPgVersion(); version.Major >= 14 {
 		e.Metrics.PgStatWalMetrics.WalRecords.Collect(ch)
@@ -414,8 +511,29 @@ func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 
 	e.collectNodesUsed()
 
+	e.collectCertificateExpirations()
+
+	e.collectTransactionIDAges(db)
+
+	e.collectBufferCacheHitRatio(db)
+
+	if version, err := e.instance.GetPgVersion(); err == nil {
+		e.collectCheckpointStats(db, version.Major)
+	}
+
+	e.collectReplicationSlots(db)
+
+	e.collectDatabaseSizes(db)
+
 	// metrics collected only on primary server
 	if isPrimary {
+		// the dead tuples are only tracked by the primary
+		var maxTables int32
+		if cluster, _ := e.getCluster(); cluster != nil {
+			maxTables = cluster.Spec.Monitoring.GetTableBloatEstimateMaxTables()
+		}
+		e.collectTableBloatEstimate(db, maxTables, e.instance.ConnectionPool().Connection)
+
 		// getting required synchronous standby number from postgres itself
 		e.collectFromPrimarySynchronousStandbysNumber(db)
 
@@ -426,6 +544,14 @@ func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 		e.collectFromPrimaryLastAvailableBackupTimestamp()
 
 		e.collectFromPrimaryLastFailedBackupTimestamp()
+
+		e.collectFromPrimaryUnarchivedWAL(db)
+
+		e.collectFromPrimaryWALArchivingPaused(specs.PgWalPath)
+	} else {
+		e.Metrics.PgWALUnarchivedBytes.Set(math.NaN())
+		e.Metrics.WALArchivingPaused.Set(0)
+		e.Metrics.DatabaseMetrics.TableBloatEstimateBytes.Reset()
 	}
 
 	if err := collectPGWalArchiveMetric(e); err != nil {
@@ -433,8 +559,11 @@ func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 		e.Metrics.Error.Set(1)
 		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.PgWALArchiveStats").Inc()
 		e.Metrics.PgWALArchiveStatus.Reset()
+		e.Metrics.PgWALArchiveOldestReadyAge.Set(math.NaN())
 	}
 
+	e.collectPGWalAvailableBytes(specs.PgWalPath)
+
 	if err := collectPGWalSettings(e, db); err != nil {
 		log.Error(err, "while collecting WAL settings", "path", specs.PgWalPath)
 		e.Metrics.Error.Set(1)
@@ -522,6 +651,49 @@ func (e *Exporter) collectNodesUsed() {
 	e.Metrics.NodesUsed.Set(float64(cluster.Status.Topology.NodesUsed))
 }
 
+func (e *Exporter) collectCertificateExpirations() {
+	e.Metrics.CertificateExpiration.Reset()
+
+	cluster, err := e.getCluster()
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return
+	}
+	if err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.CertificateExpirations").Inc()
+		return
+	}
+
+	for secretName, expiration := range cluster.Status.Certificates.Expirations {
+		expirationTime, err := time.Parse(certificateExpirationLayout, expiration)
+		if err != nil {
+			log.Error(err, "while parsing the expiration of a certificate",
+				"secretName", secretName, "expiration", expiration)
+			continue
+		}
+		e.Metrics.CertificateExpiration.WithLabelValues(secretName).Set(float64(expirationTime.Unix()))
+	}
+}
+
+func (e *Exporter) collectTransactionIDAges(db *sql.DB) {
+	var xidAge, mxidAge int64
+	row := db.QueryRow(
+		`SELECT
+			COALESCE(max(pg_catalog.age(datfrozenxid)), 0),
+			COALESCE(max(pg_catalog.mxid_age(datminmxid)), 0)
+		FROM pg_catalog.pg_database`)
+	if err := row.Scan(&xidAge, &mxidAge); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.TransactionIDAges").Inc()
+		return
+	}
+
+	e.Metrics.MaxXIDAge.Set(float64(xidAge))
+	e.Metrics.MaxMXIDAge.Set(float64(mxidAge))
+}
+
 func (e *Exporter) collectFromPrimaryLastFailedBackupTimestamp() {
 	const errorLabel = "Collect.LastFailedBackupTimestamp"
 	e.setTimestampMetric(e.Metrics.LastFailedBackupTimestamp, errorLabel, func(cluster *apiv1.Cluster) string {
@@ -196,6 +196,60 @@ var _ = Describe("test metrics parsing", func() {
 		}
 	})
 
+	It("collects the transaction ID ages", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		rows := sqlmock.NewRows([]string{"max_xid_age", "max_mxid_age"}).
+			AddRow(int64(150000000), int64(2000))
+		mock.ExpectQuery(`.*pg_database`).WillReturnRows(rows)
+
+		exporter.collectTransactionIDAges(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(exporter.Metrics.MaxXIDAge)
+		registry.MustRegister(exporter.Metrics.MaxMXIDAge)
+		metrics, _ := registry.Gather()
+		Expect(metrics).To(HaveLen(2))
+
+		values := make(map[string]float64, len(metrics))
+		for _, metric := range metrics {
+			values[metric.GetName()] = metric.GetMetric()[0].GetGauge().GetValue()
+		}
+		Expect(values).To(HaveKeyWithValue("cnpg_collector_max_xid_age", BeEquivalentTo(150000000)))
+		Expect(values).To(HaveKeyWithValue("cnpg_collector_max_mxid_age", BeEquivalentTo(2000)))
+	})
+
+	It("collects the expiration of the certificates", func() {
+		expiration := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return &apiv1.Cluster{
+				Status: apiv1.ClusterStatus{
+					Certificates: apiv1.CertificatesStatus{
+						Expirations: map[string]string{
+							"cluster-example-server": expiration.String(),
+							"cluster-example-ca":     "not a date",
+						},
+					},
+				},
+			}, nil
+		}
+
+		exporter.collectCertificateExpirations()
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(exporter.Metrics.CertificateExpiration)
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		expirationMetric := getMetric(metrics, "cnpg_collector_certificate_expiration_timestamp_seconds")
+		Expect(expirationMetric).ToNot(BeNil())
+		Expect(expirationMetric.GetMetric()).To(HaveLen(1))
+		Expect(expirationMetric.GetMetric()[0].GetLabel()[0].GetValue()).To(Equal("cluster-example-server"))
+		Expect(expirationMetric.GetMetric()[0].GetGauge().GetValue()).To(BeEquivalentTo(expiration.Unix()))
+	})
+
 	Context("collectUsedNodes", func() {
 		const (
 			nodesUsedName         = "cnpg_collector_nodes_used"
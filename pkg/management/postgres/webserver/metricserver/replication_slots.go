@@ -0,0 +1,159 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+const (
+	// replicationSlotTypeHA is the type of the replication slots
+	// managed by the operator for High Availability
+	replicationSlotTypeHA = "ha"
+
+	// replicationSlotTypeUser is the type of every other replication slot
+	replicationSlotTypeUser = "user"
+)
+
+// ReplicationSlotsMetrics contains the metrics about the replication
+// slots, that retain WAL files until they are consumed
+type ReplicationSlotsMetrics struct {
+	Inactive         *prometheus.GaugeVec
+	WALRetainedBytes *prometheus.GaugeVec
+	SafeWALSizeBytes *prometheus.GaugeVec
+}
+
+func newReplicationSlotsMetrics(subsystem string) ReplicationSlotsMetrics {
+	return ReplicationSlotsMetrics{
+		Inactive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replication_slots_inactive",
+			Help: "Number of inactive replication slots, by type " +
+				"('ha' for the slots managed by the operator, 'user' otherwise)",
+		}, []string{"type"}),
+		WALRetainedBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replication_slot_wal_retained_bytes",
+			Help: "Amount of WAL retained by the replication slot, in bytes " +
+				"(difference between the current LSN and the restart_lsn of the slot)",
+		}, []string{"slot_name", "type"}),
+		SafeWALSizeBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replication_slot_safe_wal_size_bytes",
+			Help: "Amount of WAL that can be written before the replication slot loses " +
+				"the WAL files it needs, in bytes (only when max_slot_wal_keep_size is set)",
+		}, []string{"slot_name", "type"}),
+	}
+}
+
+func (m ReplicationSlotsMetrics) describe(ch chan<- *prometheus.Desc) {
+	m.Inactive.Describe(ch)
+	m.WALRetainedBytes.Describe(ch)
+	m.SafeWALSizeBytes.Describe(ch)
+}
+
+func (m ReplicationSlotsMetrics) collect(ch chan<- prometheus.Metric) {
+	m.Inactive.Collect(ch)
+	m.WALRetainedBytes.Collect(ch)
+	m.SafeWALSizeBytes.Collect(ch)
+}
+
+const replicationSlotsQuery = `SELECT slot_name, active,
+	COALESCE(pg_catalog.pg_wal_lsn_diff(
+		CASE pg_catalog.pg_is_in_recovery()
+			WHEN TRUE THEN COALESCE(pg_catalog.pg_last_wal_receive_lsn(), pg_catalog.pg_last_wal_replay_lsn())
+			ELSE pg_catalog.pg_current_wal_lsn()
+		END, restart_lsn), 0),
+	safe_wal_size
+	FROM pg_catalog.pg_replication_slots
+	WHERE NOT temporary`
+
+func (e *Exporter) collectReplicationSlots(db *sql.DB) {
+	slotMetrics := e.Metrics.ReplicationSlotsMetrics
+	slotMetrics.Inactive.Reset()
+	slotMetrics.WALRetainedBytes.Reset()
+	slotMetrics.SafeWALSizeBytes.Reset()
+
+	var haConfig *apiv1.ReplicationSlotsHAConfiguration
+	if cluster, _ := e.getCluster(); cluster != nil && cluster.Spec.ReplicationSlots != nil {
+		haConfig = cluster.Spec.ReplicationSlots.HighAvailability
+	}
+	haSlotPrefix := haConfig.GetSlotPrefix()
+
+	rows, err := db.Query(replicationSlotsQuery)
+	if err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.ReplicationSlots").Inc()
+		return
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	inactive := map[string]int{
+		replicationSlotTypeHA:   0,
+		replicationSlotTypeUser: 0,
+	}
+	for rows.Next() {
+		var slotName string
+		var active bool
+		var retainedBytes float64
+		var safeWALSize sql.NullFloat64
+		if err := rows.Scan(&slotName, &active, &retainedBytes, &safeWALSize); err != nil {
+			log.Error(err, "unable to collect metrics")
+			e.Metrics.Error.Set(1)
+			e.Metrics.PgCollectionErrors.WithLabelValues("Collect.ReplicationSlots").Inc()
+			return
+		}
+
+		slotType := replicationSlotTypeUser
+		if strings.HasPrefix(slotName, haSlotPrefix) {
+			slotType = replicationSlotTypeHA
+		}
+
+		if !active {
+			inactive[slotType]++
+		}
+		slotMetrics.WALRetainedBytes.WithLabelValues(slotName, slotType).Set(retainedBytes)
+		if safeWALSize.Valid {
+			slotMetrics.SafeWALSizeBytes.WithLabelValues(slotName, slotType).Set(safeWALSize.Float64)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.ReplicationSlots").Inc()
+		return
+	}
+
+	for slotType, count := range inactive {
+		slotMetrics.Inactive.WithLabelValues(slotType).Set(float64(count))
+	}
+}
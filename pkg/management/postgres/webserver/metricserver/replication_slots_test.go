@@ -0,0 +1,154 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// gatherGaugeVecValues returns the values of the passed gauge vector,
+// indexed by the comma separated values of their labels
+func gatherGaugeVecValues(vec *prometheus.GaugeVec) map[string]float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(vec)
+	metrics, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	values := make(map[string]float64)
+	for _, family := range metrics {
+		for _, metric := range family.GetMetric() {
+			labelValues := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labelValues = append(labelValues, label.GetValue())
+			}
+			values[strings.Join(labelValues, ",")] = metric.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+var _ = Describe("replication slots metrics", func() {
+	var exporter *Exporter
+
+	BeforeEach(func() {
+		cache.Delete(cache.ClusterKey)
+		exporter = NewExporter(postgres.NewInstance(), fakePluginCollector{})
+	})
+
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"slot_name", "active", "retained_bytes", "safe_wal_size"}).
+			AddRow("_cnpg_cluster_example_2", true, 1024.0, nil).
+			AddRow("_cnpg_cluster_example_3", false, 4096.0, nil).
+			AddRow("logical_slot", false, 8192.0, nil).
+			AddRow("physical_slot", false, 0.0, 65536.0)
+	}
+
+	It("collects the retained WAL and the inactive slots by type", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`.*pg_replication_slots`).WillReturnRows(newRows())
+
+		exporter.collectReplicationSlots(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		slotMetrics := exporter.Metrics.ReplicationSlotsMetrics
+		Expect(gatherGaugeVecValues(slotMetrics.Inactive)).To(Equal(map[string]float64{
+			"ha":   1,
+			"user": 2,
+		}))
+		Expect(gatherGaugeVecValues(slotMetrics.WALRetainedBytes)).To(Equal(map[string]float64{
+			"_cnpg_cluster_example_2,ha": 1024,
+			"_cnpg_cluster_example_3,ha": 4096,
+			"logical_slot,user":          8192,
+			"physical_slot,user":         0,
+		}))
+		Expect(gatherGaugeVecValues(slotMetrics.SafeWALSizeBytes)).To(Equal(map[string]float64{
+			"physical_slot,user": 65536,
+		}))
+	})
+
+	It("uses the HA slot prefix configured in the cluster", func() {
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+						HighAvailability: &apiv1.ReplicationSlotsHAConfiguration{
+							SlotPrefix: "logical_",
+						},
+					},
+				},
+			}, nil
+		}
+
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`.*pg_replication_slots`).WillReturnRows(newRows())
+
+		exporter.collectReplicationSlots(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		slotMetrics := exporter.Metrics.ReplicationSlotsMetrics
+		Expect(gatherGaugeVecValues(slotMetrics.Inactive)).To(Equal(map[string]float64{
+			"ha":   1,
+			"user": 2,
+		}))
+		Expect(gatherGaugeVecValues(slotMetrics.WALRetainedBytes)).To(HaveKey("logical_slot,ha"))
+	})
+
+	It("drops the metrics of the slots that have been removed", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`.*pg_replication_slots`).WillReturnRows(newRows())
+		mock.ExpectQuery(`.*pg_replication_slots`).
+			WillReturnRows(sqlmock.NewRows([]string{"slot_name", "active", "retained_bytes", "safe_wal_size"}))
+
+		exporter.collectReplicationSlots(db)
+		exporter.collectReplicationSlots(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		slotMetrics := exporter.Metrics.ReplicationSlotsMetrics
+		Expect(gatherGaugeVecValues(slotMetrics.WALRetainedBytes)).To(BeEmpty())
+		Expect(gatherGaugeVecValues(slotMetrics.Inactive)).To(Equal(map[string]float64{
+			"ha":   0,
+			"user": 0,
+		}))
+	})
+
+	It("reports an error when the replication slots cannot be collected", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		mock.ExpectQuery(`.*pg_replication_slots`).WillReturnError(sqlmock.ErrCancelled)
+
+		exporter.collectReplicationSlots(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.Error)).
+			To(HaveKeyWithValue("cnpg_collector_last_collection_error", BeEquivalentTo(1)))
+	})
+})
@@ -24,12 +24,15 @@ import (
 	"math"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/archiver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/local"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/system/compatibility"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -41,9 +44,88 @@ func collectPGWalArchiveMetric(exporter *Exporter) error {
 
 	exporter.Metrics.PgWALArchiveStatus.WithLabelValues("ready").Set(float64(ready))
 	exporter.Metrics.PgWALArchiveStatus.WithLabelValues("done").Set(float64(done))
+
+	oldestReady, err := postgres.GetOldestReadyWALFileTime()
+	if err != nil {
+		return err
+	}
+
+	var oldestReadyAge float64
+	if !oldestReady.IsZero() {
+		oldestReadyAge = time.Since(oldestReady).Seconds()
+	}
+	exporter.Metrics.PgWALArchiveOldestReadyAge.Set(oldestReadyAge)
 	return nil
 }
 
+// collectFromPrimaryUnarchivedWAL collects the amount of WAL written after
+// the end of the last archived segment, which is the WAL that would be lost
+// together with the storage of the primary
+func (e *Exporter) collectFromPrimaryUnarchivedWAL(db *sql.DB) {
+	var unarchivedBytes sql.NullFloat64
+	row := db.QueryRow(`
+SELECT CASE WHEN a.last_archived_wal ~ '^[0-9A-F]{24}$' THEN
+	GREATEST(
+		pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_current_wal_lsn(), '0/0'::pg_lsn)
+		- ('x' || pg_catalog.substr(a.last_archived_wal, 9, 8))::bit(32)::bigint * 4294967296
+		- (('x' || pg_catalog.substr(a.last_archived_wal, 17, 8))::bit(32)::bigint + 1) * s.setting::bigint,
+		0)::float8
+END
+FROM pg_catalog.pg_stat_archiver a,
+	pg_catalog.pg_settings s
+WHERE s.name = 'wal_segment_size'`)
+	if err := row.Scan(&unarchivedBytes); err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.PgWALUnarchivedBytes").Inc()
+		e.Metrics.PgWALUnarchivedBytes.Set(math.NaN())
+		return
+	}
+
+	// No WAL segment has been archived yet
+	if !unarchivedBytes.Valid {
+		e.Metrics.PgWALUnarchivedBytes.Set(math.NaN())
+		return
+	}
+
+	e.Metrics.PgWALUnarchivedBytes.Set(unarchivedBytes.Float64)
+}
+
+// collectPGWalAvailableBytes collects the space available in the volume
+// holding the WAL files, which bounds the WAL that can be retained while
+// the WAL archiving is paused or failing
+func (e *Exporter) collectPGWalAvailableBytes(walPath string) {
+	available, err := compatibility.GetAvailableDiskSpace(walPath)
+	if err != nil {
+		log.Error(err, "unable to collect metrics", "path", walPath)
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.PgWALAvailableBytes").Inc()
+		e.Metrics.PgWALAvailableBytes.Set(math.NaN())
+		return
+	}
+
+	e.Metrics.PgWALAvailableBytes.Set(float64(available))
+}
+
+// collectFromPrimaryWALArchivingPaused collects whether the WAL archiving
+// is paused, i.e. the pause has been requested and not refused
+func (e *Exporter) collectFromPrimaryWALArchivingPaused(walPath string) {
+	cluster, err := e.getCluster()
+	if err != nil {
+		log.Error(err, "unable to collect metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.WALArchivingPaused").Inc()
+		e.Metrics.WALArchivingPaused.Set(0)
+		return
+	}
+
+	if paused, _ := archiver.IsPaused(cluster, walPath); paused {
+		e.Metrics.WALArchivingPaused.Set(1)
+		return
+	}
+	e.Metrics.WALArchivingPaused.Set(0)
+}
+
 func collectPGStatWAL(e *Exporter) error {
 	walStat, err := e.instance.TryGetPgStatWAL()
 	if walStat == nil || err != nil {
@@ -21,10 +21,13 @@ package metricserver
 
 import (
 	"database/sql"
+	"math"
 	"strconv"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -121,3 +124,54 @@ IN ('wal_segment_size', 'min_wal_size', 'max_wal_size', 'wal_keep_size', 'wal_ke
 		Expect(settings.maxSlotWalKeepSize).To(Equal(maxSlotWalKeepSize))
 	})
 })
+
+var _ = Describe("unarchived WAL metric", func() {
+	var exporter *Exporter
+
+	BeforeEach(func() {
+		cache.Delete(cache.ClusterKey)
+		exporter = NewExporter(postgres.NewInstance(), fakePluginCollector{})
+	})
+
+	It("collects the amount of WAL written after the last archived segment", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_stat_archiver`).
+			WillReturnRows(sqlmock.NewRows([]string{"unarchived_bytes"}).AddRow(float64(8192)))
+
+		exporter.collectFromPrimaryUnarchivedWAL(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.PgWALUnarchivedBytes)).
+			To(HaveKeyWithValue("cnpg_collector_pg_wal_unarchived_bytes", BeEquivalentTo(8192)))
+	})
+
+	It("reports an undefined value when no WAL segment has been archived", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_stat_archiver`).
+			WillReturnRows(sqlmock.NewRows([]string{"unarchived_bytes"}).AddRow(nil))
+
+		exporter.collectFromPrimaryUnarchivedWAL(db)
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		values := gatherGaugeValues(exporter.Metrics.PgWALUnarchivedBytes)
+		Expect(math.IsNaN(values["cnpg_collector_pg_wal_unarchived_bytes"])).To(BeTrue())
+	})
+})
+
+var _ = Describe("WAL volume available space metric", func() {
+	It("collects the space available in the volume holding the WAL files", func() {
+		exporter := NewExporter(postgres.NewInstance(), fakePluginCollector{})
+		exporter.collectPGWalAvailableBytes(GinkgoT().TempDir())
+		Expect(gatherGaugeValues(exporter.Metrics.PgWALAvailableBytes)).
+			To(HaveKeyWithValue("cnpg_collector_pg_wal_available_bytes", BeNumerically(">", 0)))
+	})
+
+	It("reports an undefined value when the directory doesn't exist", func() {
+		exporter := NewExporter(postgres.NewInstance(), fakePluginCollector{})
+		exporter.collectPGWalAvailableBytes("/nonexistent/pg_wal")
+		values := gatherGaugeValues(exporter.Metrics.PgWALAvailableBytes)
+		Expect(math.IsNaN(values["cnpg_collector_pg_wal_available_bytes"])).To(BeTrue())
+	})
+})
@@ -44,6 +44,7 @@ type PluginBackupCommand struct {
 	Backup   *apiv1.Backup
 	Client   client.Client
 	Recorder record.EventRecorder
+	Instance *postgres.Instance
 }
 
 // NewPluginBackupCommand initializes a BackupCommand object, taking a physical
@@ -53,6 +54,7 @@ func NewPluginBackupCommand(
 	backup *apiv1.Backup,
 	client client.Client,
 	recorder record.EventRecorder,
+	instance *postgres.Instance,
 ) *PluginBackupCommand {
 	backup.EnsureGVKIsPresent()
 
@@ -61,6 +63,7 @@ func NewPluginBackupCommand(
 		Backup:   backup,
 		Client:   client,
 		Recorder: recorder,
+		Instance: instance,
 	}
 }
 
@@ -158,6 +161,8 @@ func (b *PluginBackupCommand) invokeStart(ctx context.Context) {
 	}); err != nil {
 		contextLogger.Error(err, "Can't update the cluster with the completed backup data")
 	}
+
+	postgres.RunPhysicalPostBackupHook(ctx, b.Cluster, b.Backup, b.Recorder)
 }
 
 func (b *PluginBackupCommand) markBackupAsFailed(ctx context.Context, failure error) {
@@ -120,6 +120,18 @@ func (e *executor) IsHealthy(
 		return
 	}
 
+	if e.probeType == probeTypeReadiness {
+		if err := checkReadinessQuery(ctx, e.instance, cluster); err != nil {
+			contextLogger.Warning("readiness query failing", "err", err.Error())
+			http.Error(
+				w,
+				fmt.Sprintf("%s check failed: %s", e.probeType, err.Error()),
+				http.StatusInternalServerError,
+			)
+			return
+		}
+	}
+
 	contextLogger.Trace(fmt.Sprintf("%s probe succeeding", e.probeType))
 	_, _ = fmt.Fprint(w, "OK")
 }
@@ -0,0 +1,115 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// defaultReadinessQueryDatabase is the database where the readiness query
+// runs when there's no application database
+const defaultReadinessQueryDatabase = "postgres"
+
+// getReadinessQuery gets the custom query of the readiness probe, if set
+func getReadinessQuery(cluster apiv1.Cluster) *apiv1.ProbeQuery {
+	if cluster.Spec.Probes == nil || cluster.Spec.Probes.Readiness == nil {
+		return nil
+	}
+
+	return cluster.Spec.Probes.Readiness.Query
+}
+
+// getReadinessQueryDatabase gets the database where the readiness
+// query is run
+func getReadinessQueryDatabase(cluster apiv1.Cluster, query *apiv1.ProbeQuery) string {
+	if query.Database != "" {
+		return query.Database
+	}
+	if database := cluster.GetApplicationDatabaseName(); database != "" {
+		return database
+	}
+
+	return defaultReadinessQueryDatabase
+}
+
+// checkReadinessQuery runs the custom query of the readiness probe on the
+// primary instance, returning an error describing why the instance is
+// not ready when the query fails or doesn't return the expected result
+func checkReadinessQuery(ctx context.Context, instance *postgres.Instance, cluster apiv1.Cluster) error {
+	query := getReadinessQuery(cluster)
+	if query == nil {
+		instance.SetReadinessQueryFailing(false)
+		return nil
+	}
+
+	isPrimary, err := instance.IsPrimary()
+	if err != nil {
+		return fmt.Errorf("while checking if the instance is a primary: %w", err)
+	}
+	if !isPrimary {
+		instance.SetReadinessQueryFailing(false)
+		return nil
+	}
+
+	err = runReadinessQuery(ctx, instance, getReadinessQueryDatabase(cluster, query), query)
+	instance.SetReadinessQueryFailing(err != nil)
+	return err
+}
+
+func runReadinessQuery(
+	ctx context.Context,
+	instance *postgres.Instance,
+	database string,
+	query *apiv1.ProbeQuery,
+) error {
+	db, err := instance.ConnectionPool().Connection(database)
+	if err != nil {
+		return fmt.Errorf("while getting a connection to database %q: %w", database, err)
+	}
+
+	var result sql.NullString
+	if err := db.QueryRowContext(ctx, query.SQL).Scan(&result); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("readiness query returned no rows")
+		}
+		return fmt.Errorf("while running the readiness query: %w", err)
+	}
+
+	return checkReadinessQueryResult(result, query.GetExpectedResult())
+}
+
+// checkReadinessQueryResult compares the result of the readiness
+// query with the expected one
+func checkReadinessQueryResult(result sql.NullString, expectedResult string) error {
+	if !result.Valid {
+		return fmt.Errorf("readiness query returned NULL, expected %q", expectedResult)
+	}
+	if result.String != expectedResult {
+		return fmt.Errorf("readiness query returned %q, expected %q", result.String, expectedResult)
+	}
+
+	return nil
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"go.uber.org/multierr"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -68,6 +69,7 @@ type remoteWebserverEndpoints struct {
 	ongoingBackupRequest sync.Mutex
 	// livenessChecker is a  stateful probe
 	livenessChecker probes.Checker
+	eventRecorder   record.EventRecorder
 }
 
 // StartBackupRequest the required data to execute the pg_start_backup
@@ -87,11 +89,17 @@ func NewStopBackupRequest(backupName string) *StopBackupRequest {
 	return &StopBackupRequest{BackupName: backupName}
 }
 
+// PostBackupHookRequest the required data to run the post-backup hook
+type PostBackupHookRequest struct {
+	BackupName string `json:"backupName"`
+}
+
 // NewRemoteWebServer returns a webserver that allows connection from external clients
 func NewRemoteWebServer(
 	instance *postgres.Instance,
 	cancelFunc context.CancelFunc,
 	exitedConditions concurrency.MultipleExecuted,
+	recorder record.EventRecorder,
 ) (*Webserver, error) {
 	typedClient, err := management.NewControllerRuntimeClient()
 	if err != nil {
@@ -102,11 +110,13 @@ func NewRemoteWebServer(
 		typedClient:     typedClient,
 		instance:        instance,
 		livenessChecker: probes.NewLivenessChecker(typedClient, instance),
+		eventRecorder:   recorder,
 	}
 
 	serveMux := http.NewServeMux()
 	serveMux.HandleFunc(url.PathFailSafe, endpoints.failSafe)
 	serveMux.HandleFunc(url.PathPgModeBackup, endpoints.backup)
+	serveMux.HandleFunc(url.PathPgBackupHook, endpoints.postBackupHook)
 	serveMux.HandleFunc(url.PathHealth, endpoints.isServerHealthy)
 	serveMux.HandleFunc(url.PathReady, endpoints.isServerReady)
 	serveMux.HandleFunc(url.PathStartup, endpoints.isServerStartedUp)
@@ -463,6 +473,58 @@ func (ws *remoteWebserverEndpoints) backup(w http.ResponseWriter, req *http.Requ
 	}
 }
 
+// postBackupHook runs in background the post-backup hook of a completed
+// backup taken by the operator, such as a volume snapshot one
+func (ws *remoteWebserverEndpoints) postBackupHook(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "wrong method used", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var p PostBackupHookRequest
+	if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+		sendBadRequestJSONResponse(w, "FAILED_TO_PARSE_REQUEST", "Failed to parse request body")
+		return
+	}
+	defer func() {
+		if err := req.Body.Close(); err != nil {
+			log.Error(err, "while closing the body")
+		}
+	}()
+
+	var cluster apiv1.Cluster
+	if err := ws.typedClient.Get(req.Context(),
+		client.ObjectKey{
+			Namespace: ws.instance.GetNamespaceName(),
+			Name:      ws.instance.GetClusterName(),
+		},
+		&cluster); err != nil {
+		sendBadRequestJSONResponse(w, "NO_CLUSTER_FOUND", err.Error())
+		return
+	}
+
+	var backup apiv1.Backup
+	if err := ws.typedClient.Get(req.Context(),
+		client.ObjectKey{
+			Namespace: ws.instance.GetNamespaceName(),
+			Name:      p.BackupName,
+		},
+		&backup); err != nil {
+		sendBadRequestJSONResponse(w, "NO_BACKUP_FOUND", err.Error())
+		return
+	}
+
+	if backup.Status.Phase != apiv1.BackupPhaseCompleted {
+		sendUnprocessableEntityJSONResponse(w, "BACKUP_NOT_COMPLETED",
+			fmt.Sprintf("Phase is: %s", backup.Status.Phase))
+		return
+	}
+
+	go postgres.RunPhysicalPostBackupHook(context.Background(), &cluster, &backup, ws.eventRecorder)
+
+	sendJSONResponseWithData(w, 200, struct{}{})
+}
+
 func (ws *remoteWebserverEndpoints) pgArchivePartial(w http.ResponseWriter, req *http.Request) {
 	if !ws.instance.IsFenced() {
 		sendBadRequestJSONResponse(w, "NOT_FENCED", "")
@@ -61,6 +61,9 @@ const (
 	// PathPgModeBackup is the URL path to interact with pg_start_backup and pg_stop_backup
 	PathPgModeBackup string = "/pg/mode/backup"
 
+	// PathPgBackupHook is the URL path to run the post-backup hook of a completed backup
+	PathPgBackupHook string = "/pg/backup/hook"
+
 	// PathPgArchivePartial is the URL path to interact with the partial wal archive
 	PathPgArchivePartial string = "/pg/archive/partial"
 
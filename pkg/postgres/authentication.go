@@ -0,0 +1,153 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// AuthenticationRulesStatus describes the authentication rules that the
+// instance loaded from the Secrets and ConfigMaps referenced in the cluster
+type AuthenticationRulesStatus struct {
+	// The versions of the Secrets whose rules have been applied
+	SecretVersions map[string]string `json:"secretVersions,omitempty"`
+
+	// The versions of the ConfigMaps whose rules have been applied
+	ConfigMapVersions map[string]string `json:"configMapVersions,omitempty"`
+
+	// The reason why the current content of the Secrets and ConfigMaps
+	// has been refused
+	Error string `json:"error,omitempty"`
+}
+
+// hbaConnectionTypes are the connection types accepted in a pg_hba.conf rule
+var hbaConnectionTypes = []string{
+	"local", "host", "hostssl", "hostnossl", "hostgssenc", "hostnogssenc",
+}
+
+// hbaMethods are the authentication methods accepted in a pg_hba.conf rule
+var hbaMethods = []string{
+	"trust", "reject", "scram-sha-256", "md5", "password", "gss", "sspi",
+	"ident", "peer", "ldap", "radius", "cert", "pam", "bsd", "oauth",
+}
+
+// ValidateHBARule checks that the passed line is a pg_hba.conf rule that
+// PostgreSQL can load, so that reloading the configuration with it doesn't
+// make the instance refuse every connection. Empty lines and comments are
+// accepted.
+func ValidateHBARule(rule string) error {
+	fields := strings.Fields(stripComment(rule))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	connectionType := fields[0]
+	if !slices.Contains(hbaConnectionTypes, connectionType) {
+		return fmt.Errorf("unknown connection type %q", connectionType)
+	}
+
+	// local rules have no address, and the address of the host rules may
+	// be given either in CIDR notation or followed by a mask
+	methodIndex := 3
+	if connectionType != "local" {
+		methodIndex = 4
+		if len(fields) > 5 && !strings.Contains(fields[3], "/") && !slices.Contains(hbaMethods, fields[4]) {
+			methodIndex = 5
+		}
+	}
+
+	if len(fields) <= methodIndex {
+		return fmt.Errorf("missing the authentication method in %q", rule)
+	}
+	if method := fields[methodIndex]; !slices.Contains(hbaMethods, method) {
+		return fmt.Errorf("unknown authentication method %q", method)
+	}
+
+	return nil
+}
+
+// GetHBARuleTypeAndMethod gets the connection type and the authentication
+// method of a pg_hba.conf rule. Empty strings are returned for the lines
+// which are not rules, like the comments and the include directives
+func GetHBARuleTypeAndMethod(rule string) (connectionType, method string) {
+	fields := strings.Fields(rule)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", ""
+	}
+
+	var methodIndex int
+	switch connectionType = fields[0]; connectionType {
+	case "local":
+		// local database user method
+		methodIndex = 3
+	case "host", "hostssl", "hostnossl", "hostgssenc", "hostnogssenc":
+		// host database user address method, where the address can also
+		// be an IP address followed by a separate IP mask
+		methodIndex = 4
+		if len(fields) > 4 && !strings.Contains(fields[3], "/") && net.ParseIP(fields[3]) != nil {
+			methodIndex = 5
+		}
+	default:
+		return "", ""
+	}
+
+	if len(fields) <= methodIndex {
+		return connectionType, ""
+	}
+	return connectionType, fields[methodIndex]
+}
+
+// ValidateHBARuleSCRAMOverSSL checks that the passed pg_hba.conf rule only
+// accepts connections over TLS authenticated with scram-sha-256, as required
+// by the requireSCRAMOverSSL option. Empty lines and comments are accepted.
+func ValidateHBARuleSCRAMOverSSL(rule string) error {
+	connectionType, method := GetHBARuleTypeAndMethod(rule)
+	switch {
+	case method != "" && method != "scram-sha-256" && method != "reject":
+		return errors.New("only the scram-sha-256 and reject authentication methods are allowed")
+	case connectionType == "hostnossl" && method != "reject":
+		return errors.New("connections without TLS are rejected")
+	}
+
+	return nil
+}
+
+// ValidateIdentRule checks that the passed line is a pg_ident.conf rule
+// that PostgreSQL can load. Empty lines and comments are accepted.
+func ValidateIdentRule(rule string) error {
+	fields := strings.Fields(stripComment(rule))
+	if len(fields) != 0 && len(fields) < 3 {
+		return fmt.Errorf("expected map name, system user name and database user name in %q", rule)
+	}
+
+	return nil
+}
+
+// stripComment removes the comment from a line of an authentication file
+func stripComment(line string) string {
+	if index := strings.IndexByte(line, '#'); index >= 0 {
+		return line[:index]
+	}
+	return line
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pg_hba.conf rules validation", func() {
+	DescribeTable("accepts the valid rules",
+		func(rule string) {
+			Expect(ValidateHBARule(rule)).To(Succeed())
+		},
+		Entry("empty line", ""),
+		Entry("comment", "# a comment"),
+		Entry("local rule", "local all all peer"),
+		Entry("CIDR address", "host all all 10.0.0.0/8 scram-sha-256"),
+		Entry("address and mask", "hostssl app app 10.0.0.0 255.0.0.0 cert"),
+		Entry("options", "host all all ldap.example.com ldap ldapserver=ldap.example.com"),
+		Entry("trailing comment", "hostnossl all all all reject # no plain text"),
+	)
+
+	DescribeTable("refuses the invalid rules",
+		func(rule string) {
+			Expect(ValidateHBARule(rule)).ToNot(Succeed())
+		},
+		Entry("unknown connection type", "hots all all 10.0.0.0/8 md5"),
+		Entry("missing method", "host all all 10.0.0.0/8"),
+		Entry("unknown method", "host all all 10.0.0.0/8 scram"),
+		Entry("unknown method of a local rule", "local all all 10.0.0.0/8"),
+	)
+})
+
+var _ = Describe("pg_ident.conf rules validation", func() {
+	It("accepts the valid rules", func() {
+		Expect(ValidateIdentRule("")).To(Succeed())
+		Expect(ValidateIdentRule("# a comment")).To(Succeed())
+		Expect(ValidateIdentRule("mymap /^(.*)@example\\.com$ \\1")).To(Succeed())
+	})
+
+	It("refuses the rules without the database user name", func() {
+		Expect(ValidateIdentRule("mymap app")).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("GetHBARuleTypeAndMethod", func() {
+	DescribeTable("parses the pg_hba.conf rules",
+		func(rule, expectedType, expectedMethod string) {
+			connectionType, method := GetHBARuleTypeAndMethod(rule)
+			Expect(connectionType).To(Equal(expectedType))
+			Expect(method).To(Equal(expectedMethod))
+		},
+		Entry("local rule", "local all all peer map=local", "local", "peer"),
+		Entry("CIDR address", "hostssl app app 10.0.0.0/8 scram-sha-256", "hostssl", "scram-sha-256"),
+		Entry("IP address and mask", "host all all 10.0.0.1 255.0.0.0 md5", "host", "md5"),
+		Entry("host name", "hostssl all all .example.com cert clientcert=verify-full", "hostssl", "cert"),
+		Entry("all addresses", "hostnossl all all all reject", "hostnossl", "reject"),
+		Entry("comment", "# host all all all trust", "", ""),
+		Entry("include directive", "include extra.conf", "", ""),
+		Entry("incomplete rule", "host all all", "host", ""),
+	)
+})
+
+var _ = Describe("ValidateHBARuleSCRAMOverSSL", func() {
+	DescribeTable("accepts the rules requiring SCRAM over TLS",
+		func(rule string) {
+			Expect(ValidateHBARuleSCRAMOverSSL(rule)).To(Succeed())
+		},
+		Entry("empty line", ""),
+		Entry("comment", "# host all all all trust"),
+		Entry("SCRAM authentication", "hostssl app app 10.0.0.0/8 scram-sha-256"),
+		Entry("rejected connections", "hostnossl all all all reject"),
+	)
+
+	DescribeTable("refuses the rules weakening the authentication",
+		func(rule string) {
+			Expect(ValidateHBARuleSCRAMOverSSL(rule)).ToNot(Succeed())
+		},
+		Entry("md5 authentication", "host all all 0.0.0.0/0 md5"),
+		Entry("trust authentication", "hostnossl all all all trust"),
+		Entry("SCRAM authentication without TLS", "hostnossl all all all scram-sha-256"),
+	)
+})
@@ -39,3 +39,13 @@ func ParsePostgresConfigBoolean(in string) (bool, error) {
 		return false, fmt.Errorf("configuration value is not a postgres boolean: %s", in)
 	}
 }
+
+// FormatPostgresConfigBoolean returns the postgres boolean representing
+// the passed value
+func FormatPostgresConfigBoolean(in bool) string {
+	if in {
+		return "on"
+	}
+
+	return "off"
+}
@@ -51,3 +51,10 @@ var _ = DescribeTable("Test parsing of PostgreSQL configuration booleans",
 	Entry("tr", "tr", true, false),
 	Entry("fa", "fa", false, false),
 )
+
+var _ = Describe("Test formatting of PostgreSQL configuration booleans", func() {
+	It("formats the values as on/off", func() {
+		Expect(FormatPostgresConfigBoolean(true)).To(Equal("on"))
+		Expect(FormatPostgresConfigBoolean(false)).To(Equal("off"))
+	})
+})
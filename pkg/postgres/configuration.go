@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -55,11 +56,105 @@ const (
 	// ParameterRecoveryMinApplyDelay is the configuration key containing the recovery_min_apply_delay parameter
 	ParameterRecoveryMinApplyDelay = "recovery_min_apply_delay"
 
+	// ParameterSSLMinProtocolVersion is the configuration key containing the minimum TLS protocol version
+	ParameterSSLMinProtocolVersion = "ssl_min_protocol_version"
+
+	// ParameterSSLMaxProtocolVersion is the configuration key containing the maximum TLS protocol version
+	ParameterSSLMaxProtocolVersion = "ssl_max_protocol_version"
+
+	// ParameterSSLCiphers is the configuration key containing the allowed TLS ciphers
+	ParameterSSLCiphers = "ssl_ciphers"
+
+	// ParameterLogMinDurationStatement is the configuration key containing the slow query threshold
+	ParameterLogMinDurationStatement = "log_min_duration_statement"
+
+	// ParameterLogStatement is the configuration key containing the kind of statements to be logged
+	ParameterLogStatement = "log_statement"
+
+	// ParameterLogConnections is the configuration key enabling the logging of the connections
+	ParameterLogConnections = "log_connections"
+
+	// ParameterLogDisconnections is the configuration key enabling the logging of the disconnections
+	ParameterLogDisconnections = "log_disconnections"
+
+	// ParameterHugePages is the configuration key containing the usage of the huge pages
+	ParameterHugePages = "huge_pages"
+
+	// ParameterHugePageSize is the configuration key containing the size of the huge pages
+	ParameterHugePageSize = "huge_page_size"
+
+	// ParameterClusterName is the configuration key containing the name
+	// shown in the process titles of PostgreSQL
+	ParameterClusterName = "cluster_name"
+
+	// ParameterIdleSessionTimeout is the configuration key containing the
+	// timeout of the sessions idle outside of a transaction
+	ParameterIdleSessionTimeout = "idle_session_timeout"
+
+	// ParameterIdleInTransactionSessionTimeout is the configuration key containing
+	// the timeout of the sessions idle within an open transaction
+	ParameterIdleInTransactionSessionTimeout = "idle_in_transaction_session_timeout"
+
+	// ParameterPasswordEncryption is the configuration key containing
+	// the algorithm used to hash the passwords
+	ParameterPasswordEncryption = "password_encryption"
+
+	// ParameterScramIterations is the configuration key containing the
+	// number of iterations used to compute the SCRAM-SHA-256 password hashes
+	ParameterScramIterations = "scram_iterations"
+
+	// ParameterAutovacuumNaptime is the configuration key containing the
+	// minimum delay between the autovacuum runs on a database
+	ParameterAutovacuumNaptime = "autovacuum_naptime"
+
+	// ParameterAutovacuumMaxWorkers is the configuration key containing the
+	// maximum number of autovacuum worker processes
+	ParameterAutovacuumMaxWorkers = "autovacuum_max_workers"
+
+	// ParameterAutovacuumVacuumCostDelay is the configuration key containing
+	// the cost delay of the automatic vacuum operations
+	ParameterAutovacuumVacuumCostDelay = "autovacuum_vacuum_cost_delay"
+
+	// ParameterAutovacuumVacuumCostLimit is the configuration key containing
+	// the cost limit of the automatic vacuum operations
+	ParameterAutovacuumVacuumCostLimit = "autovacuum_vacuum_cost_limit"
+
 	// ParameterSyncReplicationSlots the configuration key containing the sync_replication_slots value
 	ParameterSyncReplicationSlots = "sync_replication_slots"
 
 	// ParameterHotStandbyFeedback the configuration key containing the hot_standby_feedback value
 	ParameterHotStandbyFeedback = "hot_standby_feedback"
+
+	// ParameterTCPKeepalivesIdle the configuration key containing the tcp_keepalives_idle value
+	ParameterTCPKeepalivesIdle = "tcp_keepalives_idle"
+
+	// ParameterTCPKeepalivesInterval the configuration key containing the tcp_keepalives_interval value
+	ParameterTCPKeepalivesInterval = "tcp_keepalives_interval"
+
+	// ParameterTCPKeepalivesCount the configuration key containing the tcp_keepalives_count value
+	ParameterTCPKeepalivesCount = "tcp_keepalives_count"
+
+	// ParameterMaxConnections the configuration key containing the max_connections value
+	ParameterMaxConnections = "max_connections"
+
+	// ParameterSuperuserReservedConnections the configuration key containing
+	// the number of connection slots reserved to the superusers
+	ParameterSuperuserReservedConnections = "superuser_reserved_connections"
+
+	// ParameterReservedConnections the configuration key containing the number
+	// of connection slots reserved to the roles with the pg_use_reserved_connections
+	// privilege. Available since PostgreSQL 16
+	ParameterReservedConnections = "reserved_connections"
+
+	// ParameterEffectiveCacheSize the configuration key containing the
+	// size of the disk cache assumed by the query planner
+	ParameterEffectiveCacheSize = "effective_cache_size"
+
+	// MinSuperuserReservedConnections is the minimum number of connection
+	// slots reserved to the superusers. The instance manager connects as a
+	// superuser to reconcile the instance, collect its status, and drive
+	// a failover, so it must always find a free slot
+	MinSuperuserReservedConnections = 3
 )
 
 // An acceptable wal_level value
@@ -105,7 +200,10 @@ local all all peer map=local
 hostssl postgres streaming_replica all cert map=cnpg_streaming_replica
 hostssl replication streaming_replica all cert map=cnpg_streaming_replica
 hostssl all cnpg_pooler_pgbouncer all cert map=cnpg_pooler_pgbouncer
-
+{{ if .RequireSCRAMOverSSL }}
+# Reject the connections without TLS, as SCRAM over TLS is required
+hostnossl all all all reject
+{{ end }}
 #
 # USER-DEFINED RULES
 #
@@ -124,7 +222,7 @@ hostssl all cnpg_pooler_pgbouncer all cert map=cnpg_pooler_pgbouncer
 #
 # DEFAULT RULES
 #
-host all all all {{.DefaultAuthenticationMethod}}
+{{ if .RequireSCRAMOverSSL }}hostssl{{ else }}host{{ end }} all all all {{.DefaultAuthenticationMethod}}
 `
 
 	// identTemplateString is the template used to generate the pg_ident.conf
@@ -382,6 +480,49 @@ type ConfigurationInfo struct {
 
 	// The list of additional extensions to be loaded into the PostgreSQL configuration
 	AdditionalExtensions []AdditionalExtensionConfiguration
+
+	// The memory limit of the instance pods, in bytes, used to resolve the
+	// memory parameters expressed as a percentage. When zero, these values
+	// are kept unresolved
+	MemoryLimit int64
+
+	// The TLS settings requested by the user, overriding
+	// the corresponding parameters
+	SSLMinProtocolVersion string
+	SSLMaxProtocolVersion string
+	SSLCiphers            string
+
+	// The logging settings requested by the user, overriding
+	// the corresponding parameters
+	LogMinDurationStatement string
+	LogStatement            string
+	LogConnections          string
+	LogDisconnections       string
+
+	// The usage of the huge pages, when they are managed by the operator,
+	// overriding the corresponding parameter
+	HugePages string
+
+	// The size of the huge pages, when they are managed by the operator,
+	// overriding the corresponding parameter
+	HugePageSize string
+
+	// The idle sessions timeouts requested by the user, overriding
+	// the corresponding parameters
+	IdleSessionTimeout              string
+	IdleInTransactionSessionTimeout string
+
+	// The password hashing settings requested by the user, overriding
+	// the corresponding parameters
+	PasswordEncryption string
+	ScramIterations    string
+
+	// The autovacuum tuning options requested by the user, overriding
+	// the corresponding parameters
+	AutovacuumNaptime         string
+	AutovacuumMaxWorkers      string
+	AutovacuumVacuumCostDelay string
+	AutovacuumVacuumCostLimit string
 }
 
 // getAlterSystemEnabledValue returns a config compatible value for IsAlterSystemEnabled
@@ -473,7 +614,6 @@ var (
 		"archive_mode":              fixedConfigurationParameter,
 		"bonjour":                   blockedConfigurationParameter,
 		"bonjour_name":              blockedConfigurationParameter,
-		"cluster_name":              fixedConfigurationParameter,
 		"config_file":               blockedConfigurationParameter,
 		"data_directory":            blockedConfigurationParameter,
 		"data_sync_retry":           blockedConfigurationParameter,
@@ -538,6 +678,16 @@ var (
 		"syslog_split_messages":                  blockedConfigurationParameter,
 	}
 
+	// TCPKeepalivesDefaultSettings detect the broken TCP connections, such as
+	// the replication ones, way before the default of the operating system.
+	// They are applied only when the user sets any of them, not to change
+	// the configuration of the existing clusters
+	TCPKeepalivesDefaultSettings = SettingsCollection{
+		ParameterTCPKeepalivesIdle:     "60",
+		ParameterTCPKeepalivesInterval: "10",
+		ParameterTCPKeepalivesCount:    "6",
+	}
+
 	// CnpgConfigurationSettings contains the settings that represent the
 	// default and the mandatory behavior of CNP
 	CnpgConfigurationSettings = ConfigurationSettings{
@@ -586,10 +736,12 @@ var (
 )
 
 // CreateHBARules will create the content of pg_hba.conf file given
-// the rules set by the cluster spec
+// the rules set by the cluster spec. When SCRAM over TLS is required
+// the connections without TLS are rejected
 func CreateHBARules(
 	hba []string,
 	defaultAuthenticationMethod, ldapConfigString string,
+	requireSCRAMOverSSL bool,
 ) (string, error) {
 	var hbaContent bytes.Buffer
 
@@ -597,10 +749,12 @@ func CreateHBARules(
 		UserRules                   []string
 		LDAPConfiguration           string
 		DefaultAuthenticationMethod string
+		RequireSCRAMOverSSL         bool
 	}{
 		UserRules:                   hba,
 		LDAPConfiguration:           ldapConfigString,
 		DefaultAuthenticationMethod: defaultAuthenticationMethod,
+		RequireSCRAMOverSSL:         requireSCRAMOverSSL,
 	}
 
 	if err := hbaTemplate.Execute(&hbaContent, templateData); err != nil {
@@ -706,9 +860,95 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 		if isFixed && ignoreFixedSettingsFromUser {
 			continue
 		}
+		if _, isMemoryRelative := MemoryRelativeParameters[key]; isMemoryRelative &&
+			info.MemoryLimit > 0 && IsMemoryRelativeValue(value) {
+			resolvedValue, err := ResolveMemoryRelativeValue(value, info.MemoryLimit)
+			if err != nil {
+				log.Error(err, "Error while resolving memory parameter", "key", key, "value", value)
+			} else {
+				value = resolvedValue
+			}
+		}
 		configuration.OverwriteConfig(key, value)
 	}
 
+	// Size the cache available to the planner on the memory of the pod,
+	// unless set by the user
+	configuration.setDefaultEffectiveCacheSize(info)
+
+	// Complete the TCP keepalive settings, when the user set any of them
+	configuration.setDefaultTCPKeepalives(info)
+
+	// Preserve the connection slots needed by the instance manager
+	configuration.enforceSuperuserReservedConnections()
+
+	// Apply the TLS settings, on top of the parameters set by the user
+	if info.SSLMinProtocolVersion != "" {
+		configuration.OverwriteConfig(ParameterSSLMinProtocolVersion, info.SSLMinProtocolVersion)
+	}
+	if info.SSLMaxProtocolVersion != "" {
+		configuration.OverwriteConfig(ParameterSSLMaxProtocolVersion, info.SSLMaxProtocolVersion)
+	}
+	if info.SSLCiphers != "" {
+		configuration.OverwriteConfig(ParameterSSLCiphers, info.SSLCiphers)
+	}
+
+	// Apply the logging settings, on top of the parameters set by the user.
+	// The destination and the format of the logs are part of the mandatory
+	// settings, which are applied later and can't be changed
+	for key, value := range map[string]string{
+		ParameterLogMinDurationStatement: info.LogMinDurationStatement,
+		ParameterLogStatement:            info.LogStatement,
+		ParameterLogConnections:          info.LogConnections,
+		ParameterLogDisconnections:       info.LogDisconnections,
+	} {
+		if value != "" {
+			configuration.OverwriteConfig(key, value)
+		}
+	}
+
+	// Apply the usage of the huge pages, which are requested by the
+	// operator according to the shared memory of PostgreSQL
+	if info.HugePages != "" {
+		configuration.OverwriteConfig(ParameterHugePages, info.HugePages)
+	}
+	if info.HugePageSize != "" && info.MajorVersion >= 14 {
+		configuration.OverwriteConfig(ParameterHugePageSize, info.HugePageSize)
+	}
+
+	// Apply the idle sessions timeouts, on top of the parameters set by the user
+	if info.IdleSessionTimeout != "" {
+		configuration.OverwriteConfig(ParameterIdleSessionTimeout, info.IdleSessionTimeout)
+	}
+	if info.IdleInTransactionSessionTimeout != "" {
+		configuration.OverwriteConfig(ParameterIdleInTransactionSessionTimeout, info.IdleInTransactionSessionTimeout)
+	}
+
+	// Apply the password hashing settings, on top of the parameters set
+	// by the user. `scram_iterations` is not known by the versions
+	// preceding PostgreSQL 16, which would refuse to start
+	if info.PasswordEncryption != "" {
+		configuration.OverwriteConfig(ParameterPasswordEncryption, info.PasswordEncryption)
+	}
+	if info.ScramIterations != "" && info.MajorVersion >= 16 {
+		configuration.OverwriteConfig(ParameterScramIterations, info.ScramIterations)
+	}
+
+	// Apply the autovacuum tuning options, on top of the parameters set by
+	// the user. A change of `autovacuum_max_workers` is applied with a
+	// restart of the instances before PostgreSQL 18, like any other
+	// parameter pending a restart
+	for key, value := range map[string]string{
+		ParameterAutovacuumNaptime:         info.AutovacuumNaptime,
+		ParameterAutovacuumMaxWorkers:      info.AutovacuumMaxWorkers,
+		ParameterAutovacuumVacuumCostDelay: info.AutovacuumVacuumCostDelay,
+		ParameterAutovacuumVacuumCostLimit: info.AutovacuumVacuumCostLimit,
+	} {
+		if value != "" {
+			configuration.OverwriteConfig(key, value)
+		}
+	}
+
 	// Apply all mandatory settings, on top of defaults and user settings
 	if info.IncludingMandatory {
 		for key, value := range info.Settings.MandatorySettings {
@@ -759,8 +999,10 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 		}
 	}
 
-	if info.ClusterName != "" {
-		configuration.OverwriteConfig("cluster_name", info.ClusterName)
+	// Name the processes of PostgreSQL after the cluster, unless the
+	// user chose a different name
+	if _, isSet := info.UserSettings[ParameterClusterName]; !isSet && info.ClusterName != "" {
+		configuration.OverwriteConfig(ParameterClusterName, info.ClusterName)
 	}
 
 	// Apply the replication delay
@@ -819,6 +1061,65 @@ func (p *PgConfiguration) setDefaultConfigurations(info ConfigurationInfo) {
 	}
 }
 
+// enforceSuperuserReservedConnections raises the number of connection slots
+// reserved to the superusers to the managed minimum, when the user asked for
+// fewer of them
+func (p *PgConfiguration) enforceSuperuserReservedConnections() {
+	value := p.GetConfig(ParameterSuperuserReservedConnections)
+	if value == "" {
+		// The PostgreSQL default is already equal to the minimum
+		return
+	}
+
+	reservedConnections, err := strconv.Atoi(value)
+	if err != nil || reservedConnections >= MinSuperuserReservedConnections {
+		return
+	}
+
+	p.OverwriteConfig(ParameterSuperuserReservedConnections, strconv.Itoa(MinSuperuserReservedConnections))
+}
+
+// setDefaultEffectiveCacheSize sets `effective_cache_size` to a share of
+// the memory limit of the pods, when the user didn't set it. Without a
+// memory limit, the PostgreSQL default is kept
+func (p *PgConfiguration) setDefaultEffectiveCacheSize(info ConfigurationInfo) {
+	if _, isSet := info.UserSettings[ParameterEffectiveCacheSize]; isSet || info.MemoryLimit <= 0 {
+		return
+	}
+
+	value, err := ResolveMemoryRelativeValue(DefaultEffectiveCacheSize, info.MemoryLimit)
+	if err != nil {
+		return
+	}
+
+	p.OverwriteConfig(ParameterEffectiveCacheSize, value)
+}
+
+// setDefaultTCPKeepalives sets the TCP keepalive settings which the user
+// didn't set to their managed defaults, when the user set any of them
+func (p *PgConfiguration) setDefaultTCPKeepalives(info ConfigurationInfo) {
+	if !IsTCPKeepalivesConfigured(info.UserSettings) {
+		return
+	}
+
+	for key, value := range TCPKeepalivesDefaultSettings {
+		if _, isSet := info.UserSettings[key]; !isSet {
+			p.OverwriteConfig(key, value)
+		}
+	}
+}
+
+// IsTCPKeepalivesConfigured tells whether any of the TCP keepalive settings
+// is set in the passed parameters, enabling the managed ones
+func IsTCPKeepalivesConfigured(parameters map[string]string) bool {
+	for key := range TCPKeepalivesDefaultSettings {
+		if _, isSet := parameters[key]; isSet {
+			return true
+		}
+	}
+	return false
+}
+
 // setManagedSharedPreloadLibraries sets all additional preloaded libraries
 func (p *PgConfiguration) setManagedSharedPreloadLibraries(info ConfigurationInfo) {
 	for _, extension := range ManagedExtensions {
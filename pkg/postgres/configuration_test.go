@@ -258,19 +258,32 @@ var _ = Describe("pg_hba.conf generation", func() {
 	}
 
 	It("insert the spec configuration between an header and a footer when the version can not be parsed", func() {
-		Expect(CreateHBARules(specRules, "md5", "")).To(
+		Expect(CreateHBARules(specRules, "md5", "", false)).To(
 			ContainSubstring("\ntwo\n"))
 	})
 
 	It("really use the passed default authentication method", func() {
-		Expect(CreateHBARules(specRules, "this-one", "")).To(
+		Expect(CreateHBARules(specRules, "this-one", "", false)).To(
 			ContainSubstring("\nhost all all all this-one\n"))
 	})
 
 	It("really uses the ldapConfigString", func() {
-		Expect(CreateHBARules(specRules, "defaultAuthenticationMethod", "ldapConfigString")).To(
+		Expect(CreateHBARules(specRules, "defaultAuthenticationMethod", "ldapConfigString", false)).To(
 			ContainSubstring("\nldapConfigString\n"))
 	})
+
+	It("rejects the connections without TLS when SCRAM over TLS is required", func() {
+		rules, err := CreateHBARules(specRules, "scram-sha-256", "", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).ToNot(ContainSubstring("hostnossl"))
+
+		rules, err = CreateHBARules(specRules, "scram-sha-256", "", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(ContainSubstring("\nhostnossl all all all reject\n"))
+		Expect(rules).To(ContainSubstring("\nhostssl all all all scram-sha-256\n"))
+		Expect(rules).ToNot(ContainSubstring("\nhost all all all"))
+		Expect(strings.Index(rules, "hostnossl")).To(BeNumerically("<", strings.Index(rules, "\none\n")))
+	})
 })
 
 var _ = Describe("pg_ident.conf generation", func() {
@@ -412,6 +425,284 @@ var _ = Describe("recovery_min_apply_delay", func() {
 	})
 })
 
+var _ = Describe("TLS settings", func() {
+	It("keeps the default protocol versions when not specified", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			IncludingMandatory: true,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterSSLMinProtocolVersion)).To(Equal("TLSv1.3"))
+		Expect(config.GetConfig(ParameterSSLMaxProtocolVersion)).To(Equal("TLSv1.3"))
+		Expect(config.GetConfig(ParameterSSLCiphers)).To(BeEmpty())
+	})
+
+	It("overrides the parameters set by the user", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterSSLMinProtocolVersion: "TLSv1",
+			},
+			IncludingMandatory:    true,
+			SSLMinProtocolVersion: "TLSv1.2",
+			SSLCiphers:            "HIGH:!aNULL:!MD5",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterSSLMinProtocolVersion)).To(Equal("TLSv1.2"))
+		Expect(config.GetConfig(ParameterSSLMaxProtocolVersion)).To(Equal("TLSv1.3"))
+		Expect(config.GetConfig(ParameterSSLCiphers)).To(Equal("HIGH:!aNULL:!MD5"))
+	})
+})
+
+var _ = Describe("TCP keepalive settings", func() {
+	It("keeps the defaults of the operating system unless the user sets any of them", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			IncludingMandatory: true,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterTCPKeepalivesIdle)).To(BeEmpty())
+		Expect(config.GetConfig(ParameterTCPKeepalivesInterval)).To(BeEmpty())
+		Expect(config.GetConfig(ParameterTCPKeepalivesCount)).To(BeEmpty())
+	})
+
+	It("completes the settings with the managed defaults when the user sets any of them", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterTCPKeepalivesIdle: "30",
+			},
+			IncludingMandatory: true,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterTCPKeepalivesIdle)).To(Equal("30"))
+		Expect(config.GetConfig(ParameterTCPKeepalivesInterval)).To(Equal("10"))
+		Expect(config.GetConfig(ParameterTCPKeepalivesCount)).To(Equal("6"))
+	})
+})
+
+var _ = Describe("logging settings", func() {
+	It("keeps the parameters set by the user when not specified", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterLogStatement: "ddl",
+			},
+			IncludingMandatory: true,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterLogStatement)).To(Equal("ddl"))
+		Expect(config.GetConfig(ParameterLogMinDurationStatement)).To(BeEmpty())
+	})
+
+	It("overrides the parameters set by the user, preserving the mandatory ones", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterLogStatement: "ddl",
+			},
+			IncludingMandatory:      true,
+			LogMinDurationStatement: "500ms",
+			LogStatement:            "mod",
+			LogConnections:          "on",
+			LogDisconnections:       "off",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterLogMinDurationStatement)).To(Equal("500ms"))
+		Expect(config.GetConfig(ParameterLogStatement)).To(Equal("mod"))
+		Expect(config.GetConfig(ParameterLogConnections)).To(Equal("on"))
+		Expect(config.GetConfig(ParameterLogDisconnections)).To(Equal("off"))
+		Expect(config.GetConfig("log_destination")).To(Equal("csvlog"))
+	})
+})
+
+var _ = Describe("Cluster name", func() {
+	It("defaults to the name of the cluster", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			ClusterName:  "cluster-example",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterClusterName)).To(Equal("cluster-example"))
+	})
+
+	It("keeps the name chosen by the user", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			ClusterName:        "cluster-example",
+			IncludingMandatory: true,
+			UserSettings: map[string]string{
+				ParameterClusterName: "production-eu",
+			},
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterClusterName)).To(Equal("production-eu"))
+	})
+})
+
+var _ = Describe("Huge pages configuration", func() {
+	It("keeps the parameter of the user when the huge pages are not managed", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterHugePages: "off",
+			},
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterHugePages)).To(Equal("off"))
+	})
+
+	It("sets the usage of the managed huge pages", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			IncludingMandatory: true,
+			HugePages:          "on",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterHugePages)).To(Equal("on"))
+	})
+
+	It("sets the size of the managed huge pages since PostgreSQL 14", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			IncludingMandatory: true,
+			HugePages:          "on",
+			HugePageSize:       "1048576kB",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterHugePageSize)).To(Equal("1048576kB"))
+
+		info.MajorVersion = 13
+		config = CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterHugePageSize)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Idle sessions timeouts", func() {
+	It("overrides the parameters set by the user", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterIdleInTransactionSessionTimeout: "1min",
+			},
+			IncludingMandatory:              true,
+			IdleSessionTimeout:              "3600000ms",
+			IdleInTransactionSessionTimeout: "300000ms",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterIdleSessionTimeout)).To(Equal("3600000ms"))
+		Expect(config.GetConfig(ParameterIdleInTransactionSessionTimeout)).To(Equal("300000ms"))
+	})
+})
+
+var _ = Describe("Autovacuum tuning options", func() {
+	It("overrides the parameters set by the user", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterAutovacuumNaptime: "5min",
+			},
+			IncludingMandatory:        true,
+			AutovacuumNaptime:         "30s",
+			AutovacuumMaxWorkers:      "6",
+			AutovacuumVacuumCostDelay: "0.5ms",
+			AutovacuumVacuumCostLimit: "-1",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterAutovacuumNaptime)).To(Equal("30s"))
+		Expect(config.GetConfig(ParameterAutovacuumMaxWorkers)).To(Equal("6"))
+		Expect(config.GetConfig(ParameterAutovacuumVacuumCostDelay)).To(Equal("0.5ms"))
+		Expect(config.GetConfig(ParameterAutovacuumVacuumCostLimit)).To(Equal("-1"))
+	})
+
+	It("keeps the parameters set by the user when not specified", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterAutovacuumMaxWorkers: "4",
+			},
+			IncludingMandatory: true,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterAutovacuumMaxWorkers)).To(Equal("4"))
+	})
+})
+
+var _ = Describe("Password encryption", func() {
+	It("overrides the parameters set by the user", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterPasswordEncryption: "md5",
+			},
+			IncludingMandatory: true,
+			PasswordEncryption: "scram-sha-256",
+			ScramIterations:    "10000",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterPasswordEncryption)).To(Equal("scram-sha-256"))
+		Expect(config.GetConfig(ParameterScramIterations)).To(Equal("10000"))
+	})
+
+	It("doesn't set the SCRAM iterations before PostgreSQL 16", func() {
+		info := ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       15,
+			IncludingMandatory: true,
+			ScramIterations:    "10000",
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig(ParameterScramIterations)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Superuser reserved connections", func() {
+	buildConfiguration := func(value string) *PgConfiguration {
+		return CreatePostgresqlConfiguration(ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				ParameterSuperuserReservedConnections: value,
+			},
+			IncludingMandatory: true,
+		})
+	}
+
+	It("keeps the value of the user when above the minimum", func() {
+		config := buildConfiguration("10")
+		Expect(config.GetConfig(ParameterSuperuserReservedConnections)).To(Equal("10"))
+	})
+
+	It("raises the value of the user to the minimum", func() {
+		config := buildConfiguration("0")
+		Expect(config.GetConfig(ParameterSuperuserReservedConnections)).To(Equal("3"))
+	})
+
+	It("relies on the PostgreSQL default when not specified", func() {
+		config := CreatePostgresqlConfiguration(ConfigurationInfo{
+			Settings:           CnpgConfigurationSettings,
+			MajorVersion:       17,
+			IncludingMandatory: true,
+		})
+		Expect(config.GetConfig(ParameterSuperuserReservedConnections)).To(BeEmpty())
+	})
+})
+
 var _ = Describe("PostgreSQL Extensions", func() {
 	Context("configuring extension_control_path and dynamic_library_path", func() {
 		const (
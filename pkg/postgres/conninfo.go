@@ -0,0 +1,52 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+// reservedPrimaryConnInfoParameters are the parameters of the connection
+// string used by the replicas to reach the primary which are managed by
+// the operator, as they define the endpoint and the credentials
+var reservedPrimaryConnInfoParameters = map[string]interface{}{
+	"host":         nil,
+	"hostaddr":     nil,
+	"port":         nil,
+	"dbname":       nil,
+	"user":         nil,
+	"password":     nil,
+	"passfile":     nil,
+	"service":      nil,
+	"servicefile":  nil,
+	"replication":  nil,
+	"require_auth": nil,
+	"sslmode":      nil,
+	"sslkey":       nil,
+	"sslcert":      nil,
+	"sslcertmode":  nil,
+	"sslpassword":  nil,
+	"sslrootcert":  nil,
+	"sslcrl":       nil,
+	"sslcrldir":    nil,
+}
+
+// IsPrimaryConnInfoParameterReserved checks if a parameter of the connection
+// string used by the replicas to reach the primary is managed by the operator
+func IsPrimaryConnInfoParameterReserved(name string) bool {
+	_, isReserved := reservedPrimaryConnInfoParameters[name]
+	return isReserved
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("primary_conninfo parameters", func() {
+	It("recognizes the parameters defining the endpoint and the credentials", func() {
+		Expect(IsPrimaryConnInfoParameterReserved("host")).To(BeTrue())
+		Expect(IsPrimaryConnInfoParameterReserved("user")).To(BeTrue())
+		Expect(IsPrimaryConnInfoParameterReserved("sslmode")).To(BeTrue())
+		Expect(IsPrimaryConnInfoParameterReserved("sslcert")).To(BeTrue())
+	})
+
+	It("allows the other parameters to be customized", func() {
+		Expect(IsPrimaryConnInfoParameterReserved("application_name")).To(BeFalse())
+		Expect(IsPrimaryConnInfoParameterReserved("gssencmode")).To(BeFalse())
+		Expect(IsPrimaryConnInfoParameterReserved("options")).To(BeFalse())
+	})
+})
@@ -0,0 +1,68 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postgresTimeUnits are the units accepted by PostgreSQL for time
+// configuration parameters
+var postgresTimeUnits = map[string]time.Duration{
+	"us":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"min": time.Minute,
+	"h":   time.Hour,
+	"d":   24 * time.Hour,
+}
+
+// ParsePostgresConfigTime returns the duration parsed from a string as a postgres
+// time value. When no unit is specified, defaultUnit is used.
+// It returns an error if the input string is not a valid postgres time value
+// See: https://www.postgresql.org/docs/current/config-setting.html
+// Numeric with Unit: Valid time units are us, ms, s, min, h, and d (case-sensitive)
+func ParsePostgresConfigTime(in string, defaultUnit time.Duration) (time.Duration, error) {
+	value := strings.TrimSpace(in)
+	numberEnd := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if numberEnd == -1 {
+		numberEnd = len(value)
+	}
+
+	number, err := strconv.ParseFloat(value[:numberEnd], 64)
+	if err != nil {
+		return 0, fmt.Errorf("configuration value is not a postgres time: %s", in)
+	}
+
+	unit := defaultUnit
+	if unitName := strings.TrimSpace(value[numberEnd:]); unitName != "" {
+		var ok bool
+		if unit, ok = postgresTimeUnits[unitName]; !ok {
+			return 0, fmt.Errorf("configuration value has an invalid time unit: %s", in)
+		}
+	}
+
+	return time.Duration(number * float64(unit)), nil
+}
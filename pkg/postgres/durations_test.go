@@ -0,0 +1,54 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("Test parsing of PostgreSQL configuration time values",
+	func(input string, expectedValue time.Duration, expectError bool) {
+		value, err := ParsePostgresConfigTime(input, time.Second)
+		if expectError {
+			Expect(err).Should(HaveOccurred())
+		} else {
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+		Expect(value).To(Equal(expectedValue))
+	},
+	Entry("without unit", "60", 60*time.Second, false),
+	Entry("with spaces", " 60 ", 60*time.Second, false),
+	Entry("zero", "0", time.Duration(0), false),
+	Entry("seconds", "30s", 30*time.Second, false),
+	Entry("milliseconds", "1500ms", 1500*time.Millisecond, false),
+	Entry("microseconds", "250us", 250*time.Microsecond, false),
+	Entry("minutes", "2min", 2*time.Minute, false),
+	Entry("a space before the unit", "2 min", 2*time.Minute, false),
+	Entry("hours", "1h", time.Hour, false),
+	Entry("days", "1d", 24*time.Hour, false),
+	Entry("fractional value", "1.5min", 90*time.Second, false),
+	Entry("unknown unit", "1m", time.Duration(0), true),
+	Entry("case-sensitive unit", "1MIN", time.Duration(0), true),
+	Entry("not a number", "foo", time.Duration(0), true),
+	Entry("empty", "", time.Duration(0), true),
+)
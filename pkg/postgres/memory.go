@@ -0,0 +1,123 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// postgresMemoryUnits are the units accepted by PostgreSQL for memory
+// configuration parameters, expressed in bytes
+var postgresMemoryUnits = map[string]int64{
+	"B":  1,
+	"kB": 1024,
+	"MB": 1024 * 1024,
+	"GB": 1024 * 1024 * 1024,
+	"TB": 1024 * 1024 * 1024 * 1024,
+}
+
+// DefaultEffectiveCacheSize is the value of `effective_cache_size`, relative
+// to the memory limit of the pod, used when the user didn't set it: most of
+// the memory not used by the processes is available to the page cache
+const DefaultEffectiveCacheSize = "75%"
+
+// MemoryRelativeParameters are the memory configuration parameters whose
+// value can be expressed as a percentage of the memory limit of the pod
+var MemoryRelativeParameters = map[string]struct{}{
+	"autovacuum_work_mem":       {},
+	"effective_cache_size":      {},
+	"logical_decoding_work_mem": {},
+	"maintenance_work_mem":      {},
+	"shared_buffers":            {},
+	"temp_buffers":              {},
+	"work_mem":                  {},
+}
+
+// IsMemoryRelativeValue checks if the passed configuration value is
+// expressed as a percentage of the memory limit, i.e. `25%`
+func IsMemoryRelativeValue(value string) bool {
+	return strings.HasSuffix(strings.TrimSpace(value), "%")
+}
+
+// ParseMemoryPercentage parses a configuration value expressed as a
+// percentage of the memory limit, returning the percentage.
+// It returns an error if the percentage is not in the (0, 100] range
+func ParseMemoryPercentage(value string) (float64, error) {
+	number := strings.TrimSuffix(strings.TrimSpace(value), "%")
+	percentage, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return 0, fmt.Errorf("configuration value is not a percentage: %s", value)
+	}
+
+	if percentage <= 0 || percentage > 100 {
+		return 0, fmt.Errorf("percentage must be greater than 0 and not greater than 100: %s", value)
+	}
+
+	return percentage, nil
+}
+
+// ResolveMemoryRelativeValue converts a configuration value expressed as a
+// percentage of the passed memory limit, in bytes, to a value in kB
+func ResolveMemoryRelativeValue(value string, memoryLimit int64) (string, error) {
+	percentage, err := ParseMemoryPercentage(value)
+	if err != nil {
+		return "", err
+	}
+
+	if memoryLimit <= 0 {
+		return "", fmt.Errorf("cannot resolve %s without a memory limit", value)
+	}
+
+	kiloBytes := int64(float64(memoryLimit) * percentage / 100 / 1024)
+	return fmt.Sprintf("%dkB", kiloBytes), nil
+}
+
+// ParsePostgresConfigMemory returns the amount of bytes parsed from a string
+// as a postgres memory value. When no unit is specified, defaultUnit, in
+// bytes, is used (i.e. 8kB blocks for `shared_buffers`).
+// It returns an error if the input string is not a valid postgres memory value
+// See: https://www.postgresql.org/docs/current/config-setting.html
+// Numeric with Unit: Valid memory units are B, kB, MB, GB, and TB (case-sensitive)
+func ParsePostgresConfigMemory(in string, defaultUnit int64) (int64, error) {
+	value := strings.TrimSpace(in)
+	numberEnd := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if numberEnd == -1 {
+		numberEnd = len(value)
+	}
+
+	number, err := strconv.ParseFloat(value[:numberEnd], 64)
+	if err != nil {
+		return 0, fmt.Errorf("configuration value is not a postgres memory value: %s", in)
+	}
+
+	unit := defaultUnit
+	if unitName := strings.TrimSpace(value[numberEnd:]); unitName != "" {
+		var ok bool
+		if unit, ok = postgresMemoryUnits[unitName]; !ok {
+			return 0, fmt.Errorf("configuration value has an invalid memory unit: %s", in)
+		}
+	}
+
+	return int64(number * float64(unit)), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("Test parsing of memory percentages",
+	func(input string, expectedValue float64, expectError bool) {
+		value, err := ParseMemoryPercentage(input)
+		if expectError {
+			Expect(err).Should(HaveOccurred())
+		} else {
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+		Expect(value).To(Equal(expectedValue))
+	},
+	Entry("integer percentage", "25%", 25.0, false),
+	Entry("fractional percentage", "2.5%", 2.5, false),
+	Entry("with spaces", " 10 % ", 10.0, false),
+	Entry("the whole memory", "100%", 100.0, false),
+	Entry("zero", "0%", 0.0, true),
+	Entry("more than the whole memory", "101%", 0.0, true),
+	Entry("not a number", "many%", 0.0, true),
+)
+
+var _ = Describe("Memory relative values", func() {
+	It("detects values expressed as a percentage", func() {
+		Expect(IsMemoryRelativeValue("25%")).To(BeTrue())
+		Expect(IsMemoryRelativeValue("256MB")).To(BeFalse())
+	})
+
+	It("resolves the percentage of the memory limit in kB", func() {
+		Expect(ResolveMemoryRelativeValue("25%", 4*1024*1024*1024)).To(Equal("1048576kB"))
+		Expect(ResolveMemoryRelativeValue("1.5%", 1024*1024*1024)).To(Equal("15728kB"))
+	})
+
+	It("fails to resolve a percentage without a memory limit", func() {
+		_, err := ResolveMemoryRelativeValue("25%", 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("resolves the memory parameters in the configuration", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				"shared_buffers":       "25%",
+				"maintenance_work_mem": "5%",
+				"log_line_prefix":      "%m %",
+			},
+			IncludingMandatory: true,
+			MemoryLimit:        2 * 1024 * 1024 * 1024,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("shared_buffers")).To(Equal("524288kB"))
+		Expect(config.GetConfig("maintenance_work_mem")).To(Equal("104857kB"))
+		Expect(config.GetConfig("log_line_prefix")).To(Equal("%m %"))
+	})
+
+	It("sizes effective_cache_size on the memory limit by default", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			MemoryLimit:  4 * 1024 * 1024 * 1024,
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("effective_cache_size")).To(Equal("3145728kB"))
+
+		info.UserSettings = map[string]string{
+			"effective_cache_size": "1GB",
+		}
+		config = CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("effective_cache_size")).To(Equal("1GB"))
+
+		info.UserSettings = nil
+		info.MemoryLimit = 0
+		config = CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("effective_cache_size")).To(BeEmpty())
+	})
+
+	It("keeps the percentages when the memory limit is unknown", func() {
+		info := ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				"shared_buffers": "25%",
+			},
+		}
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("shared_buffers")).To(Equal("25%"))
+	})
+})
+
+var _ = DescribeTable("ParsePostgresConfigMemory",
+	func(value string, expected int64, expectError bool) {
+		result, err := ParsePostgresConfigMemory(value, 8*1024)
+		if expectError {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(expected))
+	},
+	Entry("bytes", "512B", int64(512), false),
+	Entry("kilobytes", "64kB", int64(64*1024), false),
+	Entry("megabytes", "128MB", int64(128*1024*1024), false),
+	Entry("gigabytes with spaces", " 2 GB ", int64(2*1024*1024*1024), false),
+	Entry("terabytes", "1TB", int64(1024*1024*1024*1024), false),
+	Entry("default unit", "16384", int64(16384*8*1024), false),
+	Entry("invalid unit", "1Gi", int64(0), true),
+	Entry("not a number", "abc", int64(0), true),
+)
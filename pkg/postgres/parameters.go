@@ -0,0 +1,511 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import "strings"
+
+// knownParametersLatestMajorVersion is the most recent PostgreSQL major
+// version covered by the bundled list of configuration parameters
+const knownParametersLatestMajorVersion = 18
+
+// parameterAvailability describes the range of PostgreSQL major versions
+// where a configuration parameter is recognized by the server
+type parameterAvailability struct {
+	// since is the first major version having the parameter, zero
+	// meaning it is available in every supported major version
+	since int
+
+	// until is the last major version having the parameter, zero
+	// meaning it is still available
+	until int
+}
+
+// isAvailableIn checks if the parameter is available in the passed
+// PostgreSQL major version
+func (p parameterAvailability) isAvailableIn(majorVersion int) bool {
+	if p.since != 0 && majorVersion < p.since {
+		return false
+	}
+	return p.until == 0 || majorVersion <= p.until
+}
+
+// knownParameters contains the configuration parameters recognized by
+// the supported PostgreSQL major versions, excluding the ones defined
+// by extensions, which are always qualified by their namespace
+var knownParameters = map[string]parameterAvailability{
+	// Parameters available in every supported major version
+	"allow_in_place_tablespaces":             {},
+	"allow_system_table_mods":                {},
+	"application_name":                       {},
+	"archive_cleanup_command":                {},
+	"archive_command":                        {},
+	"archive_mode":                           {},
+	"archive_timeout":                        {},
+	"array_nulls":                            {},
+	"authentication_timeout":                 {},
+	"autovacuum":                             {},
+	"autovacuum_analyze_scale_factor":        {},
+	"autovacuum_analyze_threshold":           {},
+	"autovacuum_freeze_max_age":              {},
+	"autovacuum_max_workers":                 {},
+	"autovacuum_multixact_freeze_max_age":    {},
+	"autovacuum_naptime":                     {},
+	"autovacuum_vacuum_cost_delay":           {},
+	"autovacuum_vacuum_cost_limit":           {},
+	"autovacuum_vacuum_insert_scale_factor":  {},
+	"autovacuum_vacuum_insert_threshold":     {},
+	"autovacuum_vacuum_scale_factor":         {},
+	"autovacuum_vacuum_threshold":            {},
+	"autovacuum_work_mem":                    {},
+	"backend_flush_after":                    {},
+	"backslash_quote":                        {},
+	"backtrace_functions":                    {},
+	"bgwriter_delay":                         {},
+	"bgwriter_flush_after":                   {},
+	"bgwriter_lru_maxpages":                  {},
+	"bgwriter_lru_multiplier":                {},
+	"block_size":                             {},
+	"bonjour":                                {},
+	"bonjour_name":                           {},
+	"bytea_output":                           {},
+	"check_function_bodies":                  {},
+	"checkpoint_completion_target":           {},
+	"checkpoint_flush_after":                 {},
+	"checkpoint_timeout":                     {},
+	"checkpoint_warning":                     {},
+	"client_encoding":                        {},
+	"client_min_messages":                    {},
+	"cluster_name":                           {},
+	"commit_delay":                           {},
+	"commit_siblings":                        {},
+	"config_file":                            {},
+	"constraint_exclusion":                   {},
+	"cpu_index_tuple_cost":                   {},
+	"cpu_operator_cost":                      {},
+	"cpu_tuple_cost":                         {},
+	"cursor_tuple_fraction":                  {},
+	"data_checksums":                         {},
+	"data_directory":                         {},
+	"data_directory_mode":                    {},
+	"data_sync_retry":                        {},
+	"datestyle":                              {},
+	"deadlock_timeout":                       {},
+	"debug_assertions":                       {},
+	"debug_pretty_print":                     {},
+	"debug_print_parse":                      {},
+	"debug_print_plan":                       {},
+	"debug_print_rewritten":                  {},
+	"default_statistics_target":              {},
+	"default_table_access_method":            {},
+	"default_tablespace":                     {},
+	"default_text_search_config":             {},
+	"default_transaction_deferrable":         {},
+	"default_transaction_isolation":          {},
+	"default_transaction_read_only":          {},
+	"default_with_oids":                      {},
+	"dynamic_library_path":                   {},
+	"dynamic_shared_memory_type":             {},
+	"effective_cache_size":                   {},
+	"effective_io_concurrency":               {},
+	"enable_bitmapscan":                      {},
+	"enable_gathermerge":                     {},
+	"enable_hashagg":                         {},
+	"enable_hashjoin":                        {},
+	"enable_incremental_sort":                {},
+	"enable_indexonlyscan":                   {},
+	"enable_indexscan":                       {},
+	"enable_material":                        {},
+	"enable_mergejoin":                       {},
+	"enable_nestloop":                        {},
+	"enable_parallel_append":                 {},
+	"enable_parallel_hash":                   {},
+	"enable_partition_pruning":               {},
+	"enable_partitionwise_aggregate":         {},
+	"enable_partitionwise_join":              {},
+	"enable_seqscan":                         {},
+	"enable_sort":                            {},
+	"enable_tidscan":                         {},
+	"escape_string_warning":                  {},
+	"event_source":                           {},
+	"exit_on_error":                          {},
+	"external_pid_file":                      {},
+	"extra_float_digits":                     {},
+	"from_collapse_limit":                    {},
+	"fsync":                                  {},
+	"full_page_writes":                       {},
+	"geqo":                                   {},
+	"geqo_effort":                            {},
+	"geqo_generations":                       {},
+	"geqo_pool_size":                         {},
+	"geqo_seed":                              {},
+	"geqo_selection_bias":                    {},
+	"geqo_threshold":                         {},
+	"gin_fuzzy_search_limit":                 {},
+	"gin_pending_list_limit":                 {},
+	"hash_mem_multiplier":                    {},
+	"hba_file":                               {},
+	"hot_standby":                            {},
+	"hot_standby_feedback":                   {},
+	"huge_pages":                             {},
+	"ident_file":                             {},
+	"idle_in_transaction_session_timeout":    {},
+	"ignore_checksum_failure":                {},
+	"ignore_invalid_pages":                   {},
+	"ignore_system_indexes":                  {},
+	"integer_datetimes":                      {},
+	"intervalstyle":                          {},
+	"jit":                                    {},
+	"jit_above_cost":                         {},
+	"jit_debugging_support":                  {},
+	"jit_dump_bitcode":                       {},
+	"jit_expressions":                        {},
+	"jit_inline_above_cost":                  {},
+	"jit_optimize_above_cost":                {},
+	"jit_profiling_support":                  {},
+	"jit_provider":                           {},
+	"jit_tuple_deforming":                    {},
+	"join_collapse_limit":                    {},
+	"krb_caseins_users":                      {},
+	"krb_server_keyfile":                     {},
+	"lc_messages":                            {},
+	"lc_monetary":                            {},
+	"lc_numeric":                             {},
+	"lc_time":                                {},
+	"listen_addresses":                       {},
+	"lo_compat_privileges":                   {},
+	"local_preload_libraries":                {},
+	"lock_timeout":                           {},
+	"log_autovacuum_min_duration":            {},
+	"log_btree_build_stats":                  {},
+	"log_checkpoints":                        {},
+	"log_connections":                        {},
+	"log_destination":                        {},
+	"log_directory":                          {},
+	"log_disconnections":                     {},
+	"log_duration":                           {},
+	"log_error_verbosity":                    {},
+	"log_executor_stats":                     {},
+	"log_file_mode":                          {},
+	"log_filename":                           {},
+	"log_hostname":                           {},
+	"log_line_prefix":                        {},
+	"log_lock_waits":                         {},
+	"log_min_duration_sample":                {},
+	"log_min_duration_statement":             {},
+	"log_min_error_statement":                {},
+	"log_min_messages":                       {},
+	"log_parameter_max_length":               {},
+	"log_parameter_max_length_on_error":      {},
+	"log_parser_stats":                       {},
+	"log_planner_stats":                      {},
+	"log_replication_commands":               {},
+	"log_rotation_age":                       {},
+	"log_rotation_size":                      {},
+	"log_statement":                          {},
+	"log_statement_sample_rate":              {},
+	"log_statement_stats":                    {},
+	"log_temp_files":                         {},
+	"log_timezone":                           {},
+	"log_transaction_sample_rate":            {},
+	"log_truncate_on_rotation":               {},
+	"logging_collector":                      {},
+	"logical_decoding_work_mem":              {},
+	"maintenance_io_concurrency":             {},
+	"maintenance_work_mem":                   {},
+	"max_connections":                        {},
+	"max_files_per_process":                  {},
+	"max_function_args":                      {},
+	"max_identifier_length":                  {},
+	"max_index_keys":                         {},
+	"max_locks_per_transaction":              {},
+	"max_logical_replication_workers":        {},
+	"max_parallel_maintenance_workers":       {},
+	"max_parallel_workers":                   {},
+	"max_parallel_workers_per_gather":        {},
+	"max_pred_locks_per_page":                {},
+	"max_pred_locks_per_relation":            {},
+	"max_pred_locks_per_transaction":         {},
+	"max_prepared_transactions":              {},
+	"max_replication_slots":                  {},
+	"max_slot_wal_keep_size":                 {},
+	"max_stack_depth":                        {},
+	"max_standby_archive_delay":              {},
+	"max_standby_streaming_delay":            {},
+	"max_sync_workers_per_subscription":      {},
+	"max_wal_senders":                        {},
+	"max_wal_size":                           {},
+	"max_worker_processes":                   {},
+	"min_parallel_index_scan_size":           {},
+	"min_parallel_table_scan_size":           {},
+	"min_wal_size":                           {},
+	"parallel_leader_participation":          {},
+	"parallel_setup_cost":                    {},
+	"parallel_tuple_cost":                    {},
+	"password_encryption":                    {},
+	"plan_cache_mode":                        {},
+	"port":                                   {},
+	"post_auth_delay":                        {},
+	"pre_auth_delay":                         {},
+	"primary_conninfo":                       {},
+	"primary_slot_name":                      {},
+	"quote_all_identifiers":                  {},
+	"random_page_cost":                       {},
+	"recovery_end_command":                   {},
+	"recovery_min_apply_delay":               {},
+	"recovery_target":                        {},
+	"recovery_target_action":                 {},
+	"recovery_target_inclusive":              {},
+	"recovery_target_lsn":                    {},
+	"recovery_target_name":                   {},
+	"recovery_target_time":                   {},
+	"recovery_target_timeline":               {},
+	"recovery_target_xid":                    {},
+	"restart_after_crash":                    {},
+	"restore_command":                        {},
+	"restrict_nonsystem_relation_kind":       {},
+	"row_security":                           {},
+	"search_path":                            {},
+	"segment_size":                           {},
+	"seq_page_cost":                          {},
+	"server_encoding":                        {},
+	"server_version":                         {},
+	"server_version_num":                     {},
+	"session_preload_libraries":              {},
+	"session_replication_role":               {},
+	"shared_buffers":                         {},
+	"shared_memory_type":                     {},
+	"shared_preload_libraries":               {},
+	"ssl":                                    {},
+	"ssl_ca_file":                            {},
+	"ssl_cert_file":                          {},
+	"ssl_ciphers":                            {},
+	"ssl_crl_file":                           {},
+	"ssl_dh_params_file":                     {},
+	"ssl_ecdh_curve":                         {},
+	"ssl_key_file":                           {},
+	"ssl_library":                            {},
+	"ssl_max_protocol_version":               {},
+	"ssl_min_protocol_version":               {},
+	"ssl_passphrase_command":                 {},
+	"ssl_passphrase_command_supports_reload": {},
+	"ssl_prefer_server_ciphers":              {},
+	"standard_conforming_strings":            {},
+	"statement_timeout":                      {},
+	"superuser_reserved_connections":         {},
+	"synchronize_seqscans":                   {},
+	"synchronous_commit":                     {},
+	"synchronous_standby_names":              {},
+	"syslog_facility":                        {},
+	"syslog_ident":                           {},
+	"syslog_sequence_numbers":                {},
+	"syslog_split_messages":                  {},
+	"tcp_keepalives_count":                   {},
+	"tcp_keepalives_idle":                    {},
+	"tcp_keepalives_interval":                {},
+	"tcp_user_timeout":                       {},
+	"temp_buffers":                           {},
+	"temp_file_limit":                        {},
+	"temp_tablespaces":                       {},
+	"timezone":                               {},
+	"timezone_abbreviations":                 {},
+	"trace_notify":                           {},
+	"trace_sort":                             {},
+	"track_activities":                       {},
+	"track_activity_query_size":              {},
+	"track_commit_timestamp":                 {},
+	"track_counts":                           {},
+	"track_functions":                        {},
+	"track_io_timing":                        {},
+	"transaction_deferrable":                 {},
+	"transaction_isolation":                  {},
+	"transaction_read_only":                  {},
+	"transform_null_equals":                  {},
+	"unix_socket_directories":                {},
+	"unix_socket_group":                      {},
+	"unix_socket_permissions":                {},
+	"update_process_title":                   {},
+	"vacuum_cost_delay":                      {},
+	"vacuum_cost_limit":                      {},
+	"vacuum_cost_page_dirty":                 {},
+	"vacuum_cost_page_hit":                   {},
+	"vacuum_cost_page_miss":                  {},
+	"vacuum_freeze_min_age":                  {},
+	"vacuum_freeze_table_age":                {},
+	"vacuum_multixact_freeze_min_age":        {},
+	"vacuum_multixact_freeze_table_age":      {},
+	"wal_block_size":                         {},
+	"wal_buffers":                            {},
+	"wal_compression":                        {},
+	"wal_consistency_checking":               {},
+	"wal_init_zero":                          {},
+	"wal_keep_size":                          {},
+	"wal_level":                              {},
+	"wal_log_hints":                          {},
+	"wal_receiver_create_temp_slot":          {},
+	"wal_receiver_status_interval":           {},
+	"wal_receiver_timeout":                   {},
+	"wal_recycle":                            {},
+	"wal_retrieve_retry_interval":            {},
+	"wal_segment_size":                       {},
+	"wal_sender_timeout":                     {},
+	"wal_skip_threshold":                     {},
+	"wal_sync_method":                        {},
+	"wal_writer_delay":                       {},
+	"wal_writer_flush_after":                 {},
+	"work_mem":                               {},
+	"xmlbinary":                              {},
+	"xmloption":                              {},
+	"zero_damaged_pages":                     {},
+
+	// Parameters introduced in PostgreSQL 14
+	"client_connection_check_interval": {since: 14},
+	"compute_query_id":                 {since: 14},
+	"debug_discard_caches":             {since: 14},
+	"default_toast_compression":        {since: 14},
+	"enable_async_append":              {since: 14},
+	"enable_memoize":                   {since: 14},
+	"huge_page_size":                   {since: 14},
+	"idle_session_timeout":             {since: 14},
+	"in_hot_standby":                   {since: 14},
+	"log_recovery_conflict_waits":      {since: 14},
+	"min_dynamic_shared_memory":        {since: 14},
+	"recovery_init_sync_method":        {since: 14},
+	"remove_temp_files_after_crash":    {since: 14},
+	"ssl_crl_dir":                      {since: 14},
+	"track_wal_io_timing":              {since: 14},
+	"vacuum_failsafe_age":              {since: 14},
+	"vacuum_multixact_failsafe_age":    {since: 14},
+
+	// Parameters introduced in PostgreSQL 15
+	"archive_library":                  {since: 15},
+	"log_startup_progress_interval":    {since: 15},
+	"recovery_prefetch":                {since: 15},
+	"recursive_worktable_factor":       {since: 15},
+	"shared_memory_size":               {since: 15},
+	"shared_memory_size_in_huge_pages": {since: 15},
+	"stats_fetch_consistency":          {since: 15},
+	"wal_decode_buffer_size":           {since: 15},
+
+	// Parameters introduced in PostgreSQL 16
+	"createrole_self_grant":                       {since: 16},
+	"debug_io_direct":                             {since: 16},
+	"debug_logical_replication_streaming":         {since: 16},
+	"debug_parallel_query":                        {since: 16},
+	"enable_presorted_aggregate":                  {since: 16},
+	"gss_accept_delegation":                       {since: 16},
+	"icu_validation_level":                        {since: 16},
+	"max_parallel_apply_workers_per_subscription": {since: 16},
+	"reserved_connections":                        {since: 16},
+	"scram_iterations":                            {since: 16},
+	"send_abort_for_crash":                        {since: 16},
+	"send_abort_for_kill":                         {since: 16},
+	"vacuum_buffer_usage_limit":                   {since: 16},
+
+	// Parameters introduced in PostgreSQL 17
+	"allow_alter_system":         {since: 17},
+	"commit_timestamp_buffers":   {since: 17},
+	"enable_group_by_reordering": {since: 17},
+	"event_triggers":             {since: 17},
+	"huge_pages_status":          {since: 17},
+	"io_combine_limit":           {since: 17},
+	"max_notify_queue_pages":     {since: 17},
+	"multixact_member_buffers":   {since: 17},
+	"multixact_offset_buffers":   {since: 17},
+	"notify_buffers":             {since: 17},
+	"serializable_buffers":       {since: 17},
+	"subtransaction_buffers":     {since: 17},
+	"summarize_wal":              {since: 17},
+	"sync_replication_slots":     {since: 17},
+	"synchronized_standby_slots": {since: 17},
+	"transaction_buffers":        {since: 17},
+	"transaction_timeout":        {since: 17},
+	"wal_summary_keep_time":      {since: 17},
+
+	// Parameters introduced in PostgreSQL 18
+	"autovacuum_vacuum_max_threshold":      {since: 18},
+	"autovacuum_worker_slots":              {since: 18},
+	"enable_distinct_reordering":           {since: 18},
+	"enable_self_join_elimination":         {since: 18},
+	"extension_control_path":               {since: 18},
+	"file_copy_method":                     {since: 18},
+	"idle_replication_slot_timeout":        {since: 18},
+	"io_max_combine_limit":                 {since: 18},
+	"io_max_concurrency":                   {since: 18},
+	"io_method":                            {since: 18},
+	"io_workers":                           {since: 18},
+	"log_lock_failures":                    {since: 18},
+	"max_active_replication_origins":       {since: 18},
+	"md5_password_warnings":                {since: 18},
+	"num_os_semaphores":                    {since: 18},
+	"oauth_validator_libraries":            {since: 18},
+	"ssl_groups":                           {since: 18},
+	"ssl_tls13_ciphers":                    {since: 18},
+	"track_cost_delay_timing":              {since: 18},
+	"vacuum_max_eager_freeze_failure_rate": {since: 18},
+	"vacuum_truncate":                      {since: 18},
+
+	// Parameters removed in later major versions
+	"operator_precedence_warning":       {until: 13},
+	"vacuum_cleanup_index_scale_factor": {until: 13},
+	"stats_temp_directory":              {until: 14},
+	"force_parallel_mode":               {until: 15},
+	"lc_collate":                        {until: 15},
+	"lc_ctype":                          {until: 15},
+	"promote_trigger_file":              {until: 15},
+	"vacuum_defer_cleanup_age":          {until: 15},
+	"db_user_namespace":                 {until: 16},
+	"old_snapshot_threshold":            {until: 16},
+	"trace_recovery_messages":           {until: 16},
+}
+
+// isCustomParameter checks if the passed configuration parameter is
+// qualified by a namespace, as the ones defined by extensions
+func isCustomParameter(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// IsKnownParameter checks if the passed configuration parameter is
+// recognized by the passed PostgreSQL major version. Parameters defined
+// by extensions and major versions beyond the bundled list are
+// always considered known
+func IsKnownParameter(name string, majorVersion int) bool {
+	if isCustomParameter(name) || majorVersion > knownParametersLatestMajorVersion {
+		return true
+	}
+
+	availability, ok := knownParameters[strings.ToLower(name)]
+	return ok && availability.isAvailableIn(majorVersion)
+}
+
+// GetParameterRemovalVersion returns the PostgreSQL major version which
+// removed the passed configuration parameter, if it is available in the
+// passed major version and dropped in a later one
+func GetParameterRemovalVersion(name string, majorVersion int) (int, bool) {
+	if isCustomParameter(name) {
+		return 0, false
+	}
+
+	availability, ok := knownParameters[strings.ToLower(name)]
+	if !ok || availability.until == 0 || !availability.isAvailableIn(majorVersion) {
+		return 0, false
+	}
+
+	return availability.until + 1, true
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("Test the recognition of PostgreSQL configuration parameters",
+	func(name string, majorVersion int, expected bool) {
+		Expect(IsKnownParameter(name, majorVersion)).To(Equal(expected))
+	},
+	Entry("a core parameter", "work_mem", 13, true),
+	Entry("a core parameter in upper case", "Work_Mem", 17, true),
+	Entry("a misspelled parameter", "work_memory", 17, false),
+	Entry("an extension parameter", "pg_stat_statements.max", 17, true),
+	Entry("a parameter before its introduction", "transaction_timeout", 16, false),
+	Entry("a parameter after its introduction", "transaction_timeout", 17, true),
+	Entry("a parameter before its removal", "old_snapshot_threshold", 16, true),
+	Entry("a parameter after its removal", "old_snapshot_threshold", 17, false),
+	Entry("any parameter with a major version beyond the bundled list", "work_memory", 99, true),
+)
+
+var _ = Describe("Test the removal version of PostgreSQL configuration parameters", func() {
+	It("reports the version removing a parameter", func() {
+		version, ok := GetParameterRemovalVersion("force_parallel_mode", 15)
+		Expect(ok).To(BeTrue())
+		Expect(version).To(Equal(16))
+	})
+
+	It("ignores parameters which are still available", func() {
+		_, ok := GetParameterRemovalVersion("work_mem", 17)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores parameters already removed", func() {
+		_, ok := GetParameterRemovalVersion("force_parallel_mode", 16)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores unknown and extension parameters", func() {
+		_, ok := GetParameterRemovalVersion("work_memory", 15)
+		Expect(ok).To(BeFalse())
+		_, ok = GetParameterRemovalVersion("pg_stat_statements.max", 15)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -139,6 +139,9 @@ func getSortedInstanceNames(cluster *apiv1.Cluster) []string {
 			case cluster.Status.CurrentPrimary == instance:
 				primaryInstance = instance
 
+			case !isSyncReplicaCandidate(cluster, instance):
+				continue
+
 			case state == apiv1.PodHealthy:
 				nonPrimaryReadyInstances = append(nonPrimaryReadyInstances, instance)
 			}
@@ -146,7 +149,7 @@ func getSortedInstanceNames(cluster *apiv1.Cluster) []string {
 	}
 
 	for _, instance := range cluster.Status.InstanceNames {
-		if instance == primaryInstance {
+		if instance == primaryInstance || !isSyncReplicaCandidate(cluster, instance) {
 			continue
 		}
 
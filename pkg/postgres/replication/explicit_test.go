@@ -20,6 +20,9 @@ SPDX-License-Identifier: Apache-2.0
 package replication
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -54,6 +57,55 @@ var _ = Describe("synchronous replica configuration with the new API", func() {
 			}))
 		})
 
+		It("doesn't use the delayed replicas as synchronous standbys", func() {
+			cluster := createFakeCluster("example")
+			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+				Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number: 1,
+			}
+			cluster.Spec.DelayedReplicas = &apiv1.DelayedReplicasConfiguration{
+				Instances:     []string{"three", "four"},
+				MinApplyDelay: metav1.Duration{Duration: time.Hour},
+			}
+			cluster.Status = apiv1.ClusterStatus{
+				CurrentPrimary: "one",
+				InstancesStatus: map[apiv1.PodStatus][]string{
+					apiv1.PodHealthy: {"one", "two", "three"},
+				},
+				InstanceNames: []string{"one", "two", "three", "four"},
+			}
+
+			Expect(explicitSynchronousStandbyNames(cluster)).To(Equal(postgres.SynchronousStandbyNamesConfig{
+				Method:       "ANY",
+				NumSync:      1,
+				StandbyNames: []string{"two", "one"},
+			}))
+		})
+
+		It("doesn't use the instances excluded from the failover as synchronous standbys", func() {
+			cluster := createFakeCluster("example")
+			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+				Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number: 1,
+			}
+			cluster.Spec.Failover = &apiv1.FailoverConfiguration{
+				ExcludedInstances: []string{"three", "four"},
+			}
+			cluster.Status = apiv1.ClusterStatus{
+				CurrentPrimary: "one",
+				InstancesStatus: map[apiv1.PodStatus][]string{
+					apiv1.PodHealthy: {"one", "two", "three"},
+				},
+				InstanceNames: []string{"one", "two", "three", "four"},
+			}
+
+			Expect(explicitSynchronousStandbyNames(cluster)).To(Equal(postgres.SynchronousStandbyNamesConfig{
+				Method:       "ANY",
+				NumSync:      1,
+				StandbyNames: []string{"two", "one"},
+			}))
+		})
+
 		It("creates configuration with the FIRST clause", func() {
 			cluster := createFakeCluster("example")
 			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
@@ -159,7 +159,7 @@ func getElectableSyncReplicas(ctx context.Context, cluster *apiv1.Cluster) []str
 func getSortedNonPrimaryHealthyInstanceNames(cluster *apiv1.Cluster) []string {
 	var nonPrimaryInstances []string
 	for _, instance := range cluster.Status.InstancesStatus[apiv1.PodHealthy] {
-		if cluster.Status.CurrentPrimary != instance {
+		if cluster.Status.CurrentPrimary != instance && isSyncReplicaCandidate(cluster, instance) {
 			nonPrimaryInstances = append(nonPrimaryInstances, instance)
 		}
 	}
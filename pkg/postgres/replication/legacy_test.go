@@ -20,6 +20,10 @@ SPDX-License-Identifier: Apache-2.0
 package replication
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 
@@ -35,6 +39,27 @@ var _ = Describe("ensuring the correctness of synchronous replica data calculati
 		Expect(names).To(Equal([]string{"example-2", "example-3"}))
 	})
 
+	It("should not elect the delayed replicas", func(ctx SpecContext) {
+		cluster := createFakeCluster("example")
+		cluster.Spec.DelayedReplicas = &apiv1.DelayedReplicasConfiguration{
+			Instances:     []string{"example-3"},
+			MinApplyDelay: metav1.Duration{Duration: time.Hour},
+		}
+		number, names := getSyncReplicasData(ctx, cluster)
+		Expect(number).To(Equal(1))
+		Expect(names).To(Equal([]string{"example-2"}))
+	})
+
+	It("should not elect the instances excluded from the failover", func(ctx SpecContext) {
+		cluster := createFakeCluster("example")
+		cluster.Spec.Failover = &apiv1.FailoverConfiguration{
+			ExcludedInstances: []string{"example-3"},
+		}
+		number, names := getSyncReplicasData(ctx, cluster)
+		Expect(number).To(Equal(1))
+		Expect(names).To(Equal([]string{"example-2"}))
+	})
+
 	It("should return only the pod in the different AZ", func(ctx SpecContext) {
 		const (
 			primaryPod     = "exampleAntiAffinity-1"
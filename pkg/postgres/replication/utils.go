@@ -22,8 +22,20 @@ package replication
 import (
 	"fmt"
 	"strings"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
+// isSyncReplicaCandidate checks if the passed instance can be used as a
+// synchronous standby. Only the failover candidates can: a delayed
+// replica would delay the commits on the primary by its own apply delay,
+// and an instance excluded from the failover is allowed to lag behind
+// the primary, while a synchronous standby is expected to be promotable
+// without losing the committed transactions
+func isSyncReplicaCandidate(cluster *apiv1.Cluster, instanceName string) bool {
+	return cluster.IsFailoverCandidate(instanceName)
+}
+
 // escapePostgresConfLiteral escapes a value to make its representation
 // similar to the literals in PostgreSQL
 func escapePostgresConfLiteral(value string) string {
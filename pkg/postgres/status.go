@@ -45,6 +45,7 @@ type PostgresqlStatus struct {
 	IsWalReceiverActive       bool        `json:"isWalReceiverActive"`
 	IsPgRewindRunning         bool        `json:"isPgRewindRunning"`
 	MightBeUnavailable        bool        `json:"mightBeUnavailable"`
+	IsPermanentlyFailed       bool        `json:"isPermanentlyFailed,omitempty"`
 	IsArchivingWAL            bool        `json:"isArchivingWAL,omitempty"`
 	Node                      string      `json:"node"`
 	Pod                       *corev1.Pod `json:"pod"`
@@ -71,6 +72,23 @@ type PostgresqlStatus struct {
 	// SELECT timeline_id FROM pg_control_checkpoint()
 	TimeLineID int `json:"timeLineID,omitempty"`
 
+	// The delay applied to the changes received from the primary,
+	// i.e. the value of recovery_min_apply_delay, when it is not zero.
+	// Only populated on the replicas.
+	MinApplyDelay string `json:"minApplyDelay,omitempty"`
+
+	// The authentication rules loaded from the Secrets and ConfigMaps
+	// referenced in the cluster, if any
+	AuthenticationRules *AuthenticationRulesStatus `json:"authenticationRules,omitempty"`
+
+	// The age of the oldest unfrozen transaction ID and multixact ID
+	// across all databases, together with the settings forcing an
+	// anti-wraparound vacuum. Only populated on the primary instance.
+	MaxXIDAge                       int64 `json:"maxXIDAge,omitempty"`
+	MaxMXIDAge                      int64 `json:"maxMXIDAge,omitempty"`
+	AutovacuumFreezeMaxAge          int64 `json:"autovacuumFreezeMaxAge,omitempty"`
+	AutovacuumMultixactFreezeMaxAge int64 `json:"autovacuumMultixactFreezeMaxAge,omitempty"`
+
 	// This field is set when there is an error while extracting the
 	// status of a Pod
 	Error error `json:"-"`
@@ -88,6 +106,11 @@ type PostgresqlStatus struct {
 	InstanceArch               string `json:"instanceArch"`
 	IsInstanceManagerUpgrading bool   `json:"isInstanceManagerUpgrading"`
 
+	// IsReadinessQueryFailing is true when the custom query of the
+	// readiness probe doesn't return the expected result, explaining why
+	// the Pod is not ready while PostgreSQL is up and running
+	IsReadinessQueryFailing bool `json:"isReadinessQueryFailing,omitempty"`
+
 	// This field represents the Kubelet point-of-view of the readiness
 	// status of this instance and may be slightly stale when the Kubelet has
 	// not still invoked the readiness probe.
@@ -25,6 +25,7 @@ import (
 	"path"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -193,3 +194,32 @@ func (segment Segment) NextSegments(size int, postgresVersion *int, segmentSize
 
 	return result
 }
+
+// ExpandWALCommand replaces, in the passed archive or restore command, the
+// `%p` placeholder with the path of the WAL file, `%f` with its name and
+// `%%` with a percent sign, as PostgreSQL does for the `archive_command`
+// and the `restore_command`. Any other placeholder is kept as it is.
+func ExpandWALCommand(command, walPath, walName string) string {
+	var result strings.Builder
+	for idx := 0; idx < len(command); idx++ {
+		if command[idx] != '%' || idx+1 == len(command) {
+			result.WriteByte(command[idx])
+			continue
+		}
+
+		switch command[idx+1] {
+		case 'p':
+			result.WriteString(walPath)
+		case 'f':
+			result.WriteString(walName)
+		case '%':
+			result.WriteByte('%')
+		default:
+			result.WriteByte(command[idx])
+			continue
+		}
+		idx++
+	}
+
+	return result.String()
+}
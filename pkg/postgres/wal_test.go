@@ -247,3 +247,16 @@ var _ = Describe("WAL files checking", func() {
 		}
 	})
 })
+
+var _ = Describe("WAL command expansion", func() {
+	It("replaces the placeholders", func() {
+		Expect(ExpandWALCommand("upload %p s3://bucket/%f --rate 100%%",
+			"/var/lib/postgresql/data/pgdata/pg_wal/000000010000000000000001", "000000010000000000000001")).
+			To(Equal("upload /var/lib/postgresql/data/pgdata/pg_wal/000000010000000000000001 " +
+				"s3://bucket/000000010000000000000001 --rate 100%"))
+	})
+
+	It("keeps the unknown placeholders", func() {
+		Expect(ExpandWALCommand("echo %x %", "path", "name")).To(Equal("echo %x %"))
+	})
+})
@@ -39,12 +39,13 @@ import (
 )
 
 type fakeBackupClient struct {
-	startCalled       bool
-	stopCalled        bool
-	injectStatusError error
-	injectStartError  error
-	injectStopError   error
-	response          *webserver.Response[webserver.BackupResultData]
+	startCalled          bool
+	stopCalled           bool
+	postBackupHookCalled bool
+	injectStatusError    error
+	injectStartError     error
+	injectStopError      error
+	response             *webserver.Response[webserver.BackupResultData]
 }
 
 func (f *fakeBackupClient) StatusWithErrors(
@@ -76,6 +77,15 @@ func (f *fakeBackupClient) Stop(
 	}, f.injectStopError
 }
 
+func (f *fakeBackupClient) RunPostBackupHook(
+	_ context.Context,
+	_ *corev1.Pod,
+	_ webserver.PostBackupHookRequest,
+) (*webserver.Response[struct{}], error) {
+	f.postBackupHookCalled = true
+	return &webserver.Response[struct{}]{}, nil
+}
+
 var _ = Describe("onlineExecutor prepare", func() {
 	var (
 		cluster *apiv1.Cluster
@@ -39,6 +39,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -49,6 +50,7 @@ type Reconciler struct {
 	cli                  client.Client
 	recorder             record.EventRecorder
 	instanceStatusClient remote.InstanceClient
+	backupClient         remote.BackupClient
 }
 
 // ExecutorBuilder is a struct capable of creating a Reconciler
@@ -66,6 +68,7 @@ func NewReconcilerBuilder(
 			cli:                  cli,
 			recorder:             recorder,
 			instanceStatusClient: remote.NewClient().Instance(),
+			backupClient:         remote.NewClient().Backup(),
 		},
 	}
 }
@@ -239,7 +242,9 @@ func (se *Reconciler) internalReconcile(
 	// Step 6: set backup as completed, adds remaining metadata
 	return se.completeSnapshotBackupStep(
 		ctx,
+		cluster,
 		backup,
+		targetPod,
 	)
 }
 
@@ -280,10 +285,12 @@ func (se *Reconciler) finalizeSnapshotBackupStep(
 }
 
 // completeSnapshotBackupStep sets a backup as completed, and set the remaining metadata
-// on it
+// on it, then requests the target Pod to run the post-backup hook
 func (se *Reconciler) completeSnapshotBackupStep(
 	ctx context.Context,
+	cluster *apiv1.Cluster,
 	backup *apiv1.Backup,
+	targetPod *corev1.Pod,
 ) (*ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 	backup.Status.SetAsCompleted()
@@ -296,7 +303,37 @@ func (se *Reconciler) completeSnapshotBackupStep(
 		contextLogger.Error(err, "while enriching the snapshots's status")
 		return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 	}
-	return nil, postgres.PatchBackupStatusAndRetry(ctx, se.cli, backup)
+	if err := postgres.PatchBackupStatusAndRetry(ctx, se.cli, backup); err != nil {
+		return nil, err
+	}
+
+	se.requestPostBackupHook(ctx, cluster, backup, targetPod)
+	return nil, nil
+}
+
+// requestPostBackupHook requests the target Pod to run the post-backup hook
+// configured in the cluster, if any. As the hook runs in background, a
+// failure of the request is reported with an event, and doesn't change the
+// outcome of the backup.
+func (se *Reconciler) requestPostBackupHook(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	targetPod *corev1.Pod,
+) {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.PostBackupHook == nil {
+		return
+	}
+
+	contextLogger := log.FromContext(ctx).WithValues("podName", targetPod.Name)
+	if _, err := se.backupClient.RunPostBackupHook(
+		ctx,
+		targetPod,
+		webserver.PostBackupHookRequest{BackupName: backup.Name},
+	); err != nil {
+		contextLogger.Error(err, "while requesting the post-backup hook")
+		se.recorder.Eventf(backup, "Warning", "PostBackupHookFailed", "Post-backup hook failed: %v", err)
+	}
 }
 
 // AnnotateSnapshots adds labels and annotations to the snapshots using the backup
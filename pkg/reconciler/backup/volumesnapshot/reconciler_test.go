@@ -353,6 +353,23 @@ var _ = Describe("Volumesnapshot reconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(data.Len()).To(Equal(0))
 	})
+
+	It("requests the target pod to run the post-backup hook, if configured", func(ctx SpecContext) {
+		backupClient := &fakeBackupClient{}
+		executor := &Reconciler{
+			recorder:     record.NewFakeRecorder(3),
+			backupClient: backupClient,
+		}
+
+		executor.requestPostBackupHook(ctx, cluster, backup, targetPod)
+		Expect(backupClient.postBackupHookCalled).To(BeFalse())
+
+		cluster.Spec.Backup.PostBackupHook = &apiv1.BackupHookConfiguration{
+			Command: []string{"/bin/true"},
+		}
+		executor.requestPostBackupHook(ctx, cluster, backup, targetPod)
+		Expect(backupClient.postBackupHookCalled).To(BeTrue())
+	})
 })
 
 var _ = Describe("transferLabelsToAnnotations", func() {
@@ -22,13 +22,17 @@ package majorupgrade
 import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-const jobMajorUpgrade = "major-upgrade"
+const (
+	jobMajorUpgrade      = "major-upgrade"
+	jobMajorUpgradeCheck = "major-upgrade-check"
+)
 
 // isMajorUpgradeJob tells if the passed Job definition corresponds to
 // the job handling the major upgrade
@@ -36,6 +40,12 @@ func isMajorUpgradeJob(job *batchv1.Job) bool {
 	return job.GetLabels()[utils.JobRoleLabelName] == string(jobMajorUpgrade)
 }
 
+// isMajorUpgradeCheckJob tells if the passed Job definition corresponds to
+// the job checking the feasibility of the major upgrade
+func isMajorUpgradeCheckJob(job *batchv1.Job) bool {
+	return job.GetLabels()[utils.JobRoleLabelName] == jobMajorUpgradeCheck
+}
+
 // getTargetImageFromMajorUpgradeJob gets the image that is being used as
 // target of the major upgrade process.
 func getTargetImageFromMajorUpgradeJob(job *batchv1.Job) (string, bool) {
@@ -54,6 +64,47 @@ func getTargetImageFromMajorUpgradeJob(job *batchv1.Job) (string, bool) {
 
 // createMajorUpgradeJobDefinition creates a job to upgrade the primary node to a new Postgres major version
 func createMajorUpgradeJobDefinition(cluster *apiv1.Cluster, nodeSerial int) *batchv1.Job {
+	majorUpgradeCommand := []string{
+		"/controller/manager",
+		"instance",
+		"upgrade",
+		"execute",
+		"/controller/old/bindir.txt",
+	}
+	job := specs.CreatePrimaryJob(*cluster, nodeSerial, jobMajorUpgrade, majorUpgradeCommand)
+	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers,
+		createOldVersionInitContainer(cluster))
+
+	return job
+}
+
+// createMajorUpgradeCheckJobDefinition creates a job running "pg_upgrade --check"
+// on the primary node, without upgrading it. The output of pg_upgrade is
+// reported in the termination message of the container, and the job is not
+// retried as the result of the check would not change
+func createMajorUpgradeCheckJobDefinition(cluster *apiv1.Cluster, nodeSerial int) *batchv1.Job {
+	majorUpgradeCheckCommand := []string{
+		"/controller/manager",
+		"instance",
+		"upgrade",
+		"execute",
+		"--check",
+		"/controller/old/bindir.txt",
+	}
+	job := specs.CreatePrimaryJob(*cluster, nodeSerial, jobMajorUpgradeCheck, majorUpgradeCheckCommand)
+	job.Spec.BackoffLimit = ptr.To(int32(0))
+	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers,
+		createOldVersionInitContainer(cluster))
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	}
+
+	return job
+}
+
+// createOldVersionInitContainer creates the init container copying the
+// binaries of the current Postgres major version, needed by pg_upgrade
+func createOldVersionInitContainer(cluster *apiv1.Cluster) corev1.Container {
 	prepareCommand := []string{
 		"/controller/manager",
 		"instance",
@@ -61,7 +112,7 @@ func createMajorUpgradeJobDefinition(cluster *apiv1.Cluster, nodeSerial int) *ba
 		"prepare",
 		"/controller/old",
 	}
-	oldVersionInitContainer := corev1.Container{
+	return corev1.Container{
 		Name:            "prepare",
 		Image:           cluster.Status.PGDataImageInfo.Image,
 		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
@@ -70,16 +121,4 @@ func createMajorUpgradeJobDefinition(cluster *apiv1.Cluster, nodeSerial int) *ba
 		Resources:       cluster.Spec.Resources,
 		SecurityContext: specs.CreateContainerSecurityContext(cluster.GetSeccompProfile()),
 	}
-
-	majorUpgradeCommand := []string{
-		"/controller/manager",
-		"instance",
-		"upgrade",
-		"execute",
-		"/controller/old/bindir.txt",
-	}
-	job := specs.CreatePrimaryJob(*cluster, nodeSerial, jobMajorUpgrade, majorUpgradeCommand)
-	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers, oldVersionInitContainer)
-
-	return job
 }
@@ -21,6 +21,7 @@ package majorupgrade
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -64,12 +65,29 @@ var _ = Describe("Major upgrade Job generation", func() {
 		Expect(imgName).To(Equal(newImageName))
 	})
 
+	It("creates major upgrade check jobs", func() {
+		checkJob := createMajorUpgradeCheckJobDefinition(&cluster, 1)
+		Expect(checkJob).ToNot(BeNil())
+		Expect(*checkJob.Spec.BackoffLimit).To(BeZero())
+		Expect(checkJob.Spec.Template.Spec.InitContainers).To(ContainElement(
+			HaveField("Image", oldImageInfo.Image)))
+
+		container := checkJob.Spec.Template.Spec.Containers[0]
+		Expect(container.Name).To(Equal(jobMajorUpgradeCheck))
+		Expect(container.Image).To(Equal(newImageName))
+		Expect(container.Command).To(ContainElement("--check"))
+		Expect(container.TerminationMessagePolicy).To(Equal(corev1.TerminationMessageFallbackToLogsOnError))
+	})
+
 	DescribeTable(
 		"Tells major upgrade jobs apart from jobs of other types",
-		func(job *batchv1.Job, isMajorUpgrade bool) {
+		func(job *batchv1.Job, isMajorUpgrade bool, isMajorUpgradeCheck bool) {
 			Expect(isMajorUpgradeJob(job)).To(Equal(isMajorUpgrade))
+			Expect(isMajorUpgradeCheckJob(job)).To(Equal(isMajorUpgradeCheck))
 		},
-		Entry("initdb jobs are not major upgrades", specs.CreatePrimaryJobViaInitdb(cluster, 1), false),
-		Entry("major-upgrade jobs are major upgrades", createMajorUpgradeJobDefinition(&cluster, 1), true),
+		Entry("initdb jobs are not major upgrades", specs.CreatePrimaryJobViaInitdb(cluster, 1), false, false),
+		Entry("major-upgrade jobs are major upgrades", createMajorUpgradeJobDefinition(&cluster, 1), true, false),
+		Entry("major-upgrade-check jobs are major upgrade checks",
+			createMajorUpgradeCheckJobDefinition(&cluster, 1), false, true),
 	)
 })
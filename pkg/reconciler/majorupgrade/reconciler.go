@@ -28,6 +28,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -60,10 +61,21 @@ func Reconcile(
 ) (*ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
+	if checkJob := getMajorUpgradeCheckJob(jobs); checkJob != nil {
+		return majorVersionUpgradeCheckHandleCompletion(ctx, c, cluster, checkJob)
+	}
+
 	if majorUpgradeJob := getMajorUpdateJob(jobs); majorUpgradeJob != nil {
+		if utils.JobHasFailed(*majorUpgradeJob) {
+			return majorVersionUpgradeHandleFailure(ctx, c, cluster, majorUpgradeJob)
+		}
 		return majorVersionUpgradeHandleCompletion(ctx, c, cluster, majorUpgradeJob, pvcs)
 	}
 
+	if err := reconcileUpgradedPrimaryStarted(ctx, c, cluster, instances); err != nil {
+		return nil, err
+	}
+
 	requestedMajor, err := cluster.GetPostgresqlMajorVersion()
 	if err != nil {
 		contextLogger.Error(err, "Unable to retrieve the requested PostgreSQL version")
@@ -73,17 +85,27 @@ func Reconcile(
 		return nil, nil
 	}
 
+	if cluster.IsMajorUpgradeOnHold() {
+		contextLogger.Debug("The major upgrade dry-run has completed, waiting for the annotation to be removed",
+			"requestedMajor", requestedMajor)
+		return nil, nil
+	}
+
 	primaryNodeSerial, err := getPrimarySerial(pvcs)
 	if err != nil || primaryNodeSerial == 0 {
 		contextLogger.Error(err, "Unable to retrieve the primary node serial")
 		return nil, err
 	}
 
+	dryRun := utils.IsMajorUpgradeDryRun(&cluster.ObjectMeta)
 	contextLogger.Info("Reconciling in-place major version upgrades",
-		"primaryNodeSerial", primaryNodeSerial, "requestedMajor", requestedMajor)
+		"primaryNodeSerial", primaryNodeSerial, "requestedMajor", requestedMajor, "dryRun", dryRun)
 
-	err = registerPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade,
-		fmt.Sprintf("Upgrading cluster to major version %v", requestedMajor))
+	phaseReason := fmt.Sprintf("Upgrading cluster to major version %v", requestedMajor)
+	if dryRun {
+		phaseReason = fmt.Sprintf("Checking the upgrade of the cluster to major version %v", requestedMajor)
+	}
+	err = registerPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade, phaseReason)
 	if err != nil {
 		return nil, err
 	}
@@ -95,13 +117,30 @@ func Reconcile(
 		return result, err
 	}
 
-	if result, err := createMajorUpgradeJob(ctx, c, cluster, primaryNodeSerial); err != nil {
+	job := createMajorUpgradeJobDefinition(cluster, primaryNodeSerial)
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionMajorUpgrade),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonMajorUpgradeRunning),
+		Message: fmt.Sprintf("Running pg_upgrade to major version %v", requestedMajor),
+	}
+	if dryRun {
+		job = createMajorUpgradeCheckJobDefinition(cluster, primaryNodeSerial)
+		condition.Reason = string(apiv1.ConditionReasonMajorUpgradeCheckRunning)
+		condition.Message = fmt.Sprintf("Running pg_upgrade --check against major version %v", requestedMajor)
+	}
+
+	if result, err := createMajorUpgradeJob(ctx, c, cluster, job); err != nil {
 		contextLogger.Error(err, "Unable to create major upgrade job")
 		return nil, err
 	} else if result != nil {
 		return result, err
 	}
 
+	if err := status.PatchConditionsWithOptimisticLock(ctx, c, cluster, condition); err != nil {
+		return nil, err
+	}
+
 	return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 }
 
@@ -115,6 +154,16 @@ func getMajorUpdateJob(items []batchv1.Job) *batchv1.Job {
 	return nil
 }
 
+func getMajorUpgradeCheckJob(items []batchv1.Job) *batchv1.Job {
+	for _, job := range items {
+		if isMajorUpgradeCheckJob(&job) {
+			return &job
+		}
+	}
+
+	return nil
+}
+
 func deleteAllPodsInMajorUpgradePreparation(
 	ctx context.Context,
 	c client.Client,
@@ -158,12 +207,10 @@ func createMajorUpgradeJob(
 	ctx context.Context,
 	c client.Client,
 	cluster *apiv1.Cluster,
-	primaryNodeSerial int,
+	job *batchv1.Job,
 ) (*ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
-	job := createMajorUpgradeJobDefinition(cluster, primaryNodeSerial)
-
 	if err := ctrl.SetControllerReference(cluster, job, c.Scheme()); err != nil {
 		contextLogger.Error(err, "Unable to set the owner reference for major upgrade job")
 		return nil, err
@@ -245,6 +292,16 @@ func majorVersionUpgradeHandleCompletion(
 			Image:        jobImage,
 			MajorVersion: requestedMajor,
 		}),
+		status.SetStatisticsOutdated(
+			fmt.Sprintf("The statistics need to be collected after the upgrade to major version %v",
+				requestedMajor)),
+		status.SetCondition(metav1.Condition{
+			Type:   string(apiv1.ConditionMajorUpgrade),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonMajorUpgradeStarting),
+			Message: fmt.Sprintf("pg_upgrade completed, waiting for the primary instance to start "+
+				"on major version %v", requestedMajor),
+		}),
 	); err != nil {
 		contextLogger.Error(err, "Unable to update cluster status after major upgrade completed.")
 		return nil, err
@@ -260,6 +317,177 @@ func majorVersionUpgradeHandleCompletion(
 	return &ctrl.Result{Requeue: true}, nil
 }
 
+// majorVersionUpgradeHandleFailure reports the failure of the major upgrade
+// job. As pg_upgrade doesn't touch the data directory it is upgrading until
+// it succeeds, the cluster is rolled back to the previous major version as
+// soon as the user requests it again
+func majorVersionUpgradeHandleFailure(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	job *batchv1.Job,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	requestedMajor, err := cluster.GetPostgresqlMajorVersion()
+	if err != nil {
+		contextLogger.Error(err, "Unable to retrieve the requested PostgreSQL version")
+		return nil, err
+	}
+
+	currentMajor := cluster.Status.PGDataImageInfo.MajorVersion
+	if requestedMajor > currentMajor {
+		contextLogger.Info("Major upgrade job failed, waiting for the previous major version to be requested",
+			"jobName", job.Name, "currentMajor", currentMajor, "requestedMajor", requestedMajor)
+		if err := status.PatchConditionsWithOptimisticLock(ctx, c, cluster, metav1.Condition{
+			Type:   string(apiv1.ConditionMajorUpgrade),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonMajorUpgradeFailed),
+			Message: fmt.Sprintf("The major upgrade job %s failed, the data directory is still on major version %v: "+
+				"request it again to roll back the cluster, or delete the job to retry the upgrade",
+				job.Name, currentMajor),
+		}); err != nil {
+			return nil, err
+		}
+		return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	contextLogger.Info("Rolling back the failed major upgrade",
+		"jobName", job.Name, "currentMajor", currentMajor)
+	if err := c.Delete(ctx, job, &client.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	}); err != nil && !errors.IsNotFound(err) {
+		contextLogger.Error(err, "Unable to delete major upgrade job.")
+		return nil, err
+	}
+
+	if err := status.PatchConditionsWithOptimisticLock(ctx, c, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionMajorUpgrade),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonMajorUpgradeRolledBack),
+		Message: fmt.Sprintf("The cluster has been rolled back to major version %v", currentMajor),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+// majorVersionUpgradeCheckHandleCompletion reports the output of
+// "pg_upgrade --check" in the MajorUpgrade condition, and removes the job
+// once completed. The upgrade is then held for the current generation of the
+// cluster, as long as the dry-run is requested
+func majorVersionUpgradeCheckHandleCompletion(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	job *batchv1.Job,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	succeeded := utils.JobHasOneCompletion(*job)
+	if !succeeded && !utils.JobHasFailed(*job) {
+		contextLogger.Info("Major upgrade check job not completed.")
+		return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	output, err := getMajorUpgradeCheckOutput(ctx, c, job)
+	if err != nil {
+		contextLogger.Error(err, "Unable to get the output of the major upgrade check.")
+		return nil, err
+	}
+
+	if err := status.PatchConditionsWithOptimisticLock(
+		ctx, c, cluster, buildMajorUpgradeCheckCondition(cluster, succeeded, output),
+	); err != nil {
+		contextLogger.Error(err, "Unable to report the result of the major upgrade check.")
+		return nil, err
+	}
+
+	if err := c.Delete(ctx, job, &client.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	}); err != nil && !errors.IsNotFound(err) {
+		contextLogger.Error(err, "Unable to delete major upgrade check job.")
+		return nil, err
+	}
+
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+// getMajorUpgradeCheckOutput gets the output of "pg_upgrade --check"
+// from the termination message of the container of the check job
+func getMajorUpgradeCheckOutput(ctx context.Context, c client.Client, job *batchv1.Job) (string, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != jobMajorUpgradeCheck || containerStatus.State.Terminated == nil {
+				continue
+			}
+			return containerStatus.State.Terminated.Message, nil
+		}
+	}
+
+	return "", nil
+}
+
+// buildMajorUpgradeCheckCondition builds the condition reporting the
+// result of "pg_upgrade --check" for the current generation of the cluster
+func buildMajorUpgradeCheckCondition(cluster *apiv1.Cluster, succeeded bool, output string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(apiv1.ConditionMajorUpgrade),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(apiv1.ConditionReasonMajorUpgradeCheckSucceeded),
+		ObservedGeneration: cluster.Generation,
+		Message:            "pg_upgrade --check succeeded",
+	}
+	if !succeeded {
+		condition.Reason = string(apiv1.ConditionReasonMajorUpgradeCheckFailed)
+		condition.Message = "pg_upgrade --check failed"
+	}
+	if output != "" {
+		condition.Message += ": " + output
+	}
+
+	return condition
+}
+
+// reconcileUpgradedPrimaryStarted marks the major upgrade as succeeded
+// once the upgraded primary instance is up and running
+func reconcileUpgradedPrimaryStarted(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	instances []corev1.Pod,
+) error {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+	if condition == nil || condition.Reason != string(apiv1.ConditionReasonMajorUpgradeStarting) {
+		return nil
+	}
+
+	for _, pod := range instances {
+		if pod.GetDeletionTimestamp() != nil || !specs.IsPodPrimary(pod) || !utils.IsPodReady(pod) {
+			continue
+		}
+
+		return status.PatchConditionsWithOptimisticLock(ctx, c, cluster, metav1.Condition{
+			Type:   string(apiv1.ConditionMajorUpgrade),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonMajorUpgradeSucceeded),
+			Message: fmt.Sprintf("The primary instance %s is running on major version %v",
+				pod.Name, cluster.Status.PGDataImageInfo.MajorVersion),
+		})
+	}
+
+	return nil
+}
+
 // registerPhase sets a phase into the cluster
 func registerPhase(
 	ctx context.Context,
@@ -25,6 +25,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -113,6 +114,180 @@ var _ = Describe("Major upgrade job status reconciliation", func() {
 	})
 })
 
+var _ = Describe("Major upgrade job failure", func() {
+	newCluster := func(imageName string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: imageName,
+			},
+			Status: apiv1.ClusterStatus{
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:16",
+					MajorVersion: 16,
+				},
+			},
+		}
+	}
+
+	It("reports the failure while the new major version is requested", func(ctx SpecContext) {
+		job := buildFailedUpgradeJob()
+		cluster := newCluster("postgres:17")
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithRuntimeObjects(job, cluster).
+			WithStatusSubresource(cluster).
+			Build()
+
+		result, err := majorVersionUpgradeHandleFailure(ctx, fakeClient, cluster, job)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeFailed)))
+
+		// the job is kept to be inspected
+		var tempJob batchv1.Job
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(job), &tempJob)).To(Succeed())
+	})
+
+	It("rolls back when the previous major version is requested again", func(ctx SpecContext) {
+		job := buildFailedUpgradeJob()
+		cluster := newCluster("postgres:16")
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithRuntimeObjects(job, cluster).
+			WithStatusSubresource(cluster).
+			Build()
+
+		result, err := majorVersionUpgradeHandleFailure(ctx, fakeClient, cluster, job)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeRolledBack)))
+
+		var tempJob batchv1.Job
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(job), &tempJob)
+		Expect(err).To(MatchError(errors.IsNotFound, "is not found"))
+	})
+})
+
+var _ = Describe("Major upgrade check", func() {
+	It("reports the output of pg_upgrade --check", func(ctx SpecContext) {
+		job := buildCompletedUpgradeCheckJob()
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cluster-example",
+				Namespace:  "default",
+				Generation: 2,
+			},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example-1-major-upgrade-check-abcde",
+				Namespace: "default",
+				Labels: map[string]string{
+					batchv1.JobNameLabel: job.Name,
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: jobMajorUpgradeCheck,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: "*Clusters are compatible*",
+							},
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithRuntimeObjects(job, cluster, pod).
+			WithStatusSubresource(cluster).
+			Build()
+
+		result, err := majorVersionUpgradeCheckHandleCompletion(ctx, fakeClient, cluster, job)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeCheckSucceeded)))
+		Expect(condition.Message).To(ContainSubstring("*Clusters are compatible*"))
+		Expect(condition.ObservedGeneration).To(BeEquivalentTo(2))
+
+		var tempJob batchv1.Job
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(job), &tempJob)
+		Expect(err).To(MatchError(errors.IsNotFound, "is not found"))
+	})
+
+	It("reports the failure of pg_upgrade --check", func() {
+		condition := buildMajorUpgradeCheckCondition(&apiv1.Cluster{}, false, "incompatible extension")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeCheckFailed)))
+		Expect(condition.Message).To(ContainSubstring("incompatible extension"))
+	})
+})
+
+var _ = Describe("Upgraded primary startup", func() {
+	It("marks the upgrade as succeeded once the primary is ready", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Status: apiv1.ClusterStatus{
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:17",
+					MajorVersion: 17,
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(apiv1.ConditionMajorUpgrade),
+						Status: metav1.ConditionFalse,
+						Reason: string(apiv1.ConditionReasonMajorUpgradeStarting),
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithRuntimeObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+
+		primary := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example-1",
+				Labels: map[string]string{
+					utils.ClusterInstanceRoleLabelName: specs.ClusterRoleLabelPrimary,
+				},
+			},
+		}
+
+		// the primary is not ready yet
+		Expect(reconcileUpgradedPrimaryStarted(ctx, fakeClient, cluster, []corev1.Pod{primary})).To(Succeed())
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeStarting)))
+
+		primary.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+		}
+		Expect(reconcileUpgradedPrimaryStarted(ctx, fakeClient, cluster, []corev1.Pod{primary})).To(Succeed())
+		condition = meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionMajorUpgrade))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonMajorUpgradeSucceeded)))
+	})
+})
+
 var _ = Describe("Major upgrade job decoding", func() {
 	It("is able to find the target image", func() {
 		job := buildCompletedUpgradeJob()
@@ -220,6 +395,35 @@ func buildCompletedUpgradeJob() *batchv1.Job {
 	}
 }
 
+func buildFailedUpgradeJob() *batchv1.Job {
+	job := buildCompletedUpgradeJob()
+	job.Status = batchv1.JobStatus{
+		Failed: 1,
+		Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+		},
+	}
+	return job
+}
+
+func buildCompletedUpgradeCheckJob() *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example-1-major-upgrade-check",
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.JobRoleLabelName: jobMajorUpgradeCheck,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Completions: ptr.To[int32](1),
+		},
+		Status: batchv1.JobStatus{
+			Succeeded: 1,
+		},
+	}
+}
+
 func buildRunningUpgradeJob() *batchv1.Job {
 	return &batchv1.Job{
 		Spec: batchv1.JobSpec{
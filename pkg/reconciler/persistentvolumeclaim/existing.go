@@ -21,17 +21,37 @@ package persistentvolumeclaim
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
+	clusterstatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
+// volumeExpansionNotAllowedError is raised when a PVC needs to be resized
+// but its storage class doesn't allow volume expansion
+type volumeExpansionNotAllowedError struct {
+	pvcName          string
+	storageClassName string
+}
+
+// Error implements the error interface
+func (e *volumeExpansionNotAllowedError) Error() string {
+	return fmt.Sprintf("PVC %s cannot be resized: storage class %s doesn't allow volume expansion",
+		e.pvcName, e.storageClassName)
+}
+
 type reconciliationUnit func(
 	ctx context.Context,
 	c client.Client,
@@ -65,6 +85,7 @@ func reconcileExistingPVCs(
 		return nil
 	}
 
+	var notExpandable []string
 	for idx := range pvcs {
 		pvc := &pvcs[idx]
 
@@ -88,13 +109,83 @@ func reconcileExistingPVCs(
 		}
 
 		for _, reconciler := range reconciliationUnits {
-			if err := reconciler(ctx, c, &storageConfiguration, pvc); err != nil {
+			err := reconciler(ctx, c, &storageConfiguration, pvc)
+			var expansionErr *volumeExpansionNotAllowedError
+			if errors.As(err, &expansionErr) {
+				contextLogger.Warning("cannot resize PVC, volume expansion is not allowed by its storage class",
+					"pvcName", pvc.Name,
+					"storageClassName", expansionErr.storageClassName)
+				notExpandable = append(notExpandable, expansionErr.Error())
+				continue
+			}
+			if err != nil {
 				return err
 			}
 		}
 	}
 
-	return nil
+	return reconcileStorageResizeCondition(ctx, c, cluster, notExpandable)
+}
+
+// reconcileStorageResizeCondition reports in the cluster status the PVCs
+// that couldn't be resized because their storage class doesn't allow
+// volume expansion
+func reconcileStorageResizeCondition(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	notExpandable []string,
+) error {
+	if len(notExpandable) > 0 {
+		return clusterstatus.PatchConditionsWithOptimisticLock(ctx, c, cluster, metav1.Condition{
+			Type:    string(apiv1.ConditionStorageResize),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonVolumeExpansionNotAllowed),
+			Message: strings.Join(notExpandable, "; "),
+		})
+	}
+
+	// we only report a successful resize if we previously reported a failure
+	if !meta.IsStatusConditionFalse(cluster.Status.Conditions, string(apiv1.ConditionStorageResize)) {
+		return nil
+	}
+
+	return clusterstatus.PatchConditionsWithOptimisticLock(ctx, c, cluster, metav1.Condition{
+		Type:    string(apiv1.ConditionStorageResize),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonVolumeExpansionApplied),
+		Message: "Every requested volume expansion has been applied",
+	})
+}
+
+// isVolumeExpansionAllowed checks whether the storage class of the passed
+// PVC allows volume expansion. If the storage class can't be detected, or
+// the operator is not allowed to read it, as in namespace-scoped
+// installations, we let the API server decide.
+func isVolumeExpansionAllowed(
+	ctx context.Context,
+	c client.Client,
+	pvc *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return true, nil
+	}
+
+	var storageClass storagev1.StorageClass
+	err := c.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, &storageClass)
+	if apierrs.IsNotFound(err) {
+		return true, nil
+	}
+	if apierrs.IsForbidden(err) {
+		log.FromContext(ctx).Debug("Cannot read the storage class, attempting the resize",
+			"storageClassName", *pvc.Spec.StorageClassName, "err", err)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("while getting storage class %s: %w", *pvc.Spec.StorageClassName, err)
+	}
+
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
 }
 
 func reconcileVolumeAttributeClass(
@@ -145,6 +236,17 @@ func reconcilePVCQuantity(
 		return nil
 	}
 
+	allowed, err := isVolumeExpansionAllowed(ctx, c, pvc)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &volumeExpansionNotAllowedError{
+			pvcName:          pvc.Name,
+			storageClassName: *pvc.Spec.StorageClassName,
+		}
+	}
+
 	oldPVC := pvc.DeepCopy()
 	// right now we reconcile the metadata in a different set of functions, so it's not needed to do it here
 	pvc = resources.NewPersistentVolumeClaimBuilderFromPVC(pvc).
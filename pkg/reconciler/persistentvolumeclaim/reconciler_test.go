@@ -21,15 +21,20 @@ package persistentvolumeclaim
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -604,6 +609,144 @@ var _ = Describe("Reconcile PVC Quantity", func() {
 	})
 })
 
+var _ = Describe("Reconcile PVC Quantity with storage class constraints", func() {
+	const (
+		clusterName      = "cluster-pvc-expansion"
+		tbsName          = "fragglerock"
+		storageClassName = "not-expandable"
+	)
+
+	var (
+		cluster *apiv1.Cluster
+		pvc     corev1.PersistentVolumeClaim
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name: tbsName,
+						Storage: apiv1.StorageConfiguration{
+							Size: "4Gi",
+						},
+					},
+				},
+			},
+		}
+		pvc = makePVC(clusterName, "1", "1", NewPgTablespaceCalculator(tbsName), false)
+		pvc.Spec.StorageClassName = ptr.To(storageClassName)
+		pvc.Spec.Resources.Requests = map[corev1.ResourceName]resource.Quantity{
+			"storage": resource.MustParse("3Gi"),
+		}
+	})
+
+	buildClient := func(allowVolumeExpansion *bool) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster, &pvc, &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: storageClassName},
+				AllowVolumeExpansion: allowVolumeExpansion,
+			}).
+			WithStatusSubresource(cluster).
+			Build()
+	}
+
+	It("refuses to resize a PVC whose storage class doesn't allow expansion", func(ctx SpecContext) {
+		cli := buildClient(ptr.To(false))
+		storageConfiguration, err := NewPgTablespaceCalculator(tbsName).GetStorageConfiguration(cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = reconcilePVCQuantity(ctx, cli, &storageConfiguration, &pvc)
+		var expansionErr *volumeExpansionNotAllowedError
+		Expect(errors.As(err, &expansionErr)).To(BeTrue())
+		Expect(expansionErr.pvcName).To(Equal(pvc.Name))
+		Expect(expansionErr.storageClassName).To(Equal(storageClassName))
+
+		var livePVC corev1.PersistentVolumeClaim
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(&pvc), &livePVC)).To(Succeed())
+		Expect(livePVC.Spec.Resources.Requests.Storage().String()).To(Equal("3Gi"))
+	})
+
+	It("resizes a PVC whose storage class allows expansion", func(ctx SpecContext) {
+		cli := buildClient(ptr.To(true))
+		storageConfiguration, err := NewPgTablespaceCalculator(tbsName).GetStorageConfiguration(cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reconcilePVCQuantity(ctx, cli, &storageConfiguration, &pvc)).To(Succeed())
+
+		var livePVC corev1.PersistentVolumeClaim
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(&pvc), &livePVC)).To(Succeed())
+		Expect(livePVC.Spec.Resources.Requests.Storage().String()).To(Equal("4Gi"))
+	})
+
+	It("attempts the resize when the storage class can't be read", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster, &pvc).
+			WithStatusSubresource(cluster).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(
+					ctx context.Context,
+					cli client.WithWatch,
+					key client.ObjectKey,
+					obj client.Object,
+					opts ...client.GetOption,
+				) error {
+					if _, ok := obj.(*storagev1.StorageClass); ok {
+						return apierrs.NewForbidden(storagev1.Resource("storageclasses"), key.Name, nil)
+					}
+					return cli.Get(ctx, key, obj, opts...)
+				},
+			}).
+			Build()
+		storageConfiguration, err := NewPgTablespaceCalculator(tbsName).GetStorageConfiguration(cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reconcilePVCQuantity(ctx, cli, &storageConfiguration, &pvc)).To(Succeed())
+
+		var livePVC corev1.PersistentVolumeClaim
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(&pvc), &livePVC)).To(Succeed())
+		Expect(livePVC.Spec.Resources.Requests.Storage().String()).To(Equal("4Gi"))
+	})
+
+	It("reports the PVCs that cannot be resized in the cluster conditions", func(ctx SpecContext) {
+		cli := buildClient(nil)
+
+		Expect(reconcileExistingPVCs(ctx, cli, cluster, []corev1.PersistentVolumeClaim{pvc})).To(Succeed())
+
+		var liveCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+		condition := meta.FindStatusCondition(liveCluster.Status.Conditions, string(apiv1.ConditionStorageResize))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonVolumeExpansionNotAllowed)))
+		Expect(condition.Message).To(ContainSubstring(pvc.Name))
+	})
+
+	It("clears the condition once the PVCs can be resized", func(ctx SpecContext) {
+		cluster.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(apiv1.ConditionStorageResize),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(apiv1.ConditionReasonVolumeExpansionNotAllowed),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		cli := buildClient(ptr.To(true))
+
+		Expect(reconcileExistingPVCs(ctx, cli, cluster, []corev1.PersistentVolumeClaim{pvc})).To(Succeed())
+
+		var liveCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &liveCluster)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(liveCluster.Status.Conditions, string(apiv1.ConditionStorageResize))).
+			To(BeTrue())
+	})
+})
+
 var _ = Describe("Reconcile Volume Attribute Class", func() {
 	var (
 		clusterName = "cluster-volume-attr"
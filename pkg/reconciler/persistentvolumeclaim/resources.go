@@ -146,6 +146,10 @@ func getExpectedPVCsFromCluster(cluster *apiv1.Cluster, instanceName string) []e
 		roles = append(roles, NewPgWalCalculator())
 	}
 	for _, tbsConfig := range cluster.Spec.Tablespaces {
+		// Ephemeral tablespaces are not stored in a PVC managed by the operator
+		if tbsConfig.IsEphemeral() {
+			continue
+		}
 		roles = append(roles, NewPgTablespaceCalculator(tbsConfig.Name))
 	}
 	return buildExpectedPVCs(instanceName, roles)
@@ -150,4 +150,14 @@ var _ = Describe("instance with tablespace test", func() {
 			Expect(pvc.name).Should(Equal(pvc.calculator.GetName(instanceName)))
 		}
 	})
+
+	It("doesn't expect a pvc for ephemeral tablespaces", func() {
+		ephemeralCluster := cluster.DeepCopy()
+		ephemeralCluster.Spec.Tablespaces = append(ephemeralCluster.Spec.Tablespaces, apiv1.TablespaceConfiguration{
+			Name:      "tmp",
+			Temporary: true,
+			Ephemeral: &apiv1.EphemeralTablespaceStorage{},
+		})
+		Expect(getExpectedPVCsFromCluster(ephemeralCluster, instanceName)).Should(HaveLen(5))
+	})
 })
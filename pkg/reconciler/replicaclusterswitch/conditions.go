@@ -34,6 +34,35 @@ const (
 	ConditionReplicaClusterSwitch = "ReplicaClusterSwitch"
 )
 
+var (
+	// PromotionStartedCondition is set by the instance manager when the
+	// designated primary of a replica cluster starts being promoted to a
+	// primary instance
+	PromotionStartedCondition = metav1.Condition{
+		Type:    string(apiv1.ConditionReplicaClusterPromotion),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonPromotionStarted),
+		Message: "Promoting the designated primary and detaching from the replication source",
+	}
+
+	// PromotionCompletedCondition is set by the instance manager when the
+	// promoted primary instance doesn't reference the former replication
+	// source anymore
+	PromotionCompletedCondition = metav1.Condition{
+		Type:    string(apiv1.ConditionReplicaClusterPromotion),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonPromotionCompleted),
+		Message: "Completed the promotion of the replica cluster",
+	}
+)
+
+// IsPromotionRunning returns a boolean indicating if the promotion of the
+// replica cluster has started, and the promoted primary instance may still
+// reference the former replication source
+func IsPromotionRunning(cluster *apiv1.Cluster) bool {
+	return meta.IsStatusConditionFalse(cluster.Status.Conditions, string(apiv1.ConditionReplicaClusterPromotion))
+}
+
 // IsDesignatedPrimaryTransitionRequested returns a boolean indicating if the instance primary should transition to
 // designated primary
 func IsDesignatedPrimaryTransitionRequested(cluster *apiv1.Cluster) bool {
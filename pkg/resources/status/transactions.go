@@ -76,3 +76,29 @@ func SetPGDataImageInfo(imageInfo *apiv1.ImageInfo) Transaction {
 		cluster.Status.PGDataImageInfo = imageInfo
 	}
 }
+
+// SetStatisticsOutdated is a transaction that requests the instance manager
+// of the primary to collect the statistics of every database, unless the
+// automatic analyze is disabled in the cluster. Replica clusters are skipped,
+// as their statistics are replicated from the source cluster
+func SetStatisticsOutdated(message string) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		if !cluster.IsAutomaticAnalyzeEnabled() || cluster.IsReplica() {
+			return
+		}
+
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionStatisticsUpToDate),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonAnalyzePending),
+			Message: message,
+		})
+	}
+}
+
+// SetCondition is a transaction that sets the passed condition
+func SetCondition(condition metav1.Condition) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	}
+}
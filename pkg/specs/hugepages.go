@@ -0,0 +1,141 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// defaultSharedBuffers is the value of `shared_buffers` used by
+	// PostgreSQL when not specified
+	defaultSharedBuffers = "128MB"
+
+	// sharedBuffersBlockSize is the unit of `shared_buffers`, when the
+	// value is specified without one
+	sharedBuffersBlockSize = 8 * 1024
+
+	// sharedMemoryMinimumOverhead is the minimum amount of shared memory
+	// used by PostgreSQL in addition to `shared_buffers`
+	sharedMemoryMinimumOverhead = 64 * 1024 * 1024
+
+	// sharedMemoryOverheadRatio is the fraction of `shared_buffers` used
+	// by PostgreSQL for the other shared memory structures, like the
+	// buffer descriptors and the WAL buffers
+	sharedMemoryOverheadRatio = 0.05
+)
+
+// GetHugePagesRequest returns the amount of huge pages needed by the shared
+// memory of PostgreSQL, computed from `shared_buffers` with a margin for the
+// other shared memory structures, and rounded up to the size of the pages
+func GetHugePagesRequest(cluster *apiv1.Cluster) (resource.Quantity, error) {
+	hugePages := cluster.Spec.PostgresConfiguration.HugePages
+	if hugePages == nil {
+		return resource.Quantity{}, nil
+	}
+
+	sharedBuffersValue := cluster.Spec.PostgresConfiguration.Parameters["shared_buffers"]
+	if sharedBuffersValue == "" {
+		sharedBuffersValue = defaultSharedBuffers
+	}
+	if postgres.IsMemoryRelativeValue(sharedBuffersValue) {
+		var memoryLimit int64
+		if limit := cluster.Spec.Resources.Limits.Memory(); limit != nil {
+			memoryLimit = limit.Value()
+		}
+
+		var err error
+		sharedBuffersValue, err = postgres.ResolveMemoryRelativeValue(sharedBuffersValue, memoryLimit)
+		if err != nil {
+			return resource.Quantity{}, err
+		}
+	}
+
+	sharedBuffers, err := postgres.ParsePostgresConfigMemory(sharedBuffersValue, sharedBuffersBlockSize)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("while parsing shared_buffers: %w", err)
+	}
+
+	sharedMemory := sharedBuffers + max(
+		int64(float64(sharedBuffers)*sharedMemoryOverheadRatio),
+		sharedMemoryMinimumOverhead,
+	)
+
+	pageSize := hugePages.GetPageSize()
+	pages := (sharedMemory + pageSize.Value() - 1) / pageSize.Value()
+	return *resource.NewQuantity(pages*pageSize.Value(), resource.BinarySI), nil
+}
+
+// GetInstanceResources returns the resources of the containers running
+// PostgreSQL, including the huge pages needed by its shared memory when
+// they are managed by the operator and available on the nodes
+func GetInstanceResources(cluster apiv1.Cluster) corev1.ResourceRequirements {
+	return addHugePagesResources(cluster, cluster.Spec.Resources)
+}
+
+// GetRecoveryJobResources returns the resources of the containers of the
+// jobs restoring a backup, which default to the ones of the instances
+func GetRecoveryJobResources(cluster apiv1.Cluster) corev1.ResourceRequirements {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Resources == nil {
+		return GetInstanceResources(cluster)
+	}
+
+	return addHugePagesResources(cluster, *cluster.Spec.Backup.Resources)
+}
+
+// addHugePagesResources adds to the passed resources the huge pages needed
+// by the shared memory of PostgreSQL, when they are managed by the operator
+// and available on the nodes
+func addHugePagesResources(
+	cluster apiv1.Cluster,
+	baseResources corev1.ResourceRequirements,
+) corev1.ResourceRequirements {
+	hugePages := cluster.Spec.PostgresConfiguration.HugePages
+	if hugePages == nil || cluster.Status.HugePagesFallback {
+		return baseResources
+	}
+
+	hugePagesRequest, err := GetHugePagesRequest(&cluster)
+	if err != nil {
+		log.Error(err, "while computing the huge pages request, ignoring it",
+			"clusterName", cluster.Name, "namespace", cluster.Namespace)
+		return baseResources
+	}
+
+	resources := baseResources.DeepCopy()
+	if resources.Requests == nil {
+		resources.Requests = corev1.ResourceList{}
+	}
+	if resources.Limits == nil {
+		resources.Limits = corev1.ResourceList{}
+	}
+
+	// Kubernetes requires the huge pages limits to be equal to the requests
+	resources.Requests[hugePages.GetResourceName()] = hugePagesRequest
+	resources.Limits[hugePages.GetResourceName()] = hugePagesRequest
+	return *resources
+}
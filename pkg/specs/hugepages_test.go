@@ -0,0 +1,99 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Huge pages", func() {
+	newCluster := func(sharedBuffers string) apiv1.Cluster {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					HugePages:  &apiv1.HugePagesConfiguration{},
+					Parameters: map[string]string{},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		}
+		if sharedBuffers != "" {
+			cluster.Spec.PostgresConfiguration.Parameters["shared_buffers"] = sharedBuffers
+		}
+		return cluster
+	}
+
+	DescribeTable("computes the huge pages needed by the shared memory",
+		func(sharedBuffers, pageSize, expected string) {
+			cluster := newCluster(sharedBuffers)
+			cluster.Spec.PostgresConfiguration.HugePages.PageSize = pageSize
+
+			request, err := GetHugePagesRequest(&cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(request.Cmp(resource.MustParse(expected))).To(BeZero(), request.String())
+		},
+		Entry("with the default shared_buffers", "", "", "192Mi"),
+		Entry("with a small shared_buffers", "256MB", "", "320Mi"),
+		Entry("with a large shared_buffers", "2GB", "", "2152Mi"),
+		Entry("with shared_buffers in blocks", "131072", "", "1088Mi"),
+		Entry("with shared_buffers as a percentage", "25%", "", "1088Mi"),
+		Entry("with 1Gi pages", "2GB", "1Gi", "3Gi"),
+	)
+
+	It("fails when shared_buffers is not valid", func() {
+		cluster := newCluster("1Gi")
+		_, err := GetHugePagesRequest(&cluster)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("requests the huge pages in the instance resources", func() {
+		cluster := newCluster("2GB")
+		resources := GetInstanceResources(cluster)
+		Expect(resources.Requests.Name("hugepages-2Mi", resource.BinarySI).String()).To(Equal("2152Mi"))
+		Expect(resources.Limits.Name("hugepages-2Mi", resource.BinarySI).String()).To(Equal("2152Mi"))
+		Expect(resources.Requests.Memory().String()).To(Equal("4Gi"))
+		Expect(cluster.Spec.Resources.Requests).ToNot(HaveKey(corev1.ResourceName("hugepages-2Mi")))
+	})
+
+	It("doesn't request the huge pages when they are not available", func() {
+		cluster := newCluster("2GB")
+		cluster.Status.HugePagesFallback = true
+		Expect(GetInstanceResources(cluster)).To(Equal(cluster.Spec.Resources))
+	})
+
+	It("doesn't request the huge pages when they are not managed", func() {
+		cluster := newCluster("2GB")
+		cluster.Spec.PostgresConfiguration.HugePages = nil
+		Expect(GetInstanceResources(cluster)).To(Equal(cluster.Spec.Resources))
+	})
+})
@@ -108,9 +108,28 @@ func CreatePrimaryJobViaInitdb(cluster apiv1.Cluster, nodeSerial int) *batchv1.J
 			"--post-init-sql-refs-folder", postInitSQLRefsFolder.toString())
 	}
 
+	if cluster.IsImportDumpBootstrap() {
+		job := CreatePrimaryJob(cluster, nodeSerial, jobRoleImport, initCommand)
+		addBarmanEndpointCAToJobFromImportDump(cluster, job)
+		return job
+	}
+
 	return CreatePrimaryJob(cluster, nodeSerial, jobRoleInitDB, initCommand)
 }
 
+func addBarmanEndpointCAToJobFromImportDump(cluster apiv1.Cluster, job *batchv1.Job) {
+	externalCluster, ok := cluster.ExternalCluster(cluster.Spec.Bootstrap.InitDB.ImportDump.Source.ExternalCluster)
+	if !ok || externalCluster.BarmanObjectStore == nil {
+		return
+	}
+
+	endpointCA := externalCluster.BarmanObjectStore.EndpointCA
+	if endpointCA != nil && endpointCA.Name != "" && endpointCA.Key != "" {
+		AddBarmanEndpointCAToPodSpec(&job.Spec.Template.Spec, endpointCA,
+			externalCluster.BarmanObjectStore.BarmanCredentials)
+	}
+}
+
 func buildInitDBFlags(cluster apiv1.Cluster) (initCommand []string) {
 	config := cluster.Spec.Bootstrap.InitDB
 	var options []string
@@ -218,6 +237,11 @@ func CreatePrimaryJobViaRecovery(cluster apiv1.Cluster, nodeSerial int, backup *
 
 	addBarmanEndpointCAToJobFromCluster(cluster, backup, job)
 
+	// The WAL archive destination is checked before restoring the backup
+	if cluster.Spec.Backup.IsBarmanEndpointCASet() {
+		AddBarmanBackupEndpointCAToPodSpec(&job.Spec.Template.Spec, cluster.Spec.Backup.BarmanObjectStore.EndpointCA)
+	}
+
 	return job
 }
 
@@ -227,6 +251,9 @@ func addBarmanEndpointCAToJobFromCluster(cluster apiv1.Cluster, backup *apiv1.Ba
 	switch {
 	case cluster.Spec.Bootstrap.Recovery.Backup != nil && cluster.Spec.Bootstrap.Recovery.Backup.EndpointCA != nil:
 		endpointCA = cluster.Spec.Bootstrap.Recovery.Backup.EndpointCA
+		if backup != nil {
+			credentials = backup.Status.BarmanCredentials
+		}
 
 	case backup != nil && backup.Status.EndpointCA != nil:
 		endpointCA = backup.Status.EndpointCA
@@ -314,6 +341,16 @@ func (role jobRole) getJobName(instanceName string) string {
 	return fmt.Sprintf("%s-%s", instanceName, role)
 }
 
+// getResources returns the resources of the container of the job
+func (role jobRole) getResources(cluster apiv1.Cluster) corev1.ResourceRequirements {
+	switch role {
+	case jobRoleFullRecovery, jobRoleSnapshotRecovery:
+		return GetRecoveryJobResources(cluster)
+	default:
+		return GetInstanceResources(cluster)
+	}
+}
+
 // CreatePrimaryJob create a job that executes the provided command.
 // The role should describe the purpose of the executed job
 func CreatePrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initCommand []string) *batchv1.Job {
@@ -356,7 +393,7 @@ func CreatePrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 							EnvFrom:         envConfig.EnvFrom,
 							Command:         initCommand,
 							VolumeMounts:    CreatePostgresVolumeMounts(cluster),
-							Resources:       cluster.Spec.Resources,
+							Resources:       role.getResources(cluster),
 							SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
 						},
 					},
@@ -370,7 +407,7 @@ func CreatePrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 					ServiceAccountName:        cluster.Name,
 					RestartPolicy:             corev1.RestartPolicyNever,
 					NodeSelector:              cluster.Spec.Affinity.NodeSelector,
-					TopologySpreadConstraints: cluster.Spec.TopologySpreadConstraints,
+					TopologySpreadConstraints: CreateTopologySpreadConstraints(cluster),
 				},
 			},
 		},
@@ -24,9 +24,11 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -97,7 +99,7 @@ var _ = Describe("Barman endpoint CA", func() {
 			},
 		}
 		addBarmanEndpointCAToJobFromCluster(cluster, nil, &job)
-		Expect(job.Spec.Template.Spec.Volumes[0].VolumeSource.Secret.Items[0].Key).To(
+		Expect(job.Spec.Template.Spec.Volumes[0].VolumeSource.Projected.Sources[0].Secret.Items[0].Key).To(
 			BeEquivalentTo("test_key_endpoint"))
 	})
 
@@ -135,9 +137,174 @@ var _ = Describe("Barman endpoint CA", func() {
 			},
 		}
 		addBarmanEndpointCAToJobFromCluster(cluster, &backup, &job)
-		Expect(job.Spec.Template.Spec.Volumes[0].VolumeSource.Secret.Items[0].Key).To(
+		Expect(job.Spec.Template.Spec.Volumes[0].VolumeSource.Projected.Sources[0].Secret.Items[0].Key).To(
 			BeEquivalentTo("test_key_endpoint"))
 	})
+
+	It("is properly added to the job importing a logical dump", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						ImportDump: &apiv1.ImportDump{
+							Source: apiv1.ImportSource{ExternalCluster: "dumps"},
+							Path:   "app.dump",
+						},
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name: "dumps",
+						BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://bucket/dumps",
+							EndpointCA: &apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{
+									Name: "test_name_endpoint",
+								},
+								Key: "test_key_endpoint",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		job := CreatePrimaryJobViaInitdb(cluster, 0)
+		Expect(job.Name).To(HaveSuffix(string(jobRoleImport)))
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "barman-endpoint-ca")))
+	})
+
+	It("uses the credentials of the backup when the CA is specified in the recovery", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Backup: &apiv1.BackupSource{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: "test"},
+							EndpointCA: &apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{
+									Name: "test_name_endpoint",
+								},
+								Key: "test_key_endpoint",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		backup := apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Status: apiv1.BackupStatus{
+			BarmanCredentials: apiv1.BarmanCredentials{
+				Azure: &apiv1.AzureCredentials{},
+			},
+		}}
+
+		job := CreatePrimaryJobViaRecovery(cluster, 1, &backup)
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+			Name:  "REQUESTS_CA_BUNDLE",
+			Value: postgres.BarmanRestoreEndpointCACertificateLocation,
+		}))
+	})
+
+	It("mounts the CA of the object store used to back up the recovered cluster", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Source: "origin",
+					},
+				},
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://bucket/backups",
+						EndpointCA: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{
+								Name: "backup_name_endpoint",
+							},
+							Key: "backup_key_endpoint",
+						},
+					},
+				},
+			},
+		}
+
+		job := CreatePrimaryJobViaRecovery(cluster, 1, nil)
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(SatisfyAll(
+			HaveField("Name", "barman-endpoint-ca"),
+			HaveField("VolumeSource.Projected.Sources", ConsistOf(
+				HaveField("Secret.Name", "backup_name_endpoint"),
+			)),
+		)))
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "barman-endpoint-ca",
+			MountPath: postgres.CertificatesDir,
+		}))
+	})
+
+	It("projects both the recovery and the backup CAs in the certificates directory", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Source: "origin",
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name: "origin",
+						BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://origin/backups",
+							EndpointCA: &apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{
+									Name: "origin_name_endpoint",
+								},
+								Key: "origin_key_endpoint",
+							},
+						},
+					},
+				},
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://bucket/backups",
+						EndpointCA: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{
+								Name: "backup_name_endpoint",
+							},
+							Key: "backup_key_endpoint",
+						},
+					},
+				},
+			},
+		}
+
+		job := CreatePrimaryJobViaRecovery(cluster, 1, nil)
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(ContainElement(SatisfyAll(
+			HaveField("Name", "barman-endpoint-ca"),
+			HaveField("VolumeSource.Projected.Sources", ConsistOf(
+				HaveField("Secret.Items", ConsistOf(corev1.KeyToPath{
+					Key:  "origin_key_endpoint",
+					Path: postgres.BarmanRestoreEndpointCACertificateFileName,
+				})),
+				HaveField("Secret.Items", ConsistOf(corev1.KeyToPath{
+					Key:  "backup_key_endpoint",
+					Path: postgres.BarmanBackupEndpointCACertificateFileName,
+				})),
+			)),
+		)))
+
+		var certificatesMounts []corev1.VolumeMount
+		for _, mount := range podSpec.Containers[0].VolumeMounts {
+			if mount.MountPath == postgres.CertificatesDir {
+				certificatesMounts = append(certificatesMounts, mount)
+			}
+		}
+		Expect(certificatesMounts).To(ConsistOf(corev1.VolumeMount{
+			Name:      "barman-endpoint-ca",
+			MountPath: postgres.CertificatesDir,
+		}))
+		Expect(podSpec.Containers[0].VolumeMounts).ToNot(ContainElement(HaveField("SubPath", Not(BeEmpty()))))
+	})
 })
 
 var _ = Describe("Job created via InitDB", func() {
@@ -196,3 +363,49 @@ var _ = Describe("Job created via InitDB", func() {
 		Expect(initdbFlags).Should(ContainSubstring("'--icu-rules=&A < z <<< Z'"))
 	})
 })
+
+var _ = Describe("Resources of the jobs", func() {
+	instanceResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	backupResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+
+	newCluster := func() apiv1.Cluster {
+		return apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Resources: instanceResources,
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{Source: "origin"},
+				},
+				Backup: &apiv1.BackupConfiguration{
+					Resources: &backupResources,
+				},
+			},
+		}
+	}
+
+	It("uses the backup resources for the recovery jobs", func() {
+		cluster := newCluster()
+		job := CreatePrimaryJobViaRecovery(cluster, 1, nil)
+		Expect(job.Spec.Template.Spec.Containers[0].Resources).To(Equal(backupResources))
+
+		job = RestoreReplicaInstance(cluster, 2)
+		Expect(job.Spec.Template.Spec.Containers[0].Resources).To(Equal(backupResources))
+	})
+
+	It("uses the instance resources for the other jobs", func() {
+		cluster := newCluster()
+		job := JoinReplicaInstance(cluster, 2)
+		Expect(job.Spec.Template.Spec.Containers[0].Resources).To(Equal(instanceResources))
+	})
+
+	It("defaults to the instance resources for the recovery jobs", func() {
+		cluster := newCluster()
+		cluster.Spec.Backup.Resources = nil
+		job := CreatePrimaryJobViaRecovery(cluster, 1, nil)
+		Expect(job.Spec.Template.Spec.Containers[0].Resources).To(Equal(instanceResources))
+	})
+})
@@ -0,0 +1,301 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	neturl "net/url"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// dnsPort is the port used to resolve the names of the services
+	dnsPort = 53
+
+	// httpsPort is the port used to reach the object stores and the
+	// Kubernetes API server through its service
+	httpsPort = 443
+
+	// httpPort is the port used to reach the object stores whose endpoint
+	// URL uses the http scheme without an explicit port
+	httpPort = 80
+
+	// kubernetesAPIServerPort is the port where the Kubernetes API server
+	// usually listens, used when the CNI applies the policy after the
+	// service address has been translated
+	kubernetesAPIServerPort = 6443
+)
+
+// BuildNetworkPolicy creates the NetworkPolicy restricting the traffic of
+// the instance pods of the cluster. The traffic between the pods of the
+// cluster, i.e. the instances, the poolers and the jobs, is always allowed,
+// to not break the replication and the creation of new instances, together
+// with the traffic from the operator and the applications, and the scraping
+// of the metrics when the PodMonitor is enabled. The additional rules of the
+// user are appended to the generated ones
+func BuildNetworkPolicy(cluster *apiv1.Cluster, operatorNamespace string) *networkingv1.NetworkPolicy {
+	if cluster == nil {
+		return nil
+	}
+
+	var additionalIngress []networkingv1.NetworkPolicyIngressRule
+	var additionalEgress []networkingv1.NetworkPolicyEgressRule
+	var applicationNamespaceSelector *metav1.LabelSelector
+	if cluster.Spec.Managed != nil && cluster.Spec.Managed.NetworkPolicy != nil {
+		networkPolicyConfiguration := cluster.Spec.Managed.NetworkPolicy
+		additionalIngress = networkPolicyConfiguration.AdditionalIngress
+		additionalEgress = networkPolicyConfiguration.AdditionalEgress
+		applicationNamespaceSelector = networkPolicyConfiguration.ApplicationNamespaceSelector
+	}
+
+	instancesSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			utils.ClusterLabelName: cluster.Name,
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		},
+	}
+
+	// The jobs creating the instances, like the ones cloning the primary
+	// when joining a new replica, only have the label of the cluster
+	clusterPeer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.ClusterLabelName: cluster.Name,
+			},
+		},
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: instancesSelector,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: slices.Concat(
+				buildDefaultIngressRules(cluster, clusterPeer, operatorNamespace, applicationNamespaceSelector),
+				additionalIngress,
+			),
+			Egress: slices.Concat(
+				buildDefaultEgressRules(cluster, clusterPeer),
+				additionalEgress,
+			),
+		},
+	}
+
+	cluster.SetInheritedDataAndOwnership(&networkPolicy.ObjectMeta)
+
+	return networkPolicy
+}
+
+// buildDefaultIngressRules creates the ingress rules allowing the traffic
+// from the pods of the cluster, the operator and the applications, and
+// from anywhere to the metrics exporter when the PodMonitor is enabled, as
+// Prometheus can be deployed in any namespace
+func buildDefaultIngressRules(
+	cluster *apiv1.Cluster,
+	clusterPeer networkingv1.NetworkPolicyPeer,
+	operatorNamespace string,
+	applicationNamespaceSelector *metav1.LabelSelector,
+) []networkingv1.NetworkPolicyIngressRule {
+	result := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{clusterPeer},
+		},
+		{
+			From:  []networkingv1.NetworkPolicyPeer{buildOperatorNetworkPolicyPeer(operatorNamespace)},
+			Ports: buildNetworkPolicyPorts(corev1.ProtocolTCP, url.StatusPort, postgres.ServerPort),
+		},
+		{
+			From:  []networkingv1.NetworkPolicyPeer{buildApplicationNetworkPolicyPeer(applicationNamespaceSelector)},
+			Ports: buildNetworkPolicyPorts(corev1.ProtocolTCP, postgres.ServerPort),
+		},
+	}
+
+	if cluster.IsPodMonitorEnabled() {
+		result = append(result, networkingv1.NetworkPolicyIngressRule{
+			Ports: buildNetworkPolicyPorts(corev1.ProtocolTCP, url.PostgresMetricsPort),
+		})
+	}
+
+	return result
+}
+
+// buildDefaultEgressRules creates the egress rules allowing the instances to
+// reach the other pods of the cluster, to resolve names, to reach the object
+// stores and the Kubernetes API server, and to connect to the external clusters.
+// The ports of the object stores are taken from their endpoint URL
+func buildDefaultEgressRules(
+	cluster *apiv1.Cluster,
+	clusterPeer networkingv1.NetworkPolicyPeer,
+) []networkingv1.NetworkPolicyEgressRule {
+	result := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{clusterPeer},
+		},
+		{
+			Ports: slices.Concat(
+				buildNetworkPolicyPorts(corev1.ProtocolUDP, dnsPort),
+				buildNetworkPolicyPorts(corev1.ProtocolTCP, dnsPort),
+			),
+		},
+		{
+			Ports: buildNetworkPolicyPorts(corev1.ProtocolTCP, getObjectStoresAndAPIServerPorts(cluster)...),
+		},
+	}
+
+	if externalClusterPorts := getExternalClustersPorts(cluster); len(externalClusterPorts) > 0 {
+		result = append(result, networkingv1.NetworkPolicyEgressRule{
+			Ports: buildNetworkPolicyPorts(corev1.ProtocolTCP, externalClusterPorts...),
+		})
+	}
+
+	return result
+}
+
+// buildOperatorNetworkPolicyPeer selects the pods of the namespace where the
+// operator is installed, or every namespace when it is not known
+func buildOperatorNetworkPolicyPeer(operatorNamespace string) networkingv1.NetworkPolicyPeer {
+	if operatorNamespace == "" {
+		return networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{}}
+	}
+
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				corev1.LabelMetadataName: operatorNamespace,
+			},
+		},
+	}
+}
+
+// buildApplicationNetworkPolicyPeer selects the pods of the namespaces matching
+// the passed selector, or the pods of the namespace of the cluster when no
+// selector is passed
+func buildApplicationNetworkPolicyPeer(namespaceSelector *metav1.LabelSelector) networkingv1.NetworkPolicyPeer {
+	if namespaceSelector == nil {
+		return networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}}
+	}
+
+	return networkingv1.NetworkPolicyPeer{NamespaceSelector: namespaceSelector.DeepCopy()}
+}
+
+// getExternalClustersPorts returns, sorted and without duplicates, the ports
+// of the PostgreSQL servers of the external clusters
+func getExternalClustersPorts(cluster *apiv1.Cluster) []int32 {
+	var result []int32
+	for _, externalCluster := range cluster.Spec.ExternalClusters {
+		port := int32(postgres.ServerPort)
+		if value, ok := externalCluster.ConnectionParameters["port"]; ok {
+			parsedPort, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				continue
+			}
+			port = int32(parsedPort)
+		}
+		result = append(result, port)
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// getObjectStoresAndAPIServerPorts returns, sorted and without duplicates,
+// the ports of the Kubernetes API server and of the object stores used for
+// the backups and by the external clusters, taken from their endpoint URL.
+// The object stores without an endpoint URL are reached on the HTTPS port
+func getObjectStoresAndAPIServerPorts(cluster *apiv1.Cluster) []int32 {
+	result := []int32{httpsPort, kubernetesAPIServerPort}
+
+	endpointURLs := make([]string, 0, len(cluster.Spec.ExternalClusters)+1)
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.BarmanObjectStore != nil {
+		endpointURLs = append(endpointURLs, cluster.Spec.Backup.BarmanObjectStore.EndpointURL)
+	}
+	for _, externalCluster := range cluster.Spec.ExternalClusters {
+		if externalCluster.BarmanObjectStore != nil {
+			endpointURLs = append(endpointURLs, externalCluster.BarmanObjectStore.EndpointURL)
+		}
+	}
+
+	for _, endpointURL := range endpointURLs {
+		if port, ok := getEndpointURLPort(endpointURL); ok {
+			result = append(result, port)
+		}
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// getEndpointURLPort returns the port of the passed endpoint URL, defaulting
+// to the one of its scheme
+func getEndpointURLPort(endpointURL string) (int32, bool) {
+	if endpointURL == "" {
+		return 0, false
+	}
+
+	parsedURL, err := neturl.Parse(endpointURL)
+	if err != nil {
+		return 0, false
+	}
+
+	if value := parsedURL.Port(); value != "" {
+		port, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(port), true
+	}
+
+	if parsedURL.Scheme == "http" {
+		return httpPort, true
+	}
+	return httpsPort, true
+}
+
+// buildNetworkPolicyPorts creates the network policy ports for the passed
+// protocol and port numbers
+func buildNetworkPolicyPorts(protocol corev1.Protocol, ports ...int32) []networkingv1.NetworkPolicyPort {
+	result := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		portProtocol := protocol
+		portNumber := intstr.FromInt32(port)
+		result = append(result, networkingv1.NetworkPolicyPort{
+			Protocol: &portProtocol,
+			Port:     &portNumber,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,214 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy specifications", func() {
+	var cluster *apiv1.Cluster
+
+	instancesSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			utils.ClusterLabelName: "thistest",
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		},
+	}
+
+	clusterPeer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.ClusterLabelName: "thistest",
+			},
+		},
+	}
+
+	// matchesPeer tells whether the pod selector of the passed peer
+	// selects the pods with the passed labels
+	matchesPeer := func(peer networkingv1.NetworkPolicyPeer, podLabels map[string]string) bool {
+		selector, err := metav1.LabelSelectorAsSelector(peer.PodSelector)
+		Expect(err).ToNot(HaveOccurred())
+		return selector.Matches(labels.Set(podLabels))
+	}
+
+	getPorts := func(ports []networkingv1.NetworkPolicyPort) []int32 {
+		result := make([]int32, 0, len(ports))
+		for _, port := range ports {
+			result = append(result, port.Port.IntVal)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "thistest",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					NetworkPolicy: &apiv1.ManagedNetworkPolicy{Enabled: true},
+				},
+			},
+		}
+	})
+
+	It("selects the instance pods of the cluster", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Name).To(Equal(cluster.Name))
+		Expect(result.Namespace).To(Equal(cluster.Namespace))
+		Expect(result.Spec.PodSelector).To(Equal(instancesSelector))
+		Expect(result.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+		Expect(result.OwnerReferences).To(HaveLen(1))
+	})
+
+	It("always allows the traffic between the pods of the cluster", func() {
+		cluster.Spec.Managed.NetworkPolicy.AdditionalIngress = []networkingv1.NetworkPolicyIngressRule{
+			{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
+		}
+		cluster.Spec.Managed.NetworkPolicy.AdditionalEgress = []networkingv1.NetworkPolicyEgressRule{
+			{To: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
+		}
+
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress[0]).To(Equal(networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{clusterPeer},
+		}))
+		Expect(result.Spec.Egress[0]).To(Equal(networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{clusterPeer},
+		}))
+		Expect(result.Spec.Ingress).To(HaveLen(4))
+		Expect(result.Spec.Ingress[3]).To(Equal(cluster.Spec.Managed.NetworkPolicy.AdditionalIngress[0]))
+		Expect(result.Spec.Egress).To(HaveLen(4))
+		Expect(result.Spec.Egress[3]).To(Equal(cluster.Spec.Managed.NetworkPolicy.AdditionalEgress[0]))
+	})
+
+	It("allows the instances, the poolers and the jobs of the cluster to reach the instances", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		peer := result.Spec.Ingress[0].From[0]
+		Expect(result.Spec.Ingress[0].Ports).To(BeEmpty())
+
+		Expect(matchesPeer(peer, map[string]string{
+			utils.ClusterLabelName: "thistest",
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		})).To(BeTrue())
+		Expect(matchesPeer(peer, map[string]string{
+			utils.ClusterLabelName: "thistest",
+			utils.PodRoleLabelName: string(utils.PodRolePooler),
+		})).To(BeTrue())
+		Expect(matchesPeer(peer, map[string]string{
+			utils.ClusterLabelName: "thistest",
+			utils.JobRoleLabelName: "join",
+		})).To(BeTrue())
+		Expect(matchesPeer(peer, map[string]string{
+			utils.ClusterLabelName: "other",
+			utils.JobRoleLabelName: "join",
+		})).To(BeFalse())
+	})
+
+	It("allows the operator to reach the instances", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels).To(
+			HaveKeyWithValue("kubernetes.io/metadata.name", "cnpg-system"))
+		Expect(getPorts(result.Spec.Ingress[1].Ports)).To(Equal([]int32{8000, 5432}))
+
+		result = BuildNetworkPolicy(cluster, "")
+		Expect(result.Spec.Ingress[1].From[0].NamespaceSelector).To(Equal(&metav1.LabelSelector{}))
+	})
+
+	It("allows the applications to connect to PostgreSQL", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress[2].From[0].PodSelector).To(Equal(&metav1.LabelSelector{}))
+		Expect(result.Spec.Ingress[2].From[0].NamespaceSelector).To(BeNil())
+		Expect(getPorts(result.Spec.Ingress[2].Ports)).To(Equal([]int32{5432}))
+
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "app"}}
+		cluster.Spec.Managed.NetworkPolicy.ApplicationNamespaceSelector = selector
+		result = BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress[2].From[0].PodSelector).To(BeNil())
+		Expect(result.Spec.Ingress[2].From[0].NamespaceSelector).To(Equal(selector))
+	})
+
+	It("allows scraping the metrics when the PodMonitor is enabled", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress).To(HaveLen(3))
+
+		cluster.Spec.Monitoring = &apiv1.MonitoringConfiguration{EnablePodMonitor: true}
+		result = BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Ingress).To(HaveLen(4))
+		Expect(result.Spec.Ingress[3].From).To(BeEmpty())
+		Expect(getPorts(result.Spec.Ingress[3].Ports)).To(Equal([]int32{9187}))
+	})
+
+	It("allows the connections to the external clusters", func() {
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Egress).To(HaveLen(3))
+		Expect(getPorts(result.Spec.Egress[1].Ports)).To(Equal([]int32{53, 53}))
+		Expect(getPorts(result.Spec.Egress[2].Ports)).To(Equal([]int32{443, 6443}))
+
+		cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{
+			{Name: "one", ConnectionParameters: map[string]string{"host": "one"}},
+			{Name: "two", ConnectionParameters: map[string]string{"host": "two", "port": "6432"}},
+			{Name: "three", ConnectionParameters: map[string]string{"host": "three", "port": "5432"}},
+		}
+		result = BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(result.Spec.Egress).To(HaveLen(4))
+		Expect(result.Spec.Egress[3].Ports).To(HaveLen(2))
+		Expect(getPorts(result.Spec.Egress[3].Ports)).To(Equal([]int32{5432, 6432}))
+	})
+
+	It("allows the connections to the object stores on the port of their endpoint", func() {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				EndpointURL: "http://minio:9000",
+			},
+		}
+		cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{
+			{
+				Name: "origin",
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+					EndpointURL: "http://minio.storage.svc",
+				},
+			},
+			{
+				Name: "archive",
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+					EndpointURL: "https://s3.example.com:8443",
+				},
+			},
+			{
+				Name:              "cloud",
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{},
+			},
+		}
+
+		result := BuildNetworkPolicy(cluster, "cnpg-system")
+		Expect(getPorts(result.Spec.Egress[2].Ports)).To(Equal([]int32{80, 443, 6443, 8443, 9000}))
+	})
+})
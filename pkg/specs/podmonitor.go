@@ -20,6 +20,10 @@ SPDX-License-Identifier: Apache-2.0
 package specs
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -76,6 +80,8 @@ func (c ClusterPodMonitorManager) BuildPodMonitor() *monitoringv1.PodMonitor {
 		endpoint.MetricRelabelConfigs = c.cluster.Spec.Monitoring.PodMonitorMetricRelabelConfigs
 		//nolint:staticcheck // Using deprecated fields during deprecation period
 		endpoint.RelabelConfigs = c.cluster.Spec.Monitoring.PodMonitorRelabelConfigs
+		endpoint.Interval = toPrometheusDuration(c.cluster.Spec.Monitoring.PodMonitorInterval)
+		endpoint.ScrapeTimeout = toPrometheusDuration(c.cluster.Spec.Monitoring.PodMonitorScrapeTimeout)
 	}
 
 	spec := monitoringv1.PodMonitorSpec{
@@ -94,6 +100,35 @@ func (c ClusterPodMonitorManager) BuildPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+// toPrometheusDuration converts a duration to the format accepted by
+// Prometheus, which doesn't allow fractional values, i.e. `1m30s` or
+// `1500ms`. An empty duration, using the Prometheus default, is returned
+// when the duration isn't set
+func toPrometheusDuration(duration *metav1.Duration) monitoringv1.Duration {
+	if duration == nil || duration.Duration <= 0 {
+		return ""
+	}
+
+	remaining := duration.Milliseconds()
+	var result strings.Builder
+	for _, unit := range []struct {
+		suffix       string
+		milliseconds int64
+	}{
+		{suffix: "h", milliseconds: time.Hour.Milliseconds()},
+		{suffix: "m", milliseconds: time.Minute.Milliseconds()},
+		{suffix: "s", milliseconds: time.Second.Milliseconds()},
+		{suffix: "ms", milliseconds: 1},
+	} {
+		if remaining >= unit.milliseconds {
+			result.WriteString(strconv.FormatInt(remaining/unit.milliseconds, 10) + unit.suffix)
+			remaining %= unit.milliseconds
+		}
+	}
+
+	return monitoringv1.Duration(result.String())
+}
+
 // NewClusterPodMonitorManager returns a new instance of ClusterPodMonitorManager
 func NewClusterPodMonitorManager(cluster *apiv1.Cluster) *ClusterPodMonitorManager {
 	return &ClusterPodMonitorManager{cluster: cluster}
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package specs
 
 import (
+	"time"
+
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -115,6 +117,19 @@ var _ = Describe("PodMonitor test", func() {
 			Expect(monitor.Spec.PodMetricsEndpoints).To(ContainElement(*expectedEndpoint))
 		})
 
+		It("should create a monitoringv1.PodMonitor object with a custom scrape interval and timeout", func() {
+			customCluster := cluster.DeepCopy()
+			customCluster.Spec.Monitoring.PodMonitorInterval = &metav1.Duration{Duration: 2 * time.Minute}
+			customCluster.Spec.Monitoring.PodMonitorScrapeTimeout = &metav1.Duration{Duration: 30 * time.Second}
+			mgr := NewClusterPodMonitorManager(customCluster)
+			monitor := mgr.BuildPodMonitor()
+
+			expectedEndpoint := expectedEndpoint.DeepCopy()
+			expectedEndpoint.Interval = "2m"
+			expectedEndpoint.ScrapeTimeout = "30s"
+			Expect(monitor.Spec.PodMetricsEndpoints).To(ContainElement(*expectedEndpoint))
+		})
+
 		It("does not panic if monitoring section is not present", func() {
 			cluster := apiv1.Cluster{}
 			mgr := NewClusterPodMonitorManager(&cluster)
@@ -178,3 +193,21 @@ var _ = Describe("PodMonitor test", func() {
 		assertPodMonitorCorrect(&cluster, expectedEndpoint)
 	})
 })
+
+var _ = Describe("toPrometheusDuration", func() {
+	It("uses the Prometheus default when the duration is not set", func() {
+		Expect(toPrometheusDuration(nil)).To(BeEmpty())
+		Expect(toPrometheusDuration(&metav1.Duration{})).To(BeEmpty())
+	})
+
+	It("converts the duration without fractional values", func() {
+		Expect(toPrometheusDuration(&metav1.Duration{Duration: 30 * time.Second})).
+			To(Equal(monitoringv1.Duration("30s")))
+		Expect(toPrometheusDuration(&metav1.Duration{Duration: 90 * time.Second})).
+			To(Equal(monitoringv1.Duration("1m30s")))
+		Expect(toPrometheusDuration(&metav1.Duration{Duration: 1500 * time.Millisecond})).
+			To(Equal(monitoringv1.Duration("1s500ms")))
+		Expect(toPrometheusDuration(&metav1.Duration{Duration: 25 * time.Hour})).
+			To(Equal(monitoringv1.Duration("25h")))
+	})
+})
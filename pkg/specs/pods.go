@@ -171,6 +171,26 @@ func CreatePodEnvConfig(cluster apiv1.Cluster, podName string) EnvConfig {
 		)
 	}
 
+	if configuration.Current.InstanceConnectionRetryTimeout != 0 {
+		config.EnvVars = append(
+			config.EnvVars,
+			corev1.EnvVar{
+				Name:  "CNPG_CONNECTION_RETRY_TIMEOUT",
+				Value: strconv.Itoa(configuration.Current.InstanceConnectionRetryTimeout),
+			},
+		)
+	}
+
+	if configuration.Current.ApplicationNamePrefix != "" {
+		config.EnvVars = append(
+			config.EnvVars,
+			corev1.EnvVar{
+				Name:  "CNPG_APPLICATION_NAME_PREFIX",
+				Value: configuration.Current.ApplicationNamePrefix,
+			},
+		)
+	}
+
 	hashValue, _ := hash.ComputeHash(config)
 	config.Hash = hashValue
 	return config
@@ -186,9 +206,10 @@ func createClusterPodSpec(
 ) corev1.PodSpec {
 	return corev1.PodSpec{
 		Hostname: podName,
-		InitContainers: []corev1.Container{
+		InitContainers: append(
+			slices.Clone(cluster.Spec.InitContainers),
 			createBootstrapContainer(cluster),
-		},
+		),
 		SchedulerName: cluster.Spec.SchedulerName,
 		Containers:    createPostgresContainers(cluster, envConfig, enableHTTPS),
 		Volumes:       createPostgresVolumes(&cluster, podName),
@@ -201,7 +222,7 @@ func createClusterPodSpec(
 		ServiceAccountName:            cluster.Name,
 		NodeSelector:                  cluster.Spec.Affinity.NodeSelector,
 		TerminationGracePeriodSeconds: &gracePeriod,
-		TopologySpreadConstraints:     cluster.Spec.TopologySpreadConstraints,
+		TopologySpreadConstraints:     CreateTopologySpreadConstraints(cluster),
 	}
 }
 
@@ -257,7 +278,7 @@ func createPostgresContainers(cluster apiv1.Cluster, envConfig EnvConfig, enable
 				"instance",
 				"run",
 			},
-			Resources: cluster.Spec.Resources,
+			Resources: GetInstanceResources(cluster),
 			Ports: []corev1.ContainerPort{
 				{
 					Name:          "postgresql",
@@ -443,6 +464,33 @@ func CreateGeneratedAntiAffinity(clusterName string, config apiv1.AffinityConfig
 	return affinity
 }
 
+// CreateTopologySpreadConstraints returns the topology spread constraints
+// of the instance pods: the ones specified by the user, followed by the
+// ones generated from the topology configuration of the cluster, if any
+func CreateTopologySpreadConstraints(cluster apiv1.Cluster) []corev1.TopologySpreadConstraint {
+	topology := cluster.Spec.Topology
+	if topology == nil {
+		return cluster.Spec.TopologySpreadConstraints
+	}
+
+	result := slices.Clone(cluster.Spec.TopologySpreadConstraints)
+	for _, topologyKey := range topology.GetTopologyKeys() {
+		result = append(result, corev1.TopologySpreadConstraint{
+			MaxSkew:           topology.GetMaxSkew(),
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: topology.GetWhenUnsatisfiable(),
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.ClusterLabelName: cluster.Name,
+					utils.PodRoleLabelName: string(utils.PodRoleInstance),
+				},
+			},
+		})
+	}
+
+	return result
+}
+
 // CreatePodSecurityContext defines the security context under which the containers are running
 func CreatePodSecurityContext(seccompProfile *corev1.SeccompProfile, user, group int64) *corev1.PodSecurityContext {
 	// Under Openshift we inherit SecurityContext from the restricted security context constraint
@@ -512,7 +560,7 @@ func buildInstance(
 	tlsEnabled bool,
 ) (*corev1.Pod, error) {
 	podName := GetInstanceName(cluster.Name, nodeSerial)
-	gracePeriod := int64(cluster.GetMaxStopDelay())
+	gracePeriod := cluster.GetTerminationGracePeriodSeconds()
 
 	envConfig := CreatePodEnvConfig(cluster, podName)
 
@@ -575,6 +623,10 @@ func GetInstanceName(clusterName string, nodeSerial int) string {
 	return fmt.Sprintf("%s-%v", clusterName, nodeSerial)
 }
 
+// barmanEndpointCAVolumeName is the name of the volume holding the
+// endpoint CAs of the object stores used by barman
+const barmanEndpointCAVolumeName = "barman-endpoint-ca"
+
 // AddBarmanEndpointCAToPodSpec adds the required volumes and env variables needed by barman to work correctly
 func AddBarmanEndpointCAToPodSpec(
 	podSpec *corev1.PodSpec,
@@ -585,43 +637,101 @@ func AddBarmanEndpointCAToPodSpec(
 		return
 	}
 
+	addBarmanEndpointCAVolumeSource(podSpec, caSecret, postgres.BarmanRestoreEndpointCACertificateFileName)
+
+	var envVars []corev1.EnvVar
+	for _, name := range GetBarmanEndpointCAEnvNames(credentials) {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  name,
+			Value: postgres.BarmanRestoreEndpointCACertificateLocation,
+		})
+	}
+
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVars...)
+}
+
+// AddBarmanBackupEndpointCAToPodSpec adds the volume needed by barman to reach
+// the object store used to back up the cluster, when it needs a custom CA.
+// The environment variables are set by the instance manager when invoking
+// barman on that object store
+func AddBarmanBackupEndpointCAToPodSpec(podSpec *corev1.PodSpec, caSecret *apiv1.SecretKeySelector) {
+	if caSecret == nil || caSecret.Name == "" || caSecret.Key == "" {
+		return
+	}
+
+	addBarmanEndpointCAVolumeSource(podSpec, caSecret, postgres.BarmanBackupEndpointCACertificateFileName)
+}
+
+// addBarmanEndpointCAVolumeSource projects the CA stored in caSecret into the
+// certificates directory as fileName. Every endpoint CA shares the same volume,
+// as the certificates directory can be mounted only once
+func addBarmanEndpointCAVolumeSource(podSpec *corev1.PodSpec, caSecret *apiv1.SecretKeySelector, fileName string) {
+	source := corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: caSecret.Name,
+			},
+			Items: []corev1.KeyToPath{
+				{
+					Key:  caSecret.Key,
+					Path: fileName,
+				},
+			},
+		},
+	}
+
+	for idx := range podSpec.Volumes {
+		volume := &podSpec.Volumes[idx]
+		if volume.Name == barmanEndpointCAVolumeName && volume.Projected != nil {
+			volume.Projected.Sources = append(volume.Projected.Sources, source)
+			return
+		}
+	}
+
 	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-		Name: "barman-endpoint-ca",
+		Name: barmanEndpointCAVolumeName,
 		VolumeSource: corev1.VolumeSource{
-			Secret: &corev1.SecretVolumeSource{
-				SecretName: caSecret.Name,
-				Items: []corev1.KeyToPath{
-					{
-						Key:  caSecret.Key,
-						Path: postgres.BarmanRestoreEndpointCACertificateFileName,
-					},
-				},
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{source},
 			},
 		},
 	})
 
 	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts,
 		corev1.VolumeMount{
-			Name:      "barman-endpoint-ca",
+			Name:      barmanEndpointCAVolumeName,
 			MountPath: postgres.CertificatesDir,
 		},
 	)
+}
 
-	var envVars []corev1.EnvVar
-	// todo: add a case for the Google provider
+// GetBarmanEndpointCAEnvNames returns the names of the environment variables
+// used by the libraries of the cloud provider to find the endpoint CA
+func GetBarmanEndpointCAEnvNames(credentials apiv1.BarmanCredentials) []string {
 	switch {
-	case credentials.Azure != nil:
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  "REQUESTS_CA_BUNDLE",
-			Value: postgres.BarmanRestoreEndpointCACertificateLocation,
-		})
+	case credentials.Azure != nil, credentials.Google != nil:
+		return []string{"REQUESTS_CA_BUNDLE"}
 	// If nothing is set we fall back to AWS, this is to avoid breaking changes with previous versions
 	default:
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  "AWS_CA_BUNDLE",
-			Value: postgres.BarmanRestoreEndpointCACertificateLocation,
-		})
+		return []string{"AWS_CA_BUNDLE"}
 	}
+}
 
-	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVars...)
+// AppendBarmanEndpointCAEnv adds to env the variables pointing barman to the
+// endpoint CA stored in location, when the object store has one. Being
+// appended, they take precedence over the ones already in env
+func AppendBarmanEndpointCAEnv(
+	env []string,
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+	location string,
+) []string {
+	if configuration == nil || configuration.EndpointCA == nil {
+		return env
+	}
+
+	for _, name := range GetBarmanEndpointCAEnvNames(configuration.BarmanCredentials) {
+		env = append(env, fmt.Sprintf("%s=%s", name, location))
+	}
+
+	return env
 }
@@ -299,6 +299,52 @@ var _ = Describe("Create affinity section", func() {
 	})
 })
 
+var _ = Describe("Create topology spread constraints", func() {
+	userConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           3,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}
+
+	It("uses only the user-defined constraints without a topology configuration", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{userConstraint},
+			},
+		}
+		Expect(CreateTopologySpreadConstraints(cluster)).To(Equal(cluster.Spec.TopologySpreadConstraints))
+		Expect(CreateTopologySpreadConstraints(apiv1.Cluster{})).To(BeNil())
+	})
+
+	It("generates a constraint for every topology key", func() {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{userConstraint},
+				Topology: &apiv1.TopologyConfiguration{
+					TopologyKeys:      []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"},
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				},
+			},
+		}
+
+		constraints := CreateTopologySpreadConstraints(cluster)
+		Expect(constraints).To(HaveLen(3))
+		Expect(constraints[0]).To(Equal(userConstraint))
+		for idx, topologyKey := range []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"} {
+			constraint := constraints[idx+1]
+			Expect(constraint.TopologyKey).To(Equal(topologyKey))
+			Expect(constraint.MaxSkew).To(BeEquivalentTo(1))
+			Expect(constraint.WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+			Expect(constraint.LabelSelector.MatchLabels).To(Equal(map[string]string{
+				utils.ClusterLabelName: "cluster-example",
+				utils.PodRoleLabelName: string(utils.PodRoleInstance),
+			}))
+		}
+		Expect(cluster.Spec.TopologySpreadConstraints).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("EnvConfig", func() {
 	Context("IsEnvEqual function", func() {
 		It("returns true if the Env are equal", func() {
@@ -952,6 +998,33 @@ var _ = Describe("NewInstance", func() {
 		Expect(pod.Spec.Containers[0].Image).To(Equal("new-image:latest"))
 	})
 
+	It("runs the user-provided init containers before the bootstrap controller", func(ctx SpecContext) {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:  "preflight",
+						Image: "busybox:latest",
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "test",
+			},
+		}
+
+		pod, err := NewInstance(ctx, cluster, 1, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod.Spec.InitContainers).To(HaveLen(2))
+		Expect(pod.Spec.InitContainers[0].Name).To(Equal("preflight"))
+		Expect(pod.Spec.InitContainers[1].Name).To(Equal(BootstrapControllerContainerName))
+		Expect(cluster.Spec.InitContainers).To(HaveLen(1))
+	})
+
 	It("returns error if JSON patch is invalid", func(ctx SpecContext) {
 		cluster := apiv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -968,3 +1041,30 @@ var _ = Describe("NewInstance", func() {
 		Expect(err.Error()).To(ContainSubstring("while decoding JSON patch from annotation"))
 	})
 })
+
+var _ = Describe("Barman endpoint CA environment", func() {
+	DescribeTable("points the libraries of the provider to the CA",
+		func(credentials apiv1.BarmanCredentials, expected string) {
+			Expect(GetBarmanEndpointCAEnvNames(credentials)).To(ConsistOf(expected))
+		},
+		Entry("with AWS", apiv1.BarmanCredentials{AWS: &apiv1.S3Credentials{}}, "AWS_CA_BUNDLE"),
+		Entry("with Azure", apiv1.BarmanCredentials{Azure: &apiv1.AzureCredentials{}}, "REQUESTS_CA_BUNDLE"),
+		Entry("with Google", apiv1.BarmanCredentials{Google: &apiv1.GoogleCredentials{}}, "REQUESTS_CA_BUNDLE"),
+		Entry("without credentials", apiv1.BarmanCredentials{}, "AWS_CA_BUNDLE"),
+	)
+
+	It("appends the variables only when the object store has a CA", func() {
+		configuration := &apiv1.BarmanObjectStoreConfiguration{
+			BarmanCredentials: apiv1.BarmanCredentials{Google: &apiv1.GoogleCredentials{}},
+		}
+		Expect(AppendBarmanEndpointCAEnv([]string{"A=B"}, configuration, "/ca.crt")).
+			To(Equal([]string{"A=B"}))
+
+		configuration.EndpointCA = &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "ca"},
+			Key:                  "ca.crt",
+		}
+		Expect(AppendBarmanEndpointCAEnv([]string{"A=B"}, configuration, "/ca.crt")).
+			To(Equal([]string{"A=B", "REQUESTS_CA_BUNDLE=/ca.crt"}))
+	})
+})
@@ -0,0 +1,145 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// defaultAlertsLabelName is the label, added to the default alerts, containing
+// the name of the cluster they refer to
+const defaultAlertsLabelName = "cnpg_cluster"
+
+// ClusterPrometheusRuleManager builds the PrometheusRule containing
+// the default alerts of the cluster resource
+type ClusterPrometheusRuleManager struct {
+	cluster *apiv1.Cluster
+}
+
+// IsPrometheusRuleEnabled returns a boolean indicating if the PrometheusRule should exists or not
+func (c ClusterPrometheusRuleManager) IsPrometheusRuleEnabled() bool {
+	return c.cluster.IsDefaultAlertsEnabled()
+}
+
+// BuildPrometheusRule builds a new PrometheusRule object
+func (c ClusterPrometheusRuleManager) BuildPrometheusRule() *monitoringv1.PrometheusRule {
+	meta := metav1.ObjectMeta{
+		Namespace: c.cluster.Namespace,
+		Name:      c.cluster.Name,
+	}
+	c.cluster.SetInheritedDataAndOwnership(&meta)
+
+	var thresholds *apiv1.DefaultAlertsThresholds
+	if c.cluster.Spec.Monitoring != nil {
+		thresholds = c.cluster.Spec.Monitoring.DefaultAlertsThresholds
+	}
+
+	// The metrics of the instances are labelled by the PodMonitor with
+	// the namespace and the name of the pod exposing them
+	selector := fmt.Sprintf(`namespace=%q,pod=~"%s-[0-9]+"`, c.cluster.Namespace, c.cluster.Name)
+	retainedWAL := thresholds.GetReplicationSlotRetainedWAL()
+
+	rules := []monitoringv1.Rule{
+		c.buildAlert(
+			"CNPGReplicationLag",
+			fmt.Sprintf("cnpg_pg_replication_lag{%s} > %d", selector, ceilSeconds(thresholds.GetReplicationLag())),
+			"The standby is lagging behind the primary",
+			"Standby {{ $labels.pod }} is lagging behind the primary by {{ $value | humanizeDuration }}",
+		),
+		c.buildAlert(
+			"CNPGLastBackupTooOld",
+			fmt.Sprintf("time() - max by (namespace) (cnpg_collector_last_available_backup_timestamp{%s} > 0) > %d",
+				selector, ceilSeconds(thresholds.GetBackupAge())),
+			"The last available backup is too old",
+			"The last available backup of the cluster has been taken {{ $value | humanizeDuration }} ago",
+		),
+		c.buildAlert(
+			"CNPGReplicationSlotRetainedWAL",
+			fmt.Sprintf("cnpg_collector_replication_slot_wal_retained_bytes{%s} > %d",
+				selector, retainedWAL.Value()),
+			"A replication slot is retaining too much WAL",
+			"The replication slot {{ $labels.slot_name }} on {{ $labels.pod }} is retaining "+
+				"{{ $value | humanize1024 }}B of WAL",
+		),
+		c.buildAlert(
+			"CNPGTransactionIDAge",
+			fmt.Sprintf("cnpg_collector_max_xid_age{%s} > %d", selector, thresholds.GetTransactionIDAge()),
+			"The age of the oldest unfrozen transaction ID is too high",
+			"The oldest unfrozen transaction ID on {{ $labels.pod }} is {{ $value }} transactions old, "+
+				"check the progress of the autovacuum",
+		),
+		c.buildAlert(
+			"CNPGCertificateExpiring",
+			fmt.Sprintf("min by (namespace, secret) "+
+				"(cnpg_collector_certificate_expiration_timestamp_seconds{%s}) - time() < %d",
+				selector, ceilSeconds(thresholds.GetCertificateExpiration())),
+			"A certificate of the cluster is about to expire",
+			"The certificate in the secret {{ $labels.secret }} expires in {{ $value | humanizeDuration }}",
+		),
+	}
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: meta,
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  fmt.Sprintf("cnpg-default-alerts-%s.rules", c.cluster.Name),
+					Rules: rules,
+				},
+			},
+		},
+	}
+}
+
+// buildAlert builds an alerting rule about the cluster
+func (c ClusterPrometheusRuleManager) buildAlert(name, expr, summary, description string) monitoringv1.Rule {
+	return monitoringv1.Rule{
+		Alert: name,
+		Expr:  intstr.FromString(expr),
+		For:   ptr.To(monitoringv1.Duration("1m")),
+		Labels: map[string]string{
+			"severity":             "warning",
+			defaultAlertsLabelName: c.cluster.Name,
+		},
+		Annotations: map[string]string{
+			"summary":     summary,
+			"description": description,
+		},
+	}
+}
+
+// ceilSeconds returns the passed duration as a number of seconds, rounded up
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// NewClusterPrometheusRuleManager returns a new instance of ClusterPrometheusRuleManager
+func NewClusterPrometheusRuleManager(cluster *apiv1.Cluster) *ClusterPrometheusRuleManager {
+	return &ClusterPrometheusRuleManager{cluster: cluster}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusRule test", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-namespace",
+			},
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					EnableDefaultAlerts: true,
+				},
+			},
+		}
+	})
+
+	getExpressions := func(rule *monitoringv1.PrometheusRule) map[string]string {
+		Expect(rule.Spec.Groups).To(HaveLen(1))
+		result := make(map[string]string, len(rule.Spec.Groups[0].Rules))
+		for _, alert := range rule.Spec.Groups[0].Rules {
+			Expect(alert.Labels).To(HaveKeyWithValue("cnpg_cluster", "test"))
+			result[alert.Alert] = alert.Expr.String()
+		}
+		return result
+	}
+
+	It("is enabled only when the default alerts are requested", func() {
+		Expect(NewClusterPrometheusRuleManager(cluster).IsPrometheusRuleEnabled()).To(BeTrue())
+		cluster.Spec.Monitoring.EnableDefaultAlerts = false
+		Expect(NewClusterPrometheusRuleManager(cluster).IsPrometheusRuleEnabled()).To(BeFalse())
+	})
+
+	It("builds the default alerts with the default thresholds", func() {
+		rule := NewClusterPrometheusRuleManager(cluster).BuildPrometheusRule()
+		Expect(rule.Name).To(Equal("test"))
+		Expect(rule.Namespace).To(Equal("test-namespace"))
+		Expect(rule.Labels).To(HaveKeyWithValue(utils.ClusterLabelName, "test"))
+
+		const selector = `{namespace="test-namespace",pod=~"test-[0-9]+"}`
+		Expect(getExpressions(rule)).To(Equal(map[string]string{
+			"CNPGReplicationLag": "cnpg_pg_replication_lag" + selector + " > 300",
+			"CNPGLastBackupTooOld": "time() - max by (namespace) " +
+				"(cnpg_collector_last_available_backup_timestamp" + selector + " > 0) > 90000",
+			"CNPGReplicationSlotRetainedWAL": "cnpg_collector_replication_slot_wal_retained_bytes" + selector +
+				" > 10737418240",
+			"CNPGTransactionIDAge": "cnpg_collector_max_xid_age" + selector + " > 300000000",
+			"CNPGCertificateExpiring": "min by (namespace, secret) " +
+				"(cnpg_collector_certificate_expiration_timestamp_seconds" + selector + ") - time() < 604800",
+		}))
+	})
+
+	It("uses the thresholds configured in the cluster", func() {
+		cluster.Spec.Monitoring.DefaultAlertsThresholds = &apiv1.DefaultAlertsThresholds{
+			ReplicationLag:             &metav1.Duration{Duration: 30 * time.Second},
+			BackupAge:                  &metav1.Duration{Duration: 8 * 24 * time.Hour},
+			ReplicationSlotRetainedWAL: ptr.To(resource.MustParse("1Gi")),
+			TransactionIDAge:           ptr.To(int64(1000)),
+			CertificateExpiration:      &metav1.Duration{Duration: time.Hour},
+		}
+
+		expressions := getExpressions(NewClusterPrometheusRuleManager(cluster).BuildPrometheusRule())
+		Expect(expressions["CNPGReplicationLag"]).To(HaveSuffix(" > 30"))
+		Expect(expressions["CNPGLastBackupTooOld"]).To(HaveSuffix(" > 691200"))
+		Expect(expressions["CNPGReplicationSlotRetainedWAL"]).To(HaveSuffix(" > 1073741824"))
+		Expect(expressions["CNPGTransactionIDAge"]).To(HaveSuffix(" > 1000"))
+		Expect(expressions["CNPGCertificateExpiring"]).To(HaveSuffix(" < 3600"))
+	})
+})
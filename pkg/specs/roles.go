@@ -29,8 +29,10 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
-// CreateRole create a role with the permissions needed by the instance manager
-func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) rbacv1.Role {
+// CreateRole create a role with the permissions needed by the instance manager.
+// The databases are used to detect the secrets referenced by the user mappings
+// which the instance manager needs to read.
+func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup, databases []apiv1.Database) rbacv1.Role {
 	rules := []rbacv1.PolicyRule{
 		{
 			APIGroups: []string{
@@ -56,7 +58,7 @@ func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) rbacv1.Role {
 				"get",
 				"watch",
 			},
-			ResourceNames: getInvolvedSecretNames(cluster, backupOrigin),
+			ResourceNames: getInvolvedSecretNames(cluster, backupOrigin, databases),
 		},
 		{
 			APIGroups: []string{
@@ -257,7 +259,11 @@ func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) rbacv1.Role {
 	}
 }
 
-func getInvolvedSecretNames(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) []string {
+func getInvolvedSecretNames(
+	cluster apiv1.Cluster,
+	backupOrigin *apiv1.Backup,
+	databases []apiv1.Database,
+) []string {
 	involvedSecretNames := []string{
 		cluster.GetReplicationSecretName(),
 		cluster.GetClientCASecretName(),
@@ -277,6 +283,8 @@ func getInvolvedSecretNames(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) [
 	involvedSecretNames = append(involvedSecretNames, backupSecrets(cluster, backupOrigin)...)
 	involvedSecretNames = append(involvedSecretNames, externalClusterSecrets(cluster)...)
 	involvedSecretNames = append(involvedSecretNames, managedRolesSecrets(cluster)...)
+	involvedSecretNames = append(involvedSecretNames, databasesSecrets(cluster, databases)...)
+	involvedSecretNames = append(involvedSecretNames, cluster.GetAuthenticationRulesSecrets().ToList()...)
 
 	return cleanupResourceList(involvedSecretNames)
 }
@@ -294,6 +302,8 @@ func getInvolvedConfigMapNames(cluster apiv1.Cluster) []string {
 		}
 	}
 
+	involvedConfigMapNames = append(involvedConfigMapNames, cluster.GetAuthenticationRulesConfigMaps().ToList()...)
+
 	return cleanupResourceList(involvedConfigMapNames)
 }
 
@@ -470,3 +480,18 @@ func managedRolesSecrets(cluster apiv1.Cluster) []string {
 
 	return secretNames
 }
+
+// databasesSecrets returns the secrets referenced by the databases
+// targeting the passed cluster
+func databasesSecrets(cluster apiv1.Cluster, databases []apiv1.Database) []string {
+	var secretNames []string
+	for i := range databases {
+		if databases[i].Namespace != cluster.Namespace ||
+			databases[i].Spec.ClusterRef.Name != cluster.Name {
+			continue
+		}
+		secretNames = append(secretNames, databases[i].GetUserMappingsSecretNames()...)
+	}
+
+	return secretNames
+}
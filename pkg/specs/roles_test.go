@@ -165,14 +165,14 @@ var _ = Describe("Roles", func() {
 	}
 
 	It("are created with the cluster name for pure k8s", func() {
-		serviceAccount := CreateRole(cluster, nil)
+		serviceAccount := CreateRole(cluster, nil, nil)
 		Expect(serviceAccount.Name).To(Equal(cluster.Name))
 		Expect(serviceAccount.Namespace).To(Equal(cluster.Namespace))
 		Expect(serviceAccount.Rules).To(HaveLen(15))
 	})
 
 	It("should contain every secret of the origin backup and backup configuration of every external cluster", func() {
-		serviceAccount := CreateRole(cluster, &backupOrigin)
+		serviceAccount := CreateRole(cluster, &backupOrigin, nil)
 		Expect(serviceAccount.Name).To(Equal(cluster.Name))
 		Expect(serviceAccount.Namespace).To(Equal(cluster.Namespace))
 		Expect(serviceAccount.Rules[0].ResourceNames).To(ConsistOf("thisTest", "testConfigMapKeySelector"))
@@ -281,7 +281,7 @@ var _ = Describe("Secrets", func() {
 	})
 
 	It("should contain default secrets only", func() {
-		Expect(getInvolvedSecretNames(cluster, nil)).To(Equal([]string{
+		Expect(getInvolvedSecretNames(cluster, nil, nil)).To(Equal([]string{
 			"thisTest-app",
 			"thisTest-ca",
 			"thisTest-replication",
@@ -291,7 +291,7 @@ var _ = Describe("Secrets", func() {
 	})
 
 	It("should created an ordered string list with the backup secrets", func() {
-		Expect(getInvolvedSecretNames(cluster, &backup)).To(Equal([]string{
+		Expect(getInvolvedSecretNames(cluster, &backup, nil)).To(Equal([]string{
 			"aws-status-secret-test",
 			"azure-storage-key-secret-test",
 			"google-application-secret-test",
@@ -346,7 +346,7 @@ var _ = Describe("Managed Roles", func() {
 	It("gets the list of secrets needed by the managed roles", func() {
 		Expect(managedRolesSecrets(cluster)).
 			To(ConsistOf("my_secret1", "my_secret3"))
-		serviceAccount := CreateRole(cluster, nil)
+		serviceAccount := CreateRole(cluster, nil, nil)
 		Expect(serviceAccount.Name).To(Equal(cluster.Name))
 		Expect(serviceAccount.Namespace).To(Equal(cluster.Namespace))
 		var secretsPolicy rbacv1.PolicyRule
@@ -358,3 +358,96 @@ var _ = Describe("Managed Roles", func() {
 		Expect(secretsPolicy.ResourceNames).To(ContainElements("my_secret1", "my_secret3"))
 	})
 })
+
+var _ = Describe("Databases secrets", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "thisTest",
+			Namespace: "default",
+		},
+	}
+
+	newDatabase := func(namespace, clusterName, secretName string) apiv1.Database {
+		return apiv1.Database{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: namespace,
+			},
+			Spec: apiv1.DatabaseSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: clusterName},
+				Servers: []apiv1.ServerSpec{
+					{
+						DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "remote", Ensure: apiv1.EnsurePresent},
+						UserMappings: []apiv1.UserMappingSpec{
+							{
+								User:              "app",
+								Ensure:            apiv1.EnsurePresent,
+								CredentialsSecret: &apiv1.LocalObjectReference{Name: secretName},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("gets the secrets of the user mappings of the databases targeting the cluster", func() {
+		databases := []apiv1.Database{
+			newDatabase("default", "thisTest", "my_secret1"),
+			newDatabase("default", "anotherCluster", "my_secret2"),
+			newDatabase("another", "thisTest", "my_secret3"),
+		}
+		Expect(databasesSecrets(cluster, databases)).To(ConsistOf("my_secret1"))
+		Expect(getInvolvedSecretNames(cluster, nil, databases)).To(ContainElement("my_secret1"))
+	})
+})
+
+var _ = Describe("Authentication rules references", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "thisTest",
+			Namespace: "default",
+		},
+		Spec: apiv1.ClusterSpec{
+			PostgresConfiguration: apiv1.PostgresConfiguration{
+				PgHBARefs: &apiv1.AuthenticationRulesRefs{
+					SecretRefs: []apiv1.SecretKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "hba-secret"}, Key: "rules"},
+					},
+					ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "hba-configmap"}, Key: "rules"},
+					},
+				},
+				PgIdentRefs: &apiv1.AuthenticationRulesRefs{
+					SecretRefs: []apiv1.SecretKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "ident-secret"}, Key: "rules"},
+					},
+					ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "ident-configmap"}, Key: "rules"},
+					},
+				},
+			},
+		},
+	}
+
+	It("grants access to the referenced Secrets and ConfigMaps", func() {
+		Expect(getInvolvedSecretNames(cluster, nil, nil)).To(ContainElements("hba-secret", "ident-secret"))
+		Expect(getInvolvedConfigMapNames(cluster)).To(ConsistOf("thisTest", "hba-configmap", "ident-configmap"))
+
+		role := CreateRole(cluster, nil, nil)
+		var secretsPolicy, configMapsPolicy rbacv1.PolicyRule
+		for _, policy := range role.Rules {
+			if len(policy.Resources) == 0 {
+				continue
+			}
+			switch policy.Resources[0] {
+			case "secrets":
+				secretsPolicy = policy
+			case "configmaps":
+				configMapsPolicy = policy
+			}
+		}
+		Expect(secretsPolicy.ResourceNames).To(ContainElements("hba-secret", "ident-secret"))
+		Expect(configMapsPolicy.ResourceNames).To(ContainElements("hba-configmap", "ident-configmap"))
+	})
+})
@@ -21,17 +21,32 @@ package specs
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-// CreateSecret creates a secret with the PostgreSQL and the owner passwords
+// SecretConnectionInfoKeys are the keys of the connection secrets which
+// depend on the endpoint of the cluster, and are refreshed when it changes
+var SecretConnectionInfoKeys = []string{"host", "port", "pgpass", "uri", "jdbc-uri", "fqdn-uri", "fqdn-jdbc-uri"}
+
+// pgPassFieldEscaper escapes the fields of a pgpass line, which can contain
+// colons, like the IPv6 addresses, and backslashes.
+// Ref: https://www.postgresql.org/docs/current/libpq-pgpass.html
+var pgPassFieldEscaper = strings.NewReplacer("\\", "\\\\", ":", "\\:")
+
+// CreateSecret creates a secret with the PostgreSQL and the owner passwords.
+// When an external connection is passed, its host and port replace the ones
+// of the service in the connection information, except for the FQDN URIs
+// which always point to the service
 func CreateSecret(
 	name string,
 	namespace string,
@@ -40,8 +55,16 @@ func CreateSecret(
 	username string,
 	password string,
 	usertype utils.UserType,
+	externalConnection *apiv1.ExternalConnectionConfiguration,
 ) *corev1.Secret {
+	host := hostname
+	port := int32(postgres.ServerPort)
 	hostWithNamespace := fmt.Sprintf("%s.%s:%d", hostname, namespace, postgres.ServerPort)
+	if externalConnection != nil {
+		host = externalConnection.Host
+		port = externalConnection.GetPort()
+		hostWithNamespace = net.JoinHostPort(host, fmt.Sprintf("%d", port))
+	}
 	hostWithFQDN := fmt.Sprintf(
 		"%s.%s.svc.%s:%d",
 		hostname,
@@ -79,15 +102,15 @@ func CreateSecret(
 			"user":     username,
 			"password": password,
 			"dbname":   dbname,
-			"host":     hostname,
-			"port":     fmt.Sprintf("%d", postgres.ServerPort),
+			"host":     host,
+			"port":     fmt.Sprintf("%d", port),
 			"pgpass": fmt.Sprintf(
 				"%v:%v:%v:%v:%v\n",
-				hostname,
-				postgres.ServerPort,
-				dbname,
-				username,
-				password),
+				pgPassFieldEscaper.Replace(host),
+				port,
+				pgPassFieldEscaper.Replace(dbname),
+				pgPassFieldEscaper.Replace(username),
+				pgPassFieldEscaper.Replace(password)),
 			"uri":           namespacedBuilder.buildPostgres(),
 			"jdbc-uri":      namespacedBuilder.buildJdbc(),
 			"fqdn-uri":      fqdnBuilder.buildPostgres(),
@@ -21,6 +21,7 @@ package specs
 
 import (
 	"fmt"
+	"maps"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -116,6 +117,37 @@ func CreateClusterReadWriteService(cluster apiv1.Cluster) *corev1.Service {
 	}
 }
 
+// ApplyDefaultServiceTemplate applies to a default service the customization
+// specified in the cluster for its selector type, if any. The selector and
+// the ports of the service are left untouched.
+func ApplyDefaultServiceTemplate(
+	cluster apiv1.Cluster,
+	selectorType apiv1.ServiceSelectorType,
+	service *corev1.Service,
+) {
+	template := cluster.GetDefaultServiceTemplate(selectorType)
+	if template == nil {
+		return
+	}
+
+	if template.Type != "" {
+		service.Spec.Type = template.Type
+	}
+
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	maps.Copy(service.Labels, template.ObjectMeta.Labels)
+
+	if service.Annotations == nil {
+		service.Annotations = make(map[string]string)
+	}
+	maps.Copy(service.Annotations, template.ObjectMeta.Annotations)
+	if template.UpdateStrategy != "" {
+		service.Annotations[utils.UpdateStrategyAnnotation] = string(template.UpdateStrategy)
+	}
+}
+
 // BuildManagedServices creates a list of Kubernetes Services based on the
 // additional managed services specified in the Cluster's ManagedServices configuration.
 // Returns:
@@ -182,3 +182,61 @@ var _ = Describe("BuildManagedServices", func() {
 		})
 	})
 })
+
+var _ = Describe("ApplyDefaultServiceTemplate", func() {
+	var cluster apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clustername",
+			},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Services: &apiv1.ManagedServices{
+						DefaultServices: []apiv1.DefaultServiceTemplate{
+							{
+								SelectorType:   apiv1.ServiceSelectorTypeRW,
+								UpdateStrategy: apiv1.ServiceUpdateStrategyPatch,
+								Type:           corev1.ServiceTypeLoadBalancer,
+								ObjectMeta: apiv1.Metadata{
+									Labels: map[string]string{
+										"test-label": "test-value",
+									},
+									Annotations: map[string]string{
+										"test-annotation": "test-value",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("customizes the matching default service", func() {
+		service := CreateClusterReadWriteService(cluster)
+		ApplyDefaultServiceTemplate(cluster, apiv1.ServiceSelectorTypeRW, service)
+		Expect(service.Name).To(Equal("clustername-rw"))
+		Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(service.Labels).To(HaveKeyWithValue("test-label", "test-value"))
+		Expect(service.Annotations).To(HaveKeyWithValue("test-annotation", "test-value"))
+		Expect(service.Annotations).To(HaveKeyWithValue(utils.UpdateStrategyAnnotation, "patch"))
+		Expect(service.Spec.Selector).To(Equal(CreateClusterReadWriteService(cluster).Spec.Selector))
+		Expect(service.Spec.Ports).To(Equal(buildInstanceServicePorts()))
+	})
+
+	It("leaves the other default services untouched", func() {
+		service := CreateClusterReadOnlyService(cluster)
+		ApplyDefaultServiceTemplate(cluster, apiv1.ServiceSelectorTypeRO, service)
+		Expect(service).To(Equal(CreateClusterReadOnlyService(cluster)))
+	})
+
+	It("keeps the ClusterIP type when no type is specified", func() {
+		cluster.Spec.Managed.Services.DefaultServices[0].Type = ""
+		service := CreateClusterReadWriteService(cluster)
+		ApplyDefaultServiceTemplate(cluster, apiv1.ServiceSelectorTypeRW, service)
+		Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+	})
+})
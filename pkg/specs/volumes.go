@@ -134,12 +134,8 @@ func createPostgresVolumes(cluster *apiv1.Cluster, podName string) []corev1.Volu
 		for i := range tbsNames {
 			result = append(result,
 				corev1.Volume{
-					Name: VolumeMountNameForTablespace(tbsNames[i]),
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: PvcNameForTablespace(podName, tbsNames[i]),
-						},
-					},
+					Name:         VolumeMountNameForTablespace(tbsNames[i]),
+					VolumeSource: createTablespaceVolumeSource(cluster, podName, tbsNames[i]),
 				},
 			)
 		}
@@ -297,6 +293,35 @@ func getSortedTablespaceList(cluster *apiv1.Cluster) []string {
 	return tbsNames
 }
 
+// createTablespaceVolumeSource creates the source of the volume hosting a
+// tablespace, being an ephemeral volume or the PVC of the instance
+func createTablespaceVolumeSource(cluster *apiv1.Cluster, podName, tablespaceName string) corev1.VolumeSource {
+	tbsConfig := cluster.GetTablespaceConfiguration(tablespaceName)
+	if tbsConfig == nil || !tbsConfig.IsEphemeral() {
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: PvcNameForTablespace(podName, tablespaceName),
+			},
+		}
+	}
+
+	if tbsConfig.Ephemeral.VolumeClaimTemplate != nil {
+		return corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: tbsConfig.Ephemeral.VolumeClaimTemplate.DeepCopy(),
+			},
+		}
+	}
+
+	emptyDir := &corev1.EmptyDirVolumeSource{}
+	if tbsConfig.Ephemeral.EmptyDir != nil {
+		emptyDir = tbsConfig.Ephemeral.EmptyDir.DeepCopy()
+	}
+	return corev1.VolumeSource{
+		EmptyDir: emptyDir,
+	}
+}
+
 func createEphemeralVolume(cluster *apiv1.Cluster) corev1.Volume {
 	scratchVolumeSource := corev1.VolumeSource{}
 	if cluster.Spec.EphemeralVolumeSource != nil {
@@ -478,6 +478,50 @@ var _ = DescribeTable("test creation of volumes",
 				},
 			},
 		}),
+	Entry("should create an ephemeral volume for ephemeral tablespaces",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name:      "fragglerock",
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{},
+					},
+					{
+						Name:      "futurama",
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceStorage{
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									StorageClassName: ptr.To("local"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "fragglerock",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+			{
+				Name: "futurama",
+				VolumeSource: corev1.VolumeSource{
+					Ephemeral: &corev1.EphemeralVolumeSource{
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: ptr.To("local"),
+							},
+						},
+					},
+				},
+			},
+		}),
 )
 
 var _ = Describe("createEphemeralVolume", func() {
@@ -22,7 +22,18 @@ SPDX-License-Identifier: Apache-2.0
 
 package compatibility
 
+import "syscall"
+
 // SetCoredumpFilter for darwin compatibility
 func SetCoredumpFilter(_ string) error {
 	return nil
 }
+
+// GetAvailableDiskSpace for darwin compatibility
+func GetAvailableDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -25,6 +25,7 @@ package compatibility
 
 import (
 	"os"
+	"syscall"
 )
 
 // SetCoredumpFilter set the value of /proc/self/coredump_filter
@@ -32,3 +33,13 @@ func SetCoredumpFilter(coredumpFilter string) error {
 	coredumpFilterFile := "/proc/self/coredump_filter"
 	return os.WriteFile(coredumpFilterFile, []byte(coredumpFilter), 0o600)
 }
+
+// GetAvailableDiskSpace returns the space, in bytes, available to
+// unprivileged users in the filesystem holding the passed path
+func GetAvailableDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil // #nosec G115
+}
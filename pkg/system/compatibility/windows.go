@@ -23,7 +23,14 @@ SPDX-License-Identifier: Apache-2.0
 // Package compatibility provides a layer to cross-compile with other OS than Linux
 package compatibility
 
+import "errors"
+
 // SetCoredumpFilter for Windows compatibility
 func SetCoredumpFilter(_ string) error {
 	return nil
 }
+
+// GetAvailableDiskSpace for Windows compatibility
+func GetAvailableDiskSpace(_ string) (uint64, error) {
+	return 0, errors.New("retrieving the available disk space is not supported on Windows")
+}
@@ -185,6 +185,16 @@ func PodMonitorExist(client discovery.DiscoveryInterface) (bool, error) {
 	return exist, nil
 }
 
+// PrometheusRuleExist tries to find the PrometheusRule resource in the current cluster
+func PrometheusRuleExist(client discovery.DiscoveryInterface) (bool, error) {
+	exist, err := resourceExist(client, "monitoring.coreos.com/v1", "prometheusrules")
+	if err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
 // extractK8sMinorVersion extracts and parses the Kubernetes minor version from
 // the version info that's been  detected by discovery client
 func extractK8sMinorVersion(info *version.Info) (int, error) {
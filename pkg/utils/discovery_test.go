@@ -77,6 +77,26 @@ var _ = Describe("Detect resources properly when", func() {
 		Expect(exists).To(BeTrue())
 	})
 
+	It("should detect PrometheusRule resource", func() {
+		exists, err := PrometheusRuleExist(client.Discovery())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		fakeDiscovery.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "monitoring.coreos.com/v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name: "prometheusrules",
+					},
+				},
+			},
+		}
+		exists, err = PrometheusRuleExist(client.Discovery())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
 	It("should not detect SecurityContextConstraints", func() {
 		err := DetectSecurityContextConstraints(client.Discovery())
 		Expect(err).ToNot(HaveOccurred())
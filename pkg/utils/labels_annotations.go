@@ -164,6 +164,21 @@ const (
 	// SkipWalArchiving is the name of the annotation which turns off WAL archiving
 	SkipWalArchiving = MetadataNamespace + "/skipWalArchiving"
 
+	// SkipParametersValidation is the name of the annotation which turns off
+	// the validation of the PostgreSQL configuration parameter names
+	SkipParametersValidation = MetadataNamespace + "/skipParametersValidation"
+
+	// ApprovePrimaryUpdateAnnotationName is the name of the annotation which
+	// allows the operator to update the primary instance when the primary
+	// update strategy is supervised. It is removed once the update has started.
+	ApprovePrimaryUpdateAnnotationName = MetadataNamespace + "/approvePrimaryUpdate"
+
+	// MajorUpgradeDryRunAnnotationName is the name of the annotation which
+	// makes the operator only check the compatibility of the data directory
+	// with the requested major version, running "pg_upgrade --check",
+	// instead of upgrading it
+	MajorUpgradeDryRunAnnotationName = MetadataNamespace + "/majorUpgradeDryRun"
+
 	// skipEmptyWalArchiveCheck is the name of the annotation which turns off the checks that ensure that the WAL
 	// archive is empty before writing data
 	skipEmptyWalArchiveCheck = MetadataNamespace + "/skipEmptyWalArchiveCheck"
@@ -277,6 +292,11 @@ const (
 	// operator if a instance is recoverable or not. Not recoverable instances will
 	// be deleted with the contents of their PVCs.
 	UnrecoverableInstanceAnnotationName = AlphaMetadataNamespace + "/unrecoverable"
+
+	// PromoteInstanceAnnotationName is the name of the annotation requesting the
+	// operator to switch the primary over to the annotated instance. The operator
+	// removes the annotation once the instance has been promoted.
+	PromoteInstanceAnnotationName = MetadataNamespace + "/promote"
 )
 
 type annotationStatus string
@@ -494,6 +514,24 @@ func IsWalArchivingDisabled(object *metav1.ObjectMeta) bool {
 	return object.Annotations[SkipWalArchiving] == string(annotationStatusEnabled)
 }
 
+// IsParametersValidationSkipped returns a boolean indicating if the operator
+// should accept PostgreSQL configuration parameters it doesn't know about
+func IsParametersValidationSkipped(object *metav1.ObjectMeta) bool {
+	return object.Annotations[SkipParametersValidation] == string(annotationStatusEnabled)
+}
+
+// IsPrimaryUpdateApproved returns a boolean indicating if the user approved
+// the update of the primary instance in a supervised rolling update
+func IsPrimaryUpdateApproved(object *metav1.ObjectMeta) bool {
+	return object.Annotations[ApprovePrimaryUpdateAnnotationName] == string(annotationStatusEnabled)
+}
+
+// IsMajorUpgradeDryRun returns a boolean indicating if the user requested
+// to only check the feasibility of the major version upgrade
+func IsMajorUpgradeDryRun(object *metav1.ObjectMeta) bool {
+	return object.Annotations[MajorUpgradeDryRunAnnotationName] == string(annotationStatusEnabled)
+}
+
 // GetInstanceRole tries to fetch the ClusterRoleLabelName andClusterInstanceRoleLabelName value from a given labels map
 func GetInstanceRole(labels map[string]string) (string, bool) {
 	if value := labels[ClusterRoleLabelName]; value != "" {
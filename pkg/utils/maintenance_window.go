@@ -0,0 +1,47 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// GetMaintenanceWindowStatus checks whether the passed time is inside one of
+// the maintenance windows starting with the passed schedule and lasting for
+// the passed duration, returning the start of the current window, or of the
+// next one otherwise
+func GetMaintenanceWindowStatus(schedule string, duration time.Duration, now time.Time) (bool, time.Time, error) {
+	parsedSchedule, err := cron.Parse(schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid maintenance window schedule %q: %w", schedule, err)
+	}
+
+	// The first window starting after the beginning of the window that would
+	// include the current time is either the current one or the next one
+	windowStart := parsedSchedule.Next(now.Add(-duration))
+	if !windowStart.After(now) {
+		return true, windowStart, nil
+	}
+
+	return false, windowStart, nil
+}
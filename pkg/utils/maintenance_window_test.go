@@ -0,0 +1,50 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetMaintenanceWindowStatus", func() {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.March, 10, hour, minute, 0, 0, time.Local)
+	}
+
+	It("detects when the time is inside a window", func() {
+		inWindow, windowStart, err := GetMaintenanceWindowStatus("0 0 2 * * *", time.Hour, at(2, 30))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeTrue())
+		Expect(windowStart).To(Equal(at(2, 0)))
+
+		inWindow, windowStart, err = GetMaintenanceWindowStatus("0 0 2 * * *", time.Hour, at(3, 30))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeFalse())
+		Expect(windowStart).To(Equal(at(2, 0).AddDate(0, 0, 1)))
+	})
+
+	It("rejects an invalid schedule", func() {
+		_, _, err := GetMaintenanceWindowStatus("every night", time.Hour, at(2, 30))
+		Expect(err).To(MatchError(ContainSubstring("invalid maintenance window schedule")))
+	})
+})
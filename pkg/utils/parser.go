@@ -39,6 +39,10 @@ const (
 	// latest checkpoint's TimeLineID pg_controldata entry
 	pgControlDataKeyLatestCheckpointTimelineID pgControlDataKey = "Latest checkpoint's TimeLineID"
 
+	// pgControlDataKeyLatestCheckpointLocation is the
+	// latest checkpoint location pg_controldata entry
+	pgControlDataKeyLatestCheckpointLocation pgControlDataKey = "Latest checkpoint location"
+
 	// pgControlDataKeyREDOWALFile is the latest checkpoint's
 	// REDO WAL file pg_controldata entry
 	pgControlDataKeyREDOWALFile pgControlDataKey = "Latest checkpoint's REDO WAL file"
@@ -64,6 +68,9 @@ const (
 
 	// pgControlDataBytesPerWALSegment reports the size of the WAL segments
 	pgControlDataBytesPerWALSegment pgControlDataKey = "Bytes per WAL segment"
+
+	// pgControlDataCatalogVersionNumber is the catalog version of the data directory
+	pgControlDataCatalogVersionNumber pgControlDataKey = "Catalog version number"
 )
 
 // PgControlData represents the parsed output of pg_controldata
@@ -80,6 +87,11 @@ func (p PgControlData) TryGetLatestCheckpointTimelineID() (string, bool) {
 	return v, ok
 }
 
+// GetLatestCheckpointLocation returns the latest checkpoint location
+func (p PgControlData) GetLatestCheckpointLocation() string {
+	return p[pgControlDataKeyLatestCheckpointLocation]
+}
+
 // GetREDOWALFile returns the latest checkpoint's REDO WAL file
 func (p PgControlData) GetREDOWALFile() string {
 	return p[pgControlDataKeyREDOWALFile]
@@ -137,6 +149,15 @@ func (p PgControlData) GetBytesPerWALSegment() (int, error) {
 	return walSegmentSize, nil
 }
 
+// GetCatalogVersionNumber returns the catalog version of the data directory
+func (p PgControlData) GetCatalogVersionNumber() (string, error) {
+	value, ok := p[pgControlDataCatalogVersionNumber]
+	if !ok {
+		return "", fmt.Errorf("no '%s' section in pg_controldata output", pgControlDataCatalogVersionNumber)
+	}
+	return value, nil
+}
+
 // PgDataState represents the "Database cluster state" field of pg_controldata
 type PgDataState string
 
@@ -86,6 +86,13 @@ var _ = Describe("pg_controldata output parser", func() {
 		Expect(output["Catalog version number"]).To(Equal("202201241"))
 		Expect(output["Database disk usage"]).To(Equal("10240 KB"))
 		Expect(output).To(HaveLen(fakeControlDataEntries))
+		Expect(output.GetLatestCheckpointLocation()).To(Equal("0/3000FF0"))
+		Expect(output.GetCatalogVersionNumber()).To(Equal("202201241"))
+	})
+
+	It("fails to get the catalog version when it is missing", func() {
+		_, err := ParsePgControldataOutput("").GetCatalogVersionNumber()
+		Expect(err).To(HaveOccurred())
 	})
 
 	It("silently skips wrong lines", func() {
@@ -0,0 +1,115 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterScopedPermission is the permission to read a cluster-scoped
+// resource, needed by an optional feature of the operator
+type ClusterScopedPermission struct {
+	// The API group of the resource
+	Group string
+
+	// The name of the resource
+	Resource string
+
+	// The features which are disabled without the permission
+	Features string
+}
+
+var (
+	// NodesPermission is the permission to read the nodes
+	NodesPermission = ClusterScopedPermission{
+		Resource: "nodes",
+		Features: "pod topology extraction, node drain detection, lost node detection " +
+			"and huge pages availability check",
+	}
+
+	// ClusterImageCatalogsPermission is the permission to read the cluster image catalogs
+	ClusterImageCatalogsPermission = ClusterScopedPermission{
+		Group:    "postgresql.cnpg.io",
+		Resource: "clusterimagecatalogs",
+		Features: "ClusterImageCatalog support",
+	}
+)
+
+// clusterScopedPermissions are the permissions checked by DetectClusterScopedPermissions
+var clusterScopedPermissions = []ClusterScopedPermission{
+	NodesPermission,
+	ClusterImageCatalogsPermission,
+}
+
+// deniedPermissions stores the result of the DetectClusterScopedPermissions check
+var deniedPermissions = make(map[ClusterScopedPermission]bool)
+
+// DetectClusterScopedPermissions checks, through a SelfSubjectAccessReview,
+// whether the operator can get, list and watch the cluster-scoped resources
+// used by its optional features, which are disabled when it can't. This
+// happens when the operator is deployed with namespaced permissions only.
+// It returns the permissions which are missing
+func DetectClusterScopedPermissions(ctx context.Context, cli client.Client) ([]ClusterScopedPermission, error) {
+	var missing []ClusterScopedPermission
+	for _, permission := range clusterScopedPermissions {
+		allowed := true
+		for _, verb := range []string{"get", "list", "watch"} {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Group:    permission.Group,
+						Resource: permission.Resource,
+						Verb:     verb,
+					},
+				},
+			}
+			if err := cli.Create(ctx, review); err != nil {
+				return nil, fmt.Errorf("while checking the permission to %s %s: %w", verb, permission.Resource, err)
+			}
+			if !review.Status.Allowed {
+				allowed = false
+				break
+			}
+		}
+
+		deniedPermissions[permission] = !allowed
+		if !allowed {
+			missing = append(missing, permission)
+		}
+	}
+
+	return missing, nil
+}
+
+// SetClusterScopedPermission sets whether a permission is granted for testing purposes
+// IMPORTANT: use it only in the unit tests
+func SetClusterScopedPermission(permission ClusterScopedPermission, granted bool) {
+	deniedPermissions[permission] = !granted
+}
+
+// HaveClusterScopedPermission returns true unless DetectClusterScopedPermissions
+// found that the operator lacks the passed permission
+func HaveClusterScopedPermission(permission ClusterScopedPermission) bool {
+	return !deniedPermissions[permission]
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster-scoped permissions detection", func() {
+	AfterEach(func() {
+		SetClusterScopedPermission(NodesPermission, true)
+		SetClusterScopedPermission(ClusterImageCatalogsPermission, true)
+	})
+
+	It("reports the permissions which are missing", func(ctx SpecContext) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(
+					_ context.Context,
+					_ client.WithWatch,
+					obj client.Object,
+					_ ...client.CreateOption,
+				) error {
+					review := obj.(*authorizationv1.SelfSubjectAccessReview)
+					attributes := review.Spec.ResourceAttributes
+					review.Status.Allowed = attributes.Resource != "nodes" || attributes.Verb != "watch"
+					return nil
+				},
+			}).
+			Build()
+
+		missing, err := DetectClusterScopedPermissions(ctx, fakeClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missing).To(ConsistOf(NodesPermission))
+		Expect(HaveClusterScopedPermission(NodesPermission)).To(BeFalse())
+		Expect(HaveClusterScopedPermission(ClusterImageCatalogsPermission)).To(BeTrue())
+	})
+
+	It("assumes the permissions are granted before the detection", func() {
+		Expect(HaveClusterScopedPermission(NodesPermission)).To(BeTrue())
+	})
+})
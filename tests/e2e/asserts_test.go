@@ -2469,6 +2469,8 @@ func collectAndAssertDefaultMetricsPresentOnEachPod(
 		defaultMetrics := []string{
 			"cnpg_pg_settings_setting",
 			"cnpg_backends_waiting_total",
+			"cnpg_backends_prepared_xacts",
+			"cnpg_backends_oldest_xact_age_seconds",
 			"cnpg_pg_postmaster_start_time",
 			"cnpg_pg_replication",
 			"cnpg_pg_stat_archiver",
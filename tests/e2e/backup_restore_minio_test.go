@@ -272,6 +272,111 @@ var _ = Describe("MinIO - Backup and restore", Label(tests.LabelBackupRestore),
 			})
 		})
 
+		// We restore a backup of a cluster with three instances into a cluster
+		// with a single one, then we back up the restored cluster and
+		// restore it into a cluster with three instances
+		It("restores a backup into a cluster with a different number of instances", func() {
+			const (
+				topologyTableName         = "to_restore_topology"
+				threeInstancesBackupFile  = fixturesDir + "/backup/minio/backup-minio-three-instances.yaml"
+				singleInstanceRestoreFile = fixturesDir + "/backup/cluster-from-restore-single-instance.yaml.template"
+				threeInstancesRestoreFile = fixturesDir + "/backup/cluster-from-restore-three-instances.yaml.template"
+				singleInstanceBackupFile  = fixturesDir + "/backup/minio/backup-minio-single-instance.yaml"
+			)
+
+			singleInstanceClusterName, err := yaml.GetResourceNameFromYAML(env.Scheme, singleInstanceRestoreFile)
+			Expect(err).ToNot(HaveOccurred())
+			threeInstancesClusterName, err := yaml.GetResourceNameFromYAML(env.Scheme, threeInstancesRestoreFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			assertInstancesCount := func(clusterName string, expected int) {
+				By(fmt.Sprintf("verifying that the cluster %v has %v instances", clusterName, expected), func() {
+					Eventually(func(g Gomega) {
+						podList, err := clusterutils.ListPods(env.Ctx, env.Client, namespace, clusterName)
+						g.Expect(err).ToNot(HaveOccurred())
+						g.Expect(podList.Items).To(HaveLen(expected))
+
+						cluster, err := clusterutils.Get(env.Ctx, env.Client, namespace, clusterName)
+						g.Expect(err).ToNot(HaveOccurred())
+						g.Expect(cluster.Status.ReadyInstances).To(BeEquivalentTo(expected))
+					}, 60).Should(Succeed())
+				})
+			}
+
+			backUpCluster := func(sourceClusterName, backupFile string) {
+				By(fmt.Sprintf("backing up the cluster %v", sourceClusterName), func() {
+					backups.Execute(
+						env.Ctx, env.Client, env.Scheme,
+						namespace, backupFile, false,
+						testTimeouts[timeouts.BackupIsReady],
+					)
+				})
+			}
+
+			assertClusterRestore := func(restoreClusterFile, restoredClusterName string, expectedInstances int) {
+				By(fmt.Sprintf("restoring a backup into %v instances", expectedInstances), func() {
+					CreateResourceFromFile(namespace, restoreClusterFile)
+					AssertClusterIsReady(namespace, restoredClusterName, testTimeouts[timeouts.ClusterIsReadySlow], env)
+
+					tableLocator := TableLocator{
+						Namespace:    namespace,
+						ClusterName:  restoredClusterName,
+						DatabaseName: postgres.AppDBName,
+						TableName:    topologyTableName,
+					}
+					AssertDataExpectedCount(env, tableLocator, 2)
+
+					// The replicas, if any, are bootstrapped from the restored primary
+					AssertClusterStandbysAreStreaming(namespace, restoredClusterName, 140)
+				})
+				assertInstancesCount(restoredClusterName, expectedInstances)
+			}
+
+			By("scaling up the source cluster to three instances", func() {
+				err := clusterutils.ScaleSize(env.Ctx, env.Client, namespace, clusterName, 3)
+				Expect(err).ToNot(HaveOccurred())
+				AssertClusterIsReady(namespace, clusterName, testTimeouts[timeouts.ClusterIsReady], env)
+			})
+			assertInstancesCount(clusterName, 3)
+
+			AssertCreateTestData(env, TableLocator{
+				Namespace:    namespace,
+				ClusterName:  clusterName,
+				DatabaseName: postgres.AppDBName,
+				TableName:    topologyTableName,
+			})
+			AssertArchiveWalOnMinio(namespace, clusterName, clusterName)
+			backUpCluster(clusterName, threeInstancesBackupFile)
+
+			// Restore the backup of the three instances cluster into a single instance
+			assertClusterRestore(singleInstanceRestoreFile, singleInstanceClusterName, 1)
+
+			AssertArchiveWalOnMinio(namespace, singleInstanceClusterName, singleInstanceClusterName)
+			backUpCluster(singleInstanceClusterName, singleInstanceBackupFile)
+			latestTar := minio.GetFilePath(singleInstanceClusterName, "data.tar")
+			Eventually(func() (int, error) {
+				return minio.CountFiles(minioEnv, latestTar)
+			}, 60).Should(BeEquivalentTo(1))
+
+			// Restore the backup of the single instance cluster into three instances
+			assertClusterRestore(threeInstancesRestoreFile, threeInstancesClusterName, 3)
+
+			By("deleting the restored clusters", func() {
+				err = DeleteResourcesFromFile(namespace, threeInstancesRestoreFile)
+				Expect(err).ToNot(HaveOccurred())
+				err = DeleteResourcesFromFile(namespace, singleInstanceRestoreFile)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			// The following tests expect the source cluster to have two instances
+			By("scaling down the source cluster to two instances", func() {
+				err := clusterutils.ScaleSize(env.Ctx, env.Client, namespace, clusterName, 2)
+				Expect(err).ToNot(HaveOccurred())
+				AssertClusterIsReady(namespace, clusterName, testTimeouts[timeouts.ClusterIsReady], env)
+			})
+			assertInstancesCount(clusterName, 2)
+		})
+
 		// We backup and restore a cluster from a standby, and verify some expected data to
 		// be there
 		It("backs up and restore a cluster from standby", func() {
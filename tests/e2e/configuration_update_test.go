@@ -357,12 +357,12 @@ var _ = Describe("Configuration update", Label(tests.LabelClusterMetadata), func
 		})
 
 		It("5. erroring out when a fixedConfigurationParameter is modified", func() {
-			postgresParams["cluster_name"] = "Setting this parameter is not allowed"
+			postgresParams["archive_mode"] = "Setting this parameter is not allowed"
 			checkErrorOutFixedAndBlockedConfigurationParameter(postgresParams, namespace)
 		})
 
 		It("6. erroring out when a blockedConfigurationParameter is modified", func() {
-			delete(postgresParams, "cluster_name")
+			delete(postgresParams, "archive_mode")
 			postgresParams["port"] = "5433"
 			checkErrorOutFixedAndBlockedConfigurationParameter(postgresParams, namespace)
 		})